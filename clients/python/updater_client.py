@@ -0,0 +1,174 @@
+"""Reference client for the update service: check for an update, download the
+artifact it offers and verify its size and checksum before it is installed.
+
+It uses only the standard library and the generated types in
+updater_models.py, and needs Python 3.11 or later. The integration tests run
+it against a live server, so it tracks the documented protocol.
+
+Usage:
+
+    python3 updater_client.py --url https://updates.example.com --app-id my-app \\
+        --current-version 1.0.0 --platform linux --architecture amd64 --out downloads
+
+It prints the outcome as JSON and exits non-zero when the check, download or
+verification fails.
+"""
+
+import argparse
+import hashlib
+import json
+import os
+import sys
+import urllib.error
+import urllib.parse
+import urllib.request
+
+from updater_models import ErrorResponse, UpdateCheckResponse
+
+# Checksum types the client can verify. BLAKE3 is not in the standard library.
+HASHES = {
+    "sha256": hashlib.sha256,
+    "sha512": hashlib.sha512,
+    "sha1": hashlib.sha1,
+    "md5": hashlib.md5,
+}
+
+CHUNK_SIZE = 64 * 1024
+
+
+class UpdateError(Exception):
+    """A check, download or verification failed."""
+
+
+def check_for_update(
+    base_url: str,
+    app_id: str,
+    current_version: str,
+    platform: str,
+    architecture: str,
+    channel: str = "",
+    timeout: float = 30,
+) -> UpdateCheckResponse:
+    """Ask the service whether a newer release is available."""
+    query = {
+        "current_version": current_version,
+        "platform": platform,
+        "architecture": architecture,
+    }
+    if channel:
+        query["channel"] = channel
+    url = "%s/api/v1/updates/%s/check?%s" % (
+        base_url.rstrip("/"),
+        urllib.parse.quote(app_id, safe=""),
+        urllib.parse.urlencode(query),
+    )
+    request = urllib.request.Request(url, headers={"Accept": "application/json"})
+    try:
+        with urllib.request.urlopen(request, timeout=timeout) as response:
+            return json.load(response)
+    except urllib.error.HTTPError as err:
+        raise UpdateError("update check failed: %s" % _error_message(err)) from err
+    except urllib.error.URLError as err:
+        raise UpdateError("update check failed: %s" % err.reason) from err
+
+
+def download(url: str, dest_dir: str, timeout: float = 300) -> str:
+    """Download url into dest_dir and return the file's path."""
+    name = os.path.basename(urllib.parse.urlparse(url).path) or "artifact"
+    os.makedirs(dest_dir, exist_ok=True)
+    path = os.path.join(dest_dir, name)
+    try:
+        with urllib.request.urlopen(url, timeout=timeout) as response, open(path, "wb") as out:
+            while chunk := response.read(CHUNK_SIZE):
+                out.write(chunk)
+    except urllib.error.HTTPError as err:
+        raise UpdateError("download failed: HTTP %d" % err.code) from err
+    except urllib.error.URLError as err:
+        raise UpdateError("download failed: %s" % err.reason) from err
+    return path
+
+
+def verify(path: str, checksum: str, checksum_type: str, file_size: int = 0) -> None:
+    """Check a downloaded file against the size and checksum of the release.
+
+    A file_size of zero is not checked, as releases may be registered without
+    one.
+    """
+    new_hash = HASHES.get(checksum_type.lower())
+    if new_hash is None:
+        raise UpdateError("unsupported checksum type %s" % checksum_type)
+    digest = new_hash()
+    size = 0
+    with open(path, "rb") as f:
+        while chunk := f.read(CHUNK_SIZE):
+            digest.update(chunk)
+            size += len(chunk)
+    if file_size and size != file_size:
+        raise UpdateError("size mismatch: got %d bytes, want %d" % (size, file_size))
+    if digest.hexdigest() != checksum.lower():
+        raise UpdateError("%s mismatch: got %s, want %s" % (checksum_type, digest.hexdigest(), checksum))
+
+
+def update(
+    base_url: str,
+    app_id: str,
+    current_version: str,
+    platform: str,
+    architecture: str,
+    dest_dir: str,
+    channel: str = "",
+) -> dict:
+    """Check for an update and, when one is offered, download and verify it.
+
+    The downloaded file is removed when it fails verification.
+    """
+    check = check_for_update(base_url, app_id, current_version, platform, architecture, channel)
+    if not check["update_available"]:
+        return {"update_available": False, "current_version": check["current_version"]}
+
+    path = download(check["download_url"], dest_dir)
+    try:
+        verify(path, check["checksum"], check["checksum_type"], check.get("file_size", 0))
+    except UpdateError:
+        os.remove(path)
+        raise
+    return {
+        "update_available": True,
+        "version": check["latest_version"],
+        "required": check["required"],
+        "path": path,
+    }
+
+
+def _error_message(err: urllib.error.HTTPError) -> str:
+    try:
+        body: ErrorResponse = json.load(err)
+        return "HTTP %d: %s (%s)" % (err.code, body["message"], body.get("code", ""))
+    except (ValueError, KeyError):
+        return "HTTP %d" % err.code
+
+
+def main() -> int:
+    parser = argparse.ArgumentParser(description=__doc__.split("\n\n")[0])
+    parser.add_argument("--url", required=True, help="base URL of the update service")
+    parser.add_argument("--app-id", required=True)
+    parser.add_argument("--current-version", required=True)
+    parser.add_argument("--platform", required=True)
+    parser.add_argument("--architecture", required=True)
+    parser.add_argument("--channel", default="")
+    parser.add_argument("--out", default=".", help="directory to download the update to")
+    args = parser.parse_args()
+
+    try:
+        result = update(
+            args.url, args.app_id, args.current_version, args.platform, args.architecture, args.out, args.channel
+        )
+    except UpdateError as err:
+        print(json.dumps({"error": str(err)}))
+        return 1
+    print(json.dumps(result))
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
@@ -0,0 +1,187 @@
+/**
+ * Reference client for the update service: check for an update, download the
+ * artifact it offers and verify its size and checksum before it is installed.
+ *
+ * It uses only Node.js built-ins and the generated types in models.ts. The
+ * integration tests run it against a live server, so it tracks the documented
+ * protocol. It is written in the TypeScript subset Node.js runs by stripping
+ * types: Node.js 23.6 or later, or 22.6 or later with
+ * --experimental-strip-types.
+ *
+ * Usage:
+ *
+ *   node client.ts --url https://updates.example.com --app-id my-app \
+ *     --current-version 1.0.0 --platform linux --architecture amd64 --out downloads
+ *
+ * It prints the outcome as JSON and exits non-zero when the check, download or
+ * verification fails.
+ */
+
+import { createHash } from "node:crypto";
+import { mkdir, readFile, rm, writeFile } from "node:fs/promises";
+import { basename, join } from "node:path";
+import { parseArgs } from "node:util";
+import { pathToFileURL } from "node:url";
+import type { ErrorResponse, UpdateCheckResponse } from "./models.js";
+
+/** Checksum types the client can verify. BLAKE3 is not built into Node.js. */
+const HASHES: Record<string, string> = {
+  sha256: "sha256",
+  sha512: "sha512",
+  sha1: "sha1",
+  md5: "md5",
+};
+
+/** A check, download or verification failed. */
+export class UpdateError extends Error {}
+
+export interface CheckOptions {
+  baseURL: string;
+  appID: string;
+  currentVersion: string;
+  platform: string;
+  architecture: string;
+  channel?: string;
+}
+
+export interface UpdateResult {
+  update_available: boolean;
+  current_version?: string;
+  version?: string;
+  required?: boolean;
+  path?: string;
+}
+
+/** Asks the service whether a newer release is available. */
+export async function checkForUpdate(opts: CheckOptions): Promise<UpdateCheckResponse> {
+  const query = new URLSearchParams({
+    current_version: opts.currentVersion,
+    platform: opts.platform,
+    architecture: opts.architecture,
+  });
+  if (opts.channel) {
+    query.set("channel", opts.channel);
+  }
+  const url = `${opts.baseURL.replace(/\/+$/, "")}/api/v1/updates/${encodeURIComponent(opts.appID)}/check?${query}`;
+  let response: Response;
+  try {
+    response = await fetch(url, { headers: { Accept: "application/json" } });
+  } catch (err) {
+    throw new UpdateError(`update check failed: ${(err as Error).message}`);
+  }
+  if (!response.ok) {
+    throw new UpdateError(`update check failed: ${await errorMessage(response)}`);
+  }
+  return (await response.json()) as UpdateCheckResponse;
+}
+
+/** Downloads url into destDir and returns the file's path. */
+export async function download(url: string, destDir: string): Promise<string> {
+  const path = join(destDir, basename(new URL(url).pathname) || "artifact");
+  let response: Response;
+  try {
+    response = await fetch(url);
+  } catch (err) {
+    throw new UpdateError(`download failed: ${(err as Error).message}`);
+  }
+  if (!response.ok) {
+    throw new UpdateError(`download failed: HTTP ${response.status}`);
+  }
+  await mkdir(destDir, { recursive: true });
+  await writeFile(path, new Uint8Array(await response.arrayBuffer()));
+  return path;
+}
+
+/**
+ * Checks a downloaded file against the size and checksum of the release. A
+ * fileSize of zero is not checked, as releases may be registered without one.
+ */
+export async function verify(path: string, checksum: string, checksumType: string, fileSize = 0): Promise<void> {
+  const algorithm = HASHES[checksumType.toLowerCase()];
+  if (!algorithm) {
+    throw new UpdateError(`unsupported checksum type ${checksumType}`);
+  }
+  const data = await readFile(path);
+  if (fileSize && data.length !== fileSize) {
+    throw new UpdateError(`size mismatch: got ${data.length} bytes, want ${fileSize}`);
+  }
+  const digest = createHash(algorithm).update(data).digest("hex");
+  if (digest !== checksum.toLowerCase()) {
+    throw new UpdateError(`${checksumType} mismatch: got ${digest}, want ${checksum}`);
+  }
+}
+
+/**
+ * Checks for an update and, when one is offered, downloads and verifies it.
+ * The downloaded file is removed when it fails verification.
+ */
+export async function update(opts: CheckOptions, destDir: string): Promise<UpdateResult> {
+  const check = await checkForUpdate(opts);
+  if (!check.update_available || !check.download_url) {
+    return { update_available: false, current_version: check.current_version };
+  }
+
+  const path = await download(check.download_url, destDir);
+  try {
+    await verify(path, check.checksum ?? "", check.checksum_type ?? "", check.file_size ?? 0);
+  } catch (err) {
+    await rm(path, { force: true });
+    throw err;
+  }
+  return { update_available: true, version: check.latest_version, required: check.required, path };
+}
+
+async function errorMessage(response: Response): Promise<string> {
+  try {
+    const body = (await response.json()) as ErrorResponse;
+    return `HTTP ${response.status}: ${body.message} (${body.code ?? ""})`;
+  } catch {
+    return `HTTP ${response.status}`;
+  }
+}
+
+async function main(): Promise<number> {
+  const { values } = parseArgs({
+    options: {
+      url: { type: "string" },
+      "app-id": { type: "string" },
+      "current-version": { type: "string" },
+      platform: { type: "string" },
+      architecture: { type: "string" },
+      channel: { type: "string", default: "" },
+      out: { type: "string", default: "." },
+    },
+  });
+  for (const name of ["url", "app-id", "current-version", "platform", "architecture"] as const) {
+    if (!values[name]) {
+      console.error(`--${name} is required`);
+      return 2;
+    }
+  }
+
+  try {
+    const result = await update(
+      {
+        baseURL: values.url!,
+        appID: values["app-id"]!,
+        currentVersion: values["current-version"]!,
+        platform: values.platform!,
+        architecture: values.architecture!,
+        channel: values.channel,
+      },
+      values.out!,
+    );
+    console.log(JSON.stringify(result));
+    return 0;
+  } catch (err) {
+    if (!(err instanceof UpdateError)) {
+      throw err;
+    }
+    console.log(JSON.stringify({ error: err.message }));
+    return 1;
+  }
+}
+
+if (process.argv[1] && import.meta.url === pathToFileURL(process.argv[1]).href) {
+  process.exitCode = await main();
+}
@@ -0,0 +1,173 @@
+// Command canary behaves like an update client against a running service: it
+// checks for an update, downloads the artifact it is offered through the same
+// CDN a real client would use, verifies the size, checksum and signature
+// format, and exports the outcome as Prometheus metrics. Run one per region to
+// watch the full delivery path from where clients are.
+//
+// The API key, when a deployment requires one for checks (for example with
+// client tokens enabled), is read from UPDATER_CANARY_API_KEY so it never
+// appears in a process listing.
+//
+// Usage:
+//
+//	canary --url https://updates.example.com --app-id my-app --platform windows --arch amd64 --region eu-west-1
+//	canary --url http://localhost:8080 --app-id my-app --platform linux --arch amd64 --once
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"updater/internal/artifact"
+	"updater/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type canaryConfig struct {
+	baseURL         string
+	appID           string
+	platform        string
+	arch            string
+	currentVersion  string
+	region          string
+	interval        time.Duration
+	timeout         time.Duration
+	maxDownloadSize int64
+	metricsAddr     string
+	once            bool
+}
+
+func main() {
+	cfg, err := parseArgs(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)).With(
+		"service", "updater-canary",
+		"region", cfg.region,
+		"app_id", cfg.appID,
+	))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	registry := prometheus.NewRegistry()
+	m := newMetrics(registry, cfg)
+	p := newProber(cfg, m)
+
+	if cfg.once {
+		if !runProbe(ctx, p, m, cfg.timeout) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: cfg.metricsAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Metrics server failed", "error", err)
+			stop()
+		}
+	}()
+	slog.Info("Canary started", "url", cfg.baseURL, "interval", cfg.interval, "metrics_addr", cfg.metricsAddr)
+
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+	for {
+		runProbe(ctx, p, m, cfg.timeout)
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseArgs parses CLI arguments into a canaryConfig.
+func parseArgs(args []string) (canaryConfig, error) {
+	var cfg canaryConfig
+	flagSet := flag.NewFlagSet("canary", flag.ContinueOnError)
+	flagSet.StringVar(&cfg.baseURL, "url", "", "Base URL of the update service")
+	flagSet.StringVar(&cfg.appID, "app-id", "", "Application to check for")
+	flagSet.StringVar(&cfg.platform, "platform", "", "Platform to report")
+	flagSet.StringVar(&cfg.arch, "arch", "", "Architecture to report")
+	flagSet.StringVar(&cfg.currentVersion, "current-version", "0.0.0", "Version to report; must be older than the latest release")
+	flagSet.StringVar(&cfg.region, "region", "", "Region label for the metrics")
+	flagSet.DurationVar(&cfg.interval, "interval", time.Minute, "Time between probes")
+	flagSet.DurationVar(&cfg.timeout, "timeout", 5*time.Minute, "Limit on one probe, including the download")
+	flagSet.Int64Var(&cfg.maxDownloadSize, "max-download-size", 4<<30, "Largest artifact to download, in bytes")
+	flagSet.StringVar(&cfg.metricsAddr, "metrics-addr", ":9091", "Address to serve /metrics on")
+	flagSet.BoolVar(&cfg.once, "once", false, "Run one probe and exit nonzero if it fails")
+
+	if err := flagSet.Parse(args); err != nil {
+		return canaryConfig{}, err
+	}
+	cfg.baseURL = strings.TrimRight(cfg.baseURL, "/")
+	switch {
+	case cfg.baseURL == "":
+		return canaryConfig{}, errors.New("--url is required")
+	case cfg.appID == "" || cfg.platform == "" || cfg.arch == "":
+		return canaryConfig{}, errors.New("--app-id, --platform and --arch are required")
+	case cfg.interval <= 0 || cfg.timeout <= 0:
+		return canaryConfig{}, errors.New("--interval and --timeout must be positive")
+	case cfg.maxDownloadSize <= 0:
+		return canaryConfig{}, errors.New("--max-download-size must be positive")
+	}
+	return cfg, nil
+}
+
+func newProber(cfg canaryConfig, m *metrics) *prober {
+	return &prober{
+		baseURL:        cfg.baseURL,
+		appID:          cfg.appID,
+		platform:       cfg.platform,
+		arch:           cfg.arch,
+		currentVersion: cfg.currentVersion,
+		apiKey:         os.Getenv("UPDATER_CANARY_API_KEY"),
+		client:         &http.Client{Timeout: cfg.timeout},
+		// The operator picks the targets, so internal addresses are allowed
+		artifacts: artifact.New(models.AutoFillConfig{
+			Timeout:              cfg.timeout,
+			MaxSize:              cfg.maxDownloadSize,
+			AllowPrivateNetworks: true,
+		}),
+		observe: m.observe,
+	}
+}
+
+// runProbe runs one probe, logs and records its outcome, and reports whether
+// it succeeded.
+func runProbe(ctx context.Context, p *prober, m *metrics, timeout time.Duration) bool {
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := p.probe(probeCtx)
+	duration := time.Since(start)
+	if err != nil {
+		stage := failedStage(err)
+		m.failed(stage)
+		slog.Error("Canary probe failed", "stage", stage, "duration", duration, "error", err)
+		return false
+	}
+	m.succeeded()
+	slog.Info("Canary probe succeeded", "version", resp.LatestVersion, "duration", duration)
+	return true
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics are the canary's Prometheus series. Every series carries the
+// region and application, so canaries in several regions can share one
+// Prometheus.
+type metrics struct {
+	probes      *prometheus.CounterVec
+	failures    *prometheus.CounterVec
+	stages      *prometheus.HistogramVec
+	lastSuccess prometheus.Gauge
+}
+
+func newMetrics(reg prometheus.Registerer, cfg canaryConfig) *metrics {
+	labels := prometheus.Labels{"region": cfg.region, "app_id": cfg.appID}
+	m := &metrics{
+		probes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "updater_canary_probes_total",
+			Help:        "Canary probes run, by result (success or failure).",
+			ConstLabels: labels,
+		}, []string{"result"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "updater_canary_failures_total",
+			Help:        "Failed canary probes, by the stage that failed.",
+			ConstLabels: labels,
+		}, []string{"stage"}),
+		stages: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "updater_canary_stage_duration_seconds",
+			Help:        "Duration of each canary probe stage, successful or not.",
+			ConstLabels: labels,
+			Buckets:     []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}, []string{"stage"}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "updater_canary_last_success_timestamp_seconds",
+			Help:        "Unix time of the last successful canary probe.",
+			ConstLabels: labels,
+		}),
+	}
+	reg.MustRegister(m.probes, m.failures, m.stages, m.lastSuccess)
+	return m
+}
+
+func (m *metrics) observe(stage string, d time.Duration) {
+	m.stages.WithLabelValues(stage).Observe(d.Seconds())
+}
+
+func (m *metrics) succeeded() {
+	m.probes.WithLabelValues("success").Inc()
+	m.lastSuccess.SetToCurrentTime()
+}
+
+func (m *metrics) failed(stage string) {
+	m.probes.WithLabelValues("failure").Inc()
+	m.failures.WithLabelValues(stage).Inc()
+}
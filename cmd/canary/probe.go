@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"updater/internal/models"
+)
+
+// Probe stages, in the order a probe runs them.
+const (
+	stageCheck     = "check"
+	stageDownload  = "download"
+	stageSignature = "signature"
+)
+
+// maxSignatureSize bounds the detached signature that is read.
+const maxSignatureSize = 64 << 10
+
+// stageError is a probe failure and the stage it happened in.
+type stageError struct {
+	stage string
+	err   error
+}
+
+func (e *stageError) Error() string { return e.stage + ": " + e.err.Error() }
+func (e *stageError) Unwrap() error { return e.err }
+
+// failedStage returns the stage a probe error happened in.
+func failedStage(err error) string {
+	var se *stageError
+	if errors.As(err, &se) {
+		return se.stage
+	}
+	return "unknown"
+}
+
+// digester downloads an artifact and returns its size and hex checksum.
+type digester interface {
+	Digest(ctx context.Context, url, checksumType string) (int64, string, error)
+}
+
+// prober runs the steps a real client takes: check for an update, download
+// the artifact it is offered and verify it against the check response.
+type prober struct {
+	baseURL        string
+	appID          string
+	platform       string
+	arch           string
+	currentVersion string
+	apiKey         string
+	client         *http.Client
+	artifacts      digester
+	observe        func(stage string, d time.Duration)
+}
+
+// probe runs one check, download and verification. A canary reports an old
+// current version, so a check that offers no update is a failure too.
+func (p *prober) probe(ctx context.Context) (*models.UpdateCheckResponse, error) {
+	var resp *models.UpdateCheckResponse
+	err := p.stage(stageCheck, func() error {
+		var err error
+		resp, err = p.check(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.stage(stageDownload, func() error { return p.verifyArtifact(ctx, resp) })
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.PGPSignatureURL != "" {
+		err = p.stage(stageSignature, func() error { return p.verifySignature(ctx, resp.PGPSignatureURL) })
+	}
+	return resp, err
+}
+
+// stage times fn as stage and tags its error with the stage.
+func (p *prober) stage(stage string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	if p.observe != nil {
+		p.observe(stage, time.Since(start))
+	}
+	if err != nil {
+		return &stageError{stage: stage, err: err}
+	}
+	return nil
+}
+
+func (p *prober) check(ctx context.Context) (*models.UpdateCheckResponse, error) {
+	query := url.Values{
+		"current_version": {p.currentVersion},
+		"platform":        {p.platform},
+		"architecture":    {p.arch},
+	}
+	checkURL := p.baseURL + "/api/v1/updates/" + url.PathEscape(p.appID) + "/check?" + query.Encode()
+	body, err := p.get(ctx, checkURL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var resp models.UpdateCheckResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("invalid check response: %w", err)
+	}
+	if !resp.UpdateAvailable {
+		return nil, fmt.Errorf("no update offered to version %s", p.currentVersion)
+	}
+	if resp.DownloadURL == "" {
+		return nil, errors.New("check response has no download_url")
+	}
+	return &resp, nil
+}
+
+// verifyArtifact downloads the offered artifact and compares its size and
+// checksum with the check response.
+func (p *prober) verifyArtifact(ctx context.Context, resp *models.UpdateCheckResponse) error {
+	checksumType, checksum := verifiableChecksum(resp)
+	if checksumType == "" {
+		return fmt.Errorf("no checksum of a type the canary can compute (got %s)", resp.ChecksumType)
+	}
+	size, got, err := p.artifacts.Digest(ctx, resp.DownloadURL, checksumType)
+	if err != nil {
+		return err
+	}
+	if resp.FileSize > 0 && size != resp.FileSize {
+		return fmt.Errorf("downloaded %d bytes, check response says %d", size, resp.FileSize)
+	}
+	if !strings.EqualFold(got, checksum) {
+		return fmt.Errorf("%s checksum mismatch: got %s, want %s", checksumType, got, checksum)
+	}
+	return nil
+}
+
+// verifiableChecksum returns the primary checksum, or an additional one when
+// the response has no primary checksum.
+func verifiableChecksum(resp *models.UpdateCheckResponse) (string, string) {
+	if resp.Checksum != "" {
+		return resp.ChecksumType, resp.Checksum
+	}
+	for _, t := range []string{models.ChecksumTypeSHA256, models.ChecksumTypeSHA512} {
+		if c := resp.Checksums[t]; c != "" {
+			return t, c
+		}
+	}
+	return "", ""
+}
+
+// verifySignature fetches the release's detached signature and checks that
+// it is well formed. It is not verified against the artifact.
+func (p *prober) verifySignature(ctx context.Context, signatureURL string) error {
+	if strings.HasPrefix(signatureURL, "/") {
+		signatureURL = p.baseURL + signatureURL
+	}
+	body, err := p.get(ctx, signatureURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	signature, err := io.ReadAll(io.LimitReader(body, maxSignatureSize))
+	if err != nil {
+		return err
+	}
+	return models.ValidatePGPSignature(string(signature))
+}
+
+// get fetches u and returns the body of a 200 response.
+func (p *prober) get(ctx context.Context, u string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "updater-canary")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s returned HTTP %d", req.URL.Path, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/artifact"
+	"updater/internal/blake3"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSignature = "-----BEGIN PGP SIGNATURE-----\n\niHUEABYKAB0=\n-----END PGP SIGNATURE-----\n"
+
+// newTestService serves a check response offering an artifact, the artifact
+// and its signature. mutate adjusts the check response.
+func newTestService(t *testing.T, mutate func(*models.UpdateCheckResponse)) *httptest.Server {
+	t.Helper()
+	payload := []byte("artifact bytes")
+	sum := sha256.Sum256(payload)
+
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/api/v1/updates/canary-app/check", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "0.0.0", r.URL.Query().Get("current_version"))
+		resp := &models.UpdateCheckResponse{
+			UpdateAvailable: true,
+			LatestVersion:   "1.2.0",
+			CurrentVersion:  "0.0.0",
+			DownloadURL:     server.URL + "/cdn/app.tar.gz",
+			Checksum:        hex.EncodeToString(sum[:]),
+			ChecksumType:    models.ChecksumTypeSHA256,
+			FileSize:        int64(len(payload)),
+			PGPSignatureURL: "/api/v1/updates/canary-app/releases/1.2.0/linux/amd64/signature",
+		}
+		if mutate != nil {
+			mutate(resp)
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("/cdn/app.tar.gz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(payload)
+	})
+	mux.HandleFunc("/api/v1/updates/canary-app/releases/1.2.0/linux/amd64/signature", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(testSignature))
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestProber(baseURL string) *prober {
+	return &prober{
+		baseURL:        baseURL,
+		appID:          "canary-app",
+		platform:       "linux",
+		arch:           "amd64",
+		currentVersion: "0.0.0",
+		client:         &http.Client{Timeout: 5 * time.Second},
+		artifacts:      artifact.New(models.AutoFillConfig{Timeout: 5 * time.Second, MaxSize: 1 << 20, AllowPrivateNetworks: true}),
+	}
+}
+
+func TestProber_Probe(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(*models.UpdateCheckResponse)
+		wantStage string
+	}{
+		{name: "healthy path"},
+		{name: "no update offered", mutate: func(r *models.UpdateCheckResponse) { r.UpdateAvailable = false }, wantStage: stageCheck},
+		{name: "checksum mismatch", mutate: func(r *models.UpdateCheckResponse) { r.Checksum = "00" }, wantStage: stageDownload},
+		{name: "size mismatch", mutate: func(r *models.UpdateCheckResponse) { r.FileSize = 1 }, wantStage: stageDownload},
+		{name: "artifact missing", mutate: func(r *models.UpdateCheckResponse) { r.DownloadURL += ".missing" }, wantStage: stageDownload},
+		{name: "blake3", mutate: func(r *models.UpdateCheckResponse) {
+			sum := blake3.Sum256([]byte("artifact bytes"))
+			r.ChecksumType, r.Checksum = models.ChecksumTypeBLAKE3, hex.EncodeToString(sum[:])
+		}},
+		{name: "blake3 mismatch", mutate: func(r *models.UpdateCheckResponse) { r.ChecksumType = models.ChecksumTypeBLAKE3 }, wantStage: stageDownload},
+		{name: "no primary checksum with a sha256 alternative", mutate: func(r *models.UpdateCheckResponse) {
+			r.Checksums = map[string]string{models.ChecksumTypeSHA256: r.Checksum}
+			r.ChecksumType, r.Checksum = "", ""
+		}},
+		{name: "signature missing", mutate: func(r *models.UpdateCheckResponse) { r.PGPSignatureURL += "/missing" }, wantStage: stageSignature},
+		{name: "no signature", mutate: func(r *models.UpdateCheckResponse) { r.PGPSignatureURL = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestService(t, tt.mutate)
+			var stages []string
+			p := newTestProber(server.URL)
+			p.observe = func(stage string, _ time.Duration) { stages = append(stages, stage) }
+
+			resp, err := p.probe(context.Background())
+			if tt.wantStage != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantStage, failedStage(err))
+				assert.Equal(t, tt.wantStage, stages[len(stages)-1], "the failed stage is timed too")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "1.2.0", resp.LatestVersion)
+		})
+	}
+}
+
+func TestProber_CheckFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := newTestProber(server.URL).probe(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, stageCheck, failedStage(err))
+	assert.Contains(t, err.Error(), "HTTP 503")
+}
+
+func TestParseArgs(t *testing.T) {
+	cfg, err := parseArgs([]string{"--url", "https://updates.example.com/", "--app-id", "my-app", "--platform", "linux", "--arch", "amd64"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://updates.example.com", cfg.baseURL)
+	assert.Equal(t, "0.0.0", cfg.currentVersion)
+	assert.Equal(t, time.Minute, cfg.interval)
+
+	_, err = parseArgs([]string{"--app-id", "my-app", "--platform", "linux", "--arch", "amd64"})
+	assert.ErrorContains(t, err, "--url is required")
+	_, err = parseArgs([]string{"--url", "https://updates.example.com", "--app-id", "my-app"})
+	assert.ErrorContains(t, err, "--platform")
+	_, err = parseArgs([]string{"--url", "https://updates.example.com", "--app-id", "my-app", "--platform", "linux", "--arch", "amd64", "--interval", "0s"})
+	assert.ErrorContains(t, err, "must be positive")
+}
@@ -0,0 +1,47 @@
+// Command clientgen writes the update check protocol types for TypeScript,
+// Python and Rust clients from the Go models, so client teams import them
+// instead of porting the responses by hand. Run it after changing a model the
+// clients use; a test fails while the committed files are out of date.
+//
+// Usage:
+//
+//	clientgen
+//	clientgen --models internal/models --out clients
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"updater/internal/clientgen"
+)
+
+func main() {
+	modelsDir := flag.String("models", "internal/models", "directory of the models package, for doc comments")
+	outDir := flag.String("out", "clients", "directory to write the generated files to")
+	flag.Parse()
+
+	if err := run(*modelsDir, *outDir); err != nil {
+		fmt.Fprintf(os.Stderr, "clientgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(modelsDir, outDir string) error {
+	for _, lang := range clientgen.Languages {
+		src, err := clientgen.Generate(lang, modelsDir)
+		if err != nil {
+			return fmt.Errorf("%s: %w", lang.Name, err)
+		}
+		path := filepath.Join(outDir, lang.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"updater/internal/config"
+)
+
+const configUsage = `Usage:
+
+	updater config validate [-format text|json] [FILE]
+	updater config print-effective [-config FILE]
+	updater config env-vars
+
+Subcommands:
+
+	validate         Load FILE and the environment, run every check and report unknown keys
+	print-effective  Print the merged configuration, with secrets redacted
+	env-vars         List the environment variable of every config field
+`
+
+// runConfigCommand runs `updater config SUBCOMMAND` and returns the exit code.
+func runConfigCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, configUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "validate":
+		flagSet := flag.NewFlagSet("config validate", flag.ContinueOnError)
+		flagSet.SetOutput(stderr)
+		format := flagSet.String("format", "text", "Output format (text or json)")
+		if err := flagSet.Parse(args[1:]); err != nil {
+			return 2
+		}
+		path := *configFile
+		if flagSet.NArg() > 0 {
+			path = flagSet.Arg(0)
+		}
+		results := config.ValidateConfig(path)
+		printValidateResults(results, *format)
+		for _, r := range results {
+			if !r.OK {
+				return 1
+			}
+		}
+		return 0
+	case "print-effective":
+		flagSet := flag.NewFlagSet("config print-effective", flag.ContinueOnError)
+		flagSet.SetOutput(stderr)
+		path := flagSet.String("config", *configFile, "Path to configuration file")
+		if err := flagSet.Parse(args[1:]); err != nil {
+			return 2
+		}
+		// Print even an invalid configuration; finding out why it is invalid
+		// is what the command is for
+		cfg, err := config.LoadUnvalidated(*path)
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 1
+		}
+		data, err := config.MarshalRedacted(cfg)
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 1
+		}
+		stdout.Write(data)
+		return 0
+	case "env-vars":
+		for _, v := range config.EnvironmentVariables() {
+			fmt.Fprintf(stdout, "%-55s %s\n", v.Name, v.Path)
+		}
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown config subcommand %q\n\n%s", args[0], configUsage)
+		return 2
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"updater/internal/signing"
+)
+
+const signingKeyUsage = `Usage:
+
+	updater signing-key generate -out FILE
+	updater signing-key show FILE
+
+Subcommands:
+
+	generate  Write a new Ed25519 private key to FILE and print its key ID and public key
+	show      Print the key ID and public key of the private key in FILE
+`
+
+// runSigningKeyCommand runs `updater signing-key SUBCOMMAND` and returns the
+// exit code.
+func runSigningKeyCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, signingKeyUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "generate":
+		flagSet := flag.NewFlagSet("signing-key generate", flag.ContinueOnError)
+		flagSet.SetOutput(stderr)
+		out := flagSet.String("out", "", "Path to write the private key to; must not exist")
+		if err := flagSet.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if *out == "" {
+			fmt.Fprintf(stderr, "error: -out is required\n\n%s", signingKeyUsage)
+			return 2
+		}
+		key, encoded, err := signing.GenerateKey()
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 1
+		}
+		// Never replace a key: the old one may still be in use
+		file, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 1
+		}
+		_, err = file.Write(encoded)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 1
+		}
+		return printSigningKey(key, stdout, stderr)
+	case "show":
+		if len(args) != 2 {
+			fmt.Fprint(stderr, signingKeyUsage)
+			return 2
+		}
+		key, err := signing.LoadKey(args[1])
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 1
+		}
+		return printSigningKey(key, stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "unknown signing-key subcommand %q\n\n%s", args[0], signingKeyUsage)
+		return 2
+	}
+}
+
+// printSigningKey writes the key ID and PEM public key of key.
+func printSigningKey(key ed25519.PrivateKey, stdout, stderr io.Writer) int {
+	pub := key.Public().(ed25519.PublicKey)
+	encoded, err := signing.PublicKeyPEM(pub)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Key ID: %s\n%s", signing.KeyID(pub), encoded)
+	return 0
+}
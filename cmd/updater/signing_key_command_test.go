@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"path/filepath"
+	"strings"
+	"testing"
+	"updater/internal/signing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSigningKeyCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.pem")
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, runSigningKeyCommand([]string{"generate", "-out", path}, &stdout, &stderr), stderr.String())
+	key, err := signing.LoadKey(path)
+	require.NoError(t, err)
+	generated := stdout.String()
+	assert.True(t, strings.HasPrefix(generated, "Key ID: "+signing.KeyID(key.Public().(ed25519.PublicKey))+"\n"))
+	assert.Contains(t, generated, "-----BEGIN PUBLIC KEY-----")
+
+	stdout.Reset()
+	assert.Equal(t, 1, runSigningKeyCommand([]string{"generate", "-out", path}, &stdout, &stderr), "an existing key is never replaced")
+
+	require.Equal(t, 0, runSigningKeyCommand([]string{"show", path}, &stdout, &stderr))
+	assert.Equal(t, generated, stdout.String())
+
+	assert.Equal(t, 2, runSigningKeyCommand([]string{"generate"}, &stdout, &stderr))
+	assert.Equal(t, 2, runSigningKeyCommand([]string{"rotate"}, &stdout, &stderr))
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"updater/internal/api"
+	"updater/internal/config"
+	"updater/internal/models"
+	"updater/internal/selfcheck"
+	"updater/internal/storage"
+	"updater/internal/version"
+)
+
+const smokeUsage = `Usage:
+
+	updater smoke [-config FILE] [-timeout DURATION] [-download-url URL]
+
+Boot the server with FILE on an ephemeral loopback port, register a release
+for a temporary application, check for it through the public endpoints and
+remove everything again. Exits nonzero when any step fails.
+`
+
+// smokeVersion is the version of the release a smoke run registers.
+const smokeVersion = "1.0.0"
+
+// runSmokeCommand runs `updater smoke` and returns the exit code.
+func runSmokeCommand(args []string, stdout, stderr io.Writer) int {
+	flagSet := flag.NewFlagSet("smoke", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.Usage = func() { fmt.Fprint(stderr, smokeUsage) }
+	path := flagSet.String("config", *configFile, "Path to configuration file")
+	timeout := flagSet.Duration("timeout", 30*time.Second, "Limit on the whole run")
+	downloadURL := flagSet.String("download-url", "https://example.com/updater-smoke/app.tar.gz",
+		"Download URL of the test release; must pass the configured download URL policies")
+	if err := flagSet.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := config.Load(*path)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := runSmoke(ctx, cfg, *downloadURL, stdout); err != nil {
+		fmt.Fprintf(stdout, "FAIL %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, "PASS")
+	return 0
+}
+
+// runSmoke boots the server from cfg and runs the smoke steps against it,
+// printing one line per step.
+func runSmoke(ctx context.Context, cfg *models.Config, downloadURL string, out io.Writer) error {
+	store, err := initializeStorage(cfg)
+	if err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+	defer store.Close()
+
+	bus := newEventBus()
+	updateService, err := newUpdateService(cfg, store, bus)
+	if err != nil {
+		return err
+	}
+	handlerOpts, err := newHandlerOptions(cfg, store, bus, version.GetInfo())
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	server := &http.Server{Handler: api.SetupRoutes(api.NewHandlers(updateService, handlerOpts...), cfg)}
+	go server.Serve(listener)
+	defer server.Close()
+
+	s := &smokeRun{
+		baseURL: "http://" + listener.Addr().String(),
+		client:  &http.Client{Timeout: 10 * time.Second},
+		appID:   "smoke-" + randomHex(4),
+		out:     out,
+	}
+	fmt.Fprintf(out, "     server listening on %s, application %s\n", s.baseURL, s.appID)
+
+	if cfg.Security.EnableAuth {
+		cleanup, err := s.createTemporaryKey(ctx, store)
+		if err != nil {
+			return fmt.Errorf("create temporary API key: %w", err)
+		}
+		defer cleanup()
+	}
+
+	// Catch what a fresh deploy gets wrong, such as unapplied migrations,
+	// before any request fails on it
+	if err := s.step("self-checks", func() error {
+		checker := selfcheck.New(cfg, store, selfcheck.WithBuildDate(version.GetInfo().BuildDate))
+		var problems []string
+		for _, r := range checker.Run(ctx).Failed() {
+			problems = append(problems, r.Name+": "+r.Message)
+		}
+		if len(problems) > 0 {
+			return errors.New(strings.Join(problems, "; "))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	defer s.cleanup(ctx)
+	return s.run(ctx, downloadURL)
+}
+
+// smokeRun talks to the booted server over HTTP, as a client would.
+type smokeRun struct {
+	baseURL  string
+	client   *http.Client
+	apiKey   string
+	appID    string
+	out      io.Writer
+	created  bool
+	released bool
+}
+
+func (s *smokeRun) run(ctx context.Context, downloadURL string) error {
+	checksum := hex.EncodeToString(make([]byte, 32))
+
+	if err := s.step("health", func() error {
+		return s.do(ctx, http.MethodGet, "/health", nil, http.StatusOK, nil)
+	}); err != nil {
+		return err
+	}
+	if err := s.step("create application", func() error {
+		req := models.CreateApplicationRequest{ID: s.appID, Name: "Smoke test", Platforms: []string{models.PlatformLinux}}
+		err := s.do(ctx, http.MethodPost, "/api/v1/applications", req, http.StatusCreated, nil)
+		s.created = err == nil
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := s.step("register release", func() error {
+		req := models.RegisterReleaseRequest{
+			Version:      smokeVersion,
+			Platform:     models.PlatformLinux,
+			Architecture: models.ArchAMD64,
+			DownloadURL:  downloadURL,
+			Checksum:     checksum,
+			ChecksumType: models.ChecksumTypeSHA256,
+		}
+		err := s.do(ctx, http.MethodPost, "/api/v1/updates/"+s.appID+"/register", req, http.StatusCreated, nil)
+		s.released = err == nil
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := s.step("check for update", func() error {
+		var resp models.UpdateCheckResponse
+		query := "?current_version=0.9.0&platform=linux&architecture=amd64"
+		if err := s.do(ctx, http.MethodGet, "/api/v1/updates/"+s.appID+"/check"+query, nil, http.StatusOK, &resp); err != nil {
+			return err
+		}
+		switch {
+		case !resp.UpdateAvailable:
+			return errors.New("no update offered")
+		case resp.LatestVersion != smokeVersion:
+			return fmt.Errorf("offered %s, want %s", resp.LatestVersion, smokeVersion)
+		case resp.DownloadURL != downloadURL || resp.Checksum != checksum:
+			return errors.New("download URL or checksum differs from the registered release")
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := s.step("latest version", func() error {
+		var resp models.LatestVersionResponse
+		if err := s.do(ctx, http.MethodGet, "/api/v1/updates/"+s.appID+"/latest?platform=linux&architecture=amd64", nil, http.StatusOK, &resp); err != nil {
+			return err
+		}
+		if resp.Version != smokeVersion {
+			return fmt.Errorf("latest is %s, want %s", resp.Version, smokeVersion)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return s.step("version badge", func() error {
+		var badge models.BadgeResponse
+		if err := s.do(ctx, http.MethodGet, "/badge/"+s.appID+"/version.json", nil, http.StatusOK, &badge); err != nil {
+			return err
+		}
+		if !strings.Contains(badge.Message, smokeVersion) {
+			return fmt.Errorf("badge shows %q, want %s", badge.Message, smokeVersion)
+		}
+		return nil
+	})
+}
+
+// cleanup removes what the run created, even after the run timed out.
+// Failures are reported but do not change the result of the run.
+func (s *smokeRun) cleanup(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if s.released {
+		s.report(s.step("delete release", func() error {
+			path := "/api/v1/updates/" + s.appID + "/releases/" + smokeVersion + "/linux/amd64"
+			return s.do(ctx, http.MethodDelete, path, nil, http.StatusOK, nil)
+		}))
+	}
+	if s.created {
+		s.report(s.step("delete application", func() error {
+			return s.do(ctx, http.MethodDelete, "/api/v1/applications/"+s.appID, nil, http.StatusNoContent, nil)
+		}))
+	}
+}
+
+// createTemporaryKey stores an admin key for the run and returns a function
+// removing it again.
+func (s *smokeRun) createTemporaryKey(ctx context.Context, store storage.Storage) (func(), error) {
+	rawKey, err := models.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	key := models.NewAPIKey(models.NewKeyID(), "smoke-test "+s.appID, rawKey, []string{"admin"})
+	if err := store.CreateAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+	s.apiKey = rawKey
+	return func() {
+		s.report(s.step("delete temporary API key", func() error {
+			return store.DeleteAPIKey(context.WithoutCancel(ctx), key.ID)
+		}))
+	}, nil
+}
+
+// step runs fn, printing the step when it succeeds and naming it in the error
+// when it fails.
+func (s *smokeRun) step(name string, fn func() error) error {
+	if err := fn(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	fmt.Fprintf(s.out, "ok   %s\n", name)
+	return nil
+}
+
+// report prints the error of a cleanup step, which does not fail the run.
+func (s *smokeRun) report(err error) {
+	if err != nil {
+		fmt.Fprintf(s.out, "WARN %v\n", err)
+	}
+}
+
+// do sends a request with an optional JSON body, expects status and decodes
+// the response into out when it is not nil.
+func (s *smokeRun) do(ctx context.Context, method, path string, body any, status int, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s returned HTTP %d: %s", method, path, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
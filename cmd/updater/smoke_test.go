@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSmokeConfig(t *testing.T) *models.Config {
+	t.Helper()
+	cfg := models.NewDefaultConfig()
+	cfg.Storage.Type = "memory"
+	cfg.Security.EnableAuth = false
+	return cfg
+}
+
+func TestRunSmoke(t *testing.T) {
+	for _, auth := range []bool{false, true} {
+		t.Run(map[bool]string{false: "without auth", true: "with auth"}[auth], func(t *testing.T) {
+			cfg := newSmokeConfig(t)
+			cfg.Security.EnableAuth = auth
+
+			var out bytes.Buffer
+			err := runSmoke(context.Background(), cfg, "https://example.com/app.tar.gz", &out)
+			require.NoError(t, err, out.String())
+			assert.Contains(t, out.String(), "ok   check for update")
+			assert.Contains(t, out.String(), "ok   delete application")
+			assert.NotContains(t, out.String(), "WARN")
+			if auth {
+				assert.Contains(t, out.String(), "ok   delete temporary API key")
+			}
+		})
+	}
+}
+
+func TestRunSmoke_FailureCleansUp(t *testing.T) {
+	cfg := newSmokeConfig(t)
+	cfg.Security.DownloadURLs.AllowedHosts = []string{"downloads.example.org"}
+
+	var out bytes.Buffer
+	err := runSmoke(context.Background(), cfg, "https://example.com/app.tar.gz", &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "register release")
+	assert.Contains(t, out.String(), "ok   delete application", "the application is removed after a failed step")
+	assert.NotContains(t, out.String(), "delete release")
+}
+
+func TestRunSmoke_UnmigratedDatabase(t *testing.T) {
+	cfg := newSmokeConfig(t)
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.Database.DSN = filepath.Join(t.TempDir(), "smoke.db")
+
+	var out bytes.Buffer
+	err := runSmoke(context.Background(), cfg, "https://example.com/app.tar.gz", &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.migrations")
+	assert.NotContains(t, out.String(), "create application")
+}
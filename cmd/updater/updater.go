@@ -3,19 +3,31 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 	"updater/internal/api"
+	"updater/internal/artifact"
+	"updater/internal/blob"
+	"updater/internal/clientbundle"
+	"updater/internal/clienttoken"
+	"updater/internal/coap"
 	"updater/internal/config"
+	"updater/internal/entitlement"
+	"updater/internal/events"
 	"updater/internal/logger"
 	"updater/internal/models"
+	"updater/internal/notesdraft"
 	"updater/internal/observability"
+	"updater/internal/seed"
+	"updater/internal/selfcheck"
 	"updater/internal/storage"
 	"updater/internal/update"
 	"updater/internal/version"
@@ -26,6 +38,8 @@ var (
 	showVersion    = flag.Bool("version", false, "Show version information and exit")
 	validateOnly   = flag.Bool("validate", false, "Validate configuration and exit")
 	validateFormat = flag.String("validate-format", "text", "Output format for --validate (text or json)")
+	strictConfig   = flag.Bool("strict-config", false, "Refuse to start when the configuration has unknown keys")
+	seedFile       = flag.String("seed", "", "Path to a fixtures file of applications, releases and API keys to create at startup")
 )
 
 func main() {
@@ -40,6 +54,21 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle `updater config ...` subcommands
+	if flag.Arg(0) == "config" {
+		os.Exit(runConfigCommand(flag.Args()[1:], os.Stdout, os.Stderr))
+	}
+
+	// Handle `updater signing-key ...`: offline signing key management
+	if flag.Arg(0) == "signing-key" {
+		os.Exit(runSigningKeyCommand(flag.Args()[1:], os.Stdout, os.Stderr))
+	}
+
+	// Handle `updater smoke`: boot, exercise and tear down a server
+	if flag.Arg(0) == "smoke" {
+		os.Exit(runSmokeCommand(flag.Args()[1:], os.Stdout, os.Stderr))
+	}
+
 	// Handle --validate flag: run all checks and exit without starting the server.
 	if *validateOnly {
 		results := config.ValidateConfig(*configFile)
@@ -52,6 +81,14 @@ func main() {
 		os.Exit(0)
 	}
 
+	// In strict mode a misspelt key stops startup instead of being ignored
+	if *strictConfig {
+		if err := config.CheckUnknownKeys(*configFile); err != nil {
+			slog.Error("Configuration has unknown keys", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Load configuration
 	cfg, err := config.Load(*configFile)
 	if err != nil {
@@ -59,6 +96,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *seedFile != "" {
+		cfg.Seed.File = *seedFile
+	}
+
 	// I/O validation: verify TLS files and log directory before starting subsystems.
 	if err := config.ValidateRuntime(cfg); err != nil {
 		slog.Error("Configuration runtime validation failed", "error", err)
@@ -98,6 +139,17 @@ func main() {
 	}
 	defer storageInstance.Close()
 
+	// Probe dependencies before accepting traffic
+	if cfg.Server.SelfCheck.Enabled {
+		checker := selfcheck.New(cfg, storageInstance, selfcheck.WithBuildDate(versionInfo.BuildDate))
+		report := checker.Run(context.Background())
+		report.Log(slog.Default())
+		if failed := report.Failed(); len(failed) > 0 && cfg.Server.SelfCheck.RefuseOnFailure {
+			slog.Error("Refusing to start after failed self-checks", "failed", len(failed))
+			os.Exit(1)
+		}
+	}
+
 	// Wrap storage with instrumentation if metrics are enabled
 	var activeStorage storage.Storage = storageInstance
 	if cfg.Metrics.Enabled {
@@ -115,12 +167,25 @@ func main() {
 	}
 
 	// Initialize update service
-	updateService := update.NewService(activeStorage)
+	bus := newEventBus()
+	updateService, err := newUpdateService(cfg, activeStorage, bus)
+	if err != nil {
+		slog.Error("Failed to initialize update service", "error", err)
+		os.Exit(1)
+	}
+
+	if cfg.Seed.File != "" {
+		if err := applySeed(context.Background(), cfg.Seed.File, updateService, activeStorage); err != nil {
+			slog.Error("Failed to seed fixtures", "file", cfg.Seed.File, "error", err)
+			os.Exit(1)
+		}
+	}
 
 	// Initialize HTTP handlers with storage for health checks
-	handlerOpts := []api.HandlersOption{
-		api.WithStorage(activeStorage),
-		api.WithVersionInfo(versionInfo),
+	handlerOpts, err := newHandlerOptions(cfg, activeStorage, bus, versionInfo)
+	if err != nil {
+		slog.Error("Failed to initialize handlers", "error", err)
+		os.Exit(1)
 	}
 	if cfg.Metrics.Enabled {
 		appMetrics, err := observability.NewAppMetrics(otelProvider)
@@ -130,6 +195,22 @@ func main() {
 		}
 		handlerOpts = append(handlerOpts, api.WithAppMetrics(appMetrics))
 	}
+	healthCtx, stopHealthHistory := context.WithCancel(context.Background())
+	defer stopHealthHistory()
+	if hh := cfg.Observability.HealthHistory; hh.Enabled {
+		history := observability.NewHealthHistory(hh.Interval, hh.Retention, activeStorage.Ping)
+		go history.Run(healthCtx)
+		handlerOpts = append(handlerOpts, api.WithHealthHistory(history))
+	}
+	if cfg.Server.SLO.Enabled {
+		tracker, err := observability.NewSLOTracker(cfg.Server.SLO)
+		if err != nil {
+			slog.Error("Failed to create SLO tracker", "error", err)
+			os.Exit(1)
+		}
+		defer tracker.Close()
+		handlerOpts = append(handlerOpts, api.WithSLOTracker(tracker))
+	}
 	handlers := api.NewHandlers(updateService, handlerOpts...)
 
 	// Setup routes with middleware
@@ -159,6 +240,46 @@ func main() {
 		}()
 	}
 
+	// Serve the admin API on its own listener when it is separated from the
+	// public one
+	var adminServer *http.Server
+	if admin := cfg.Server.AdminListener; admin.Enabled {
+		listener, err := listenAdmin(admin)
+		if err != nil {
+			slog.Error("Failed to open admin listener", "error", err)
+			os.Exit(1)
+		}
+		adminServer = &http.Server{
+			Handler:      api.SetupAdminRoutes(handlers, cfg, routeOpts...),
+			ReadTimeout:  cfg.Server.ReadTimeout,
+			WriteTimeout: cfg.Server.WriteTimeout,
+			IdleTimeout:  cfg.Server.IdleTimeout,
+		}
+		go func() {
+			slog.Info("Starting admin listener", "addr", listener.Addr().String())
+			var err error
+			if cfg.Server.TLSEnabled && admin.Socket == "" {
+				err = adminServer.ServeTLS(listener, cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+			} else {
+				err = adminServer.Serve(listener)
+			}
+			if err != nil && err != http.ErrServerClosed {
+				slog.Error("Admin listener failed", "error", err)
+			}
+		}()
+	}
+
+	// Start CoAP gateway if enabled
+	var coapServer *coap.Server
+	if cfg.CoAP.Enabled {
+		coapServer = coap.NewServer(cfg.CoAP.Host, cfg.CoAP.Port, updateService, coap.WithWorkers(cfg.CoAP.Workers, cfg.CoAP.QueueDepth))
+		go func() {
+			if err := coapServer.Start(); err != nil && !errors.Is(err, coap.ErrServerClosed) {
+				slog.Error("CoAP gateway failed", "error", err)
+			}
+		}()
+	}
+
 	// Create HTTP server
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -167,6 +288,8 @@ func main() {
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
+	// Release held long-polling checks so they do not delay graceful shutdown
+	server.RegisterOnShutdown(updateService.CancelWaits)
 
 	// Start server in a goroutine
 	go func() {
@@ -205,6 +328,20 @@ func main() {
 		}
 	}
 
+	// Shutdown admin listener
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			slog.Error("Admin listener forced to shutdown", "error", err)
+		}
+	}
+
+	// Shutdown CoAP gateway
+	if coapServer != nil {
+		if err := coapServer.Shutdown(ctx); err != nil {
+			slog.Error("CoAP gateway forced to shutdown", "error", err)
+		}
+	}
+
 	// Attempt graceful shutdown
 	if err := server.Shutdown(ctx); err != nil {
 		slog.Error("Server forced to shutdown", "error", err)
@@ -213,6 +350,119 @@ func main() {
 	slog.Info("Server shutdown complete", "elapsed", time.Since(start))
 }
 
+// newEventBus creates the bus application, release and key changes are
+// published to, with changes logged at debug level.
+func newEventBus() *events.Bus {
+	bus := events.NewBus()
+	bus.Subscribe(func(e events.Event) {
+		slog.Debug("Change published", "type", e.Type, "app_id", e.ApplicationID, "release_id", e.ReleaseID, "key_id", e.KeyID)
+	})
+	return bus
+}
+
+// newUpdateService creates the update service over store with the features
+// cfg enables, publishing its changes to bus.
+func newUpdateService(cfg *models.Config, store storage.Storage, bus *events.Bus) (*update.Service, error) {
+	serviceOpts := []update.ServiceOption{
+		update.WithEventBus(bus),
+		update.WithApplicationTemplates(cfg.ApplicationTemplates),
+		update.WithDownloadURLPolicy(cfg.Security.DownloadURLs),
+		update.WithRejectWeakChecksums(cfg.Security.RejectWeakChecksums),
+	}
+	if cfg.Observability.DecisionLog.Enabled {
+		serviceOpts = append(serviceOpts, update.WithDecisionLog(update.NewDecisionLog(cfg.Observability.DecisionLog.Size)))
+	}
+	entitlements, err := entitlement.New(cfg.Entitlements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entitlement provider %q: %w", cfg.Entitlements.Provider, err)
+	}
+	if entitlements != nil {
+		serviceOpts = append(serviceOpts, update.WithEntitlementProvider(entitlements))
+	}
+	if cfg.AutoFill.Enabled {
+		serviceOpts = append(serviceOpts, update.WithArtifactFetcher(artifact.New(cfg.AutoFill)))
+	}
+	if cfg.NotesDrafts.Enabled {
+		serviceOpts = append(serviceOpts, update.WithNotesDrafter(notesdraft.New(cfg.NotesDrafts)))
+	}
+	if cfg.Artifacts.Enabled {
+		artifacts, err := blob.New(cfg.Artifacts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s artifact store: %w", cfg.Artifacts.Driver, err)
+		}
+		serviceOpts = append(serviceOpts, update.WithArtifactStore(artifacts, cfg.Artifacts.MaxSize))
+	}
+	if cfg.Storage.IDStrategy != "" {
+		newID, err := models.NewIDGenerator(cfg.Storage.IDStrategy)
+		if err != nil {
+			return nil, err
+		}
+		serviceOpts = append(serviceOpts, update.WithIDGenerator(newID))
+	}
+	return update.NewService(store, serviceOpts...), nil
+}
+
+// newHandlerOptions returns the handler options cfg enables, other than the
+// observability ones, which need the running telemetry providers. Key changes
+// made through the admin endpoints are published to bus.
+func newHandlerOptions(cfg *models.Config, store storage.Storage, bus *events.Bus, versionInfo version.Info) ([]api.HandlersOption, error) {
+	handlerOpts := []api.HandlersOption{
+		api.WithStorage(store),
+		api.WithKeyService(update.NewKeyManager(store, update.WithKeyEventBus(bus))),
+		api.WithVersionInfo(versionInfo),
+	}
+	if path := cfg.Security.PGPPublicKeyFile; path != "" {
+		key, err := os.ReadFile(path)
+		if err == nil {
+			err = models.ValidatePGPPublicKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load PGP public key %s: %w", path, err)
+		}
+		handlerOpts = append(handlerOpts, api.WithPGPPublicKey(key))
+	}
+	if cfg.Security.ClientTokens.Enabled {
+		if cfg.Security.ClientTokens.Secret == "" {
+			slog.Warn("No client token secret configured; tokens will not survive a restart or work across replicas")
+		}
+		issuer, err := clienttoken.New(cfg.Security.ClientTokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client token issuer: %w", err)
+		}
+		handlerOpts = append(handlerOpts, api.WithClientTokens(issuer))
+	}
+	if cfg.ClientBundle.Enabled {
+		keys, err := clientbundle.LoadSigningKeys(context.Background(), cfg.ClientBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client bundle signing key %w", err)
+		}
+		signer, err := clientbundle.New(context.Background(), cfg.ClientBundle, keys)
+		if err == nil {
+			_, err = signer.PublicKey()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client bundle signer: %w", err)
+		}
+		handlerOpts = append(handlerOpts, api.WithClientBundle(signer))
+	}
+	if cfg.Security.EnableAuth && cfg.Security.Credentials.QueryToken.Enabled {
+		handlerOpts = append(handlerOpts, api.WithQueryTokens(cfg.Security.Credentials.QueryToken))
+	}
+	if cfg.Artifacts.Enabled {
+		// Artifacts on the server's disk are downloaded from the server itself
+		var files http.Handler
+		if cfg.Artifacts.Driver == models.ArtifactDriverLocal {
+			local, err := blob.NewLocal(cfg.Artifacts.Local.Path, cfg.Artifacts.PublicURL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open artifact directory: %w", err)
+			}
+			files = local
+		}
+		handlerOpts = append(handlerOpts, api.WithArtifacts(cfg.Artifacts, files))
+	}
+	return handlerOpts, nil
+}
+
 // initializeStorage creates and returns a storage instance based on configuration
 func initializeStorage(cfg *models.Config) (storage.Storage, error) {
 	switch cfg.Storage.Type {
@@ -227,6 +477,28 @@ func initializeStorage(cfg *models.Config) (storage.Storage, error) {
 	}
 }
 
+// listenAdmin opens the admin listener's Unix socket, or its TCP address when
+// no socket is configured. A socket file left by an earlier process is
+// replaced.
+func listenAdmin(admin models.AdminListenerConfig) (net.Listener, error) {
+	if admin.Socket == "" {
+		return net.Listen("tcp", fmt.Sprintf("%s:%d", admin.Host, admin.Port))
+	}
+	if err := os.Remove(admin.Socket); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("remove stale admin socket: %w", err)
+	}
+	listener, err := net.Listen("unix", admin.Socket)
+	if err != nil {
+		return nil, err
+	}
+	// Only the service's user and group may connect
+	if err := os.Chmod(admin.Socket, 0660); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("restrict admin socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
 // printValidateResults writes validation check results to stdout in the
 // requested format. Unrecognised formats fall back to text.
 func printValidateResults(results []config.CheckResult, format string) {
@@ -251,6 +523,20 @@ func printValidateResults(results []config.CheckResult, format string) {
 	}
 }
 
+// applySeed creates the fixtures in path that do not exist yet.
+func applySeed(ctx context.Context, path string, service update.ServiceInterface, store storage.Storage) error {
+	fixtures, err := seed.Load(path)
+	if err != nil {
+		return err
+	}
+	result, err := seed.New(service, store).Apply(ctx, fixtures)
+	if err != nil {
+		return err
+	}
+	slog.Info("Fixtures seeded", "file", path, "created", result.Created, "existed", result.Existed)
+	return nil
+}
+
 // seedBootstrapKey inserts the configured bootstrap key into storage if it
 // does not already exist. It is a no-op when BootstrapKey is empty.
 func seedBootstrapKey(ctx context.Context, store storage.Storage, cfg *models.Config) error {
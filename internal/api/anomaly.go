@@ -0,0 +1,236 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"updater/internal/models"
+	"updater/internal/observability"
+	"updater/internal/update"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxTrackedChecks bounds the distinct checks remembered per client and
+// window, so a client varying its query cannot grow the detector's memory.
+const maxTrackedChecks = 1024
+
+// maxTrackedClients bounds the client IPs the detector remembers, so traffic
+// from many addresses cannot grow its memory either. Addresses seen while it
+// is full are not watched until idle ones are pruned.
+const maxTrackedClients = 100000
+
+// anomalyDetector temporarily blocks client IPs whose update checks no
+// installed application would send: checks for unknown applications or decoy
+// IDs, versions newer than any release, and the same check over and over.
+// Counts are kept per replica in fixed windows.
+type anomalyDetector struct {
+	cfg       models.AnomalyDetectionConfig
+	honeypots map[string]bool
+	metrics   *observability.AppMetrics
+	now       func() time.Time
+
+	mu         sync.Mutex
+	clients    map[string]*clientActivity
+	maxClients int
+	lastPruned time.Time
+}
+
+// clientActivity is what the detector knows about one client IP.
+type clientActivity struct {
+	windowStart  time.Time
+	anomalies    map[string]int // Anomaly kind to count in the window
+	checks       map[string]int // Check fingerprint to count in the window
+	blockedUntil time.Time
+}
+
+func newAnomalyDetector(cfg models.AnomalyDetectionConfig, metrics *observability.AppMetrics) *anomalyDetector {
+	honeypots := make(map[string]bool, len(cfg.HoneypotAppIDs))
+	for _, id := range cfg.HoneypotAppIDs {
+		honeypots[id] = true
+	}
+	return &anomalyDetector{
+		cfg:        cfg,
+		honeypots:  honeypots,
+		metrics:    metrics,
+		now:        time.Now,
+		clients:    make(map[string]*clientActivity),
+		maxClients: maxTrackedClients,
+	}
+}
+
+// Middleware answers checks from blocked clients with 403 and a Retry-After
+// header, and watches the rest. Requests with an API key are not watched; with
+// auth enabled, dry runs take the support-only routes and never get here.
+func (d *anomalyDetector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetAPIKey(r) != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip := getClientIP(r)
+		if until, blocked := d.checkRepeat(r, ip, checkFingerprint(r)); blocked {
+			writeBlockedResponse(w, until.Sub(d.now()))
+			return
+		}
+
+		// The service reports unknown applications and future versions while
+		// it answers the check; each is counted once per request.
+		seen := make(map[string]bool)
+		ctx := update.WithAnomalyReporter(r.Context(), func(kind, appID string) {
+			if d.honeypots[appID] {
+				kind = models.AnomalyHoneypot
+			}
+			d.report(r, ip, seen, kind, appID)
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// checkRepeat counts a check and reports whether its client is blocked,
+// blocking it when it has sent the same check too often.
+func (d *anomalyDetector) checkRepeat(r *http.Request, ip, fingerprint string) (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	client := d.activity(ip)
+	if d.now().Before(client.blockedUntil) {
+		return client.blockedUntil, true
+	}
+	if d.cfg.RepeatedCheckLimit == 0 || fingerprint == "" {
+		return time.Time{}, false
+	}
+	if _, ok := client.checks[fingerprint]; !ok && len(client.checks) >= maxTrackedChecks {
+		return time.Time{}, false
+	}
+	client.checks[fingerprint]++
+	if n := client.checks[fingerprint]; n > d.cfg.RepeatedCheckLimit {
+		d.block(r, ip, client, models.AnomalyRepeatedCheck, "", n)
+		return client.blockedUntil, true
+	}
+	return time.Time{}, false
+}
+
+// report counts an anomaly, blocking the client when it is over the kind's
+// limit or hit a honeypot.
+func (d *anomalyDetector) report(r *http.Request, ip string, seen map[string]bool, kind, appID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := kind + "/" + appID
+	if seen[key] {
+		return
+	}
+	seen[key] = true
+
+	client := d.activity(ip)
+	if d.now().Before(client.blockedUntil) {
+		return
+	}
+	client.anomalies[kind]++
+	n := client.anomalies[kind]
+
+	var limit int
+	switch kind {
+	case models.AnomalyHoneypot:
+		d.block(r, ip, client, kind, appID, n)
+		return
+	case models.AnomalyUnknownApplication:
+		limit = d.cfg.UnknownApplicationLimit
+	case models.AnomalyFutureVersion:
+		limit = d.cfg.FutureVersionLimit
+	}
+	if limit > 0 && n > limit {
+		d.block(r, ip, client, kind, appID, n)
+	}
+}
+
+// block blocks a client for the configured duration and records why.
+func (d *anomalyDetector) block(r *http.Request, ip string, client *clientActivity, reason, appID string, count int) {
+	client.blockedUntil = d.now().Add(d.cfg.BlockDuration)
+	slog.Warn("Client blocked for suspicious check traffic",
+		"event", "security_audit",
+		"client_ip", ip,
+		"reason", reason,
+		"app_id", appID,
+		"count", count,
+		"blocked_until", client.blockedUntil)
+	if d.metrics != nil {
+		d.metrics.ClientsBlocked.Add(r.Context(), 1, metric.WithAttributes(attribute.String("reason", reason)))
+	}
+}
+
+// activity returns ip's activity in the current window, starting a new window
+// when the last one has ended. When the detector tracks as many clients as it
+// may, a new client gets activity that is not kept. Callers hold d.mu.
+func (d *anomalyDetector) activity(ip string) *clientActivity {
+	now := d.now()
+	if now.Sub(d.lastPruned) >= d.cfg.Window {
+		for key, client := range d.clients {
+			if now.Sub(client.windowStart) >= d.cfg.Window && !now.Before(client.blockedUntil) {
+				delete(d.clients, key)
+			}
+		}
+		d.lastPruned = now
+	}
+
+	client, ok := d.clients[ip]
+	if !ok {
+		client = &clientActivity{}
+		if len(d.clients) < d.maxClients {
+			d.clients[ip] = client
+		}
+	}
+	if now.Sub(client.windowStart) >= d.cfg.Window {
+		client.windowStart = now
+		client.anomalies = make(map[string]int)
+		client.checks = make(map[string]int)
+	}
+	return client
+}
+
+// checkFingerprint identifies a check by its method, path, query and body. The
+// body is read and put back for the handler; when it cannot be read the
+// handler sees the same error and the check is not fingerprinted.
+func checkFingerprint(r *http.Request) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+	if r.Method == http.MethodPost && r.Body != nil {
+		body, err := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err}))
+		if err != nil {
+			return ""
+		}
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// errorReader returns err, or io.EOF when err is nil.
+type errorReader struct{ err error }
+
+func (e errorReader) Read([]byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	return 0, io.EOF
+}
+
+// writeBlockedResponse answers a check from a blocked client.
+func writeBlockedResponse(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.WriteHeader(http.StatusForbidden)
+	errorResp := models.NewErrorResponse("Client temporarily blocked for suspicious traffic", models.ErrorCodeForbidden)
+	json.NewEncoder(w).Encode(errorResp)
+}
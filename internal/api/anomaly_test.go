@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAnomalyTestRouter(t *testing.T, cfg models.AnomalyDetectionConfig) http.Handler {
+	t.Helper()
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "real-app", "Real App")
+	cfg.Enabled = true
+	return SetupRoutes(h, &models.Config{Security: models.SecurityConfig{AnomalyDetection: cfg}})
+}
+
+func checkFrom(router http.Handler, ip, method, target, body string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body != "" {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	req.RemoteAddr = ip
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestAnomalyDetector_UnknownApplications(t *testing.T) {
+	router := newAnomalyTestRouter(t, models.AnomalyDetectionConfig{
+		Window: time.Minute, BlockDuration: 10 * time.Minute, UnknownApplicationLimit: 2,
+	})
+	const query = "/check?current_version=1.0.0&platform=linux&architecture=amd64"
+
+	for i, app := range []string{"nope-1", "nope-2", "nope-3"} {
+		rec := checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/updates/"+app+query, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code, "check %d is answered", i)
+	}
+
+	rec := checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/updates/real-app"+query, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, "the client is blocked after exceeding the limit")
+	assert.Equal(t, "600", rec.Header().Get("Retry-After"))
+
+	rec = checkFrom(router, "198.51.100.8", http.MethodGet, "/api/v1/updates/nope-1"+query, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code, "other clients are not blocked")
+}
+
+func TestAnomalyDetector_DryRunIsWatched(t *testing.T) {
+	router := newAnomalyTestRouter(t, models.AnomalyDetectionConfig{
+		Window: time.Minute, BlockDuration: time.Minute, HoneypotAppIDs: []string{"internal-tools"},
+	})
+	const query = "/check?current_version=1.0.0&platform=linux&architecture=amd64&dry_run=true"
+
+	rec := checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/updates/internal-tools"+query, "")
+	assert.NotEqual(t, http.StatusForbidden, rec.Code)
+
+	rec = checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/updates/real-app"+query, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, "a dry run does not get a blocked client past the block")
+}
+
+func TestAnomalyDetector_Honeypot(t *testing.T) {
+	router := newAnomalyTestRouter(t, models.AnomalyDetectionConfig{
+		Window: time.Minute, BlockDuration: time.Minute, HoneypotAppIDs: []string{"internal-tools"},
+	})
+
+	rec := checkFrom(router, "198.51.100.7", http.MethodPost, "/api/v1/check",
+		`{"application_id":"internal-tools","current_version":"1.0.0","platform":"linux","architecture":"amd64"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/latest?app_id=real-app&platform=linux&architecture=amd64", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, "one honeypot hit blocks the client")
+}
+
+func TestAnomalyDetector_RepeatedChecks(t *testing.T) {
+	router := newAnomalyTestRouter(t, models.AnomalyDetectionConfig{
+		Window: time.Minute, BlockDuration: time.Minute, RepeatedCheckLimit: 2,
+	})
+	const body = `{"application_id":"real-app","current_version":"1.0.0","platform":"linux","architecture":"amd64"}`
+
+	for i := 0; i < 2; i++ {
+		rec := checkFrom(router, "198.51.100.7", http.MethodPost, "/api/v1/check", body)
+		assert.NotEqual(t, http.StatusForbidden, rec.Code)
+		assert.NotEqual(t, http.StatusBadRequest, rec.Code, "the handler still reads the body")
+	}
+	rec := checkFrom(router, "198.51.100.7", http.MethodPost, "/api/v1/check",
+		strings.Replace(body, "1.0.0", "1.0.1", 1))
+	assert.NotEqual(t, http.StatusForbidden, rec.Code, "a different check is counted separately")
+
+	rec = checkFrom(router, "198.51.100.7", http.MethodPost, "/api/v1/check", body)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestAnomalyDetector_BlockExpires(t *testing.T) {
+	detector := newAnomalyDetector(models.AnomalyDetectionConfig{
+		Enabled: true, Window: time.Minute, BlockDuration: 5 * time.Minute, RepeatedCheckLimit: 1,
+	}, nil)
+	now := time.Now()
+	detector.now = func() time.Time { return now }
+	handler := detector.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	check := func() int {
+		return checkFrom(handler, "198.51.100.7", http.MethodGet, "/api/v1/latest?app_id=real-app", "").Code
+	}
+	assert.Equal(t, http.StatusOK, check())
+	assert.Equal(t, http.StatusForbidden, check())
+
+	now = now.Add(5*time.Minute + time.Second)
+	assert.Equal(t, http.StatusOK, check(), "the block lifts and a new window starts")
+
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	require.Len(t, detector.clients, 1, "idle clients are pruned")
+}
+
+func TestAnomalyDetector_ClientLimit(t *testing.T) {
+	detector := newAnomalyDetector(models.AnomalyDetectionConfig{
+		Enabled: true, Window: time.Minute, BlockDuration: 5 * time.Minute, RepeatedCheckLimit: 1,
+	}, nil)
+	detector.maxClients = 1
+	handler := detector.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	check := func(ip string) int {
+		return checkFrom(handler, ip, http.MethodGet, "/api/v1/latest?app_id=real-app", "").Code
+	}
+	assert.Equal(t, http.StatusOK, check("198.51.100.7"))
+	assert.Equal(t, http.StatusForbidden, check("198.51.100.7"))
+
+	assert.Equal(t, http.StatusOK, check("198.51.100.8"))
+	assert.Equal(t, http.StatusOK, check("198.51.100.8"), "clients beyond the limit are not watched")
+
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	assert.Len(t, detector.clients, 1)
+}
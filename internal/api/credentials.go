@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// errInvalidQueryToken means a query token was not signed by this service or
+// has expired.
+var errInvalidQueryToken = errors.New("invalid or expired query token")
+
+// queryTokenSigner issues and verifies signed API key tokens for clients that
+// can only put credentials in the URL. A token names the key by ID and carries
+// its expiry, so a URL that leaks stops working on its own and never reveals
+// the key itself.
+type queryTokenSigner struct {
+	param  string
+	key    []byte
+	maxTTL time.Duration
+	now    func() time.Time
+}
+
+func newQueryTokenSigner(cfg models.QueryTokenConfig) *queryTokenSigner {
+	param := cfg.Param
+	if param == "" {
+		param = models.DefaultQueryTokenParam
+	}
+	return &queryTokenSigner{param: param, key: []byte(cfg.Secret), maxTTL: cfg.MaxTTL, now: time.Now}
+}
+
+// WithQueryTokens enables signed API key tokens in a query parameter and the
+// endpoint that issues them.
+func WithQueryTokens(cfg models.QueryTokenConfig) HandlersOption {
+	return func(h *Handlers) { h.queryTokens = newQueryTokenSigner(cfg) }
+}
+
+// sign returns a token for keyID valid for ttl, capped at the maximum TTL.
+func (s *queryTokenSigner) sign(keyID string, ttl time.Duration) (string, time.Time) {
+	if ttl <= 0 || ttl > s.maxTTL {
+		ttl = s.maxTTL
+	}
+	expiresAt := s.now().Add(ttl).Truncate(time.Second)
+	payload := keyID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.mac(payload), expiresAt.UTC()
+}
+
+// verify returns the key ID of a token that is validly signed and unexpired.
+func (s *queryTokenSigner) verify(token string) (string, error) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return "", errInvalidQueryToken
+	}
+	payload, sig := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(s.mac(payload))) {
+		return "", errInvalidQueryToken
+	}
+	keyID, expiry, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", errInvalidQueryToken
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !s.now().Before(time.Unix(unix, 0)) {
+		return "", errInvalidQueryToken
+	}
+	return keyID, nil
+}
+
+func (s *queryTokenSigner) mac(payload string) string {
+	m := hmac.New(sha256.New, s.key)
+	m.Write([]byte("query-token:" + payload))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil))
+}
+
+// altCredentialKey is the context key of the credential taken from an
+// alternative location by credentialExtractor.
+type altCredentialKey struct{}
+
+// altCredential is an API key credential from outside the Authorization
+// header: a raw key from the custom header, or the key ID of a verified query
+// token. err is set when a query token was present but did not verify.
+type altCredential struct {
+	source string
+	rawKey string
+	keyID  string
+	err    error
+}
+
+// credentialExtractor moves API key credentials found outside the
+// Authorization header into the request context, removing them from the
+// request so that no later middleware, access log or trace records them. It
+// runs ahead of every other middleware.
+func credentialExtractor(header string, tokens *queryTokenSigner) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var cred *altCredential
+			if header != "" {
+				if key := r.Header.Get(header); key != "" {
+					cred = &altCredential{source: "header", rawKey: key}
+				}
+				r.Header.Del(header)
+			}
+			if tokens != nil && strings.Contains(r.URL.RawQuery, tokens.param) {
+				query := r.URL.Query()
+				if query.Has(tokens.param) {
+					token := query.Get(tokens.param)
+					query.Del(tokens.param)
+					r = stripQuery(r, query)
+					if cred == nil {
+						keyID, err := tokens.verify(token)
+						cred = &altCredential{source: "query", keyID: keyID, err: err}
+					}
+				}
+			}
+			if cred != nil {
+				r = r.WithContext(context.WithValue(r.Context(), altCredentialKey{}, cred))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// stripQuery returns a copy of r whose URL carries query instead of its
+// original query string.
+func stripQuery(r *http.Request, query url.Values) *http.Request {
+	r2 := r.Clone(r.Context())
+	r2.URL.RawQuery = query.Encode()
+	r2.RequestURI = r2.URL.RequestURI()
+	return r2
+}
+
+// authenticateRequest returns the enabled API key presented by r, from the
+// Authorization header or an alternative location, or the reason there is
+// none. A request without any credential gets neither.
+func authenticateRequest(r *http.Request, store storage.Storage) (*models.APIKey, string) {
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		const prefix = "Bearer "
+		if !strings.HasPrefix(authHeader, prefix) {
+			return nil, "Invalid authorization format"
+		}
+		return lookupKeyByRaw(r, store, authHeader[len(prefix):])
+	}
+
+	cred, _ := r.Context().Value(altCredentialKey{}).(*altCredential)
+	switch {
+	case cred == nil:
+		return nil, ""
+	case cred.rawKey != "":
+		return lookupKeyByRaw(r, store, cred.rawKey)
+	case cred.err != nil:
+		return nil, "Invalid API key"
+	}
+
+	// Tokens name the key by ID; the signature has already been checked, so
+	// only genuine tokens reach storage
+	keys, err := store.ListAPIKeys(r.Context())
+	if err != nil {
+		return nil, "Invalid API key"
+	}
+	for _, k := range keys {
+		if k.ID == cred.keyID && k.Enabled {
+			return k, ""
+		}
+	}
+	return nil, "Invalid API key"
+}
+
+func lookupKeyByRaw(r *http.Request, store storage.Storage, rawKey string) (*models.APIKey, string) {
+	key, err := store.GetAPIKeyByHash(r.Context(), models.HashAPIKey(rawKey))
+	if err != nil || !key.Enabled {
+		return nil, "Invalid API key"
+	}
+	return key, ""
+}
+
+// logRejectedCredential records an alternative credential that did not
+// authenticate, naming where it came from but never the credential itself.
+func logRejectedCredential(r *http.Request) {
+	cred, _ := r.Context().Value(altCredentialKey{}).(*altCredential)
+	if cred == nil || r.Header.Get("Authorization") != "" {
+		return
+	}
+	reason := "unknown or disabled key"
+	if cred.err != nil {
+		reason = cred.err.Error()
+	}
+	slog.Warn("Rejected API key credential",
+		"event", "security_audit",
+		"source", cred.source,
+		"key_id", cred.keyID,
+		"reason", reason,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"client_ip", getClientIP(r))
+}
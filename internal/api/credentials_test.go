@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testQueryTokenSecret = "0123456789abcdef0123456789abcdef"
+
+func TestQueryTokenSigner(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := newQueryTokenSigner(models.QueryTokenConfig{Secret: testQueryTokenSecret, MaxTTL: time.Hour})
+	signer.now = func() time.Time { return now }
+
+	token, expiresAt := signer.sign("key-1", 0)
+	assert.Equal(t, now.Add(time.Hour), expiresAt, "zero TTL uses the maximum")
+	keyID, err := signer.verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", keyID)
+
+	_, expiresAt = signer.sign("key-1", 48*time.Hour)
+	assert.Equal(t, now.Add(time.Hour), expiresAt, "TTL is capped at the maximum")
+
+	_, err = signer.verify(strings.Replace(token, "key-1", "key-2", 1))
+	assert.ErrorIs(t, err, errInvalidQueryToken, "tampered key ID")
+	_, err = signer.verify("not-a-token")
+	assert.ErrorIs(t, err, errInvalidQueryToken)
+
+	other := newQueryTokenSigner(models.QueryTokenConfig{Secret: strings.Repeat("x", 32), MaxTTL: time.Hour})
+	_, err = other.verify(token)
+	assert.ErrorIs(t, err, errInvalidQueryToken, "signed with another secret")
+
+	now = now.Add(time.Hour)
+	_, err = signer.verify(token)
+	assert.ErrorIs(t, err, errInvalidQueryToken, "expired")
+}
+
+func TestCredentialExtractorRedacts(t *testing.T) {
+	signer := newQueryTokenSigner(models.QueryTokenConfig{Secret: testQueryTokenSecret, MaxTTL: time.Hour})
+	token, _ := signer.sign("key-1", time.Minute)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/app/releases?limit=5&access_token="+token, nil)
+	req.Header.Set("X-API-Key", "raw-key")
+
+	var seen *http.Request
+	credentialExtractor("X-API-Key", signer)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seen = r
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, seen)
+	assert.Empty(t, seen.Header.Get("X-API-Key"))
+	assert.Equal(t, "limit=5", seen.URL.RawQuery)
+	assert.NotContains(t, seen.RequestURI, token)
+	cred, _ := seen.Context().Value(altCredentialKey{}).(*altCredential)
+	require.NotNil(t, cred)
+	assert.Equal(t, "raw-key", cred.rawKey, "the header wins over the query token")
+}
+
+func TestAlternativeCredentials(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	admin := models.NewAPIKey(models.NewKeyID(), "Admin", "admin-key", []string{"admin"})
+	reader := models.NewAPIKey(models.NewKeyID(), "Legacy", "legacy-key", []string{"read"})
+	disabled := models.NewAPIKey(models.NewKeyID(), "Disabled", "disabled-key", []string{"read"})
+	disabled.Enabled = false
+	for _, k := range []*models.APIKey{admin, reader, disabled} {
+		require.NoError(t, store.CreateAPIKey(context.Background(), k))
+	}
+
+	mockService := &MockUpdateService{}
+	mockService.On("ListReleases", mock.Anything, mock.Anything).Return(&models.ListReleasesResponse{}, nil).Maybe()
+
+	credentials := models.CredentialsConfig{
+		Header:     "X-API-Key",
+		QueryToken: models.QueryTokenConfig{Enabled: true, Param: "access_token", Secret: testQueryTokenSecret, MaxTTL: time.Hour},
+	}
+	config := &models.Config{Security: models.SecurityConfig{EnableAuth: true, BootstrapKey: "upd_test-bootstrap", Credentials: credentials}}
+	router := SetupRoutes(NewHandlers(mockService, WithStorage(store), WithQueryTokens(credentials.QueryToken)), config)
+
+	issue := func(t *testing.T, keyID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/keys/"+keyID+"/query-token", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-key")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := issue(t, reader.ID, `{"ttl_seconds":600}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var issued models.QueryTokenResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &issued))
+	assert.Equal(t, "access_token", issued.Param)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), issued.ExpiresAt, 5*time.Second)
+
+	assert.Equal(t, http.StatusCreated, issue(t, reader.ID, "").Code, "body is optional")
+	assert.Equal(t, http.StatusNotFound, issue(t, "missing", "").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, issue(t, disabled.ID, "").Code)
+	disabledToken, _ := newQueryTokenSigner(credentials.QueryToken).sign(disabled.ID, time.Minute)
+
+	tests := []struct {
+		name     string
+		header   string
+		query    string
+		wantCode int
+	}{
+		{name: "custom header", header: "legacy-key", wantCode: http.StatusOK},
+		{name: "custom header with unknown key", header: "nope", wantCode: http.StatusUnauthorized},
+		{name: "query token", query: "access_token=" + issued.Token, wantCode: http.StatusOK},
+		{name: "tampered query token", query: "access_token=" + issued.Token + "x", wantCode: http.StatusUnauthorized},
+		{name: "query token of disabled key", query: "access_token=" + disabledToken, wantCode: http.StatusUnauthorized},
+		{name: "raw key in query is not accepted", query: "access_token=legacy-key", wantCode: http.StatusUnauthorized},
+		{name: "no credentials", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/releases?"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("X-API-Key", tt.header)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+}
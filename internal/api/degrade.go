@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"updater/internal/models"
+	"updater/internal/observability"
+)
+
+// maxStaleBody bounds the size of a response kept for degraded mode; larger
+// responses are not cached.
+const maxStaleBody = 64 << 10
+
+// staleRoutes are the latest-version routes degraded mode may answer from
+// cache. Their responses depend only on the host, the URL and the Accept
+// header, unlike update checks, which depend on rollout state.
+var staleRoutes = map[string]bool{
+	"/api/v1/updates/{app_id}/latest": true,
+	"/api/v1/latest":                  true,
+	"/latest":                         true, // Vanity host endpoint
+}
+
+// staleCache keeps the last good response of each latest-version request and
+// serves it while the route's SLO tracker reports it degraded.
+type staleCache struct {
+	slo        *observability.SLOTracker
+	staleFor   time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*staleEntry
+}
+
+// staleEntry is one cached response.
+type staleEntry struct {
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+func newStaleCache(cfg models.SLODegradeConfig, slo *observability.SLOTracker) *staleCache {
+	return &staleCache{
+		slo:        slo,
+		staleFor:   cfg.StaleFor,
+		maxEntries: cfg.MaxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*staleEntry),
+	}
+}
+
+// Middleware answers latest-version requests from cache while their route is
+// degraded and caches the successful responses of the others. Requests
+// carrying credentials or a license token are never cached because their
+// response may differ per caller. It runs after the client token check, so a
+// cached response is only served to clients allowed to ask.
+func (c *staleCache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routePath(r)
+		if r.Method != http.MethodGet || !staleRoutes[route] ||
+			r.Header.Get("Authorization") != "" || r.Header.Get(licenseTokenHeader) != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := r.Host + " " + r.URL.RequestURI() + " " + r.Header.Get("Accept")
+		if c.slo.Degraded(r.Method, route) && c.serve(w, key) {
+			observability.ExcludeFromSLO(r)
+			return
+		}
+
+		cw := &capturingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+		if cw.status == http.StatusOK && !cw.overflow {
+			c.store(key, w.Header(), cw.body.Bytes())
+		}
+	})
+}
+
+// serve writes the cached response for key, with an Age header and
+// X-Stale, and reports whether one younger than staleFor was found.
+func (c *staleCache) serve(w http.ResponseWriter, key string) bool {
+	c.mu.Lock()
+	entry := c.entries[key]
+	c.mu.Unlock()
+	if entry == nil {
+		return false
+	}
+	age := c.now().Sub(entry.storedAt)
+	if age > c.staleFor {
+		return false
+	}
+	for name, values := range entry.header {
+		if w.Header().Get(name) == "" {
+			w.Header()[name] = values
+		}
+	}
+	w.Header().Set("Age", strconv.Itoa(int(age.Seconds())))
+	w.Header().Set("X-Stale", "true")
+	w.WriteHeader(http.StatusOK)
+	w.Write(entry.body)
+	return true
+}
+
+// store caches a response, evicting the oldest entry when the cache is full.
+func (c *staleCache) store(key string, header http.Header, body []byte) {
+	entry := &staleEntry{header: header.Clone(), body: bytes.Clone(body), storedAt: c.now()}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		var oldest string
+		for k, e := range c.entries {
+			if oldest == "" || e.storedAt.Before(c.entries[oldest].storedAt) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = entry
+}
+
+// capturingWriter copies the response it writes, up to maxStaleBody.
+type capturingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	overflow    bool
+}
+
+func (cw *capturingWriter) WriteHeader(code int) {
+	if !cw.wroteHeader {
+		cw.status = code
+		cw.wroteHeader = true
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *capturingWriter) Write(b []byte) (int, error) {
+	cw.wroteHeader = true
+	if !cw.overflow {
+		if cw.body.Len()+len(b) > maxStaleBody {
+			cw.overflow = true
+			cw.body.Reset()
+		} else {
+			cw.body.Write(b)
+		}
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (cw *capturingWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/observability"
+	"updater/internal/update"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleCache_ServesLatestWhileDegraded(t *testing.T) {
+	config := models.NewDefaultConfig()
+	config.Security.EnableAuth = false
+	config.Server.SLO = models.SLOConfig{
+		Enabled:     true,
+		Window:      time.Minute,
+		MinRequests: 3,
+		Routes: []models.RouteSLO{
+			{Route: "/api/v1/updates/{app_id}/latest", Method: http.MethodGet, Latency: time.Minute, Objective: 0.5},
+		},
+		Degrade: models.SLODegradeConfig{Enabled: true, BurnRate: 1, StaleFor: time.Minute, MaxEntries: 10},
+	}
+	tracker, err := observability.NewSLOTracker(config.Server.SLO)
+	require.NoError(t, err)
+	defer tracker.Close()
+
+	mockService := &MockUpdateService{}
+	mockService.On("GetLatestVersion", mock.Anything, mock.Anything).
+		Return(&models.LatestVersionResponse{Version: "2.0.0"}, nil).Once()
+	mockService.On("GetLatestVersion", mock.Anything, mock.Anything).
+		Return(nil, update.NewInternalError("storage timeout", errors.New("deadline exceeded")))
+	router := SetupRoutes(NewHandlers(mockService, WithSLOTracker(tracker)), config)
+
+	latestURL := "/api/v1/updates/app/latest?platform=windows&architecture=amd64"
+	get := func(target string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get(latestURL, nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("X-Stale"))
+
+	// Two failures in three requests burn a 50% budget too fast
+	for range 2 {
+		assert.Equal(t, http.StatusInternalServerError, get(latestURL, nil).Code)
+	}
+	require.True(t, tracker.Degraded(http.MethodGet, "/api/v1/updates/{app_id}/latest"))
+
+	rr = get(latestURL, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "true", rr.Header().Get("X-Stale"))
+	assert.Equal(t, "0", rr.Header().Get("Age"))
+	assert.Contains(t, rr.Body.String(), `"version":"2.0.0"`)
+	assert.NotEmpty(t, rr.Header().Get(requestIDHeader))
+
+	assert.Equal(t, http.StatusInternalServerError, get(latestURL+"&edition=pro", nil).Code, "other queries are not cached")
+	assert.Equal(t, http.StatusInternalServerError, get(latestURL, map[string]string{licenseTokenHeader: "token"}).Code,
+		"licensed lookups are never served from cache")
+	mockService.AssertNumberOfCalls(t, "GetLatestVersion", 5)
+}
+
+func TestStaleCache_StaleForAndEviction(t *testing.T) {
+	now := time.Now()
+	cache := newStaleCache(models.SLODegradeConfig{StaleFor: time.Minute, MaxEntries: 2}, nil)
+	cache.now = func() time.Time { return now }
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	cache.store("a", header, []byte(`{"version":"1.0.0"}`))
+	now = now.Add(time.Second)
+	cache.store("b", header, []byte(`{"version":"1.0.0"}`))
+	now = now.Add(time.Second)
+	cache.store("c", header, []byte(`{"version":"1.0.0"}`))
+	assert.Len(t, cache.entries, 2)
+	assert.NotContains(t, cache.entries, "a", "the oldest entry is evicted")
+
+	rr := httptest.NewRecorder()
+	require.True(t, cache.serve(rr, "b"))
+	assert.Equal(t, "1", rr.Header().Get("Age"))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	now = now.Add(2 * time.Minute)
+	assert.False(t, cache.serve(httptest.NewRecorder(), "b"), "entries older than stale_for are not served")
+}
+
+func TestCapturingWriter_Overflow(t *testing.T) {
+	cw := &capturingWriter{ResponseWriter: httptest.NewRecorder(), status: http.StatusOK}
+	_, _ = cw.Write(make([]byte, maxStaleBody))
+	assert.False(t, cw.overflow)
+	_, _ = cw.Write([]byte("x"))
+	assert.True(t, cw.overflow)
+	assert.Zero(t, cw.body.Len())
+}
@@ -0,0 +1,84 @@
+package encoding
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// CBOR major types and simple values (RFC 8949 section 3).
+const (
+	cborUnsigned = 0
+	cborNegative = 1
+	cborText     = 3
+	cborArray    = 4
+	cborMap      = 5
+	cborFalse    = 0xf4
+	cborTrue     = 0xf5
+	cborNull     = 0xf6
+	cborFloat64  = 0xfb
+)
+
+// appendCBOR appends the CBOR encoding of a value-model value. Maps and arrays
+// use definite lengths.
+func appendCBOR(buf []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, cborNull), nil
+	case bool:
+		if v {
+			return append(buf, cborTrue), nil
+		}
+		return append(buf, cborFalse), nil
+	case string:
+		buf = appendCBORHead(buf, cborText, uint64(len(v)))
+		return append(buf, v...), nil
+	case int64:
+		if v < 0 {
+			return appendCBORHead(buf, cborNegative, uint64(-(v + 1))), nil
+		}
+		return appendCBORHead(buf, cborUnsigned, uint64(v)), nil
+	case uint64:
+		return appendCBORHead(buf, cborUnsigned, v), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(append(buf, cborFloat64), math.Float64bits(v)), nil
+	case []interface{}:
+		buf = appendCBORHead(buf, cborArray, uint64(len(v)))
+		var err error
+		for _, item := range v {
+			if buf, err = appendCBOR(buf, item); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case object:
+		buf = appendCBORHead(buf, cborMap, uint64(len(v)))
+		var err error
+		for _, m := range v {
+			buf = appendCBORHead(buf, cborText, uint64(len(m.key)))
+			buf = append(buf, m.key...)
+			if buf, err = appendCBOR(buf, m.value); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	return nil, fmt.Errorf("cbor: unsupported value type %T", v)
+}
+
+// appendCBORHead writes a data item head using the shortest argument encoding.
+func appendCBORHead(buf []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(buf, major|byte(n))
+	case n <= 0xff:
+		return append(buf, major|24, byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major|27), n)
+	}
+}
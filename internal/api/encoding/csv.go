@@ -0,0 +1,142 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MediaTypeCSV is the media type of list exports.
+const MediaTypeCSV = "text/csv"
+
+// MarshalCSV encodes the objects in the array field collection of v as CSV
+// with a header row. Columns are the union of the objects' fields in the order
+// they first appear. Arrays of scalars are joined with ";" and other nested
+// values are written as JSON. The remaining scalar fields of v, such as
+// total_count and next_cursor, are returned as envelope for the caller to
+// expose separately.
+//
+// Text cells starting with =, +, - or @ are prefixed with a single quote so
+// spreadsheet applications do not evaluate them as formulas.
+func MarshalCSV(v interface{}, collection string) ([]byte, map[string]string, error) {
+	value, err := toValue(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj, ok := value.(object)
+	if !ok {
+		return nil, nil, fmt.Errorf("csv: expected an object, got %T", value)
+	}
+
+	var items []interface{}
+	envelope := make(map[string]string)
+	for _, m := range obj {
+		if m.key == collection {
+			items, _ = m.value.([]interface{})
+			continue
+		}
+		switch m.value.(type) {
+		case object, []interface{}:
+		default:
+			envelope[m.key] = csvCell(m.value)
+		}
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		row, ok := item.(object)
+		if !ok {
+			return nil, nil, fmt.Errorf("csv: %s must contain objects", collection)
+		}
+		for _, m := range row {
+			if !seen[m.key] {
+				seen[m.key] = true
+				columns = append(columns, m.key)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(columns); err != nil {
+		return nil, nil, err
+	}
+	for _, item := range items {
+		cells := make(map[string]string)
+		for _, m := range item.(object) {
+			cells[m.key] = csvCell(m.value)
+		}
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = cells[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), envelope, writer.Error()
+}
+
+func csvCell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			switch item.(type) {
+			case object, []interface{}:
+				return jsonCell(v)
+			}
+			parts = append(parts, csvCell(item))
+		}
+		return strings.Join(parts, ";")
+	}
+	return jsonCell(v)
+}
+
+// jsonCell writes a nested value as compact JSON.
+func jsonCell(v interface{}) string {
+	data, err := json.Marshal(toJSON(v))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// toJSON converts a value-model value back into types encoding/json accepts.
+func toJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case object:
+		m := make(map[string]interface{}, len(v))
+		for _, member := range v {
+			m[member.key] = toJSON(member.value)
+		}
+		return m
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = toJSON(item)
+		}
+		return out
+	}
+	return v
+}
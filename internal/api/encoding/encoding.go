@@ -0,0 +1,160 @@
+// Package encoding implements the response encodings the API negotiates with
+// the Accept header: JSON, CBOR (RFC 8949) and MessagePack. The binary
+// encoders work from the JSON form of a value, so every format carries the
+// same field names, omissions and field order as the JSON response. List
+// responses can also be exported as CSV.
+package encoding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"updater/internal/models"
+)
+
+// Supported media types.
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeCBOR    = "application/cbor"
+	MediaTypeMsgPack = "application/msgpack"
+)
+
+// mediaTypes maps accepted Accept values, including common MessagePack
+// aliases, to the canonical media type.
+var mediaTypes = map[string]string{
+	MediaTypeJSON:             MediaTypeJSON,
+	"application/*":           MediaTypeJSON,
+	"*/*":                     MediaTypeJSON,
+	MediaTypeCBOR:             MediaTypeCBOR,
+	MediaTypeMsgPack:          MediaTypeMsgPack,
+	"application/x-msgpack":   MediaTypeMsgPack,
+	"application/vnd.msgpack": MediaTypeMsgPack,
+}
+
+// Negotiate returns the supported media type the Accept header prefers. Media
+// ranges are ranked by q-value, then by position; q=0 excludes a type. JSON is
+// returned when the header is empty or names nothing supported.
+func Negotiate(accept string) string {
+	best, bestQ := MediaTypeJSON, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		canonical, ok := mediaTypes[strings.ToLower(strings.TrimSpace(mediaType))]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if name, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok && strings.EqualFold(name, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > bestQ {
+			best, bestQ = canonical, q
+		}
+	}
+	return best
+}
+
+// Marshal encodes v in the given media type. models.CompactFields values are
+// encoded as a map in field order; anything else is encoded from its JSON form.
+func Marshal(mediaType string, v interface{}) ([]byte, error) {
+	if mediaType == MediaTypeJSON {
+		return json.Marshal(v)
+	}
+
+	value, err := toValue(v)
+	if err != nil {
+		return nil, err
+	}
+	switch mediaType {
+	case MediaTypeCBOR:
+		return appendCBOR(nil, value)
+	case MediaTypeMsgPack:
+		return appendMsgPack(nil, value)
+	}
+	return nil, fmt.Errorf("encoding: unsupported media type %q", mediaType)
+}
+
+// member is one key/value pair of an object. Objects are kept as ordered
+// member lists rather than Go maps so encodings preserve field order.
+type member struct {
+	key   string
+	value interface{}
+}
+
+type object []member
+
+// toValue converts v into the value model the binary encoders accept: nil,
+// bool, string, int64, uint64, float64, []interface{} and object.
+func toValue(v interface{}) (interface{}, error) {
+	if fields, ok := v.(models.CompactFields); ok {
+		obj := make(object, 0, len(fields))
+		for _, field := range fields {
+			obj = append(obj, member{field.Key, field.Value})
+		}
+		return obj, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decodeValue(decoder)
+}
+
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		obj := object{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, member{key.(string), value})
+		}
+		_, err := decoder.Token()
+		return obj, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := decoder.Token()
+		return array, err
+	}
+	if n, ok := token.(json.Number); ok {
+		return parseNumber(n)
+	}
+	return token, nil
+}
+
+// parseNumber converts a JSON number to int64 when it fits, then uint64, and
+// float64 otherwise.
+func parseNumber(n json.Number) (interface{}, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u, nil
+	}
+	return strconv.ParseFloat(string(n), 64)
+}
@@ -0,0 +1,214 @@
+package encoding
+
+import (
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", MediaTypeJSON},
+		{"text/html", MediaTypeJSON},
+		{"application/json", MediaTypeJSON},
+		{"application/cbor", MediaTypeCBOR},
+		{"application/msgpack", MediaTypeMsgPack},
+		{"application/x-msgpack", MediaTypeMsgPack},
+		{"Application/VND.MsgPack", MediaTypeMsgPack},
+		{"application/cbor, application/json", MediaTypeCBOR},
+		{"application/json;q=0.5, application/msgpack", MediaTypeMsgPack},
+		{"application/cbor;q=0, */*", MediaTypeJSON},
+		{"application/cbor;q=0", MediaTypeJSON},
+		{"text/html, application/cbor; charset=binary", MediaTypeCBOR},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Negotiate(tt.accept), "Accept: %q", tt.accept)
+	}
+}
+
+type sample struct {
+	A string   `json:"a"`
+	N int      `json:"n"`
+	L []int    `json:"l"`
+	X *int     `json:"x"`
+	F float64  `json:"f"`
+	O string   `json:"o,omitempty"`
+	E []string `json:"e"`
+}
+
+func TestMarshal(t *testing.T) {
+	v := sample{A: "hi", N: -1, L: []int{1, 300}, F: 1.5, E: []string{}}
+
+	t.Run("cbor keeps JSON field order and omissions", func(t *testing.T) {
+		data, err := Marshal(MediaTypeCBOR, v)
+		require.NoError(t, err)
+		want := "a6" +
+			"6161" + "626869" +
+			"616e" + "20" +
+			"616c" + "82" + "01" + "19012c" +
+			"6178" + "f6" +
+			"6166" + "fb3ff8000000000000" +
+			"6165" + "80"
+		assert.Equal(t, want, hex.EncodeToString(data))
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		data, err := Marshal(MediaTypeMsgPack, v)
+		require.NoError(t, err)
+		want := "86" +
+			"a161" + "a26869" +
+			"a16e" + "ff" +
+			"a16c" + "92" + "01" + "cd012c" +
+			"a178" + "c0" +
+			"a166" + "cb3ff8000000000000" +
+			"a165" + "90"
+		assert.Equal(t, want, hex.EncodeToString(data))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := Marshal(MediaTypeJSON, v)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a":"hi","n":-1,"l":[1,300],"x":null,"f":1.5,"e":[]}`, string(data))
+	})
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		_, err := Marshal("text/csv", v)
+		assert.Error(t, err)
+	})
+}
+
+func TestMarshal_CompactFields(t *testing.T) {
+	t.Run("no update", func(t *testing.T) {
+		fields := (&models.OTACheckResponse{}).CompactFields()
+
+		data, err := Marshal(MediaTypeCBOR, fields)
+		require.NoError(t, err)
+		// {"update": false}
+		assert.Equal(t, "a166757064617465f4", hex.EncodeToString(data))
+
+		data, err = Marshal(MediaTypeMsgPack, fields)
+		require.NoError(t, err)
+		assert.Equal(t, "81a6757064617465c2", hex.EncodeToString(data))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		resp := &models.OTACheckResponse{UpdateAvailable: true, Version: "1.1.0", Size: 10000, Required: true}
+		data, err := Marshal(MediaTypeCBOR, resp.CompactFields())
+		require.NoError(t, err)
+		// {"update": true, "version": "1.1.0", "size": 10000, "required": true}
+		want := "a4" +
+			"66757064617465" + "f5" +
+			"6776657273696f6e" + "65312e312e30" +
+			"6473697a65" + "192710" +
+			"687265717569726564" + "f5"
+		assert.Equal(t, want, hex.EncodeToString(data))
+	})
+}
+
+func TestAppendCBORHead(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{255, "18ff"},
+		{256, "190100"},
+		{65536, "1a00010000"},
+		{1 << 32, "1b0000000100000000"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, hex.EncodeToString(appendCBORHead(nil, cborUnsigned, tt.n)), "n=%d", tt.n)
+	}
+}
+
+func TestAppendMsgPack_Integers(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{int64(0), "00"},
+		{int64(127), "7f"},
+		{int64(128), "cc80"},
+		{int64(65536), "ce00010000"},
+		{uint64(math.MaxUint64), "cfffffffffffffffff"},
+		{int64(-32), "e0"},
+		{int64(-33), "d0df"},
+		{int64(-129), "d1ff7f"},
+		{int64(math.MinInt64), "d38000000000000000"},
+	}
+
+	for _, tt := range tests {
+		data, err := appendMsgPack(nil, tt.v)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, hex.EncodeToString(data), "v=%v", tt.v)
+	}
+}
+
+func TestAppendMsgPack_Lengths(t *testing.T) {
+	long := make([]byte, 40)
+	for i := range long {
+		long[i] = 'x'
+	}
+
+	data, err := appendMsgPack(nil, string(long))
+	require.NoError(t, err)
+	assert.Equal(t, "d928", hex.EncodeToString(data[:2]))
+
+	data, err = appendMsgPack(nil, make([]interface{}, 16))
+	require.NoError(t, err)
+	assert.Equal(t, "dc0010", hex.EncodeToString(data[:3]))
+}
+
+func TestMarshalCSV(t *testing.T) {
+	list := models.ListApplicationsResponse{
+		Applications: []models.ApplicationSummary{
+			{ID: "app-a", Name: "App, A", Platforms: []string{"windows", "linux"}, Tags: []string{}},
+			{ID: "app-b", Name: "=HYPERLINK(\"x\")", Group: "tools"},
+		},
+		TotalCount: 2,
+		NextCursor: "abc",
+	}
+
+	data, envelope, err := MarshalCSV(list, "applications")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "id,name,description,platforms,tags,"), lines[0])
+	assert.Contains(t, lines[0], ",group")
+	assert.True(t, strings.HasPrefix(lines[1], `app-a,"App, A",,windows;linux,,`), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], `app-b,"'=HYPERLINK(""x"")"`), lines[2])
+	assert.True(t, strings.HasSuffix(lines[2], ",tools"), lines[2])
+	assert.Equal(t, map[string]string{"total_count": "2", "next_cursor": "abc"}, envelope)
+}
+
+func TestMarshalCSV_NestedValues(t *testing.T) {
+	v := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": "r1", "metadata": map[string]string{"k": "v"}, "size": -1},
+		},
+	}
+
+	data, _, err := MarshalCSV(v, "items")
+	require.NoError(t, err)
+	assert.Equal(t, "id,metadata,size\nr1,\"{\"\"k\"\":\"\"v\"\"}\",-1\n", string(data))
+}
+
+func TestMarshalCSV_EmptyCollection(t *testing.T) {
+	data, envelope, err := MarshalCSV(models.ListReleasesResponse{}, "releases")
+	require.NoError(t, err)
+	assert.Equal(t, "\n", string(data))
+	assert.Equal(t, "0", envelope["total_count"])
+}
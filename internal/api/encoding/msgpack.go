@@ -0,0 +1,136 @@
+package encoding
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// MessagePack format bytes (https://github.com/msgpack/msgpack/blob/master/spec.md).
+const (
+	msgpackNil      = 0xc0
+	msgpackFalse    = 0xc2
+	msgpackTrue     = 0xc3
+	msgpackFloat64  = 0xcb
+	msgpackUint8    = 0xcc
+	msgpackUint16   = 0xcd
+	msgpackUint32   = 0xce
+	msgpackUint64   = 0xcf
+	msgpackInt8     = 0xd0
+	msgpackInt16    = 0xd1
+	msgpackInt32    = 0xd2
+	msgpackInt64    = 0xd3
+	msgpackStr8     = 0xd9
+	msgpackStr16    = 0xda
+	msgpackStr32    = 0xdb
+	msgpackArray16  = 0xdc
+	msgpackArray32  = 0xdd
+	msgpackMap16    = 0xde
+	msgpackMap32    = 0xdf
+	msgpackFixStr   = 0xa0
+	msgpackFixArray = 0x90
+	msgpackFixMap   = 0x80
+)
+
+// appendMsgPack appends the MessagePack encoding of a value-model value using
+// the smallest representation of each item.
+func appendMsgPack(buf []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, msgpackNil), nil
+	case bool:
+		if v {
+			return append(buf, msgpackTrue), nil
+		}
+		return append(buf, msgpackFalse), nil
+	case string:
+		return appendMsgPackString(buf, v), nil
+	case int64:
+		if v >= 0 {
+			return appendMsgPackUint(buf, uint64(v)), nil
+		}
+		return appendMsgPackInt(buf, v), nil
+	case uint64:
+		return appendMsgPackUint(buf, v), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(append(buf, msgpackFloat64), math.Float64bits(v)), nil
+	case []interface{}:
+		buf = appendMsgPackLength(buf, len(v), msgpackFixArray, msgpackArray16, msgpackArray32)
+		var err error
+		for _, item := range v {
+			if buf, err = appendMsgPack(buf, item); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case object:
+		buf = appendMsgPackLength(buf, len(v), msgpackFixMap, msgpackMap16, msgpackMap32)
+		var err error
+		for _, m := range v {
+			buf = appendMsgPackString(buf, m.key)
+			if buf, err = appendMsgPack(buf, m.value); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	return nil, fmt.Errorf("msgpack: unsupported value type %T", v)
+}
+
+func appendMsgPackString(buf []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		buf = append(buf, msgpackFixStr|byte(n))
+	case n <= 0xff:
+		buf = append(buf, msgpackStr8, byte(n))
+	case n <= 0xffff:
+		buf = binary.BigEndian.AppendUint16(append(buf, msgpackStr16), uint16(n))
+	default:
+		buf = binary.BigEndian.AppendUint32(append(buf, msgpackStr32), uint32(n))
+	}
+	return append(buf, s...)
+}
+
+// appendMsgPackLength writes an array or map header: the fix form holds up to
+// 15 entries, then the 16- and 32-bit forms.
+func appendMsgPackLength(buf []byte, n int, fix, form16, form32 byte) []byte {
+	switch {
+	case n < 16:
+		return append(buf, fix|byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(buf, form16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, form32), uint32(n))
+	}
+}
+
+func appendMsgPackUint(buf []byte, n uint64) []byte {
+	switch {
+	case n < 0x80:
+		return append(buf, byte(n))
+	case n <= 0xff:
+		return append(buf, msgpackUint8, byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(buf, msgpackUint16), uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(buf, msgpackUint32), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, msgpackUint64), n)
+	}
+}
+
+// appendMsgPackInt encodes a negative integer.
+func appendMsgPackInt(buf []byte, n int64) []byte {
+	switch {
+	case n >= -32:
+		return append(buf, byte(int8(n)))
+	case n >= math.MinInt8:
+		return append(buf, msgpackInt8, byte(int8(n)))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(buf, msgpackInt16), uint16(int16(n)))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(buf, msgpackInt32), uint32(int32(n)))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, msgpackInt64), uint64(n))
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"updater/internal/api/encoding"
+	"updater/internal/models"
+)
+
+// fieldsParam is the query parameter that selects a sparse fieldset, for
+// example ?fields=version,download_url,checksum.
+const fieldsParam = "fields"
+
+// requestedFields returns the field names listed in the fields query parameter,
+// or nil when the client did not ask for a sparse fieldset.
+func requestedFields(r *http.Request) map[string]bool {
+	names := splitAndTrim(r.URL.Query().Get(fieldsParam), ",")
+	if len(names) == 0 {
+		return nil
+	}
+	fields := make(map[string]bool, len(names))
+	for _, name := range names {
+		fields[name] = true
+	}
+	return fields
+}
+
+// formatParam selects the export format of list responses: json (default) or csv.
+const formatParam = "format"
+
+// writeFieldsResponse writes data as JSON, keeping only the fields the client
+// listed in ?fields=. See sparseFieldset for how list responses are filtered.
+// List responses (non-empty collection) are written as CSV with ?format=csv.
+func (h *Handlers) writeFieldsResponse(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}, collection string) {
+	data = sparseFieldset(r, data, collection)
+	if collection == "" {
+		h.writeJSONResponse(w, statusCode, data)
+		return
+	}
+
+	switch r.URL.Query().Get(formatParam) {
+	case "", "json":
+		h.writeJSONResponse(w, statusCode, data)
+	case "csv":
+		h.writeCSVResponse(w, statusCode, data, collection)
+	default:
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "format must be json or csv")
+	}
+}
+
+// writeCSVResponse writes the collection of a list response as a CSV
+// attachment, one row per item. Envelope fields are sent as headers:
+// total_count becomes X-Total-Count and next_cursor becomes X-Next-Cursor.
+func (h *Handlers) writeCSVResponse(w http.ResponseWriter, statusCode int, data interface{}, collection string) {
+	body, envelope, err := encoding.MarshalCSV(data, collection)
+	if err != nil {
+		slog.Error("Failed to encode CSV response", "error", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "Internal server error")
+		return
+	}
+
+	for field, value := range envelope {
+		if value != "" {
+			w.Header().Set(envelopeHeader(field), value)
+		}
+	}
+	w.Header().Set("Content-Type", encoding.MediaTypeCSV+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", collection+".csv"))
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+// envelopeHeader maps an envelope field name to its CSV response header, for
+// example next_cursor to X-Next-Cursor.
+func envelopeHeader(field string) string {
+	parts := strings.Split(field, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return "X-" + strings.Join(parts, "-")
+}
+
+// sparseFieldset keeps only the top-level fields the client listed in
+// ?fields=. For list responses, collection names the array of resources (for
+// example "releases"); the filter then applies to each element and the
+// envelope fields such as total_count and next_cursor are kept. Unknown field
+// names are ignored. Without ?fields= data is returned unchanged.
+func sparseFieldset(r *http.Request, data interface{}, collection string) interface{} {
+	fields := requestedFields(r)
+	if fields == nil {
+		return data
+	}
+
+	filtered, err := filterFields(data, fields, collection)
+	if err != nil {
+		slog.Error("Failed to apply sparse fieldset", "error", err)
+		return data
+	}
+	return filtered
+}
+
+// filterFields round-trips data through JSON so the filter works on the wire
+// field names, including omitempty and custom marshalling.
+func filterFields(data interface{}, fields map[string]bool, collection string) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	if collection == "" {
+		return keepFields(object, fields), nil
+	}
+	if items, ok := object[collection].([]interface{}); ok {
+		for i, item := range items {
+			if resource, ok := item.(map[string]interface{}); ok {
+				items[i] = keepFields(resource, fields)
+			}
+		}
+	}
+	return object, nil
+}
+
+func keepFields(object map[string]interface{}, fields map[string]bool) map[string]interface{} {
+	for key := range object {
+		if !fields[key] {
+			delete(object, key)
+		}
+	}
+	return object
+}
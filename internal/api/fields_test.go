@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_GetLatestVersion_Fields(t *testing.T) {
+	mockService := &MockUpdateService{}
+	handlers := NewHandlers(mockService)
+
+	mockService.On("GetLatestVersion", mock.Anything, mock.AnythingOfType("*models.LatestVersionRequest")).Return(&models.LatestVersionResponse{
+		Version:      "2.0.0",
+		DownloadURL:  "https://example.com/v2.0.0/app.exe",
+		Checksum:     "def456",
+		ChecksumType: "sha256",
+		ReleaseNotes: "Latest stable release",
+		ReleaseDate:  time.Now(),
+		FileSize:     2048000,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/latest?app_id=test-app&platform=windows&architecture=amd64&fields=version,%20download_url,checksum,unknown", nil)
+	recorder := httptest.NewRecorder()
+	handlers.GetLatestVersion(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"version":"2.0.0","download_url":"https://example.com/v2.0.0/app.exe","checksum":"def456"}`, recorder.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_ListReleases_Fields(t *testing.T) {
+	mockService := &MockUpdateService{}
+	handlers := NewHandlers(mockService)
+
+	mockService.On("ListReleases", mock.Anything, mock.AnythingOfType("*models.ListReleasesRequest")).Return(&models.ListReleasesResponse{
+		Releases: []models.ReleaseInfo{
+			{ID: "r1", Version: "1.0.0", Platform: "windows", FileSize: 1024},
+			{ID: "r2", Version: "1.1.0", Platform: "windows", FileSize: 2048},
+		},
+		TotalCount: 2,
+		NextCursor: "abc",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/releases?fields=version,file_size", nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+	recorder := httptest.NewRecorder()
+	handlers.ListReleases(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{
+		"releases": [{"version":"1.0.0","file_size":1024},{"version":"1.1.0","file_size":2048}],
+		"total_count": 2,
+		"next_cursor": "abc"
+	}`, recorder.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestFilterFields(t *testing.T) {
+	fields := map[string]bool{"version": true}
+
+	t.Run("keeps large numbers exact", func(t *testing.T) {
+		filtered, err := filterFields(map[string]interface{}{"version": int64(1) << 60, "other": 1}, fields, "")
+		require.NoError(t, err)
+		data, err := json.Marshal(filtered)
+		require.NoError(t, err)
+		assert.Equal(t, `{"version":1152921504606846976}`, string(data))
+	})
+
+	t.Run("missing collection leaves envelope unchanged", func(t *testing.T) {
+		filtered, err := filterFields(&models.ListReleasesResponse{TotalCount: 0}, fields, "releases")
+		require.NoError(t, err)
+		data, err := json.Marshal(filtered)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"releases":null,"total_count":0,"next_cursor":""}`, string(data))
+	})
+
+	t.Run("no fields parameter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?fields=", nil)
+		assert.Nil(t, requestedFields(req))
+	})
+}
+
+func TestHandlers_ListReleases_CSV(t *testing.T) {
+	listResp := &models.ListReleasesResponse{
+		Releases: []models.ReleaseInfo{
+			{ID: "r1", Version: "1.0.0", Platform: "windows", Tags: []string{"lts", "security"}},
+			{ID: "r2", Version: "1.1.0", Platform: "linux"},
+		},
+		TotalCount: 5,
+		NextCursor: "abc",
+	}
+
+	t.Run("csv with fields", func(t *testing.T) {
+		mockService := &MockUpdateService{}
+		mockService.On("ListReleases", mock.Anything, mock.AnythingOfType("*models.ListReleasesRequest")).Return(listResp, nil)
+		handlers := NewHandlers(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/releases?format=csv&fields=version,platform,tags", nil)
+		req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+		recorder := httptest.NewRecorder()
+		handlers.ListReleases(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="releases.csv"`, recorder.Header().Get("Content-Disposition"))
+		assert.Equal(t, "5", recorder.Header().Get("X-Total-Count"))
+		assert.Equal(t, "abc", recorder.Header().Get("X-Next-Cursor"))
+		assert.Equal(t, "platform,tags,version\nwindows,lts;security,1.0.0\nlinux,,1.1.0\n", recorder.Body.String())
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		mockService := &MockUpdateService{}
+		mockService.On("ListReleases", mock.Anything, mock.AnythingOfType("*models.ListReleasesRequest")).Return(listResp, nil)
+		handlers := NewHandlers(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/releases?format=xlsx", nil)
+		req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+		recorder := httptest.NewRecorder()
+		handlers.ListReleases(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestEnvelopeHeader(t *testing.T) {
+	assert.Equal(t, "X-Next-Cursor", envelopeHeader("next_cursor"))
+	assert.Equal(t, "X-Application-Id", envelopeHeader("application_id"))
+}
@@ -9,6 +9,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"updater/internal/api/encoding"
+	"updater/internal/clientbundle"
+	"updater/internal/clienttoken"
 	"updater/internal/models"
 	"updater/internal/observability"
 	"updater/internal/storage"
@@ -23,9 +26,19 @@ import (
 // Handlers contains HTTP handlers for the updater API
 type Handlers struct {
 	updateService update.ServiceInterface
+	keys          update.KeyService
 	storage       storage.Storage
 	versionInfo   version.Info
 	appMetrics    *observability.AppMetrics
+	healthHistory *observability.HealthHistory
+	sloTracker    *observability.SLOTracker
+	pgpPublicKey  []byte
+	clientTokens  *clienttoken.Issuer
+	clientBundle  *clientbundle.Signer
+	queryTokens   *queryTokenSigner
+	artifacts     models.ArtifactsConfig
+	artifactFiles http.Handler
+	now           func() time.Time
 }
 
 // NewHandlers creates a new handlers instance
@@ -33,10 +46,14 @@ func NewHandlers(updateService update.ServiceInterface, opts ...HandlersOption)
 	h := &Handlers{
 		updateService: updateService,
 		versionInfo:   version.GetInfo(), // Default to current version info
+		now:           time.Now,
 	}
 	for _, opt := range opts {
 		opt(h)
 	}
+	if h.keys == nil && h.storage != nil {
+		h.keys = update.NewKeyManager(h.storage)
+	}
 	return h
 }
 
@@ -50,6 +67,13 @@ func WithStorage(s storage.Storage) HandlersOption {
 	}
 }
 
+// WithKeyService sets the API key management used by the admin key
+// endpoints. Without it, keys are managed directly in the storage set by
+// WithStorage.
+func WithKeyService(keys update.KeyService) HandlersOption {
+	return func(h *Handlers) { h.keys = keys }
+}
+
 // WithVersionInfo sets the version information for health and version endpoints.
 func WithVersionInfo(info version.Info) HandlersOption {
 	return func(h *Handlers) { h.versionInfo = info }
@@ -60,11 +84,41 @@ func WithAppMetrics(m *observability.AppMetrics) HandlersOption {
 	return func(h *Handlers) { h.appMetrics = m }
 }
 
+// WithHealthHistory enables the health history endpoint and request counting
+// for its samples.
+func WithHealthHistory(history *observability.HealthHistory) HandlersOption {
+	return func(h *Handlers) { h.healthHistory = history }
+}
+
+// WithSLOTracker measures requests against their route objectives and, when
+// degraded mode is configured, lets latest-version routes answer from cache
+// while their burn rate is too high.
+func WithSLOTracker(tracker *observability.SLOTracker) HandlersOption {
+	return func(h *Handlers) { h.sloTracker = tracker }
+}
+
+// WithClock sets the time source of the times handlers write or compare
+// against, such as the condition times of reconcile responses. Network
+// deadlines always use the wall clock.
+func WithClock(now func() time.Time) HandlersOption {
+	return func(h *Handlers) { h.now = now }
+}
+
+// licenseTokenHeader carries the client's license token on GET requests. A
+// header keeps the token out of URLs, which end up in access logs.
+const licenseTokenHeader = "X-License-Token"
+
+// dryRunParam is the query parameter that turns an update check into a dry
+// run. Only the value "true" enables it, which lets the router send dry runs
+// to a route that requires support permission.
+const dryRunParam = "dry_run"
+
 // CheckForUpdates handles update check requests
 // GET /api/v1/updates/{app_id}/check (path variables + query params)
 // POST /api/v1/check (JSON body)
 func (h *Handlers) CheckForUpdates(w http.ResponseWriter, r *http.Request) {
 	var req *models.UpdateCheckRequest
+	var wait time.Duration
 
 	if r.Method == http.MethodPost {
 		// Validate content-type for POST requests
@@ -84,6 +138,9 @@ func (h *Handlers) CheckForUpdates(w http.ResponseWriter, r *http.Request) {
 			h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid JSON body")
 			return
 		}
+		if requestBody.LicenseToken == "" {
+			requestBody.LicenseToken = r.Header.Get(licenseTokenHeader)
+		}
 		req = &requestBody
 	} else {
 		// Handle GET request with path variables and query parameters
@@ -99,22 +156,140 @@ func (h *Handlers) CheckForUpdates(w http.ResponseWriter, r *http.Request) {
 			IncludeMetadata: r.URL.Query().Get("include_metadata") == "true",
 			UserAgent:       r.Header.Get("User-Agent"),
 			ClientID:        r.URL.Query().Get("client_id"),
+			HostVersion:     r.URL.Query().Get("host_version"),
+			LicenseToken:    r.Header.Get(licenseTokenHeader),
+			Edition:         r.URL.Query().Get("edition"),
+			Channel:         r.URL.Query().Get("channel"),
+			OSVersion:       r.URL.Query().Get("os_version"),
+			Locale:          r.URL.Query().Get("locale"),
+			Variant:         r.URL.Query().Get("variant"),
 		}
+		if tags := r.URL.Query().Get("client_tags"); tags != "" {
+			req.ClientTags = splitAndTrim(tags, ",")
+		}
+		if codeStr := r.URL.Query().Get("version_code"); codeStr != "" {
+			code, err := strconv.ParseInt(codeStr, 10, 64)
+			if err != nil {
+				h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "version_code must be an integer")
+				return
+			}
+			req.VersionCode = code
+		}
+
+		// Long-poll: hold the check until a matching release is published
+		if waitStr := r.URL.Query().Get("wait"); waitStr != "" {
+			parsed, err := parseWait(waitStr)
+			if err != nil {
+				h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "wait must be a duration such as 60s")
+				return
+			}
+			wait = parsed
+		}
+	}
+
+	// Dry runs explain the decision instead of answering a real client, so they
+	// are not recorded as update checks. With auth enabled the route requires
+	// support permission, which admin keys also hold.
+	if r.URL.Query().Get(dryRunParam) == "true" {
+		dryRun, err := h.updateService.DryRunCheckForUpdate(r.Context(), req)
+		if err != nil {
+			h.writeServiceErrorResponse(w, err)
+			return
+		}
+		h.writeJSONResponse(w, http.StatusOK, dryRun)
+		return
+	}
+
+	// A check admitted through the priority lane is answered only when it
+	// offers a required or security release, and never waits.
+	priorityOnly := isPriorityOnly(r)
+	if priorityOnly {
+		wait = 0
 	}
 
 	// Check for updates
-	response, err := h.updateService.CheckForUpdate(r.Context(), req)
+	var response *models.UpdateCheckResponse
+	var err error
+	if wait > 0 {
+		// The server write timeout would otherwise cut a held check short
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(wait + longPollGrace)); err != nil {
+			slog.Debug("Could not extend write deadline for long-poll", "error", err)
+		}
+		response, err = h.updateService.WaitForUpdate(r.Context(), req, wait)
+	} else {
+		response, err = h.updateService.CheckForUpdate(r.Context(), req)
+	}
 	if err != nil {
 		h.recordUpdateCheck(r, req.ApplicationID, "error")
 		h.writeServiceErrorResponse(w, err)
 		return
 	}
+	if priorityOnly && !response.IsPriority() {
+		recordShed(h.appMetrics, r, routeClassPriority, shedNotPriority)
+		writeBusyResponse(w, time.Second)
+		return
+	}
 
 	result := "no_update"
 	if response.UpdateAvailable {
 		result = "update_available"
 	}
 	h.recordUpdateCheck(r, req.ApplicationID, result)
+	if response.IsPriority() {
+		h.recordPriorityCheck(r, req.ApplicationID, priorityOnly)
+	}
+
+	h.writeEncodedResponse(w, r, http.StatusOK, sparseFieldset(r, response, ""))
+}
+
+// BatchCheckForUpdates handles batched update check requests
+// POST /api/v1/check/batch
+func (h *Handlers) BatchCheckForUpdates(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" || (!strings.HasPrefix(contentType, "application/json")) {
+		h.writeErrorResponse(w, http.StatusUnsupportedMediaType, models.ErrorCodeBadRequest, "Content-Type must be application/json")
+		return
+	}
+
+	var req models.BatchUpdateCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid JSON body")
+		return
+	}
+
+	response, err := h.updateService.BatchCheckForUpdates(r.Context(), &req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	for _, item := range response.Results {
+		result := "error"
+		if item.Result != nil {
+			result = "no_update"
+			if item.Result.UpdateAvailable {
+				result = "update_available"
+			}
+		}
+		h.recordUpdateCheck(r, item.ApplicationID, result)
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetDecisionLog returns the decision traces recorded for a request ID
+// GET /api/v1/admin/decisions/{request_id}
+// Requires authentication and 'admin' permission
+func (h *Handlers) GetDecisionLog(w http.ResponseWriter, r *http.Request) {
+	response, err := h.updateService.GetDecisionLog(r.Context(), mux.Vars(r)["request_id"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
 
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
@@ -144,6 +319,10 @@ func (h *Handlers) GetLatestVersion(w http.ResponseWriter, r *http.Request) {
 		Architecture:    r.URL.Query().Get("architecture"),
 		AllowPrerelease: r.URL.Query().Get("allow_prerelease") == "true",
 		IncludeMetadata: r.URL.Query().Get("include_metadata") == "true",
+		LicenseToken:    r.Header.Get(licenseTokenHeader),
+		Edition:         r.URL.Query().Get("edition"),
+		Channel:         r.URL.Query().Get("channel"),
+		Variant:         r.URL.Query().Get("variant"),
 	}
 
 	// Get latest version
@@ -153,7 +332,28 @@ func (h *Handlers) GetLatestVersion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+	h.writeEncodedResponse(w, r, http.StatusOK, sparseFieldset(r, response, ""))
+}
+
+// ListPluginUpdates handles plugin update requests for a host application
+// GET /api/v1/updates/{app_id}/plugins
+func (h *Handlers) ListPluginUpdates(w http.ResponseWriter, r *http.Request) {
+	req := &models.PluginUpdatesRequest{
+		HostApplicationID: mux.Vars(r)["app_id"],
+		HostVersion:       r.URL.Query().Get("host_version"),
+		Platform:          r.URL.Query().Get("platform"),
+		Architecture:      r.URL.Query().Get("architecture"),
+		AllowPrerelease:   r.URL.Query().Get("allow_prerelease") == "true",
+		LicenseToken:      r.Header.Get(licenseTokenHeader),
+	}
+
+	response, err := h.updateService.ListPluginUpdates(r.Context(), req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	h.writeFieldsResponse(w, r, http.StatusOK, response, "plugins")
 }
 
 // ListReleases handles release list requests
@@ -200,6 +400,11 @@ func (h *Handlers) ListReleases(w http.ResponseWriter, r *http.Request) {
 		req.Platforms = splitAndTrim(platforms, ",")
 	}
 
+	// Parse tags array; releases must carry every listed tag
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		req.Tags = splitAndTrim(tags, ",")
+	}
+
 	// List releases
 	response, err := h.updateService.ListReleases(r.Context(), req)
 	if err != nil {
@@ -207,7 +412,27 @@ func (h *Handlers) ListReleases(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+	h.writeFieldsResponse(w, r, http.StatusOK, response, "releases")
+}
+
+// CompareReleases handles release comparison requests
+// GET /api/v1/updates/{app_id}/releases/compare?from={version}&to={version}
+func (h *Handlers) CompareReleases(w http.ResponseWriter, r *http.Request) {
+	req := &models.CompareReleasesRequest{
+		ApplicationID: mux.Vars(r)["app_id"],
+		From:          r.URL.Query().Get("from"),
+		To:            r.URL.Query().Get("to"),
+		Platform:      r.URL.Query().Get("platform"),
+		Architecture:  r.URL.Query().Get("architecture"),
+	}
+
+	response, err := h.updateService.CompareReleases(r.Context(), req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	h.writeFieldsResponse(w, r, http.StatusOK, response, "")
 }
 
 // RegisterRelease handles release registration requests
@@ -245,6 +470,19 @@ func (h *Handlers) RegisterRelease(w http.ResponseWriter, r *http.Request) {
 	// Set application ID from URL
 	req.ApplicationID = appID
 
+	if req.AutoFill {
+		// Fetching the artifact can outlast the server write timeout; the
+		// fetch has its own timeout
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			slog.Debug("Could not lift write deadline for auto-fill", "error", err)
+		}
+	}
+
+	r, ok := h.breakGlass(w, r)
+	if !ok {
+		return
+	}
+
 	// Register release
 	response, err := h.updateService.RegisterRelease(r.Context(), &req)
 	if err != nil {
@@ -319,6 +557,30 @@ func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// HealthHistory returns periodic health samples
+// GET /api/v1/health/history?since=1h
+// Requires authentication and 'read' permission
+func (h *Handlers) HealthHistory(w http.ResponseWriter, r *http.Request) {
+	if h.healthHistory == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, models.ErrorCodeNotFound, "Health history is not enabled")
+		return
+	}
+
+	var since time.Time
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		if d, err := time.ParseDuration(sinceStr); err == nil && d > 0 {
+			since = h.now().Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, sinceStr); err == nil {
+			since = t
+		} else {
+			h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "since must be a positive duration or an RFC 3339 timestamp")
+			return
+		}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, h.healthHistory.History(since))
+}
+
 // VersionInfo handles version information requests
 // GET /version
 // Returns build metadata and runtime information
@@ -338,6 +600,27 @@ func (h *Handlers) writeJSONResponse(w http.ResponseWriter, statusCode int, data
 	}
 }
 
+// writeEncodedResponse writes data in the format the Accept header prefers:
+// CBOR, MessagePack or JSON by default. Error responses are always JSON.
+func (h *Handlers) writeEncodedResponse(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) {
+	w.Header().Add("Vary", "Accept")
+	mediaType := encoding.Negotiate(r.Header.Get("Accept"))
+	if mediaType == encoding.MediaTypeJSON {
+		h.writeJSONResponse(w, statusCode, data)
+		return
+	}
+
+	body, err := encoding.Marshal(mediaType, data)
+	if err != nil {
+		slog.Error("Failed to encode response", "media_type", mediaType, "error", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "Internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
 // writeErrorResponse writes an error response
 func (h *Handlers) writeErrorResponse(w http.ResponseWriter, statusCode int, errorCode, message string) {
 	errorResp := models.NewErrorResponse(message, errorCode)
@@ -368,6 +651,23 @@ func isMaxBytesError(err error) bool {
 	return errors.As(err, &maxBytesErr)
 }
 
+// longPollGrace is added to a long-poll's write deadline to leave time for the
+// final check and the response write.
+const longPollGrace = 10 * time.Second
+
+// parseWait parses the wait query parameter as a Go duration ("60s", "1m") or
+// a bare number of seconds. Negative values are rejected.
+func parseWait(s string) (time.Duration, error) {
+	wait, err := time.ParseDuration(s)
+	if seconds, convErr := strconv.Atoi(s); convErr == nil {
+		wait, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err == nil && wait < 0 {
+		err = errors.New("negative wait")
+	}
+	return wait, err
+}
+
 // splitAndTrim splits a string by delimiter and trims whitespace
 func splitAndTrim(s, delim string) []string {
 	if s == "" {
@@ -394,23 +694,14 @@ func getAPIKeyName(apiKey *models.APIKey) string {
 	return "unnamed-key"
 }
 
-// getClientIP extracts the client IP address from the request
+// getClientIP returns the client IP resolved by the route's clientIPResolver.
+// Requests that did not pass through one, such as in handler tests, are
+// attributed to their peer.
 func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header first (for proxies)
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		ips := strings.Split(xff, ",")
-		if len(ips) > 0 {
-			return strings.TrimSpace(ips[0])
-		}
-	}
-
-	// Check X-Real-IP header
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return xri
+	if ip, ok := r.Context().Value(clientIPContextKey{}).(string); ok {
+		return ip
 	}
-
-	// Fallback to RemoteAddr
-	return r.RemoteAddr
+	return newClientIPResolver(nil).resolve(r)
 }
 
 // recordUpdateCheck increments the update-check counter when app metrics are configured.
@@ -424,6 +715,22 @@ func (h *Handlers) recordUpdateCheck(r *http.Request, appID, result string) {
 	))
 }
 
+// recordPriorityCheck increments the priority-check counter when app metrics
+// are configured. The lane label tells checks served under load shedding apart.
+func (h *Handlers) recordPriorityCheck(r *http.Request, appID string, priorityOnly bool) {
+	if h.appMetrics == nil {
+		return
+	}
+	lane := routeClassPublic
+	if priorityOnly {
+		lane = routeClassPriority
+	}
+	h.appMetrics.PriorityChecks.Add(r.Context(), 1, metric.WithAttributes(
+		attribute.String("app_id", appID),
+		attribute.String("lane", lane),
+	))
+}
+
 // recordReleaseRegistered increments the release-registered counter when app metrics are configured.
 func (h *Handlers) recordReleaseRegistered(r *http.Request, appID string) {
 	if h.appMetrics == nil {
@@ -433,3 +740,19 @@ func (h *Handlers) recordReleaseRegistered(r *http.Request, appID string) {
 		attribute.String("app_id", appID),
 	))
 }
+
+// recordPanic increments the recovered-panic counter when app metrics are
+// configured. The route template keeps the path label bounded.
+func (h *Handlers) recordPanic(r *http.Request) {
+	if h.appMetrics == nil {
+		return
+	}
+	path := routePath(r)
+	if path == "" {
+		path = r.URL.Path
+	}
+	h.appMetrics.Panics.Add(r.Context(), 1, metric.WithAttributes(
+		attribute.String("method", r.Method),
+		attribute.String("path", path),
+	))
+}
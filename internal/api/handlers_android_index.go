@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AndroidIndex serves the newest APK of each architecture of an Android app,
+// so a sideloaded app's updater can read one JSON index and pick the APK for
+// its ABI. The index is the same for every installed version.
+// GET /api/v1/updates/{app_id}/android?channel=beta
+func (h *Handlers) AndroidIndex(w http.ResponseWriter, r *http.Request) {
+	index, err := h.updateService.GetAndroidIndex(r.Context(), mux.Vars(r)["app_id"],
+		r.URL.Query().Get("channel"), r.Header.Get(licenseTokenHeader))
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", electronCacheControl)
+	h.writeJSONResponse(w, http.StatusOK, index)
+}
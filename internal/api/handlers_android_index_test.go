@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_AndroidIndex(t *testing.T) {
+	h := newTestHandlers(t)
+	ctx := context.Background()
+	_, err := h.updateService.CreateApplication(ctx, &models.CreateApplicationRequest{
+		ID: "droid-app", Name: "Droid App", Platforms: []string{"android"},
+	})
+	require.NoError(t, err)
+	_, err = h.updateService.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "droid-app", Version: "2.0.0", Platform: "android", Architecture: "arm64",
+		DownloadURL: "https://cdn.example.com/app-2.0.0.apk", Checksum: "abc123", ChecksumType: "sha256",
+		Variant: models.VariantAPK, VersionCode: 200,
+		SigningCertSHA256: "A4:0D:A8:0A:59:D1:70:CA:A9:50:CF:15:C1:8C:45:4D:47:A3:9B:26:98:9D:8B:64:0E:CD:74:5B:A7:1B:F5:DC",
+	})
+	require.NoError(t, err)
+	vars := map[string]string{"app_id": "droid-app"}
+
+	t.Run("index", func(t *testing.T) {
+		rr := serveElectron(h, h.AndroidIndex, "/api/v1/updates/droid-app/android", vars)
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, electronCacheControl, rr.Header().Get("Cache-Control"))
+		var index models.AndroidIndex
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &index))
+		assert.Equal(t, "stable", index.Channel)
+		require.Len(t, index.Packages, 1)
+		assert.Equal(t, "arm64-v8a", index.Packages[0].ABI)
+		assert.Equal(t, "2.0.0", index.Packages[0].VersionName)
+		assert.Equal(t, int64(200), index.Packages[0].VersionCode)
+		assert.Equal(t, "a40da80a59d170caa950cf15c18c454d47a39b26989d8b640ecd745ba71bf5dc", index.Packages[0].SigningCertSHA256)
+		assert.Equal(t, "https://cdn.example.com/app-2.0.0.apk", index.Packages[0].DownloadURL)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		rr := serveElectron(h, h.AndroidIndex, "/api/v1/updates/missing/android", map[string]string{"app_id": "missing"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
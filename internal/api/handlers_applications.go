@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"updater/internal/models"
 
 	"github.com/gorilla/mux"
@@ -45,6 +46,11 @@ func (h *Handlers) CreateApplication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A ?template= query parameter takes precedence over a template in the body
+	if template := r.URL.Query().Get("template"); template != "" {
+		req.Template = template
+	}
+
 	// Create application
 	response, err := h.updateService.CreateApplication(r.Context(), &req)
 	if err != nil {
@@ -66,6 +72,57 @@ func (h *Handlers) CreateApplication(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, http.StatusCreated, response)
 }
 
+// CloneApplication handles application cloning requests
+// POST /api/v1/applications/{app_id}/clone
+// Requires authentication and 'write' permission
+func (h *Handlers) CloneApplication(w http.ResponseWriter, r *http.Request) {
+	apiKey := GetAPIKey(r)
+	sourceID := mux.Vars(r)["app_id"]
+
+	slog.Warn("Application clone attempt",
+		"event", "security_audit",
+		"source_id", sourceID,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" || !strings.HasPrefix(contentType, "application/json") {
+		h.writeErrorResponse(w, http.StatusUnsupportedMediaType, models.ErrorCodeBadRequest, "Content-Type must be application/json")
+		return
+	}
+
+	var req models.CloneApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid JSON body")
+		return
+	}
+
+	response, err := h.updateService.CloneApplication(r.Context(), sourceID, &req)
+	if err != nil {
+		slog.Warn("Application clone failed",
+			"event", "security_audit",
+			"source_id", sourceID,
+			"app_id", req.ID,
+			"api_key", getAPIKeyName(apiKey),
+			"error", err.Error())
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Application cloned successfully",
+		"event", "security_audit",
+		"source_id", sourceID,
+		"app_id", response.ID,
+		"releases_copied", response.ReleasesCopied,
+		"api_key", getAPIKeyName(apiKey))
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
 // GetApplication handles application retrieval requests
 // GET /api/v1/applications/{app_id}
 func (h *Handlers) GetApplication(w http.ResponseWriter, r *http.Request) {
@@ -79,9 +136,60 @@ func (h *Handlers) GetApplication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ?client_id= shows one client's slot in the check schedule
+	if clientID := r.URL.Query().Get("client_id"); clientID != "" && response.Schedule != nil {
+		response.Schedule.ForClient(response.ID, clientID, time.Now())
+	}
+
+	h.writeFieldsResponse(w, r, http.StatusOK, response, "")
+}
+
+// GetApplicationUsage handles application usage requests
+// GET /api/v1/applications/{app_id}/usage
+func (h *Handlers) GetApplicationUsage(w http.ResponseWriter, r *http.Request) {
+	response, err := h.updateService.GetApplicationUsage(r.Context(), mux.Vars(r)["app_id"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// GetIntegrationSnippets handles client integration snippet requests
+// GET /api/v1/applications/{app_id}/snippets?platform=&architecture=&base_url=
+func (h *Handlers) GetIntegrationSnippets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &models.IntegrationSnippetsRequest{
+		ApplicationID: mux.Vars(r)["app_id"],
+		BaseURL:       query.Get("base_url"),
+		Platform:      query.Get("platform"),
+		Architecture:  query.Get("architecture"),
+	}
+	if req.BaseURL == "" {
+		req.BaseURL = requestBaseURL(r)
+	}
+
+	response, err := h.updateService.GetIntegrationSnippets(r.Context(), req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// requestBaseURL returns the scheme and host the request was addressed to.
+// Behind a TLS-terminating proxy the scheme is reported as http, so callers
+// that know the public address should pass ?base_url= instead.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
 // ListApplications handles application listing requests
 // GET /api/v1/applications
 func (h *Handlers) ListApplications(w http.ResponseWriter, r *http.Request) {
@@ -104,6 +212,14 @@ func (h *Handlers) ListApplications(w http.ResponseWriter, r *http.Request) {
 		After: after,
 	}
 
+	// Parse tags array; applications must carry every listed tag
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		req.Tags = splitAndTrim(tags, ",")
+	}
+
+	// Restrict to a single application group
+	req.Group = r.URL.Query().Get("group")
+
 	// List applications
 	response, err := h.updateService.ListApplications(r.Context(), req)
 	if err != nil {
@@ -111,6 +227,30 @@ func (h *Handlers) ListApplications(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.writeFieldsResponse(w, r, http.StatusOK, response, "applications")
+}
+
+// ListApplicationGroups handles application group listing requests
+// GET /api/v1/groups
+func (h *Handlers) ListApplicationGroups(w http.ResponseWriter, r *http.Request) {
+	response, err := h.updateService.ListApplicationGroups(r.Context())
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// ListApplicationTemplates handles application template listing requests
+// GET /api/v1/templates
+func (h *Handlers) ListApplicationTemplates(w http.ResponseWriter, r *http.Request) {
+	response, err := h.updateService.ListApplicationTemplates(r.Context())
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
@@ -174,6 +314,68 @@ func (h *Handlers) UpdateApplication(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// ApplyApplicationDesiredState handles declarative application configuration
+// PUT /api/v1/applications/{app_id}/desired-state
+// Requires authentication and 'admin' permission
+func (h *Handlers) ApplyApplicationDesiredState(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["app_id"]
+	apiKey := GetAPIKey(r)
+
+	slog.Warn("Application desired state apply attempt",
+		"event", "security_audit",
+		"app_id", appID,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" || !strings.HasPrefix(contentType, "application/json") {
+		h.writeErrorResponse(w, http.StatusUnsupportedMediaType, models.ErrorCodeBadRequest, "Content-Type must be application/json")
+		return
+	}
+
+	// Unknown fields are rejected rather than ignored, so a document written
+	// for settings this server does not have is never half applied
+	var state models.ApplicationDesiredState
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&state); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		slog.Warn("Invalid JSON in application desired state",
+			"event", "security_audit",
+			"app_id", appID,
+			"api_key", getAPIKeyName(apiKey))
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid desired state document: "+err.Error())
+		return
+	}
+
+	response, err := h.updateService.ApplyApplicationDesiredState(r.Context(), appID, &state)
+	if err != nil {
+		slog.Warn("Application desired state apply failed",
+			"event", "security_audit",
+			"app_id", appID,
+			"api_key", getAPIKeyName(apiKey),
+			"error", err.Error())
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Application desired state applied",
+		"event", "security_audit",
+		"app_id", appID,
+		"api_key", getAPIKeyName(apiKey),
+		"created", response.Created,
+		"changes", response.Changes)
+
+	status := http.StatusOK
+	if response.Created {
+		status = http.StatusCreated
+	}
+	h.writeJSONResponse(w, status, response)
+}
+
 // DeleteApplication handles application deletion requests
 // DELETE /api/v1/applications/{app_id}
 // Requires authentication and 'admin' permission
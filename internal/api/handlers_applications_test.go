@@ -2,10 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 	"updater/internal/models"
 	"updater/internal/storage"
 	"updater/internal/update"
@@ -128,6 +131,126 @@ func TestHandlers_CreateApplication(t *testing.T) {
 	}
 }
 
+func TestHandlers_CreateApplication_Template(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	svc := update.NewService(store, update.WithApplicationTemplates([]models.ApplicationTemplate{
+		{Name: "desktop-app", Platforms: []string{"windows", "darwin"}, Group: "Desktop"},
+	}))
+	h := NewHandlers(svc, WithStorage(store))
+
+	body, _ := json.Marshal(models.CreateApplicationRequest{ID: "test-app", Name: "Test Application"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/applications?template=desktop-app", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h.CreateApplication(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	app, err := store.GetApplication(req.Context(), "test-app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"windows", "darwin"}, app.Platforms)
+	assert.Equal(t, "Desktop", app.Group)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/applications?template=missing", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	h.CreateApplication(rr, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/templates", nil)
+	rr = httptest.NewRecorder()
+	h.ListApplicationTemplates(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"templates":[{"name":"desktop-app","platforms":["windows","darwin"],"group":"Desktop"}]}`, rr.Body.String())
+}
+
+func TestHandlers_CloneApplication(t *testing.T) {
+	tests := []struct {
+		name           string
+		sourceID       string
+		body           models.CloneApplicationRequest
+		expectedStatus int
+		expectedCopies int
+	}{
+		{
+			name:           "clone with releases",
+			sourceID:       "test-app",
+			body:           models.CloneApplicationRequest{ID: "test-app-enterprise", Name: "Test Enterprise", Releases: 2},
+			expectedStatus: http.StatusCreated,
+			expectedCopies: 3,
+		},
+		{
+			name:           "clone without releases",
+			sourceID:       "test-app",
+			body:           models.CloneApplicationRequest{ID: "test-app-copy"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "source not found",
+			sourceID:       "missing-app",
+			body:           models.CloneApplicationRequest{ID: "test-app-copy"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "target exists",
+			sourceID:       "test-app",
+			body:           models.CloneApplicationRequest{ID: "test-app"},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "too many releases",
+			sourceID:       "test-app",
+			body:           models.CloneApplicationRequest{ID: "test-app-copy", Releases: models.MaxCloneReleaseVersions + 1},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandlers(t)
+			createTestApplication(t, h, "test-app", "Test App")
+			createTestRelease(t, h, "test-app", "1.0.0", "windows", "amd64")
+			createTestRelease(t, h, "test-app", "1.1.0", "windows", "amd64")
+			createTestRelease(t, h, "test-app", "1.1.0", "linux", "amd64")
+
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/applications/"+tt.sourceID+"/clone", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"app_id": tt.sourceID})
+			rr := httptest.NewRecorder()
+
+			h.CloneApplication(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				return
+			}
+
+			var resp models.CloneApplicationResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			assert.Equal(t, tt.body.ID, resp.ID)
+			assert.Equal(t, "test-app", resp.SourceID)
+			assert.Equal(t, tt.expectedCopies, resp.ReleasesCopied)
+
+			req = httptest.NewRequest(http.MethodGet, "/api/v1/applications/"+resp.ID, nil)
+			req = mux.SetURLVars(req, map[string]string{"app_id": resp.ID})
+			rr = httptest.NewRecorder()
+			h.GetApplication(rr, req)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var info models.ApplicationInfoResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&info))
+			assert.Equal(t, []string{"windows", "linux"}, info.Platforms)
+			assert.Equal(t, tt.expectedCopies, info.Stats.TotalReleases)
+			if tt.body.Name != "" {
+				assert.Equal(t, tt.body.Name, info.Name)
+			} else {
+				assert.Equal(t, "Test App", info.Name)
+			}
+		})
+	}
+}
+
 func TestHandlers_GetApplication(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -175,6 +298,123 @@ func TestHandlers_GetApplication(t *testing.T) {
 	}
 }
 
+func TestHandlers_GetApplication_CheckSchedule(t *testing.T) {
+	h := newTestHandlers(t)
+	_, err := h.updateService.CreateApplication(context.Background(), &models.CreateApplicationRequest{
+		ID:        "fleet-app",
+		Name:      "Fleet App",
+		Platforms: []string{"windows"},
+		Config:    models.ApplicationConfig{UpdateInterval: 3600},
+	})
+	require.NoError(t, err)
+
+	get := func(query string) models.ApplicationInfoResponse {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/applications/fleet-app"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"app_id": "fleet-app"})
+		rr := httptest.NewRecorder()
+		h.GetApplication(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp models.ApplicationInfoResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp
+	}
+
+	resp := get("")
+	require.NotNil(t, resp.Schedule)
+	assert.Equal(t, int64(3600), resp.Schedule.IntervalSeconds)
+	assert.Equal(t, models.CheckSpreadClientHash, resp.Schedule.Spread)
+	assert.Nil(t, resp.Schedule.OffsetSeconds)
+
+	resp = get("?client_id=device-1")
+	require.NotNil(t, resp.Schedule.OffsetSeconds)
+	assert.Equal(t, int64(models.CheckOffset("fleet-app", "device-1", time.Hour)/time.Second), *resp.Schedule.OffsetSeconds)
+	require.NotNil(t, resp.Schedule.NextCheckAt)
+	assert.WithinDuration(t, time.Now(), *resp.Schedule.NextCheckAt, time.Hour)
+}
+
+func TestHandlers_GetIntegrationSnippets(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "snippet-app", "Snippet App")
+
+	tests := []struct {
+		name        string
+		query       string
+		wantCode    int
+		wantBaseURL string
+		wantInCurl  string
+	}{
+		{name: "defaults from request", wantCode: http.StatusOK, wantBaseURL: "http://updates.example.com", wantInCurl: "platform=windows&architecture=amd64"},
+		{name: "explicit target and base URL", query: "?platform=linux&architecture=arm64&base_url=https://updates.example.com/", wantCode: http.StatusOK, wantBaseURL: "https://updates.example.com", wantInCurl: "platform=linux&architecture=arm64"},
+		{name: "unsupported platform", query: "?platform=darwin", wantCode: http.StatusBadRequest},
+		{name: "invalid base URL", query: "?base_url=ftp://example.com", wantCode: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://updates.example.com/api/v1/applications/snippet-app/snippets"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"app_id": "snippet-app"})
+			rr := httptest.NewRecorder()
+			h.GetIntegrationSnippets(rr, req)
+
+			require.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var resp models.IntegrationSnippetsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantBaseURL, resp.BaseURL)
+			require.NotEmpty(t, resp.Snippets)
+			assert.Equal(t, "curl", resp.Snippets[0].Name)
+			assert.Contains(t, resp.Snippets[0].Content, tt.wantBaseURL+"/api/v1/updates/snippet-app/check?")
+			assert.Contains(t, resp.Snippets[0].Content, tt.wantInCurl)
+		})
+	}
+}
+
+func TestHandlers_GetApplicationUsage(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "usage-app", "Usage App")
+	_, err := h.updateService.RegisterRelease(context.Background(), &models.RegisterReleaseRequest{
+		ApplicationID: "usage-app",
+		Version:       "1.0.0",
+		Platform:      "windows",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/app.exe",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+		FileSize:      2048,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		appID    string
+		wantCode int
+	}{
+		{name: "existing application", appID: "usage-app", wantCode: http.StatusOK},
+		{name: "unknown application", appID: "missing-app", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/applications/"+tt.appID+"/usage", nil)
+			req = mux.SetURLVars(req, map[string]string{"app_id": tt.appID})
+			rr := httptest.NewRecorder()
+			h.GetApplicationUsage(rr, req)
+
+			require.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var resp models.ApplicationUsageResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, 1, resp.Releases)
+			assert.Equal(t, 1, resp.Artifacts)
+			assert.Equal(t, int64(2048), resp.ArtifactBytes)
+		})
+	}
+}
+
 func TestHandlers_ListApplications(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -280,6 +520,50 @@ func TestHandlers_UpdateApplication(t *testing.T) {
 	}
 }
 
+func TestHandlers_ApplyApplicationDesiredState(t *testing.T) {
+	h := newTestHandlers(t)
+
+	apply := func(body string) (*httptest.ResponseRecorder, models.ApplyDesiredStateResponse) {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/applications/gitops-app/desired-state", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = mux.SetURLVars(req, map[string]string{"app_id": "gitops-app"})
+		rr := httptest.NewRecorder()
+		h.ApplyApplicationDesiredState(rr, req)
+
+		var resp models.ApplyDesiredStateResponse
+		if rr.Code < 300 {
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		}
+		return rr, resp
+	}
+
+	const document = `{"name":"GitOps App","platforms":["linux"],"tags":["Managed"],"config":{"custom_fields":{"team":"infra"}}}`
+
+	rr, resp := apply(document)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	assert.True(t, resp.Created)
+
+	rr, resp = apply(document)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, resp.Created)
+	assert.Empty(t, resp.Changes, "applying the same document again changes nothing")
+
+	rr, resp = apply(`{"name":"GitOps App","platforms":["linux","windows"]}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"platforms", "config", "tags"}, resp.Changes, "omitted fields are reset")
+
+	app, err := h.storage.GetApplication(context.Background(), "gitops-app")
+	require.NoError(t, err)
+	assert.Empty(t, app.Tags)
+	assert.Empty(t, app.Config.CustomFields)
+
+	rr, _ = apply(`{"name":"GitOps App","platforms":["linux"],"channels":["beta"]}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "unknown settings are rejected")
+
+	rr, _ = apply(`{"name":"","platforms":["linux"]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
 func TestHandlers_DeleteApplication(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -407,3 +691,146 @@ func TestHandlers_DeleteRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlers_ListApplications_TagFilter(t *testing.T) {
+	h := newTestHandlers(t)
+
+	body, _ := json.Marshal(models.CreateApplicationRequest{
+		ID:        "tagged-app",
+		Name:      "Tagged App",
+		Platforms: []string{"linux"},
+		Tags:      []string{"Internal", "q3-redesign"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/applications", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h.CreateApplication(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	createTestApplication(t, h, "plain-app", "Plain App")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/applications?tags=internal,q3-redesign", nil)
+	rr = httptest.NewRecorder()
+	h.ListApplications(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp models.ListApplicationsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Applications, 1)
+	assert.Equal(t, "tagged-app", resp.Applications[0].ID)
+	assert.Equal(t, []string{"internal", "q3-redesign"}, resp.Applications[0].Tags)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/applications?tags=not%20valid", nil)
+	rr = httptest.NewRecorder()
+	h.ListApplications(rr, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestHandlers_ApplicationGroups(t *testing.T) {
+	h := newTestHandlers(t)
+
+	for _, app := range []models.CreateApplicationRequest{
+		{ID: "billing-api", Name: "Billing API", Platforms: []string{"linux"}, Group: "Payments"},
+		{ID: "billing-ui", Name: "Billing UI", Platforms: []string{"linux"}, Group: "Payments"},
+		{ID: "chat", Name: "Chat", Platforms: []string{"linux"}, Group: "Messaging"},
+	} {
+		body, _ := json.Marshal(app)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/applications", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		h.CreateApplication(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+	createTestApplication(t, h, "loner", "Loner")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/groups", nil)
+	rr := httptest.NewRecorder()
+	h.ListApplicationGroups(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var groups models.ListApplicationGroupsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&groups))
+	assert.Equal(t, []models.ApplicationGroup{
+		{Name: "Messaging", ApplicationCount: 1},
+		{Name: "Payments", ApplicationCount: 2},
+	}, groups.Groups)
+	assert.Equal(t, 1, groups.Ungrouped)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/applications?group=Payments", nil)
+	rr = httptest.NewRecorder()
+	h.ListApplications(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp models.ListApplicationsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, 2, resp.TotalCount)
+	for _, app := range resp.Applications {
+		assert.Equal(t, "Payments", app.Group)
+	}
+}
+
+func TestHandlers_ListPluginUpdates(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "host", "Host")
+
+	body, _ := json.Marshal(models.CreateApplicationRequest{
+		ID: "spellcheck", Name: "Spellcheck", Platforms: []string{"windows"}, ParentID: "host",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/applications", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h.CreateApplication(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	for _, rel := range []struct{ version, constraint string }{
+		{"1.0.0", "< 2.0.0"},
+		{"2.0.0", ">= 2.0.0"},
+	} {
+		body, _ := json.Marshal(models.RegisterReleaseRequest{
+			ApplicationID:         "spellcheck",
+			Version:               rel.version,
+			Platform:              "windows",
+			Architecture:          "amd64",
+			DownloadURL:           "https://example.com/download",
+			Checksum:              "abc123def456",
+			ChecksumType:          "sha256",
+			FileSize:              1024,
+			HostVersionConstraint: rel.constraint,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/spellcheck/register", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = mux.SetURLVars(req, map[string]string{"app_id": "spellcheck"})
+		rr := httptest.NewRecorder()
+		h.RegisterRelease(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/updates/host/plugins?host_version=1.4.0&platform=windows&architecture=amd64", nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "host"})
+	rr = httptest.NewRecorder()
+	h.ListPluginUpdates(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp models.PluginUpdatesResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Plugins, 1)
+	assert.Equal(t, "spellcheck", resp.Plugins[0].ApplicationID)
+	require.NotNil(t, resp.Plugins[0].Release)
+	assert.Equal(t, "1.0.0", resp.Plugins[0].Release.Version)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/updates/spellcheck/check?current_version=1.0.0&platform=windows&architecture=amd64&host_version=2.1.0", nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "spellcheck"})
+	rr = httptest.NewRecorder()
+	h.CheckForUpdates(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var check models.UpdateCheckResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&check))
+	assert.True(t, check.UpdateAvailable)
+	assert.Equal(t, "2.0.0", check.LatestVersion)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/updates/host/plugins?platform=windows&architecture=amd64", nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "host"})
+	rr = httptest.NewRecorder()
+	h.ListPluginUpdates(rr, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
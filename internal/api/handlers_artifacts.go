@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"time"
+	"updater/internal/blob"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// artifactUploadRoute and artifactUploadByIDRoute name the upload routes,
+// whose bodies are limited by the artifact size instead of maxRequestBodySize.
+const (
+	artifactUploadRoute     = "upload-release-artifact"
+	artifactUploadByIDRoute = "upload-release-artifact-by-id"
+)
+
+// WithArtifacts enables artifact uploads with the limits of cfg. files serves
+// the stored artifacts under /artifacts/ when the server hosts them itself, as
+// with the local driver; it is nil when they are downloaded from a bucket.
+func WithArtifacts(cfg models.ArtifactsConfig, files http.Handler) HandlersOption {
+	return func(h *Handlers) {
+		h.artifacts = cfg
+		h.artifactFiles = files
+	}
+}
+
+// UploadReleaseArtifact stores the artifact of a release, sent as the "file"
+// field of a multipart form. Uploads are bounded by artifacts.max_size and
+// artifacts.timeout rather than the server's request limits.
+// POST /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/artifact
+func (h *Handlers) UploadReleaseArtifact(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.uploadArtifact(w, r, slog.String("version", vars["version"]), func(ctx context.Context, body io.Reader) (*models.UploadArtifactResponse, error) {
+		return h.updateService.UploadReleaseArtifact(ctx, vars["app_id"], vars["version"], vars["platform"], vars["arch"], body)
+	})
+}
+
+// UploadReleaseArtifactByID stores the artifact of the release with the ID
+// returned when it was registered, as UploadReleaseArtifact does.
+// POST /api/v1/updates/{app_id}/releases/{id}/artifact
+func (h *Handlers) UploadReleaseArtifactByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.uploadArtifact(w, r, slog.String("release_id", vars["id"]), func(ctx context.Context, body io.Reader) (*models.UploadArtifactResponse, error) {
+		return h.updateService.UploadReleaseArtifactByID(ctx, vars["app_id"], vars["id"], body)
+	})
+}
+
+// uploadArtifact reads the artifact of a multipart upload and stores it with
+// upload. release is the log attribute naming the release.
+func (h *Handlers) uploadArtifact(w http.ResponseWriter, r *http.Request, release slog.Attr,
+	upload func(ctx context.Context, body io.Reader) (*models.UploadArtifactResponse, error)) {
+	appID := mux.Vars(r)["app_id"]
+	apiKey := GetAPIKey(r)
+
+	slog.Warn("Release artifact upload attempt",
+		"event", "security_audit",
+		"app_id", appID,
+		release,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	if h.artifacts.Enabled {
+		// Large uploads outlast the server's read and write timeouts
+		rc := http.NewResponseController(w)
+		deadline := time.Now().Add(h.artifacts.Timeout)
+		if err := rc.SetReadDeadline(deadline); err != nil {
+			slog.Debug("Could not extend read deadline for artifact upload", "error", err)
+		}
+		if err := rc.SetWriteDeadline(deadline); err != nil {
+			slog.Debug("Could not extend write deadline for artifact upload", "error", err)
+		}
+		// The multipart framing around the artifact counts towards the body
+		r.Body = http.MaxBytesReader(w, r.Body, h.artifacts.MaxSize+maxRequestBodySize)
+	} else {
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	}
+
+	part, err := artifactPart(r)
+	if err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Artifact too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest,
+			"body must be a multipart/form-data form with a \""+models.ArtifactUploadField+"\" field")
+		return
+	}
+	defer part.Close()
+
+	ctx := r.Context()
+	if h.artifacts.Enabled {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.artifacts.Timeout)
+		defer cancel()
+	}
+	response, err := upload(ctx, part)
+	if err != nil {
+		slog.Warn("Release artifact upload failed",
+			"event", "security_audit",
+			"app_id", appID,
+			release,
+			"api_key", getAPIKeyName(apiKey),
+			"error", err.Error())
+		if isMaxBytesError(err) || errors.Is(err, blob.ErrTooLarge) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Artifact too large")
+			return
+		}
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Release artifact uploaded",
+		"event", "security_audit",
+		"app_id", appID,
+		"release_id", response.ID,
+		"file_size", response.FileSize,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// artifactPart returns the part of a multipart upload that carries the
+// artifact, skipping any other fields before it.
+func artifactPart(r *http.Request) (*multipart.Part, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing " + models.ArtifactUploadField + " field")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == models.ArtifactUploadField {
+			return part, nil
+		}
+		part.Close()
+	}
+}
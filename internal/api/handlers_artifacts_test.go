@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/blob"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartUpload returns a multipart form with a notes field and the
+// artifact in the file field, and its content type.
+func multipartUpload(t *testing.T, artifact []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	require.NoError(t, mw.WriteField("notes", "ignored"))
+	fw, err := mw.CreateFormFile(models.ArtifactUploadField, "app.exe")
+	require.NoError(t, err)
+	_, err = fw.Write(artifact)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+	return &body, mw.FormDataContentType()
+}
+
+func TestHandlers_UploadReleaseArtifact(t *testing.T) {
+	mockService := &MockUpdateService{}
+	cfg := models.Config{Artifacts: models.ArtifactsConfig{Enabled: true, MaxSize: 2 << 20, Timeout: time.Minute}}
+	router := SetupRoutes(NewHandlers(mockService, WithArtifacts(cfg.Artifacts, nil)), &cfg)
+	upload := func(artifact []byte) *httptest.ResponseRecorder {
+		body, contentType := multipartUpload(t, artifact)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/test-app/releases/1.0.0/windows/amd64/artifact", body)
+		req.Header.Set("Content-Type", contentType)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Artifacts may be larger than the limit on other request bodies
+	artifact := bytes.Repeat([]byte("x"), maxRequestBodySize+1)
+	mockService.On("UploadReleaseArtifact", mock.Anything, "test-app", "1.0.0", "windows", "amd64", string(artifact)).
+		Return(&models.UploadArtifactResponse{ID: "rel-1", FileSize: int64(len(artifact))}, nil).Once()
+	rr := upload(artifact)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp models.UploadArtifactResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "rel-1", resp.ID)
+
+	rr = upload(bytes.Repeat([]byte("x"), 3<<20))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+	mockService.On("UploadReleaseArtifact", mock.Anything, "test-app", "1.0.0", "windows", "amd64", "binary").
+		Return(nil, update.NewValidationError("artifact exceeds the maximum size", blob.ErrTooLarge)).Once()
+	rr = upload([]byte("binary"))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+	mockService.On("UploadReleaseArtifact", mock.Anything, "test-app", "1.0.0", "windows", "amd64", "tampered").
+		Return(nil, update.NewValidationError("artifact does not match the release's sha256 checksum", nil)).Once()
+	rr = upload([]byte("tampered"))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/test-app/releases/1.0.0/windows/amd64/artifact", strings.NewReader("binary"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_UploadReleaseArtifactByID(t *testing.T) {
+	mockService := &MockUpdateService{}
+	cfg := models.Config{Artifacts: models.ArtifactsConfig{Enabled: true, MaxSize: 2 << 20, Timeout: time.Minute}}
+	router := SetupRoutes(NewHandlers(mockService, WithArtifacts(cfg.Artifacts, nil)), &cfg)
+	upload := func(artifact []byte) *httptest.ResponseRecorder {
+		body, contentType := multipartUpload(t, artifact)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/test-app/releases/01JREL0/artifact", body)
+		req.Header.Set("Content-Type", contentType)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// The artifact size limit applies here too, not the limit on other bodies
+	artifact := bytes.Repeat([]byte("x"), maxRequestBodySize+1)
+	mockService.On("UploadReleaseArtifactByID", mock.Anything, "test-app", "01JREL0", string(artifact)).
+		Return(&models.UploadArtifactResponse{ID: "01JREL0", FileSize: int64(len(artifact))}, nil).Once()
+	rr := upload(artifact)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp models.UploadArtifactResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "01JREL0", resp.ID)
+
+	rr = upload(bytes.Repeat([]byte("x"), 3<<20))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+	mockService.On("UploadReleaseArtifactByID", mock.Anything, "test-app", "01JREL0", "binary").
+		Return(nil, update.NewNotFoundError("release '01JREL0' of application 'test-app' not found")).Once()
+	rr = upload([]byte("binary"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_UploadReleaseArtifact_NotEnabled(t *testing.T) {
+	mockService := &MockUpdateService{}
+	router := SetupRoutes(NewHandlers(mockService), &models.Config{})
+
+	// Without artifact uploads the upload route has the usual body limit
+	body, contentType := multipartUpload(t, bytes.Repeat([]byte("x"), maxRequestBodySize+1))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/test-app/releases/1.0.0/windows/amd64/artifact", body)
+	req.Header.Set("Content-Type", contentType)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRoutes_ServeLocalArtifacts(t *testing.T) {
+	files, err := blob.NewLocal(t.TempDir(), "https://updates.example.com/artifacts")
+	require.NoError(t, err)
+	require.NoError(t, files.Put(context.Background(), "test-app/rel-1", blob.Object{Body: strings.NewReader("binary"), Size: 6}))
+	cfg := models.Config{Artifacts: models.ArtifactsConfig{Enabled: true, MaxSize: 1 << 20, Timeout: time.Minute}}
+	router := SetupRoutes(NewHandlers(&MockUpdateService{}, WithArtifacts(cfg.Artifacts, files)), &cfg)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/artifacts/test-app/rel-1", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "binary", rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/artifacts/test-app/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
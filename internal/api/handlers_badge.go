@@ -0,0 +1,133 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// badgeDefaultLabel is the left-hand text used when no ?label= is given.
+	badgeDefaultLabel = "version"
+
+	// badgeMaxLabelLength caps caller-supplied labels so a badge stays readable.
+	badgeMaxLabelLength = 50
+
+	// badgeCacheControl lets CDNs and README renderers cache badges briefly
+	// without hiding a new release for long.
+	badgeCacheControl = "public, max-age=300"
+)
+
+// badgeColors maps the shields.io named colours used by this service to hex values.
+var badgeColors = map[string]string{
+	"blue":      "#007ec6",
+	"lightgrey": "#9f9f9f",
+}
+
+// VersionBadgeSVG renders the latest stable version of an application as a
+// shields.io-style flat SVG badge.
+// GET /badge/{app_id}/version.svg
+func (h *Handlers) VersionBadgeSVG(w http.ResponseWriter, r *http.Request) {
+	badge, status, ok := h.resolveVersionBadge(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	w.Header().Set("Cache-Control", badgeCacheControl)
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(renderBadgeSVG(badge)))
+}
+
+// VersionBadgeJSON returns the latest stable version of an application in the
+// shields.io endpoint badge schema.
+// GET /badge/{app_id}/version.json
+func (h *Handlers) VersionBadgeJSON(w http.ResponseWriter, r *http.Request) {
+	badge, status, ok := h.resolveVersionBadge(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Cache-Control", badgeCacheControl)
+	h.writeJSONResponse(w, status, badge)
+}
+
+// resolveVersionBadge looks up the version badge for the requested application.
+// Unknown applications yield a "not found" badge with a 404 status so that image
+// embeds still render. Other service failures are written as JSON errors and
+// ok is false.
+func (h *Handlers) resolveVersionBadge(w http.ResponseWriter, r *http.Request) (models.BadgeResponse, int, bool) {
+	label := r.URL.Query().Get("label")
+	if label == "" {
+		label = badgeDefaultLabel
+	}
+	if utf8.RuneCountInString(label) > badgeMaxLabelLength {
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest,
+			fmt.Sprintf("label cannot exceed %d characters", badgeMaxLabelLength))
+		return models.BadgeResponse{}, 0, false
+	}
+
+	badge := models.BadgeResponse{SchemaVersion: 1, Label: label}
+
+	version, err := h.updateService.GetLatestStableVersion(r.Context(), mux.Vars(r)["app_id"])
+	if err != nil {
+		var serviceError *update.ServiceError
+		if errors.As(err, &serviceError) && serviceError.StatusCode == http.StatusNotFound {
+			badge.Message = "not found"
+			badge.Color = "lightgrey"
+			badge.IsError = true
+			return badge, http.StatusNotFound, true
+		}
+		h.writeServiceErrorResponse(w, err)
+		return models.BadgeResponse{}, 0, false
+	}
+
+	if version == "" {
+		badge.Message = "none"
+		badge.Color = "lightgrey"
+		return badge, http.StatusOK, true
+	}
+
+	badge.Message = "v" + strings.TrimPrefix(version, "v")
+	badge.Color = "blue"
+	return badge, http.StatusOK, true
+}
+
+// renderBadgeSVG draws a flat two-part badge. Text widths are approximated
+// from the rune count, which is close enough for the 11px Verdana used by
+// shields.io without shipping font metrics.
+func renderBadgeSVG(b models.BadgeResponse) string {
+	labelWidth := badgeTextWidth(b.Label)
+	messageWidth := badgeTextWidth(b.Message)
+	total := labelWidth + messageWidth
+
+	color, ok := badgeColors[b.Color]
+	if !ok {
+		color = badgeColors["lightgrey"]
+	}
+
+	label := html.EscapeString(b.Label)
+	message := html.EscapeString(b.Message)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">`+
+		`<title>%[4]s: %[5]s</title>`+
+		`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`+
+		`<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>`+
+		`<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%[7]d" y="14">%[4]s</text><text x="%[8]d" y="14">%[5]s</text></g></svg>`,
+		total, labelWidth, messageWidth, label, message, color,
+		labelWidth/2, labelWidth+messageWidth/2)
+}
+
+// badgeTextWidth estimates the pixel width of a badge segment including padding.
+func badgeTextWidth(s string) int {
+	return utf8.RuneCountInString(s)*7 + 10
+}
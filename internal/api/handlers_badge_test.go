@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_VersionBadgeJSON(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "badge-app", "Badge App")
+	createTestApplication(t, h, "empty-app", "Empty App")
+	createTestRelease(t, h, "badge-app", "1.2.0", "linux", "amd64")
+	createTestRelease(t, h, "badge-app", "1.4.1", "windows", "amd64")
+	createTestRelease(t, h, "badge-app", "2.0.0-beta.1", "linux", "amd64")
+
+	tests := []struct {
+		name           string
+		appID          string
+		query          string
+		expectedStatus int
+		expected       models.BadgeResponse
+	}{
+		{
+			name:           "highest stable version across platforms",
+			appID:          "badge-app",
+			expectedStatus: http.StatusOK,
+			expected:       models.BadgeResponse{SchemaVersion: 1, Label: "version", Message: "v1.4.1", Color: "blue"},
+		},
+		{
+			name:           "custom label",
+			appID:          "badge-app",
+			query:          "?label=stable",
+			expectedStatus: http.StatusOK,
+			expected:       models.BadgeResponse{SchemaVersion: 1, Label: "stable", Message: "v1.4.1", Color: "blue"},
+		},
+		{
+			name:           "no releases",
+			appID:          "empty-app",
+			expectedStatus: http.StatusOK,
+			expected:       models.BadgeResponse{SchemaVersion: 1, Label: "version", Message: "none", Color: "lightgrey"},
+		},
+		{
+			name:           "unknown application",
+			appID:          "missing",
+			expectedStatus: http.StatusNotFound,
+			expected:       models.BadgeResponse{SchemaVersion: 1, Label: "version", Message: "not found", Color: "lightgrey", IsError: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/badge/"+tt.appID+"/version.json"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"app_id": tt.appID})
+			rr := httptest.NewRecorder()
+
+			h.VersionBadgeJSON(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, badgeCacheControl, rr.Header().Get("Cache-Control"))
+			var got models.BadgeResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestHandlers_VersionBadgeSVG(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "badge-app", "Badge App")
+	createTestRelease(t, h, "badge-app", "1.2.0", "linux", "amd64")
+
+	req := httptest.NewRequest(http.MethodGet, "/badge/badge-app/version.svg?label=%3Cb%3E", nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "badge-app"})
+	rr := httptest.NewRecorder()
+
+	h.VersionBadgeSVG(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "image/svg+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+	body := rr.Body.String()
+	assert.Contains(t, body, "<svg")
+	assert.Contains(t, body, ">v1.2.0</text>")
+	assert.Contains(t, body, "&lt;b&gt;")
+	assert.NotContains(t, body, "<b>")
+}
+
+func TestHandlers_VersionBadge_LabelTooLong(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "badge-app", "Badge App")
+
+	label := strings.Repeat("a", badgeMaxLabelLength+1)
+	req := httptest.NewRequest(http.MethodGet, "/badge/badge-app/version.svg?label="+label, nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "badge-app"})
+	rr := httptest.NewRecorder()
+
+	h.VersionBadgeSVG(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ListChannels lists the channels an application's releases are published to.
+// GET /api/v1/applications/{app_id}/channels
+func (h *Handlers) ListChannels(w http.ResponseWriter, r *http.Request) {
+	response, err := h.updateService.ListChannels(r.Context(), mux.Vars(r)["app_id"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// PublishToChannel moves every release of a version to a channel.
+// PUT /api/v1/applications/{app_id}/channels/{channel}/releases/{version}
+func (h *Handlers) PublishToChannel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	appID := vars["app_id"]
+	apiKey := GetAPIKey(r)
+	r, ok := h.breakGlass(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.updateService.PublishToChannel(r.Context(), appID, vars["channel"], vars["version"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Release published to channel",
+		"event", "security_audit",
+		"app_id", appID,
+		"channel", response.Channel,
+		"version", response.Version,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_Channels(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "channel-app", "Channel App")
+	createTestRelease(t, h, "channel-app", "1.1.0", "windows", "amd64")
+	createTestRelease(t, h, "channel-app", "1.2.0-beta.1", "windows", "amd64")
+
+	config := models.NewDefaultConfig()
+	config.Security.EnableAuth = false
+	router := SetupRoutes(h, config)
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+		return rr
+	}
+	latest := func(channel string) string {
+		rr := serve(http.MethodGet, "/api/v1/updates/channel-app/check?current_version=1.0.0&platform=windows&architecture=amd64&channel="+channel)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp models.UpdateCheckResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp.LatestVersion
+	}
+
+	assert.Equal(t, "1.1.0", latest("stable"))
+	assert.Equal(t, "1.2.0-beta.1", latest("beta"))
+
+	rr := serve(http.MethodPut, "/api/v1/applications/channel-app/channels/stable/releases/1.2.0-beta.1")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var published models.PublishToChannelResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+	assert.Equal(t, "stable", published.Channel)
+	assert.Len(t, published.ReleaseIDs, 1)
+	assert.Equal(t, "1.2.0-beta.1", latest("stable"))
+
+	rr = serve(http.MethodGet, "/api/v1/applications/channel-app/channels")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var list models.ChannelListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	require.Len(t, list.Channels, 3)
+	assert.Equal(t, 2, list.Channels[0].Releases)
+	assert.Equal(t, "1.2.0-beta.1", list.Channels[0].LatestVersion)
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/api/v1/applications/channel-app/channels/stable/releases/9.9.9").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/applications/missing-app/channels").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(http.MethodGet, "/api/v1/updates/channel-app/check?current_version=1.0.0&platform=windows&architecture=amd64&channel=Not%20Valid").Code)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"updater/internal/clientbundle"
+	"updater/internal/models"
+	"updater/internal/signing"
+)
+
+// WithClientBundle serves the client configuration bundle signed by signer.
+func WithClientBundle(signer *clientbundle.Signer) HandlersOption {
+	return func(h *Handlers) { h.clientBundle = signer }
+}
+
+// ClientBundle returns the signed client configuration bundle.
+// GET /api/v1/client-bundle
+func (h *Handlers) ClientBundle(w http.ResponseWriter, r *http.Request) {
+	signed, err := h.clientBundle.Bundle(r.Context())
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to sign client bundle")
+		return
+	}
+	// Clients must see a raised version or a flipped kill switch on their
+	// next fetch, so shared caches may keep the bundle only briefly.
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	h.writeJSONResponse(w, http.StatusOK, signed)
+}
+
+// ClientBundlePublicKey returns the PEM-encoded public key client bundles are
+// currently signed with, for clients to embed at build time.
+// GET /api/v1/keys/client-bundle
+func (h *Handlers) ClientBundlePublicKey(w http.ResponseWriter, r *http.Request) {
+	pub, err := h.clientBundle.PublicKey()
+	var key []byte
+	if err == nil {
+		key, err = signing.PublicKeyPEM(pub)
+	}
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to encode client bundle key")
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(key)
+}
+
+// ClientBundleKeyHistory lists every client bundle signing key with its
+// period, status and endorsement by the key before it, so clients can move
+// their trust from an old key to the current one.
+// GET /api/v1/keys/client-bundle/history
+func (h *Handlers) ClientBundleKeyHistory(w http.ResponseWriter, r *http.Request) {
+	history, err := h.clientBundle.History()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to list client bundle keys")
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, history)
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/clientbundle"
+	"updater/internal/models"
+	"updater/internal/signing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_ClientBundle(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	next, _, err := signing.GenerateKey()
+	require.NoError(t, err)
+	signer, err := clientbundle.New(context.Background(), models.ClientBundleConfig{
+		Enabled:  true,
+		Version:  2,
+		Validity: time.Hour,
+		FeedURLs: []string{"https://updates.example.com"},
+		Flags:    map[string]bool{"disable_updates": true},
+	}, []clientbundle.SigningKey{{Key: signing.NewLocal(key)}, {Key: signing.NewLocal(next), NotBefore: time.Now().Add(24 * time.Hour)}})
+	require.NoError(t, err)
+
+	config := &models.Config{Security: models.SecurityConfig{EnableAuth: true}}
+	router := SetupRoutes(NewHandlers(&MockUpdateService{}, WithClientBundle(signer)), config)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/client-bundle", nil))
+	require.Equal(t, http.StatusOK, recorder.Code, "the bundle is public")
+	var signed models.SignedClientBundle
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &signed))
+
+	// Verify against the key as served by the key endpoint
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/keys/client-bundle", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/x-pem-file", recorder.Header().Get("Content-Type"))
+	block, _ := pem.Decode(recorder.Body.Bytes())
+	require.NotNil(t, block)
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+
+	bundle, err := clientbundle.Verify(&signed, []ed25519.PublicKey{pub.(ed25519.PublicKey)}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), bundle.Version)
+	assert.Equal(t, []string{"https://updates.example.com"}, bundle.FeedURLs)
+	assert.True(t, bundle.Flags["disable_updates"])
+
+	// The next key is published, endorsed by the current one
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/keys/client-bundle/history", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var history models.SigningKeyHistoryResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &history))
+	require.Len(t, history.Keys, 2)
+	assert.Equal(t, signed.KeyID, history.Keys[0].KeyID)
+	assert.Equal(t, models.SigningKeyActive, history.Keys[0].Status)
+	assert.Equal(t, models.SigningKeyPending, history.Keys[1].Status)
+	endorsed, _, err := clientbundle.VerifyEndorsement(history.Keys[1].Endorsement, []ed25519.PublicKey{pub.(ed25519.PublicKey)})
+	require.NoError(t, err)
+	assert.True(t, endorsed.Equal(next.Public()))
+}
+
+func TestHandlers_ClientBundle_Disabled(t *testing.T) {
+	router := SetupRoutes(NewHandlers(&MockUpdateService{}), &models.Config{})
+	for _, path := range []string{"/api/v1/client-bundle", "/api/v1/keys/client-bundle", "/api/v1/keys/client-bundle/history"} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code, path)
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"updater/internal/clienttoken"
+	"updater/internal/models"
+)
+
+// clientTokenHeader carries the client token on public update checks when
+// client tokens are enabled.
+const clientTokenHeader = "X-Client-Token"
+
+// WithClientTokens enables the client token endpoints and requires a token on
+// the public update check endpoints.
+func WithClientTokens(issuer *clienttoken.Issuer) HandlersOption {
+	return func(h *Handlers) { h.clientTokens = issuer }
+}
+
+// ClientTokenChallenge issues a proof-of-work challenge.
+// GET /api/v1/client-tokens/challenge
+func (h *Handlers) ClientTokenChallenge(w http.ResponseWriter, r *http.Request) {
+	challenge, err := h.clientTokens.Challenge()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to issue challenge")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeJSONResponse(w, http.StatusOK, challenge)
+}
+
+// IssueClientToken trades a solved challenge for a client token.
+// POST /api/v1/client-tokens
+func (h *Handlers) IssueClientToken(w http.ResponseWriter, r *http.Request) {
+	var req models.ClientTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, err.Error())
+		return
+	}
+
+	token, err := h.clientTokens.Redeem(req.Challenge, req.Nonce)
+	switch {
+	case errors.Is(err, clienttoken.ErrInvalidChallenge), errors.Is(err, clienttoken.ErrInsufficientWork):
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, models.ErrorCodeValidation, err.Error())
+		return
+	case err != nil:
+		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to issue client token")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeJSONResponse(w, http.StatusCreated, token)
+}
+
+// requireClientToken rejects update checks without a valid X-Client-Token when
+// client tokens are enabled. Requests authenticated with an API key are
+// exempt.
+func (h *Handlers) requireClientToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.clientTokens == nil || GetAPIKey(r) != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := h.clientTokens.Verify(r.Header.Get(clientTokenHeader)); err != nil {
+			h.writeErrorResponse(w, http.StatusUnauthorized, models.ErrorCodeUnauthorized,
+				"A valid X-Client-Token is required; obtain one from /api/v1/client-tokens")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
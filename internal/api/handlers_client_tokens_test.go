@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/clienttoken"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_ClientTokens(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.CreateAPIKey(context.Background(), models.NewAPIKey(models.NewKeyID(), "Reader", "read-key-123", []string{"read"})))
+
+	issuer, err := clienttoken.New(models.ClientTokenConfig{Enabled: true, Difficulty: 8, TokenTTL: time.Hour})
+	require.NoError(t, err)
+
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.AnythingOfType("*models.UpdateCheckRequest")).
+		Return(&models.UpdateCheckResponse{UpdateAvailable: false, CurrentVersion: "1.0.0"}, nil)
+
+	config := &models.Config{Security: models.SecurityConfig{EnableAuth: true}}
+	router := SetupRoutes(NewHandlers(mockService, WithStorage(store), WithClientTokens(issuer)), config)
+
+	check := func(header, value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/my-app/check?current_version=1.0.0&platform=linux&architecture=amd64", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, check("", ""), "a check without a token is rejected")
+	assert.Equal(t, http.StatusUnauthorized, check(clientTokenHeader, "bogus"))
+	assert.Equal(t, http.StatusOK, check("Authorization", "Bearer read-key-123"), "API key holders are exempt")
+
+	// Fetch and solve a challenge
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/client-tokens/challenge", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var challenge models.ClientTokenChallengeResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &challenge))
+
+	nonce := ""
+	for n := 0; nonce == ""; n++ {
+		if clienttoken.Solves(challenge.Challenge, strconv.Itoa(n), challenge.Difficulty) {
+			nonce = strconv.Itoa(n)
+		}
+	}
+
+	redeem := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/client-tokens", strings.NewReader(body)))
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusBadRequest, redeem(`{"challenge":"`+challenge.Challenge+`"}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, redeem(`{"challenge":"nope","nonce":"1"}`).Code)
+
+	recorder = redeem(`{"challenge":"` + challenge.Challenge + `","nonce":"` + nonce + `"}`)
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	var token models.ClientTokenResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &token))
+
+	assert.Equal(t, http.StatusOK, check(clientTokenHeader, token.Token))
+	assert.Equal(t, http.StatusUnprocessableEntity, redeem(`{"challenge":"`+challenge.Challenge+`","nonce":"`+nonce+`"}`).Code,
+		"a solved challenge is redeemed once")
+}
+
+func TestHandlers_ClientTokens_Disabled(t *testing.T) {
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.AnythingOfType("*models.UpdateCheckRequest")).
+		Return(&models.UpdateCheckResponse{UpdateAvailable: false, CurrentVersion: "1.0.0"}, nil)
+	router := SetupRoutes(NewHandlers(mockService), &models.Config{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/updates/my-app/check?current_version=1.0.0&platform=linux&architecture=amd64", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code, "checks are open when client tokens are disabled")
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/client-tokens/challenge", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// CheckContainerImage tells an agent whether a newer or re-pushed image is
+// available for the tag (and optionally digest) it is running.
+// GET /api/v1/updates/{app_id}/image?tag=...&digest=...&platform=linux/arm64
+func (h *Handlers) CheckContainerImage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &models.ContainerImageCheckRequest{
+		ApplicationID:   mux.Vars(r)["app_id"],
+		Tag:             query.Get("tag"),
+		Digest:          query.Get("digest"),
+		Platform:        query.Get("platform"),
+		AllowPrerelease: query.Get("allow_prerelease") == "true",
+	}
+
+	response, err := h.updateService.CheckContainerImage(r.Context(), req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	h.writeFieldsResponse(w, r, http.StatusOK, response, "")
+}
+
+// ListContainerImages lists an application's container image tags
+// GET /api/v1/updates/{app_id}/images
+func (h *Handlers) ListContainerImages(w http.ResponseWriter, r *http.Request) {
+	response, err := h.updateService.ListContainerImages(r.Context(), mux.Vars(r)["app_id"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	h.writeFieldsResponse(w, r, http.StatusOK, response, "images")
+}
+
+// RegisterContainerImage registers or replaces a container image tag
+// POST /api/v1/updates/{app_id}/images
+func (h *Handlers) RegisterContainerImage(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["app_id"]
+	apiKey := GetAPIKey(r)
+
+	var req models.RegisterContainerImageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid JSON body")
+		return
+	}
+	req.ApplicationID = appID
+
+	image, err := h.updateService.RegisterContainerImage(r.Context(), &req)
+	if err != nil {
+		slog.Warn("Container image registration failed",
+			"event", "security_audit",
+			"app_id", appID,
+			"tag", req.Tag,
+			"api_key", getAPIKeyName(apiKey),
+			"error", err.Error())
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Container image registered",
+		"event", "security_audit",
+		"app_id", appID,
+		"tag", image.Tag,
+		"digest", image.Digest,
+		"api_key", getAPIKeyName(apiKey))
+
+	h.writeJSONResponse(w, http.StatusCreated, image)
+}
+
+// DeleteContainerImage removes a container image tag
+// DELETE /api/v1/updates/{app_id}/images/{tag}
+func (h *Handlers) DeleteContainerImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	appID := vars["app_id"]
+	tag := vars["tag"]
+	apiKey := GetAPIKey(r)
+
+	slog.Warn("Container image deletion attempt",
+		"event", "security_audit",
+		"app_id", appID,
+		"tag", tag,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	response, err := h.updateService.DeleteContainerImage(r.Context(), appID, tag)
+	if err != nil {
+		slog.Warn("Container image deletion failed",
+			"event", "security_audit",
+			"app_id", appID,
+			"tag", tag,
+			"api_key", getAPIKeyName(apiKey),
+			"error", err.Error())
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Container image deleted successfully",
+		"event", "security_audit",
+		"app_id", appID,
+		"tag", tag,
+		"api_key", getAPIKeyName(apiKey))
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testImageDigestOld = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	testImageDigestNew = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func registerTestImage(t *testing.T, h *Handlers, appID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/"+appID+"/images", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = mux.SetURLVars(req, map[string]string{"app_id": appID})
+	rr := httptest.NewRecorder()
+	h.RegisterContainerImage(rr, req)
+	return rr
+}
+
+func TestHandlers_RegisterContainerImage(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "test-app", "Test App")
+
+	rr := registerTestImage(t, h, "test-app", `{"repository": "ghcr.io/acme/agent", "tag": "1.0.0",
+		"digest": "`+testImageDigestOld+`", "platforms": ["linux/amd64", "linux/arm64"]}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var image models.ContainerImage
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&image))
+	assert.Equal(t, "test-app-1.0.0", image.ID)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, image.Platforms)
+
+	rr = registerTestImage(t, h, "test-app", "{")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = registerTestImage(t, h, "test-app", `{"repository": "ghcr.io/acme/agent", "tag": "1.0.0",
+		"digest": "not-a-digest", "platforms": ["linux/amd64"]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestHandlers_CheckContainerImage(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "test-app", "Test App")
+	for _, body := range []string{
+		`{"repository": "ghcr.io/acme/agent", "tag": "1.0.0", "digest": "` + testImageDigestOld + `", "platforms": ["linux/amd64"]}`,
+		`{"repository": "ghcr.io/acme/agent", "tag": "1.1.0", "digest": "` + testImageDigestNew + `", "platforms": ["linux/amd64"]}`,
+	} {
+		require.Equal(t, http.StatusCreated, registerTestImage(t, h, "test-app", body).Code)
+	}
+
+	check := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/image?"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+		rr := httptest.NewRecorder()
+		h.CheckContainerImage(rr, req)
+		return rr
+	}
+
+	rr := check("tag=1.0.0&platform=linux/amd64")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp models.ContainerImageCheckResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.True(t, resp.UpdateAvailable)
+	assert.Equal(t, models.ImageUpdateReasonNewerTag, resp.Reason)
+	assert.Equal(t, "ghcr.io/acme/agent:1.1.0@"+testImageDigestNew, resp.Reference)
+
+	rr = check("tag=1.1.0&digest=" + testImageDigestNew)
+	require.Equal(t, http.StatusOK, rr.Code)
+	resp = models.ContainerImageCheckResponse{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.False(t, resp.UpdateAvailable)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, check("platform=linux/amd64").Code)
+}
+
+func TestHandlers_ListAndDeleteContainerImages(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "test-app", "Test App")
+	require.Equal(t, http.StatusCreated, registerTestImage(t, h, "test-app",
+		`{"repository": "ghcr.io/acme/agent", "tag": "1.0.0", "digest": "`+testImageDigestOld+`", "platforms": ["linux/amd64"]}`).Code)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/images", nil),
+		map[string]string{"app_id": "test-app"})
+	rr := httptest.NewRecorder()
+	h.ListContainerImages(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var list models.ListContainerImagesResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&list))
+	require.Len(t, list.Images, 1)
+	assert.Equal(t, "1.0.0", list.Images[0].Tag)
+
+	deleteImage := func() int {
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/api/v1/updates/test-app/images/1.0.0", nil),
+			map[string]string{"app_id": "test-app", "tag": "1.0.0"})
+		rr := httptest.NewRecorder()
+		h.DeleteContainerImage(rr, req)
+		return rr.Code
+	}
+	assert.Equal(t, http.StatusOK, deleteImage())
+	assert.Equal(t, http.StatusNotFound, deleteImage())
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v3"
+)
+
+// electronCacheControl lets CDNs cache desktop feeds briefly, as with badges,
+// without hiding a new release for long.
+const electronCacheControl = "public, max-age=300"
+
+// electronLatestChannel is the channel file name electron-updater uses for
+// stable releases. Feeds always look releases up by channel, so an
+// architecture without releases is not found rather than an error.
+const electronLatestChannel = "latest"
+
+// electronFeed is what an electron-updater channel file asks for.
+type electronFeed struct {
+	channel       string
+	platform      string
+	architectures []string // The first is the primary download; files of the others are listed after it
+	variant       string
+}
+
+// electronLinuxArchitectures maps the architecture suffixes of Linux channel
+// files to architectures. x64 has no suffix.
+var electronLinuxArchitectures = map[string]string{
+	"arm64":  models.ArchARM64,
+	"armv7l": models.ArchARM,
+}
+
+// parseElectronFeedFile parses a channel file name: {channel}.yml for Windows,
+// {channel}-mac.yml for macOS and {channel}-linux.yml or
+// {channel}-linux-{arch}.yml for Linux. Windows installers are the amd64
+// exe-installer variant, Linux the AppImage variant. macOS lists the zip
+// variant of amd64 and arm64, which Squirrel.Mac installs; electron-updater
+// picks the arm64 file by "arm64" in its URL, as electron-builder names it.
+func parseElectronFeedFile(file string) (electronFeed, bool) {
+	name, ok := strings.CutSuffix(file, ".yml")
+	if !ok {
+		return electronFeed{}, false
+	}
+	feed := electronFeed{
+		platform:      models.PlatformWindows,
+		architectures: []string{models.ArchAMD64},
+		variant:       models.VariantExeInstaller,
+	}
+	if channel, ok := strings.CutSuffix(name, "-mac"); ok {
+		name = channel
+		feed.platform = models.PlatformDarwin
+		feed.architectures = []string{models.ArchAMD64, models.ArchARM64}
+		feed.variant = models.VariantPortableZip
+	} else if channel, ok := strings.CutSuffix(name, "-linux"); ok {
+		name = channel
+		feed.platform = models.PlatformLinux
+		feed.variant = models.VariantAppImage
+	} else if i := strings.LastIndex(name, "-linux-"); i > 0 {
+		arch, ok := electronLinuxArchitectures[name[i+len("-linux-"):]]
+		if !ok {
+			return electronFeed{}, false
+		}
+		name = name[:i]
+		feed.platform = models.PlatformLinux
+		feed.architectures = []string{arch}
+		feed.variant = models.VariantAppImage
+	}
+	if name == "" {
+		return electronFeed{}, false
+	}
+	feed.channel = name
+	if name == electronLatestChannel {
+		feed.channel = models.ChannelStable
+	}
+	return feed, true
+}
+
+// ElectronUpdateInfo serves the electron-updater channel file of the newest
+// release, so an Electron app's generic publish URL can point here.
+// GET /api/v1/updates/{app_id}/electron/{file}
+func (h *Handlers) ElectronUpdateInfo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	feed, ok := parseElectronFeedFile(vars["file"])
+	if !ok {
+		h.writeErrorResponse(w, http.StatusNotFound, models.ErrorCodeNotFound,
+			fmt.Sprintf("unknown electron-updater file %q", vars["file"]))
+		return
+	}
+
+	var primary *models.LatestVersionResponse
+	var files []models.ElectronFileInfo
+	var notFound error
+	for _, arch := range feed.architectures {
+		latest, err := h.updateService.GetLatestVersion(r.Context(), &models.LatestVersionRequest{
+			ApplicationID: vars["app_id"],
+			Platform:      feed.platform,
+			Architecture:  arch,
+			LicenseToken:  r.Header.Get(licenseTokenHeader),
+			Channel:       feed.channel,
+			Variant:       feed.variant,
+		})
+		if err != nil {
+			// A macOS app need not ship both architectures
+			if !isNotFound(err) {
+				h.writeServiceErrorResponse(w, err)
+				return
+			}
+			notFound = err
+			continue
+		}
+		if primary != nil && latest.Version != primary.Version {
+			continue
+		}
+		file, err := models.NewElectronFileInfo(latest)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusNotFound, models.ErrorCodeNotFound,
+				fmt.Sprintf("release %s for %s-%s cannot be served to electron-updater: %v", latest.Version, feed.platform, arch, err))
+			return
+		}
+		if primary == nil {
+			primary = latest
+		}
+		files = append(files, file)
+	}
+
+	if primary == nil {
+		h.writeServiceErrorResponse(w, notFound)
+		return
+	}
+
+	info := models.NewElectronUpdateInfo(primary.Version, primary.ReleaseDate, primary.ReleaseNotes, files)
+	body, err := yaml.Marshal(info)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/yaml; charset=utf-8")
+	w.Header().Set("Cache-Control", electronCacheControl)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// squirrelArchitectures maps the arch query parameter Squirrel.Windows sends
+// to architectures.
+var squirrelArchitectures = map[string]string{
+	"":      models.ArchAMD64,
+	"amd64": models.ArchAMD64,
+	"x64":   models.ArchAMD64,
+	"x86":   models.Arch386,
+}
+
+// SquirrelReleases serves a Squirrel.Windows RELEASES file naming the full
+// nupkg package of the newest Windows release, for Electron's built-in
+// autoUpdater. Delta packages are not offered.
+// GET /api/v1/updates/{app_id}/squirrel/RELEASES?arch=amd64&channel=beta
+func (h *Handlers) SquirrelReleases(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	arch, ok := squirrelArchitectures[query.Get("arch")]
+	if !ok {
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "arch must be amd64, x64 or x86")
+		return
+	}
+
+	channel := query.Get("channel")
+	if channel == "" {
+		channel = models.ChannelStable
+	}
+
+	latest, err := h.updateService.GetLatestVersion(r.Context(), &models.LatestVersionRequest{
+		ApplicationID: mux.Vars(r)["app_id"],
+		Platform:      models.PlatformWindows,
+		Architecture:  arch,
+		LicenseToken:  r.Header.Get(licenseTokenHeader),
+		Channel:       channel,
+		Variant:       models.VariantNupkg,
+	})
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	line, err := models.SquirrelReleasesLine(latest)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusNotFound, models.ErrorCodeNotFound,
+			fmt.Sprintf("release %s cannot be served to Squirrel.Windows: %v", latest.Version, err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", electronCacheControl)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(line + "\n"))
+}
+
+// isNotFound reports whether err is a service error with a 404 status.
+func isNotFound(err error) bool {
+	var serviceError *update.ServiceError
+	return errors.As(err, &serviceError) && serviceError.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseElectronFeedFile(t *testing.T) {
+	tests := []struct {
+		file   string
+		want   electronFeed
+		wantOK bool
+	}{
+		{file: "latest.yml", want: electronFeed{channel: "stable", platform: "windows", architectures: []string{"amd64"}, variant: "exe-installer"}, wantOK: true},
+		{file: "beta.yml", want: electronFeed{channel: "beta", platform: "windows", architectures: []string{"amd64"}, variant: "exe-installer"}, wantOK: true},
+		{file: "latest-mac.yml", want: electronFeed{channel: "stable", platform: "darwin", architectures: []string{"amd64", "arm64"}, variant: "portable-zip"}, wantOK: true},
+		{file: "latest-linux.yml", want: electronFeed{channel: "stable", platform: "linux", architectures: []string{"amd64"}, variant: "appimage"}, wantOK: true},
+		{file: "beta-linux-arm64.yml", want: electronFeed{channel: "beta", platform: "linux", architectures: []string{"arm64"}, variant: "appimage"}, wantOK: true},
+		{file: "latest-linux-armv7l.yml", want: electronFeed{channel: "stable", platform: "linux", architectures: []string{"arm"}, variant: "appimage"}, wantOK: true},
+		{file: "latest-linux-mips.yml"},
+		{file: "-mac.yml"},
+		{file: "latest.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got, ok := parseElectronFeedFile(tt.file)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// newElectronTestHandlers creates an application on every desktop platform
+// with releases shaped the way electron-builder publishes them.
+func newElectronTestHandlers(t *testing.T) *Handlers {
+	t.Helper()
+	h := newTestHandlers(t)
+	ctx := context.Background()
+	_, err := h.updateService.CreateApplication(ctx, &models.CreateApplicationRequest{
+		ID: "electron-app", Name: "Electron App", Platforms: []string{"windows", "darwin", "linux"},
+	})
+	require.NoError(t, err)
+
+	sha512 := strings.Repeat("ab", 64)
+	register := func(version, platform, arch, url, variant string, variants map[string]models.VariantArtifact) {
+		t.Helper()
+		_, err := h.updateService.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "electron-app", Version: version, Platform: platform, Architecture: arch,
+			DownloadURL: url, Checksum: sha512, ChecksumType: "sha512", FileSize: 2048,
+			ReleaseNotes: "Fixes", Variant: variant, Variants: variants,
+		})
+		require.NoError(t, err)
+	}
+	register("1.1.0", "windows", "amd64", "https://cdn.example.com/App-Setup-1.1.0.exe", "exe-installer", nil)
+	register("1.2.0", "windows", "amd64", "https://cdn.example.com/App-Setup-1.2.0.exe", "exe-installer", map[string]models.VariantArtifact{
+		"nupkg": {DownloadURL: "https://cdn.example.com/App-1.2.0-full.nupkg", Checksum: strings.Repeat("0a", 20), ChecksumType: "sha1", FileSize: 4096},
+	})
+	register("1.2.0", "darwin", "amd64", "https://cdn.example.com/App-1.2.0-mac.zip", "portable-zip", nil)
+	register("1.2.0", "darwin", "arm64", "https://cdn.example.com/App-1.2.0-arm64-mac.zip", "portable-zip", nil)
+	return h
+}
+
+func serveElectron(h *Handlers, handler http.HandlerFunc, target string, vars map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req = mux.SetURLVars(req, vars)
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	return rr
+}
+
+func TestHandlers_ElectronUpdateInfo(t *testing.T) {
+	h := newElectronTestHandlers(t)
+
+	t.Run("windows installer", func(t *testing.T) {
+		rr := serveElectron(h, h.ElectronUpdateInfo, "/api/v1/updates/electron-app/electron/latest.yml",
+			map[string]string{"app_id": "electron-app", "file": "latest.yml"})
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "text/yaml; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, electronCacheControl, rr.Header().Get("Cache-Control"))
+		var info models.ElectronUpdateInfo
+		require.NoError(t, yaml.Unmarshal(rr.Body.Bytes(), &info))
+		assert.Equal(t, "1.2.0", info.Version)
+		assert.Equal(t, "https://cdn.example.com/App-Setup-1.2.0.exe", info.Path)
+		require.Len(t, info.Files, 1)
+		assert.Equal(t, info.SHA512, info.Files[0].SHA512)
+		assert.Equal(t, int64(2048), info.Files[0].Size)
+		assert.Equal(t, "Fixes", info.ReleaseNotes)
+	})
+
+	t.Run("macOS lists both architectures", func(t *testing.T) {
+		rr := serveElectron(h, h.ElectronUpdateInfo, "/api/v1/updates/electron-app/electron/latest-mac.yml",
+			map[string]string{"app_id": "electron-app", "file": "latest-mac.yml"})
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var info models.ElectronUpdateInfo
+		require.NoError(t, yaml.Unmarshal(rr.Body.Bytes(), &info))
+		require.Len(t, info.Files, 2)
+		assert.Equal(t, "https://cdn.example.com/App-1.2.0-mac.zip", info.Files[0].URL)
+		assert.Equal(t, "https://cdn.example.com/App-1.2.0-arm64-mac.zip", info.Files[1].URL)
+	})
+
+	t.Run("no releases for platform", func(t *testing.T) {
+		rr := serveElectron(h, h.ElectronUpdateInfo, "/api/v1/updates/electron-app/electron/latest-linux.yml",
+			map[string]string{"app_id": "electron-app", "file": "latest-linux.yml"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("unknown file", func(t *testing.T) {
+		rr := serveElectron(h, h.ElectronUpdateInfo, "/api/v1/updates/electron-app/electron/latest.json",
+			map[string]string{"app_id": "electron-app", "file": "latest.json"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestHandlers_SquirrelReleases(t *testing.T) {
+	h := newElectronTestHandlers(t)
+	vars := map[string]string{"app_id": "electron-app"}
+
+	t.Run("newest full package", func(t *testing.T) {
+		rr := serveElectron(h, h.SquirrelReleases, "/api/v1/updates/electron-app/squirrel/RELEASES?id=App&localVersion=1.1.0&arch=amd64", vars)
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, strings.Repeat("0A", 20)+" https://cdn.example.com/App-1.2.0-full.nupkg 4096\n", rr.Body.String())
+	})
+
+	t.Run("unsupported arch", func(t *testing.T) {
+		rr := serveElectron(h, h.SquirrelReleases, "/api/v1/updates/electron-app/squirrel/RELEASES?arch=arm64", vars)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("no 32-bit releases", func(t *testing.T) {
+		rr := serveElectron(h, h.SquirrelReleases, "/api/v1/updates/electron-app/squirrel/RELEASES?arch=x86", vars)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,14 @@
+package api
+
+import (
+	"net/http"
+	"updater/internal/models"
+)
+
+// ErrorCodeDictionary lists every error code the API returns with the action
+// clients should take, so client implementations share one retry policy.
+// GET /api/v1/error-codes
+func (h *Handlers) ErrorCodeDictionary(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	h.writeJSONResponse(w, http.StatusOK, models.ErrorCodes())
+}
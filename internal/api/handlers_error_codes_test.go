@@ -0,0 +1,27 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_ErrorCodeDictionary(t *testing.T) {
+	config := &models.Config{Security: models.SecurityConfig{EnableAuth: true}}
+	router := SetupRoutes(NewHandlers(&MockUpdateService{}), config)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/error-codes", nil))
+	require.Equal(t, http.StatusOK, recorder.Code, "the dictionary is public")
+	assert.Equal(t, "public, max-age=3600", recorder.Header().Get("Cache-Control"))
+
+	var dictionary models.ErrorCodeDictionary
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &dictionary))
+	assert.Equal(t, models.ErrorCodeDictionaryVersion, dictionary.Version)
+	assert.NotEmpty(t, dictionary.Codes)
+}
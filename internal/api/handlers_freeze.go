@@ -0,0 +1,44 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"updater/internal/models"
+	"updater/internal/security"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+)
+
+// breakGlassHeader carries the reason for publishing to the stable channel
+// during a freeze window that allows break glass.
+const breakGlassHeader = "X-Break-Glass"
+
+// breakGlass returns the request with a context that passes freeze windows
+// allowing break glass, when it gives a reason in the X-Break-Glass header.
+// Without authentication any request may break glass. It writes an error and
+// returns false when the reason is too long or the key lacks the break_glass
+// permission.
+func (h *Handlers) breakGlass(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	reason := strings.TrimSpace(r.Header.Get(breakGlassHeader))
+	if reason == "" {
+		return r, true
+	}
+	if len(reason) > models.MaxFreezeReasonLength {
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest,
+			fmt.Sprintf("%s exceeds maximum length of %d", breakGlassHeader, models.MaxFreezeReasonLength))
+		return nil, false
+	}
+	if principal, ok := security.FromContext(r.Context()); ok && !principal.HasPermission(string(PermissionBreakGlass)) {
+		slog.Warn("Break glass refused",
+			"event", "security_audit",
+			"app_id", mux.Vars(r)["app_id"],
+			"api_key", getAPIKeyName(GetAPIKey(r)),
+			"client_ip", getClientIP(r))
+		h.writeErrorResponse(w, http.StatusForbidden, models.ErrorCodeForbidden, "Breaking glass requires the break_glass permission")
+		return nil, false
+	}
+	return r.WithContext(update.WithBreakGlass(r.Context(), reason)), true
+}
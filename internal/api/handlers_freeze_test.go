@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/security"
+	"updater/internal/storage"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_BreakGlass(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	h := NewHandlers(update.NewService(store), WithStorage(store))
+	createTestApplication(t, h, "frozen-app", "Frozen App")
+	createTestRelease(t, h, "frozen-app", "1.2.0-beta.1", "windows", "amd64")
+
+	app, err := store.GetApplication(ctx, "frozen-app")
+	require.NoError(t, err)
+	app.Config.FreezeWindows = []models.FreezeWindow{{
+		Name:       "black-friday",
+		StartsAt:   time.Now().Add(-time.Hour),
+		EndsAt:     time.Now().Add(time.Hour),
+		BreakGlass: true,
+	}}
+	require.NoError(t, store.SaveApplication(ctx, app))
+
+	publish := func(reason string, key *models.APIKey) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/applications/frozen-app/channels/stable/releases/1.2.0-beta.1", nil)
+		req = mux.SetURLVars(req, map[string]string{"app_id": "frozen-app", "channel": "stable", "version": "1.2.0-beta.1"})
+		if reason != "" {
+			req.Header.Set(breakGlassHeader, reason)
+		}
+		if key != nil {
+			req = req.WithContext(security.NewContext(req.Context(), &security.Principal{APIKey: key}))
+		}
+		rr := httptest.NewRecorder()
+		h.PublishToChannel(rr, req)
+		return rr
+	}
+	writer := &models.APIKey{Name: "ci", Permissions: []string{"write"}, Enabled: true}
+	breakGlass := &models.APIKey{Name: "release-manager", Permissions: []string{"write", "break_glass"}, Enabled: true}
+
+	rr := publish("", breakGlass)
+	assert.Equal(t, http.StatusConflict, rr.Code, "the key must ask to break glass")
+	assert.Contains(t, rr.Body.String(), "black-friday")
+
+	rr = publish("checkout outage hotfix", writer)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "break_glass permission")
+
+	rr = publish(strings.Repeat("a", models.MaxFreezeReasonLength+1), breakGlass)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = publish("checkout outage hotfix", breakGlass)
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = publish("checkout outage hotfix", nil)
+	assert.Equal(t, http.StatusOK, rr.Code, "without authentication any request may break glass")
+}
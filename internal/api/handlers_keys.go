@@ -3,21 +3,16 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"time"
 	"updater/internal/models"
-	"updater/internal/storage"
+	"updater/internal/security"
 
 	"github.com/gorilla/mux"
 )
 
-// createAPIKeyRequest is the request body for POST /api/v1/admin/keys.
-type createAPIKeyRequest struct {
-	Name        string   `json:"name"`
-	Permissions []string `json:"permissions"`
-}
-
 // createAPIKeyResponse includes the raw key — returned exactly once.
 type createAPIKeyResponse struct {
 	ID          string    `json:"id"`
@@ -40,14 +35,6 @@ type apiKeyResponse struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-// updateAPIKeyRequest is the request body for PATCH /api/v1/admin/keys/{id}.
-// All fields are optional.
-type updateAPIKeyRequest struct {
-	Name        *string  `json:"name"`
-	Permissions []string `json:"permissions"`
-	Enabled     *bool    `json:"enabled"`
-}
-
 func apiKeyToResponse(k *models.APIKey) apiKeyResponse {
 	return apiKeyResponse{
 		ID:          k.ID,
@@ -62,9 +49,9 @@ func apiKeyToResponse(k *models.APIKey) apiKeyResponse {
 
 // ListAPIKeys handles GET /api/v1/admin/keys
 func (h *Handlers) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
-	keys, err := h.storage.ListAPIKeys(r.Context())
+	keys, err := h.keys.ListAPIKeys(r.Context())
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to list keys")
+		h.writeServiceErrorResponse(w, err)
 		return
 	}
 	resp := make([]apiKeyResponse, len(keys))
@@ -76,7 +63,7 @@ func (h *Handlers) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
 
 // CreateAPIKey handles POST /api/v1/admin/keys
 func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
-	var req createAPIKeyRequest
+	var req models.CreateAPIKeyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		if isMaxBytesError(err) {
 			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
@@ -85,24 +72,10 @@ func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "invalid request body")
 		return
 	}
-	if req.Name == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "name is required")
-		return
-	}
-	if len(req.Permissions) == 0 {
-		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "permissions is required")
-		return
-	}
 
-	rawKey, err := models.GenerateAPIKey()
+	key, rawKey, err := h.keys.CreateAPIKey(r.Context(), &req)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to generate key")
-		return
-	}
-
-	key := models.NewAPIKey(models.NewKeyID(), req.Name, rawKey, req.Permissions)
-	if err := h.storage.CreateAPIKey(r.Context(), key); err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to create key")
+		h.writeServiceErrorResponse(w, err)
 		return
 	}
 
@@ -128,7 +101,7 @@ func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 // UpdateAPIKey handles PATCH /api/v1/admin/keys/{id}
 func (h *Handlers) UpdateAPIKey(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
-	var req updateAPIKeyRequest
+	var req models.UpdateAPIKeyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		if isMaxBytesError(err) {
 			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
@@ -138,38 +111,9 @@ func (h *Handlers) UpdateAPIKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fetch existing key by scanning the list (no GetByID method).
-	keys, err := h.storage.ListAPIKeys(r.Context())
+	key, err := h.keys.UpdateAPIKey(r.Context(), id, &req)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to fetch keys")
-		return
-	}
-	var key *models.APIKey
-	for _, k := range keys {
-		if k.ID == id {
-			c := *k
-			key = &c
-			break
-		}
-	}
-	if key == nil {
-		h.writeErrorResponse(w, http.StatusNotFound, models.ErrorCodeNotFound, "key not found")
-		return
-	}
-
-	if req.Name != nil {
-		key.Name = *req.Name
-	}
-	if req.Permissions != nil {
-		key.Permissions = req.Permissions
-	}
-	if req.Enabled != nil {
-		key.Enabled = *req.Enabled
-	}
-	key.UpdatedAt = time.Now().UTC()
-
-	if err := h.storage.UpdateAPIKey(r.Context(), key); err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to update key")
+		h.writeServiceErrorResponse(w, err)
 		return
 	}
 
@@ -187,12 +131,8 @@ func (h *Handlers) UpdateAPIKey(w http.ResponseWriter, r *http.Request) {
 // DeleteAPIKey handles DELETE /api/v1/admin/keys/{id}
 func (h *Handlers) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
-	if err := h.storage.DeleteAPIKey(r.Context(), id); err != nil {
-		if errors.Is(err, storage.ErrNotFound) {
-			h.writeErrorResponse(w, http.StatusNotFound, models.ErrorCodeNotFound, "key not found")
-		} else {
-			h.writeErrorResponse(w, http.StatusInternalServerError, models.ErrorCodeInternalError, "failed to delete key")
-		}
+	if err := h.keys.DeleteAPIKey(r.Context(), id); err != nil {
+		h.writeServiceErrorResponse(w, err)
 		return
 	}
 
@@ -206,10 +146,61 @@ func (h *Handlers) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// IssueQueryToken handles POST /api/v1/admin/keys/{id}/query-token. The body
+// is optional; without ttl_seconds the token lives for the configured maximum.
+func (h *Handlers) IssueQueryToken(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req models.QueryTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "invalid request body")
+		return
+	}
+	if req.TTLSeconds < 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "ttl_seconds must not be negative")
+		return
+	}
+
+	key, err := h.keys.GetAPIKey(r.Context(), id)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	if !key.Enabled {
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, models.ErrorCodeValidation, "key is disabled")
+		return
+	}
+
+	ttl := h.queryTokens.maxTTL
+	if req.TTLSeconds > 0 && req.TTLSeconds < int64(ttl/time.Second) {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	token, expiresAt := h.queryTokens.sign(key.ID, ttl)
+
+	slog.Info("api key query token issued",
+		"event", "security_audit",
+		"action", "issue_query_token",
+		"key_id", key.ID,
+		"key_name", key.Name,
+		"expires_at", expiresAt,
+		"actor_key_id", actorKeyID(r),
+	)
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeJSONResponse(w, http.StatusCreated, models.QueryTokenResponse{
+		Param:     h.queryTokens.param,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+}
+
 // actorKeyID extracts the ID of the authenticated key making this request.
 func actorKeyID(r *http.Request) string {
-	if k, ok := r.Context().Value(apiKeyContextKey).(*models.APIKey); ok {
-		return k.ID
+	if p, ok := security.FromContext(r.Context()); ok {
+		return p.KeyID()
 	}
 	return "unknown"
 }
@@ -4,14 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"updater/internal/models"
 	"updater/internal/storage"
+	"updater/internal/update"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,8 +45,7 @@ func adminCtxRequest(method, path string, body []byte, store storage.Storage, ra
 	}
 	hash := models.HashAPIKey(rawKey)
 	ak, _ := store.GetAPIKeyByHash(context.Background(), hash)
-	ctx := context.WithValue(req.Context(), apiKeyContextKey, ak)
-	return req.WithContext(ctx)
+	return withPrincipal(req, ak)
 }
 
 func TestListAPIKeys_ReturnsEmptyList(t *testing.T) {
@@ -79,7 +81,7 @@ func TestListAPIKeys_ReturnsKeys(t *testing.T) {
 func TestCreateAPIKey_ValidRequest_Returns201(t *testing.T) {
 	h, adminRaw := newKeyTestHandlers(t)
 
-	body, _ := json.Marshal(createAPIKeyRequest{Name: "CI Publisher", Permissions: []string{"write"}})
+	body, _ := json.Marshal(models.CreateAPIKeyRequest{Name: "CI Publisher", Permissions: []string{"write"}})
 	req := adminCtxRequest(http.MethodPost, "/api/v1/admin/keys", body, h.storage, adminRaw)
 	rr := httptest.NewRecorder()
 	h.CreateAPIKey(rr, req)
@@ -96,7 +98,7 @@ func TestCreateAPIKey_ValidRequest_Returns201(t *testing.T) {
 func TestCreateAPIKey_MissingName_Returns400(t *testing.T) {
 	h, adminRaw := newKeyTestHandlers(t)
 
-	body, _ := json.Marshal(createAPIKeyRequest{Permissions: []string{"read"}})
+	body, _ := json.Marshal(models.CreateAPIKeyRequest{Permissions: []string{"read"}})
 	req := adminCtxRequest(http.MethodPost, "/api/v1/admin/keys", body, h.storage, adminRaw)
 	rr := httptest.NewRecorder()
 	h.CreateAPIKey(rr, req)
@@ -107,7 +109,7 @@ func TestCreateAPIKey_MissingName_Returns400(t *testing.T) {
 func TestCreateAPIKey_MissingPermissions_Returns400(t *testing.T) {
 	h, adminRaw := newKeyTestHandlers(t)
 
-	body, _ := json.Marshal(createAPIKeyRequest{Name: "Test"})
+	body, _ := json.Marshal(models.CreateAPIKeyRequest{Name: "Test"})
 	req := adminCtxRequest(http.MethodPost, "/api/v1/admin/keys", body, h.storage, adminRaw)
 	rr := httptest.NewRecorder()
 	h.CreateAPIKey(rr, req)
@@ -125,7 +127,7 @@ func TestUpdateAPIKey_ValidRequest_Returns200(t *testing.T) {
 	id := keys[0].ID
 
 	newName := "renamed-admin"
-	body, _ := json.Marshal(updateAPIKeyRequest{Name: &newName})
+	body, _ := json.Marshal(models.UpdateAPIKeyRequest{Name: &newName})
 	req := adminCtxRequest(http.MethodPatch, "/api/v1/admin/keys/"+id, body, h.storage, adminRaw)
 	req = mux.SetURLVars(req, map[string]string{"id": id})
 	rr := httptest.NewRecorder()
@@ -141,7 +143,7 @@ func TestUpdateAPIKey_NotFound_Returns404(t *testing.T) {
 	h, adminRaw := newKeyTestHandlers(t)
 
 	newName := "x"
-	body, _ := json.Marshal(updateAPIKeyRequest{Name: &newName})
+	body, _ := json.Marshal(models.UpdateAPIKeyRequest{Name: &newName})
 	req := adminCtxRequest(http.MethodPatch, "/api/v1/admin/keys/nonexistent", body, h.storage, adminRaw)
 	req = mux.SetURLVars(req, map[string]string{"id": "nonexistent"})
 	rr := httptest.NewRecorder()
@@ -180,3 +182,65 @@ func TestDeleteAPIKey_NotFound_Returns404(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
+
+// MockKeyService implements update.KeyService for testing
+type MockKeyService struct {
+	mock.Mock
+}
+
+func (m *MockKeyService) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	args := m.Called(ctx)
+	keys, _ := args.Get(0).([]*models.APIKey)
+	return keys, args.Error(1)
+}
+
+func (m *MockKeyService) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	args := m.Called(ctx, id)
+	key, _ := args.Get(0).(*models.APIKey)
+	return key, args.Error(1)
+}
+
+func (m *MockKeyService) CreateAPIKey(ctx context.Context, req *models.CreateAPIKeyRequest) (*models.APIKey, string, error) {
+	args := m.Called(ctx, req)
+	key, _ := args.Get(0).(*models.APIKey)
+	return key, args.String(1), args.Error(2)
+}
+
+func (m *MockKeyService) UpdateAPIKey(ctx context.Context, id string, req *models.UpdateAPIKeyRequest) (*models.APIKey, error) {
+	args := m.Called(ctx, id, req)
+	key, _ := args.Get(0).(*models.APIKey)
+	return key, args.Error(1)
+}
+
+func (m *MockKeyService) DeleteAPIKey(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func TestKeyHandlersUseKeyService(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	keys := &MockKeyService{}
+	h := NewHandlers(&MockUpdateService{}, WithStorage(store), WithKeyService(keys))
+
+	created := models.NewAPIKey("key-1", "CI", "upd_raw", []string{"write"})
+	keys.On("CreateAPIKey", mock.Anything, &models.CreateAPIKeyRequest{Name: "CI", Permissions: []string{"write"}}).Return(created, "upd_raw", nil)
+	keys.On("ListAPIKeys", mock.Anything).Return(nil, update.NewInternalError("failed to list keys", errors.New("db down")))
+
+	body, _ := json.Marshal(models.CreateAPIKeyRequest{Name: "CI", Permissions: []string{"write"}})
+	rr := httptest.NewRecorder()
+	h.CreateAPIKey(rr, httptest.NewRequest(http.MethodPost, "/api/v1/admin/keys", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var resp createAPIKeyResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "upd_raw", resp.Key)
+
+	rr = httptest.NewRecorder()
+	h.ListAPIKeys(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/keys", nil))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "db down")
+
+	stored, err := store.ListAPIKeys(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, stored, "handlers do not bypass the key service")
+	keys.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestYAMLTypes lists the Content-Type values accepted for YAML manifests.
+var manifestYAMLTypes = map[string]bool{
+	"application/yaml":   true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+}
+
+// IngestReleaseManifest handles CI release manifest uploads
+// POST /api/v1/updates/{app_id}/manifest
+func (h *Handlers) IngestReleaseManifest(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["app_id"]
+	apiKey := GetAPIKey(r)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var decode func(io.Reader, *models.ReleaseManifest) error
+	switch {
+	case mediaType == "application/json":
+		decode = func(body io.Reader, m *models.ReleaseManifest) error {
+			return json.NewDecoder(body).Decode(m)
+		}
+	case manifestYAMLTypes[mediaType]:
+		decode = func(body io.Reader, m *models.ReleaseManifest) error {
+			return yaml.NewDecoder(body).Decode(m)
+		}
+	default:
+		h.writeErrorResponse(w, http.StatusUnsupportedMediaType, models.ErrorCodeBadRequest, "Content-Type must be application/json or application/yaml")
+		return
+	}
+
+	var manifest models.ReleaseManifest
+	if err := decode(r.Body, &manifest); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid manifest body")
+		return
+	}
+
+	// A manifest built for another application is almost certainly a pipeline
+	// misconfiguration, so refuse it rather than silently retargeting it.
+	if manifest.ApplicationID != "" && manifest.ApplicationID != appID {
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Manifest application_id does not match the URL")
+		return
+	}
+	manifest.ApplicationID = appID
+
+	r, ok := h.breakGlass(w, r)
+	if !ok {
+		return
+	}
+	response, err := h.updateService.IngestReleaseManifest(r.Context(), &manifest)
+	if err != nil {
+		slog.Warn("Release manifest ingest failed",
+			"event", "security_audit",
+			"app_id", appID,
+			"version", manifest.Version,
+			"api_key", getAPIKeyName(apiKey),
+			"error", err.Error())
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Release manifest ingested",
+		"event", "security_audit",
+		"app_id", appID,
+		"version", response.Version,
+		"artifacts", len(response.Releases),
+		"api_key", getAPIKeyName(apiKey))
+
+	for range response.Releases {
+		h.recordReleaseRegistered(r, appID)
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
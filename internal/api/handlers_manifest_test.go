@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifestYAML = `
+version: 2.0.0
+release_notes: Multi-platform release
+metadata:
+  commit_sha: abc123
+artifacts:
+  - platform: windows
+    architecture: amd64
+    download_url: https://example.com/app-2.0.0-windows-amd64.exe
+    checksum: abc123def456
+    checksum_type: sha256
+    file_size: 1024
+  - platform: linux
+    architecture: arm64
+    download_url: https://example.com/app-2.0.0-linux-arm64.tar.gz
+    checksum: 0123456789ab
+    checksum_type: sha256
+    file_size: 2048
+    metadata:
+      libc: musl
+`
+
+func postManifest(h *Handlers, appID, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/"+appID+"/manifest", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req = mux.SetURLVars(req, map[string]string{"app_id": appID})
+	rr := httptest.NewRecorder()
+	h.IngestReleaseManifest(rr, req)
+	return rr
+}
+
+func TestHandlers_IngestReleaseManifest_YAML(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "test-app", "Test App")
+
+	rr := postManifest(h, "test-app", "application/yaml", testManifestYAML)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var resp models.IngestManifestResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "test-app", resp.ApplicationID)
+	assert.Equal(t, "2.0.0", resp.Version)
+	require.Len(t, resp.Releases, 2)
+	assert.Equal(t, "windows", resp.Releases[0].Platform)
+	assert.Equal(t, "linux", resp.Releases[1].Platform)
+	assert.Equal(t, map[string]string{"commit_sha": "abc123", "libc": "musl"}, resp.Releases[1].Metadata)
+}
+
+func TestHandlers_IngestReleaseManifest_JSON(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "test-app", "Test App")
+
+	body := `{"application_id": "test-app", "version": "1.2.0", "artifacts": [
+		{"platform": "windows", "architecture": "amd64", "download_url": "https://example.com/a.exe",
+		 "checksum": "abc123", "checksum_type": "sha256", "file_size": 10}]}`
+	rr := postManifest(h, "test-app", "application/json; charset=utf-8", body)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+}
+
+func TestHandlers_IngestReleaseManifest_Errors(t *testing.T) {
+	valid := `{"version": "1.0.0", "artifacts": [
+		{"platform": "windows", "architecture": "amd64", "download_url": "https://example.com/a.exe",
+		 "checksum": "abc123", "checksum_type": "sha256"}]}`
+
+	tests := []struct {
+		name        string
+		appID       string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "unsupported content type", appID: "test-app", contentType: "text/plain", body: valid, wantStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed body", appID: "test-app", contentType: "application/json", body: "{", wantStatus: http.StatusBadRequest},
+		{name: "application mismatch", appID: "test-app", contentType: "application/json",
+			body: `{"application_id": "other-app", "version": "1.0.0", "artifacts": []}`, wantStatus: http.StatusBadRequest},
+		{name: "no artifacts", appID: "test-app", contentType: "application/json",
+			body: `{"version": "1.0.0", "artifacts": []}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "unknown application", appID: "missing-app", contentType: "application/json", body: valid, wantStatus: http.StatusNotFound},
+		{name: "unsupported platform", appID: "test-app", contentType: "application/json",
+			body: `{"version": "1.0.0", "artifacts": [
+				{"platform": "darwin", "architecture": "arm64", "download_url": "https://example.com/a.dmg",
+				 "checksum": "abc123", "checksum_type": "sha256"}]}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandlers(t)
+			createTestApplication(t, h, "test-app", "Test App")
+
+			rr := postManifest(h, tt.appID, tt.contentType, tt.body)
+			assert.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+		})
+	}
+}
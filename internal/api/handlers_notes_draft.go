@@ -0,0 +1,71 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetReleaseNotesDraft returns the release notes drafted for a release, next
+// to its current notes, for review.
+// GET /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft
+func (h *Handlers) GetReleaseNotesDraft(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	response, err := h.updateService.GetReleaseNotesDraft(r.Context(), vars["app_id"], vars["version"], vars["platform"], vars["arch"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// PublishReleaseNotesDraft makes a release's drafted notes its release notes.
+// POST /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft/publish
+func (h *Handlers) PublishReleaseNotesDraft(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	appID := vars["app_id"]
+	version := vars["version"]
+	apiKey := GetAPIKey(r)
+
+	response, err := h.updateService.PublishReleaseNotesDraft(r.Context(), appID, version, vars["platform"], vars["arch"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Release notes draft published",
+		"event", "security_audit",
+		"app_id", appID,
+		"release_id", response.ReleaseID,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DiscardReleaseNotesDraft removes a release's drafted notes without
+// publishing them.
+// DELETE /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft
+func (h *Handlers) DiscardReleaseNotesDraft(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	appID := vars["app_id"]
+	version := vars["version"]
+	apiKey := GetAPIKey(r)
+
+	if err := h.updateService.DiscardReleaseNotesDraft(r.Context(), appID, version, vars["platform"], vars["arch"]); err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	slog.Info("Release notes draft discarded",
+		"event", "security_audit",
+		"app_id", appID,
+		"version", version,
+		"platform", vars["platform"],
+		"arch", vars["arch"],
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	w.WriteHeader(http.StatusNoContent)
+}
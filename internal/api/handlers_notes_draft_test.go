@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func notesDraftRequest(method, suffix string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/updates/test-app/releases/1.0.0/windows/amd64/notes-draft"+suffix, nil)
+	return mux.SetURLVars(req, map[string]string{"app_id": "test-app", "version": "1.0.0", "platform": "windows", "arch": "amd64"})
+}
+
+func TestHandlers_ReleaseNotesDraft(t *testing.T) {
+	mockService := &MockUpdateService{}
+	h := NewHandlers(mockService)
+	args := []any{mock.Anything, "test-app", "1.0.0", "windows", "amd64"}
+
+	t.Run("get", func(t *testing.T) {
+		mockService.On("GetReleaseNotesDraft", args...).Return(&models.ReleaseNotesDraftResponse{
+			ReleaseID: "test-app-1.0.0-windows-amd64", Draft: "- Fixed a crash", ReleaseNotes: "Bug fixes",
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		h.GetReleaseNotesDraft(rr, notesDraftRequest(http.MethodGet, ""))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp models.ReleaseNotesDraftResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "- Fixed a crash", resp.Draft)
+		assert.Equal(t, "Bug fixes", resp.ReleaseNotes)
+	})
+
+	t.Run("get without a draft", func(t *testing.T) {
+		mockService.On("GetReleaseNotesDraft", args...).Return(nil, update.NewNotFoundError("no draft")).Once()
+
+		rr := httptest.NewRecorder()
+		h.GetReleaseNotesDraft(rr, notesDraftRequest(http.MethodGet, ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		mockService.On("PublishReleaseNotesDraft", args...).Return(&models.ReleaseNotesDraftResponse{
+			ReleaseID: "test-app-1.0.0-windows-amd64", ReleaseNotes: "- Fixed a crash",
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		h.PublishReleaseNotesDraft(rr, notesDraftRequest(http.MethodPost, "/publish"))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"release_notes":"- Fixed a crash"`)
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		mockService.On("DiscardReleaseNotesDraft", args...).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		h.DiscardReleaseNotesDraft(rr, notesDraftRequest(http.MethodDelete, ""))
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	mockService.AssertExpectations(t)
+}
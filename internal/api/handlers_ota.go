@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"updater/internal/api/encoding"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// contentTypeFlat is the media type of the key=value OTA response. Binary
+// formats are negotiated by the encoding package.
+const contentTypeFlat = "text/plain"
+
+// CheckOTAUpdate handles compact update checks from embedded devices
+// GET /api/v1/updates/{app_id}/ota?current_version=...&platform=...&architecture=...&slot=a
+func (h *Handlers) CheckOTAUpdate(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &models.OTACheckRequest{
+		ApplicationID:   mux.Vars(r)["app_id"],
+		CurrentVersion:  query.Get("current_version"),
+		Platform:        query.Get("platform"),
+		Architecture:    query.Get("architecture"),
+		Slot:            query.Get("slot"),
+		AllowPrerelease: query.Get("allow_prerelease") == "true",
+	}
+	if chunkStr := query.Get("chunk_size"); chunkStr != "" {
+		chunk, err := strconv.ParseInt(chunkStr, 10, 64)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "chunk_size must be an integer")
+			return
+		}
+		req.ChunkSize = chunk
+	}
+
+	response, err := h.updateService.CheckOTAUpdate(r.Context(), req)
+	if err != nil {
+		h.recordUpdateCheck(r, req.ApplicationID, "error")
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	result := "no_update"
+	if response.UpdateAvailable {
+		result = "update_available"
+	}
+	h.recordUpdateCheck(r, req.ApplicationID, result)
+
+	h.writeOTAResponse(w, r, response)
+}
+
+// writeOTAResponse encodes an OTA check result in the format named by the
+// Accept header: flat key=value lines, CBOR or MessagePack, or JSON by default.
+func (h *Handlers) writeOTAResponse(w http.ResponseWriter, r *http.Request, response *models.OTACheckResponse) {
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, contentTypeFlat):
+		w.Header().Set("Content-Type", contentTypeFlat+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(response.CompactFields().MarshalFlat())
+	case encoding.Negotiate(accept) != encoding.MediaTypeJSON:
+		h.writeEncodedResponse(w, r, http.StatusOK, response.CompactFields())
+	default:
+		h.writeJSONResponse(w, http.StatusOK, response)
+	}
+}
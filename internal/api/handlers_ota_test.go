@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/api/encoding"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newOTARequest(query, accept string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/sensor-fw/ota?"+query, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	return mux.SetURLVars(req, map[string]string{"app_id": "sensor-fw"})
+}
+
+func TestHandlers_CheckOTAUpdate(t *testing.T) {
+	otaResp := &models.OTACheckResponse{
+		UpdateAvailable: true,
+		Version:         "1.1.0",
+		URL:             "https://fw.example.com/1.1.0.bin",
+		Size:            4096,
+		TargetSlot:      models.OTASlotB,
+		ChunkSize:       1024,
+		Chunks:          4,
+	}
+	expectedReq := &models.OTACheckRequest{
+		ApplicationID:  "sensor-fw",
+		CurrentVersion: "1.0.0",
+		Platform:       "linux",
+		Architecture:   "arm",
+		Slot:           "a",
+		ChunkSize:      1024,
+	}
+	query := "current_version=1.0.0&platform=linux&architecture=arm&slot=a&chunk_size=1024"
+
+	t.Run("json by default", func(t *testing.T) {
+		mockService := new(MockUpdateService)
+		mockService.On("CheckOTAUpdate", mock.Anything, expectedReq).Return(otaResp, nil)
+		h := NewHandlers(mockService)
+
+		rr := httptest.NewRecorder()
+		h.CheckOTAUpdate(rr, newOTARequest(query, ""))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		var body models.OTACheckResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, *otaResp, body)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("cbor", func(t *testing.T) {
+		mockService := new(MockUpdateService)
+		mockService.On("CheckOTAUpdate", mock.Anything, expectedReq).Return(otaResp, nil)
+		h := NewHandlers(mockService)
+
+		rr := httptest.NewRecorder()
+		h.CheckOTAUpdate(rr, newOTARequest(query, "application/cbor"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/cbor", rr.Header().Get("Content-Type"))
+		want, err := encoding.Marshal(encoding.MediaTypeCBOR, otaResp.CompactFields())
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(want, rr.Body.Bytes()))
+	})
+
+	t.Run("flat", func(t *testing.T) {
+		mockService := new(MockUpdateService)
+		mockService.On("CheckOTAUpdate", mock.Anything, expectedReq).Return(otaResp, nil)
+		h := NewHandlers(mockService)
+
+		rr := httptest.NewRecorder()
+		h.CheckOTAUpdate(rr, newOTARequest(query, "text/plain"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "update=true\nversion=1.1.0\n")
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		h := NewHandlers(new(MockUpdateService))
+
+		rr := httptest.NewRecorder()
+		h.CheckOTAUpdate(rr, newOTARequest("current_version=1.0.0&platform=linux&architecture=arm&chunk_size=big", ""))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestHandlers_CheckOTAUpdate_Integration(t *testing.T) {
+	h := newTestHandlers(t)
+
+	body, _ := json.Marshal(models.CreateApplicationRequest{
+		ID:        "sensor-fw",
+		Name:      "Sensor Firmware",
+		Platforms: []string{"linux"},
+		Config:    models.ApplicationConfig{Profile: models.ApplicationProfileOTA},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/applications", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h.CreateApplication(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	createTestRelease(t, h, "sensor-fw", "1.1.0", "linux", "arm")
+
+	rr = httptest.NewRecorder()
+	h.CheckOTAUpdate(rr, newOTARequest("current_version=1.0.0&platform=linux&architecture=arm&slot=b", "text/plain"))
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), "update=true\n")
+	assert.Contains(t, rr.Body.String(), "slot=a\n")
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PauseRelease halts the rollout of a release without deleting it.
+// POST /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/pause
+func (h *Handlers) PauseRelease(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	response, err := h.updateService.PauseRelease(r.Context(), vars["app_id"], vars["version"], vars["platform"], vars["arch"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	h.logPauseAudit(r, "Release rollout paused", response.ID)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// ResumeRelease continues the rollout of a paused release.
+// POST /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/resume
+func (h *Handlers) ResumeRelease(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	response, err := h.updateService.ResumeRelease(r.Context(), vars["app_id"], vars["version"], vars["platform"], vars["arch"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	h.logPauseAudit(r, "Release rollout resumed", response.ID)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *Handlers) logPauseAudit(r *http.Request, msg, releaseID string) {
+	slog.Info(msg,
+		"event", "security_audit",
+		"app_id", mux.Vars(r)["app_id"],
+		"release_id", releaseID,
+		"api_key", getAPIKeyName(GetAPIKey(r)),
+		"client_ip", getClientIP(r))
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_PauseRelease(t *testing.T) {
+	mockService := &MockUpdateService{}
+	h := NewHandlers(mockService)
+	args := []any{mock.Anything, "test-app", "1.0.0", "windows", "amd64"}
+	request := func(action string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/updates/test-app/releases/1.0.0/windows/amd64/"+action, nil)
+		return mux.SetURLVars(req, map[string]string{"app_id": "test-app", "version": "1.0.0", "platform": "windows", "arch": "amd64"})
+	}
+
+	mockService.On("PauseRelease", args...).Return(&models.PauseReleaseResponse{ID: "rel-1", Paused: true}, nil).Once()
+	rr := httptest.NewRecorder()
+	h.PauseRelease(rr, request("pause"))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp models.PauseReleaseResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.True(t, resp.Paused)
+
+	mockService.On("ResumeRelease", args...).Return(&models.PauseReleaseResponse{ID: "rel-1"}, nil).Once()
+	rr = httptest.NewRecorder()
+	h.ResumeRelease(rr, request("resume"))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), `"paused":false`)
+
+	mockService.On("PauseRelease", args...).Return(nil, update.NewNotFoundError("release not found")).Once()
+	rr = httptest.NewRecorder()
+	h.PauseRelease(rr, request("pause"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
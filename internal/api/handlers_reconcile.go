@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"updater/internal/models"
+	"updater/internal/update"
+)
+
+// Reconcile applies a custom resource from the companion Kubernetes operator
+// and returns the status to write back to it
+// POST /api/v1/reconcile
+// Requires authentication and 'admin' permission
+//
+// A malformed resource is rejected with 400. Once the resource is understood
+// the response is 200 with a Ready condition, False when the spec could not be
+// applied, so the operator can surface the reason on the resource; only server
+// errors, which the operator should retry, are answered with an error status.
+func (h *Handlers) Reconcile(w http.ResponseWriter, r *http.Request) {
+	apiKey := GetAPIKey(r)
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" || !strings.HasPrefix(contentType, "application/json") {
+		h.writeErrorResponse(w, http.StatusUnsupportedMediaType, models.ErrorCodeBadRequest, "Content-Type must be application/json")
+		return
+	}
+
+	var resource models.Resource
+	if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid JSON body")
+		return
+	}
+	if err := resource.Validate(); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, err.Error())
+		return
+	}
+
+	slog.Warn("Resource reconcile attempt",
+		"event", "security_audit",
+		"kind", resource.Kind,
+		"name", resource.Metadata.Name,
+		"namespace", resource.Metadata.Namespace,
+		"api_key", getAPIKeyName(apiKey),
+		"client_ip", getClientIP(r))
+
+	r, ok := h.breakGlass(w, r)
+	if !ok {
+		return
+	}
+
+	var (
+		response *models.ApplyDesiredStateResponse
+		err      error
+	)
+	switch resource.Kind {
+	case models.ResourceKindApplication:
+		var state *models.ApplicationDesiredState
+		if state, err = resource.ApplicationState(); err == nil {
+			response, err = h.updateService.ApplyApplicationDesiredState(r.Context(), resource.Metadata.Name, state)
+		} else {
+			err = update.NewValidationError(err.Error(), nil)
+		}
+	case models.ResourceKindRelease:
+		var req *models.RegisterReleaseRequest
+		if req, err = resource.ReleaseRequest(); err == nil {
+			response, err = h.updateService.ApplyReleaseDesiredState(r.Context(), req)
+		} else {
+			err = update.NewValidationError(err.Error(), nil)
+		}
+	}
+
+	condition := models.Condition{
+		Type:               models.ConditionReady,
+		LastTransitionTime: h.now().UTC(),
+	}
+	if err != nil {
+		var serviceError *update.ServiceError
+		if !errors.As(err, &serviceError) || serviceError.StatusCode >= http.StatusInternalServerError {
+			h.writeServiceErrorResponse(w, err)
+			return
+		}
+		condition.Status = models.ConditionFalse
+		condition.Reason = reconcileFailureReason(serviceError.StatusCode)
+		condition.Message = serviceError.Error()
+		slog.Warn("Resource reconcile failed",
+			"event", "security_audit",
+			"kind", resource.Kind,
+			"name", resource.Metadata.Name,
+			"api_key", getAPIKeyName(apiKey),
+			"error", err.Error())
+	} else {
+		condition.Status = models.ConditionTrue
+		condition.Message = response.Message
+		switch {
+		case response.Created:
+			condition.Reason = models.ReasonCreated
+		case len(response.Changes) > 0:
+			condition.Reason = models.ReasonUpdated
+		default:
+			condition.Reason = models.ReasonUpToDate
+		}
+		slog.Info("Resource reconciled",
+			"event", "security_audit",
+			"kind", resource.Kind,
+			"name", resource.Metadata.Name,
+			"api_key", getAPIKeyName(apiKey),
+			"reason", condition.Reason,
+			"changes", response.Changes)
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, models.ReconcileResponse{
+		Status: models.ResourceStatus{
+			ObservedGeneration: resource.Metadata.Generation,
+			Conditions:         []models.Condition{condition},
+		},
+	})
+}
+
+// reconcileFailureReason maps the status of a rejected spec to a condition
+// reason.
+func reconcileFailureReason(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return models.ReasonNotFound
+	case http.StatusConflict:
+		return models.ReasonConflict
+	default:
+		return models.ReasonInvalidSpec
+	}
+}
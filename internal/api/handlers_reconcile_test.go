@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_Reconcile(t *testing.T) {
+	h := newTestHandlers(t)
+
+	reconcile := func(body string) (int, models.Condition) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/reconcile", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		h.Reconcile(rr, req)
+		if rr.Code != http.StatusOK {
+			return rr.Code, models.Condition{}
+		}
+		var resp models.ReconcileResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.Len(t, resp.Status.Conditions, 1)
+		assert.Equal(t, int64(3), resp.Status.ObservedGeneration)
+		return rr.Code, resp.Status.Conditions[0]
+	}
+	resource := func(kind, name, spec string) string {
+		return `{"apiVersion":"` + models.ResourceAPIVersion + `","kind":"` + kind +
+			`","metadata":{"name":"` + name + `","generation":3},"spec":` + spec + `}`
+	}
+
+	const release = `{"application_id":"operator-app","version":"1.0.0","platform":"linux","architecture":"amd64",
+		"download_url":"https://example.com/app-1.0.0.tar.gz","checksum":"abc123","checksum_type":"sha256"}`
+
+	_, condition := reconcile(resource(models.ResourceKindRelease, "operator-app-1-0-0", release))
+	assert.Equal(t, models.ConditionFalse, condition.Status)
+	assert.Equal(t, models.ReasonNotFound, condition.Reason, "the application does not exist yet")
+
+	application := resource(models.ResourceKindApplication, "operator-app", `{"name":"Operator App","platforms":["linux"]}`)
+	_, condition = reconcile(application)
+	assert.Equal(t, models.ConditionTrue, condition.Status)
+	assert.Equal(t, models.ReasonCreated, condition.Reason)
+
+	_, condition = reconcile(application)
+	assert.Equal(t, models.ReasonUpToDate, condition.Reason)
+
+	_, condition = reconcile(resource(models.ResourceKindRelease, "operator-app-1-0-0", release))
+	assert.Equal(t, models.ReasonCreated, condition.Reason)
+
+	_, condition = reconcile(resource(models.ResourceKindRelease, "operator-app-1-0-0", release))
+	assert.Equal(t, models.ReasonUpToDate, condition.Reason, "an identical release is not registered again")
+
+	_, condition = reconcile(resource(models.ResourceKindRelease, "operator-app-1-0-0",
+		strings.Replace(release, `"checksum_type"`, `"required":true,"checksum_type"`, 1)))
+	assert.Equal(t, models.ReasonUpdated, condition.Reason)
+
+	_, condition = reconcile(resource(models.ResourceKindApplication, "operator-app", `{"name":"Operator App","channels":["beta"]}`))
+	assert.Equal(t, models.ConditionFalse, condition.Status)
+	assert.Equal(t, models.ReasonInvalidSpec, condition.Reason)
+
+	code, _ := reconcile(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"x"},"spec":{}}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestHandlers_ReconcileWithClock(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	h := newTestHandlers(t)
+	WithClock(func() time.Time { return now })(h)
+
+	body := `{"apiVersion":"` + models.ResourceAPIVersion + `","kind":"` + models.ResourceKindApplication +
+		`","metadata":{"name":"clock-app"},"spec":{"name":"Clock App","platforms":["linux"]}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/reconcile", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h.Reconcile(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp models.ReconcileResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Status.Conditions, 1)
+	assert.True(t, now.Equal(resp.Status.Conditions[0].LastTransitionTime))
+}
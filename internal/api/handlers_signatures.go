@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// WithPGPPublicKey serves the ASCII-armored public key that release signatures
+// are made with. The key is expected to have been checked with
+// models.ValidatePGPPublicKey.
+func WithPGPPublicKey(key []byte) HandlersOption {
+	return func(h *Handlers) { h.pgpPublicKey = key }
+}
+
+// ReleaseSignature returns the detached OpenPGP signature of a release, ready
+// for gpg --verify.
+// GET /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/signature
+func (h *Handlers) ReleaseSignature(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	signature, err := h.updateService.GetReleaseSignature(r.Context(), vars["app_id"], vars["version"], vars["platform"], vars["arch"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pgp-signature")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(signature + "\n"))
+}
+
+// PGPPublicKey returns the public key release signatures are made with, for
+// gpg --import.
+// GET /api/v1/keys/pgp
+func (h *Handlers) PGPPublicKey(w http.ResponseWriter, r *http.Request) {
+	if len(h.pgpPublicKey) == 0 {
+		h.writeErrorResponse(w, http.StatusNotFound, models.ErrorCodeNotFound, "No PGP public key is configured")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pgp-keys")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.pgpPublicKey)
+}
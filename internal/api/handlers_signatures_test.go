@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPGPSignature = "-----BEGIN PGP SIGNATURE-----\n\niHUEABYKAB0=\n-----END PGP SIGNATURE-----"
+
+func TestHandlers_ReleaseSignature(t *testing.T) {
+	mockService := &MockUpdateService{}
+	mockService.On("GetReleaseSignature", mock.Anything, "my-app", "1.0.0", "linux", "amd64").Return(testPGPSignature, nil)
+	mockService.On("GetReleaseSignature", mock.Anything, "my-app", "2.0.0", "linux", "amd64").Return("", update.NewNotFoundError("release 'my-app-2.0.0-linux-amd64' has no signature"))
+
+	config := &models.Config{Security: models.SecurityConfig{EnableAuth: true}}
+	router := SetupRoutes(NewHandlers(mockService), config)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/my-app/releases/1.0.0/linux/amd64/signature", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "signatures are public")
+	assert.Equal(t, "application/pgp-signature", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, testPGPSignature+"\n", recorder.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/updates/my-app/releases/2.0.0/linux/amd64/signature", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_PGPPublicKey(t *testing.T) {
+	key := []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmDMEZ\n-----END PGP PUBLIC KEY BLOCK-----\n")
+
+	recorder := httptest.NewRecorder()
+	NewHandlers(&MockUpdateService{}, WithPGPPublicKey(key)).PGPPublicKey(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/keys/pgp", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/pgp-keys", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, string(key), recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	NewHandlers(&MockUpdateService{}).PGPPublicKey(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/keys/pgp", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
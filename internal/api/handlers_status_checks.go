@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// ReportStatusCheck records the result of a required status check, such as
+// CI, for every release of a version.
+// POST /api/v1/updates/{app_id}/releases/{version}/statuses
+func (h *Handlers) ReportStatusCheck(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var req models.ReportStatusCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	response, err := h.updateService.ReportStatusCheck(r.Context(), vars["app_id"], vars["version"], &req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	slog.Info("Release status check reported",
+		"event", "security_audit",
+		"app_id", vars["app_id"],
+		"version", vars["version"],
+		"check", req.Name,
+		"state", req.State,
+		"api_key", getAPIKeyName(GetAPIKey(r)),
+		"client_ip", getClientIP(r))
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetReleaseStatus returns the combined status checks of a version's releases.
+// GET /api/v1/updates/{app_id}/releases/{version}/statuses
+func (h *Handlers) GetReleaseStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	response, err := h.updateService.GetReleaseStatus(r.Context(), vars["app_id"], vars["version"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_ReleaseStatusChecks(t *testing.T) {
+	mockService := &MockUpdateService{}
+	h := NewHandlers(mockService)
+	vars := map[string]string{"app_id": "test-app", "version": "1.0.0"}
+	request := func(method, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v1/updates/test-app/releases/1.0.0/statuses", strings.NewReader(body))
+		return mux.SetURLVars(req, vars)
+	}
+
+	reported := &models.ReleaseStatusResponse{
+		ApplicationID: "test-app", Version: "1.0.0", State: models.StatusCheckSuccess,
+		Checks: map[string]models.StatusCheck{"ci": {State: models.StatusCheckSuccess}},
+	}
+	mockService.On("ReportStatusCheck", mock.Anything, "test-app", "1.0.0", &models.ReportStatusCheckRequest{Name: "ci", State: "success"}).Return(reported, nil).Once()
+	rr := httptest.NewRecorder()
+	h.ReportStatusCheck(rr, request(http.MethodPost, `{"name":"ci","state":"success"}`))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp models.ReleaseStatusResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, models.StatusCheckSuccess, resp.State)
+
+	rr = httptest.NewRecorder()
+	h.ReportStatusCheck(rr, request(http.MethodPost, `{"name":`))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	mockService.On("GetReleaseStatus", mock.Anything, "test-app", "1.0.0").Return(nil, update.NewNotFoundError("no releases")).Once()
+	rr = httptest.NewRecorder()
+	h.GetReleaseStatus(rr, request(http.MethodGet, ""))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
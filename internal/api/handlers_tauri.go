@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// TauriManifest serves the Tauri v2 updater manifest of the newest release, so
+// a Tauri app's updater endpoint can point here. Tauri compares the version
+// itself, so the manifest is the same for every installed version.
+// GET /api/v1/updates/{app_id}/tauri?channel=beta
+func (h *Handlers) TauriManifest(w http.ResponseWriter, r *http.Request) {
+	manifest, err := h.updateService.GetTauriManifest(r.Context(), mux.Vars(r)["app_id"],
+		r.URL.Query().Get("channel"), r.Header.Get(licenseTokenHeader))
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", electronCacheControl)
+	h.writeJSONResponse(w, http.StatusOK, manifest)
+}
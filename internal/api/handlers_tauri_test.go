@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_TauriManifest(t *testing.T) {
+	h := newTestHandlers(t)
+	ctx := context.Background()
+	_, err := h.updateService.CreateApplication(ctx, &models.CreateApplicationRequest{
+		ID: "tauri-app", Name: "Tauri App", Platforms: []string{"windows", "darwin"},
+	})
+	require.NoError(t, err)
+	_, err = h.updateService.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "tauri-app", Version: "2.0.0", Platform: "windows", Architecture: "amd64",
+		DownloadURL: "https://cdn.example.com/App_2.0.0_x64-setup.exe", Checksum: "abc123", ChecksumType: "sha256",
+		ReleaseNotes: "Fixes", Variant: models.VariantExeInstaller,
+		Metadata: map[string]string{models.TauriSignatureMetadataKey: "dW50cnVzdGVkIGNvbW1lbnQ="},
+	})
+	require.NoError(t, err)
+	vars := map[string]string{"app_id": "tauri-app"}
+
+	t.Run("manifest", func(t *testing.T) {
+		rr := serveElectron(h, h.TauriManifest, "/api/v1/updates/tauri-app/tauri", vars)
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, electronCacheControl, rr.Header().Get("Cache-Control"))
+		var manifest map[string]any
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &manifest))
+		assert.Equal(t, "2.0.0", manifest["version"])
+		assert.Equal(t, "Fixes", manifest["notes"])
+		assert.NotEmpty(t, manifest["pub_date"])
+		platform := map[string]any{"signature": "dW50cnVzdGVkIGNvbW1lbnQ=", "url": "https://cdn.example.com/App_2.0.0_x64-setup.exe"}
+		assert.Equal(t, map[string]any{"windows-x86_64": platform, "windows-x86_64-nsis": platform}, manifest["platforms"])
+	})
+
+	t.Run("stable release offered on nightly", func(t *testing.T) {
+		rr := serveElectron(h, h.TauriManifest, "/api/v1/updates/tauri-app/tauri?channel=nightly", vars)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		rr := serveElectron(h, h.TauriManifest, "/api/v1/updates/missing/tauri", map[string]string{"app_id": "missing"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
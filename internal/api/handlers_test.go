@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+	"updater/internal/api/encoding"
 	"updater/internal/models"
+	"updater/internal/observability"
 	"updater/internal/storage"
 	"updater/internal/update"
 
@@ -30,6 +34,9 @@ func (m *mockStorage) Applications(_ context.Context) ([]*models.Application, er
 func (m *mockStorage) GetApplication(_ context.Context, _ string) (*models.Application, error) {
 	return nil, nil
 }
+func (m *mockStorage) GetApplicationBySlug(_ context.Context, _ string) (*models.Application, error) {
+	return nil, storage.ErrNotFound
+}
 func (m *mockStorage) SaveApplication(_ context.Context, _ *models.Application) error { return nil }
 func (m *mockStorage) DeleteApplication(_ context.Context, _ string) error            { return nil }
 func (m *mockStorage) Releases(_ context.Context, _ string) ([]*models.Release, error) {
@@ -38,8 +45,12 @@ func (m *mockStorage) Releases(_ context.Context, _ string) ([]*models.Release,
 func (m *mockStorage) GetRelease(_ context.Context, _, _, _, _ string) (*models.Release, error) {
 	return nil, nil
 }
-func (m *mockStorage) SaveRelease(_ context.Context, _ *models.Release) error   { return nil }
-func (m *mockStorage) DeleteRelease(_ context.Context, _, _, _, _ string) error { return nil }
+func (m *mockStorage) GetReleaseByID(_ context.Context, _ string) (*models.Release, error) {
+	return nil, nil
+}
+func (m *mockStorage) SaveRelease(_ context.Context, _ *models.Release) error    { return nil }
+func (m *mockStorage) SaveReleases(_ context.Context, _ []*models.Release) error { return nil }
+func (m *mockStorage) DeleteRelease(_ context.Context, _, _, _, _ string) error  { return nil }
 func (m *mockStorage) GetLatestRelease(_ context.Context, _, _, _ string) (*models.Release, error) {
 	return nil, nil
 }
@@ -64,10 +75,14 @@ func (m *mockStorage) DeleteAPIKey(_ context.Context, _ string) error {
 	return nil
 }
 
-func (m *mockStorage) ListApplicationsPaged(_ context.Context, _ int, _ *models.ApplicationCursor) ([]*models.Application, int, error) {
+func (m *mockStorage) ListApplicationsPaged(_ context.Context, _ models.ApplicationFilters, _ int, _ *models.ApplicationCursor) ([]*models.Application, int, error) {
 	return nil, 0, nil
 }
 
+func (m *mockStorage) ListApplicationGroups(_ context.Context) ([]models.ApplicationGroup, error) {
+	return nil, nil
+}
+
 func (m *mockStorage) ListReleasesPaged(_ context.Context, _ string, _ models.ReleaseFilters, _, _ string, _ int, _ *models.ReleaseCursor) ([]*models.Release, int, error) {
 	return nil, 0, nil
 }
@@ -79,6 +94,13 @@ func (m *mockStorage) GetLatestStableRelease(_ context.Context, _, _, _ string)
 func (m *mockStorage) GetApplicationStats(_ context.Context, _ string) (models.ApplicationStats, error) {
 	return models.ApplicationStats{}, nil
 }
+func (m *mockStorage) SaveContainerImage(_ context.Context, _ *models.ContainerImage) error {
+	return nil
+}
+func (m *mockStorage) ListContainerImages(_ context.Context, _ string) ([]*models.ContainerImage, error) {
+	return nil, nil
+}
+func (m *mockStorage) DeleteContainerImage(_ context.Context, _, _ string) error { return nil }
 
 // MockUpdateService implements the update.ServiceInterface for testing
 type MockUpdateService struct {
@@ -90,11 +112,32 @@ func (m *MockUpdateService) CheckForUpdate(ctx context.Context, req *models.Upda
 	return args.Get(0).(*models.UpdateCheckResponse), args.Error(1)
 }
 
+func (m *MockUpdateService) WaitForUpdate(ctx context.Context, req *models.UpdateCheckRequest, wait time.Duration) (*models.UpdateCheckResponse, error) {
+	args := m.Called(ctx, req, wait)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UpdateCheckResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) BatchCheckForUpdates(ctx context.Context, req *models.BatchUpdateCheckRequest) (*models.BatchUpdateCheckResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BatchUpdateCheckResponse), args.Error(1)
+}
+
 func (m *MockUpdateService) GetLatestVersion(ctx context.Context, req *models.LatestVersionRequest) (*models.LatestVersionResponse, error) {
 	args := m.Called(ctx, req)
 	return args.Get(0).(*models.LatestVersionResponse), args.Error(1)
 }
 
+func (m *MockUpdateService) GetLatestStableVersion(ctx context.Context, appID string) (string, error) {
+	args := m.Called(ctx, appID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockUpdateService) ListReleases(ctx context.Context, req *models.ListReleasesRequest) (*models.ListReleasesResponse, error) {
 	args := m.Called(ctx, req)
 	return args.Get(0).(*models.ListReleasesResponse), args.Error(1)
@@ -113,6 +156,78 @@ func (m *MockUpdateService) CreateApplication(ctx context.Context, req *models.C
 	return args.Get(0).(*models.CreateApplicationResponse), args.Error(1)
 }
 
+func (m *MockUpdateService) DryRunCheckForUpdate(ctx context.Context, req *models.UpdateCheckRequest) (*models.DryRunCheckResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DryRunCheckResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) GetDecisionLog(ctx context.Context, requestID string) (*models.DecisionLogResponse, error) {
+	args := m.Called(ctx, requestID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DecisionLogResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) GetIntegrationSnippets(ctx context.Context, req *models.IntegrationSnippetsRequest) (*models.IntegrationSnippetsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IntegrationSnippetsResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) GetApplicationUsage(ctx context.Context, appID string) (*models.ApplicationUsageResponse, error) {
+	args := m.Called(ctx, appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplicationUsageResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) ListChannels(ctx context.Context, appID string) (*models.ChannelListResponse, error) {
+	args := m.Called(ctx, appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChannelListResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) PublishToChannel(ctx context.Context, appID, channel, version string) (*models.PublishToChannelResponse, error) {
+	args := m.Called(ctx, appID, channel, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PublishToChannelResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) CompareReleases(ctx context.Context, req *models.CompareReleasesRequest) (*models.CompareReleasesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CompareReleasesResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) ListApplicationTemplates(ctx context.Context) (*models.ListApplicationTemplatesResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListApplicationTemplatesResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) CloneApplication(ctx context.Context, sourceID string, req *models.CloneApplicationRequest) (*models.CloneApplicationResponse, error) {
+	args := m.Called(ctx, sourceID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CloneApplicationResponse), args.Error(1)
+}
+
 func (m *MockUpdateService) GetApplication(ctx context.Context, id string) (*models.ApplicationInfoResponse, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -129,6 +244,78 @@ func (m *MockUpdateService) ListApplications(ctx context.Context, req *models.Li
 	return args.Get(0).(*models.ListApplicationsResponse), args.Error(1)
 }
 
+func (m *MockUpdateService) ListApplicationGroups(ctx context.Context) (*models.ListApplicationGroupsResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListApplicationGroupsResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) IngestReleaseManifest(ctx context.Context, manifest *models.ReleaseManifest) (*models.IngestManifestResponse, error) {
+	args := m.Called(ctx, manifest)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IngestManifestResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) CheckContainerImage(ctx context.Context, req *models.ContainerImageCheckRequest) (*models.ContainerImageCheckResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContainerImageCheckResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) RegisterContainerImage(ctx context.Context, req *models.RegisterContainerImageRequest) (*models.ContainerImage, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContainerImage), args.Error(1)
+}
+
+func (m *MockUpdateService) ListContainerImages(ctx context.Context, appID string) (*models.ListContainerImagesResponse, error) {
+	args := m.Called(ctx, appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListContainerImagesResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) DeleteContainerImage(ctx context.Context, appID, tag string) (*models.DeleteContainerImageResponse, error) {
+	args := m.Called(ctx, appID, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DeleteContainerImageResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) CheckOTAUpdate(ctx context.Context, req *models.OTACheckRequest) (*models.OTACheckResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OTACheckResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) VerifyChecksum(ctx context.Context, req *models.VerifyChecksumRequest) (*models.VerifyChecksumResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VerifyChecksumResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) ListPluginUpdates(ctx context.Context, req *models.PluginUpdatesRequest) (*models.PluginUpdatesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PluginUpdatesResponse), args.Error(1)
+}
+
 func (m *MockUpdateService) UpdateApplication(ctx context.Context, id string, req *models.UpdateApplicationRequest) (*models.UpdateApplicationResponse, error) {
 	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
@@ -137,11 +324,48 @@ func (m *MockUpdateService) UpdateApplication(ctx context.Context, id string, re
 	return args.Get(0).(*models.UpdateApplicationResponse), args.Error(1)
 }
 
+func (m *MockUpdateService) ApplyApplicationDesiredState(ctx context.Context, id string, state *models.ApplicationDesiredState) (*models.ApplyDesiredStateResponse, error) {
+	args := m.Called(ctx, id, state)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplyDesiredStateResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) ApplyReleaseDesiredState(ctx context.Context, req *models.RegisterReleaseRequest) (*models.ApplyDesiredStateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplyDesiredStateResponse), args.Error(1)
+}
+
 func (m *MockUpdateService) DeleteApplication(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockUpdateService) GetReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) (*models.ReleaseNotesDraftResponse, error) {
+	args := m.Called(ctx, appID, version, platform, arch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReleaseNotesDraftResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) PublishReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) (*models.ReleaseNotesDraftResponse, error) {
+	args := m.Called(ctx, appID, version, platform, arch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReleaseNotesDraftResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) DiscardReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) error {
+	args := m.Called(ctx, appID, version, platform, arch)
+	return args.Error(0)
+}
+
 func (m *MockUpdateService) DeleteRelease(ctx context.Context, appID, version, platform, arch string) (*models.DeleteReleaseResponse, error) {
 	args := m.Called(ctx, appID, version, platform, arch)
 	if args.Get(0) == nil {
@@ -150,6 +374,101 @@ func (m *MockUpdateService) DeleteRelease(ctx context.Context, appID, version, p
 	return args.Get(0).(*models.DeleteReleaseResponse), args.Error(1)
 }
 
+func (m *MockUpdateService) PauseRelease(ctx context.Context, appID, version, platform, arch string) (*models.PauseReleaseResponse, error) {
+	args := m.Called(ctx, appID, version, platform, arch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PauseReleaseResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) ResumeRelease(ctx context.Context, appID, version, platform, arch string) (*models.PauseReleaseResponse, error) {
+	args := m.Called(ctx, appID, version, platform, arch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PauseReleaseResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) YankRelease(ctx context.Context, appID, version, platform, arch string, req *models.YankReleaseRequest) (*models.YankReleaseResponse, error) {
+	args := m.Called(ctx, appID, version, platform, arch, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.YankReleaseResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) GetTauriManifest(ctx context.Context, appID, channel, licenseToken string) (*models.TauriUpdateManifest, error) {
+	args := m.Called(ctx, appID, channel, licenseToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TauriUpdateManifest), args.Error(1)
+}
+
+func (m *MockUpdateService) GetAndroidIndex(ctx context.Context, appID, channel, licenseToken string) (*models.AndroidIndex, error) {
+	args := m.Called(ctx, appID, channel, licenseToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AndroidIndex), args.Error(1)
+}
+
+func (m *MockUpdateService) ReportStatusCheck(ctx context.Context, appID, version string, req *models.ReportStatusCheckRequest) (*models.ReleaseStatusResponse, error) {
+	args := m.Called(ctx, appID, version, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReleaseStatusResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) GetReleaseStatus(ctx context.Context, appID, version string) (*models.ReleaseStatusResponse, error) {
+	args := m.Called(ctx, appID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReleaseStatusResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) UnyankRelease(ctx context.Context, appID, version, platform, arch string) (*models.YankReleaseResponse, error) {
+	args := m.Called(ctx, appID, version, platform, arch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.YankReleaseResponse), args.Error(1)
+}
+
+// UploadReleaseArtifact records the uploaded bytes as a string, so tests can
+// match on them.
+func (m *MockUpdateService) UploadReleaseArtifact(ctx context.Context, appID, version, platform, arch string, body io.Reader) (*models.UploadArtifactResponse, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx, appID, version, platform, arch, string(data))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadArtifactResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) UploadReleaseArtifactByID(ctx context.Context, appID, releaseID string, body io.Reader) (*models.UploadArtifactResponse, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx, appID, releaseID, string(data))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadArtifactResponse), args.Error(1)
+}
+
+func (m *MockUpdateService) GetReleaseSignature(ctx context.Context, appID, version, platform, arch string) (string, error) {
+	args := m.Called(ctx, appID, version, platform, arch)
+	return args.String(0), args.Error(1)
+}
+
 func TestNewHandlers(t *testing.T) {
 	mockService := &MockUpdateService{}
 	handlers := NewHandlers(mockService)
@@ -268,6 +587,108 @@ func TestHandlers_CheckForUpdates_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestHandlers_LicenseTokenHeader(t *testing.T) {
+	mockService := &MockUpdateService{}
+	handlers := NewHandlers(mockService)
+	hasToken := func(token string) func(*models.UpdateCheckRequest) bool {
+		return func(req *models.UpdateCheckRequest) bool { return req.LicenseToken == token }
+	}
+	mockService.On("CheckForUpdate", mock.Anything, mock.MatchedBy(hasToken("header-token"))).Return(&models.UpdateCheckResponse{}, nil).Twice()
+	mockService.On("CheckForUpdate", mock.Anything, mock.MatchedBy(hasToken("body-token"))).Return(&models.UpdateCheckResponse{}, nil).Once()
+	mockService.On("GetLatestVersion", mock.Anything, mock.MatchedBy(func(req *models.LatestVersionRequest) bool {
+		return req.LicenseToken == "header-token"
+	})).Return(&models.LatestVersionResponse{}, nil).Once()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/updates/{app_id}/check", handlers.CheckForUpdates).Methods("GET")
+	router.HandleFunc("/api/v1/updates/{app_id}/latest", handlers.GetLatestVersion).Methods("GET")
+	router.HandleFunc("/api/v1/check", handlers.CheckForUpdates).Methods("POST")
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/check?current_version=1.0.0&platform=windows&architecture=amd64", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/latest?platform=windows&architecture=amd64", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/check", strings.NewReader(`{"application_id":"test-app","current_version":"1.0.0","platform":"windows","architecture":"amd64"}`)),
+		httptest.NewRequest(http.MethodPost, "/api/v1/check", strings.NewReader(`{"application_id":"test-app","current_version":"1.0.0","platform":"windows","architecture":"amd64","license_token":"body-token"}`)),
+	}
+	for _, req := range requests {
+		req.Header.Set("X-License-Token", "header-token")
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code, req.URL.String())
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_BatchCheckForUpdates(t *testing.T) {
+	h := newTestHandlers(t)
+	createTestApplication(t, h, "agent-core", "Agent Core")
+	createTestApplication(t, h, "agent-plugin", "Agent Plugin")
+	createTestRelease(t, h, "agent-core", "2.0.0", "windows", "amd64")
+	createTestRelease(t, h, "agent-plugin", "1.0.0", "windows", "amd64")
+
+	body, err := json.Marshal(models.BatchUpdateCheckRequest{Checks: []models.UpdateCheckRequest{
+		{ApplicationID: "agent-core", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64"},
+		{ApplicationID: "agent-plugin", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64"},
+		{ApplicationID: "missing", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64"},
+		{ApplicationID: "agent-core", CurrentVersion: "not-a-version", Platform: "windows", Architecture: "amd64"},
+	}})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/check/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	h.BatchCheckForUpdates(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response models.BatchUpdateCheckResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Results, 4)
+
+	require.NotNil(t, response.Results[0].Result)
+	assert.True(t, response.Results[0].Result.UpdateAvailable)
+	assert.Equal(t, "2.0.0", response.Results[0].Result.LatestVersion)
+
+	require.NotNil(t, response.Results[1].Result)
+	assert.False(t, response.Results[1].Result.UpdateAvailable)
+
+	assert.Nil(t, response.Results[2].Result)
+	require.NotNil(t, response.Results[2].Error)
+	assert.Equal(t, models.ErrorCodeApplicationNotFound, response.Results[2].Error.Code)
+
+	require.NotNil(t, response.Results[3].Error)
+	assert.Equal(t, models.ErrorCodeValidation, response.Results[3].Error.Code)
+}
+
+func TestHandlers_BatchCheckForUpdates_InvalidBatch(t *testing.T) {
+	h := newTestHandlers(t)
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{name: "wrong content type", contentType: "text/plain", body: `{"checks":[]}`, expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "invalid json", contentType: "application/json", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "empty batch", contentType: "application/json", body: `{"checks":[]}`, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/check/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			recorder := httptest.NewRecorder()
+
+			h.BatchCheckForUpdates(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
 func TestHandlers_GetLatestVersion_Success(t *testing.T) {
 	mockService := &MockUpdateService{}
 	handlers := NewHandlers(mockService)
@@ -390,6 +811,47 @@ func TestHandlers_ListReleases_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestHandlers_CompareReleases(t *testing.T) {
+	mockService := &MockUpdateService{}
+	handlers := NewHandlers(mockService)
+
+	expected := &models.CompareReleasesRequest{ApplicationID: "test-app", From: "1.0.0", To: "1.1.0", Platform: "windows"}
+	mockService.On("CompareReleases", mock.Anything, expected).Return(&models.CompareReleasesResponse{
+		ApplicationID: "test-app",
+		From:          "1.0.0",
+		To:            "1.1.0",
+		Targets: []models.ReleaseTarget{{
+			Platform:      "windows",
+			Architecture:  "amd64",
+			Status:        models.CompareStatusChanged,
+			FileSizeDelta: 200,
+			Changes:       []models.FieldChange{{Field: "file_size", From: 1000, To: 1200}},
+		}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/releases/compare?from=1.0.0&to=1.1.0&platform=windows", nil)
+	recorder := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/updates/{app_id}/releases/compare", handlers.CompareReleases).Methods("GET")
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{
+		"application_id": "test-app",
+		"from": "1.0.0",
+		"to": "1.1.0",
+		"targets": [{
+			"platform": "windows",
+			"architecture": "amd64",
+			"status": "changed",
+			"file_size_delta": 200,
+			"changes": [{"field": "file_size", "from": 1000, "to": 1200}]
+		}]
+	}`, recorder.Body.String())
+	mockService.AssertExpectations(t)
+}
+
 func TestHandlers_ListReleases_WithPagination(t *testing.T) {
 	mockService := &MockUpdateService{}
 	handlers := NewHandlers(mockService)
@@ -813,3 +1275,213 @@ func TestHandlers_ErrorResponseFormat(t *testing.T) {
 	assert.NotEmpty(t, errorResponse.Timestamp)
 	assert.Empty(t, errorResponse.Details) // Should be empty for this error type
 }
+
+func TestHandlers_GetLatestVersion_ContentNegotiation(t *testing.T) {
+	latest := &models.LatestVersionResponse{
+		Version:     "2.0.0",
+		DownloadURL: "https://example.com/v2.0.0/app.exe",
+		Checksum:    "def456",
+		ReleaseDate: time.Now(),
+	}
+
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+	}{
+		{name: "json by default", wantContentType: "application/json"},
+		{name: "cbor", accept: "application/cbor", wantContentType: encoding.MediaTypeCBOR},
+		{name: "msgpack alias", accept: "application/x-msgpack", wantContentType: encoding.MediaTypeMsgPack},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockUpdateService{}
+			mockService.On("GetLatestVersion", mock.Anything, mock.AnythingOfType("*models.LatestVersionRequest")).Return(latest, nil)
+			handlers := NewHandlers(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/latest?app_id=test-app&platform=windows&architecture=amd64", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			recorder := httptest.NewRecorder()
+			handlers.GetLatestVersion(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, tt.wantContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+			mockService.AssertExpectations(t)
+		})
+	}
+
+	t.Run("msgpack body honours fields", func(t *testing.T) {
+		mockService := &MockUpdateService{}
+		mockService.On("GetLatestVersion", mock.Anything, mock.AnythingOfType("*models.LatestVersionRequest")).Return(latest, nil)
+		handlers := NewHandlers(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/latest?app_id=test-app&platform=windows&architecture=amd64&fields=version", nil)
+		req.Header.Set("Accept", "application/msgpack")
+		recorder := httptest.NewRecorder()
+		handlers.GetLatestVersion(recorder, req)
+
+		// {"version": "2.0.0"}
+		assert.Equal(t, []byte("\x81\xa7version\xa52.0.0"), recorder.Body.Bytes())
+	})
+}
+
+func TestHandlers_CheckForUpdates_DryRun(t *testing.T) {
+	mockService := &MockUpdateService{}
+	mockService.On("DryRunCheckForUpdate", mock.Anything, mock.AnythingOfType("*models.UpdateCheckRequest")).Return(&models.DryRunCheckResponse{
+		Decision: models.CheckDecisionRejected,
+		Error:    "application test-app does not support platform linux",
+		Trace: []models.DecisionStep{
+			{Rule: models.RuleApplication, Result: models.DecisionPass, Detail: "application test-app found"},
+			{Rule: models.RulePlatform, Result: models.DecisionFail, Detail: "linux is not one of the application's platforms (windows)"},
+		},
+	}, nil)
+	handlers := NewHandlers(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/check?current_version=1.0.0&platform=linux&architecture=amd64&dry_run=true&wait=30s", nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+	recorder := httptest.NewRecorder()
+	handlers.CheckForUpdates(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var resp models.DryRunCheckResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, models.CheckDecisionRejected, resp.Decision)
+	assert.Len(t, resp.Trace, 2)
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "WaitForUpdate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandlers_CheckForUpdates_ClientAttributes(t *testing.T) {
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.MatchedBy(func(req *models.UpdateCheckRequest) bool {
+		return req.OSVersion == "14.2" && req.Locale == "de-AT" && assert.ObjectsAreEqual([]string{"insider", "m2"}, req.ClientTags) &&
+			req.Variant == "dmg"
+	})).Return(&models.UpdateCheckResponse{CurrentVersion: "1.0.0"}, nil)
+	handlers := NewHandlers(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/check?current_version=1.0.0&platform=darwin&architecture=arm64&os_version=14.2&locale=de-AT&client_tags=insider,%20m2&variant=dmg", nil)
+	req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+	recorder := httptest.NewRecorder()
+	handlers.CheckForUpdates(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_CheckForUpdates_VersionCode(t *testing.T) {
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.MatchedBy(func(req *models.UpdateCheckRequest) bool {
+		return req.VersionCode == 42
+	})).Return(&models.UpdateCheckResponse{CurrentVersion: "1.0.0"}, nil).Once()
+	handlers := NewHandlers(mockService)
+
+	check := func(versionCode string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/check?current_version=1.0.0&platform=android&architecture=arm64&version_code="+versionCode, nil)
+		req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+		recorder := httptest.NewRecorder()
+		handlers.CheckForUpdates(recorder, req)
+		return recorder
+	}
+	assert.Equal(t, http.StatusOK, check("42").Code)
+	assert.Equal(t, http.StatusBadRequest, check("forty-two").Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_GetDecisionLog(t *testing.T) {
+	mockService := &MockUpdateService{}
+	mockService.On("GetDecisionLog", mock.Anything, "req-1").Return(&models.DecisionLogResponse{
+		RequestID: "req-1",
+		Records:   []models.DecisionRecord{{RequestID: "req-1", Decision: models.CheckDecisionNoUpdate, Trace: []models.DecisionStep{}}},
+	}, nil)
+	mockService.On("GetDecisionLog", mock.Anything, "missing").Return(nil, update.NewNotFoundError("no update checks recorded for request missing"))
+	handlers := NewHandlers(mockService)
+
+	tests := []struct {
+		requestID string
+		wantCode  int
+	}{
+		{"req-1", http.StatusOK},
+		{"missing", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/decisions/"+tt.requestID, nil)
+		req = mux.SetURLVars(req, map[string]string{"request_id": tt.requestID})
+		recorder := httptest.NewRecorder()
+		handlers.GetDecisionLog(recorder, req)
+		assert.Equal(t, tt.wantCode, recorder.Code, tt.requestID)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestHandlers_HealthHistory(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		handlers := NewHandlers(&MockUpdateService{})
+		recorder := httptest.NewRecorder()
+		handlers.HealthHistory(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/health/history", nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	history := observability.NewHealthHistory(time.Minute, time.Hour, func(context.Context) error { return nil })
+	handlers := NewHandlers(&MockUpdateService{}, WithHealthHistory(history))
+
+	tests := []struct {
+		since    string
+		wantCode int
+	}{
+		{"", http.StatusOK},
+		{"1h", http.StatusOK},
+		{"2026-01-02T15:04:05Z", http.StatusOK},
+		{"-1h", http.StatusBadRequest},
+		{"yesterday", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		handlers.HealthHistory(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/health/history?since="+tt.since, nil))
+		assert.Equal(t, tt.wantCode, recorder.Code, tt.since)
+	}
+
+	recorder := httptest.NewRecorder()
+	handlers.HealthHistory(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/health/history", nil))
+	var resp models.HealthHistoryResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, "1m0s", resp.Interval)
+	assert.Equal(t, 1.0, resp.Availability)
+}
+
+func TestHandlers_CheckForUpdates_Wait(t *testing.T) {
+	noUpdate := &models.UpdateCheckResponse{UpdateAvailable: false, CurrentVersion: "1.0.0"}
+
+	tests := []struct {
+		name     string
+		wait     string
+		wantWait time.Duration
+		wantCode int
+	}{
+		{name: "duration", wait: "60s", wantWait: time.Minute, wantCode: http.StatusOK},
+		{name: "seconds", wait: "45", wantWait: 45 * time.Second, wantCode: http.StatusOK},
+		{name: "invalid", wait: "soon", wantCode: http.StatusBadRequest},
+		{name: "negative", wait: "-5s", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockUpdateService{}
+			if tt.wantCode == http.StatusOK {
+				mockService.On("WaitForUpdate", mock.Anything, mock.AnythingOfType("*models.UpdateCheckRequest"), tt.wantWait).Return(noUpdate, nil)
+			}
+			handlers := NewHandlers(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/updates/test-app/check?current_version=1.0.0&platform=windows&architecture=amd64&wait="+tt.wait, nil)
+			req = mux.SetURLVars(req, map[string]string{"app_id": "test-app"})
+			recorder := httptest.NewRecorder()
+			handlers.CheckForUpdates(recorder, req)
+
+			assert.Equal(t, tt.wantCode, recorder.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"updater/internal/models"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// VerifyChecksum confirms or denies a checksum a client computed for its
+// download. Mismatches are logged as security events: a corrupted CDN edge
+// or a tampered mirror shows up here before users report broken installs.
+// POST /api/v1/verify
+func (h *Handlers) VerifyChecksum(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		h.writeErrorResponse(w, http.StatusUnsupportedMediaType, models.ErrorCodeBadRequest, "Content-Type must be application/json")
+		return
+	}
+
+	var req models.VerifyChecksumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "Invalid JSON body")
+		return
+	}
+
+	response, err := h.updateService.VerifyChecksum(r.Context(), &req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+
+	result := "match"
+	if !response.Verified {
+		result = "mismatch"
+		slog.Warn("Client reported a checksum mismatch",
+			"event", "security_audit",
+			"client_ip", getClientIP(r),
+			"release_id", response.ReleaseID,
+			"app_id", req.ApplicationID,
+			"version", req.Version,
+			"platform", req.Platform,
+			"arch", req.Architecture,
+			"checksum_type", response.ChecksumType,
+			"reported_checksum", req.Checksum)
+	}
+	if h.appMetrics != nil {
+		h.appMetrics.ChecksumReports.Add(r.Context(), 1, metric.WithAttributes(
+			attribute.String("app_id", req.ApplicationID),
+			attribute.String("result", result),
+		))
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_VerifyChecksum(t *testing.T) {
+	body := `{"application_id":"app","version":"1.2.0","platform":"linux","architecture":"amd64","checksum":"abc123"}`
+	expectedReq := &models.VerifyChecksumRequest{
+		ApplicationID: "app",
+		Version:       "1.2.0",
+		Platform:      "linux",
+		Architecture:  "amd64",
+		Checksum:      "abc123",
+	}
+	newRequest := func(contentType string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/verify", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	for _, verified := range []bool{true, false} {
+		mockService := &MockUpdateService{}
+		mockService.On("VerifyChecksum", mock.Anything, expectedReq).Return(&models.VerifyChecksumResponse{
+			Verified:     verified,
+			ReleaseID:    "app-1.2.0-linux-amd64",
+			ChecksumType: models.ChecksumTypeSHA256,
+		}, nil)
+		config := &models.Config{Security: models.SecurityConfig{EnableAuth: true}}
+		router := SetupRoutes(NewHandlers(mockService), config)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest("application/json"))
+		require.Equal(t, http.StatusOK, recorder.Code, "checksum reports need no API key")
+		var resp models.VerifyChecksumResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+		assert.Equal(t, verified, resp.Verified)
+		mockService.AssertExpectations(t)
+	}
+
+	t.Run("requires JSON", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		NewHandlers(&MockUpdateService{}).VerifyChecksum(recorder, newRequest("text/plain"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService := &MockUpdateService{}
+		mockService.On("VerifyChecksum", mock.Anything, expectedReq).Return(nil, update.NewNotFoundError("release not found"))
+		recorder := httptest.NewRecorder()
+		NewHandlers(mockService).VerifyChecksum(recorder, newRequest("application/json"))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// YankRelease withdraws a release. The body, with the reason for the yank,
+// is optional.
+// POST /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/yank
+func (h *Handlers) YankRelease(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var req models.YankReleaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		if isMaxBytesError(err) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, models.ErrorCodeBadRequest, "Request body too large")
+			return
+		}
+		h.writeErrorResponse(w, http.StatusBadRequest, models.ErrorCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	response, err := h.updateService.YankRelease(r.Context(), vars["app_id"], vars["version"], vars["platform"], vars["arch"], &req)
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	h.logYankAudit(r, "Release yanked", response)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UnyankRelease withdraws the yank of a release.
+// DELETE /api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/yank
+func (h *Handlers) UnyankRelease(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	response, err := h.updateService.UnyankRelease(r.Context(), vars["app_id"], vars["version"], vars["platform"], vars["arch"])
+	if err != nil {
+		h.writeServiceErrorResponse(w, err)
+		return
+	}
+	h.logYankAudit(r, "Release yank withdrawn", response)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *Handlers) logYankAudit(r *http.Request, msg string, response *models.YankReleaseResponse) {
+	slog.Info(msg,
+		"event", "security_audit",
+		"app_id", mux.Vars(r)["app_id"],
+		"release_id", response.ID,
+		"reason", response.YankReason,
+		"api_key", getAPIKeyName(GetAPIKey(r)),
+		"client_ip", getClientIP(r))
+}
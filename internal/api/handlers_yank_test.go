@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_YankRelease(t *testing.T) {
+	mockService := &MockUpdateService{}
+	h := NewHandlers(mockService)
+	args := []any{mock.Anything, "test-app", "1.0.0", "windows", "amd64"}
+	request := func(method, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v1/updates/test-app/releases/1.0.0/windows/amd64/yank", strings.NewReader(body))
+		return mux.SetURLVars(req, map[string]string{"app_id": "test-app", "version": "1.0.0", "platform": "windows", "arch": "amd64"})
+	}
+
+	mockService.On("YankRelease", append(args, &models.YankReleaseRequest{Reason: "crashes on start"})...).
+		Return(&models.YankReleaseResponse{ID: "rel-1", Yanked: true, YankReason: "crashes on start"}, nil).Once()
+	rr := httptest.NewRecorder()
+	h.YankRelease(rr, request(http.MethodPost, `{"reason":"crashes on start"}`))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resp models.YankReleaseResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.True(t, resp.Yanked)
+	assert.Equal(t, "crashes on start", resp.YankReason)
+
+	// The body is optional
+	mockService.On("YankRelease", append(args, &models.YankReleaseRequest{})...).
+		Return(&models.YankReleaseResponse{ID: "rel-1", Yanked: true}, nil).Once()
+	rr = httptest.NewRecorder()
+	h.YankRelease(rr, request(http.MethodPost, ""))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	h.YankRelease(rr, request(http.MethodPost, "{"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	mockService.On("UnyankRelease", args...).Return(&models.YankReleaseResponse{ID: "rel-1"}, nil).Once()
+	rr = httptest.NewRecorder()
+	h.UnyankRelease(rr, request(http.MethodDelete, ""))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), `"yanked":false`)
+
+	mockService.On("UnyankRelease", args...).Return(nil, update.NewNotFoundError("release not found")).Once()
+	rr = httptest.NewRecorder()
+	h.UnyankRelease(rr, request(http.MethodDelete, ""))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
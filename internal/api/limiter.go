@@ -0,0 +1,289 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"updater/internal/models"
+	"updater/internal/observability"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Route classes the concurrency limiter keeps in separate lanes, so a spike
+// in one cannot starve the others.
+const (
+	routeClassPublic        = "public"
+	routeClassAuthenticated = "authenticated"
+	routeClassAdmin         = "admin"
+	routeClassPriority      = "priority"
+)
+
+// Reasons a request is shed, reported in updater_requests_shed_total.
+const (
+	shedQueueFull    = "queue_full"
+	shedQueueTimeout = "queue_timeout"
+	shedCanceled     = "canceled"
+	shedNotPriority  = "not_priority"
+	shedBackground   = "background"
+)
+
+// checkPriorityHeader lets clients declare what triggered a request:
+// interactive, the default, for a user clicking "Check for updates", or
+// background for a scheduled check that can wait.
+const (
+	checkPriorityHeader     = "X-Check-Priority"
+	checkPriorityBackground = "background"
+)
+
+// publicRoutes are the path templates of the unauthenticated client endpoints.
+var publicRoutes = map[string]bool{
+	"/api/v1/updates/{app_id}/check":      true,
+	"/api/v1/updates/{app_id}/latest":     true,
+	"/api/v1/updates/{app_id}/plugins":    true,
+	"/api/v1/updates/{app_id}/image":      true,
+	"/api/v1/updates/{app_id}/ota":        true,
+	"/api/v1/updates/{app_id}/tauri":      true,
+	"/api/v1/updates/{app_id}/android":    true,
+	"/api/v1/check":                       true,
+	"/api/v1/check/batch":                 true,
+	"/api/v1/latest":                      true,
+	"/api/v1/verify":                      true,
+	"/badge/{app_id}/version.svg":         true,
+	"/badge/{app_id}/version.json":        true,
+	"/api/v1/badge/{app_id}/version.svg":  true,
+	"/api/v1/badge/{app_id}/version.json": true,
+	"/status.json":                        true,
+	"/api/v1/status.json":                 true,
+	"/api/v1/keys/pgp":                    true,
+	"/api/v1/client-tokens/challenge":     true,
+	"/api/v1/client-tokens":               true,
+	"/api/v1/client-bundle":               true,
+	"/api/v1/keys/client-bundle":          true,
+	"/api/v1/keys/client-bundle/history":  true,
+	"/api/v1/error-codes":                 true,
+	"/check":                              true, // Vanity host endpoints
+	"/latest":                             true,
+	"/plugins":                            true,
+	"/image":                              true,
+	"/ota":                                true,
+	"/artifacts/":                         true, // Hosted artifacts of the local store
+
+	"/api/v1/updates/{app_id}/electron/{file}":                                true,
+	"/api/v1/updates/{app_id}/squirrel/RELEASES":                              true,
+	"/api/v1/updates/{app_id}/releases/{version}/{platform}/{arch}/signature": true,
+}
+
+// priorityRoutes are the update checks that may use the priority lane when
+// the public lane is full.
+var priorityRoutes = map[string]bool{
+	"/api/v1/updates/{app_id}/check": true,
+	"/api/v1/check":                  true,
+	"/check":                         true,
+}
+
+// unlimitedRoutes are never limited, so probes and the API docs still answer
+// while the service sheds load.
+var unlimitedRoutes = map[string]bool{
+	"/health":              true,
+	"/api/v1/health":       true,
+	"/version":             true,
+	"/api/v1/version":      true,
+	"/api/v1/openapi.yaml": true,
+	"/api/v1/docs":         true,
+}
+
+// routeClass returns the lane a request belongs to, or "" for requests that
+// are never limited. Dry-run checks are admin operations even though they
+// share the public check paths.
+func routeClass(r *http.Request) string {
+	if r.Method == http.MethodOptions {
+		return ""
+	}
+	tpl := routePath(r)
+	switch {
+	case unlimitedRoutes[tpl]:
+		return ""
+	case publicRoutes[tpl] && r.URL.Query().Get(dryRunParam) == "true":
+		return routeClassAdmin
+	case publicRoutes[tpl]:
+		return routeClassPublic
+	case strings.HasPrefix(tpl, "/api/v1/admin/"), tpl == "/api/v1/reconcile", r.Method == http.MethodPut, r.Method == http.MethodDelete:
+		return routeClassAdmin
+	default:
+		return routeClassAuthenticated
+	}
+}
+
+// lane bounds the requests of one route class: slots holds a token per request
+// being served and waiting a token per request queued for a slot.
+type lane struct {
+	slots   chan struct{}
+	waiting chan struct{}
+}
+
+func newLane(limit models.ConcurrencyLimit) *lane {
+	return &lane{
+		slots:   make(chan struct{}, limit.MaxInFlight),
+		waiting: make(chan struct{}, limit.MaxQueue),
+	}
+}
+
+// acquire takes a slot, queueing for up to timeout when all are in use. It
+// returns the shed reason when no slot was taken.
+func (l *lane) acquire(ctx context.Context, timeout time.Duration) (bool, string) {
+	select {
+	case l.slots <- struct{}{}:
+		return true, ""
+	default:
+	}
+
+	select {
+	case l.waiting <- struct{}{}:
+	default:
+		return false, shedQueueFull
+	}
+	defer func() { <-l.waiting }()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return true, ""
+	case <-timer.C:
+		return false, shedQueueTimeout
+	case <-ctx.Done():
+		return false, shedCanceled
+	}
+}
+
+// tryAcquire takes a slot without queueing, and only while more than reserve
+// slots are free and nothing is queued, so it never delays other requests.
+func (l *lane) tryAcquire(reserve int) bool {
+	if len(l.waiting) > 0 || cap(l.slots)-len(l.slots) <= reserve {
+		return false
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *lane) release() {
+	<-l.slots
+}
+
+// concurrencyLimiter gives each route class its own lane.
+type concurrencyLimiter struct {
+	lanes                map[string]*lane
+	queueTimeout         time.Duration
+	backgroundReserve    int
+	backgroundRetryAfter time.Duration
+	metrics              *observability.AppMetrics
+}
+
+func newConcurrencyLimiter(cfg models.ConcurrencyConfig, metrics *observability.AppMetrics) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		lanes: map[string]*lane{
+			routeClassPublic:        newLane(cfg.Public),
+			routeClassAuthenticated: newLane(cfg.Authenticated),
+			routeClassAdmin:         newLane(cfg.Admin),
+			routeClassPriority:      newLane(cfg.Priority),
+		},
+		queueTimeout:         cfg.QueueTimeout,
+		backgroundReserve:    int(cfg.BackgroundReserve * float64(cfg.Public.MaxInFlight)),
+		backgroundRetryAfter: cfg.BackgroundRetryAfter,
+		metrics:              metrics,
+	}
+}
+
+// Middleware serves requests within their lane's limit and answers the rest
+// with 503 and a Retry-After header. An update check shed from the public lane
+// is retried in the priority lane, marked so the handler only answers it when
+// it offers a required or security release. Public requests marked as
+// background checks are shed first: they never queue, cannot take the public
+// slots reserved for interactive checks and are told to retry much later.
+func (cl *concurrencyLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class := routeClass(r)
+		l := cl.lanes[class]
+		if l == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if class == routeClassPublic && strings.EqualFold(r.Header.Get(checkPriorityHeader), checkPriorityBackground) {
+			if !l.tryAcquire(cl.backgroundReserve) {
+				recordShed(cl.metrics, r, class, shedBackground)
+				writeBusyResponse(w, cl.backgroundRetryAfter)
+				return
+			}
+			defer l.release()
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ok, reason := l.acquire(r.Context(), cl.queueTimeout)
+		if !ok && reason != shedCanceled && class == routeClassPublic && priorityRoutes[routePath(r)] {
+			recordShed(cl.metrics, r, class, reason)
+			class, l = routeClassPriority, cl.lanes[routeClassPriority]
+			ok, reason = l.acquire(r.Context(), cl.queueTimeout)
+			r = r.WithContext(context.WithValue(r.Context(), priorityOnlyKey{}, true))
+		}
+		if !ok {
+			recordShed(cl.metrics, r, class, reason)
+			writeBusyResponse(w, time.Second)
+			return
+		}
+		defer l.release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// priorityOnlyKey marks a request served through the priority lane.
+type priorityOnlyKey struct{}
+
+// isPriorityOnly reports whether the request was admitted through the priority
+// lane and must be shed unless it offers a required or security release.
+func isPriorityOnly(r *http.Request) bool {
+	v, _ := r.Context().Value(priorityOnlyKey{}).(bool)
+	return v
+}
+
+// routePath returns the path template of the matched route.
+func routePath(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return ""
+}
+
+// writeBusyResponse answers a shed request, asking the client to retry after
+// retryAfter, rounded up to whole seconds.
+func writeBusyResponse(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(max(int((retryAfter+time.Second-1)/time.Second), 1)))
+	w.WriteHeader(http.StatusServiceUnavailable)
+	errorResp := models.NewErrorResponse("Server is busy, retry later", models.ErrorCodeServiceUnavailable)
+	json.NewEncoder(w).Encode(errorResp)
+}
+
+// recordShed increments the shed-request counter when app metrics are configured.
+func recordShed(metrics *observability.AppMetrics, r *http.Request, class, reason string) {
+	if metrics == nil {
+		return
+	}
+	metrics.RequestsShed.Add(r.Context(), 1, metric.WithAttributes(
+		attribute.String("class", class),
+		attribute.String("reason", reason),
+	))
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/clientbundle"
+	"updater/internal/models"
+	"updater/internal/signing"
+	"updater/internal/update"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteClass(t *testing.T) {
+	var got string
+	capture := func(r *mux.Router) {
+		r.Use(func(http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { got = routeClass(r) })
+		})
+	}
+	config := &models.Config{
+		Security:      models.SecurityConfig{EnableAuth: true},
+		Observability: models.ObservabilityConfig{StatusPage: models.StatusPageConfig{Enabled: true, CheckWindow: time.Hour}},
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := clientbundle.New(context.Background(), models.ClientBundleConfig{Enabled: true, Validity: time.Hour},
+		[]clientbundle.SigningKey{{Key: signing.NewLocal(key)}})
+	require.NoError(t, err)
+	router := SetupRoutes(NewHandlers(&MockUpdateService{},
+		WithArtifacts(models.ArtifactsConfig{}, http.NotFoundHandler()), WithClientBundle(signer)), config, capture)
+
+	tests := []struct {
+		method string
+		target string
+		want   string
+	}{
+		{http.MethodGet, "/api/v1/updates/app/check?current_version=1.0.0", routeClassPublic},
+		{http.MethodGet, "/api/v1/updates/app/check?dry_run=true", routeClassAdmin},
+		{http.MethodGet, "/api/v1/updates/app/electron/latest.yml", routeClassPublic},
+		{http.MethodGet, "/api/v1/updates/app/squirrel/RELEASES", routeClassPublic},
+		{http.MethodGet, "/api/v1/updates/app/tauri?current_version=1.0.0&target=linux&arch=x86_64", routeClassPublic},
+		{http.MethodGet, "/api/v1/updates/app/android", routeClassPublic},
+		{http.MethodPost, "/api/v1/check/batch", routeClassPublic},
+		{http.MethodPost, "/api/v1/verify", routeClassPublic},
+		{http.MethodGet, "/badge/app/version.svg", routeClassPublic},
+		{http.MethodGet, "/artifacts/app/01JREL0", routeClassPublic},
+		{http.MethodGet, "/status.json", routeClassPublic},
+		{http.MethodGet, "/api/v1/status.json", routeClassPublic},
+		{http.MethodGet, "/api/v1/client-bundle", routeClassPublic},
+		{http.MethodGet, "/api/v1/keys/client-bundle", routeClassPublic},
+		{http.MethodGet, "/api/v1/keys/client-bundle/history", routeClassPublic},
+		{http.MethodGet, "/api/v1/error-codes", routeClassPublic},
+		{http.MethodPost, "/api/v1/updates/app/releases/1.0.0/windows/amd64/artifact", routeClassAuthenticated},
+		{http.MethodGet, "/api/v1/updates/app/releases", routeClassAuthenticated},
+		{http.MethodPost, "/api/v1/updates/app/register", routeClassAuthenticated},
+		{http.MethodGet, "/api/v1/applications/app/snippets", routeClassAuthenticated},
+		{http.MethodPut, "/api/v1/applications/app", routeClassAdmin},
+		{http.MethodDelete, "/api/v1/updates/app/releases/1.0.0/windows/amd64", routeClassAdmin},
+		{http.MethodPatch, "/api/v1/admin/keys/k1", routeClassAdmin},
+		{http.MethodPost, "/api/v1/reconcile", routeClassAdmin},
+		{http.MethodGet, "/health", ""},
+		{http.MethodGet, "/api/v1/version", ""},
+	}
+	for _, tt := range tests {
+		got = "unmatched"
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.target, nil))
+		assert.Equal(t, tt.want, got, "%s %s", tt.method, tt.target)
+	}
+}
+
+func TestLane_Acquire(t *testing.T) {
+	l := newLane(models.ConcurrencyLimit{MaxInFlight: 1, MaxQueue: 1})
+	ctx := context.Background()
+
+	ok, _ := l.acquire(ctx, time.Second)
+	require.True(t, ok)
+
+	t.Run("queue timeout", func(t *testing.T) {
+		ok, reason := l.acquire(ctx, 10*time.Millisecond)
+		assert.False(t, ok)
+		assert.Equal(t, shedQueueTimeout, reason)
+	})
+
+	t.Run("queued request gets released slot", func(t *testing.T) {
+		done := make(chan bool)
+		go func() {
+			ok, _ := l.acquire(ctx, time.Second)
+			done <- ok
+		}()
+		require.Eventually(t, func() bool { return len(l.waiting) == 1 }, time.Second, time.Millisecond)
+
+		ok, reason := l.acquire(ctx, time.Second)
+		assert.False(t, ok, "queue is full")
+		assert.Equal(t, shedQueueFull, reason)
+
+		l.release()
+		assert.True(t, <-done)
+	})
+
+	t.Run("canceled while queued", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		ok, reason := l.acquire(canceled, time.Second)
+		assert.False(t, ok)
+		assert.Equal(t, shedCanceled, reason)
+	})
+}
+
+func TestConcurrencyLimiter_Sheds(t *testing.T) {
+	config := models.NewDefaultConfig()
+	config.Security.EnableAuth = false
+	config.Server.Concurrency = models.ConcurrencyConfig{
+		Enabled:       true,
+		QueueTimeout:  10 * time.Millisecond,
+		Public:        models.ConcurrencyLimit{MaxInFlight: 1},
+		Authenticated: models.ConcurrencyLimit{MaxInFlight: 1},
+		Admin:         models.ConcurrencyLimit{MaxInFlight: 1},
+	}
+
+	// The first check holds the only public slot until released.
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		close(blocked)
+		<-release
+	}).Return(&models.UpdateCheckResponse{CurrentVersion: "1.0.0"}, nil).Once()
+	mockService.On("GetDecisionLog", mock.Anything, "req-1").Return(nil, update.NewNotFoundError("no update checks recorded for request req-1"))
+	router := SetupRoutes(NewHandlers(mockService), config)
+
+	checkURL := "/api/v1/updates/app/check?current_version=1.0.0&platform=windows&architecture=amd64"
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, checkURL, nil))
+		close(done)
+	}()
+	<-blocked
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, checkURL, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), models.ErrorCodeServiceUnavailable)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/decisions/req-1", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code, "admin lane is not affected by public load")
+
+	close(release)
+	<-done
+	mockService.AssertExpectations(t)
+}
+
+func TestConcurrencyLimiter_PriorityLane(t *testing.T) {
+	config := models.NewDefaultConfig()
+	config.Security.EnableAuth = false
+	config.Server.Concurrency = models.ConcurrencyConfig{
+		Enabled:       true,
+		QueueTimeout:  10 * time.Millisecond,
+		Public:        models.ConcurrencyLimit{MaxInFlight: 1},
+		Authenticated: models.ConcurrencyLimit{MaxInFlight: 1},
+		Admin:         models.ConcurrencyLimit{MaxInFlight: 1},
+		Priority:      models.ConcurrencyLimit{MaxInFlight: 1},
+	}
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		close(blocked)
+		<-release
+	}).Return(&models.UpdateCheckResponse{CurrentVersion: "1.0.0"}, nil).Once()
+	mockService.On("CheckForUpdate", mock.Anything, mock.Anything).Return(&models.UpdateCheckResponse{
+		UpdateAvailable: true, LatestVersion: "1.0.1", CurrentVersion: "1.0.0", Security: true,
+	}, nil).Once()
+	mockService.On("CheckForUpdate", mock.Anything, mock.Anything).Return(&models.UpdateCheckResponse{
+		UpdateAvailable: true, LatestVersion: "1.1.0", CurrentVersion: "1.0.0",
+	}, nil).Once()
+	router := SetupRoutes(NewHandlers(mockService), config)
+
+	checkURL := "/api/v1/updates/app/check?current_version=1.0.0&platform=windows&architecture=amd64"
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, checkURL, nil))
+		close(done)
+	}()
+	<-blocked
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, checkURL, nil))
+	assert.Equal(t, http.StatusOK, rr.Code, "security update gets through the priority lane")
+	assert.Contains(t, rr.Body.String(), `"security":true`)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, checkURL, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code, "ordinary update is shed")
+
+	close(release)
+	<-done
+	mockService.AssertExpectations(t)
+}
+
+func TestLane_TryAcquire(t *testing.T) {
+	l := newLane(models.ConcurrencyLimit{MaxInFlight: 2, MaxQueue: 1})
+
+	assert.False(t, l.tryAcquire(2), "every slot is reserved")
+	require.True(t, l.tryAcquire(1))
+	assert.False(t, l.tryAcquire(1), "the last slot is reserved")
+
+	l.waiting <- struct{}{}
+	assert.False(t, l.tryAcquire(0), "queued requests go first")
+	<-l.waiting
+	require.True(t, l.tryAcquire(0))
+	assert.False(t, l.tryAcquire(0))
+}
+
+func TestConcurrencyLimiter_ShedsBackgroundFirst(t *testing.T) {
+	config := models.NewDefaultConfig()
+	config.Security.EnableAuth = false
+	config.Server.Concurrency = models.ConcurrencyConfig{
+		Enabled:              true,
+		QueueTimeout:         10 * time.Millisecond,
+		Public:               models.ConcurrencyLimit{MaxInFlight: 2},
+		Authenticated:        models.ConcurrencyLimit{MaxInFlight: 1},
+		Admin:                models.ConcurrencyLimit{MaxInFlight: 1},
+		Priority:             models.ConcurrencyLimit{MaxInFlight: 1},
+		BackgroundReserve:    0.5,
+		BackgroundRetryAfter: 30 * time.Second,
+	}
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		close(blocked)
+		<-release
+	}).Return(&models.UpdateCheckResponse{CurrentVersion: "1.0.0"}, nil).Once()
+	mockService.On("CheckForUpdate", mock.Anything, mock.Anything).Return(&models.UpdateCheckResponse{CurrentVersion: "1.0.0"}, nil).Once()
+	router := SetupRoutes(NewHandlers(mockService), config)
+
+	checkURL := "/api/v1/updates/app/check?current_version=1.0.0&platform=windows&architecture=amd64"
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, checkURL, nil))
+		close(done)
+	}()
+	<-blocked
+
+	// One slot is free, but it is reserved for interactive checks
+	req := httptest.NewRequest(http.MethodGet, checkURL, nil)
+	req.Header.Set(checkPriorityHeader, "background")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+
+	req = httptest.NewRequest(http.MethodGet, checkURL, nil)
+	req.Header.Set(checkPriorityHeader, "interactive")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code, "interactive checks may use the reserved slot")
+
+	close(release)
+	<-done
+	mockService.AssertExpectations(t)
+}
+
+func TestWriteBusyResponse_RetryAfter(t *testing.T) {
+	for retryAfter, want := range map[time.Duration]string{0: "1", time.Second: "1", 1500 * time.Millisecond: "2", time.Minute: "60"} {
+		rr := httptest.NewRecorder()
+		writeBusyResponse(rr, retryAfter)
+		assert.Equal(t, want, rr.Header().Get("Retry-After"), "retry after %s", retryAfter)
+	}
+}
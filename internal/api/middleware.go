@@ -1,13 +1,15 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
-	"strings"
+	"regexp"
 	"updater/internal/models"
+	"updater/internal/security"
 	"updater/internal/storage"
+	"updater/internal/update"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
@@ -15,33 +17,56 @@ import (
 type Permission string
 
 const (
-	PermissionRead  Permission = "read"
-	PermissionWrite Permission = "write"
-	PermissionAdmin Permission = "admin"
+	PermissionRead       Permission = "read"
+	PermissionWrite      Permission = "write"
+	PermissionSupport    Permission = "support" // Read-only access to admin views
+	PermissionAdmin      Permission = "admin"
+	PermissionBreakGlass Permission = "break_glass" // Publishing to stable during freeze windows that allow it
 )
 
-// contextKey is an unexported type for context keys in this package.
-// Using a named type prevents collisions with context keys from other packages.
-type contextKey struct{}
-
-// apiKeyContextKey is the context key used to store and retrieve the authenticated API key.
-var apiKeyContextKey = contextKey{}
+// supportModeHeader marks responses to support keys, so admin tools can show
+// that the session is read-only.
+const supportModeHeader = "X-Support-Mode"
 
 // GetAPIKey extracts the authenticated API key from request context.
 // Returns nil if no key is present (unauthenticated request).
 func GetAPIKey(r *http.Request) *models.APIKey {
-	if apiKey, ok := r.Context().Value(apiKeyContextKey).(*models.APIKey); ok {
-		return apiKey
-	}
-	return nil
+	return security.APIKeyFromContext(r.Context())
+}
+
+// withPrincipal returns r carrying key as its authenticated caller.
+func withPrincipal(r *http.Request, key *models.APIKey) *http.Request {
+	return r.WithContext(security.NewContext(r.Context(), &security.Principal{APIKey: key}))
+}
+
+// requestIDHeader carries the ID update checks are recorded under in the
+// decision log.
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID matches client-supplied request IDs that are safe to log and
+// echo back.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// requestIDMiddleware gives every request an ID and returns it in the
+// X-Request-ID response header. A client-supplied X-Request-ID is kept when it
+// is a short token, so clients can quote an ID they already log.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.New().String()
+		}
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(update.WithRequestID(r.Context(), id)))
+	})
 }
 
 // RequirePermission creates middleware that enforces a specific permission
 func RequirePermission(required Permission) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			apiKey := GetAPIKey(r)
-			if apiKey == nil || !apiKey.HasPermission(string(required)) {
+			principal, _ := security.FromContext(r.Context())
+			if !principal.HasPermission(string(required)) {
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusForbidden)
 				errorResp := models.NewErrorResponse(
@@ -62,35 +87,15 @@ func RequirePermission(required Permission) mux.MiddlewareFunc {
 func OptionalAuth(store storage.Storage) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Check for API key in Authorization header
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				// No auth provided, continue without security context
-				next.ServeHTTP(w, r)
-				return
-			}
-
 			// Try to authenticate, but don't fail if invalid
-			const prefix = "Bearer "
-			if !strings.HasPrefix(authHeader, prefix) {
-				// Invalid format, continue without auth
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			token := authHeader[len(prefix):]
-
-			hash := models.HashAPIKey(token)
-			validKey, err := store.GetAPIKeyByHash(r.Context(), hash)
-			if err != nil || !validKey.Enabled {
-				// Invalid or disabled key, continue without auth
+			validKey, _ := authenticateRequest(r, store)
+			if validKey == nil {
 				next.ServeHTTP(w, r)
 				return
 			}
 
 			// Add API key info to context for handlers to use
-			ctx := context.WithValue(r.Context(), apiKeyContextKey, validKey)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, withPrincipal(r, validKey))
 		})
 	}
 }
@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"updater/internal/models"
 	"updater/internal/storage"
+	"updater/internal/update"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,7 +89,7 @@ func TestOptionalAuthWithStorage(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var ctxKey *models.APIKey
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				ctxKey, _ = r.Context().Value(apiKeyContextKey).(*models.APIKey)
+				ctxKey = GetAPIKey(r)
 				w.WriteHeader(http.StatusOK)
 			})
 
@@ -107,3 +109,79 @@ func TestOptionalAuthWithStorage(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		keepsOwn bool
+	}{
+		{"generates an ID", "", false},
+		{"keeps a client token", "client-1234.abc_def", true},
+		{"replaces unsafe IDs", "bad id\r\nX-Evil: 1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctxID string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxID = update.RequestIDFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(requestIDHeader, tt.header)
+			}
+			rr := httptest.NewRecorder()
+			requestIDMiddleware(handler).ServeHTTP(rr, req)
+
+			id := rr.Header().Get(requestIDHeader)
+			require.NotEmpty(t, id)
+			assert.Equal(t, id, ctxID)
+			if tt.keepsOwn {
+				assert.Equal(t, tt.header, id)
+			} else {
+				assert.NotEqual(t, tt.header, id)
+			}
+		})
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	h := NewHandlers(&MockUpdateService{})
+
+	t.Run("returns problem with request ID", func(t *testing.T) {
+		handler := requestIDMiddleware(h.recoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var m map[string]string
+			m["boom"] = "x"
+		})))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/check", nil)
+		req.Header.Set(requestIDHeader, "client-42")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+		var problem map[string]interface{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+		assert.Equal(t, "about:blank", problem["type"])
+		assert.Equal(t, "Internal Server Error", problem["title"])
+		assert.Equal(t, float64(500), problem["status"])
+		assert.Equal(t, "/api/v1/check", problem["instance"])
+		assert.Equal(t, models.ErrorCodeInternalError, problem["code"])
+		assert.Equal(t, "client-42", problem["request_id"])
+		assert.NotContains(t, rr.Body.String(), "nil map", "panic value must not leak")
+	})
+
+	t.Run("aborts when response already started", func(t *testing.T) {
+		handler := h.recoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			panic("late failure")
+		}))
+		rr := httptest.NewRecorder()
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
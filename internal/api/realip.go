@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"updater/internal/models"
+)
+
+// clientIPContextKey is the context key of the client IP resolved by
+// clientIPResolver.
+type clientIPContextKey struct{}
+
+// clientIPResolver finds the address of the client behind any trusted proxies.
+// Forwarding headers are only believed when the peer that sent them is a
+// trusted proxy, so a client connecting directly cannot pose as another
+// address in audit logs or slip past anomaly detection.
+type clientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+// newClientIPResolver returns a resolver trusting the given proxies, which
+// SecurityConfig.Validate has already checked. An empty list trusts no peer,
+// so forwarding headers are ignored.
+func newClientIPResolver(proxies []string) *clientIPResolver {
+	c := &clientIPResolver{}
+	for _, proxy := range proxies {
+		if prefix, err := models.ParseTrustedProxy(proxy); err == nil {
+			c.trusted = append(c.trusted, prefix)
+		}
+	}
+	return c
+}
+
+// Middleware stores the resolved client IP in the request context, where
+// getClientIP finds it.
+func (c *clientIPResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPContextKey{}, c.resolve(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isTrusted reports whether addr is a trusted proxy.
+func (c *clientIPResolver) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range c.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the client IP of a request. X-Forwarded-For is walked from
+// the nearest hop outwards, and the first address that is not a trusted proxy
+// is the client; X-Real-IP is used when there is no X-Forwarded-For.
+func (c *clientIPResolver) resolve(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	peer, err := netip.ParseAddr(host)
+	if err != nil {
+		// Unix socket peers have no address, so they cannot be trusted
+		return r.RemoteAddr
+	}
+	if peer = peer.Unmap(); !c.isTrusted(peer) {
+		return peer.String()
+	}
+
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		client := peer
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break // A hop that is not an address was not written by a proxy we trust
+			}
+			client = hop.Unmap()
+			if !c.isTrusted(client) {
+				break
+			}
+		}
+		return client.String()
+	}
+	if xri, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return xri.Unmap().String()
+	}
+	return peer.String()
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIPResolver(t *testing.T) {
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		xff        []string
+		xRealIP    string
+		want       string
+	}{
+		{
+			name:       "no proxies trusts no peer",
+			remoteAddr: "203.0.113.9:4321",
+			xff:        []string{"198.51.100.7, 10.0.0.2"},
+			xRealIP:    "198.51.100.8",
+			want:       "203.0.113.9",
+		},
+		{
+			name:       "every address trusted explicitly",
+			proxies:    []string{"0.0.0.0/0"},
+			remoteAddr: "203.0.113.9:4321",
+			xff:        []string{"198.51.100.7, 10.0.0.2"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "unix socket peer",
+			proxies:    []string{"0.0.0.0/0"},
+			remoteAddr: "@",
+			xff:        []string{"198.51.100.7"},
+			want:       "@",
+		},
+		{
+			name:       "untrusted peer cannot spoof",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "203.0.113.9:4321",
+			xff:        []string{"198.51.100.7"},
+			xRealIP:    "198.51.100.8",
+			want:       "203.0.113.9",
+		},
+		{
+			name:       "trusted proxy chain is skipped",
+			proxies:    []string{"10.0.0.0/8", "192.0.2.10"},
+			remoteAddr: "10.0.0.1:4321",
+			xff:        []string{"1.1.1.1, 198.51.100.7", "192.0.2.10"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "client-supplied hop left of the client is ignored",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:4321",
+			xff:        []string{"not-an-ip, 198.51.100.7"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "garbage hop stops the walk",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:4321",
+			xff:        []string{"not-an-ip"},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "X-Real-IP from a trusted proxy",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:4321",
+			xRealIP:    "198.51.100.7",
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "IPv4-mapped peer matches an IPv4 range",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "[::ffff:10.0.0.1]:4321",
+			xff:        []string{"2001:db8::1"},
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "peer without headers",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:4321",
+			want:       "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/check", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.xff {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tt.xRealIP)
+			}
+
+			var got string
+			newClientIPResolver(tt.proxies).Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = getClientIP(r)
+			})).ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
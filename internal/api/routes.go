@@ -1,14 +1,14 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"strings"
+	"runtime/debug"
 	"updater/internal/models"
 	"updater/internal/observability"
 	"updater/internal/storage"
+	"updater/internal/update"
 
 	"github.com/gorilla/mux"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
@@ -68,24 +68,89 @@ func registerPublicEndpoint(router *mux.Router, path string, handler http.Handle
 func SetupRoutes(handlers *Handlers, config *models.Config, opts ...RouteOption) *mux.Router {
 	router := mux.NewRouter()
 
+	// Credentials outside the Authorization header are taken off the request
+	// before any instrumentation or logging sees it
+	if config.Security.EnableAuth {
+		router.Use(credentialExtractor(config.Security.Credentials.Header, handlers.queryTokens))
+	}
 	for _, opt := range opts {
 		opt(router)
 	}
-
 	api := router.PathPrefix("/api/v1").Subrouter()
 
+	// Dry-run checks explain the decision for any client, so with auth enabled they
+	// require support or admin permission. They are registered ahead of the public
+	// check routes.
+	if config.Security.EnableAuth {
+		dryRunAPI := api.PathPrefix("").Subrouter()
+		dryRunAPI.Use(authMiddleware(handlers.storage))
+		dryRunAPI.Use(RequirePermission(PermissionSupport))
+		dryRunAPI.HandleFunc("/updates/{app_id}/check", handlers.CheckForUpdates).Methods("GET").Queries(dryRunParam, "true")
+		dryRunAPI.HandleFunc("/check", handlers.CheckForUpdates).Methods("POST").Queries(dryRunParam, "true")
+	}
+
+	// Update checks and checksum reports are watched for abuse when anomaly
+	// detection is enabled, and need a client token when client tokens are
+	// enabled. Latest-version lookups may be answered from cache while their
+	// SLO is degraded
+	var checkMiddleware []mux.MiddlewareFunc
+	if config.Security.AnomalyDetection.Enabled {
+		checkMiddleware = append(checkMiddleware, newAnomalyDetector(config.Security.AnomalyDetection, handlers.appMetrics).Middleware)
+	}
+	checkMiddleware = append(checkMiddleware, handlers.requireClientToken)
+	if handlers.sloTracker != nil && config.Server.SLO.Degrade.Enabled {
+		checkMiddleware = append(checkMiddleware, newStaleCache(config.Server.SLO.Degrade, handlers.sloTracker).Middleware)
+	}
+	checkAPI := api.PathPrefix("").Subrouter()
+	checkAPI.Use(checkMiddleware...)
+	checkAPI.HandleFunc("/updates/{app_id}/check", handlers.CheckForUpdates).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/latest", handlers.GetLatestVersion).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/plugins", handlers.ListPluginUpdates).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/image", handlers.CheckContainerImage).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/ota", handlers.CheckOTAUpdate).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/electron/{file}", handlers.ElectronUpdateInfo).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/squirrel/RELEASES", handlers.SquirrelReleases).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/tauri", handlers.TauriManifest).Methods("GET")
+	checkAPI.HandleFunc("/updates/{app_id}/android", handlers.AndroidIndex).Methods("GET")
+	checkAPI.HandleFunc("/check", handlers.CheckForUpdates).Methods("POST")
+	checkAPI.HandleFunc("/check/batch", handlers.BatchCheckForUpdates).Methods("POST")
+	checkAPI.HandleFunc("/latest", handlers.GetLatestVersion).Methods("GET")
+	checkAPI.HandleFunc("/verify", handlers.VerifyChecksum).Methods("POST")
+	registerVanityHosts(router, handlers, config.Server.VanityHosts, checkMiddleware)
+
 	publicAPI := api.PathPrefix("").Subrouter()
-	publicAPI.HandleFunc("/updates/{app_id}/check", handlers.CheckForUpdates).Methods("GET")
-	publicAPI.HandleFunc("/updates/{app_id}/latest", handlers.GetLatestVersion).Methods("GET")
-	publicAPI.HandleFunc("/check", handlers.CheckForUpdates).Methods("POST")
 	publicAPI.HandleFunc("/check", methodNotAllowedHandler).Methods("GET", "PUT", "DELETE", "PATCH")
-	publicAPI.HandleFunc("/latest", handlers.GetLatestVersion).Methods("GET")
+	publicAPI.HandleFunc("/check/batch", methodNotAllowedHandler).Methods("GET", "PUT", "DELETE", "PATCH")
+	publicAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/signature", handlers.ReleaseSignature).Methods("GET")
+	publicAPI.HandleFunc("/keys/pgp", handlers.PGPPublicKey).Methods("GET")
+	if handlers.clientTokens != nil {
+		publicAPI.HandleFunc("/client-tokens/challenge", handlers.ClientTokenChallenge).Methods("GET")
+		publicAPI.HandleFunc("/client-tokens", handlers.IssueClientToken).Methods("POST")
+	}
+	if handlers.clientBundle != nil {
+		publicAPI.HandleFunc("/client-bundle", handlers.ClientBundle).Methods("GET")
+		publicAPI.HandleFunc("/keys/client-bundle", handlers.ClientBundlePublicKey).Methods("GET")
+		publicAPI.HandleFunc("/keys/client-bundle/history", handlers.ClientBundleKeyHistory).Methods("GET")
+	}
 
 	api.HandleFunc("/openapi.yaml", handlers.ServeOpenAPISpec).Methods("GET")
+	api.HandleFunc("/error-codes", handlers.ErrorCodeDictionary).Methods("GET")
 	api.HandleFunc("/docs", handlers.ServeSwaggerUI).Methods("GET")
 
 	registerPublicEndpoint(router, "/health", handlers.HealthCheck)
 	registerPublicEndpoint(router, "/version", handlers.VersionInfo)
+	registerPublicEndpoint(router, "/badge/{app_id}/version.svg", handlers.VersionBadgeSVG)
+	registerPublicEndpoint(router, "/badge/{app_id}/version.json", handlers.VersionBadgeJSON)
+	// Every visitor of a public status page may fetch its summary, so it is
+	// cached and rate limited per client IP on top of the public lane
+	var status *statusPage
+	if config.Observability.StatusPage.Enabled {
+		status = newStatusPage(config.Observability.StatusPage, handlers)
+		registerPublicEndpoint(router, "/status.json", status.ServeHTTP)
+	}
+	if handlers.artifactFiles != nil {
+		router.PathPrefix("/artifacts/").Handler(http.StripPrefix("/artifacts", handlers.artifactFiles)).Methods("GET", "HEAD")
+	}
 
 	api.PathPrefix("").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "OPTIONS" {
@@ -95,9 +160,26 @@ func SetupRoutes(handlers *Handlers, config *models.Config, opts ...RouteOption)
 		w.WriteHeader(http.StatusNotFound)
 	}).Methods("OPTIONS")
 
+	router.Use(requestIDMiddleware)
+	router.Use(newClientIPResolver(config.Security.TrustedProxies).Middleware)
+	if handlers.healthHistory != nil {
+		router.Use(handlers.healthHistory.Middleware)
+	}
+	if handlers.sloTracker != nil {
+		router.Use(handlers.sloTracker.Middleware)
+	}
+	if status != nil {
+		router.Use(status.Middleware)
+	}
 	router.Use(loggingMiddleware)
-	router.Use(recoveryMiddleware)
+	router.Use(handlers.recoveryMiddleware)
+	if config.Server.Concurrency.Enabled {
+		router.Use(newConcurrencyLimiter(config.Server.Concurrency, handlers.appMetrics).Middleware)
+	}
 	router.Use(maxBytesMiddleware)
+	// Slugs are resolved to IDs once the request is admitted, before auth and
+	// the per-route middleware see the app_id
+	router.Use(handlers.resolveApplicationSlug)
 
 	router.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -111,60 +193,170 @@ func SetupRoutes(handlers *Handlers, config *models.Config, opts ...RouteOption)
 		readAPI.Use(authMiddleware(handlers.storage))
 		readAPI.Use(RequirePermission(PermissionRead))
 		readAPI.HandleFunc("/updates/{app_id}/releases", handlers.ListReleases).Methods("GET")
+		readAPI.HandleFunc("/updates/{app_id}/releases/compare", handlers.CompareReleases).Methods("GET")
+		readAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft", handlers.GetReleaseNotesDraft).Methods("GET")
+		readAPI.HandleFunc("/updates/{app_id}/releases/{version}/statuses", handlers.GetReleaseStatus).Methods("GET")
+		readAPI.HandleFunc("/updates/{app_id}/images", handlers.ListContainerImages).Methods("GET")
+		readAPI.HandleFunc("/groups", handlers.ListApplicationGroups).Methods("GET")
+		readAPI.HandleFunc("/templates", handlers.ListApplicationTemplates).Methods("GET")
+		readAPI.HandleFunc("/health/history", handlers.HealthHistory).Methods("GET")
 
 		writeAPI := api.PathPrefix("").Subrouter()
 		writeAPI.Use(authMiddleware(handlers.storage))
 		writeAPI.Use(RequirePermission(PermissionWrite))
 		writeAPI.HandleFunc("/updates/{app_id}/register", handlers.RegisterRelease).Methods("POST")
+		writeAPI.HandleFunc("/updates/{app_id}/manifest", handlers.IngestReleaseManifest).Methods("POST")
+		writeAPI.HandleFunc("/updates/{app_id}/releases/{version}/statuses", handlers.ReportStatusCheck).Methods("POST")
+		writeAPI.HandleFunc("/updates/{app_id}/images", handlers.RegisterContainerImage).Methods("POST")
+		writeAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/artifact", handlers.UploadReleaseArtifact).Methods("POST").Name(artifactUploadRoute)
+		writeAPI.HandleFunc("/updates/{app_id}/releases/{id}/artifact", handlers.UploadReleaseArtifactByID).Methods("POST").Name(artifactUploadByIDRoute)
 
 		appReadAPI := api.PathPrefix("/applications").Subrouter()
 		appReadAPI.Use(authMiddleware(handlers.storage))
 		appReadAPI.Use(RequirePermission(PermissionRead))
 		appReadAPI.HandleFunc("", handlers.ListApplications).Methods("GET")
 		appReadAPI.HandleFunc("/{app_id}", handlers.GetApplication).Methods("GET")
+		appReadAPI.HandleFunc("/{app_id}/snippets", handlers.GetIntegrationSnippets).Methods("GET")
+		appReadAPI.HandleFunc("/{app_id}/usage", handlers.GetApplicationUsage).Methods("GET")
+		appReadAPI.HandleFunc("/{app_id}/channels", handlers.ListChannels).Methods("GET")
 
 		appWriteAPI := api.PathPrefix("/applications").Subrouter()
 		appWriteAPI.Use(authMiddleware(handlers.storage))
 		appWriteAPI.Use(RequirePermission(PermissionWrite))
 		appWriteAPI.HandleFunc("", handlers.CreateApplication).Methods("POST")
+		appWriteAPI.HandleFunc("/{app_id}/clone", handlers.CloneApplication).Methods("POST")
+		appWriteAPI.HandleFunc("/{app_id}/channels/{channel}/releases/{version}", handlers.PublishToChannel).Methods("PUT")
 
 		appAdminAPI := api.PathPrefix("/applications").Subrouter()
 		appAdminAPI.Use(authMiddleware(handlers.storage))
 		appAdminAPI.Use(RequirePermission(PermissionAdmin))
 		appAdminAPI.HandleFunc("/{app_id}", handlers.UpdateApplication).Methods("PUT")
+		appAdminAPI.HandleFunc("/{app_id}/desired-state", handlers.ApplyApplicationDesiredState).Methods("PUT")
 		appAdminAPI.HandleFunc("/{app_id}", handlers.DeleteApplication).Methods("DELETE")
 
 		adminAPI := api.PathPrefix("").Subrouter()
 		adminAPI.Use(authMiddleware(handlers.storage))
 		adminAPI.Use(RequirePermission(PermissionAdmin))
 		adminAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}", handlers.DeleteRelease).Methods("DELETE")
-
-		// API key management (admin permission required)
-		keyAdminAPI := api.PathPrefix("/admin/keys").Subrouter()
-		keyAdminAPI.Use(authMiddleware(handlers.storage))
-		keyAdminAPI.Use(RequirePermission(PermissionAdmin))
-		keyAdminAPI.HandleFunc("", handlers.ListAPIKeys).Methods("GET")
-		keyAdminAPI.HandleFunc("", handlers.CreateAPIKey).Methods("POST")
-		keyAdminAPI.HandleFunc("/{id}", handlers.UpdateAPIKey).Methods("PATCH")
-		keyAdminAPI.HandleFunc("/{id}", handlers.DeleteAPIKey).Methods("DELETE")
+		adminAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/pause", handlers.PauseRelease).Methods("POST")
+		adminAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/resume", handlers.ResumeRelease).Methods("POST")
+		adminAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/yank", handlers.YankRelease).Methods("POST")
+		adminAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/yank", handlers.UnyankRelease).Methods("DELETE")
+		adminAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft/publish", handlers.PublishReleaseNotesDraft).Methods("POST")
+		adminAPI.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft", handlers.DiscardReleaseNotesDraft).Methods("DELETE")
+		adminAPI.HandleFunc("/updates/{app_id}/images/{tag}", handlers.DeleteContainerImage).Methods("DELETE")
 
 		router.Use(OptionalAuth(handlers.storage))
 	} else {
 		api.HandleFunc("/updates/{app_id}/releases", handlers.ListReleases).Methods("GET")
+		api.HandleFunc("/updates/{app_id}/releases/compare", handlers.CompareReleases).Methods("GET")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft", handlers.GetReleaseNotesDraft).Methods("GET")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/statuses", handlers.GetReleaseStatus).Methods("GET")
 		api.HandleFunc("/updates/{app_id}/register", handlers.RegisterRelease).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/manifest", handlers.IngestReleaseManifest).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/statuses", handlers.ReportStatusCheck).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/images", handlers.ListContainerImages).Methods("GET")
+		api.HandleFunc("/updates/{app_id}/images", handlers.RegisterContainerImage).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/artifact", handlers.UploadReleaseArtifact).Methods("POST").Name(artifactUploadRoute)
+		api.HandleFunc("/updates/{app_id}/releases/{id}/artifact", handlers.UploadReleaseArtifactByID).Methods("POST").Name(artifactUploadByIDRoute)
 		api.HandleFunc("/applications", handlers.ListApplications).Methods("GET")
+		api.HandleFunc("/groups", handlers.ListApplicationGroups).Methods("GET")
+		api.HandleFunc("/templates", handlers.ListApplicationTemplates).Methods("GET")
+		api.HandleFunc("/health/history", handlers.HealthHistory).Methods("GET")
 		api.HandleFunc("/applications/{app_id}", handlers.GetApplication).Methods("GET")
+		api.HandleFunc("/applications/{app_id}/snippets", handlers.GetIntegrationSnippets).Methods("GET")
+		api.HandleFunc("/applications/{app_id}/usage", handlers.GetApplicationUsage).Methods("GET")
+		api.HandleFunc("/applications/{app_id}/channels", handlers.ListChannels).Methods("GET")
+		api.HandleFunc("/applications/{app_id}/channels/{channel}/releases/{version}", handlers.PublishToChannel).Methods("PUT")
 		api.HandleFunc("/applications", handlers.CreateApplication).Methods("POST")
+		api.HandleFunc("/applications/{app_id}/clone", handlers.CloneApplication).Methods("POST")
 		api.HandleFunc("/applications/{app_id}", handlers.UpdateApplication).Methods("PUT")
+		api.HandleFunc("/applications/{app_id}/desired-state", handlers.ApplyApplicationDesiredState).Methods("PUT")
 		api.HandleFunc("/applications/{app_id}", handlers.DeleteApplication).Methods("DELETE")
 		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}", handlers.DeleteRelease).Methods("DELETE")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/pause", handlers.PauseRelease).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/resume", handlers.ResumeRelease).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/yank", handlers.YankRelease).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/yank", handlers.UnyankRelease).Methods("DELETE")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft/publish", handlers.PublishReleaseNotesDraft).Methods("POST")
+		api.HandleFunc("/updates/{app_id}/releases/{version}/{platform}/{arch}/notes-draft", handlers.DiscardReleaseNotesDraft).Methods("DELETE")
+		api.HandleFunc("/updates/{app_id}/images/{tag}", handlers.DeleteContainerImage).Methods("DELETE")
+	}
+
+	// With a dedicated admin listener these routes are served only there
+	if !config.Server.AdminListener.Enabled {
+		registerAdminRoutes(api, handlers, config)
+	}
+
+	return router
+}
+
+// SetupAdminRoutes configures the HTTP routes of the dedicated admin listener:
+// the admin and key-management API, plus health and version for probes.
+func SetupAdminRoutes(handlers *Handlers, config *models.Config, opts ...RouteOption) *mux.Router {
+	router := mux.NewRouter()
+
+	// Credentials outside the Authorization header are taken off the request
+	// before any instrumentation or logging sees it
+	if config.Security.EnableAuth {
+		router.Use(credentialExtractor(config.Security.Credentials.Header, handlers.queryTokens))
+	}
+	for _, opt := range opts {
+		opt(router)
+	}
+
+	registerAdminRoutes(router.PathPrefix("/api/v1").Subrouter(), handlers, config)
+	registerPublicEndpoint(router, "/health", handlers.HealthCheck)
+	registerPublicEndpoint(router, "/version", handlers.VersionInfo)
+
+	router.Use(requestIDMiddleware)
+	router.Use(newClientIPResolver(config.Security.TrustedProxies).Middleware)
+	router.Use(loggingMiddleware)
+	router.Use(handlers.recoveryMiddleware)
+	router.Use(maxBytesMiddleware)
+	router.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
+	if config.Security.EnableAuth {
+		router.Use(OptionalAuth(handlers.storage))
+	}
+
+	return router
+}
+
+// registerAdminRoutes registers the admin and key-management API and the
+// operator's reconcile endpoint on api.
+func registerAdminRoutes(api *mux.Router, handlers *Handlers, config *models.Config) {
+	if !config.Security.EnableAuth {
+		api.HandleFunc("/reconcile", handlers.Reconcile).Methods("POST")
 		api.HandleFunc("/admin/keys", handlers.ListAPIKeys).Methods("GET")
 		api.HandleFunc("/admin/keys", handlers.CreateAPIKey).Methods("POST")
 		api.HandleFunc("/admin/keys/{id}", handlers.UpdateAPIKey).Methods("PATCH")
 		api.HandleFunc("/admin/keys/{id}", handlers.DeleteAPIKey).Methods("DELETE")
+		api.HandleFunc("/admin/decisions/{request_id}", handlers.GetDecisionLog).Methods("GET")
+		return
 	}
 
-	return router
+	reconcileAPI := api.PathPrefix("/reconcile").Subrouter()
+	reconcileAPI.Use(authMiddleware(handlers.storage))
+	reconcileAPI.Use(RequirePermission(PermissionAdmin))
+	reconcileAPI.HandleFunc("", handlers.Reconcile).Methods("POST")
+
+	// Admin views that support keys can see without being able to change anything
+	supportAPI := api.PathPrefix("/admin").Subrouter()
+	supportAPI.Use(authMiddleware(handlers.storage))
+	supportAPI.Use(RequirePermission(PermissionSupport))
+	supportAPI.HandleFunc("/decisions/{request_id}", handlers.GetDecisionLog).Methods("GET")
+	supportAPI.HandleFunc("/keys", handlers.ListAPIKeys).Methods("GET")
+
+	// API key management (admin permission required)
+	keyAdminAPI := api.PathPrefix("/admin/keys").Subrouter()
+	keyAdminAPI.Use(authMiddleware(handlers.storage))
+	keyAdminAPI.Use(RequirePermission(PermissionAdmin))
+	keyAdminAPI.HandleFunc("", handlers.CreateAPIKey).Methods("POST")
+	keyAdminAPI.HandleFunc("/{id}", handlers.UpdateAPIKey).Methods("PATCH")
+	keyAdminAPI.HandleFunc("/{id}", handlers.DeleteAPIKey).Methods("DELETE")
+	if handlers.queryTokens != nil {
+		keyAdminAPI.HandleFunc("/{id}/query-token", handlers.IssueQueryToken).Methods("POST")
+	}
 }
 
 // methodNotAllowedHandler handles requests with invalid HTTP methods
@@ -182,6 +374,11 @@ const maxRequestBodySize = 1 << 20
 // denial-of-service via unbounded reads (see #48).
 func maxBytesMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Artifact uploads apply the artifact size limit themselves
+		if route := mux.CurrentRoute(r); route != nil && (route.GetName() == artifactUploadRoute || route.GetName() == artifactUploadByIDRoute) {
+			next.ServeHTTP(w, r)
+			return
+		}
 		if r.Body != nil {
 			r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
 		}
@@ -195,27 +392,73 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		slog.Info("HTTP request",
 			"method", r.Method,
 			"path", r.URL.Path,
-			"remote_addr", r.RemoteAddr)
+			"remote_addr", r.RemoteAddr,
+			"client_ip", getClientIP(r),
+			"request_id", update.RequestIDFromContext(r.Context()))
 		next.ServeHTTP(w, r)
 	})
 }
 
-// recoveryMiddleware handles panics
-func recoveryMiddleware(next http.Handler) http.Handler {
+// recoveryMiddleware turns a handler panic into a problem+json 500 carrying
+// the request ID, logs the stack and counts the panic. If the handler already
+// started its response, the connection is aborted instead because a second
+// status cannot be sent.
+func (h *Handlers) recoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &recoveryWriter{ResponseWriter: w}
 		defer func() {
-			if err := recover(); err != nil {
-				slog.Error("Panic recovered", "error", err, "path", r.URL.Path)
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-				errorResp := models.NewErrorResponse("Internal server error", models.ErrorCodeInternalError)
-				json.NewEncoder(w).Encode(errorResp)
+			err := recover()
+			if err == nil {
+				return
+			}
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+
+			requestID := update.RequestIDFromContext(r.Context())
+			slog.Error("Panic recovered",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"request_id", requestID,
+				"stack", string(debug.Stack()))
+			h.recordPanic(r)
+
+			if rw.wroteHeader {
+				panic(http.ErrAbortHandler)
 			}
+			problem := models.NewProblemResponse(http.StatusInternalServerError, "Internal server error", models.ErrorCodeInternalError, r.URL.Path)
+			problem.RequestID = requestID
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(problem)
 		}()
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(rw, r)
 	})
 }
 
+// recoveryWriter records whether the response has started, so a recovered
+// panic knows whether it can still send an error.
+type recoveryWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (rw *recoveryWriter) WriteHeader(code int) {
+	rw.wroteHeader = true
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recoveryWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (rw *recoveryWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // authMiddleware handles API key authentication using storage-backed key lookup.
 func authMiddleware(store storage.Storage) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
@@ -224,34 +467,29 @@ func authMiddleware(store storage.Storage) mux.MiddlewareFunc {
 				next.ServeHTTP(w, r)
 				return
 			}
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				errorResp := models.NewErrorResponse("Authorization required", models.ErrorCodeUnauthorized)
-				json.NewEncoder(w).Encode(errorResp)
-				return
-			}
-			const prefix = "Bearer "
-			if !strings.HasPrefix(authHeader, prefix) {
+			validKey, reason := authenticateRequest(r, store)
+			if validKey == nil {
+				if reason == "" {
+					reason = "Authorization required"
+				} else {
+					logRejectedCredential(r)
+				}
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusUnauthorized)
-				errorResp := models.NewErrorResponse("Invalid authorization format", models.ErrorCodeUnauthorized)
+				errorResp := models.NewErrorResponse(reason, models.ErrorCodeUnauthorized)
 				json.NewEncoder(w).Encode(errorResp)
 				return
 			}
-			token := authHeader[len(prefix):]
-			hash := models.HashAPIKey(token)
-			validKey, err := store.GetAPIKeyByHash(r.Context(), hash)
-			if err != nil || !validKey.Enabled {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				errorResp := models.NewErrorResponse("Invalid API key", models.ErrorCodeUnauthorized)
-				json.NewEncoder(w).Encode(errorResp)
-				return
+			if validKey.IsSupportOnly() {
+				w.Header().Set(supportModeHeader, "read-only")
+				slog.Info("Support session request",
+					"event", "security_audit",
+					"api_key", validKey.Name,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"client_ip", getClientIP(r))
 			}
-			ctx := context.WithValue(r.Context(), apiKeyContextKey, validKey)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, withPrincipal(r, validKey))
 		})
 	}
 }
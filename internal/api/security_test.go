@@ -185,11 +185,10 @@ func TestAuthMiddleware(t *testing.T) {
 			// Create test handler
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				// Check if API key is in context
-				apiKey := r.Context().Value(apiKeyContextKey)
+				apiKey := GetAPIKey(r)
 				if tt.expectAPIKeyInCtx {
-					assert.NotNil(t, apiKey, "Expected API key in context")
-					if actualKey, ok := apiKey.(*models.APIKey); ok {
-						assert.Equal(t, "Test Key", actualKey.Name)
+					if assert.NotNil(t, apiKey, "Expected API key in context") {
+						assert.Equal(t, "Test Key", apiKey.Name)
 					}
 				} else {
 					assert.Nil(t, apiKey, "Expected no API key in context")
@@ -304,8 +303,7 @@ func TestRequirePermissionMiddleware(t *testing.T) {
 			testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				if tt.apiKey != nil {
 					// Add API key to context (simulating auth middleware)
-					ctx := context.WithValue(r.Context(), apiKeyContextKey, tt.apiKey)
-					r = r.WithContext(ctx)
+					r = withPrincipal(r, tt.apiKey)
 				}
 				middleware(handler).ServeHTTP(w, r)
 			})
@@ -449,6 +447,46 @@ func TestEndpointSecurity(t *testing.T) {
 			expectedStatus: http.StatusCreated, // Mock returns successful response
 			description:    "Release registration should accept admin permission",
 		},
+		{
+			name:           "protected manifest ingest without auth",
+			method:         "POST",
+			path:           "/api/v1/updates/test-app/manifest",
+			authHeader:     "",
+			expectedStatus: http.StatusUnauthorized,
+			description:    "Manifest ingest should require authentication",
+		},
+		{
+			name:           "protected manifest ingest with insufficient permission",
+			method:         "POST",
+			path:           "/api/v1/updates/test-app/manifest",
+			authHeader:     "Bearer read-key-123",
+			expectedStatus: http.StatusForbidden,
+			description:    "Manifest ingest should require write permission",
+		},
+		{
+			name:           "protected image list without auth",
+			method:         "GET",
+			path:           "/api/v1/updates/test-app/images",
+			authHeader:     "",
+			expectedStatus: http.StatusUnauthorized,
+			description:    "Container image list should require authentication",
+		},
+		{
+			name:           "protected image registration with insufficient permission",
+			method:         "POST",
+			path:           "/api/v1/updates/test-app/images",
+			authHeader:     "Bearer read-key-123",
+			expectedStatus: http.StatusForbidden,
+			description:    "Container image registration should require write permission",
+		},
+		{
+			name:           "protected image deletion with insufficient permission",
+			method:         "DELETE",
+			path:           "/api/v1/updates/test-app/images/1.0.0",
+			authHeader:     "Bearer write-key-456",
+			expectedStatus: http.StatusForbidden,
+			description:    "Container image deletion should require admin permission",
+		},
 		{
 			name:           "health check public access",
 			method:         "GET",
@@ -761,6 +799,155 @@ func TestRequestBodySizeLimit(t *testing.T) {
 	})
 }
 
+// TestDryRunRequiresAdmin tests that dry-run update checks need a support or
+// admin key while ordinary checks stay public
+func TestDryRunRequiresAdmin(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.CreateAPIKey(context.Background(), models.NewAPIKey(models.NewKeyID(), "Admin", "dry-run-admin", []string{"admin"})))
+	require.NoError(t, store.CreateAPIKey(context.Background(), models.NewAPIKey(models.NewKeyID(), "Reader", "dry-run-reader", []string{"read"})))
+	require.NoError(t, store.CreateAPIKey(context.Background(), models.NewAPIKey(models.NewKeyID(), "Support", "dry-run-support", []string{"support"})))
+
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.Anything).
+		Return(&models.UpdateCheckResponse{CurrentVersion: "1.0.0"}, nil).Maybe()
+	mockService.On("DryRunCheckForUpdate", mock.Anything, mock.Anything).
+		Return(&models.DryRunCheckResponse{Decision: models.CheckDecisionNoUpdate, Trace: []models.DecisionStep{}}, nil).Maybe()
+
+	config := &models.Config{Security: models.SecurityConfig{EnableAuth: true, BootstrapKey: "upd_test-bootstrap"}}
+	router := SetupRoutes(NewHandlers(mockService, WithStorage(store)), config)
+
+	body := `{"application_id":"test-app","current_version":"1.0.0","platform":"windows","architecture":"amd64"}`
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		key      string
+		wantCode int
+		wantRun  bool
+	}{
+		{name: "public check", method: "GET", path: "/api/v1/updates/test-app/check?current_version=1.0.0&platform=windows&architecture=amd64", wantCode: http.StatusOK},
+		{name: "dry run without key", method: "GET", path: "/api/v1/updates/test-app/check?current_version=1.0.0&platform=windows&architecture=amd64&dry_run=true", wantCode: http.StatusUnauthorized},
+		{name: "dry run with read key", method: "GET", path: "/api/v1/updates/test-app/check?current_version=1.0.0&platform=windows&architecture=amd64&dry_run=true", key: "dry-run-reader", wantCode: http.StatusForbidden},
+		{name: "dry run with admin key", method: "GET", path: "/api/v1/updates/test-app/check?current_version=1.0.0&platform=windows&architecture=amd64&dry_run=true", key: "dry-run-admin", wantCode: http.StatusOK, wantRun: true},
+		{name: "dry run with support key", method: "GET", path: "/api/v1/updates/test-app/check?current_version=1.0.0&platform=windows&architecture=amd64&dry_run=true", key: "dry-run-support", wantCode: http.StatusOK, wantRun: true},
+		{name: "POST dry run without key", method: "POST", path: "/api/v1/check?dry_run=true", wantCode: http.StatusUnauthorized},
+		{name: "POST dry run with admin key", method: "POST", path: "/api/v1/check?dry_run=true", key: "dry-run-admin", wantCode: http.StatusOK, wantRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.key)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+			assert.Equal(t, tt.wantRun, strings.Contains(rr.Body.String(), `"decision"`))
+		})
+	}
+}
+
+// TestSupportKeyIsReadOnly tests that a support key sees the admin views but
+// cannot change anything, and that its responses are marked read-only
+func TestSupportKeyIsReadOnly(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.CreateAPIKey(context.Background(), models.NewAPIKey(models.NewKeyID(), "Support", "support-key", []string{"support"})))
+	require.NoError(t, store.CreateAPIKey(context.Background(), models.NewAPIKey(models.NewKeyID(), "Admin", "admin-key", []string{"admin"})))
+
+	mockService := &MockUpdateService{}
+	mockService.On("ListReleases", mock.Anything, mock.Anything).Return(&models.ListReleasesResponse{}, nil).Maybe()
+	mockService.On("GetDecisionLog", mock.Anything, "unknown").Return(nil, update.NewNotFoundError("no update checks recorded for request unknown")).Maybe()
+
+	config := &models.Config{Security: models.SecurityConfig{EnableAuth: true, BootstrapKey: "upd_test-bootstrap"}}
+	router := SetupRoutes(NewHandlers(mockService, WithStorage(store)), config)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		key      string
+		wantCode int
+	}{
+		{name: "list keys", method: "GET", path: "/api/v1/admin/keys", key: "support-key", wantCode: http.StatusOK},
+		{name: "decision trace", method: "GET", path: "/api/v1/admin/decisions/unknown", key: "support-key", wantCode: http.StatusNotFound},
+		{name: "list releases", method: "GET", path: "/api/v1/updates/test-app/releases", key: "support-key", wantCode: http.StatusOK},
+		{name: "create key", method: "POST", path: "/api/v1/admin/keys", key: "support-key", wantCode: http.StatusForbidden},
+		{name: "register release", method: "POST", path: "/api/v1/updates/test-app/register", key: "support-key", wantCode: http.StatusForbidden},
+		{name: "delete release", method: "DELETE", path: "/api/v1/updates/test-app/releases/1.0.0/windows/amd64", key: "support-key", wantCode: http.StatusForbidden},
+		{name: "update application", method: "PUT", path: "/api/v1/applications/test-app", key: "support-key", wantCode: http.StatusForbidden},
+		{name: "admin still creates keys", method: "POST", path: "/api/v1/admin/keys", key: "admin-key", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+tt.key)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+			if tt.key == "support-key" {
+				assert.Equal(t, "read-only", rr.Header().Get(supportModeHeader))
+			} else {
+				assert.Empty(t, rr.Header().Get(supportModeHeader))
+			}
+		})
+	}
+}
+
+func TestAdminListenerSeparatesAdminRoutes(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.CreateAPIKey(context.Background(), models.NewAPIKey(models.NewKeyID(), "Admin", "admin-key", []string{"admin"})))
+
+	mockService := &MockUpdateService{}
+	mockService.On("ListReleases", mock.Anything, mock.Anything).Return(&models.ListReleasesResponse{}, nil).Maybe()
+
+	config := &models.Config{
+		Server:   models.ServerConfig{AdminListener: models.AdminListenerConfig{Enabled: true, Port: 8081}},
+		Security: models.SecurityConfig{EnableAuth: true, BootstrapKey: "upd_test-bootstrap"},
+	}
+	handlers := NewHandlers(mockService, WithStorage(store))
+	public := SetupRoutes(handlers, config)
+	admin := SetupAdminRoutes(handlers, config)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		publicCode int
+		adminCode  int
+	}{
+		{name: "list keys", method: "GET", path: "/api/v1/admin/keys", publicCode: http.StatusNotFound, adminCode: http.StatusOK},
+		{name: "create key", method: "POST", path: "/api/v1/admin/keys", publicCode: http.StatusNotFound, adminCode: http.StatusBadRequest},
+		{name: "reconcile", method: "POST", path: "/api/v1/reconcile", publicCode: http.StatusNotFound, adminCode: http.StatusBadRequest},
+		{name: "list releases", method: "GET", path: "/api/v1/updates/test-app/releases", publicCode: http.StatusOK, adminCode: http.StatusNotFound},
+		{name: "health", method: "GET", path: "/health", publicCode: http.StatusOK, adminCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, listener := range []struct {
+				router   http.Handler
+				wantCode int
+			}{{public, tt.publicCode}, {admin, tt.adminCode}} {
+				req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{}`))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Authorization", "Bearer admin-key")
+				rr := httptest.NewRecorder()
+				listener.router.ServeHTTP(rr, req)
+				assert.Equal(t, listener.wantCode, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
 // TestInternalErrorSanitization tests that internal error details are not leaked (#49)
 func TestInternalErrorSanitization(t *testing.T) {
 	mockService := &MockUpdateService{}
@@ -884,7 +1071,7 @@ func TestOptionalAuthMiddleware(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create test handler
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				apiKey := r.Context().Value(apiKeyContextKey)
+				apiKey := GetAPIKey(r)
 				if tt.expectAPIKeyInCtx {
 					assert.NotNil(t, apiKey, "Expected API key in context")
 				} else {
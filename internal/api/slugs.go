@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// resolveApplicationSlug lets every {app_id} route take an application's slug
+// in place of its ID. A current slug is served as if the ID had been used; a
+// former slug is answered with a permanent redirect to the same URL under the
+// current slug, so clients configured before a rename keep working and can
+// update their configuration. References that resolve to nothing are passed
+// on unchanged for the handler to report.
+func (h *Handlers) resolveApplicationSlug(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		ref, ok := vars["app_id"]
+		if !ok || h.storage == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if _, err := h.storage.GetApplication(r.Context(), ref); err == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		app, err := h.storage.GetApplicationBySlug(r.Context(), ref)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if app.Slug != ref {
+			location := *r.URL
+			location.Path = replaceAppIDSegment(r, app.Slug)
+			location.RawPath = ""
+			http.Redirect(w, r, location.RequestURI(), http.StatusPermanentRedirect)
+			return
+		}
+		vars["app_id"] = app.ID
+		next.ServeHTTP(w, mux.SetURLVars(r, vars))
+	})
+}
+
+// replaceAppIDSegment returns r's path with the segment matched by {app_id}
+// in its route template replaced by slug. The segment is found by position,
+// since a slug may equal another segment of the path, such as "updates".
+func replaceAppIDSegment(r *http.Request, slug string) string {
+	segments := strings.Split(r.URL.Path, "/")
+	template, err := mux.CurrentRoute(r).GetPathTemplate()
+	if err != nil {
+		return r.URL.Path
+	}
+	for i, segment := range strings.Split(template, "/") {
+		if (segment == "{app_id}" || strings.HasPrefix(segment, "{app_id:")) && i < len(segments) {
+			segments[i] = slug
+			break
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveApplicationSlug(t *testing.T) {
+	h := newTestHandlers(t)
+	router := SetupRoutes(h, &models.Config{})
+	createTestApplication(t, h, "acme-notes", "Acme Notes")
+	createTestRelease(t, h, "acme-notes", "1.0.0", "linux", "amd64")
+
+	rename := func(slug string) {
+		t.Helper()
+		body, _ := json.Marshal(models.UpdateApplicationRequest{Slug: &slug})
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/applications/acme-notes", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+	rename("notes")
+	rename("jotter")
+
+	check := "/check?current_version=0.9.0&platform=linux&architecture=amd64"
+	tests := []struct {
+		name         string
+		target       string
+		wantCode     int
+		wantLocation string
+	}{
+		{"immutable ID", "/api/v1/updates/acme-notes" + check, http.StatusOK, ""},
+		{"current slug", "/api/v1/updates/jotter" + check, http.StatusOK, ""},
+		{"former slug", "/api/v1/updates/notes" + check, http.StatusPermanentRedirect, "/api/v1/updates/jotter" + check},
+		{"former slug on badge", "/badge/notes/version.json", http.StatusPermanentRedirect, "/badge/jotter/version.json"},
+		{"unknown", "/api/v1/updates/unknown" + check, http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			assert.Equal(t, tt.wantCode, rr.Code)
+			assert.Equal(t, tt.wantLocation, rr.Header().Get("Location"))
+		})
+	}
+
+	// Responses name the application by its immutable ID
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/applications/jotter", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var info models.ApplicationInfoResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&info))
+	assert.Equal(t, "acme-notes", info.ID)
+	assert.Equal(t, "jotter", info.Slug)
+	assert.Equal(t, []string{"notes"}, info.FormerSlugs)
+}
+
+func TestResolveApplicationSlug_FormerSlugNamedLikeRoute(t *testing.T) {
+	h := newTestHandlers(t)
+	router := SetupRoutes(h, &models.Config{})
+	createTestApplication(t, h, "acme-notes", "Acme Notes")
+
+	for _, slug := range []string{"updates", "jotter"} {
+		body, _ := json.Marshal(models.UpdateApplicationRequest{Slug: &slug})
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/applications/acme-notes", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	// The {app_id} segment is rewritten, not the first segment equal to the slug
+	check := "/check?current_version=0.9.0&platform=linux&architecture=amd64"
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/updates/updates"+check, nil))
+	assert.Equal(t, http.StatusPermanentRedirect, rr.Code)
+	assert.Equal(t, "/api/v1/updates/jotter"+check, rr.Header().Get("Location"))
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"updater/internal/models"
+	"updater/internal/update"
+)
+
+// statusPageBuckets is the number of buckets the check window is split into;
+// the window slides one bucket at a time.
+const statusPageBuckets = 10
+
+// statusPage serves the public status summary. It counts the update checks
+// the replica answers, rebuilds the summary at most once per cache TTL and
+// limits each client IP to a number of requests per fixed window, so
+// embedding the summary in a busy status page costs the backend little.
+type statusPage struct {
+	cfg       models.StatusPageConfig
+	handlers  *Handlers
+	bucket    time.Duration
+	startedAt time.Time
+
+	checksMu sync.Mutex
+	buckets  [statusPageBuckets]checkBucket
+
+	cacheMu sync.Mutex
+	cached  *models.StatusPageResponse
+	body    []byte
+
+	limitMu    sync.Mutex
+	clients    map[string]*statusPageClient
+	lastPruned time.Time
+}
+
+// checkBucket counts the update checks of one slice of the check window.
+type checkBucket struct {
+	index            int64 // Start of the slice, in bucket widths since the Unix epoch
+	total, succeeded int64
+}
+
+// statusPageClient counts one client IP's requests in its current window.
+type statusPageClient struct {
+	windowStart time.Time
+	requests    int
+}
+
+func newStatusPage(cfg models.StatusPageConfig, handlers *Handlers) *statusPage {
+	return &statusPage{
+		cfg:       cfg,
+		handlers:  handlers,
+		bucket:    cfg.CheckWindow / statusPageBuckets,
+		startedAt: handlers.now().UTC(),
+		clients:   make(map[string]*statusPageClient),
+	}
+}
+
+// Middleware counts update checks and whether they were answered without a
+// server error. It runs ahead of the concurrency limiter, so checks shed
+// while the service is overloaded count as failed. Dry runs are not counted.
+func (p *statusPage) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !priorityRoutes[routePath(r)] || r.URL.Query().Get(dryRunParam) == "true" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		sw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		p.recordCheck(sw.status < http.StatusInternalServerError)
+	})
+}
+
+// recordCheck counts one check in the current bucket.
+func (p *statusPage) recordCheck(succeeded bool) {
+	index := p.handlers.now().UnixNano() / int64(p.bucket)
+	p.checksMu.Lock()
+	defer p.checksMu.Unlock()
+	b := &p.buckets[index%statusPageBuckets]
+	if b.index != index {
+		*b = checkBucket{index: index}
+	}
+	b.total++
+	if succeeded {
+		b.succeeded++
+	}
+}
+
+// checks sums the buckets still inside the check window.
+func (p *statusPage) checks(now time.Time) models.StatusPageChecks {
+	current := now.UnixNano() / int64(p.bucket)
+	summary := models.StatusPageChecks{Window: p.cfg.CheckWindow.String(), SuccessRate: 1}
+	p.checksMu.Lock()
+	for _, b := range p.buckets {
+		if current-b.index < statusPageBuckets {
+			summary.Total += b.total
+			summary.Succeeded += b.succeeded
+		}
+	}
+	p.checksMu.Unlock()
+	if summary.Total > 0 {
+		summary.SuccessRate = float64(summary.Succeeded) / float64(summary.Total)
+	}
+	return summary
+}
+
+// ServeHTTP returns the status summary, answering clients over the rate limit
+// with 429 and a Retry-After header.
+// GET /status.json
+func (p *statusPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if retryAfter, limited := p.limit(getClientIP(r)); limited {
+		writeRateLimitedResponse(w, retryAfter)
+		return
+	}
+
+	body, err := p.summary(r)
+	if err != nil {
+		p.handlers.writeServiceErrorResponse(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(p.cfg.CacheTTL.Seconds())))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// limit counts a request from ip and reports whether it is over the rate
+// limit, with the time left until the client's window ends.
+func (p *statusPage) limit(ip string) (time.Duration, bool) {
+	now := p.handlers.now()
+	window := p.cfg.RateLimitWindow
+	p.limitMu.Lock()
+	defer p.limitMu.Unlock()
+
+	if now.Sub(p.lastPruned) >= window {
+		for key, client := range p.clients {
+			if now.Sub(client.windowStart) >= window {
+				delete(p.clients, key)
+			}
+		}
+		p.lastPruned = now
+	}
+
+	client, ok := p.clients[ip]
+	if !ok {
+		client = &statusPageClient{}
+		p.clients[ip] = client
+	}
+	if now.Sub(client.windowStart) >= window {
+		client.windowStart = now
+		client.requests = 0
+	}
+	client.requests++
+	if client.requests > p.cfg.RateLimit {
+		return client.windowStart.Add(window).Sub(now), true
+	}
+	return 0, false
+}
+
+// summary returns the encoded summary, rebuilding it when the cached one is
+// older than the cache TTL. Concurrent requests wait for a single rebuild.
+func (p *statusPage) summary(r *http.Request) ([]byte, error) {
+	now := p.handlers.now().UTC()
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	if p.cached != nil && now.Sub(p.cached.GeneratedAt) < p.cfg.CacheTTL {
+		return p.body, nil
+	}
+
+	resp := &models.StatusPageResponse{
+		GeneratedAt:   now,
+		StartedAt:     p.startedAt,
+		UptimeSeconds: int64(now.Sub(p.startedAt).Seconds()),
+		Checks:        p.checks(now),
+		Applications:  []models.StatusPageApplication{},
+	}
+	if p.handlers.healthHistory != nil {
+		if history := p.handlers.healthHistory.History(now.Add(-p.cfg.CheckWindow)); len(history.Samples) > 0 {
+			resp.Availability = &history.Availability
+		}
+	}
+	for _, appID := range p.cfg.Applications {
+		// The version clients without a channel are offered, so paused,
+		// yanked and held releases do not show before they roll out
+		version, err := p.handlers.updateService.GetLatestStableVersion(r.Context(), appID)
+		var serviceErr *update.ServiceError
+		if errors.As(err, &serviceErr) && serviceErr.StatusCode == http.StatusNotFound {
+			slog.WarnContext(r.Context(), "Status page application not found", "app_id", appID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		resp.Applications = append(resp.Applications, models.StatusPageApplication{ID: appID, LatestVersion: version})
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	p.cached, p.body = resp, body
+	return body, nil
+}
+
+// statusRecorder captures the status code a handler writes first.
+type statusRecorder struct {
+	http.ResponseWriter
+	status  int
+	written bool
+}
+
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	sr.written = true
+	return sr.ResponseWriter.Write(b)
+}
+
+func (sr *statusRecorder) WriteHeader(code int) {
+	if !sr.written {
+		sr.status = code
+		sr.written = true
+	}
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController, so long
+// polls can extend their write deadline.
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+// writeRateLimitedResponse answers a client over a rate limit, asking it to
+// retry after retryAfter, rounded up to whole seconds.
+func writeRateLimitedResponse(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(max(int(math.Ceil(retryAfter.Seconds())), 1)))
+	w.WriteHeader(http.StatusTooManyRequests)
+	errorResp := models.NewErrorResponse("Too many requests, retry later", models.ErrorCodeServiceUnavailable)
+	json.NewEncoder(w).Encode(errorResp)
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatusPageTestRouter returns a router serving the status page and a
+// pointer to the clock its handlers read.
+func newStatusPageTestRouter(t *testing.T, cfg models.StatusPageConfig) (*Handlers, http.Handler, *time.Time) {
+	t.Helper()
+	h := newTestHandlers(t)
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	cfg.Enabled = true
+	router := SetupRoutes(h, &models.Config{Observability: models.ObservabilityConfig{StatusPage: cfg}})
+	return h, router, &now
+}
+
+func getStatusPage(t *testing.T, router http.Handler, ip, path string) models.StatusPageResponse {
+	t.Helper()
+	rec := checkFrom(router, ip, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp models.StatusPageResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestStatusPage_Summary(t *testing.T) {
+	h, router, now := newStatusPageTestRouter(t, models.StatusPageConfig{
+		Applications: []string{"status-app", "missing-app"},
+		CheckWindow:  time.Hour, CacheTTL: 30 * time.Second, RateLimit: 100, RateLimitWindow: time.Minute,
+	})
+	createTestApplication(t, h, "status-app", "Status App")
+	createTestRelease(t, h, "status-app", "1.2.0", "linux", "amd64")
+
+	const query = "/check?current_version=1.0.0&platform=linux&architecture=amd64"
+	rec := checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/updates/status-app"+query, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/updates/status-app"+query+"&dry_run=true", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	*now = now.Add(90 * time.Second)
+	resp := getStatusPage(t, router, "198.51.100.7", "/status.json")
+	assert.Equal(t, int64(90), resp.UptimeSeconds)
+	assert.Nil(t, resp.Availability, "health history is not enabled")
+	assert.Equal(t, models.StatusPageChecks{Window: "1h0m0s", Total: 1, Succeeded: 1, SuccessRate: 1}, resp.Checks,
+		"dry runs are not counted")
+	assert.Equal(t, []models.StatusPageApplication{{ID: "status-app", LatestVersion: "1.2.0"}}, resp.Applications,
+		"unknown applications are left out")
+
+	// The summary is served from cache until it is older than the TTL
+	createTestRelease(t, h, "status-app", "1.3.0", "linux", "amd64")
+	resp = getStatusPage(t, router, "198.51.100.7", "/api/v1/status.json")
+	assert.Equal(t, "1.2.0", resp.Applications[0].LatestVersion)
+
+	*now = now.Add(30 * time.Second)
+	resp = getStatusPage(t, router, "198.51.100.7", "/status.json")
+	assert.Equal(t, "1.3.0", resp.Applications[0].LatestVersion)
+
+	rec = checkFrom(router, "198.51.100.7", http.MethodGet, "/status.json", "")
+	assert.Equal(t, "public, max-age=30", rec.Header().Get("Cache-Control"))
+}
+
+func TestStatusPage_OfferedReleasesOnly(t *testing.T) {
+	ctx := context.Background()
+	h, router, _ := newStatusPageTestRouter(t, models.StatusPageConfig{
+		Applications: []string{"status-app"},
+		CheckWindow:  time.Hour, CacheTTL: 30 * time.Second, RateLimit: 100, RateLimitWindow: time.Minute,
+	})
+	createTestApplication(t, h, "status-app", "Status App")
+	for _, version := range []string{"1.2.0", "1.3.0", "1.4.0", "1.5.0"} {
+		createTestRelease(t, h, "status-app", version, "linux", "amd64")
+	}
+	_, err := h.updateService.PauseRelease(ctx, "status-app", "1.5.0", "linux", "amd64")
+	require.NoError(t, err)
+	_, err = h.updateService.YankRelease(ctx, "status-app", "1.4.0", "linux", "amd64", &models.YankReleaseRequest{})
+	require.NoError(t, err)
+	_, err = h.updateService.PublishToChannel(ctx, "status-app", "lts", "1.3.0")
+	require.NoError(t, err)
+
+	resp := getStatusPage(t, router, "198.51.100.7", "/status.json")
+	assert.Equal(t, []models.StatusPageApplication{{ID: "status-app", LatestVersion: "1.2.0"}}, resp.Applications,
+		"paused, yanked and lts releases are not offered to clients without a channel")
+}
+
+func TestStatusPage_CheckWindow(t *testing.T) {
+	h := newTestHandlers(t)
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	p := newStatusPage(models.StatusPageConfig{CheckWindow: 10 * time.Minute}, h)
+
+	assert.Equal(t, 1.0, p.checks(now).SuccessRate, "no checks count as all succeeded")
+
+	p.recordCheck(true)
+	p.recordCheck(false)
+	now = now.Add(5 * time.Minute)
+	p.recordCheck(true)
+	p.recordCheck(true)
+	checks := p.checks(now)
+	assert.Equal(t, int64(4), checks.Total)
+	assert.Equal(t, int64(3), checks.Succeeded)
+	assert.Equal(t, 0.75, checks.SuccessRate)
+
+	// The first two checks slide out of the window
+	now = now.Add(5 * time.Minute)
+	checks = p.checks(now)
+	assert.Equal(t, int64(2), checks.Total)
+	assert.Equal(t, 1.0, checks.SuccessRate)
+}
+
+func TestStatusPage_RateLimit(t *testing.T) {
+	_, router, now := newStatusPageTestRouter(t, models.StatusPageConfig{
+		CheckWindow: time.Hour, CacheTTL: 30 * time.Second, RateLimit: 2, RateLimitWindow: time.Minute,
+	})
+
+	for i := 0; i < 2; i++ {
+		getStatusPage(t, router, "198.51.100.7", "/status.json")
+	}
+	*now = now.Add(15 * time.Second)
+	rec := checkFrom(router, "198.51.100.7", http.MethodGet, "/api/v1/status.json", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "45", rec.Header().Get("Retry-After"))
+	var errResp models.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+	assert.Equal(t, models.ErrorCodeServiceUnavailable, errResp.Code)
+
+	getStatusPage(t, router, "198.51.100.8", "/status.json")
+
+	*now = now.Add(45 * time.Second)
+	getStatusPage(t, router, "198.51.100.7", "/status.json")
+}
+
+func TestStatusPage_NotEnabled(t *testing.T) {
+	router := SetupRoutes(newTestHandlers(t), &models.Config{})
+	rec := checkFrom(router, "198.51.100.7", http.MethodGet, "/status.json", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
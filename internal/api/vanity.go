@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// vanityHosts resolves the application of a request from its Host header,
+// for hostnames that are dedicated to one application.
+type vanityHosts map[string]string
+
+// appID returns the application of the request's host, ignoring any port.
+func (v vanityHosts) appID(r *http.Request) (string, bool) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	appID, ok := v[strings.ToLower(strings.TrimSuffix(host, "."))]
+	return appID, ok
+}
+
+// withApp serves next with the host's application as the app_id route
+// variable, as if the request had used the /api/v1/updates/{app_id} path.
+func (v vanityHosts) withApp(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		appID, _ := v.appID(r)
+		vars := mux.Vars(r)
+		if vars == nil {
+			vars = make(map[string]string, 1)
+		}
+		vars["app_id"] = appID
+		next(w, mux.SetURLVars(r, vars))
+	}
+}
+
+// registerVanityHosts registers the update check endpoints without the app_id
+// path segment for requests to a vanity host, behind the same middleware as
+// the regular check endpoints.
+func registerVanityHosts(router *mux.Router, handlers *Handlers, hosts map[string]string, middleware []mux.MiddlewareFunc) {
+	if len(hosts) == 0 {
+		return
+	}
+	v := vanityHosts(hosts)
+	vanityAPI := router.MatcherFunc(func(r *http.Request, _ *mux.RouteMatch) bool {
+		_, ok := v.appID(r)
+		return ok
+	}).Subrouter()
+	vanityAPI.Use(middleware...)
+	vanityAPI.HandleFunc("/check", v.withApp(handlers.CheckForUpdates)).Methods("GET")
+	vanityAPI.HandleFunc("/latest", v.withApp(handlers.GetLatestVersion)).Methods("GET")
+	vanityAPI.HandleFunc("/plugins", v.withApp(handlers.ListPluginUpdates)).Methods("GET")
+	vanityAPI.HandleFunc("/image", v.withApp(handlers.CheckContainerImage)).Methods("GET")
+	vanityAPI.HandleFunc("/ota", v.withApp(handlers.CheckOTAUpdate)).Methods("GET")
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVanityHosts(t *testing.T) {
+	mockService := &MockUpdateService{}
+	mockService.On("CheckForUpdate", mock.Anything, mock.MatchedBy(func(req *models.UpdateCheckRequest) bool {
+		return req.ApplicationID == "myproduct"
+	})).Return(&models.UpdateCheckResponse{UpdateAvailable: false, CurrentVersion: "1.0.0"}, nil)
+
+	config := &models.Config{Server: models.ServerConfig{
+		VanityHosts: map[string]string{"updates.myproduct.com": "myproduct"},
+	}}
+	router := SetupRoutes(NewHandlers(mockService), config)
+
+	tests := []struct {
+		name     string
+		host     string
+		target   string
+		wantCode int
+	}{
+		{"check on vanity host", "updates.myproduct.com", "/check?current_version=1.0.0&platform=linux&architecture=amd64", http.StatusOK},
+		{"host with port and upper case", "Updates.MyProduct.com:8443", "/check?current_version=1.0.0&platform=linux&architecture=amd64", http.StatusOK},
+		{"full path still works", "updates.myproduct.com", "/api/v1/updates/myproduct/check?current_version=1.0.0&platform=linux&architecture=amd64", http.StatusOK},
+		{"health on vanity host", "updates.myproduct.com", "/health", http.StatusOK},
+		{"short path on other hosts", "updates.example.com", "/check?current_version=1.0.0&platform=linux&architecture=amd64", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Host = tt.host
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+// Package artifact fetches release artifacts from their download URLs to
+// measure them, for registrations that ask the server to fill in the file
+// size and checksum.
+//
+// Publishers choose the URLs, so by default the fetcher refuses to connect to
+// loopback, private and link-local addresses. The check runs on the address
+// actually dialled, after DNS resolution and on every redirect, so a public
+// name that resolves to an internal address is refused too.
+package artifact
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+	"updater/internal/blake3"
+	"updater/internal/models"
+)
+
+var (
+	// ErrTooLarge means the artifact exceeds the configured maximum size.
+	ErrTooLarge = errors.New("artifact exceeds the maximum size")
+	// ErrInternalAddress means the download URL resolved to an address the
+	// fetcher may not connect to.
+	ErrInternalAddress = errors.New("download URL resolves to a private, loopback or link-local address")
+)
+
+// maxRedirects bounds the redirects followed to reach an artifact.
+const maxRedirects = 5
+
+// Fetcher measures artifacts over HTTP.
+type Fetcher struct {
+	client  *http.Client
+	maxSize int64
+}
+
+// New creates a fetcher from cfg, which is expected to have been validated.
+func New(cfg models.AutoFillConfig) *Fetcher {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+	if cfg.AllowPrivateNetworks {
+		// A proxy resolves the target itself, so one is only used when
+		// internal targets are allowed anyway
+		transport.Proxy = http.ProxyFromEnvironment
+	} else {
+		dialer.Control = refuseInternal
+	}
+	return &Fetcher{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   cfg.Timeout,
+			CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				return nil
+			},
+		},
+		maxSize: cfg.MaxSize,
+	}
+}
+
+// refuseInternal is a net.Dialer Control function rejecting connections to
+// addresses that are not publicly routable.
+func refuseInternal(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || models.IsInternalAddr(addr) {
+		return ErrInternalAddress
+	}
+	return nil
+}
+
+// Size returns the artifact's size from a HEAD request, falling back to
+// downloading it when the server does not report a length.
+func (f *Fetcher) Size(ctx context.Context, url string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK && resp.ContentLength >= 0 {
+		if resp.ContentLength > f.maxSize {
+			return 0, ErrTooLarge
+		}
+		return resp.ContentLength, nil
+	}
+	size, _, err := f.Digest(ctx, url, models.ChecksumTypeSHA256)
+	return size, err
+}
+
+// Digest downloads the artifact and returns its size and its hex checksum of
+// checksumType.
+func (f *Fetcher) Digest(ctx context.Context, url, checksumType string) (int64, string, error) {
+	h, err := newHash(checksumType)
+	if err != nil {
+		return 0, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", fmt.Errorf("download returned HTTP %d", resp.StatusCode)
+	}
+	if resp.ContentLength > f.maxSize {
+		return 0, "", ErrTooLarge
+	}
+
+	size, err := io.Copy(h, io.LimitReader(resp.Body, f.maxSize+1))
+	if err != nil {
+		return 0, "", fmt.Errorf("download failed: %w", err)
+	}
+	if size > f.maxSize {
+		return 0, "", ErrTooLarge
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newHash returns a hash for a checksum type the server can compute.
+func newHash(checksumType string) (hash.Hash, error) {
+	switch checksumType {
+	case models.ChecksumTypeSHA256:
+		return sha256.New(), nil
+	case models.ChecksumTypeSHA512:
+		return sha512.New(), nil
+	case models.ChecksumTypeSHA1:
+		return sha1.New(), nil
+	case models.ChecksumTypeMD5:
+		return md5.New(), nil
+	case models.ChecksumTypeBLAKE3:
+		return blake3.New(), nil
+	default:
+		return nil, fmt.Errorf("cannot compute %s checksums", checksumType)
+	}
+}
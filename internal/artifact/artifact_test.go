@@ -0,0 +1,81 @@
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/blake3"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetcher(t *testing.T) {
+	body := strings.Repeat("artifact", 128)
+	sum := sha256.Sum256([]byte(body))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app.exe", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	mux.HandleFunc("/streamed", func(w http.ResponseWriter, r *http.Request) {
+		// No Content-Length, so HEAD falls back to downloading
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.(http.Flusher).Flush()
+		w.Write([]byte(body))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/app.exe", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	f := New(models.AutoFillConfig{Enabled: true, Timeout: 5 * time.Second, MaxSize: 4096, AllowPrivateNetworks: true})
+
+	size, checksum, err := f.Digest(ctx, server.URL+"/redirect", models.ChecksumTypeSHA256)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(body)), size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+
+	size, err = f.Size(ctx, server.URL+"/app.exe")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(body)), size)
+
+	size, err = f.Size(ctx, server.URL+"/streamed")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(body)), size)
+
+	_, _, err = f.Digest(ctx, server.URL+"/missing", models.ChecksumTypeSHA256)
+	assert.ErrorContains(t, err, "HTTP 404")
+
+	_, checksum, err = f.Digest(ctx, server.URL+"/app.exe", models.ChecksumTypeBLAKE3)
+	require.NoError(t, err)
+	blake3sum := blake3.Sum256([]byte(body))
+	assert.Equal(t, hex.EncodeToString(blake3sum[:]), checksum)
+
+	small := New(models.AutoFillConfig{Enabled: true, Timeout: 5 * time.Second, MaxSize: 100, AllowPrivateNetworks: true})
+	_, _, err = small.Digest(ctx, server.URL+"/streamed", models.ChecksumTypeSHA256)
+	assert.ErrorIs(t, err, ErrTooLarge)
+	_, err = small.Size(ctx, server.URL+"/app.exe")
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestFetcherRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secret"))
+	}))
+	defer server.Close()
+
+	f := New(models.AutoFillConfig{Enabled: true, Timeout: 5 * time.Second, MaxSize: 4096})
+	_, _, err := f.Digest(context.Background(), server.URL, models.ChecksumTypeSHA256)
+	assert.ErrorIs(t, err, ErrInternalAddress)
+}
@@ -0,0 +1,249 @@
+// Package blake3 computes BLAKE3 hashes, so the service verifies and computes
+// blake3 release checksums as it does the others. It implements the default
+// hash mode with a 32-byte output, following the reference implementation:
+// keyed hashing, key derivation and extended output are left out, as no
+// checksum uses them.
+package blake3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size is the size of a BLAKE3 checksum in bytes.
+	Size = 32
+	// BlockSize is the block size of BLAKE3 in bytes.
+	BlockSize = 64
+
+	chunkLen = 1024
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+var iv = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+// g mixes a column or diagonal of the state with two message words.
+func g(s *[16]uint32, a, b, c, d int, mx, my uint32) {
+	s[a] += s[b] + mx
+	s[d] = bits.RotateLeft32(s[d]^s[a], -16)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -12)
+	s[a] += s[b] + my
+	s[d] = bits.RotateLeft32(s[d]^s[a], -8)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -7)
+}
+
+func round(s *[16]uint32, m *[16]uint32) {
+	g(s, 0, 4, 8, 12, m[0], m[1])
+	g(s, 1, 5, 9, 13, m[2], m[3])
+	g(s, 2, 6, 10, 14, m[4], m[5])
+	g(s, 3, 7, 11, 15, m[6], m[7])
+	g(s, 0, 5, 10, 15, m[8], m[9])
+	g(s, 1, 6, 11, 12, m[10], m[11])
+	g(s, 2, 7, 8, 13, m[12], m[13])
+	g(s, 3, 4, 9, 14, m[14], m[15])
+}
+
+func permute(m *[16]uint32) {
+	var permuted [16]uint32
+	for i, j := range msgPermutation {
+		permuted[i] = m[j]
+	}
+	*m = permuted
+}
+
+// compress runs the compression function on one block.
+func compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	s := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	m := *block
+	for i := 0; i < 7; i++ {
+		round(&s, &m)
+		if i < 6 {
+			permute(&m)
+		}
+	}
+	for i := 0; i < 8; i++ {
+		s[i] ^= s[i+8]
+		s[i+8] ^= cv[i]
+	}
+	return s
+}
+
+func first8(words [16]uint32) [8]uint32 {
+	var cv [8]uint32
+	copy(cv[:], words[:8])
+	return cv
+}
+
+func blockWords(block *[BlockSize]byte) [16]uint32 {
+	var words [16]uint32
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(block[i*4:])
+	}
+	return words
+}
+
+// output is a node of the hash tree that is yet to be compressed, either to a
+// chaining value for its parent or, for the root, to the hash.
+type output struct {
+	cv       [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o *output) chainingValue() [8]uint32 {
+	return first8(compress(&o.cv, &o.block, o.counter, o.blockLen, o.flags))
+}
+
+func (o *output) root() [Size]byte {
+	words := compress(&o.cv, &o.block, 0, o.blockLen, o.flags|flagRoot)
+	var sum [Size]byte
+	for i := 0; i < 8; i++ {
+		binary.LittleEndian.PutUint32(sum[i*4:], words[i])
+	}
+	return sum
+}
+
+func parentOutput(left, right [8]uint32) output {
+	o := output{cv: iv, blockLen: BlockSize, flags: flagParent}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+	return o
+}
+
+// chunkState hashes one chunk of up to chunkLen bytes.
+type chunkState struct {
+	cv               [8]uint32
+	counter          uint64
+	block            [BlockSize]byte
+	blockLen         int
+	blocksCompressed int
+}
+
+func newChunkState(counter uint64) chunkState {
+	return chunkState{cv: iv, counter: counter}
+}
+
+func (c *chunkState) len() int {
+	return c.blocksCompressed*BlockSize + c.blockLen
+}
+
+func (c *chunkState) startFlag() uint32 {
+	if c.blocksCompressed == 0 {
+		return flagChunkStart
+	}
+	return 0
+}
+
+func (c *chunkState) update(p []byte) {
+	for len(p) > 0 {
+		// The last block of a chunk is compressed by output, with the end flag
+		if c.blockLen == BlockSize {
+			words := blockWords(&c.block)
+			c.cv = first8(compress(&c.cv, &words, c.counter, BlockSize, c.startFlag()))
+			c.blocksCompressed++
+			c.block = [BlockSize]byte{}
+			c.blockLen = 0
+		}
+		n := copy(c.block[c.blockLen:], p)
+		c.blockLen += n
+		p = p[n:]
+	}
+}
+
+func (c *chunkState) output() output {
+	return output{
+		cv:       c.cv,
+		block:    blockWords(&c.block),
+		counter:  c.counter,
+		blockLen: uint32(c.blockLen),
+		flags:    c.startFlag() | flagChunkEnd,
+	}
+}
+
+// digest is a BLAKE3 hash.Hash. Chaining values of completed subtrees are
+// kept on a stack, one per set bit of the number of chunks hashed so far.
+type digest struct {
+	chunk   chunkState
+	cvStack [][8]uint32
+}
+
+// New returns a hash.Hash computing 32-byte BLAKE3 checksums.
+func New() hash.Hash {
+	return &digest{chunk: newChunkState(0)}
+}
+
+// Sum256 returns the BLAKE3 checksum of data.
+func Sum256(data []byte) [Size]byte {
+	d := &digest{chunk: newChunkState(0)}
+	d.Write(data)
+	return d.sum()
+}
+
+func (d *digest) Size() int      { return Size }
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Reset() {
+	d.chunk = newChunkState(0)
+	d.cvStack = d.cvStack[:0]
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// A full chunk is only finished once more input arrives, since the
+		// last chunk is the root when there is no more
+		if d.chunk.len() == chunkLen {
+			cv := d.chunk.output()
+			d.addChunk(cv.chainingValue(), d.chunk.counter+1)
+			d.chunk = newChunkState(d.chunk.counter + 1)
+		}
+		take := min(chunkLen-d.chunk.len(), len(p))
+		d.chunk.update(p[:take])
+		p = p[take:]
+	}
+	return n, nil
+}
+
+// addChunk pushes a finished chunk's chaining value, first merging it with
+// every completed subtree of the same size. totalChunks counts the chunks
+// hashed so far; each trailing zero bit is a merge.
+func (d *digest) addChunk(cv [8]uint32, totalChunks uint64) {
+	for totalChunks&1 == 0 {
+		parent := parentOutput(d.cvStack[len(d.cvStack)-1], cv)
+		cv = parent.chainingValue()
+		d.cvStack = d.cvStack[:len(d.cvStack)-1]
+		totalChunks >>= 1
+	}
+	d.cvStack = append(d.cvStack, cv)
+}
+
+func (d *digest) sum() [Size]byte {
+	o := d.chunk.output()
+	for i := len(d.cvStack) - 1; i >= 0; i-- {
+		o = parentOutput(d.cvStack[i], o.chainingValue())
+	}
+	return o.root()
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	sum := d.sum()
+	return append(b, sum[:]...)
+}
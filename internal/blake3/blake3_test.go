@@ -0,0 +1,63 @@
+package blake3
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testInput returns the input of the official BLAKE3 test vectors: n bytes
+// repeating 0, 1, ..., 250.
+func testInput(n int) []byte {
+	in := make([]byte, n)
+	for i := range in {
+		in[i] = byte(i % 251)
+	}
+	return in
+}
+
+func TestSum256(t *testing.T) {
+	// From the BLAKE3 repository's test_vectors.json, truncated to 32 bytes
+	vectors := []struct {
+		n    int
+		want string
+	}{
+		{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+		{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+		{1023, "10108970eeda3eb932baac1428c7a2163b0e924c9a9e25b35bba72b28f70bd11"},
+		{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+		{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+		{2048, "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+		{2049, "5f4d72f40d7a5f82b15ca2b2e44b1de3c2ef86c426c95c1af0b6879522563030"},
+		{3072, "b98cb0ff3623be03326b373de6b9095218513e64f1ee2edd2525c7ad1e5cffd2"},
+		{4096, "015094013f57a5277b59d8475c0501042c0b642e531b0a1c8f58d2163229e969"},
+	}
+	for _, v := range vectors {
+		sum := Sum256(testInput(v.n))
+		assert.Equal(t, v.want, hex.EncodeToString(sum[:]), "input of %d bytes", v.n)
+	}
+
+	sum := Sum256([]byte("abc"))
+	assert.Equal(t, "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", hex.EncodeToString(sum[:]))
+}
+
+func TestNew_Streaming(t *testing.T) {
+	in := testInput(10 * chunkLen)
+	want := Sum256(in)
+
+	h := New()
+	for rest := in; len(rest) > 0; {
+		n := min(37, len(rest))
+		h.Write(rest[:n])
+		rest = rest[n:]
+	}
+	assert.Equal(t, want[:], h.Sum(nil))
+	assert.Equal(t, want[:], h.Sum(nil), "Sum does not change the state")
+
+	h.Reset()
+	h.Write([]byte("abc"))
+	abc := Sum256([]byte("abc"))
+	assert.Equal(t, abc[:], h.Sum(nil))
+	assert.Equal(t, Size, h.Size())
+}
@@ -0,0 +1,165 @@
+// Package blob keeps uploaded release artifacts in blob storage: a directory
+// on the server's disk, an S3 bucket or a Cloud Storage bucket. The update
+// service writes and deletes artifacts under keys such as
+// "<app_id>/<release_id>", and clients download them from the store's public
+// URL, which may be a CDN in front of the bucket.
+package blob
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"updater/internal/blake3"
+	"updater/internal/models"
+)
+
+var (
+	// ErrInvalidKey means a key has an empty, relative or unsafe segment.
+	ErrInvalidKey = errors.New("invalid artifact key")
+	// ErrTooLarge means an upload exceeds the configured maximum size.
+	ErrTooLarge = errors.New("artifact exceeds the maximum size")
+)
+
+// Object is an artifact to store.
+type Object struct {
+	Body        io.Reader
+	Size        int64
+	SHA256      string // Hex digest of Body; sent to stores that verify it
+	ContentType string // Defaults to application/octet-stream
+}
+
+// Store keeps artifacts under keys of slash-separated segments.
+type Store interface {
+	// Put stores obj under key, replacing the object stored there
+	Put(ctx context.Context, key string, obj Object) error
+	// Delete removes the object under key; a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public download URL of the object under key
+	URL(key string) string
+}
+
+// New creates the store cfg selects. The configuration is expected to have
+// been validated.
+func New(cfg models.ArtifactsConfig) (Store, error) {
+	switch cfg.Driver {
+	case models.ArtifactDriverLocal:
+		return NewLocal(cfg.Local.Path, cfg.PublicURL)
+	case models.ArtifactDriverS3:
+		return NewS3(S3Options{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			Prefix:          cfg.S3.Prefix,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			PathStyle:       cfg.S3.PathStyle,
+			PublicURL:       cfg.PublicURL,
+		})
+	case models.ArtifactDriverGCS:
+		return NewGCS(cfg.GCS, cfg.PublicURL)
+	default:
+		return nil, fmt.Errorf("unknown artifact driver %q", cfg.Driver)
+	}
+}
+
+// ValidateKey checks that every segment of key is a plain file name of
+// letters, digits, dots, dashes and underscores, so a key can name neither a
+// parent directory nor anything a store would interpret.
+func ValidateKey(key string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+		for _, c := range segment {
+			if !isKeyChar(c) {
+				return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+			}
+		}
+	}
+	return nil
+}
+
+func isKeyChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_'
+}
+
+// publicURL is the base URL objects are downloaded from.
+type publicURL string
+
+// URL returns the download URL of the object under key.
+func (base publicURL) URL(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.TrimRight(string(base), "/") + "/" + strings.Join(segments, "/")
+}
+
+// Spooled is an upload copied to a temporary file, positioned at its start.
+type Spooled struct {
+	*os.File
+	Size      int64
+	Checksums map[string]string // Hex checksums keyed by type, of every type the server can compute
+}
+
+// Spool copies r to a temporary file, computing its checksums on the way.
+// It returns ErrTooLarge when r holds more than maxSize bytes. The caller
+// closes the Spooled, which removes the file.
+func Spool(r io.Reader, maxSize int64) (*Spooled, error) {
+	f, err := os.CreateTemp("", "updater-artifact-*")
+	if err != nil {
+		return nil, err
+	}
+	s := &Spooled{File: f}
+	hashes := map[string]hash.Hash{
+		models.ChecksumTypeSHA256: sha256.New(),
+		models.ChecksumTypeSHA512: sha512.New(),
+		models.ChecksumTypeSHA1:   sha1.New(),
+		models.ChecksumTypeMD5:    md5.New(),
+		models.ChecksumTypeBLAKE3: blake3.New(),
+	}
+	writers := []io.Writer{f}
+	for _, h := range hashes {
+		writers = append(writers, h)
+	}
+
+	// Read one byte more than allowed to tell a full upload from an oversized one
+	s.Size, err = io.Copy(io.MultiWriter(writers...), io.LimitReader(r, maxSize+1))
+	if err == nil && s.Size > maxSize {
+		err = ErrTooLarge
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.Checksums = make(map[string]string, len(hashes))
+	for checksumType, h := range hashes {
+		s.Checksums[checksumType] = hex.EncodeToString(h.Sum(nil))
+	}
+	return s, nil
+}
+
+// Close closes and removes the temporary file.
+func (s *Spooled) Close() error {
+	err := s.File.Close()
+	if removeErr := os.Remove(s.Name()); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+		return removeErr
+	}
+	return err
+}
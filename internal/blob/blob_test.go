@@ -0,0 +1,156 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"my-app/01J0", "app_1/v1.2.3", "artifact"} {
+		assert.NoError(t, ValidateKey(key), key)
+	}
+	for _, key := range []string{"", "/my-app", "my-app/", "my-app//01J0", "../etc/passwd", "my-app/./x", "a b", "my-app/%2e%2e"} {
+		assert.ErrorIs(t, ValidateKey(key), ErrInvalidKey, key)
+	}
+}
+
+func TestNew(t *testing.T) {
+	store, err := New(models.ArtifactsConfig{
+		Driver:    models.ArtifactDriverLocal,
+		PublicURL: "https://updates.example.com/artifacts",
+		Local:     models.LocalArtifactsConfig{Path: t.TempDir()},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &Local{}, store)
+	assert.Equal(t, "https://updates.example.com/artifacts/my-app/01J0", store.URL("my-app/01J0"))
+
+	store, err = New(models.ArtifactsConfig{Driver: models.ArtifactDriverGCS, GCS: models.GCSArtifactsConfig{Bucket: "releases"}})
+	require.NoError(t, err)
+	assert.IsType(t, &S3{}, store)
+
+	_, err = New(models.ArtifactsConfig{Driver: "azure"})
+	assert.ErrorContains(t, err, "unknown artifact driver")
+}
+
+func TestLocal_PutServeDelete(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocal(filepath.Join(dir, "artifacts"), "https://updates.example.com/artifacts")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "my-app/01J0", Object{Body: strings.NewReader("binary"), Size: 6}))
+	data, err := os.ReadFile(filepath.Join(dir, "artifacts", "my-app", "01J0"))
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(data))
+
+	// A second upload replaces the first
+	require.NoError(t, store.Put(ctx, "my-app/01J0", Object{Body: strings.NewReader("binary v2"), Size: 9}))
+
+	rec := httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/my-app/01J0", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "binary v2", rec.Body.String())
+	assert.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/my-app/01J0", nil)
+	req.Header.Set("Range", "bytes=0-5")
+	store.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, "binary", rec.Body.String())
+
+	for _, path := range []string{"/my-app", "/my-app/missing", "/../secret"} {
+		rec = httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.URL.Path = path
+		store.ServeHTTP(rec, r)
+		assert.Equal(t, http.StatusNotFound, rec.Code, path)
+	}
+
+	require.NoError(t, store.Delete(ctx, "my-app/01J0"))
+	require.NoError(t, store.Delete(ctx, "my-app/01J0"))
+	_, err = os.Stat(filepath.Join(dir, "artifacts", "my-app", "01J0"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLocal_PutShortBody(t *testing.T) {
+	store, err := NewLocal(t.TempDir(), "https://updates.example.com/artifacts")
+	require.NoError(t, err)
+
+	err = store.Put(context.Background(), "my-app/01J0", Object{Body: strings.NewReader("bin"), Size: 6})
+	assert.ErrorContains(t, err, "expected 6")
+
+	rec := httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/my-app/01J0", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestLocal_PutConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocal(dir, "https://updates.example.com/artifacts")
+	require.NoError(t, err)
+
+	// Two uploads to one key write in turns; the stored artifact is one of
+	// them whole
+	contents := []string{strings.Repeat("a", 8), strings.Repeat("b", 8)}
+	writers := make([]*io.PipeWriter, len(contents))
+	errs := make(chan error, len(contents))
+	for i := range contents {
+		r, w := io.Pipe()
+		writers[i] = w
+		go func() {
+			errs <- store.Put(context.Background(), "my-app/01J0", Object{Body: r, Size: 8})
+		}()
+	}
+	for _, half := range []int{0, 4} {
+		for i, w := range writers {
+			_, err := w.Write([]byte(contents[i][half : half+4]))
+			require.NoError(t, err)
+		}
+	}
+	for _, w := range writers {
+		w.Close()
+	}
+	for range writers {
+		require.NoError(t, <-errs)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "my-app", "01J0"))
+	require.NoError(t, err)
+	assert.Contains(t, contents, string(data))
+	entries, err := os.ReadDir(filepath.Join(dir, "my-app"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no upload files are left behind")
+}
+
+func TestSpool(t *testing.T) {
+	spooled, err := Spool(strings.NewReader("hello"), 5)
+	require.NoError(t, err)
+	name := spooled.Name()
+
+	assert.Equal(t, int64(5), spooled.Size)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", spooled.Checksums[models.ChecksumTypeSHA256])
+	assert.Equal(t, "5d41402abc4b2a76b9719d911017c592", spooled.Checksums[models.ChecksumTypeMD5])
+	assert.Equal(t, "ea8f163db38682925e4491c5e58d4bb3506ef8c14eb78a86e908c5624a67200f", spooled.Checksums[models.ChecksumTypeBLAKE3])
+	assert.Len(t, spooled.Checksums, 5)
+	data, err := io.ReadAll(spooled)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	require.NoError(t, spooled.Close())
+	_, err = os.Stat(name)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Spool(strings.NewReader("hello!"), 5)
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
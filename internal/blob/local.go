@@ -0,0 +1,112 @@
+package blob
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// Local keeps artifacts in a directory on the server's disk and serves them
+// over HTTP itself. Files are written next to their final name and renamed
+// into place, so a download never sees a partly written artifact.
+type Local struct {
+	publicURL
+	root *os.Root
+}
+
+// NewLocal creates a store in dir, creating the directory when it does not
+// exist. Objects are downloaded from baseURL, where the server is expected
+// to serve the store with ServeHTTP.
+func NewLocal(dir, baseURL string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating artifact directory: %w", err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening artifact directory: %w", err)
+	}
+	return &Local{publicURL: publicURL(baseURL), root: root}, nil
+}
+
+// Put writes obj to the file named by key.
+func (l *Local) Put(_ context.Context, key string, obj Object) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	if dir := path.Dir(key); dir != "." {
+		if err := l.root.MkdirAll(dir, 0o750); err != nil {
+			return err
+		}
+	}
+
+	// Each upload gets its own file, so concurrent uploads to one key never
+	// write to the same file
+	suffix := make([]byte, 8)
+	rand.Read(suffix)
+	tmp := key + "." + hex.EncodeToString(suffix) + ".upload"
+	f, err := l.root.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, obj.Body)
+	if err == nil && n != obj.Size {
+		err = fmt.Errorf("wrote %d bytes, expected %d", n, obj.Size)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = l.root.Rename(tmp, key)
+	}
+	if err != nil {
+		l.root.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Delete removes the file named by key.
+func (l *Local) Delete(_ context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	if err := l.root.Remove(key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ServeHTTP serves the object whose key is the request path, supporting
+// range and conditional requests. Directories and upload files in progress
+// are not served.
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	if ValidateKey(key) != nil || strings.HasSuffix(key, ".upload") {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := l.root.Open(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, "", info.ModTime(), f)
+}
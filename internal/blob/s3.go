@@ -0,0 +1,282 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"updater/internal/models"
+)
+
+// unsignedPayload is signed in place of the body digest when it is unknown.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// maxErrorBodySize bounds the error document read from a failed request.
+const maxErrorBodySize = 64 << 10
+
+// gcsEndpoint is the S3-compatible XML API of Cloud Storage.
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// S3Options configures an S3 store.
+type S3Options struct {
+	Endpoint        string // Defaults to AWS for Region
+	Region          string
+	Bucket          string
+	Prefix          string // Prepended to every key
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool         // Address the bucket in the path instead of the host name
+	PublicURL       string       // Base URL objects are downloaded from
+	HTTPClient      *http.Client // Defaults to a client without a timeout; uploads are bounded by their context
+}
+
+// S3 keeps artifacts in an S3 bucket, signing requests with AWS Signature
+// Version 4. It works with S3-compatible stores, including Cloud Storage
+// with HMAC keys.
+type S3 struct {
+	publicURL
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3 creates an S3 store.
+func NewS3(opts S3Options) (*S3, error) {
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + opts.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	if opts.Prefix != "" {
+		opts.Prefix = strings.Trim(opts.Prefix, "/")
+		if err := ValidateKey(opts.Prefix); err != nil {
+			return nil, fmt.Errorf("invalid prefix: %w", err)
+		}
+		opts.Prefix += "/"
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &S3{publicURL: publicURL(opts.PublicURL), opts: opts, endpoint: u, client: client, now: time.Now}, nil
+}
+
+// NewGCS creates a store for a Cloud Storage bucket, using its S3-compatible
+// XML API with an HMAC key.
+func NewGCS(cfg models.GCSArtifactsConfig, baseURL string) (*S3, error) {
+	return NewS3(S3Options{
+		Endpoint:        gcsEndpoint,
+		Region:          "auto",
+		Bucket:          cfg.Bucket,
+		Prefix:          cfg.Prefix,
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+		PathStyle:       true,
+		PublicURL:       baseURL,
+	})
+}
+
+// URL returns the download URL of the object under key. The prefix is part
+// of the object's name in the bucket, so it is part of the URL too.
+func (s *S3) URL(key string) string {
+	return s.publicURL.URL(s.opts.Prefix + key)
+}
+
+// Put uploads obj with a single PUT request.
+func (s *S3) Put(ctx context.Context, key string, obj Object) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	var body io.Reader = http.NoBody
+	if obj.Size > 0 {
+		body = obj.Body
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = obj.Size
+	contentType := obj.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.Header.Set("Content-Type", contentType)
+	payloadHash := obj.SHA256
+	if payloadHash == "" {
+		payloadHash = unsignedPayload
+	}
+	return s.do(req, payloadHash)
+}
+
+// Delete removes the object under key.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	err = s.do(req, emptyPayloadHash)
+	// S3 answers 204 for missing objects, Cloud Storage 404
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// objectURL returns the API URL of the object under key.
+func (s *S3) objectURL(key string) string {
+	u := *s.endpoint
+	objectPath := "/" + s.opts.Prefix + key
+	if s.opts.PathStyle {
+		objectPath = "/" + s.opts.Bucket + objectPath
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + objectPath
+	u.RawPath = ""
+	return u.String()
+}
+
+// StatusError is returned when the store answers with an error status.
+type StatusError struct {
+	StatusCode int
+	Code       string // Error code from the response document, such as AccessDenied
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("blob store returned HTTP %d: %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("blob store returned HTTP %d", e.StatusCode)
+}
+
+// do signs and sends req, returning a StatusError for an error status.
+func (s *S3) do(req *http.Request, payloadHash string) error {
+	signV4(req, payloadHash, s.opts.Region, s.opts.AccessKeyID, s.opts.SecretAccessKey, s.now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	statusErr := &StatusError{StatusCode: resp.StatusCode}
+	var doc struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if xml.NewDecoder(io.LimitReader(resp.Body, maxErrorBodySize)).Decode(&doc) == nil {
+		statusErr.Code, statusErr.Message = doc.Code, doc.Message
+	}
+	return statusErr
+}
+
+// emptyPayloadHash is the SHA-256 digest of an empty body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// signV4 adds AWS Signature Version 4 headers for the s3 service to req,
+// signing the host and every header already set on it.
+func signV4(req *http.Request, payloadHash, region, accessKeyID, secretAccessKey string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	digest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(digest[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery encodes query parameters sorted by name, as Signature
+// Version 4 requires.
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var parts []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, uriEncode(name)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes every byte but the unreserved characters.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
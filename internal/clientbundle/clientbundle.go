@@ -0,0 +1,306 @@
+// Package clientbundle signs the client configuration bundle. The bundle is
+// signed with an Ed25519 key and handed out as a base64 payload and signature,
+// so clients verify exactly the bytes that were signed instead of a
+// re-encoding of them.
+//
+// Keys are signing.Signers, so a key can live in a key file or in a cloud
+// KMS that signs on the service's behalf.
+//
+// A signed bundle is reused until half its validity has passed and then
+// signed again with fresh timestamps, so clients always receive a bundle with
+// at least half its validity left.
+//
+// Signing keys rotate on a schedule of overlapping periods. Each key is
+// endorsed by the key before it, and the endorsements are published with the
+// key history, so a client that trusts any one key can follow the chain to
+// the key signing today.
+package clientbundle
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"updater/internal/models"
+	"updater/internal/signing"
+)
+
+var (
+	// ErrInvalidSignature means the bundle was not signed by the given key.
+	ErrInvalidSignature = errors.New("invalid client bundle signature")
+	// ErrExpired means the bundle's validity has run out.
+	ErrExpired = errors.New("client bundle has expired")
+	// ErrNoActiveKey means no signing key's period includes the current time.
+	ErrNoActiveKey = errors.New("no client bundle signing key is active")
+)
+
+// SigningKey is a bundle signing key and the period it signs bundles in. Zero
+// times leave the period open at that end.
+type SigningKey struct {
+	Key       signing.Signer
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// validAt reports whether t falls in the key's period.
+func (k *SigningKey) validAt(t time.Time) bool {
+	return !t.Before(k.NotBefore) && (k.NotAfter.IsZero() || t.Before(k.NotAfter))
+}
+
+// signingKey is a configured key with what is derived from it once.
+type signingKey struct {
+	SigningKey
+	id          string
+	endorsement *models.KeyEndorsement // Nil for the first key
+}
+
+// Signer signs the bundle described by a ClientBundleConfig with the newest
+// signing key whose period has started.
+type Signer struct {
+	cfg  models.ClientBundleConfig
+	keys []signingKey // Oldest first
+	now  func() time.Time
+
+	mu       sync.Mutex
+	signed   *models.SignedClientBundle
+	resignAt time.Time
+}
+
+// New creates a signer for the bundle cfg describes. keys are in rotation
+// order, oldest first; each key after the first is endorsed by the one
+// before it.
+func New(ctx context.Context, cfg models.ClientBundleConfig, keys []SigningKey) (*Signer, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no client bundle signing keys")
+	}
+	s := &Signer{cfg: cfg, now: time.Now}
+	for i, key := range keys {
+		k := signingKey{SigningKey: key, id: signing.KeyID(key.Key.Public())}
+		if i > 0 {
+			endorsement, err := endorse(ctx, keys[i-1].Key, key)
+			if err != nil {
+				return nil, err
+			}
+			k.endorsement = endorsement
+		}
+		s.keys = append(s.keys, k)
+	}
+	return s, nil
+}
+
+// LoadSigningKeys opens the signing keys cfg names: its signing_keys, or
+// signing_key_file as a single key with an open period.
+func LoadSigningKeys(ctx context.Context, cfg models.ClientBundleConfig) ([]SigningKey, error) {
+	configured := cfg.SigningKeys
+	if cfg.SigningKeyFile != "" {
+		configured = []models.SigningKeyConfig{{File: cfg.SigningKeyFile}}
+	}
+	keys := make([]SigningKey, 0, len(configured))
+	for _, kc := range configured {
+		key, err := signing.New(ctx, kc)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, SigningKey{Key: key, NotBefore: kc.NotBefore, NotAfter: kc.NotAfter})
+	}
+	return keys, nil
+}
+
+// active returns the newest key whose period includes t, or nil.
+func (s *Signer) active(t time.Time) *signingKey {
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if s.keys[i].validAt(t) {
+			return &s.keys[i]
+		}
+	}
+	return nil
+}
+
+// PublicKey returns the key bundles are currently verified with.
+func (s *Signer) PublicKey() (ed25519.PublicKey, error) {
+	key := s.active(s.now())
+	if key == nil {
+		return nil, ErrNoActiveKey
+	}
+	return key.Key.Public(), nil
+}
+
+// Bundle returns the signed bundle, signing it again when the previous
+// signature has used up half its validity or the active key has changed. A
+// bundle never outlives the key that signed it.
+func (s *Signer) Bundle(ctx context.Context) (*models.SignedClientBundle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	key := s.active(now)
+	if key == nil {
+		return nil, ErrNoActiveKey
+	}
+	if s.signed != nil && s.signed.KeyID == key.id && now.Before(s.resignAt) {
+		return s.signed, nil
+	}
+
+	issued := now.UTC().Truncate(time.Second)
+	expires := issued.Add(s.cfg.Validity)
+	if !key.NotAfter.IsZero() && key.NotAfter.Before(expires) {
+		expires = key.NotAfter.UTC()
+	}
+	payload, err := json.Marshal(models.ClientBundle{
+		Version:              s.cfg.Version,
+		IssuedAt:             issued,
+		ExpiresAt:            expires,
+		CheckIntervalSeconds: int64(s.cfg.CheckInterval / time.Second),
+		FeedURLs:             s.cfg.FeedURLs,
+		PublicKeys:           s.cfg.PublicKeys,
+		Flags:                s.cfg.Flags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode client bundle: %w", err)
+	}
+	signature, err := key.Key.Sign(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign client bundle: %w", err)
+	}
+	s.signed = &models.SignedClientBundle{
+		Payload:   base64.StdEncoding.EncodeToString(payload),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+		Algorithm: models.ClientBundleAlgorithm,
+		KeyID:     key.id,
+	}
+	s.resignAt = issued.Add(expires.Sub(issued) / 2)
+	return s.signed, nil
+}
+
+// History describes every signing key, oldest first, with its status now.
+func (s *Signer) History() (*models.SigningKeyHistoryResponse, error) {
+	now := s.now()
+	active := s.active(now)
+	history := &models.SigningKeyHistoryResponse{Keys: make([]models.SigningKeyInfo, 0, len(s.keys))}
+	for i := range s.keys {
+		key := &s.keys[i]
+		pub, err := signing.PublicKeyPEM(key.Key.Public())
+		if err != nil {
+			return nil, err
+		}
+		info := models.SigningKeyInfo{
+			KeyID:       key.id,
+			PublicKey:   string(pub),
+			NotBefore:   optionalTime(key.NotBefore),
+			NotAfter:    optionalTime(key.NotAfter),
+			Endorsement: key.endorsement,
+		}
+		switch {
+		case key == active:
+			info.Status = models.SigningKeyActive
+		case now.Before(key.NotBefore):
+			info.Status = models.SigningKeyPending
+		case key.validAt(now):
+			info.Status = models.SigningKeySuperseded
+		default:
+			info.Status = models.SigningKeyRetired
+		}
+		history.Keys = append(history.Keys, info)
+	}
+	return history, nil
+}
+
+// endorse signs next's public key and period with prev.
+func endorse(ctx context.Context, prev signing.Signer, next SigningKey) (*models.KeyEndorsement, error) {
+	pub := next.Key.Public()
+	payload, err := json.Marshal(models.EndorsedKey{
+		KeyID:     signing.KeyID(pub),
+		PublicKey: base64.StdEncoding.EncodeToString(pub),
+		NotBefore: optionalTime(next.NotBefore),
+		NotAfter:  optionalTime(next.NotAfter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key endorsement: %w", err)
+	}
+	signature, err := prev.Sign(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to endorse key %s: %w", signing.KeyID(pub), err)
+	}
+	return &models.KeyEndorsement{
+		Payload:   base64.StdEncoding.EncodeToString(payload),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+		KeyID:     signing.KeyID(prev.Public()),
+	}, nil
+}
+
+// VerifyEndorsement checks endorsement the way a client rolling its trust
+// forward does: it must be signed by one of trusted. It returns the endorsed
+// key and its period.
+func VerifyEndorsement(endorsement *models.KeyEndorsement, trusted []ed25519.PublicKey) (ed25519.PublicKey, *models.EndorsedKey, error) {
+	payload, err := base64.StdEncoding.DecodeString(endorsement.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid endorsement payload: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(endorsement.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid endorsement signature encoding: %w", err)
+	}
+	if !slices.ContainsFunc(trusted, func(pub ed25519.PublicKey) bool {
+		return signing.KeyID(pub) == endorsement.KeyID && ed25519.Verify(pub, payload, signature)
+	}) {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	var endorsed models.EndorsedKey
+	if err := json.Unmarshal(payload, &endorsed); err != nil {
+		return nil, nil, fmt.Errorf("invalid endorsement payload: %w", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(endorsed.PublicKey)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, nil, errors.New("invalid endorsed public key")
+	}
+	pub := ed25519.PublicKey(raw)
+	if signing.KeyID(pub) != endorsed.KeyID {
+		return nil, nil, errors.New("endorsed key ID does not match its public key")
+	}
+	return pub, &endorsed, nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// Verify checks signed the way a client does: the signature must be valid
+// for one of trusted and the bundle must not have expired at now. It returns
+// the verified bundle.
+func Verify(signed *models.SignedClientBundle, trusted []ed25519.PublicKey, now time.Time) (*models.ClientBundle, error) {
+	if signed.Algorithm != models.ClientBundleAlgorithm {
+		return nil, fmt.Errorf("unsupported client bundle algorithm %q", signed.Algorithm)
+	}
+	payload, err := base64.StdEncoding.DecodeString(signed.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client bundle payload: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client bundle signature encoding: %w", err)
+	}
+	if !slices.ContainsFunc(trusted, func(pub ed25519.PublicKey) bool {
+		return signing.KeyID(pub) == signed.KeyID && ed25519.Verify(pub, payload, signature)
+	}) {
+		return nil, ErrInvalidSignature
+	}
+
+	var bundle models.ClientBundle
+	if err := json.Unmarshal(payload, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid client bundle payload: %w", err)
+	}
+	if !now.Before(bundle.ExpiresAt) {
+		return nil, ErrExpired
+	}
+	return &bundle, nil
+}
@@ -0,0 +1,230 @@
+package clientbundle
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/signing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKey(t *testing.T) *signing.Local {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return signing.NewLocal(key)
+}
+
+func testConfig() models.ClientBundleConfig {
+	return models.ClientBundleConfig{
+		Enabled:       true,
+		Version:       3,
+		Validity:      24 * time.Hour,
+		CheckInterval: 6 * time.Hour,
+		FeedURLs:      []string{"https://updates.example.com", "https://updates-eu.example.com"},
+		PublicKeys:    []models.ClientPublicKey{{ID: "release-2026", Use: "release", Key: "-----BEGIN PUBLIC KEY-----"}},
+		Flags:         map[string]bool{"disable_updates": false},
+	}
+}
+
+func newTestSigner(t *testing.T, keys ...SigningKey) *Signer {
+	t.Helper()
+	signer, err := New(context.Background(), testConfig(), keys)
+	require.NoError(t, err)
+	return signer
+}
+
+func publicKey(key signing.Signer) ed25519.PublicKey {
+	return key.Public()
+}
+
+func TestSigner_BundleVerifies(t *testing.T) {
+	key := newTestKey(t)
+	signer := newTestSigner(t, SigningKey{Key: key})
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	signer.now = func() time.Time { return now }
+
+	signed, err := signer.Bundle(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.ClientBundleAlgorithm, signed.Algorithm)
+	assert.Equal(t, signing.KeyID(publicKey(key)), signed.KeyID)
+
+	bundle, err := Verify(signed, []ed25519.PublicKey{publicKey(key)}, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), bundle.Version)
+	assert.Equal(t, now, bundle.IssuedAt)
+	assert.Equal(t, now.Add(24*time.Hour), bundle.ExpiresAt)
+	assert.Equal(t, int64(6*60*60), bundle.CheckIntervalSeconds)
+	assert.Equal(t, testConfig().FeedURLs, bundle.FeedURLs)
+	assert.Equal(t, testConfig().PublicKeys, bundle.PublicKeys)
+	assert.Equal(t, map[string]bool{"disable_updates": false}, bundle.Flags)
+}
+
+func TestSigner_ResignsAfterHalfValidity(t *testing.T) {
+	key := newTestKey(t)
+	signer := newTestSigner(t, SigningKey{Key: key})
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	signer.now = func() time.Time { return now }
+
+	first, err := signer.Bundle(context.Background())
+	require.NoError(t, err)
+
+	now = now.Add(11 * time.Hour)
+	again, err := signer.Bundle(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, again, "the signed bundle is reused within half its validity")
+
+	now = now.Add(time.Hour)
+	renewed, err := signer.Bundle(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Payload, renewed.Payload)
+	bundle, err := Verify(renewed, []ed25519.PublicKey{publicKey(key)}, now)
+	require.NoError(t, err)
+	assert.Equal(t, now, bundle.IssuedAt)
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	key := newTestKey(t)
+	signer := newTestSigner(t, SigningKey{Key: key})
+	now := time.Now()
+	trusted := []ed25519.PublicKey{publicKey(key)}
+	signed, err := signer.Bundle(context.Background())
+	require.NoError(t, err)
+
+	other := newTestKey(t).Public()
+	_, err = Verify(signed, []ed25519.PublicKey{other}, now)
+	assert.ErrorIs(t, err, ErrInvalidSignature, "untrusted key")
+
+	tampered := *signed
+	tampered.Payload = signed.Payload[:len(signed.Payload)-4] + "AAAA"
+	_, err = Verify(&tampered, trusted, now)
+	assert.ErrorIs(t, err, ErrInvalidSignature, "modified payload")
+
+	_, err = Verify(signed, trusted, now.Add(25*time.Hour))
+	assert.ErrorIs(t, err, ErrExpired)
+
+	wrongAlg := *signed
+	wrongAlg.Algorithm = "rsa"
+	_, err = Verify(&wrongAlg, trusted, now)
+	assert.Error(t, err)
+}
+
+func TestSigner_Rotation(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+	signer := newTestSigner(t,
+		SigningKey{Key: oldKey, NotAfter: day(20)},
+		SigningKey{Key: newKey, NotBefore: day(10)},
+	)
+	now := day(5)
+	signer.now = func() time.Time { return now }
+
+	history, err := signer.History()
+	require.NoError(t, err)
+	require.Len(t, history.Keys, 2)
+	assert.Equal(t, models.SigningKeyActive, history.Keys[0].Status)
+	assert.Equal(t, models.SigningKeyPending, history.Keys[1].Status, "the next key is published before it signs")
+	assert.Nil(t, history.Keys[0].Endorsement)
+
+	// A client trusting only the old key rolls its trust forward
+	endorsed, period, err := VerifyEndorsement(history.Keys[1].Endorsement, []ed25519.PublicKey{publicKey(oldKey)})
+	require.NoError(t, err)
+	assert.True(t, publicKey(newKey).Equal(endorsed))
+	require.NotNil(t, period.NotBefore)
+	assert.Equal(t, day(10), *period.NotBefore)
+	assert.Nil(t, period.NotAfter)
+	_, _, err = VerifyEndorsement(history.Keys[1].Endorsement, []ed25519.PublicKey{publicKey(newKey)})
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	signed, err := signer.Bundle(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, signing.KeyID(publicKey(oldKey)), signed.KeyID)
+
+	// Once its period starts the new key signs, even though the cached
+	// bundle has validity left
+	now = day(10)
+	signed, err = signer.Bundle(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, signing.KeyID(publicKey(newKey)), signed.KeyID)
+	history, err = signer.History()
+	require.NoError(t, err)
+	assert.Equal(t, models.SigningKeySuperseded, history.Keys[0].Status)
+	assert.Equal(t, models.SigningKeyActive, history.Keys[1].Status)
+
+	now = day(20)
+	history, err = signer.History()
+	require.NoError(t, err)
+	assert.Equal(t, models.SigningKeyRetired, history.Keys[0].Status)
+}
+
+func TestSigner_BundleExpiresWithKey(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	key := newTestKey(t)
+	signer := newTestSigner(t, SigningKey{Key: key, NotAfter: now.Add(6 * time.Hour)})
+	signer.now = func() time.Time { return now }
+
+	signed, err := signer.Bundle(context.Background())
+	require.NoError(t, err)
+	bundle, err := Verify(signed, []ed25519.PublicKey{publicKey(key)}, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(6*time.Hour), bundle.ExpiresAt, "a bundle never outlives its key")
+
+	now = now.Add(6 * time.Hour)
+	_, err = signer.Bundle(context.Background())
+	assert.ErrorIs(t, err, ErrNoActiveKey)
+	_, err = signer.PublicKey()
+	assert.ErrorIs(t, err, ErrNoActiveKey)
+}
+
+// failingSigner stands in for a remote signer that cannot be reached.
+type failingSigner struct{ signing.Signer }
+
+func (failingSigner) Sign(context.Context, []byte) ([]byte, error) {
+	return nil, errors.New("KMS unavailable")
+}
+
+func TestSigner_SignError(t *testing.T) {
+	key := newTestKey(t)
+	_, err := New(context.Background(), testConfig(), []SigningKey{{Key: failingSigner{key}}, {Key: newTestKey(t)}})
+	assert.ErrorContains(t, err, "KMS unavailable", "the endorsement of the second key fails")
+
+	signer := newTestSigner(t, SigningKey{Key: failingSigner{key}})
+	_, err = signer.Bundle(context.Background())
+	assert.ErrorContains(t, err, "KMS unavailable")
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string) string {
+		_, encoded, err := signing.GenerateKey()
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, encoded, 0600))
+		return path
+	}
+	notBefore := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	keys, err := LoadSigningKeys(context.Background(), models.ClientBundleConfig{SigningKeyFile: write("single.pem")})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].NotBefore.IsZero())
+
+	keys, err = LoadSigningKeys(context.Background(), models.ClientBundleConfig{SigningKeys: []models.SigningKeyConfig{
+		{File: write("2026.pem")},
+		{File: write("2027.pem"), NotBefore: notBefore},
+	}})
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, notBefore, keys[1].NotBefore)
+
+	_, err = LoadSigningKeys(context.Background(), models.ClientBundleConfig{SigningKeys: []models.SigningKeyConfig{{File: filepath.Join(dir, "missing.pem")}}})
+	assert.ErrorContains(t, err, "missing.pem")
+}
@@ -0,0 +1,267 @@
+// Package clientgen generates the wire types of the update check protocol for
+// client languages from the Go models, so client teams use types that match
+// the server instead of porting UpdateCheckResponse by hand. Types are read
+// by reflection, which gives the JSON names and optionality exactly as
+// encoding/json sees them, and their doc comments are read from the models
+// package source. The generated files are committed under clients/ and a
+// test fails when they no longer match the models.
+package clientgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+	"updater/internal/models"
+)
+
+// Roots are the types of the client protocol: update checks, batch checks,
+// latest-version lookups, checksum verification and error bodies. Types they
+// reference are generated too.
+var Roots = []reflect.Type{
+	reflect.TypeFor[models.UpdateCheckRequest](),
+	reflect.TypeFor[models.UpdateCheckResponse](),
+	reflect.TypeFor[models.BatchUpdateCheckRequest](),
+	reflect.TypeFor[models.BatchUpdateCheckResponse](),
+	reflect.TypeFor[models.LatestVersionResponse](),
+	reflect.TypeFor[models.VerifyChecksumRequest](),
+	reflect.TypeFor[models.VerifyChecksumResponse](),
+	reflect.TypeFor[models.ErrorResponse](),
+}
+
+// Languages maps each supported language to the file it is written to,
+// relative to the output directory, and its generator.
+var Languages = []Language{
+	{Name: "typescript", Path: "typescript/models.ts", generate: generateTypeScript},
+	{Name: "python", Path: "python/updater_models.py", generate: generatePython},
+	{Name: "rust", Path: "rust/models.rs", generate: generateRust},
+}
+
+// Language is a target language of the generator.
+type Language struct {
+	Name     string
+	Path     string
+	generate func(types []*Type) string
+}
+
+// Type is a struct of the protocol.
+type Type struct {
+	Name   string
+	Doc    string
+	Fields []Field
+}
+
+// Field is a JSON member of a Type.
+type Field struct {
+	JSONName string
+	Doc      string
+	Kind     Kind
+	Optional bool // Omitted when empty (omitempty)
+	Nullable bool // Encoded as null when unset (a pointer without omitempty)
+}
+
+// Kind is the JSON shape of a field's value.
+type Kind struct {
+	Scalar string // "string", "bool", "int", "float" or "time"; empty for the other kinds
+	Ref    string // Name of a generated Type
+	Elem   *Kind  // Element of an array, or value of a string-keyed map
+	Map    bool   // Elem is a map value rather than an array element
+}
+
+// Generate renders the protocol types in lang, reading doc comments from the
+// models package source in modelsDir.
+func Generate(lang Language, modelsDir string) ([]byte, error) {
+	types, err := Collect(modelsDir)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(lang.generate(types)), nil
+}
+
+// Collect returns the root types and every struct they reference, each after
+// the types it references, so languages that need definitions before use can
+// emit them in order.
+func Collect(modelsDir string) ([]*Type, error) {
+	docs, err := readDocs(modelsDir)
+	if err != nil {
+		return nil, err
+	}
+	c := &collector{docs: docs, seen: make(map[reflect.Type]bool)}
+	for _, root := range Roots {
+		if err := c.visit(root); err != nil {
+			return nil, err
+		}
+	}
+	return c.types, nil
+}
+
+type collector struct {
+	docs  map[string]string // "Type" and "Type.Field" to their doc comments
+	seen  map[reflect.Type]bool
+	types []*Type
+}
+
+func (c *collector) visit(t reflect.Type) error {
+	if c.seen[t] {
+		return nil
+	}
+	c.seen[t] = true
+	typ := &Type{Name: t.Name(), Doc: c.docs[t.Name()]}
+	if err := c.addFields(typ, t); err != nil {
+		return err
+	}
+	c.types = append(c.types, typ)
+	return nil
+}
+
+// addFields adds the JSON members of struct t to typ, flattening embedded
+// structs as encoding/json does.
+func (c *collector) addFields(typ *Type, t reflect.Type) error {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			if err := c.addFields(typ, f.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		kind, err := c.kind(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+		optional := strings.Contains(","+opts+",", ",omitempty,")
+		typ.Fields = append(typ.Fields, Field{
+			JSONName: name,
+			Doc:      c.docs[t.Name()+"."+f.Name],
+			Kind:     kind,
+			Optional: optional,
+			Nullable: f.Type.Kind() == reflect.Pointer && !optional,
+		})
+	}
+	return nil
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func (c *collector) kind(t reflect.Type) (Kind, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return Kind{Scalar: "time"}, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return Kind{Scalar: "string"}, nil
+	case reflect.Bool:
+		return Kind{Scalar: "bool"}, nil
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint32:
+		return Kind{Scalar: "int"}, nil
+	case reflect.Float32, reflect.Float64:
+		return Kind{Scalar: "float"}, nil
+	case reflect.Slice:
+		elem, err := c.kind(t.Elem())
+		if err != nil {
+			return Kind{}, err
+		}
+		return Kind{Elem: &elem}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return Kind{}, fmt.Errorf("unsupported map key %s", t.Key())
+		}
+		elem, err := c.kind(t.Elem())
+		if err != nil {
+			return Kind{}, err
+		}
+		return Kind{Elem: &elem, Map: true}, nil
+	case reflect.Struct:
+		if err := c.visit(t); err != nil {
+			return Kind{}, err
+		}
+		return Kind{Ref: t.Name()}, nil
+	}
+	return Kind{}, fmt.Errorf("unsupported type %s", t)
+}
+
+// readDocs returns the doc comments of the types and struct fields declared
+// in the Go files of dir. A field's doc is its trailing line comment, or the
+// comment above it.
+func readDocs(dir string) (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				docs[ts.Name.Name] = commentText(doc)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					text := commentText(field.Comment)
+					if text == "" {
+						text = commentText(field.Doc)
+					}
+					for _, name := range field.Names {
+						docs[ts.Name.Name+"."+name.Name] = text
+					}
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.TrimSpace(group.Text())
+}
+
+// header is the first line of every generated file, in the form Go tools
+// recognize as generated code.
+const header = "Code generated by clientgen from internal/models. DO NOT EDIT."
+
+// docLines splits a doc comment into lines for a language's comment syntax.
+func docLines(doc string) []string {
+	if doc == "" {
+		return nil
+	}
+	return strings.Split(doc, "\n")
+}
@@ -0,0 +1,55 @@
+package clientgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	modelsDir  = "../models"
+	clientsDir = "../../clients"
+)
+
+func TestGenerate_MatchesCommittedClients(t *testing.T) {
+	for _, lang := range Languages {
+		t.Run(lang.Name, func(t *testing.T) {
+			got, err := Generate(lang, modelsDir)
+			require.NoError(t, err)
+			want, err := os.ReadFile(filepath.Join(clientsDir, lang.Path))
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got), "%s is out of date: run make clients-generate", lang.Path)
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	types, err := Collect(modelsDir)
+	require.NoError(t, err)
+
+	index := make(map[string]int)
+	for i, typ := range types {
+		index[typ.Name] = i
+	}
+
+	t.Run("referenced types come first", func(t *testing.T) {
+		require.Contains(t, index, "BatchUpdateCheckResult")
+		assert.Less(t, index["BatchUpdateCheckResult"], index["BatchUpdateCheckResponse"])
+	})
+
+	t.Run("fields follow json tags", func(t *testing.T) {
+		fields := make(map[string]Field)
+		for _, f := range types[index["UpdateCheckResponse"]].Fields {
+			fields[f.JSONName] = f
+		}
+		assert.False(t, fields["update_available"].Optional)
+		assert.Equal(t, "bool", fields["update_available"].Kind.Scalar)
+		assert.True(t, fields["latest_version"].Optional)
+		assert.Equal(t, "time", fields["release_date"].Kind.Scalar)
+		assert.True(t, fields["metadata"].Kind.Map)
+		assert.Equal(t, "Primary decision flag", fields["update_available"].Doc)
+	})
+}
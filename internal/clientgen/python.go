@@ -0,0 +1,71 @@
+package clientgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// generatePython renders the types as TypedDicts, so a decoded JSON body is
+// used as it is. Optional members are NotRequired, which needs Python 3.11,
+// and timestamps are RFC 3339 strings.
+func generatePython(types []*Type) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", header)
+	b.WriteString("from typing import NotRequired, TypedDict\n")
+	for _, t := range types {
+		b.WriteString("\n\n")
+		fmt.Fprintf(&b, "class %s(TypedDict):\n", t.Name)
+		if lines := docLines(t.Doc); len(lines) > 0 {
+			writePythonDocstring(&b, lines)
+		}
+		if len(t.Fields) == 0 {
+			b.WriteString("    pass\n")
+		}
+		for _, f := range t.Fields {
+			typ := pyType(f.Kind)
+			if f.Nullable {
+				typ += " | None"
+			}
+			if f.Optional {
+				typ = "NotRequired[" + typ + "]"
+			}
+			for _, line := range docLines(f.Doc) {
+				fmt.Fprintf(&b, "    %s\n", strings.TrimRight("# "+line, " "))
+			}
+			fmt.Fprintf(&b, "    %s: %s\n", f.JSONName, typ)
+		}
+	}
+	return b.String()
+}
+
+func pyType(k Kind) string {
+	switch {
+	case k.Ref != "":
+		return k.Ref
+	case k.Map:
+		return "dict[str, " + pyType(*k.Elem) + "]"
+	case k.Elem != nil:
+		return "list[" + pyType(*k.Elem) + "]"
+	}
+	switch k.Scalar {
+	case "bool":
+		return "bool"
+	case "int":
+		return "int"
+	case "float":
+		return "float"
+	}
+	return "str"
+}
+
+func writePythonDocstring(b *strings.Builder, lines []string) {
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "    \"\"\"%s\"\"\"\n\n", lines[0])
+		return
+	}
+	fmt.Fprintf(b, "    \"\"\"%s\n", lines[0])
+	for _, line := range lines[1:] {
+		fmt.Fprintf(b, "%s\n", strings.TrimRight("    "+line, " "))
+	}
+	b.WriteString("    \"\"\"\n\n")
+}
@@ -0,0 +1,76 @@
+package clientgen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// rustKeywords are the member names that must be raw identifiers in Rust.
+var rustKeywords = []string{
+	"as", "async", "await", "break", "const", "continue", "crate", "dyn", "else",
+	"enum", "extern", "false", "fn", "for", "if", "impl", "in", "let", "loop",
+	"match", "mod", "move", "mut", "pub", "ref", "return", "static", "struct",
+	"super", "trait", "true", "type", "unsafe", "use", "where", "while",
+}
+
+// generateRust renders the types as serde structs. Optional and nullable
+// members are Options, members missing from a body take their defaults, and
+// timestamps are RFC 3339 strings.
+func generateRust(types []*Type) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s\n\n", header)
+	b.WriteString("use serde::{Deserialize, Serialize};\n")
+	b.WriteString("use std::collections::HashMap;\n")
+	for _, t := range types {
+		b.WriteString("\n")
+		for _, line := range docLines(t.Doc) {
+			fmt.Fprintf(&b, "%s\n", strings.TrimRight("/// "+line, " "))
+		}
+		b.WriteString("#[derive(Debug, Clone, PartialEq, Default, Serialize, Deserialize)]\n")
+		b.WriteString("#[serde(default)]\n")
+		fmt.Fprintf(&b, "pub struct %s {\n", t.Name)
+		for _, f := range t.Fields {
+			for _, line := range docLines(f.Doc) {
+				fmt.Fprintf(&b, "    %s\n", strings.TrimRight("/// "+line, " "))
+			}
+			typ := rustType(f.Kind)
+			if f.Optional || f.Nullable {
+				typ = "Option<" + typ + ">"
+			}
+			if f.Optional {
+				b.WriteString("    #[serde(skip_serializing_if = \"Option::is_none\")]\n")
+			}
+			fmt.Fprintf(&b, "    pub %s: %s,\n", rustIdent(f.JSONName), typ)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func rustType(k Kind) string {
+	switch {
+	case k.Ref != "":
+		return k.Ref
+	case k.Map:
+		return "HashMap<String, " + rustType(*k.Elem) + ">"
+	case k.Elem != nil:
+		return "Vec<" + rustType(*k.Elem) + ">"
+	}
+	switch k.Scalar {
+	case "bool":
+		return "bool"
+	case "int":
+		return "i64"
+	case "float":
+		return "f64"
+	}
+	return "String"
+}
+
+func rustIdent(name string) string {
+	if slices.Contains(rustKeywords, name) {
+		return "r#" + name
+	}
+	return name
+}
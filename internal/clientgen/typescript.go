@@ -0,0 +1,70 @@
+package clientgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// generateTypeScript renders the types as interfaces. Optional members are
+// marked with ?, and timestamps are RFC 3339 strings.
+func generateTypeScript(types []*Type) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s\n", header)
+	for _, t := range types {
+		b.WriteString("\n")
+		writeJSDoc(&b, "", t.Doc)
+		fmt.Fprintf(&b, "export interface %s {\n", t.Name)
+		for _, f := range t.Fields {
+			writeJSDoc(&b, "  ", f.Doc)
+			optional := ""
+			if f.Optional {
+				optional = "?"
+			}
+			typ := tsType(f.Kind)
+			if f.Nullable {
+				typ += " | null"
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", f.JSONName, optional, typ)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func tsType(k Kind) string {
+	switch {
+	case k.Ref != "":
+		return k.Ref
+	case k.Map:
+		return "Record<string, " + tsType(*k.Elem) + ">"
+	case k.Elem != nil:
+		elem := tsType(*k.Elem)
+		if strings.Contains(elem, " ") {
+			return "Array<" + elem + ">"
+		}
+		return elem + "[]"
+	}
+	switch k.Scalar {
+	case "bool":
+		return "boolean"
+	case "int", "float":
+		return "number"
+	}
+	return "string"
+}
+
+func writeJSDoc(b *strings.Builder, indent, doc string) {
+	lines := docLines(doc)
+	if len(lines) == 0 {
+		return
+	}
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s%s\n", indent, strings.TrimRight(" * "+line, " "))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
@@ -0,0 +1,205 @@
+// Package clienttoken issues and verifies anonymous client tokens. A client
+// asks for a challenge, finds a nonce such that SHA-256(challenge + nonce)
+// starts with the challenge's number of zero bits, and trades the solution for
+// a token that the public update check endpoints accept until it expires.
+//
+// Challenges and tokens are signed with HMAC-SHA256, so the only state kept is
+// the set of challenges already redeemed, which stops one solution from
+// minting many tokens. That set is per process: behind a load balancer a
+// solution can be redeemed once on each replica.
+package clienttoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+	"updater/internal/models"
+)
+
+var (
+	// ErrInvalidChallenge means the challenge was not issued by this service,
+	// has expired or has already been redeemed.
+	ErrInvalidChallenge = errors.New("invalid, expired or already redeemed challenge")
+	// ErrInsufficientWork means the nonce does not solve the challenge.
+	ErrInsufficientWork = errors.New("nonce does not solve the challenge")
+	// ErrInvalidToken means the token was not issued by this service or has
+	// expired.
+	ErrInvalidToken = errors.New("invalid or expired client token")
+)
+
+// Signed payload kinds, so a challenge is never accepted as a token.
+const (
+	kindChallenge byte = 'c'
+	kindToken     byte = 't'
+)
+
+// Payload layout: kind (1) | expiry unix seconds (8) | difficulty (1) | random (16).
+const payloadSize = 1 + 8 + 1 + 16
+
+// pruneInterval bounds how often expired redeemed challenges are dropped.
+const pruneInterval = time.Minute
+
+// Issuer hands out challenges and tokens and verifies them.
+type Issuer struct {
+	key        []byte
+	difficulty int
+	tokenTTL   time.Duration
+	now        func() time.Time
+
+	mu         sync.Mutex
+	redeemed   map[string]time.Time // Challenge to its expiry
+	lastPruned time.Time
+}
+
+// New creates an issuer from cfg, generating a random key when cfg.Secret is
+// empty.
+func New(cfg models.ClientTokenConfig) (*Issuer, error) {
+	key := []byte(cfg.Secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate client token key: %w", err)
+		}
+	}
+	return &Issuer{
+		key:        key,
+		difficulty: cfg.Difficulty,
+		tokenTTL:   cfg.TokenTTL,
+		now:        time.Now,
+		redeemed:   make(map[string]time.Time),
+	}, nil
+}
+
+// Challenge issues a new proof-of-work challenge.
+func (i *Issuer) Challenge() (*models.ClientTokenChallengeResponse, error) {
+	expiresAt := i.now().Add(models.ClientTokenChallengeTTL).Truncate(time.Second)
+	challenge, err := i.sign(kindChallenge, expiresAt, i.difficulty)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ClientTokenChallengeResponse{
+		Challenge:  challenge,
+		Difficulty: i.difficulty,
+		ExpiresAt:  expiresAt.UTC(),
+	}, nil
+}
+
+// Redeem issues a token for a solved challenge. Each challenge can be
+// redeemed once.
+func (i *Issuer) Redeem(challenge, nonce string) (*models.ClientTokenResponse, error) {
+	expiresAt, difficulty, err := i.verify(kindChallenge, challenge)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	if !Solves(challenge, nonce, difficulty) {
+		return nil, ErrInsufficientWork
+	}
+	if !i.markRedeemed(challenge, expiresAt) {
+		return nil, ErrInvalidChallenge
+	}
+
+	tokenExpiresAt := i.now().Add(i.tokenTTL).Truncate(time.Second)
+	token, err := i.sign(kindToken, tokenExpiresAt, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ClientTokenResponse{Token: token, ExpiresAt: tokenExpiresAt.UTC()}, nil
+}
+
+// Verify checks that token was issued by this issuer and has not expired.
+func (i *Issuer) Verify(token string) error {
+	if _, _, err := i.verify(kindToken, token); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// Solves reports whether SHA-256(challenge + nonce) starts with at least
+// difficulty zero bits.
+func Solves(challenge, nonce string, difficulty int) bool {
+	sum := sha256.Sum256([]byte(challenge + nonce))
+	zeros := 0
+	for _, b := range sum {
+		if b != 0 {
+			zeros += bits.LeadingZeros8(b)
+			break
+		}
+		zeros += 8
+	}
+	return zeros >= difficulty
+}
+
+// sign returns base64url(payload) "." base64url(HMAC(payload)).
+func (i *Issuer) sign(kind byte, expiresAt time.Time, difficulty int) (string, error) {
+	payload := make([]byte, payloadSize)
+	payload[0] = kind
+	binary.BigEndian.PutUint64(payload[1:9], uint64(expiresAt.Unix()))
+	payload[9] = byte(difficulty)
+	if _, err := rand.Read(payload[10:]); err != nil {
+		return "", fmt.Errorf("failed to generate client token nonce: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(i.mac(payload)), nil
+}
+
+// verify checks the signature, kind and expiry of a signed value and returns
+// its expiry and difficulty.
+func (i *Issuer) verify(kind byte, value string) (time.Time, int, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(value, ".")
+	if !ok {
+		return time.Time{}, 0, errors.New("malformed")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != payloadSize {
+		return time.Time{}, 0, errors.New("malformed payload")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, i.mac(payload)) {
+		return time.Time{}, 0, errors.New("bad signature")
+	}
+	if payload[0] != kind {
+		return time.Time{}, 0, errors.New("wrong kind")
+	}
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[1:9])), 0)
+	if !i.now().Before(expiresAt) {
+		return time.Time{}, 0, errors.New("expired")
+	}
+	return expiresAt, int(payload[9]), nil
+}
+
+func (i *Issuer) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, i.key)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// markRedeemed records challenge as redeemed, reporting false when it already
+// was. Challenges are forgotten once they expire, since verify rejects them
+// from then on anyway.
+func (i *Issuer) markRedeemed(challenge string, expiresAt time.Time) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := i.now()
+	if now.Sub(i.lastPruned) >= pruneInterval {
+		for c, exp := range i.redeemed {
+			if !now.Before(exp) {
+				delete(i.redeemed, c)
+			}
+		}
+		i.lastPruned = now
+	}
+
+	if _, ok := i.redeemed[challenge]; ok {
+		return false
+	}
+	i.redeemed[challenge] = expiresAt
+	return true
+}
@@ -0,0 +1,119 @@
+package clienttoken
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIssuer(t *testing.T) *Issuer {
+	t.Helper()
+	issuer, err := New(models.ClientTokenConfig{Enabled: true, Difficulty: 8, TokenTTL: time.Hour})
+	require.NoError(t, err)
+	return issuer
+}
+
+// solve finds a nonce for challenge by brute force.
+func solve(t *testing.T, challenge string, difficulty int) string {
+	t.Helper()
+	for n := 0; n < 1<<24; n++ {
+		nonce := strconv.Itoa(n)
+		if Solves(challenge, nonce, difficulty) {
+			return nonce
+		}
+	}
+	t.Fatal("no nonce found")
+	return ""
+}
+
+func TestIssuer_RedeemAndVerify(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	challenge, err := issuer.Challenge()
+	require.NoError(t, err)
+	assert.Equal(t, 8, challenge.Difficulty)
+
+	nonce := solve(t, challenge.Challenge, challenge.Difficulty)
+	token, err := issuer.Redeem(challenge.Challenge, nonce)
+	require.NoError(t, err)
+	assert.NoError(t, issuer.Verify(token.Token))
+
+	_, err = issuer.Redeem(challenge.Challenge, nonce)
+	assert.ErrorIs(t, err, ErrInvalidChallenge, "a challenge is redeemed once")
+}
+
+func TestIssuer_Redeem_Rejects(t *testing.T) {
+	issuer := newTestIssuer(t)
+	challenge, err := issuer.Challenge()
+	require.NoError(t, err)
+
+	// Find a nonce that does not solve the challenge
+	wrong := "x"
+	for Solves(challenge.Challenge, wrong, challenge.Difficulty) {
+		wrong += "x"
+	}
+	_, err = issuer.Redeem(challenge.Challenge, wrong)
+	assert.ErrorIs(t, err, ErrInsufficientWork)
+
+	other := newTestIssuer(t)
+	_, err = other.Redeem(challenge.Challenge, solve(t, challenge.Challenge, challenge.Difficulty))
+	assert.ErrorIs(t, err, ErrInvalidChallenge, "challenges are bound to the issuer's key")
+
+	_, err = issuer.Redeem("not-a-challenge", "1")
+	assert.ErrorIs(t, err, ErrInvalidChallenge)
+}
+
+func TestIssuer_Expiry(t *testing.T) {
+	issuer := newTestIssuer(t)
+	now := time.Now()
+	issuer.now = func() time.Time { return now }
+
+	challenge, err := issuer.Challenge()
+	require.NoError(t, err)
+	nonce := solve(t, challenge.Challenge, challenge.Difficulty)
+	token, err := issuer.Redeem(challenge.Challenge, nonce)
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour + time.Second)
+	assert.ErrorIs(t, issuer.Verify(token.Token), ErrInvalidToken)
+
+	stale, err := issuer.Challenge()
+	require.NoError(t, err)
+	staleNonce := solve(t, stale.Challenge, stale.Difficulty)
+	now = now.Add(models.ClientTokenChallengeTTL)
+	_, err = issuer.Redeem(stale.Challenge, staleNonce)
+	assert.ErrorIs(t, err, ErrInvalidChallenge)
+}
+
+func TestIssuer_Verify_Rejects(t *testing.T) {
+	issuer := newTestIssuer(t)
+	challenge, err := issuer.Challenge()
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, issuer.Verify(""), ErrInvalidToken)
+	assert.ErrorIs(t, issuer.Verify(challenge.Challenge), ErrInvalidToken, "a challenge is not a token")
+
+	token, err := issuer.Redeem(challenge.Challenge, solve(t, challenge.Challenge, challenge.Difficulty))
+	require.NoError(t, err)
+	payload, _, _ := strings.Cut(token.Token, ".")
+	assert.ErrorIs(t, issuer.Verify(payload+".AAAA"), ErrInvalidToken)
+}
+
+func TestNew_Secret(t *testing.T) {
+	cfg := models.ClientTokenConfig{Enabled: true, Secret: strings.Repeat("k", 32), Difficulty: 8, TokenTTL: time.Hour}
+	a, err := New(cfg)
+	require.NoError(t, err)
+	b, err := New(cfg)
+	require.NoError(t, err)
+
+	challenge, err := a.Challenge()
+	require.NoError(t, err)
+	token, err := a.Redeem(challenge.Challenge, solve(t, challenge.Challenge, challenge.Difficulty))
+	require.NoError(t, err)
+	assert.NoError(t, b.Verify(token.Token), "replicas sharing a secret accept each other's tokens")
+}
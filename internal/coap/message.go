@@ -0,0 +1,226 @@
+// Package coap implements a minimal CoAP (RFC 7252) gateway for constrained
+// devices that cannot afford HTTP or TLS handshakes. It supports single
+// GET exchanges over UDP with piggybacked responses; block-wise transfer,
+// observe and DTLS are out of scope.
+package coap
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Message types (RFC 7252 section 3).
+const (
+	TypeConfirmable     uint8 = 0
+	TypeNonConfirmable  uint8 = 1
+	TypeAcknowledgement uint8 = 2
+	TypeReset           uint8 = 3
+)
+
+// Codes are written as class.detail, encoded as class<<5 | detail.
+const (
+	CodeEmpty               uint8 = 0
+	CodeGET                 uint8 = 1
+	CodeContent             uint8 = 2<<5 | 5
+	CodeBadRequest          uint8 = 4<<5 | 0
+	CodeNotFound            uint8 = 4<<5 | 4
+	CodeMethodNotAllowed    uint8 = 4<<5 | 5
+	CodeNotAcceptable       uint8 = 4<<5 | 6
+	CodeUnprocessableEntity uint8 = 4<<5 | 22
+	CodeInternalServerError uint8 = 5<<5 | 0
+	CodeServiceUnavailable  uint8 = 5<<5 | 3
+)
+
+// Option numbers used by the gateway.
+const (
+	OptionURIPath       uint16 = 11
+	OptionContentFormat uint16 = 12
+	OptionURIQuery      uint16 = 15
+	OptionAccept        uint16 = 17
+)
+
+// Content formats from the CoAP Content-Formats registry.
+const (
+	FormatTextPlain uint16 = 0
+	FormatJSON      uint16 = 50
+	FormatCBOR      uint16 = 60
+)
+
+const (
+	version       = 1
+	payloadMarker = 0xff
+	maxTokenLen   = 8
+)
+
+// Option is a single CoAP option. Repeatable options such as Uri-Path appear
+// once per value.
+type Option struct {
+	Number uint16
+	Value  []byte
+}
+
+// Message is a decoded CoAP message.
+type Message struct {
+	Type      uint8
+	Code      uint8
+	MessageID uint16
+	Token     []byte
+	Options   []Option
+	Payload   []byte
+}
+
+// Strings returns every value of a repeatable string option in order.
+func (m *Message) Strings(number uint16) []string {
+	var values []string
+	for _, opt := range m.Options {
+		if opt.Number == number {
+			values = append(values, string(opt.Value))
+		}
+	}
+	return values
+}
+
+// Uint returns the value of a uint option and whether it is present.
+func (m *Message) Uint(number uint16) (uint32, bool) {
+	for _, opt := range m.Options {
+		if opt.Number == number {
+			var v uint32
+			for _, b := range opt.Value {
+				v = v<<8 | uint32(b)
+			}
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// AddUint appends a uint option using the shortest encoding.
+func (m *Message) AddUint(number uint16, v uint32) {
+	var value []byte
+	for v > 0 {
+		value = append([]byte{byte(v)}, value...)
+		v >>= 8
+	}
+	m.Options = append(m.Options, Option{Number: number, Value: value})
+}
+
+var errTruncated = errors.New("coap: message truncated")
+
+// Parse decodes a CoAP message from a datagram.
+func Parse(data []byte) (*Message, error) {
+	if len(data) < 4 {
+		return nil, errTruncated
+	}
+	if data[0]>>6 != version {
+		return nil, fmt.Errorf("coap: unsupported version %d", data[0]>>6)
+	}
+	tkl := int(data[0] & 0x0f)
+	if tkl > maxTokenLen {
+		return nil, fmt.Errorf("coap: invalid token length %d", tkl)
+	}
+	m := &Message{
+		Type:      (data[0] >> 4) & 0x03,
+		Code:      data[1],
+		MessageID: binary.BigEndian.Uint16(data[2:4]),
+	}
+	data = data[4:]
+	if len(data) < tkl {
+		return nil, errTruncated
+	}
+	m.Token = append([]byte(nil), data[:tkl]...)
+	data = data[tkl:]
+
+	var number uint16
+	for len(data) > 0 {
+		if data[0] == payloadMarker {
+			if len(data) == 1 {
+				return nil, errors.New("coap: payload marker without payload")
+			}
+			m.Payload = append([]byte(nil), data[1:]...)
+			break
+		}
+		delta, length := uint32(data[0]>>4), uint32(data[0]&0x0f)
+		data = data[1:]
+		var err error
+		if delta, data, err = extendOptionNibble(delta, data); err != nil {
+			return nil, err
+		}
+		if length, data, err = extendOptionNibble(length, data); err != nil {
+			return nil, err
+		}
+		if uint32(number)+delta > 0xffff {
+			return nil, errors.New("coap: option number overflow")
+		}
+		number += uint16(delta)
+		if uint32(len(data)) < length {
+			return nil, errTruncated
+		}
+		m.Options = append(m.Options, Option{Number: number, Value: append([]byte(nil), data[:length]...)})
+		data = data[length:]
+	}
+	return m, nil
+}
+
+// extendOptionNibble resolves the 13 and 14 escape values of an option delta
+// or length nibble (RFC 7252 section 3.1).
+func extendOptionNibble(n uint32, data []byte) (uint32, []byte, error) {
+	switch n {
+	case 13:
+		if len(data) < 1 {
+			return 0, nil, errTruncated
+		}
+		return uint32(data[0]) + 13, data[1:], nil
+	case 14:
+		if len(data) < 2 {
+			return 0, nil, errTruncated
+		}
+		return uint32(binary.BigEndian.Uint16(data[:2])) + 269, data[2:], nil
+	case 15:
+		return 0, nil, errors.New("coap: reserved option nibble")
+	}
+	return n, data, nil
+}
+
+// Marshal encodes the message. Options are written in ascending number order
+// as the delta encoding requires; repeated options keep their relative order.
+func (m *Message) Marshal() ([]byte, error) {
+	if len(m.Token) > maxTokenLen {
+		return nil, fmt.Errorf("coap: invalid token length %d", len(m.Token))
+	}
+	buf := []byte{version<<6 | m.Type<<4 | uint8(len(m.Token)), m.Code}
+	buf = binary.BigEndian.AppendUint16(buf, m.MessageID)
+	buf = append(buf, m.Token...)
+
+	opts := append([]Option(nil), m.Options...)
+	sort.SliceStable(opts, func(i, j int) bool { return opts[i].Number < opts[j].Number })
+	var prev uint16
+	for _, opt := range opts {
+		delta, deltaExt := optionNibble(uint32(opt.Number - prev))
+		length, lengthExt := optionNibble(uint32(len(opt.Value)))
+		buf = append(buf, delta<<4|length)
+		buf = append(buf, deltaExt...)
+		buf = append(buf, lengthExt...)
+		buf = append(buf, opt.Value...)
+		prev = opt.Number
+	}
+
+	if len(m.Payload) > 0 {
+		buf = append(buf, payloadMarker)
+		buf = append(buf, m.Payload...)
+	}
+	return buf, nil
+}
+
+// optionNibble returns the 4-bit nibble and extended bytes encoding n.
+func optionNibble(n uint32) (byte, []byte) {
+	switch {
+	case n < 13:
+		return byte(n), nil
+	case n < 269:
+		return 13, []byte{byte(n - 13)}
+	default:
+		return 14, binary.BigEndian.AppendUint16(nil, uint16(n-269))
+	}
+}
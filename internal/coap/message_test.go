@@ -0,0 +1,75 @@
+package coap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_RoundTrip(t *testing.T) {
+	longSegment := strings.Repeat("x", 300)
+	msg := &Message{
+		Type:      TypeConfirmable,
+		Code:      CodeGET,
+		MessageID: 0x1234,
+		Token:     []byte{0xca, 0xfe},
+		Options: []Option{
+			{Number: OptionURIQuery, Value: []byte("platform=linux")},
+			{Number: OptionURIPath, Value: []byte("check")},
+			{Number: OptionURIPath, Value: []byte(longSegment)},
+		},
+		Payload: []byte("hello"),
+	}
+	msg.AddUint(OptionAccept, uint32(FormatCBOR))
+
+	data, err := msg.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, TypeConfirmable, parsed.Type)
+	assert.Equal(t, CodeGET, parsed.Code)
+	assert.Equal(t, uint16(0x1234), parsed.MessageID)
+	assert.Equal(t, []byte{0xca, 0xfe}, parsed.Token)
+	assert.Equal(t, []string{"check", longSegment}, parsed.Strings(OptionURIPath))
+	assert.Equal(t, []string{"platform=linux"}, parsed.Strings(OptionURIQuery))
+	accept, ok := parsed.Uint(OptionAccept)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(FormatCBOR), accept)
+	assert.Equal(t, []byte("hello"), parsed.Payload)
+}
+
+func TestParse_KnownEncoding(t *testing.T) {
+	// CON GET, MID 0x7d34, no token, Uri-Path "temperature" (RFC 7252 appendix A style).
+	data := []byte{0x40, 0x01, 0x7d, 0x34, 0xbb}
+	data = append(data, "temperature"...)
+
+	msg, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x7d34), msg.MessageID)
+	assert.Equal(t, []string{"temperature"}, msg.Strings(OptionURIPath))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too short", data: []byte{0x40, 0x01}},
+		{name: "wrong version", data: []byte{0x80, 0x01, 0x00, 0x01}},
+		{name: "token length over 8", data: []byte{0x49, 0x01, 0x00, 0x01}},
+		{name: "token truncated", data: []byte{0x42, 0x01, 0x00, 0x01, 0xaa}},
+		{name: "option value truncated", data: []byte{0x40, 0x01, 0x00, 0x01, 0xb5, 'a'}},
+		{name: "reserved nibble", data: []byte{0x40, 0x01, 0x00, 0x01, 0xf0}},
+		{name: "marker without payload", data: []byte{0x40, 0x01, 0x00, 0x01, 0xff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.data)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package coap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"updater/internal/api/encoding"
+	"updater/internal/models"
+	"updater/internal/update"
+	"updater/internal/workerpool"
+)
+
+// ErrServerClosed is returned by Start and Serve after Shutdown.
+var ErrServerClosed = errors.New("coap: server closed")
+
+const (
+	// maxDatagramSize covers the largest request a constrained device sends;
+	// RFC 7252 recommends messages stay below 1152 bytes.
+	maxDatagramSize = 1500
+
+	// requestTimeout bounds the service call for a single request.
+	requestTimeout = 10 * time.Second
+
+	// DefaultWorkers and DefaultQueueDepth size the pool requests are
+	// handled on when WithWorkers is not given.
+	DefaultWorkers    = 16
+	DefaultQueueDepth = 256
+)
+
+// Server translates CoAP GET requests into update service calls. It exposes
+// two resources:
+//
+//	/check/{app_id}?current_version=...&platform=...&architecture=...
+//	/latest/{app_id}?platform=...&architecture=...
+//
+// Query parameters, validation and release selection are the same as the HTTP
+// endpoints. Responses use the compact field sets from the models package,
+// encoded as CBOR unless the Accept option asks for text/plain or JSON.
+type Server struct {
+	addr    string
+	service update.ServiceInterface
+
+	workers    int
+	queueDepth int
+
+	mu     sync.Mutex
+	conn   net.PacketConn
+	pool   *workerpool.Pool
+	closed bool
+
+	messageID atomic.Uint32
+}
+
+// ServerOption configures optional Server behavior.
+type ServerOption func(*Server)
+
+// WithWorkers sets how many requests are handled at once and how many more
+// may wait for a worker. Datagrams arriving while the queue is full are
+// dropped; confirmable requests are retransmitted by the client.
+func WithWorkers(workers, queueDepth int) ServerOption {
+	return func(s *Server) {
+		s.workers, s.queueDepth = workers, queueDepth
+	}
+}
+
+// NewServer creates a CoAP server that listens on host:port.
+func NewServer(host string, port int, service update.ServiceInterface, opts ...ServerOption) *Server {
+	s := &Server{
+		addr:       net.JoinHostPort(host, fmt.Sprint(port)),
+		service:    service,
+		workers:    DefaultWorkers,
+		queueDepth: DefaultQueueDepth,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start listens on the configured UDP address and serves requests in a
+// blocking call. Returns ErrServerClosed on graceful shutdown.
+func (s *Server) Start() error {
+	conn, err := net.ListenPacket("udp", s.addr)
+	if err != nil {
+		return err
+	}
+	slog.Info("Starting CoAP gateway", "addr", conn.LocalAddr().String())
+	return s.Serve(conn)
+}
+
+// Serve handles datagrams from conn until Shutdown is called. Requests are
+// handled on a worker pool named "coap".
+func (s *Server) Serve(conn net.PacketConn) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		conn.Close()
+		return ErrServerClosed
+	}
+	pool, err := workerpool.New("coap", s.workers, s.queueDepth)
+	if err != nil {
+		s.mu.Unlock()
+		conn.Close()
+		return err
+	}
+	s.conn, s.pool = conn, pool
+	s.mu.Unlock()
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		req, err := Parse(buf[:n])
+		if err != nil {
+			slog.Debug("Dropping malformed CoAP message", "remote", addr.String(), "error", err)
+			continue
+		}
+
+		err = pool.Submit(func(context.Context) { s.respond(conn, addr, req) })
+		if errors.Is(err, workerpool.ErrQueueFull) {
+			slog.Debug("Dropping CoAP message: all workers busy", "remote", addr.String())
+		}
+	}
+}
+
+// Shutdown stops reading new requests and waits for in-flight requests to
+// finish or ctx to expire.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	var err error
+	if s.conn != nil {
+		err = s.conn.Close()
+	}
+	pool := s.pool
+	s.mu.Unlock()
+
+	if pool == nil {
+		return err
+	}
+	if poolErr := pool.Shutdown(ctx); poolErr != nil {
+		return poolErr
+	}
+	return err
+}
+
+func (s *Server) respond(conn net.PacketConn, addr net.Addr, req *Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp := s.Handle(ctx, req)
+	if resp == nil {
+		return
+	}
+	data, err := resp.Marshal()
+	if err != nil {
+		slog.Error("Failed to encode CoAP response", "error", err)
+		return
+	}
+	if _, err := conn.WriteTo(data, addr); err != nil {
+		slog.Debug("Failed to send CoAP response", "remote", addr.String(), "error", err)
+	}
+}
+
+// Handle builds the response to a single request. It returns nil for messages
+// that need no reply (acknowledgements, resets and empty non-confirmable
+// messages).
+func (s *Server) Handle(ctx context.Context, req *Message) *Message {
+	if req.Type == TypeAcknowledgement || req.Type == TypeReset {
+		return nil
+	}
+	if req.Code == CodeEmpty {
+		// An empty confirmable message is a ping; RFC 7252 section 4.3.
+		if req.Type == TypeConfirmable {
+			return &Message{Type: TypeReset, MessageID: req.MessageID}
+		}
+		return nil
+	}
+
+	resp := &Message{Type: TypeAcknowledgement, MessageID: req.MessageID, Token: req.Token}
+	if req.Type == TypeNonConfirmable {
+		resp.Type = TypeNonConfirmable
+		resp.MessageID = uint16(s.messageID.Add(1))
+	}
+
+	if req.Code != CodeGET {
+		return withDiagnostic(resp, CodeMethodNotAllowed, "only GET is supported")
+	}
+
+	format := FormatCBOR
+	if accept, ok := req.Uint(OptionAccept); ok {
+		switch uint16(accept) {
+		case FormatCBOR, FormatTextPlain, FormatJSON:
+			format = uint16(accept)
+		default:
+			return withDiagnostic(resp, CodeNotAcceptable, "supported formats: cbor, text/plain, json")
+		}
+	}
+
+	path := req.Strings(OptionURIPath)
+	if len(path) != 2 || path[1] == "" {
+		return withDiagnostic(resp, CodeNotFound, "resource not found")
+	}
+	query := parseQuery(req.Strings(OptionURIQuery))
+
+	var (
+		body any
+		err  error
+	)
+	switch path[0] {
+	case "check":
+		body, err = s.check(ctx, path[1], query)
+	case "latest":
+		body, err = s.latest(ctx, path[1], query)
+	default:
+		return withDiagnostic(resp, CodeNotFound, "resource not found")
+	}
+	if err != nil {
+		return withServiceError(resp, err)
+	}
+
+	payload, err := encode(body, format)
+	if err != nil {
+		slog.Error("Failed to encode CoAP payload", "error", err)
+		return withDiagnostic(resp, CodeInternalServerError, "internal server error")
+	}
+	resp.Code = CodeContent
+	resp.AddUint(OptionContentFormat, uint32(format))
+	resp.Payload = payload
+	return resp
+}
+
+func (s *Server) check(ctx context.Context, appID string, query map[string]string) (*models.OTACheckResponse, error) {
+	check, err := s.service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID:   appID,
+		CurrentVersion:  query["current_version"],
+		Platform:        query["platform"],
+		Architecture:    query["architecture"],
+		AllowPrerelease: query["allow_prerelease"] == "true",
+		ClientID:        query["client_id"],
+	})
+	if err != nil {
+		return nil, err
+	}
+	return models.NewOTACheckResponse(check, nil, &models.OTACheckRequest{}), nil
+}
+
+func (s *Server) latest(ctx context.Context, appID string, query map[string]string) (*models.LatestVersionResponse, error) {
+	return s.service.GetLatestVersion(ctx, &models.LatestVersionRequest{
+		ApplicationID:   appID,
+		Platform:        query["platform"],
+		Architecture:    query["architecture"],
+		AllowPrerelease: query["allow_prerelease"] == "true",
+	})
+}
+
+// compactResponse is implemented by the model types the gateway returns.
+type compactResponse interface {
+	CompactFields() models.CompactFields
+}
+
+func encode(body any, format uint16) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.Marshal(body)
+	case FormatTextPlain:
+		return body.(compactResponse).CompactFields().MarshalFlat(), nil
+	default:
+		return encoding.Marshal(encoding.MediaTypeCBOR, body.(compactResponse).CompactFields())
+	}
+}
+
+// parseQuery turns Uri-Query options ("key=value") into a map. Later values
+// for the same key win; a key without "=" has an empty value.
+func parseQuery(options []string) map[string]string {
+	query := make(map[string]string, len(options))
+	for _, opt := range options {
+		key, value, _ := strings.Cut(opt, "=")
+		query[key] = value
+	}
+	return query
+}
+
+func withDiagnostic(resp *Message, code uint8, message string) *Message {
+	resp.Code = code
+	resp.Payload = []byte(message)
+	return resp
+}
+
+// withServiceError maps an update service error onto the matching CoAP
+// response code, mirroring the HTTP status the API would return.
+func withServiceError(resp *Message, err error) *Message {
+	var serviceErr *update.ServiceError
+	if !errors.As(err, &serviceErr) {
+		slog.Error("Unexpected error in CoAP handler", "error", err)
+		return withDiagnostic(resp, CodeInternalServerError, "internal server error")
+	}
+
+	code := CodeInternalServerError
+	switch serviceErr.StatusCode {
+	case http.StatusBadRequest:
+		code = CodeBadRequest
+	case http.StatusNotFound:
+		code = CodeNotFound
+	case http.StatusUnprocessableEntity:
+		code = CodeUnprocessableEntity
+	case http.StatusServiceUnavailable:
+		code = CodeServiceUnavailable
+	}
+	return withDiagnostic(resp, code, serviceErr.Message)
+}
@@ -0,0 +1,197 @@
+package coap
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/storage"
+	"updater/internal/update"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("sensor-fw", "Sensor Firmware", []string{"linux"})))
+
+	release := models.NewRelease("sensor-fw", "1.1.0", "linux", "arm", "https://fw.example.com/1.1.0.bin")
+	release.Checksum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	release.ChecksumType = "sha256"
+	release.FileSize = 2048
+	require.NoError(t, store.SaveRelease(ctx, release))
+
+	return NewServer("127.0.0.1", 0, update.NewService(store))
+}
+
+func newGet(path []string, query ...string) *Message {
+	msg := &Message{Type: TypeConfirmable, Code: CodeGET, MessageID: 42, Token: []byte{1, 2, 3}}
+	for _, p := range path {
+		msg.Options = append(msg.Options, Option{Number: OptionURIPath, Value: []byte(p)})
+	}
+	for _, q := range query {
+		msg.Options = append(msg.Options, Option{Number: OptionURIQuery, Value: []byte(q)})
+	}
+	return msg
+}
+
+func TestServer_Handle(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	checkQuery := []string{"current_version=1.0.0", "platform=linux", "architecture=arm"}
+
+	t.Run("check as cbor", func(t *testing.T) {
+		resp := s.Handle(ctx, newGet([]string{"check", "sensor-fw"}, checkQuery...))
+		require.NotNil(t, resp)
+		assert.Equal(t, TypeAcknowledgement, resp.Type)
+		assert.Equal(t, uint16(42), resp.MessageID)
+		assert.Equal(t, []byte{1, 2, 3}, resp.Token)
+		assert.Equal(t, CodeContent, resp.Code)
+		format, _ := resp.Uint(OptionContentFormat)
+		assert.Equal(t, uint32(FormatCBOR), format)
+		assert.Equal(t, byte(0xa0), resp.Payload[0]&0xe0, "payload is a CBOR map")
+	})
+
+	t.Run("check as text", func(t *testing.T) {
+		req := newGet([]string{"check", "sensor-fw"}, checkQuery...)
+		req.AddUint(OptionAccept, uint32(FormatTextPlain))
+		resp := s.Handle(ctx, req)
+		require.Equal(t, CodeContent, resp.Code)
+		assert.Contains(t, string(resp.Payload), "update=true\nversion=1.1.0\n")
+	})
+
+	t.Run("latest as json", func(t *testing.T) {
+		req := newGet([]string{"latest", "sensor-fw"}, "platform=linux", "architecture=arm")
+		req.AddUint(OptionAccept, uint32(FormatJSON))
+		resp := s.Handle(ctx, req)
+		require.Equal(t, CodeContent, resp.Code, string(resp.Payload))
+
+		var body models.LatestVersionResponse
+		require.NoError(t, json.Unmarshal(resp.Payload, &body))
+		assert.Equal(t, "1.1.0", body.Version)
+	})
+
+	t.Run("non-confirmable request gets non-confirmable response", func(t *testing.T) {
+		req := newGet([]string{"check", "sensor-fw"}, checkQuery...)
+		req.Type = TypeNonConfirmable
+		resp := s.Handle(ctx, req)
+		assert.Equal(t, TypeNonConfirmable, resp.Type)
+		assert.Equal(t, []byte{1, 2, 3}, resp.Token)
+	})
+
+	t.Run("ping", func(t *testing.T) {
+		resp := s.Handle(ctx, &Message{Type: TypeConfirmable, Code: CodeEmpty, MessageID: 7})
+		require.NotNil(t, resp)
+		assert.Equal(t, TypeReset, resp.Type)
+		assert.Equal(t, uint16(7), resp.MessageID)
+	})
+
+	t.Run("acknowledgement is ignored", func(t *testing.T) {
+		assert.Nil(t, s.Handle(ctx, &Message{Type: TypeAcknowledgement, MessageID: 7}))
+	})
+
+	tests := []struct {
+		name string
+		req  func() *Message
+		code uint8
+	}{
+		{
+			name: "unknown application",
+			req:  func() *Message { return newGet([]string{"check", "missing"}, checkQuery...) },
+			code: CodeNotFound,
+		},
+		{
+			name: "invalid version",
+			req: func() *Message {
+				return newGet([]string{"check", "sensor-fw"}, "current_version=abc", "platform=linux", "architecture=arm")
+			},
+			code: CodeUnprocessableEntity,
+		},
+		{
+			name: "unsupported platform",
+			req: func() *Message {
+				return newGet([]string{"check", "sensor-fw"}, "current_version=1.0.0", "platform=windows", "architecture=arm")
+			},
+			code: CodeBadRequest,
+		},
+		{
+			name: "unknown resource",
+			req:  func() *Message { return newGet([]string{"releases", "sensor-fw"}) },
+			code: CodeNotFound,
+		},
+		{
+			name: "method not allowed",
+			req: func() *Message {
+				req := newGet([]string{"check", "sensor-fw"}, checkQuery...)
+				req.Code = 2 // POST
+				return req
+			},
+			code: CodeMethodNotAllowed,
+		},
+		{
+			name: "unsupported accept",
+			req: func() *Message {
+				req := newGet([]string{"check", "sensor-fw"}, checkQuery...)
+				req.AddUint(OptionAccept, 41) // application/xml
+				return req
+			},
+			code: CodeNotAcceptable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := s.Handle(ctx, tt.req())
+			require.NotNil(t, resp)
+			assert.Equal(t, tt.code, resp.Code, string(resp.Payload))
+			assert.NotEmpty(t, resp.Payload, "diagnostic payload")
+		})
+	}
+}
+
+func TestServer_Serve(t *testing.T) {
+	s := newTestServer(t)
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(conn) }()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	req, err := newGet([]string{"latest", "sensor-fw"}, "platform=linux", "architecture=arm").Marshal()
+	require.NoError(t, err)
+	_, err = client.Write(req)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, maxDatagramSize)
+	n, err := client.Read(buf)
+	require.NoError(t, err)
+
+	resp, err := Parse(buf[:n])
+	require.NoError(t, err)
+	assert.Equal(t, CodeContent, resp.Code)
+	assert.Equal(t, uint16(42), resp.MessageID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, s.Shutdown(ctx))
+	assert.ErrorIs(t, <-done, ErrServerClosed)
+}
+
+func TestServer_ServeRejectsInvalidPool(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	s := NewServer("127.0.0.1", 0, update.NewService(store), WithWorkers(0, 1))
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	assert.ErrorContains(t, s.Serve(conn), "size must be at least 1")
+}
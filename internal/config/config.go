@@ -15,25 +15,57 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// Load loads configuration from file and environment variables
+// Environment variables holding a whole config document, for platforms that
+// cannot mount a config file. At most one of them may be set.
+const (
+	configJSONEnv = "UPDATER_CONFIG_JSON"
+	configYAMLEnv = "UPDATER_CONFIG_YAML"
+)
+
+// Load loads configuration from file and environment variables. Later sources
+// override earlier ones: defaults, the config file, a config document in
+// UPDATER_CONFIG_JSON or UPDATER_CONFIG_YAML, path-named variables such as
+// UPDATER_SERVER_PORT, then the short variables such as UPDATER_PORT.
 func Load(configPath string) (*models.Config, error) {
+	config, err := LoadUnvalidated(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the final configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// LoadUnvalidated merges configuration like Load but does not validate the
+// result, so that an invalid configuration can still be inspected.
+func LoadUnvalidated(configPath string) (*models.Config, error) {
 	// Start with default configuration
 	config := models.NewDefaultConfig()
 
 	// Load from file if provided and exists
+	if configPath == "" && os.Getenv(profileEnv) != "" {
+		return nil, fmt.Errorf("%s is set but there is no config file to define profiles", profileEnv)
+	}
 	if configPath != "" {
 		if err := loadFromFile(config, configPath); err != nil {
 			return nil, fmt.Errorf("failed to load config from file: %w", err)
 		}
 	}
 
-	// Override with environment variables
-	loadFromEnvironment(config)
+	// Overlay a config document from the environment
+	if err := loadFromEnvironmentDocument(config); err != nil {
+		return nil, err
+	}
 
-	// Validate the final configuration
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+	// Override with environment variables
+	if err := loadFromEnvironmentPaths(config); err != nil {
+		return nil, fmt.Errorf("invalid environment variable: %w", err)
 	}
+	loadFromEnvironment(config)
 
 	return config, nil
 }
@@ -44,9 +76,8 @@ type deprecatedConfig struct {
 		CORS interface{} `yaml:"cors"`
 	} `yaml:"server"`
 	Security struct {
-		JWTSecret      string      `yaml:"jwt_secret"`
-		TrustedProxies interface{} `yaml:"trusted_proxies"`
-		RateLimit      interface{} `yaml:"rate_limit"`
+		JWTSecret string      `yaml:"jwt_secret"`
+		RateLimit interface{} `yaml:"rate_limit"`
 	} `yaml:"security"`
 	Observability struct {
 		ServiceVersion string `yaml:"service_version"`
@@ -67,9 +98,6 @@ func warnDeprecatedKeys(data []byte) {
 	if dep.Security.JWTSecret != "" {
 		slog.Warn("Config key is no longer used and can be removed from your config file.", "config_key", "security.jwt_secret")
 	}
-	if dep.Security.TrustedProxies != nil {
-		slog.Warn("Config key is no longer supported; configure proxy trust at your reverse proxy. See docs/reverse-proxy.md.", "config_key", "security.trusted_proxies")
-	}
 	if dep.Security.RateLimit != nil {
 		slog.Warn("Config key is no longer supported; configure rate limiting at your reverse proxy. See docs/reverse-proxy.md.", "config_key", "security.rate_limit")
 	}
@@ -81,22 +109,48 @@ func warnDeprecatedKeys(data []byte) {
 	}
 }
 
-// loadFromFile loads configuration from a YAML file
+// loadFromFile loads configuration from a YAML file, its includes and the
+// profile named by UPDATER_PROFILE
 func loadFromFile(config *models.Config, filePath string) error {
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return fmt.Errorf("config file not found: %s", filePath)
+	doc, err := loadConfigDocument(filePath)
+	if err != nil {
+		return err
 	}
-	data, err := os.ReadFile(filePath)
+	doc.warn()
+	body, err := doc.resolve(os.Getenv(profileEnv))
 	if err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+		return err
 	}
-	warnDeprecatedKeys(data)
-	if err := yaml.Unmarshal(data, config); err != nil {
+	if err := body.Decode(config); err != nil {
 		return fmt.Errorf("failed to parse YAML config: %w", err)
 	}
 	return nil
 }
 
+// loadFromEnvironmentDocument overlays the config document in
+// UPDATER_CONFIG_JSON or UPDATER_CONFIG_YAML. Keys it leaves out keep their
+// values from the defaults and config file. JSON is decoded as YAML, of which
+// it is a subset, so both use the config file's key names.
+func loadFromEnvironmentDocument(config *models.Config) error {
+	jsonDoc, yamlDoc := os.Getenv(configJSONEnv), os.Getenv(configYAMLEnv)
+	name, doc := configJSONEnv, jsonDoc
+	switch {
+	case jsonDoc != "" && yamlDoc != "":
+		return fmt.Errorf("set only one of %s and %s", configJSONEnv, configYAMLEnv)
+	case yamlDoc != "":
+		name, doc = configYAMLEnv, yamlDoc
+	case jsonDoc == "":
+		return nil
+	}
+
+	warnDeprecatedKeys([]byte(doc))
+	warnUnknownKeys([]byte(doc), name)
+	if err := yaml.Unmarshal([]byte(doc), config); err != nil {
+		return fmt.Errorf("failed to parse config from %s: %w", name, err)
+	}
+	return nil
+}
+
 // loadFromEnvironment loads configuration from environment variables
 func loadFromEnvironment(config *models.Config) {
 	// Server configuration
@@ -146,6 +200,16 @@ func loadFromEnvironment(config *models.Config) {
 		config.Server.TLSKeyFile = keyFile
 	}
 
+	if concurrency := os.Getenv("UPDATER_CONCURRENCY_ENABLED"); concurrency != "" {
+		config.Server.Concurrency.Enabled = strings.ToLower(concurrency) == "true"
+	}
+
+	if timeout := os.Getenv("UPDATER_CONCURRENCY_QUEUE_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			config.Server.Concurrency.QueueTimeout = d
+		}
+	}
+
 	// Storage configuration
 	if storageType := os.Getenv("UPDATER_STORAGE_TYPE"); storageType != "" {
 		config.Storage.Type = storageType
@@ -185,6 +249,71 @@ func loadFromEnvironment(config *models.Config) {
 		config.Security.BootstrapKey = bk
 	}
 
+	if deny := os.Getenv("UPDATER_DENY_PRIVATE_DOWNLOAD_URLS"); deny != "" {
+		config.Security.DownloadURLs.DenyPrivateNetworks = strings.ToLower(deny) == "true"
+	}
+
+	if hosts := os.Getenv("UPDATER_ALLOWED_DOWNLOAD_HOSTS"); hosts != "" {
+		config.Security.DownloadURLs.AllowedHosts = nil
+		for _, host := range strings.Split(hosts, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				config.Security.DownloadURLs.AllowedHosts = append(config.Security.DownloadURLs.AllowedHosts, host)
+			}
+		}
+	}
+
+	if reject := os.Getenv("UPDATER_REJECT_WEAK_CHECKSUMS"); reject != "" {
+		config.Security.RejectWeakChecksums = strings.ToLower(reject) == "true"
+	}
+
+	if keyFile := os.Getenv("UPDATER_PGP_PUBLIC_KEY_FILE"); keyFile != "" {
+		config.Security.PGPPublicKeyFile = keyFile
+	}
+
+	if clientTokens := os.Getenv("UPDATER_CLIENT_TOKENS_ENABLED"); clientTokens != "" {
+		config.Security.ClientTokens.Enabled = strings.ToLower(clientTokens) == "true"
+	}
+
+	if secret := os.Getenv("UPDATER_CLIENT_TOKENS_SECRET"); secret != "" {
+		config.Security.ClientTokens.Secret = secret
+	}
+
+	if difficulty := os.Getenv("UPDATER_CLIENT_TOKENS_DIFFICULTY"); difficulty != "" {
+		if bits, err := strconv.Atoi(difficulty); err == nil {
+			config.Security.ClientTokens.Difficulty = bits
+		}
+	}
+
+	if anomalies := os.Getenv("UPDATER_ANOMALY_DETECTION_ENABLED"); anomalies != "" {
+		config.Security.AnomalyDetection.Enabled = strings.ToLower(anomalies) == "true"
+	}
+
+	if honeypots := os.Getenv("UPDATER_HONEYPOT_APP_IDS"); honeypots != "" {
+		config.Security.AnomalyDetection.HoneypotAppIDs = nil
+		for _, id := range strings.Split(honeypots, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				config.Security.AnomalyDetection.HoneypotAppIDs = append(config.Security.AnomalyDetection.HoneypotAppIDs, id)
+			}
+		}
+	}
+
+	// Entitlements configuration
+	if provider := os.Getenv("UPDATER_ENTITLEMENTS_PROVIDER"); provider != "" {
+		config.Entitlements.Provider = provider
+	}
+
+	if secret := os.Getenv("UPDATER_ENTITLEMENTS_JWT_SECRET"); secret != "" {
+		config.Entitlements.JWT.Secret = secret
+	}
+
+	if keyFile := os.Getenv("UPDATER_ENTITLEMENTS_JWT_PUBLIC_KEY_FILE"); keyFile != "" {
+		config.Entitlements.JWT.PublicKeyFile = keyFile
+	}
+
+	if url := os.Getenv("UPDATER_ENTITLEMENTS_HTTP_URL"); url != "" {
+		config.Entitlements.HTTP.URL = url
+	}
+
 	// Logging configuration
 	if level := os.Getenv("UPDATER_LOG_LEVEL"); level != "" {
 		config.Logging.Level = level
@@ -216,6 +345,54 @@ func loadFromEnvironment(config *models.Config) {
 			config.Metrics.Port = p
 		}
 	}
+
+	// Decision log configuration
+	if decisionLog := os.Getenv("UPDATER_DECISION_LOG_ENABLED"); decisionLog != "" {
+		config.Observability.DecisionLog.Enabled = strings.ToLower(decisionLog) == "true"
+	}
+
+	if size := os.Getenv("UPDATER_DECISION_LOG_SIZE"); size != "" {
+		if n, err := strconv.Atoi(size); err == nil {
+			config.Observability.DecisionLog.Size = n
+		}
+	}
+
+	// Health history configuration
+	if history := os.Getenv("UPDATER_HEALTH_HISTORY_ENABLED"); history != "" {
+		config.Observability.HealthHistory.Enabled = strings.ToLower(history) == "true"
+	}
+
+	if interval := os.Getenv("UPDATER_HEALTH_HISTORY_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.Observability.HealthHistory.Interval = d
+		}
+	}
+
+	if retention := os.Getenv("UPDATER_HEALTH_HISTORY_RETENTION"); retention != "" {
+		if d, err := time.ParseDuration(retention); err == nil {
+			config.Observability.HealthHistory.Retention = d
+		}
+	}
+
+	// Status page configuration
+	if statusPage := os.Getenv("UPDATER_STATUS_PAGE_ENABLED"); statusPage != "" {
+		config.Observability.StatusPage.Enabled = strings.ToLower(statusPage) == "true"
+	}
+
+	// CoAP gateway configuration
+	if coap := os.Getenv("UPDATER_COAP_ENABLED"); coap != "" {
+		config.CoAP.Enabled = strings.ToLower(coap) == "true"
+	}
+
+	if host := os.Getenv("UPDATER_COAP_HOST"); host != "" {
+		config.CoAP.Host = host
+	}
+
+	if port := os.Getenv("UPDATER_COAP_PORT"); port != "" {
+		if p, err := strconv.Atoi(port); err == nil {
+			config.CoAP.Port = p
+		}
+	}
 }
 
 // CheckResult holds the outcome of a single named validation check.
@@ -228,7 +405,7 @@ type CheckResult struct {
 // ValidateConfig loads configuration from configPath (or defaults if empty) and runs
 // all validation checks, returning a structured list of per-section results. Unlike
 // Load, it does not fail fast — every check runs regardless of prior failures.
-// Intended for use with the --validate CLI flag.
+// Intended for use with the --validate CLI flag and `updater config validate`.
 func ValidateConfig(configPath string) []CheckResult {
 	var results []CheckResult
 
@@ -247,14 +424,28 @@ func ValidateConfig(configPath string) []CheckResult {
 			return results // Cannot validate without a parseable config.
 		}
 	}
+	if err := loadFromEnvironmentDocument(cfg); err != nil {
+		add("config.load", err)
+		return results
+	}
+	if err := loadFromEnvironmentPaths(cfg); err != nil {
+		add("config.load", err)
+		return results
+	}
 	loadFromEnvironment(cfg)
 
+	add("config.unknown-keys", CheckUnknownKeys(configPath))
 	add("config.server", cfg.Server.Validate())
 	add("config.storage", cfg.Storage.Validate())
 	add("config.security", cfg.Security.Validate())
 	add("config.logging", cfg.Logging.Validate())
 	add("config.metrics", cfg.Metrics.Validate())
 	add("config.observability", cfg.Observability.Validate())
+	add("config.coap", cfg.CoAP.Validate())
+	add("config.auto-fill", cfg.AutoFill.Validate())
+	add("config.artifacts", cfg.Artifacts.Validate())
+	add("config.notes-drafts", cfg.NotesDrafts.Validate())
+	add("config.client-bundle", cfg.ClientBundle.Validate())
 
 	// Cross-field: server and metrics ports must not conflict.
 	var crossErrs []error
@@ -263,6 +454,11 @@ func ValidateConfig(configPath string) []CheckResult {
 			"server port and metrics port must not be the same (both are %d)", cfg.Server.Port,
 		))
 	}
+	if admin := cfg.Server.AdminListener; cfg.Metrics.Enabled && admin.Enabled && admin.Socket == "" && admin.Port == cfg.Metrics.Port {
+		crossErrs = append(crossErrs, fmt.Errorf(
+			"admin listener port and metrics port must not be the same (both are %d)", admin.Port,
+		))
+	}
 	add("config.cross-field", errors.Join(crossErrs...))
 
 	add("runtime.tls", validateTLS(cfg))
@@ -142,6 +142,28 @@ func TestLoad_WithEnvironmentVariables(t *testing.T) {
 		"UPDATER_BOOTSTRAP_KEY":    os.Getenv("UPDATER_BOOTSTRAP_KEY"),
 		"UPDATER_LOG_LEVEL":        os.Getenv("UPDATER_LOG_LEVEL"),
 		"UPDATER_SHUTDOWN_TIMEOUT": os.Getenv("UPDATER_SHUTDOWN_TIMEOUT"),
+		"UPDATER_COAP_ENABLED":     os.Getenv("UPDATER_COAP_ENABLED"),
+		"UPDATER_COAP_PORT":        os.Getenv("UPDATER_COAP_PORT"),
+
+		"UPDATER_DECISION_LOG_ENABLED":       os.Getenv("UPDATER_DECISION_LOG_ENABLED"),
+		"UPDATER_DECISION_LOG_SIZE":          os.Getenv("UPDATER_DECISION_LOG_SIZE"),
+		"UPDATER_HEALTH_HISTORY_ENABLED":     os.Getenv("UPDATER_HEALTH_HISTORY_ENABLED"),
+		"UPDATER_HEALTH_HISTORY_INTERVAL":    os.Getenv("UPDATER_HEALTH_HISTORY_INTERVAL"),
+		"UPDATER_HEALTH_HISTORY_RETENTION":   os.Getenv("UPDATER_HEALTH_HISTORY_RETENTION"),
+		"UPDATER_STATUS_PAGE_ENABLED":        os.Getenv("UPDATER_STATUS_PAGE_ENABLED"),
+		"UPDATER_CONCURRENCY_ENABLED":        os.Getenv("UPDATER_CONCURRENCY_ENABLED"),
+		"UPDATER_CONCURRENCY_QUEUE_TIMEOUT":  os.Getenv("UPDATER_CONCURRENCY_QUEUE_TIMEOUT"),
+		"UPDATER_DENY_PRIVATE_DOWNLOAD_URLS": os.Getenv("UPDATER_DENY_PRIVATE_DOWNLOAD_URLS"),
+		"UPDATER_ALLOWED_DOWNLOAD_HOSTS":     os.Getenv("UPDATER_ALLOWED_DOWNLOAD_HOSTS"),
+		"UPDATER_REJECT_WEAK_CHECKSUMS":      os.Getenv("UPDATER_REJECT_WEAK_CHECKSUMS"),
+		"UPDATER_PGP_PUBLIC_KEY_FILE":        os.Getenv("UPDATER_PGP_PUBLIC_KEY_FILE"),
+		"UPDATER_ENTITLEMENTS_PROVIDER":      os.Getenv("UPDATER_ENTITLEMENTS_PROVIDER"),
+		"UPDATER_ENTITLEMENTS_HTTP_URL":      os.Getenv("UPDATER_ENTITLEMENTS_HTTP_URL"),
+		"UPDATER_CLIENT_TOKENS_ENABLED":      os.Getenv("UPDATER_CLIENT_TOKENS_ENABLED"),
+		"UPDATER_CLIENT_TOKENS_SECRET":       os.Getenv("UPDATER_CLIENT_TOKENS_SECRET"),
+		"UPDATER_CLIENT_TOKENS_DIFFICULTY":   os.Getenv("UPDATER_CLIENT_TOKENS_DIFFICULTY"),
+		"UPDATER_ANOMALY_DETECTION_ENABLED":  os.Getenv("UPDATER_ANOMALY_DETECTION_ENABLED"),
+		"UPDATER_HONEYPOT_APP_IDS":           os.Getenv("UPDATER_HONEYPOT_APP_IDS"),
 	}
 
 	// Clean up after test
@@ -164,6 +186,27 @@ func TestLoad_WithEnvironmentVariables(t *testing.T) {
 	os.Setenv("UPDATER_BOOTSTRAP_KEY", "upd_test-env-bootstrap-key")
 	os.Setenv("UPDATER_LOG_LEVEL", "warn")
 	os.Setenv("UPDATER_SHUTDOWN_TIMEOUT", "45s")
+	os.Setenv("UPDATER_COAP_ENABLED", "true")
+	os.Setenv("UPDATER_COAP_PORT", "5684")
+	os.Setenv("UPDATER_DECISION_LOG_ENABLED", "true")
+	os.Setenv("UPDATER_DECISION_LOG_SIZE", "500")
+	os.Setenv("UPDATER_HEALTH_HISTORY_ENABLED", "true")
+	os.Setenv("UPDATER_HEALTH_HISTORY_INTERVAL", "30s")
+	os.Setenv("UPDATER_HEALTH_HISTORY_RETENTION", "6h")
+	os.Setenv("UPDATER_STATUS_PAGE_ENABLED", "true")
+	os.Setenv("UPDATER_CONCURRENCY_ENABLED", "true")
+	os.Setenv("UPDATER_CONCURRENCY_QUEUE_TIMEOUT", "250ms")
+	os.Setenv("UPDATER_DENY_PRIVATE_DOWNLOAD_URLS", "true")
+	os.Setenv("UPDATER_ALLOWED_DOWNLOAD_HOSTS", "downloads.example.com, *.cdn.example.com")
+	os.Setenv("UPDATER_REJECT_WEAK_CHECKSUMS", "true")
+	os.Setenv("UPDATER_PGP_PUBLIC_KEY_FILE", "/etc/updater/release-key.asc")
+	os.Setenv("UPDATER_ENTITLEMENTS_PROVIDER", "http")
+	os.Setenv("UPDATER_ENTITLEMENTS_HTTP_URL", "https://licensing.example.com/entitlements")
+	os.Setenv("UPDATER_CLIENT_TOKENS_ENABLED", "true")
+	os.Setenv("UPDATER_CLIENT_TOKENS_SECRET", "0123456789abcdef0123456789abcdef")
+	os.Setenv("UPDATER_CLIENT_TOKENS_DIFFICULTY", "16")
+	os.Setenv("UPDATER_ANOMALY_DETECTION_ENABLED", "true")
+	os.Setenv("UPDATER_HONEYPOT_APP_IDS", "internal-tools, legacy-admin")
 
 	tempDir := t.TempDir()
 	configFile := filepath.Join(tempDir, "env_config.yaml")
@@ -198,6 +241,27 @@ logging:
 	assert.True(t, config.Security.EnableAuth)
 	assert.Equal(t, "warn", config.Logging.Level)
 	assert.Equal(t, 45*time.Second, config.Server.ShutdownTimeout)
+	assert.True(t, config.CoAP.Enabled)
+	assert.Equal(t, 5684, config.CoAP.Port)
+	assert.True(t, config.Observability.DecisionLog.Enabled)
+	assert.Equal(t, 500, config.Observability.DecisionLog.Size)
+	assert.True(t, config.Observability.HealthHistory.Enabled)
+	assert.Equal(t, 30*time.Second, config.Observability.HealthHistory.Interval)
+	assert.Equal(t, 6*time.Hour, config.Observability.HealthHistory.Retention)
+	assert.True(t, config.Observability.StatusPage.Enabled)
+	assert.True(t, config.Server.Concurrency.Enabled)
+	assert.Equal(t, 250*time.Millisecond, config.Server.Concurrency.QueueTimeout)
+	assert.True(t, config.Security.DownloadURLs.DenyPrivateNetworks)
+	assert.Equal(t, []string{"downloads.example.com", "*.cdn.example.com"}, config.Security.DownloadURLs.AllowedHosts)
+	assert.True(t, config.Security.RejectWeakChecksums)
+	assert.Equal(t, "/etc/updater/release-key.asc", config.Security.PGPPublicKeyFile)
+	assert.Equal(t, "http", config.Entitlements.Provider)
+	assert.Equal(t, "https://licensing.example.com/entitlements", config.Entitlements.HTTP.URL)
+	assert.True(t, config.Security.ClientTokens.Enabled)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", config.Security.ClientTokens.Secret)
+	assert.Equal(t, 16, config.Security.ClientTokens.Difficulty)
+	assert.True(t, config.Security.AnomalyDetection.Enabled)
+	assert.Equal(t, []string{"internal-tools", "legacy-admin"}, config.Security.AnomalyDetection.HoneypotAppIDs)
 }
 
 func TestLoad_NonExistentFile(t *testing.T) {
@@ -242,6 +306,53 @@ func TestLoad_EmptyConfigFile(t *testing.T) {
 	assert.Contains(t, config.Storage.Path, "updater.db") // Default
 }
 
+func TestLoad_WithConfigDocumentInEnvironment(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.yaml")
+	configContent := `
+server:
+  port: 9000
+  host: "127.0.0.1"
+storage:
+  type: "memory"
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+	t.Setenv("UPDATER_CONFIG_JSON", `{"server": {"port": 9100, "read_timeout": "45s"}, "logging": {"level": "debug"}}`)
+	t.Setenv("UPDATER_LOG_LEVEL", "warn")
+
+	config, err := Load(configFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, 9100, config.Server.Port, "the document overrides the file")
+	assert.Equal(t, "127.0.0.1", config.Server.Host, "keys the document leaves out keep their file values")
+	assert.Equal(t, 45*time.Second, config.Server.ReadTimeout)
+	assert.Equal(t, "memory", config.Storage.Type)
+	assert.Equal(t, "warn", config.Logging.Level, "individual variables override the document")
+}
+
+func TestLoad_WithConfigDocumentInEnvironment_YAML(t *testing.T) {
+	t.Setenv("UPDATER_CONFIG_YAML", "storage:\n  type: memory\nmetrics:\n  enabled: false\n")
+
+	config, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, "memory", config.Storage.Type)
+	assert.False(t, config.Metrics.Enabled)
+}
+
+func TestLoad_WithConfigDocumentInEnvironment_Errors(t *testing.T) {
+	t.Setenv("UPDATER_CONFIG_JSON", `{"server": {"port": 9100}`)
+	_, err := Load("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UPDATER_CONFIG_JSON")
+
+	t.Setenv("UPDATER_CONFIG_JSON", `{}`)
+	t.Setenv("UPDATER_CONFIG_YAML", "server: {}")
+	_, err = Load("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "set only one of")
+}
+
 func TestLoad_WithTLSConfig(t *testing.T) {
 	tempDir := t.TempDir()
 	configFile := filepath.Join(tempDir, "tls_config.yaml")
@@ -360,6 +471,48 @@ logging:
 	assert.Equal(t, "/var/log/updater.log", config.Logging.FilePath)
 }
 
+func TestLoad_WithApplicationTemplates(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "test_config.yaml")
+
+	configContent := `
+storage:
+  type: "memory"
+application_templates:
+  - name: "desktop-app"
+    platforms: ["windows", "darwin"]
+    tags: ["desktop"]
+    group: "Desktop"
+    custom_fields:
+      support_url: "https://support.example.com"
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+	config, err := Load(configFile)
+	require.NoError(t, err)
+	require.Len(t, config.ApplicationTemplates, 1)
+
+	template := config.ApplicationTemplates[0]
+	assert.Equal(t, "desktop-app", template.Name)
+	assert.Equal(t, []string{"windows", "darwin"}, template.Platforms)
+	assert.Equal(t, []string{"desktop"}, template.Tags)
+	assert.Equal(t, "Desktop", template.Group)
+	assert.Equal(t, "https://support.example.com", template.CustomFields["support_url"])
+
+	invalid := filepath.Join(tempDir, "invalid_config.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte(`
+storage:
+  type: "memory"
+application_templates:
+  - name: "desktop-app"
+  - name: "desktop-app"
+`), 0644))
+
+	_, err = Load(invalid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate template name")
+}
+
 func TestValidate_ValidConfig(t *testing.T) {
 	config := &models.Config{
 		Server: models.ServerConfig{
@@ -458,9 +611,9 @@ func TestWarnDeprecatedKeys(t *testing.T) {
 			wantWarn: []string{"security.jwt_secret"},
 		},
 		{
-			name:     "trusted_proxies key warns",
+			name:     "trusted_proxies key does not warn",
 			yaml:     "security:\n  trusted_proxies:\n    - \"10.0.0.1\"\n",
-			wantWarn: []string{"security.trusted_proxies"},
+			wantWarn: nil,
 		},
 		{
 			name:     "cache key warns",
@@ -469,8 +622,8 @@ func TestWarnDeprecatedKeys(t *testing.T) {
 		},
 		{
 			name:     "all deprecated keys warn",
-			yaml:     "server:\n  cors:\n    enabled: true\nsecurity:\n  jwt_secret: \"x\"\n  rate_limit:\n    enabled: true\n  trusted_proxies: []\ncache:\n  enabled: true\n",
-			wantWarn: []string{"server.cors", "security.jwt_secret", "security.rate_limit", "security.trusted_proxies", "cache"},
+			yaml:     "server:\n  cors:\n    enabled: true\nsecurity:\n  jwt_secret: \"x\"\n  rate_limit:\n    enabled: true\ncache:\n  enabled: true\n",
+			wantWarn: []string{"server.cors", "security.jwt_secret", "security.rate_limit", "cache"},
 		},
 	}
 
@@ -702,8 +855,8 @@ func TestValidateConfig_EmptyPath_UsesDefaults(t *testing.T) {
 	}
 	for _, want := range []string{
 		"config.server", "config.storage", "config.security",
-		"config.logging", "config.metrics", "config.observability",
-		"config.cross-field", "runtime.tls", "runtime.log-dir",
+		"config.unknown-keys", "config.logging", "config.metrics", "config.observability",
+		"config.coap", "config.cross-field", "runtime.tls", "runtime.log-dir",
 	} {
 		assert.True(t, names[want], "expected check %q to be present", want)
 	}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"updater/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix starts the environment variable of every config field.
+const envPrefix = "UPDATER_"
+
+// redacted replaces secret values in printed configuration.
+const redacted = "[REDACTED]"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// EnvVar is a config field and the environment variable that sets it.
+type EnvVar struct {
+	Name string // Environment variable, e.g. UPDATER_SERVER_READ_TIMEOUT
+	Path string // YAML path of the field, e.g. server.read_timeout
+}
+
+// EnvironmentVariables lists the variable of every config field that can be
+// set from the environment, in config file order. A field's variable is its
+// YAML path in upper case with dots replaced by underscores. Maps and lists
+// of objects, such as application_templates, have no variable; set them in
+// the config file or UPDATER_CONFIG_YAML.
+func EnvironmentVariables() []EnvVar {
+	var vars []EnvVar
+	walkEnvFields(reflect.ValueOf(models.NewDefaultConfig()).Elem(), "", func(path string, _ reflect.Value) {
+		vars = append(vars, EnvVar{Name: envName(path), Path: path})
+	})
+	return vars
+}
+
+// loadFromEnvironmentPaths sets config fields from their path-named
+// environment variables. Lists are comma-separated. Unlike the short variable
+// names in loadFromEnvironment, a value that does not parse is an error rather
+// than ignored.
+func loadFromEnvironmentPaths(config *models.Config) error {
+	var errs []error
+	walkEnvFields(reflect.ValueOf(config).Elem(), "", func(path string, field reflect.Value) {
+		name := envName(path)
+		if value := os.Getenv(name); value != "" {
+			if err := setField(field, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// walkEnvFields calls fn for every field under v that an environment variable
+// can set, with the field's YAML path.
+func walkEnvFields(v reflect.Value, prefix string, fn func(path string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if !sf.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			walkEnvFields(field, path, fn)
+		case isEnvSettable(field.Type()):
+			fn(path, field)
+		}
+	}
+}
+
+// isEnvSettable reports whether a field of type t can be set from a single
+// environment variable.
+func isEnvSettable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// setField parses value into field.
+func setField(field reflect.Value, value string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Int || field.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case field.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case field.Kind() == reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	}
+	return nil
+}
+
+// envName returns the environment variable for a YAML path.
+func envName(path string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// MarshalRedacted renders a configuration as YAML with secrets replaced by
+// [REDACTED]. Secrets are the string fields the config keeps out of JSON
+// (tagged json:"-") and the password of the database DSN.
+func MarshalRedacted(config *models.Config) ([]byte, error) {
+	// Round-trip through YAML for a deep copy that redaction can modify
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var copied models.Config
+	if err := yaml.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	redactSecrets(reflect.ValueOf(&copied).Elem())
+	copied.Storage.Database.DSN = redactDSN(copied.Storage.Database.DSN)
+	return yaml.Marshal(&copied)
+}
+
+// redactSecrets replaces non-empty secret strings under v.
+func redactSecrets(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			field := v.Field(i)
+			if field.Kind() == reflect.String && t.Field(i).Tag.Get("json") == "-" {
+				if field.String() != "" {
+					field.SetString(redacted)
+				}
+				continue
+			}
+			redactSecrets(field)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redactSecrets(v.Index(i))
+		}
+	}
+}
+
+// dsnPassword matches the password of a key=value DSN.
+var dsnPassword = regexp.MustCompile(`(?i)(password=)('[^']*'|\S+)`)
+
+// redactDSN hides the password in a URL or key=value database DSN. URL
+// passwords are shown as "xxxxx", as url.URL.Redacted does.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_WithPathEnvironmentVariables(t *testing.T) {
+	t.Setenv("UPDATER_STORAGE_TYPE", "memory")
+	t.Setenv("UPDATER_SERVER_HOST", "127.0.0.1")
+	t.Setenv("UPDATER_SERVER_CONCURRENCY_ADMIN_MAX_IN_FLIGHT", "4")
+	t.Setenv("UPDATER_ENTITLEMENTS_HTTP_TIMEOUT", "3s")
+	t.Setenv("UPDATER_OBSERVABILITY_TRACING_SAMPLE_RATE", "0.25")
+	t.Setenv("UPDATER_SECURITY_CLIENT_TOKENS_SECRET", "path-named-secret-of-at-least-32-bytes")
+	t.Setenv("UPDATER_SECURITY_DOWNLOAD_URLS_ALLOWED_HOSTS", "a.example.com, *.cdn.example.com")
+	t.Setenv("UPDATER_LOGGING_LEVEL", "debug")
+	t.Setenv("UPDATER_LOG_LEVEL", "warn")
+
+	config, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "127.0.0.1", config.Server.Host)
+	assert.Equal(t, 4, config.Server.Concurrency.Admin.MaxInFlight)
+	assert.Equal(t, 3*time.Second, config.Entitlements.HTTP.Timeout)
+	assert.Equal(t, 0.25, config.Observability.Tracing.SampleRate)
+	assert.Equal(t, "path-named-secret-of-at-least-32-bytes", config.Security.ClientTokens.Secret)
+	assert.Equal(t, []string{"a.example.com", "*.cdn.example.com"}, config.Security.DownloadURLs.AllowedHosts)
+	assert.Equal(t, "warn", config.Logging.Level, "short variable names win over path names")
+}
+
+func TestLoad_WithInvalidPathEnvironmentVariable(t *testing.T) {
+	t.Setenv("UPDATER_SERVER_READ_TIMEOUT", "soon")
+	t.Setenv("UPDATER_SECURITY_ENABLE_AUTH", "maybe")
+
+	_, err := Load("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UPDATER_SERVER_READ_TIMEOUT")
+	assert.Contains(t, err.Error(), "UPDATER_SECURITY_ENABLE_AUTH")
+}
+
+func TestEnvironmentVariables(t *testing.T) {
+	names := make(map[string]string)
+	for _, v := range EnvironmentVariables() {
+		names[v.Name] = v.Path
+	}
+
+	assert.Equal(t, "server.read_timeout", names["UPDATER_SERVER_READ_TIMEOUT"])
+	assert.Equal(t, "security.bootstrap_key", names["UPDATER_SECURITY_BOOTSTRAP_KEY"])
+	assert.Equal(t, "security.anomaly_detection.honeypot_app_ids", names["UPDATER_SECURITY_ANOMALY_DETECTION_HONEYPOT_APP_IDS"])
+	assert.NotContains(t, names, "UPDATER_STORAGE_OPTIONS", "maps have no variable")
+	assert.NotContains(t, names, "UPDATER_APPLICATION_TEMPLATES", "lists of objects have no variable")
+}
+
+func TestMarshalRedacted(t *testing.T) {
+	config := models.NewDefaultConfig()
+	config.Security.BootstrapKey = "upd_bootstrap"
+	config.Entitlements.JWT.Secret = "jwt-secret"
+	config.Entitlements.Static = []models.StaticLicense{{TokenSHA256: "abc123", Entitlements: []string{"pro"}}}
+	config.Storage.Database.DSN = "host=db user=updater password=hunter2 dbname=updater"
+
+	data, err := MarshalRedacted(config)
+	require.NoError(t, err)
+	out := string(data)
+
+	for _, secret := range []string{"upd_bootstrap", "jwt-secret", "abc123", "hunter2"} {
+		assert.NotContains(t, out, secret)
+	}
+	assert.Contains(t, out, "password=[REDACTED]")
+	assert.Contains(t, out, "- pro", "non-secret fields are kept")
+	assert.Equal(t, "upd_bootstrap", config.Security.BootstrapKey, "the config itself is not modified")
+
+	assert.Equal(t, "postgres://updater:xxxxx@db/updater", redactDSN("postgres://updater:hunter2@db/updater"))
+	assert.Equal(t, "./data/updater.db", redactDSN("./data/updater.db"))
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// profileEnv selects one of the profiles defined in the config file.
+const profileEnv = "UPDATER_PROFILE"
+
+// maxIncludeDepth bounds how deeply config files may include each other.
+const maxIncludeDepth = 10
+
+// Config files can share settings instead of repeating them. A file's
+// include list names files, relative to it, that are merged in first, in
+// order, so the including file overrides what it includes. Its profiles map
+// holds named overlays such as dev, staging and prod; the one named by
+// UPDATER_PROFILE is merged over the result. Mappings merge key by key while
+// lists and scalars replace, so the merge order fully decides the outcome.
+//
+//	include: [base.yaml]
+//	profiles:
+//	  prod:
+//	    security:
+//	      enable_auth: true
+
+// configDocument is a config file with its includes merged in.
+type configDocument struct {
+	body     *yaml.Node            // Mapping of config keys
+	profiles map[string]*yaml.Node // Profile name to mapping of config keys
+	files    []configFileData      // Every file read, in merge order
+}
+
+// configFileData is one file of a configDocument.
+type configFileData struct {
+	path    string
+	data    []byte
+	unknown []string // Unknown keys, as reported by unknownKeysIn
+}
+
+// loadConfigDocument reads a config file and the files it includes.
+func loadConfigDocument(path string) (*configDocument, error) {
+	doc := &configDocument{
+		body:     &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+		profiles: make(map[string]*yaml.Node),
+	}
+	if err := doc.add(path, nil); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// add merges the file at path, after its includes, into the document. stack
+// holds the files including it.
+func (d *configDocument) add(path string, stack []string) error {
+	if slices.Contains(stack, path) {
+		return fmt.Errorf("config include cycle: %s", strings.Join(append(stack, path), " -> "))
+	}
+	if len(stack) >= maxIncludeDepth {
+		return fmt.Errorf("config includes are nested more than %d deep at %s", maxIncludeDepth, path)
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("config file not found: %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse YAML config %s: %w", path, err)
+	}
+	file := configFileData{path: path, data: data}
+	if len(root.Content) == 0 {
+		d.files = append(d.files, file)
+		return nil
+	}
+	body := root.Content[0]
+	if body.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s must contain a mapping", path)
+	}
+
+	// Take the include list and profiles out of the settings
+	var includes []string
+	var profiles *yaml.Node
+	settings := make([]*yaml.Node, 0, len(body.Content))
+	for i := 0; i+1 < len(body.Content); i += 2 {
+		key, value := body.Content[i], body.Content[i+1]
+		switch key.Value {
+		case "include":
+			if err := value.Decode(&includes); err != nil {
+				return fmt.Errorf("%s: include must be a list of file paths", path)
+			}
+		case "profiles":
+			if value.Kind != yaml.MappingNode {
+				return fmt.Errorf("%s: profiles must map profile names to settings", path)
+			}
+			profiles = value
+		default:
+			settings = append(settings, key, value)
+		}
+	}
+	body.Content = settings
+
+	stack = append(slices.Clip(stack), path)
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+		if err := d.add(include, stack); err != nil {
+			return err
+		}
+	}
+
+	file.unknown = unknownKeysIn(body)
+	mergeNodes(d.body, body)
+	if profiles != nil {
+		for i := 0; i+1 < len(profiles.Content); i += 2 {
+			name, profile := profiles.Content[i].Value, profiles.Content[i+1]
+			if profile.Kind != yaml.MappingNode {
+				return fmt.Errorf("%s: profile %q must be a mapping of settings", path, name)
+			}
+			for _, key := range unknownKeysIn(profile) {
+				file.unknown = append(file.unknown, "profiles."+name+"."+key)
+			}
+			if existing, ok := d.profiles[name]; ok {
+				mergeNodes(existing, profile)
+			} else {
+				d.profiles[name] = profile
+			}
+		}
+	}
+	d.files = append(d.files, file)
+	return nil
+}
+
+// resolve returns the document's settings with the named profile, if any,
+// merged over them.
+func (d *configDocument) resolve(profile string) (*yaml.Node, error) {
+	if profile == "" {
+		return d.body, nil
+	}
+	overlay, ok := d.profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %q is not defined; %s names one of the config file's profiles", profile, profileEnv)
+	}
+	mergeNodes(d.body, overlay)
+	return d.body, nil
+}
+
+// unknownKeysError reports the document's unknown keys by file.
+func (d *configDocument) unknownKeysError() error {
+	var errs []error
+	for _, file := range d.files {
+		if len(file.unknown) > 0 {
+			errs = append(errs, fmt.Errorf("%s: unknown keys: %s", file.path, strings.Join(file.unknown, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// warn logs the document's deprecated and unknown keys.
+func (d *configDocument) warn() {
+	for _, file := range d.files {
+		warnDeprecatedKeys(file.data)
+		for _, key := range file.unknown {
+			slog.Warn("Unknown config key is ignored; check it for typos", "config_key", key, "source", file.path)
+		}
+	}
+}
+
+// mergeNodes merges mapping src into mapping dst: nested mappings are merged
+// key by key, and any other value in src replaces the one in dst.
+func mergeNodes(dst, src *yaml.Node) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		j := mappingIndex(dst, key.Value)
+		switch {
+		case j < 0:
+			dst.Content = append(dst.Content, key, value)
+		case dst.Content[j+1].Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			mergeNodes(dst.Content[j+1], value)
+		default:
+			dst.Content[j+1] = value
+		}
+	}
+}
+
+// mappingIndex returns the index of key in a mapping node's content, or -1.
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFiles writes name to content files into a temporary directory
+// and returns the directory.
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestLoad_WithIncludesAndProfiles(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"shared/base.yaml": `
+server:
+  port: 9000
+  host: 127.0.0.1
+logging:
+  level: warn
+profiles:
+  prod:
+    logging:
+      level: error
+`,
+		"shared/storage.yaml": `
+storage:
+  type: sqlite
+  path: /var/lib/updater/updater.db
+`,
+		"config.yaml": `
+include:
+  - shared/base.yaml
+  - shared/storage.yaml
+server:
+  port: 8081
+profiles:
+  dev:
+    storage:
+      type: memory
+  prod:
+    server:
+      host: 0.0.0.0
+`,
+	})
+	configFile := filepath.Join(dir, "config.yaml")
+
+	config, err := Load(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, 8081, config.Server.Port, "including file overrides its includes")
+	assert.Equal(t, "127.0.0.1", config.Server.Host, "sibling keys of an override are kept")
+	assert.Equal(t, "warn", config.Logging.Level)
+	assert.Equal(t, "sqlite", config.Storage.Type)
+
+	t.Setenv("UPDATER_PROFILE", "prod")
+	config, err = Load(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, 8081, config.Server.Port)
+	assert.Equal(t, "0.0.0.0", config.Server.Host)
+	assert.Equal(t, "error", config.Logging.Level, "profiles merge across included files")
+	assert.Equal(t, "sqlite", config.Storage.Type)
+
+	t.Setenv("UPDATER_PROFILE", "dev")
+	config, err = Load(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "memory", config.Storage.Type)
+	assert.Equal(t, "warn", config.Logging.Level)
+
+	t.Setenv("UPDATER_PROFILE", "staging")
+	_, err = Load(configFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "staging" is not defined`)
+}
+
+func TestLoad_ProfileWithoutConfigFile(t *testing.T) {
+	t.Setenv("UPDATER_PROFILE", "prod")
+	_, err := Load("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UPDATER_PROFILE is set")
+}
+
+func TestLoad_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"config.yaml": "include: [a.yaml]\n",
+				"a.yaml":      "include: [config.yaml]\n",
+			},
+			wantErr: "config include cycle",
+		},
+		{
+			name:    "missing include",
+			files:   map[string]string{"config.yaml": "include: [missing.yaml]\n"},
+			wantErr: "config file not found",
+		},
+		{
+			name:    "include is not a list",
+			files:   map[string]string{"config.yaml": "include:\n  file: a.yaml\n"},
+			wantErr: "include must be a list of file paths",
+		},
+		{
+			name:    "profile is not a mapping",
+			files:   map[string]string{"config.yaml": "profiles:\n  prod: true\n"},
+			wantErr: `profile "prod" must be a mapping`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigFiles(t, tt.files)
+			_, err := Load(filepath.Join(dir, "config.yaml"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCheckUnknownKeys_IncludesAndProfiles(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"base.yaml": "logging:\n  levle: debug\n",
+		"config.yaml": `include: [base.yaml]
+profiles:
+  prod:
+    server:
+      prot: 80
+`,
+	})
+
+	err := CheckUnknownKeys(filepath.Join(dir, "config.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "base.yaml: unknown keys: logging.levle (line 2)")
+	assert.Contains(t, err.Error(), "config.yaml: unknown keys: profiles.prod.server.prot (line 5)")
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"updater/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// removedKeys are the keys of deprecatedConfig. warnDeprecatedKeys explains
+// them, so they are not also reported as unknown.
+var removedKeys = map[string]bool{
+	"server.cors":                   true,
+	"security.jwt_secret":           true,
+	"security.rate_limit":           true,
+	"observability.service_version": true,
+	"cache":                         true,
+}
+
+// unknownKeys returns the keys of a YAML config document that no config field
+// reads, such as a misspelt "requets_per_minute", as dotted paths with their
+// line numbers. The decoder ignores these keys, so the setting silently keeps
+// its default.
+func unknownKeys(data []byte) ([]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return unknownKeysIn(doc.Content[0]), nil
+}
+
+// unknownKeysIn returns the unknown keys of a mapping of config keys.
+func unknownKeysIn(node *yaml.Node) []string {
+	var unknown []string
+	collectUnknownKeys(node, reflect.TypeOf(models.Config{}), "", &unknown)
+	return unknown
+}
+
+// collectUnknownKeys walks node alongside the type it decodes into.
+func collectUnknownKeys(node *yaml.Node, t reflect.Type, prefix string, unknown *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := make(map[string]reflect.Type, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+			if name == "" {
+				name = strings.ToLower(t.Field(i).Name)
+			}
+			if t.Field(i).IsExported() && name != "-" {
+				fields[name] = t.Field(i).Type
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			path := key.Value
+			if prefix != "" {
+				path = prefix + "." + key.Value
+			}
+			fieldType, ok := fields[key.Value]
+			if !ok {
+				if !removedKeys[path] {
+					*unknown = append(*unknown, fmt.Sprintf("%s (line %d)", path, key.Line))
+				}
+				continue
+			}
+			collectUnknownKeys(node.Content[i+1], fieldType, path, unknown)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			collectUnknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", prefix, i), unknown)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectUnknownKeys(node.Content[i+1], t.Elem(), prefix+"."+node.Content[i].Value, unknown)
+		}
+	}
+}
+
+// warnUnknownKeys logs a warning for each unknown key in a config document.
+func warnUnknownKeys(data []byte, source string) {
+	unknown, err := unknownKeys(data)
+	if err != nil {
+		return
+	}
+	for _, key := range unknown {
+		slog.Warn("Unknown config key is ignored; check it for typos", "config_key", key, "source", source)
+	}
+}
+
+// CheckUnknownKeys reports the unknown keys in the config file, the files it
+// includes and their profiles, and in a config document from
+// UPDATER_CONFIG_JSON or UPDATER_CONFIG_YAML. Strict mode refuses to start
+// when it returns an error.
+func CheckUnknownKeys(configPath string) error {
+	var errs []error
+	if configPath != "" {
+		doc, err := loadConfigDocument(configPath)
+		if err != nil {
+			return err
+		}
+		if err := doc.unknownKeysError(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	sources := []struct{ name, data string }{
+		{configJSONEnv, os.Getenv(configJSONEnv)},
+		{configYAMLEnv, os.Getenv(configYAMLEnv)},
+	}
+	for _, source := range sources {
+		if source.data == "" {
+			continue
+		}
+		unknown, err := unknownKeys([]byte(source.data))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source.name, err))
+			continue
+		}
+		if len(unknown) > 0 {
+			errs = append(errs, fmt.Errorf("%s: unknown keys: %s", source.name, strings.Join(unknown, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownKeys(t *testing.T) {
+	unknown, err := unknownKeys([]byte(`
+server:
+  port: 8080
+  requets_per_minute: 100
+  concurrency:
+    admin:
+      max_inflight: 4
+storage:
+  options:
+    journal_mode: wal
+security:
+  jwt_secret: "removed keys are reported by warnDeprecatedKeys instead"
+application_templates:
+  - name: desktop
+    platfroms: [windows]
+entitlements:
+  static:
+    - token_sha256: abc
+      entitlements: [pro]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"server.requets_per_minute (line 4)",
+		"server.concurrency.admin.max_inflight (line 7)",
+		"application_templates[0].platfroms (line 15)",
+	}, unknown)
+
+	unknown, err = unknownKeys(nil)
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+}
+
+func TestCheckUnknownKeys(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("storage:\n  type: memory\n"), 0644))
+	assert.NoError(t, CheckUnknownKeys(configFile))
+
+	t.Setenv("UPDATER_CONFIG_JSON", `{"logging": {"levle": "debug"}}`)
+	err := CheckUnknownKeys(configFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UPDATER_CONFIG_JSON: unknown keys: logging.levle")
+}
+
+func TestValidateConfig_UnknownKeys(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("storage:\n  type: memory\n  typo: true\n"), 0644))
+
+	for _, r := range ValidateConfig(configFile) {
+		if r.Name == "config.unknown-keys" {
+			assert.False(t, r.OK)
+			assert.Contains(t, r.Message, "storage.typo (line 3)")
+			return
+		}
+	}
+	t.Fatal("expected a config.unknown-keys check")
+}
@@ -0,0 +1,65 @@
+// Package entitlement resolves the license tokens clients send with update
+// checks into the entitlements they grant. Releases that require an
+// entitlement are only offered to clients whose token grants it.
+//
+// Three providers are available, selected by entitlements.provider:
+//   - static: tokens listed in the configuration by SHA-256 digest
+//   - jwt: JWTs signed by a licensing system, verified locally
+//   - http: an external licensing service asked on each lookup
+package entitlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"updater/internal/models"
+)
+
+// ErrInvalidToken is returned by providers for tokens that grant nothing:
+// unknown, expired, badly signed or revoked. Other errors mean the provider
+// could not decide.
+var ErrInvalidToken = errors.New("invalid license token")
+
+// Provider returns the entitlements a license token grants for an
+// application. The returned names are normalized.
+type Provider interface {
+	Entitlements(ctx context.Context, appID, token string) ([]string, error)
+}
+
+// New creates the provider selected by cfg, or returns nil when no provider is
+// configured. cfg is expected to have been validated.
+func New(cfg models.EntitlementsConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case models.EntitlementProviderStatic:
+		return NewStatic(cfg.Static), nil
+	case models.EntitlementProviderJWT:
+		var publicKey []byte
+		if cfg.JWT.PublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.JWT.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read jwt public key: %w", err)
+			}
+			publicKey = data
+		}
+		return NewJWT(cfg.JWT, publicKey)
+	case models.EntitlementProviderHTTP:
+		return NewHTTP(cfg.HTTP.URL, &http.Client{Timeout: cfg.HTTP.Timeout}), nil
+	default:
+		return nil, fmt.Errorf("unknown entitlement provider: %s", cfg.Provider)
+	}
+}
+
+// normalize normalizes entitlement names and drops empty ones.
+func normalize(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = models.NormalizeEntitlement(name); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
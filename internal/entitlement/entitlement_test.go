@@ -0,0 +1,217 @@
+package entitlement
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// signJWT builds a compact JWT with the given alg header, claims and signer.
+func signJWT(t *testing.T, alg string, claims map[string]any, sign func([]byte) []byte) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return input + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(input)))
+}
+
+func hs256(input []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(input)
+	return mac.Sum(nil)
+}
+
+func pemPublicKey(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestNew(t *testing.T) {
+	provider, err := New(models.EntitlementsConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = New(models.EntitlementsConfig{Provider: models.EntitlementProviderStatic})
+	require.NoError(t, err)
+	assert.IsType(t, &Static{}, provider)
+
+	provider, err = New(models.EntitlementsConfig{Provider: models.EntitlementProviderHTTP, HTTP: models.HTTPEntitlementsConfig{URL: "https://licensing.example.com", Timeout: time.Second}})
+	require.NoError(t, err)
+	assert.IsType(t, &HTTP{}, provider)
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "license.pem")
+	require.NoError(t, os.WriteFile(keyFile, pemPublicKey(t, pub), 0o600))
+	provider, err = New(models.EntitlementsConfig{Provider: models.EntitlementProviderJWT, JWT: models.JWTEntitlementsConfig{Algorithm: models.JWTAlgorithmEdDSA, PublicKeyFile: keyFile, Claim: "entitlements"}})
+	require.NoError(t, err)
+	assert.IsType(t, &JWT{}, provider)
+
+	_, err = New(models.EntitlementsConfig{Provider: models.EntitlementProviderJWT, JWT: models.JWTEntitlementsConfig{Algorithm: models.JWTAlgorithmEdDSA, PublicKeyFile: filepath.Join(t.TempDir(), "missing.pem")}})
+	assert.Error(t, err)
+}
+
+func TestStatic(t *testing.T) {
+	provider := NewStatic([]models.StaticLicense{
+		{TokenSHA256: models.HashAPIKey("partner-token"), Entitlements: []string{"Pro", "beta"}},
+		{TokenSHA256: models.HashAPIKey("partner-token"), Entitlements: []string{"enterprise"}},
+	})
+	ctx := context.Background()
+
+	granted, err := provider.Entitlements(ctx, "app", "partner-token")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pro", "beta", "enterprise"}, granted)
+
+	_, err = provider.Entitlements(ctx, "app", "other-token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestJWT_HS256(t *testing.T) {
+	provider, err := NewJWT(models.JWTEntitlementsConfig{
+		Algorithm: models.JWTAlgorithmHS256,
+		Secret:    testSecret,
+		Issuer:    "https://licensing.example.com",
+		Audience:  "updater",
+		Claim:     "entitlements",
+	}, nil)
+	require.NoError(t, err)
+	now := time.Unix(1_800_000_000, 0)
+	provider.now = func() time.Time { return now }
+
+	valid := map[string]any{
+		"iss":          "https://licensing.example.com",
+		"aud":          []string{"updater", "portal"},
+		"exp":          now.Add(time.Hour).Unix(),
+		"nbf":          now.Add(-time.Hour).Unix(),
+		"entitlements": []string{"PRO"},
+	}
+	granted, err := provider.Entitlements(context.Background(), "app", signJWT(t, "HS256", valid, hs256))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pro"}, granted)
+
+	with := func(key string, value any) map[string]any {
+		claims := map[string]any{}
+		for k, v := range valid {
+			claims[k] = v
+		}
+		claims[key] = value
+		return claims
+	}
+	invalid := map[string]string{
+		"expired":         signJWT(t, "HS256", with("exp", now.Add(-time.Second).Unix()), hs256),
+		"not yet valid":   signJWT(t, "HS256", with("nbf", now.Add(time.Minute).Unix()), hs256),
+		"wrong issuer":    signJWT(t, "HS256", with("iss", "https://evil.example.com"), hs256),
+		"wrong audience":  signJWT(t, "HS256", with("aud", "other"), hs256),
+		"bad signature":   signJWT(t, "HS256", valid, func([]byte) []byte { return []byte("forged") }),
+		"alg none":        signJWT(t, "none", valid, func([]byte) []byte { return nil }),
+		"malformed claim": signJWT(t, "HS256", with("entitlements", "pro"), hs256),
+		"not a jwt":       "opaque-token",
+	}
+	for name, token := range invalid {
+		_, err := provider.Entitlements(context.Background(), "app", token)
+		assert.ErrorIs(t, err, ErrInvalidToken, name)
+	}
+}
+
+func TestJWT_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	provider, err := NewJWT(models.JWTEntitlementsConfig{Algorithm: models.JWTAlgorithmRS256, Claim: "tiers"}, pemPublicKey(t, &key.PublicKey))
+	require.NoError(t, err)
+
+	token := signJWT(t, "RS256", map[string]any{"tiers": []string{"pro"}}, func(input []byte) []byte {
+		digest := sha256.Sum256(input)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		return sig
+	})
+	granted, err := provider.Entitlements(context.Background(), "app", token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pro"}, granted)
+
+	// An HS256 token signed with the public key must not verify
+	hmacToken := signJWT(t, "HS256", map[string]any{"tiers": []string{"pro"}}, func(input []byte) []byte {
+		mac := hmac.New(sha256.New, pemPublicKey(t, &key.PublicKey))
+		mac.Write(input)
+		return mac.Sum(nil)
+	})
+	_, err = provider.Entitlements(context.Background(), "app", hmacToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = NewJWT(models.JWTEntitlementsConfig{Algorithm: models.JWTAlgorithmRS256, Claim: "tiers"}, pemPublicKey(t, pub))
+	assert.Error(t, err, "key type must match the algorithm")
+}
+
+func TestJWT_EdDSA(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	provider, err := NewJWT(models.JWTEntitlementsConfig{Algorithm: models.JWTAlgorithmEdDSA, Claim: "entitlements"}, pemPublicKey(t, pub))
+	require.NoError(t, err)
+
+	token := signJWT(t, "EdDSA", map[string]any{}, func(input []byte) []byte { return ed25519.Sign(priv, input) })
+	granted, err := provider.Entitlements(context.Background(), "app", token)
+	require.NoError(t, err)
+	assert.Empty(t, granted, "a valid token without the claim grants nothing")
+
+	_, err = NewJWT(models.JWTEntitlementsConfig{Algorithm: models.JWTAlgorithmEdDSA}, []byte("not pem"))
+	assert.Error(t, err)
+}
+
+func TestHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req httpEntitlementsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "test-app", req.ApplicationID)
+
+		switch req.LicenseToken {
+		case "valid":
+			_ = json.NewEncoder(w).Encode(httpEntitlementsResponse{Entitlements: []string{"Pro"}})
+		case "revoked":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewHTTP(server.URL, server.Client())
+	ctx := context.Background()
+
+	granted, err := provider.Entitlements(ctx, "test-app", "valid")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pro"}, granted)
+
+	_, err = provider.Entitlements(ctx, "test-app", "revoked")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = provider.Entitlements(ctx, "test-app", "broken")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidToken)
+}
@@ -0,0 +1,73 @@
+package entitlement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxResponseSize bounds the licensing service response that is read.
+const maxResponseSize = 64 << 10
+
+// HTTP asks an external licensing service for a token's entitlements. The
+// service receives a POST with a JSON body of application_id and
+// license_token, and answers 200 with {"entitlements": [...]} for a valid
+// token or 401, 403 or 404 for one that grants nothing.
+type HTTP struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTP creates a provider that posts to url with client. The client's
+// timeout bounds how long an update check waits for the service.
+func NewHTTP(url string, client *http.Client) *HTTP {
+	return &HTTP{url: url, client: client}
+}
+
+type httpEntitlementsRequest struct {
+	ApplicationID string `json:"application_id"`
+	LicenseToken  string `json:"license_token"`
+}
+
+type httpEntitlementsResponse struct {
+	Entitlements []string `json:"entitlements"`
+}
+
+// Entitlements asks the licensing service about token. Errors other than
+// ErrInvalidToken mean the service could not be reached or answered
+// unexpectedly.
+func (p *HTTP) Entitlements(ctx context.Context, appID, token string) ([]string, error) {
+	body, err := json.Marshal(httpEntitlementsRequest{ApplicationID: appID, LicenseToken: token})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("licensing service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return nil, ErrInvalidToken
+	default:
+		return nil, fmt.Errorf("licensing service returned status %d", resp.StatusCode)
+	}
+
+	var result httpEntitlementsResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid licensing service response: %w", err)
+	}
+	return normalize(result.Entitlements), nil
+}
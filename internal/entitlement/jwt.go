@@ -0,0 +1,204 @@
+package entitlement
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"updater/internal/models"
+)
+
+// minRSAKeyBits is the smallest RS256 public key accepted.
+const minRSAKeyBits = 2048
+
+// JWT grants the entitlements listed in a claim of a signed JWT. Tokens are
+// verified with the configured key and algorithm only; the alg header must
+// match, so a token cannot choose a weaker algorithm or "none".
+type JWT struct {
+	algorithm string
+	verify    func(signingInput, signature []byte) bool
+	issuer    string
+	audience  string
+	claim     string
+	now       func() time.Time
+}
+
+// NewJWT creates a provider for cfg. publicKey is the PEM-encoded public key
+// for RS256 and EdDSA and is ignored for HS256.
+func NewJWT(cfg models.JWTEntitlementsConfig, publicKey []byte) (*JWT, error) {
+	j := &JWT{
+		algorithm: cfg.Algorithm,
+		issuer:    cfg.Issuer,
+		audience:  cfg.Audience,
+		claim:     cfg.Claim,
+		now:       time.Now,
+	}
+
+	switch cfg.Algorithm {
+	case models.JWTAlgorithmHS256:
+		secret := []byte(cfg.Secret)
+		j.verify = func(signingInput, signature []byte) bool {
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(signingInput)
+			return hmac.Equal(mac.Sum(nil), signature)
+		}
+	case models.JWTAlgorithmRS256:
+		key, err := parsePublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("jwt public key is not an RSA key")
+		}
+		if rsaKey.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("jwt RSA public key must be at least %d bits", minRSAKeyBits)
+		}
+		j.verify = func(signingInput, signature []byte) bool {
+			digest := sha256.Sum256(signingInput)
+			return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature) == nil
+		}
+	case models.JWTAlgorithmEdDSA:
+		key, err := parsePublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		edKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, errors.New("jwt public key is not an Ed25519 key")
+		}
+		j.verify = func(signingInput, signature []byte) bool {
+			return ed25519.Verify(edKey, signingInput, signature)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", cfg.Algorithm)
+	}
+	return j, nil
+}
+
+// parsePublicKey decodes a PEM "PUBLIC KEY" block.
+func parsePublicKey(data []byte) (any, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("jwt public key must be a PEM \"PUBLIC KEY\" block")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwt public key: %w", err)
+	}
+	return key, nil
+}
+
+// jwtHeader is the part of a JOSE header that is checked.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+// jwtClaims are the registered claims that are checked. Exp and Nbf are
+// optional; a token without exp does not expire.
+type jwtClaims struct {
+	Issuer    string    `json:"iss"`
+	Audience  audience  `json:"aud"`
+	ExpiresAt *jsonTime `json:"exp"`
+	NotBefore *jsonTime `json:"nbf"`
+}
+
+// Entitlements verifies the token and returns its entitlements claim. The
+// application ID is not checked; name entitlements per product when several
+// applications share a licensing system.
+func (j *JWT) Entitlements(_ context.Context, _, token string) ([]string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != j.algorithm {
+		return nil, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !j.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	now := j.now()
+	if claims.ExpiresAt != nil && !now.Before(claims.ExpiresAt.Time) {
+		return nil, ErrInvalidToken
+	}
+	if claims.NotBefore != nil && now.Before(claims.NotBefore.Time) {
+		return nil, ErrInvalidToken
+	}
+	if j.issuer != "" && claims.Issuer != j.issuer {
+		return nil, ErrInvalidToken
+	}
+	if j.audience != "" && !slices.Contains(claims.Audience, j.audience) {
+		return nil, ErrInvalidToken
+	}
+
+	var all map[string]json.RawMessage
+	if err := decodeSegment(parts[1], &all); err != nil {
+		return nil, ErrInvalidToken
+	}
+	var granted []string
+	if raw, ok := all[j.claim]; ok {
+		if err := json.Unmarshal(raw, &granted); err != nil {
+			return nil, ErrInvalidToken
+		}
+	}
+	return normalize(granted), nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a JWT into v.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// audience is the aud claim, which may be a string or a list of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// jsonTime is a NumericDate claim: seconds since the Unix epoch.
+type jsonTime struct {
+	time.Time
+}
+
+func (t *jsonTime) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return err
+	}
+	t.Time = time.Unix(int64(seconds), 0)
+	return nil
+}
@@ -0,0 +1,34 @@
+package entitlement
+
+import (
+	"context"
+	"strings"
+	"updater/internal/models"
+)
+
+// Static grants the entitlements listed for each token in the configuration.
+// It is meant for a handful of tokens, such as beta testers or partners.
+type Static struct {
+	byHash map[string][]string
+}
+
+// NewStatic creates a provider for the given licenses. A token listed more
+// than once grants the entitlements of every entry.
+func NewStatic(licenses []models.StaticLicense) *Static {
+	s := &Static{byHash: make(map[string][]string, len(licenses))}
+	for _, license := range licenses {
+		hash := strings.ToLower(license.TokenSHA256)
+		s.byHash[hash] = append(s.byHash[hash], normalize(license.Entitlements)...)
+	}
+	return s
+}
+
+// Entitlements looks the token up by its SHA-256 digest. Every application
+// sees the same entitlements for a token.
+func (s *Static) Entitlements(_ context.Context, _, token string) ([]string, error) {
+	granted, ok := s.byHash[models.HashAPIKey(token)]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return granted, nil
+}
@@ -0,0 +1,118 @@
+// Package events is an in-process publish/subscribe bus for changes to
+// applications, releases and API keys. The update service and key manager
+// publish to it after a change is stored; subsystems that react to changes,
+// such as long-polling update checks, subscribe to it instead of being called
+// from the code that makes the change.
+package events
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Type names what changed.
+type Type string
+
+// Event types. Release events are published once per stored release, so a
+// manifest that registers a version for three platforms publishes three.
+const (
+	ApplicationCreated Type = "application.created"
+	ApplicationUpdated Type = "application.updated"
+	ApplicationDeleted Type = "application.deleted"
+	ReleasePublished   Type = "release.published"
+	ReleaseUpdated     Type = "release.updated"
+	ReleaseDeleted     Type = "release.deleted"
+	KeyCreated         Type = "key.created"
+	KeyUpdated         Type = "key.updated"
+	KeyDeleted         Type = "key.deleted"
+)
+
+// Event describes a stored change. It identifies what changed rather than
+// carrying it; subscribers that need the current state read it from storage.
+type Event struct {
+	Type          Type
+	ApplicationID string    // Set for application and release events
+	ReleaseID     string    // Set for release events
+	Version       string    // Set for release events
+	Platform      string    // Set for release events
+	Architecture  string    // Set for release events
+	KeyID         string    // Set for key events
+	Time          time.Time // When the change was made
+}
+
+// Handler receives published events.
+type Handler func(Event)
+
+type subscription struct {
+	handler Handler
+	types   map[Type]bool // nil for every type
+}
+
+// Bus delivers each published event to the handlers subscribed to its type.
+// Delivery is synchronous, in the publisher's goroutine and in subscription
+// order, so handlers must return quickly; one that does slow work hands the
+// event to its own goroutine. Events are not persisted: a bus only reaches
+// subscribers in the same process. A nil *Bus discards what is published to it.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   map[int]subscription
+	nextID int
+}
+
+// NewBus creates a bus with no subscribers.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[int]subscription)}
+}
+
+// Subscribe registers handler for events of the given types, or of every type
+// when none are given. It returns a function that removes the subscription.
+func (b *Bus) Subscribe(handler Handler, types ...Type) (unsubscribe func()) {
+	sub := subscription{handler: handler}
+	if len(types) > 0 {
+		sub.types = make(map[Type]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// Publish delivers e to every handler subscribed to its type. Handlers may
+// subscribe, unsubscribe or publish while being called.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	ids := make([]int, 0, len(b.subs))
+	for id, sub := range b.subs {
+		if sub.types == nil || sub.types[e.Type] {
+			ids = append(ids, id)
+		}
+	}
+	handlers := make([]Handler, 0, len(ids))
+	slices.Sort(ids)
+	for _, id := range ids {
+		handlers = append(handlers, b.subs[id].handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(e)
+	}
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus()
+	var all, releases []Type
+	bus.Subscribe(func(e Event) { all = append(all, e.Type) })
+	unsubscribe := bus.Subscribe(func(e Event) { releases = append(releases, e.Type) }, ReleasePublished, ReleaseDeleted)
+
+	bus.Publish(Event{Type: ApplicationCreated, ApplicationID: "app"})
+	bus.Publish(Event{Type: ReleasePublished, ApplicationID: "app", Version: "1.0.0"})
+	unsubscribe()
+	unsubscribe()
+	bus.Publish(Event{Type: ReleaseDeleted, ApplicationID: "app", Version: "1.0.0"})
+
+	assert.Equal(t, []Type{ApplicationCreated, ReleasePublished, ReleaseDeleted}, all)
+	assert.Equal(t, []Type{ReleasePublished}, releases)
+}
+
+func TestBus_DeliversInSubscriptionOrder(t *testing.T) {
+	bus := NewBus()
+	var order []int
+	for i := range 5 {
+		bus.Subscribe(func(Event) { order = append(order, i) })
+	}
+	bus.Publish(Event{Type: KeyCreated})
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+}
+
+func TestBus_HandlerMaySubscribeAndPublish(t *testing.T) {
+	bus := NewBus()
+	var got []Type
+	bus.Subscribe(func(e Event) {
+		if e.Type == ApplicationCreated {
+			bus.Subscribe(func(e Event) { got = append(got, e.Type) })
+			bus.Publish(Event{Type: ApplicationUpdated})
+		}
+	})
+	bus.Publish(Event{Type: ApplicationCreated})
+	assert.Equal(t, []Type{ApplicationUpdated}, got)
+}
+
+func TestBus_NilDiscards(t *testing.T) {
+	var bus *Bus
+	assert.NotPanics(t, func() { bus.Publish(Event{Type: KeyDeleted}) })
+}
@@ -0,0 +1,172 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"updater/internal/api"
+	"updater/internal/models"
+	"updater/internal/storage"
+	"updater/internal/update"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clientsDir holds the reference clients the tests run.
+const clientsDir = "../../clients"
+
+// referenceClient runs one of the in-tree reference clients.
+type referenceClient struct {
+	name    string
+	command func(t *testing.T) []string // Command and arguments before the client's flags; skips the test when the runtime is missing
+}
+
+var referenceClients = []referenceClient{
+	{
+		name: "python",
+		command: func(t *testing.T) []string {
+			python, err := exec.LookPath("python3")
+			if err != nil {
+				t.Skip("python3 not installed")
+			}
+			return []string{python, filepath.Join(clientsDir, "python", "updater_client.py")}
+		},
+	},
+	{
+		name: "typescript",
+		command: func(t *testing.T) []string {
+			node, err := exec.LookPath("node")
+			if err != nil {
+				t.Skip("node not installed")
+			}
+			// Type stripping needs Node.js 22.6 or later
+			if err := exec.Command(node, "--experimental-strip-types", "-e", "").Run(); err != nil {
+				t.Skip("node cannot strip TypeScript types")
+			}
+			return []string{node, "--experimental-strip-types", "--no-warnings", filepath.Join(clientsDir, "typescript", "client.ts")}
+		},
+	},
+}
+
+// clientResult is the JSON a reference client prints.
+type clientResult struct {
+	UpdateAvailable bool   `json:"update_available"`
+	CurrentVersion  string `json:"current_version"`
+	Version         string `json:"version"`
+	Required        bool   `json:"required"`
+	Path            string `json:"path"`
+	Error           string `json:"error"`
+}
+
+// TestIntegration_ReferenceClients runs the Python and TypeScript reference
+// clients against a live server: they check, download an artifact the server
+// also hosts, and verify it.
+func TestIntegration_ReferenceClients(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	router := api.SetupRoutes(api.NewHandlers(update.NewService(store)), &models.Config{Storage: models.StorageConfig{Type: "memory"}})
+	artifact := []byte(strings.Repeat("reference client artifact\n", 512))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/artifacts/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(artifact)
+	})
+	mux.Handle("/", router)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	require.NoError(t, store.SaveApplication(ctx, &models.Application{
+		ID: "client-app", Name: "Client App", Platforms: []string{"linux"},
+	}))
+	sum := sha256.Sum256(artifact)
+	register := func(version, arch, checksum string) {
+		t.Helper()
+		body, err := json.Marshal(models.RegisterReleaseRequest{
+			ApplicationID: "client-app",
+			Version:       version,
+			Platform:      "linux",
+			Architecture:  arch,
+			DownloadURL:   server.URL + "/artifacts/client-app-" + version + ".tar.gz",
+			Checksum:      checksum,
+			ChecksumType:  "sha256",
+			FileSize:      int64(len(artifact)),
+			Required:      true,
+		})
+		require.NoError(t, err)
+		resp, err := http.Post(server.URL+"/api/v1/updates/client-app/register", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	register("1.1.0", "amd64", hex.EncodeToString(sum[:]))
+	register("1.1.0", "arm64", strings.Repeat("0", 64))
+
+	for _, client := range referenceClients {
+		t.Run(client.name, func(t *testing.T) {
+			command := client.command(t)
+			run := func(currentVersion, arch string) (clientResult, error) {
+				out := t.TempDir()
+				args := append(command[1:],
+					"--url", server.URL,
+					"--app-id", "client-app",
+					"--current-version", currentVersion,
+					"--platform", "linux",
+					"--architecture", arch,
+					"--out", out,
+				)
+				cmd := exec.Command(command[0], args...)
+				var stderr bytes.Buffer
+				cmd.Stderr = &stderr
+				stdout, err := cmd.Output()
+				var result clientResult
+				require.NoError(t, json.Unmarshal(stdout, &result), "stdout: %s\nstderr: %s", stdout, stderr.String())
+				return result, err
+			}
+
+			t.Run("downloads and verifies the update", func(t *testing.T) {
+				result, err := run("1.0.0", "amd64")
+				require.NoError(t, err, result.Error)
+				assert.True(t, result.UpdateAvailable)
+				assert.Equal(t, "1.1.0", result.Version)
+				assert.True(t, result.Required)
+				assert.Equal(t, "client-app-1.1.0.tar.gz", filepath.Base(result.Path))
+				downloaded, err := os.ReadFile(result.Path)
+				require.NoError(t, err)
+				assert.Equal(t, artifact, downloaded)
+			})
+
+			t.Run("up to date", func(t *testing.T) {
+				result, err := run("1.1.0", "amd64")
+				require.NoError(t, err, result.Error)
+				assert.False(t, result.UpdateAvailable)
+				assert.Equal(t, "1.1.0", result.CurrentVersion)
+			})
+
+			t.Run("rejects a checksum mismatch", func(t *testing.T) {
+				result, err := run("1.0.0", "arm64")
+				require.Error(t, err)
+				assert.Contains(t, result.Error, "sha256 mismatch")
+			})
+
+			t.Run("reports service errors", func(t *testing.T) {
+				result, err := run("not-a-version", "amd64")
+				require.Error(t, err)
+				assert.Contains(t, result.Error, "update check failed: HTTP 4")
+			})
+		})
+	}
+}
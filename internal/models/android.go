@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Android apps are sideloaded as APKs, and Android installs an APK over an
+// installed app only when its versionCode is higher and it is signed with the
+// same certificate, whatever its versionName. An Android release records both:
+// VersionCode, and SigningCertSHA256, the SHA-256 digest of its signing
+// certificate as apksigner prints it. A client that reports its versionCode
+// with an update check is not offered a release whose version code is not
+// higher, since Android would refuse to install it; that includes downgrades
+// off a yanked release. The app compares the digest with its own signature
+// before it downloads the APK.
+
+// MaxVersionCode is the largest versionCode Google Play accepts.
+const MaxVersionCode = 2100000000
+
+var signingCertDigestPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// NormalizeSigningCertDigest lowercases a certificate digest and drops the
+// colons keytool separates its bytes with.
+func NormalizeSigningCertDigest(digest string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(digest), ":", ""))
+}
+
+// ValidateAndroidFields checks a release's version code and normalized
+// signing certificate digest, which only Android releases may set.
+func ValidateAndroidFields(platform string, versionCode int64, signingCertSHA256 string) error {
+	if versionCode == 0 && signingCertSHA256 == "" {
+		return nil
+	}
+	if platform != PlatformAndroid {
+		return errors.New("version_code and signing_cert_sha256 only apply to android releases")
+	}
+	if versionCode < 0 || versionCode > MaxVersionCode {
+		return fmt.Errorf("version_code must be between 1 and %d", MaxVersionCode)
+	}
+	if signingCertSHA256 != "" && !signingCertDigestPattern.MatchString(signingCertSHA256) {
+		return errors.New("signing_cert_sha256 must be a SHA-256 digest of 64 hex characters")
+	}
+	return nil
+}
+
+// AndroidArchitectures are the architectures Android apps ship APKs for, in
+// the order an Android index lists them.
+var AndroidArchitectures = []string{ArchARM64, ArchARM, ArchAMD64, Arch386}
+
+// androidABIs maps architectures to the ABI names Android reports in
+// Build.SUPPORTED_ABIS.
+var androidABIs = map[string]string{
+	ArchARM64: "arm64-v8a",
+	ArchARM:   "armeabi-v7a",
+	ArchAMD64: "x86_64",
+	Arch386:   "x86",
+}
+
+// AndroidABI returns the Android ABI name of an architecture.
+func AndroidABI(arch string) string {
+	return androidABIs[arch]
+}
+
+// AndroidIndex lists the newest APK of each architecture of an application on
+// one channel, so an in-app updater can pick the APK for the device's ABI and
+// check it against its own version code and signature before downloading.
+type AndroidIndex struct {
+	ApplicationID string           `json:"application_id"`
+	Channel       string           `json:"channel"`
+	Packages      []AndroidPackage `json:"packages"`
+}
+
+// AndroidPackage is the newest APK of one architecture in an Android index.
+type AndroidPackage struct {
+	ABI               string    `json:"abi"` // Android ABI name, such as arm64-v8a
+	Architecture      string    `json:"architecture"`
+	VersionName       string    `json:"version_name"`
+	VersionCode       int64     `json:"version_code,omitempty"`
+	SigningCertSHA256 string    `json:"signing_cert_sha256,omitempty"`
+	DownloadURL       string    `json:"download_url"`
+	Checksum          string    `json:"checksum"`
+	ChecksumType      string    `json:"checksum_type"`
+	FileSize          int64     `json:"file_size"`
+	ReleaseNotes      string    `json:"release_notes"`
+	ReleaseDate       time.Time `json:"release_date"`
+	Required          bool      `json:"required"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSigningCertDigest(t *testing.T) {
+	digest := strings.Repeat("ab", 32)
+	assert.Equal(t, digest, NormalizeSigningCertDigest(" "+strings.ToUpper(digest)+" "))
+	assert.Equal(t, digest, NormalizeSigningCertDigest(strings.TrimSuffix(strings.Repeat("AB:", 32), ":")))
+}
+
+func TestValidateAndroidFields(t *testing.T) {
+	digest := strings.Repeat("ab", 32)
+	assert.NoError(t, ValidateAndroidFields(PlatformWindows, 0, ""))
+	assert.NoError(t, ValidateAndroidFields(PlatformAndroid, 42, digest))
+	assert.NoError(t, ValidateAndroidFields(PlatformAndroid, 42, ""))
+
+	assert.ErrorContains(t, ValidateAndroidFields(PlatformWindows, 42, ""), "only apply to android")
+	assert.ErrorContains(t, ValidateAndroidFields(PlatformAndroid, -1, ""), "version_code")
+	assert.ErrorContains(t, ValidateAndroidFields(PlatformAndroid, MaxVersionCode+1, ""), "version_code")
+	assert.ErrorContains(t, ValidateAndroidFields(PlatformAndroid, 42, "abc"), "signing_cert_sha256")
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Anomaly kinds: update check traffic no legitimate client produces.
+const (
+	// AnomalyUnknownApplication is a check for an application that does not
+	// exist.
+	AnomalyUnknownApplication = "unknown_application"
+	// AnomalyFutureVersion is a check whose current version is newer than any
+	// release of the application.
+	AnomalyFutureVersion = "future_version"
+	// AnomalyRepeatedCheck is the same check sent again and again.
+	AnomalyRepeatedCheck = "repeated_check"
+	// AnomalyHoneypot is a check for a decoy application ID.
+	AnomalyHoneypot = "honeypot"
+)
+
+// AnomalyDetectionConfig temporarily blocks clients whose update checks look
+// like scraping or abuse rather than an installed application checking in.
+// Each limit is the number of suspicious checks one client IP may send per
+// Window before it is blocked for BlockDuration; a limit of 0 turns that
+// signal off. A check for one of HoneypotAppIDs blocks the client at once.
+type AnomalyDetectionConfig struct {
+	Enabled       bool          `yaml:"enabled" json:"enabled"`
+	Window        time.Duration `yaml:"window" json:"window"`
+	BlockDuration time.Duration `yaml:"block_duration" json:"block_duration"`
+	// UnknownApplicationLimit bounds checks for applications that do not exist.
+	UnknownApplicationLimit int `yaml:"unknown_application_limit" json:"unknown_application_limit"`
+	// FutureVersionLimit bounds checks claiming a version newer than any release.
+	FutureVersionLimit int `yaml:"future_version_limit" json:"future_version_limit"`
+	// RepeatedCheckLimit bounds identical checks. It is off by default, since
+	// installs behind one NAT send identical checks unless they report a
+	// client_id, which is part of what makes two checks identical.
+	RepeatedCheckLimit int `yaml:"repeated_check_limit" json:"repeated_check_limit"`
+	// HoneypotAppIDs are decoy application IDs that no real client uses, such as
+	// names planted in old documentation. They must not be registered.
+	HoneypotAppIDs []string `yaml:"honeypot_app_ids" json:"honeypot_app_ids"`
+}
+
+// Validate checks the settings when anomaly detection is enabled.
+func (c *AnomalyDetectionConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	if c.Window <= 0 {
+		errs = append(errs, errors.New("window must be positive"))
+	}
+	if c.BlockDuration <= 0 {
+		errs = append(errs, errors.New("block_duration must be positive"))
+	}
+	if c.UnknownApplicationLimit < 0 || c.FutureVersionLimit < 0 || c.RepeatedCheckLimit < 0 {
+		errs = append(errs, errors.New("limits cannot be negative"))
+	}
+	for _, id := range c.HoneypotAppIDs {
+		if strings.TrimSpace(id) == "" {
+			errs = append(errs, errors.New("honeypot_app_ids cannot contain empty IDs"))
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -28,7 +28,7 @@ type APIKey struct {
 	KeyHash string `json:"key_hash"`
 	// Prefix is the first 8 characters of the raw key, shown in the admin UI for identification.
 	Prefix string `json:"prefix"`
-	// Permissions lists the permission levels granted to this key ("read", "write", "admin", or "*").
+	// Permissions lists the permission levels granted to this key ("read", "write", "support", "admin", or "*").
 	Permissions []string `json:"permissions"`
 	// Enabled controls whether the key is accepted. Disabled keys are rejected at auth time.
 	Enabled   bool      `json:"enabled"`
@@ -78,17 +78,48 @@ func NewKeyID() string {
 }
 
 // HasPermission returns true when the key is enabled and possesses the required permission.
-// Permission hierarchy: admin (and "*") grant everything; write grants read and write.
+// Permission hierarchy: admin (and "*") grant everything; write grants read and write;
+// support grants read and support, which opens the admin views but no mutations.
 // Result is independent of the order of elements in Permissions.
 func (ak *APIKey) HasPermission(required string) bool {
 	if ak == nil || !ak.Enabled {
 		return false
 	}
+	perms := ak.permissionSet()
+	return perms["*"] || perms["admin"] ||
+		(perms["write"] && (required == "read" || required == "write")) ||
+		(perms["support"] && (required == "read" || required == "support")) ||
+		perms[required]
+}
+
+// IsSupportOnly reports whether the key is a read-only support key: it has
+// the support permission and cannot change anything.
+func (ak *APIKey) IsSupportOnly() bool {
+	if ak == nil {
+		return false
+	}
+	perms := ak.permissionSet()
+	return perms["support"] && !perms["*"] && !perms["admin"] && !perms["write"]
+}
+
+func (ak *APIKey) permissionSet() map[string]bool {
 	perms := make(map[string]bool, len(ak.Permissions))
 	for _, p := range ak.Permissions {
 		perms[p] = true
 	}
-	return perms["*"] || perms["admin"] ||
-		(perms["write"] && (required == "read" || required == "write")) ||
-		perms[required]
+	return perms
+}
+
+// CreateAPIKeyRequest is the request body for POST /api/v1/admin/keys.
+type CreateAPIKeyRequest struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateAPIKeyRequest is the request body for PATCH /api/v1/admin/keys/{id}.
+// All fields are optional.
+type UpdateAPIKeyRequest struct {
+	Name        *string  `json:"name"`
+	Permissions []string `json:"permissions"`
+	Enabled     *bool    `json:"enabled"`
 }
@@ -43,6 +43,12 @@ func TestAPIKeyHasPermission(t *testing.T) {
 		{"read denied write", []string{"read"}, true, "write", false},
 		{"wildcard grants all", []string{"*"}, true, "admin", true},
 		{"disabled key denied", []string{"admin"}, false, "read", false},
+		{"support grants read", []string{"support"}, true, "read", true},
+		{"support grants support", []string{"support"}, true, "support", true},
+		{"support denied write", []string{"support"}, true, "write", false},
+		{"support denied admin", []string{"support"}, true, "admin", false},
+		{"admin grants support", []string{"admin"}, true, "support", true},
+		{"write denied support", []string{"write"}, true, "support", false},
 		// Multi-permission keys: result must not depend on slice order.
 		{"write then admin grants admin", []string{"write", "admin"}, true, "admin", true},
 		{"admin then write grants read", []string{"admin", "write"}, true, "read", true},
@@ -58,6 +64,14 @@ func TestAPIKeyHasPermission(t *testing.T) {
 	}
 }
 
+func TestAPIKeyIsSupportOnly(t *testing.T) {
+	assert.True(t, (&models.APIKey{Permissions: []string{"support"}}).IsSupportOnly())
+	assert.True(t, (&models.APIKey{Permissions: []string{"read", "support"}}).IsSupportOnly())
+	assert.False(t, (&models.APIKey{Permissions: []string{"support", "write"}}).IsSupportOnly())
+	assert.False(t, (&models.APIKey{Permissions: []string{"admin"}}).IsSupportOnly())
+	assert.False(t, (*models.APIKey)(nil).IsSupportOnly())
+}
+
 func TestNewAPIKey(t *testing.T) {
 	raw := "upd_testkey123456789012345678901234567890123"
 	key := models.NewAPIKey("test-id", "test", raw, []string{"read"})
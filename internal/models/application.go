@@ -59,9 +59,10 @@ var (
 //
 // Design Principles:
 // - ID serves as unique identifier and is used in API URLs (must be URL-safe)
+// - ID never changes; Slug is the renameable name URLs may use instead (see slug.go)
 // - Platforms array supports multi-platform applications
 // - Configuration is embedded for easy access and serialization
-// - Timestamps as strings for flexibility with different storage backends
+// - Timestamps are time.Time; each storage backend converts them to its column type
 // - Validation tags provide input validation constraints
 type Application struct {
 	ID          string            `json:"id" validate:"required"`              // Unique application identifier (URL-safe)
@@ -69,8 +70,13 @@ type Application struct {
 	Description string            `json:"description"`                         // Optional application description
 	Platforms   []string          `json:"platforms" validate:"required,min=1"` // Supported platforms (windows, linux, etc.)
 	Config      ApplicationConfig `json:"config"`                              // Application-specific configuration
-	CreatedAt   string            `json:"created_at,omitempty"`                // Creation timestamp (RFC3339 format)
-	UpdatedAt   string            `json:"updated_at,omitempty"`                // Last modification timestamp
+	CreatedAt   time.Time         `json:"created_at"`                          // Creation time
+	UpdatedAt   time.Time         `json:"updated_at"`                          // Last modification time
+	Tags        []string          `json:"tags"`                                // Free-form labels for grouping and filtering
+	Group       string            `json:"group,omitempty"`                     // Organizational group (team or product family)
+	ParentID    string            `json:"parent_id,omitempty"`                 // Host application ID when this application is a plugin
+	Slug        string            `json:"slug,omitempty"`                      // Renameable URL identifier; the ID when never renamed
+	FormerSlugs []string          `json:"former_slugs,omitempty"`              // Earlier slugs, redirected to the current one
 }
 
 // ApplicationConfig contains application-specific metadata.
@@ -78,8 +84,25 @@ type Application struct {
 // Design Considerations:
 // - Extensible via CustomFields for application-specific key-value metadata
 // - Kept minimal: update behaviour is driven by per-request parameters, not stored config
+// - Profile opts an application into a client family's extra endpoints (see ota.go)
+// - ReleaseNotesTemplate is copied into releases registered without notes (see release_notes.go)
+// - AllowedDownloadHosts is checked on release registration (see download_policy.go)
+// - UpdateInterval spreads client checks over the interval (see schedule.go)
+// - BandwidthBudget pauses update offers once an hour's downloads reach it (see bandwidth.go)
+// - RequiredChecks hold new releases back until external checks pass (see status_check.go)
+// - FreezeWindows block publishing to the stable channel for a period (see freeze_window.go)
+// - Notice is an operational message returned with update checks (see notice.go)
 type ApplicationConfig struct {
-	CustomFields map[string]string `json:"custom_fields,omitempty"` // Application-specific metadata
+	CustomFields         map[string]string `json:"custom_fields,omitempty"`          // Application-specific metadata
+	Profile              string            `json:"profile,omitempty"`                // Client profile; "ota" enables the embedded OTA endpoint
+	OTA                  *OTAConfig        `json:"ota,omitempty"`                    // OTA delivery hints; only valid with the ota profile
+	ReleaseNotesTemplate string            `json:"release_notes_template,omitempty"` // Default notes for releases registered without any
+	AllowedDownloadHosts []string          `json:"allowed_download_hosts,omitempty"` // Host patterns release download URLs must match
+	UpdateInterval       int               `json:"update_interval,omitempty"`        // Seconds between client checks; 0 leaves scheduling to clients
+	BandwidthBudget      int64             `json:"bandwidth_budget,omitempty"`       // Bytes of downloads offered per hour; 0 for no limit
+	RequiredChecks       []string          `json:"required_checks,omitempty"`        // Status checks new releases must pass before they are offered
+	FreezeWindows        []FreezeWindow    `json:"freeze_windows,omitempty"`         // Periods during which publishing to the stable channel is blocked
+	Notice               *Notice           `json:"notice,omitempty"`                 // Operational message shown to clients, such as an outage notice
 }
 
 // NewApplication creates a new Application with sensible defaults.
@@ -91,6 +114,7 @@ func NewApplication(id, name string, platforms []string) *Application {
 		Config: ApplicationConfig{
 			CustomFields: make(map[string]string),
 		},
+		Tags: []string{},
 	}
 }
 
@@ -121,14 +145,26 @@ func (a *Application) Validate() error {
 		return fmt.Errorf("invalid config: %w", err)
 	}
 
-	if a.CreatedAt != "" {
-		if _, err := time.Parse(time.RFC3339, a.CreatedAt); err != nil {
-			return fmt.Errorf("invalid created_at timestamp: %w", err)
+	if err := ValidateTags(a.Tags); err != nil {
+		return err
+	}
+
+	if err := ValidateGroup(a.Group); err != nil {
+		return err
+	}
+
+	if a.ParentID != "" {
+		if !isValidID(a.ParentID) {
+			return errors.New("parent ID must contain only alphanumeric characters, hyphens, and underscores")
+		}
+		if a.ParentID == a.ID {
+			return errors.New("application cannot be its own parent")
 		}
 	}
-	if a.UpdatedAt != "" {
-		if _, err := time.Parse(time.RFC3339, a.UpdatedAt); err != nil {
-			return fmt.Errorf("invalid updated_at timestamp: %w", err)
+
+	if a.Slug != "" {
+		if err := ValidateSlug(a.Slug); err != nil {
+			return err
 		}
 	}
 
@@ -152,6 +188,37 @@ func (a *Application) SupportsArchitecture(platform, arch string) bool {
 }
 
 func (ac *ApplicationConfig) Validate() error {
+	switch ac.Profile {
+	case "", ApplicationProfileOTA:
+	default:
+		return fmt.Errorf("invalid profile: %s", ac.Profile)
+	}
+	if ac.OTA != nil {
+		if ac.Profile != ApplicationProfileOTA {
+			return errors.New("ota settings require the ota profile")
+		}
+		if err := ac.OTA.Validate(); err != nil {
+			return fmt.Errorf("invalid ota settings: %w", err)
+		}
+	}
+	if err := ValidateHostPatterns(ac.AllowedDownloadHosts); err != nil {
+		return fmt.Errorf("invalid allowed_download_hosts: %w", err)
+	}
+	if err := ValidateUpdateInterval(ac.UpdateInterval); err != nil {
+		return err
+	}
+	if err := ValidateBandwidthBudget(ac.BandwidthBudget); err != nil {
+		return err
+	}
+	if err := ValidateRequiredChecks(ac.RequiredChecks); err != nil {
+		return fmt.Errorf("invalid required_checks: %w", err)
+	}
+	if err := ValidateFreezeWindows(ac.FreezeWindows); err != nil {
+		return fmt.Errorf("invalid freeze_windows: %w", err)
+	}
+	if err := ValidateNotice(ac.Notice); err != nil {
+		return fmt.Errorf("invalid notice: %w", err)
+	}
 	return nil
 }
 
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Artifact storage drivers.
+const (
+	ArtifactDriverLocal = "local" // Files on the server's disk, served by the server itself
+	ArtifactDriverS3    = "s3"    // Amazon S3 or an S3-compatible store such as MinIO
+	ArtifactDriverGCS   = "gcs"   // Google Cloud Storage through its S3-compatible XML API
+)
+
+// ArtifactsConfig lets publishers upload release artifacts to the server,
+// which keeps them in blob storage and generates their download URLs, instead
+// of hosting binaries elsewhere.
+type ArtifactsConfig struct {
+	Enabled   bool          `yaml:"enabled" json:"enabled"`
+	Driver    string        `yaml:"driver" json:"driver"`         // local, s3 or gcs
+	PublicURL string        `yaml:"public_url" json:"public_url"` // Base URL clients download stored artifacts from
+	MaxSize   int64         `yaml:"max_size" json:"max_size"`     // Largest artifact accepted, in bytes
+	Timeout   time.Duration `yaml:"timeout" json:"timeout"`       // Limit on receiving and storing one upload
+
+	Local LocalArtifactsConfig `yaml:"local" json:"local"`
+	S3    S3ArtifactsConfig    `yaml:"s3" json:"s3"`
+	GCS   GCSArtifactsConfig   `yaml:"gcs" json:"gcs"`
+}
+
+// LocalArtifactsConfig keeps artifacts in a directory. The server serves
+// them under /artifacts/, so public_url is this server's address followed by
+// /artifacts.
+type LocalArtifactsConfig struct {
+	Path string `yaml:"path" json:"path"`
+}
+
+// S3ArtifactsConfig keeps artifacts in an S3 bucket. Endpoint defaults to
+// AWS for the region; set it, usually with path_style, for S3-compatible
+// stores.
+type S3ArtifactsConfig struct {
+	Endpoint        string `yaml:"endpoint" json:"endpoint"`
+	Region          string `yaml:"region" json:"region"`
+	Bucket          string `yaml:"bucket" json:"bucket"`
+	Prefix          string `yaml:"prefix" json:"prefix"` // Prepended to every object key
+	AccessKeyID     string `yaml:"access_key_id" json:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key" json:"-"`
+	PathStyle       bool   `yaml:"path_style" json:"path_style"` // Address the bucket in the path instead of the host name
+}
+
+// GCSArtifactsConfig keeps artifacts in a Cloud Storage bucket, authenticating
+// with an HMAC key of a service account that can write to it.
+type GCSArtifactsConfig struct {
+	Bucket          string `yaml:"bucket" json:"bucket"`
+	Prefix          string `yaml:"prefix" json:"prefix"` // Prepended to every object key
+	AccessKeyID     string `yaml:"access_key_id" json:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key" json:"-"`
+}
+
+// Validate checks the settings when artifact uploads are enabled.
+func (c *ArtifactsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	u, err := url.Parse(c.PublicURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		errs = append(errs, errors.New("public_url must be an http or https URL"))
+	} else if u.RawQuery != "" || u.Fragment != "" {
+		errs = append(errs, errors.New("public_url cannot have a query or fragment"))
+	}
+	if c.MaxSize <= 0 {
+		errs = append(errs, errors.New("max_size must be positive"))
+	}
+	if c.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout must be positive"))
+	}
+	switch c.Driver {
+	case ArtifactDriverLocal:
+		if strings.TrimSpace(c.Local.Path) == "" {
+			errs = append(errs, errors.New("local.path is required"))
+		}
+	case ArtifactDriverS3:
+		if c.S3.Bucket == "" {
+			errs = append(errs, errors.New("s3.bucket is required"))
+		}
+		if c.S3.Region == "" {
+			errs = append(errs, errors.New("s3.region is required"))
+		}
+		if c.S3.Endpoint != "" {
+			if u, err := url.Parse(c.S3.Endpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				errs = append(errs, errors.New("s3.endpoint must be an http or https URL"))
+			}
+		}
+		if c.S3.AccessKeyID == "" || c.S3.SecretAccessKey == "" {
+			errs = append(errs, errors.New("s3.access_key_id and s3.secret_access_key are required"))
+		}
+	case ArtifactDriverGCS:
+		if c.GCS.Bucket == "" {
+			errs = append(errs, errors.New("gcs.bucket is required"))
+		}
+		if c.GCS.AccessKeyID == "" || c.GCS.SecretAccessKey == "" {
+			errs = append(errs, errors.New("gcs.access_key_id and gcs.secret_access_key are required"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("driver must be %s, %s or %s", ArtifactDriverLocal, ArtifactDriverS3, ArtifactDriverGCS))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactsConfig_Validate(t *testing.T) {
+	valid := func(driver string) ArtifactsConfig {
+		return ArtifactsConfig{
+			Enabled:   true,
+			Driver:    driver,
+			PublicURL: "https://downloads.example.com",
+			MaxSize:   1 << 30,
+			Timeout:   time.Hour,
+			Local:     LocalArtifactsConfig{Path: "/var/lib/updater/artifacts"},
+			S3:        S3ArtifactsConfig{Region: "eu-west-1", Bucket: "releases", AccessKeyID: "AKID", SecretAccessKey: "secret"},
+			GCS:       GCSArtifactsConfig{Bucket: "releases", AccessKeyID: "GOOG1", SecretAccessKey: "secret"},
+		}
+	}
+	tests := []struct {
+		name    string
+		config  func() ArtifactsConfig
+		wantErr string
+	}{
+		{name: "disabled", config: func() ArtifactsConfig { return ArtifactsConfig{} }},
+		{name: "local", config: func() ArtifactsConfig { return valid(ArtifactDriverLocal) }},
+		{name: "s3", config: func() ArtifactsConfig { return valid(ArtifactDriverS3) }},
+		{name: "gcs", config: func() ArtifactsConfig { return valid(ArtifactDriverGCS) }},
+		{name: "unknown driver", config: func() ArtifactsConfig { return valid("azure") }, wantErr: "driver must be"},
+		{name: "missing public URL", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverLocal)
+			c.PublicURL = ""
+			return c
+		}, wantErr: "public_url must be"},
+		{name: "public URL with query", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverLocal)
+			c.PublicURL = "https://downloads.example.com/?sig=x"
+			return c
+		}, wantErr: "query or fragment"},
+		{name: "no max size", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverLocal)
+			c.MaxSize = 0
+			return c
+		}, wantErr: "max_size must be positive"},
+		{name: "no timeout", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverLocal)
+			c.Timeout = 0
+			return c
+		}, wantErr: "timeout must be positive"},
+		{name: "local without path", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverLocal)
+			c.Local.Path = " "
+			return c
+		}, wantErr: "local.path is required"},
+		{name: "s3 without secret", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverS3)
+			c.S3.SecretAccessKey = ""
+			return c
+		}, wantErr: "s3.access_key_id and s3.secret_access_key are required"},
+		{name: "s3 with invalid endpoint", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverS3)
+			c.S3.Endpoint = "minio:9000"
+			return c
+		}, wantErr: "s3.endpoint must be"},
+		{name: "gcs without bucket", config: func() ArtifactsConfig {
+			c := valid(ArtifactDriverGCS)
+			c.GCS.Bucket = ""
+			return c
+		}, wantErr: "gcs.bucket is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config()
+			err := config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// AutoFillConfig lets release registrations that set auto_fill have the
+// server fetch the artifact to fill in its file size and checksum, so CI does
+// not have to hash large artifacts itself.
+type AutoFillConfig struct {
+	Enabled bool          `yaml:"enabled" json:"enabled"`
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`   // Limit on fetching one artifact
+	MaxSize int64         `yaml:"max_size" json:"max_size"` // Largest artifact fetched, in bytes
+	// AllowPrivateNetworks lets the server fetch from loopback, private and
+	// link-local addresses. Off by default, because the service usually has
+	// network access that publishers should not be able to use.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" json:"allow_private_networks"`
+}
+
+// Validate checks the settings when auto-fill is enabled.
+func (c *AutoFillConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	if c.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout must be positive"))
+	}
+	if c.MaxSize <= 0 {
+		errs = append(errs, errors.New("max_size must be positive"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// An application's bandwidth_budget caps the download traffic its update
+// offers may trigger in each clock hour. Every offer is charged the release's
+// file size, as an estimate of the download it starts, and once the hour's
+// charges reach the budget further offers are paused until the next hour:
+// clients are told no update is available and find it on a later check.
+// Required and security releases, and downgrades off a yanked release, are
+// still offered, and charged, so an emergency patch is never held back. The
+// last offer before the pause may take the charges past the budget by up to
+// one file.
+
+// BandwidthWindow is the period a bandwidth budget applies to.
+const BandwidthWindow = time.Hour
+
+// ValidateBandwidthBudget checks a bandwidth budget in bytes per hour; zero
+// disables it.
+func ValidateBandwidthBudget(bytes int64) error {
+	if bytes < 0 {
+		return errors.New("bandwidth_budget must not be negative")
+	}
+	return nil
+}
+
+// BandwidthUsage reports an application's bandwidth budget and the current
+// hour's charges, in ApplicationInfoResponse.
+type BandwidthUsage struct {
+	BudgetBytes int64     `json:"budget_bytes"`
+	UsedBytes   int64     `json:"used_bytes"`   // File sizes of the updates offered this hour
+	WindowStart time.Time `json:"window_start"` // Start of the current hour
+	Paused      bool      `json:"paused"`       // Offers other than required and security releases are paused
+}
+
+// NewBandwidthUsage returns the usage of an application with a budget of
+// budget bytes that has been charged used bytes in the window starting at
+// start.
+func NewBandwidthUsage(budget, used int64, start time.Time) *BandwidthUsage {
+	return &BandwidthUsage{BudgetBytes: budget, UsedBytes: used, WindowStart: start.UTC(), Paused: used >= budget}
+}
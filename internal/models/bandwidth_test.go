@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBandwidthBudget(t *testing.T) {
+	assert.NoError(t, ValidateBandwidthBudget(0))
+	assert.NoError(t, ValidateBandwidthBudget(50<<30))
+	assert.Error(t, ValidateBandwidthBudget(-1))
+
+	config := ApplicationConfig{BandwidthBudget: -1}
+	assert.ErrorContains(t, config.Validate(), "bandwidth_budget")
+}
+
+func TestNewBandwidthUsage(t *testing.T) {
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	usage := NewBandwidthUsage(100, 99, start)
+	assert.False(t, usage.Paused)
+	assert.Equal(t, time.UTC, usage.WindowStart.Location())
+	assert.True(t, NewBandwidthUsage(100, 100, start).Paused)
+}
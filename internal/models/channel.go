@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Releases are published to a channel, a track clients subscribe to such as
+// stable, beta or nightly. The built-in channels are ordered from most to
+// least stable, and a client on one of them is also offered the releases of
+// the more stable ones, so a beta client gets a stable release that is newer
+// than the newest beta. Other channels, such as lts, only offer their own
+// releases. A release registered without a channel is on beta when its
+// version is a pre-release and on stable otherwise. Clients that follow no
+// channel are offered stable, or beta when they allow pre-releases.
+
+// Built-in channels.
+const (
+	ChannelStable  = "stable"
+	ChannelBeta    = "beta"
+	ChannelNightly = "nightly"
+)
+
+// MaxChannelLength is the maximum length of a channel name.
+const MaxChannelLength = 50
+
+// builtinChannels are the built-in channels, most stable first.
+var builtinChannels = []string{ChannelStable, ChannelBeta, ChannelNightly}
+
+// NormalizeChannel lowercases and trims a channel name.
+func NormalizeChannel(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateChannel checks a channel name, which uses the tag format.
+func ValidateChannel(name string) error {
+	if len(name) > MaxChannelLength {
+		return fmt.Errorf("channel %q exceeds maximum length of %d", name, MaxChannelLength)
+	}
+	if !tagPattern.MatchString(name) {
+		return fmt.Errorf("invalid channel %q: must contain only lowercase letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// DefaultChannel is the channel of a release registered without one.
+func DefaultChannel(version string) string {
+	if v, err := semver.NewVersion(version); err == nil && v.Prerelease() != "" {
+		return ChannelBeta
+	}
+	return ChannelStable
+}
+
+// EffectiveChannel returns the release's channel, or its default channel for
+// releases stored before channels existed.
+func (r *Release) EffectiveChannel() string {
+	if r.Channel != "" {
+		return r.Channel
+	}
+	return DefaultChannel(r.Version)
+}
+
+// ChannelIncludes returns the channels whose releases a client subscribed to
+// channel is offered, most stable first.
+func ChannelIncludes(channel string) []string {
+	if i := slices.Index(builtinChannels, channel); i >= 0 {
+		return slices.Clone(builtinChannels[:i+1])
+	}
+	return []string{channel}
+}
+
+// ChannelOffers reports whether a client subscribed to channel is offered a
+// release.
+func ChannelOffers(channel string, release *Release) bool {
+	return slices.Contains(ChannelIncludes(channel), release.EffectiveChannel())
+}
+
+// ChannelSummary describes one channel of an application.
+type ChannelSummary struct {
+	Name          string   `json:"name"`
+	Includes      []string `json:"includes"`                 // Channels whose releases clients of this channel are offered
+	Releases      int      `json:"releases"`                 // Releases published to the channel, counting each platform
+	LatestVersion string   `json:"latest_version,omitempty"` // Newest version published to the channel
+}
+
+// ChannelListResponse is the response of GET /api/v1/applications/{app_id}/channels.
+// The built-in channels are always listed, followed by the other channels in
+// use in alphabetical order.
+type ChannelListResponse struct {
+	ApplicationID string           `json:"application_id"`
+	Channels      []ChannelSummary `json:"channels"`
+}
+
+// ListChannels summarizes the channels of an application's releases.
+func ListChannels(appID string, releases []*Release) *ChannelListResponse {
+	summaries := make(map[string]*ChannelSummary)
+	latest := make(map[string]*semver.Version)
+	for _, name := range builtinChannels {
+		summaries[name] = &ChannelSummary{Name: name}
+	}
+	for _, r := range releases {
+		name := r.EffectiveChannel()
+		s, ok := summaries[name]
+		if !ok {
+			s = &ChannelSummary{Name: name}
+			summaries[name] = s
+		}
+		s.Releases++
+		if v, err := semver.NewVersion(r.Version); err == nil && (latest[name] == nil || v.GreaterThan(latest[name])) {
+			latest[name] = v
+			s.LatestVersion = r.Version
+		}
+	}
+
+	resp := &ChannelListResponse{ApplicationID: appID, Channels: []ChannelSummary{}}
+	var custom []string
+	for name := range summaries {
+		if !slices.Contains(builtinChannels, name) {
+			custom = append(custom, name)
+		}
+	}
+	slices.Sort(custom)
+	for _, name := range append(slices.Clone(builtinChannels), custom...) {
+		s := summaries[name]
+		s.Includes = ChannelIncludes(name)
+		resp.Channels = append(resp.Channels, *s)
+	}
+	return resp
+}
+
+// PublishToChannelResponse is the response of moving a version to a channel.
+type PublishToChannelResponse struct {
+	ApplicationID string   `json:"application_id"`
+	Channel       string   `json:"channel"`
+	Version       string   `json:"version"`
+	ReleaseIDs    []string `json:"release_ids"` // Releases of the version, one per platform, now on the channel
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateChannel(t *testing.T) {
+	assert.NoError(t, ValidateChannel("stable"))
+	assert.NoError(t, ValidateChannel("lts-2026"))
+	assert.Error(t, ValidateChannel(""))
+	assert.Error(t, ValidateChannel("Beta"))
+	assert.Error(t, ValidateChannel("early access"))
+	assert.Error(t, ValidateChannel(strings.Repeat("a", MaxChannelLength+1)))
+}
+
+func TestRelease_EffectiveChannel(t *testing.T) {
+	release := NewRelease("app", "1.0.0", "linux", "amd64", "https://example.com/app")
+	assert.Equal(t, ChannelStable, release.EffectiveChannel())
+
+	release.Version = "1.1.0-rc.1"
+	assert.Equal(t, ChannelBeta, release.EffectiveChannel(), "pre-releases default to beta")
+
+	release.Channel = ChannelNightly
+	assert.Equal(t, ChannelNightly, release.EffectiveChannel())
+}
+
+func TestChannelOffers(t *testing.T) {
+	stable := &Release{Version: "1.0.0"}
+	beta := &Release{Version: "1.1.0-beta.1"}
+	lts := &Release{Version: "1.0.1", Channel: "lts"}
+
+	assert.True(t, ChannelOffers(ChannelStable, stable))
+	assert.False(t, ChannelOffers(ChannelStable, beta))
+	assert.True(t, ChannelOffers(ChannelBeta, stable), "beta clients get newer stable releases")
+	assert.True(t, ChannelOffers(ChannelNightly, beta))
+	assert.False(t, ChannelOffers(ChannelNightly, lts))
+	assert.True(t, ChannelOffers("lts", lts))
+	assert.False(t, ChannelOffers("lts", stable), "custom channels only offer their own releases")
+}
+
+func TestListChannels(t *testing.T) {
+	resp := ListChannels("app", []*Release{
+		{Version: "1.0.0"},
+		{Version: "1.2.0"},
+		{Version: "1.10.0-beta.1"},
+		{Version: "1.0.1", Channel: "lts"},
+		{Version: "1.0.2", Channel: "edu"},
+	})
+	require.Len(t, resp.Channels, 5)
+
+	names := make([]string, 0, len(resp.Channels))
+	for _, c := range resp.Channels {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"stable", "beta", "nightly", "edu", "lts"}, names)
+	assert.Equal(t, ChannelSummary{Name: "stable", Includes: []string{"stable"}, Releases: 2, LatestVersion: "1.2.0"}, resp.Channels[0])
+	assert.Equal(t, []string{"stable", "beta"}, resp.Channels[1].Includes)
+	assert.Equal(t, "1.10.0-beta.1", resp.Channels[1].LatestVersion)
+	assert.Zero(t, resp.Channels[2].Releases, "built-in channels are listed even when empty")
+	assert.Equal(t, []string{"lts"}, resp.Channels[4].Includes)
+}
+
+func TestUpdateCheckRequest_Channel(t *testing.T) {
+	req := UpdateCheckRequest{ApplicationID: "app", CurrentVersion: "1.0.0", Platform: "linux", Architecture: "amd64", Channel: " Beta "}
+	require.NoError(t, req.Validate())
+	req.Normalize()
+	assert.Equal(t, ChannelBeta, req.Channel)
+
+	req.Channel = "early access"
+	assert.Error(t, req.Validate())
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// A release has one primary checksum in Checksum and ChecksumType, which
+// every client understands, and optionally more checksums of the same file
+// keyed by checksum type. Publishing several lets clients with different
+// verification stacks, such as one without BLAKE3, all verify the download.
+
+// ValidateChecksums checks a release's additional checksums. Each key must be
+// a supported checksum type other than the primary one, and each value must be
+// set.
+func ValidateChecksums(checksums map[string]string, primaryType string) error {
+	for _, checksumType := range slices.Sorted(maps.Keys(checksums)) {
+		if !isValidChecksumType(checksumType) {
+			return fmt.Errorf("invalid checksums type: %s", checksumType)
+		}
+		if strings.EqualFold(checksumType, primaryType) {
+			return fmt.Errorf("checksums cannot repeat the primary checksum_type %s", primaryType)
+		}
+		if strings.TrimSpace(checksums[checksumType]) == "" {
+			return fmt.Errorf("checksums.%s cannot be empty", checksumType)
+		}
+	}
+	return nil
+}
+
+// NormalizeChecksums returns a copy of checksums with lowercase types and
+// values, or nil when there are none.
+func NormalizeChecksums(checksums map[string]string) map[string]string {
+	if len(checksums) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(checksums))
+	for checksumType, checksum := range checksums {
+		out[strings.ToLower(strings.TrimSpace(checksumType))] = strings.ToLower(strings.TrimSpace(checksum))
+	}
+	return out
+}
+
+// AllChecksums returns every checksum of the release keyed by type, including
+// the primary one.
+func (r *Release) AllChecksums() map[string]string {
+	all := make(map[string]string, len(r.Checksums)+1)
+	maps.Copy(all, r.Checksums)
+	all[r.ChecksumType] = r.Checksum
+	return all
+}
+
+// copyChecksums returns a copy of checksums, or nil when there are none, so
+// that responses omit the field for single-checksum releases.
+func copyChecksums(checksums map[string]string) map[string]string {
+	if len(checksums) == 0 {
+		return nil
+	}
+	return maps.Clone(checksums)
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChecksums(t *testing.T) {
+	assert.NoError(t, ValidateChecksums(nil, ChecksumTypeSHA256))
+	assert.NoError(t, ValidateChecksums(map[string]string{"sha512": "abc", "BLAKE3": "def"}, ChecksumTypeSHA256))
+	assert.ErrorContains(t, ValidateChecksums(map[string]string{"crc32": "abc"}, ChecksumTypeSHA256), "invalid checksums type")
+	assert.ErrorContains(t, ValidateChecksums(map[string]string{"SHA256": "abc"}, ChecksumTypeSHA256), "primary")
+	assert.ErrorContains(t, ValidateChecksums(map[string]string{"sha512": " "}, ChecksumTypeSHA256), "checksums.sha512")
+}
+
+func TestNormalizeChecksums(t *testing.T) {
+	assert.Nil(t, NormalizeChecksums(map[string]string{}))
+	assert.Equal(t, map[string]string{"sha512": "abcdef"}, NormalizeChecksums(map[string]string{" SHA512 ": "ABCDEF"}))
+}
+
+func TestRelease_VerifyChecksum_Multiple(t *testing.T) {
+	data := []byte("test data")
+	sha256sum, _ := computeChecksum(ChecksumTypeSHA256, data)
+	sha512sum, _ := computeChecksum(ChecksumTypeSHA512, data)
+	blake3sum, _ := computeChecksum(ChecksumTypeBLAKE3, data)
+
+	release := &Release{
+		Checksum:     sha256sum,
+		ChecksumType: ChecksumTypeSHA256,
+		Checksums:    map[string]string{ChecksumTypeSHA512: sha512sum, ChecksumTypeBLAKE3: blake3sum},
+	}
+	assert.True(t, release.VerifyChecksum(data))
+
+	release.Checksums[ChecksumTypeSHA512] = "0000"
+	assert.False(t, release.VerifyChecksum(data), "every checksum must match")
+
+	blake3Only := &Release{Checksum: blake3sum, ChecksumType: ChecksumTypeBLAKE3}
+	assert.True(t, blake3Only.VerifyChecksum(data))
+	blake3Only.Checksum = "abc"
+	assert.False(t, blake3Only.VerifyChecksum(data))
+}
+
+func TestRelease_AllChecksums(t *testing.T) {
+	release := &Release{Checksum: "abc", ChecksumType: ChecksumTypeSHA256, Checksums: map[string]string{"sha512": "def"}}
+	assert.Equal(t, map[string]string{"sha256": "abc", "sha512": "def"}, release.AllChecksums())
+	assert.Len(t, release.Checksums, 1)
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// ClientBundleAlgorithm is the signature algorithm of client configuration
+// bundles.
+const ClientBundleAlgorithm = "ed25519"
+
+// MaxClientBundleValidity bounds how long a signed bundle is valid. Clients
+// keep using a cached bundle until it expires, so a long validity delays a
+// rotated endpoint or key reaching clients that cannot fetch a new one.
+const MaxClientBundleValidity = 90 * 24 * time.Hour
+
+// ClientBundleConfig configures the signed bundle of client settings served
+// at /api/v1/client-bundle. Clients cache the bundle and verify it against a
+// public key they ship with, so feed URLs, check intervals, trusted keys and
+// kill switches can change without a client release.
+type ClientBundleConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// SigningKeyFile is a PEM-encoded PKCS#8 Ed25519 private key, as written
+	// by `updater signing-key generate`. Use SigningKeys instead to rotate keys.
+	SigningKeyFile string `yaml:"signing_key_file" json:"signing_key_file"`
+	// SigningKeys lists signing keys in rotation order, each with the period
+	// it signs bundles in. Periods overlap so that a key can be published
+	// before it is used and stays trusted while bundles it signed are cached.
+	SigningKeys []SigningKeyConfig `yaml:"signing_keys" json:"signing_keys"`
+	// Version must be raised whenever the settings change; clients refuse a
+	// bundle older than the one they have cached.
+	Version  int64         `yaml:"version" json:"version"`
+	Validity time.Duration `yaml:"validity" json:"validity"` // How long a signed bundle is accepted
+
+	CheckInterval time.Duration     `yaml:"check_interval" json:"check_interval"` // Time between update checks, when set
+	FeedURLs      []string          `yaml:"feed_urls" json:"feed_urls"`           // Base URLs clients check for updates, in order of preference
+	PublicKeys    []ClientPublicKey `yaml:"public_keys" json:"public_keys"`       // Keys clients trust
+	Flags         map[string]bool   `yaml:"flags" json:"flags"`                   // Kill switches and feature flags
+}
+
+// SigningKeyConfig is one key of a signing key rotation. The newest key whose
+// period has started signs; zero times leave the period open at that end.
+// The key is either a file or a Cloud KMS key version, never both.
+type SigningKeyConfig struct {
+	File string `yaml:"file" json:"file"` // PEM-encoded PKCS#8 Ed25519 private key
+	// GCPKMSKey is the resource name of an EC_SIGN_ED25519 Google Cloud KMS
+	// key version, which signs without the private key leaving KMS.
+	GCPKMSKey string    `yaml:"gcp_kms_key" json:"gcp_kms_key"`
+	NotBefore time.Time `yaml:"not_before" json:"not_before"`
+	NotAfter  time.Time `yaml:"not_after" json:"not_after"`
+}
+
+// gcpKMSKeyVersion matches the resource name of a Cloud KMS key version.
+var gcpKMSKeyVersion = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+/cryptoKeyVersions/[^/]+$`)
+
+// ClientPublicKey is a key handed to clients in the bundle. A key with use
+// client_bundle is trusted for later bundles, which is how the bundle signing
+// key is rotated: publish the new key in a bundle signed by the old one, then
+// switch signing keys once clients have picked it up.
+type ClientPublicKey struct {
+	ID  string `yaml:"id" json:"id"`
+	Use string `yaml:"use" json:"use"` // What the key verifies, such as client_bundle or release
+	Key string `yaml:"key" json:"key"` // The key in the encoding clients expect, such as PEM
+}
+
+// Validate checks the settings when the bundle is enabled.
+func (c *ClientBundleConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	switch {
+	case c.SigningKeyFile == "" && len(c.SigningKeys) == 0:
+		errs = append(errs, errors.New("signing_key_file or signing_keys is required"))
+	case c.SigningKeyFile != "" && len(c.SigningKeys) > 0:
+		errs = append(errs, errors.New("signing_key_file and signing_keys cannot both be set"))
+	}
+	for i, key := range c.SigningKeys {
+		switch {
+		case key.File == "" && key.GCPKMSKey == "":
+			errs = append(errs, fmt.Errorf("signing_keys[%d]: file or gcp_kms_key is required", i))
+		case key.File != "" && key.GCPKMSKey != "":
+			errs = append(errs, fmt.Errorf("signing_keys[%d]: file and gcp_kms_key cannot both be set", i))
+		case key.GCPKMSKey != "" && !gcpKMSKeyVersion.MatchString(key.GCPKMSKey):
+			errs = append(errs, fmt.Errorf("signing_keys[%d]: gcp_kms_key must be projects/P/locations/L/keyRings/R/cryptoKeys/K/cryptoKeyVersions/V", i))
+		}
+		if !key.NotAfter.IsZero() && !key.NotAfter.After(key.NotBefore) {
+			errs = append(errs, fmt.Errorf("signing_keys[%d]: not_after must be after not_before", i))
+		}
+		if i > 0 && !key.NotBefore.After(c.SigningKeys[i-1].NotBefore) {
+			errs = append(errs, fmt.Errorf("signing_keys[%d]: not_before must be after the previous key's", i))
+		}
+	}
+	if c.Version < 1 {
+		errs = append(errs, errors.New("version must be at least 1"))
+	}
+	if c.Validity <= 0 || c.Validity > MaxClientBundleValidity {
+		errs = append(errs, fmt.Errorf("validity must be positive and at most %s", MaxClientBundleValidity))
+	}
+	if c.CheckInterval < 0 {
+		errs = append(errs, errors.New("check_interval cannot be negative"))
+	}
+	for i, feed := range c.FeedURLs {
+		u, err := url.Parse(feed)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("feed_urls[%d] must be an absolute http or https URL", i))
+		}
+	}
+	ids := make(map[string]bool, len(c.PublicKeys))
+	for i, key := range c.PublicKeys {
+		switch {
+		case key.ID == "":
+			errs = append(errs, fmt.Errorf("public_keys[%d]: id is required", i))
+		case ids[key.ID]:
+			errs = append(errs, fmt.Errorf("public_keys[%d]: duplicate id %q", i, key.ID))
+		}
+		ids[key.ID] = true
+		if key.Use == "" {
+			errs = append(errs, fmt.Errorf("public_keys[%d]: use is required", i))
+		}
+		if key.Key == "" {
+			errs = append(errs, fmt.Errorf("public_keys[%d]: key is required", i))
+		}
+	}
+	for name := range c.Flags {
+		if name == "" {
+			errs = append(errs, errors.New("flags cannot have an empty name"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ClientBundle is the signed content of a client configuration bundle.
+// Clients reject a bundle past ExpiresAt or with a lower Version than the one
+// they have cached.
+type ClientBundle struct {
+	Version              int64             `json:"version"`
+	IssuedAt             time.Time         `json:"issued_at"`
+	ExpiresAt            time.Time         `json:"expires_at"`
+	CheckIntervalSeconds int64             `json:"check_interval_seconds,omitempty"`
+	FeedURLs             []string          `json:"feed_urls,omitempty"`
+	PublicKeys           []ClientPublicKey `json:"public_keys,omitempty"`
+	Flags                map[string]bool   `json:"flags,omitempty"`
+}
+
+// SignedClientBundle is the response of /api/v1/client-bundle. Payload is the
+// JSON-encoded ClientBundle exactly as signed; clients verify Signature over
+// the decoded payload bytes before parsing them.
+type SignedClientBundle struct {
+	Payload   string `json:"payload"`   // Standard base64 of the bundle JSON
+	Signature string `json:"signature"` // Standard base64 of the signature over the bundle JSON
+	Algorithm string `json:"algorithm"` // Always ed25519
+	KeyID     string `json:"key_id"`    // Identifies the signing key among those a client trusts
+}
+
+// Statuses of a key in the signing key history.
+const (
+	SigningKeyPending    = "pending"    // Period not started; clients should start trusting it
+	SigningKeyActive     = "active"     // Signs new bundles
+	SigningKeySuperseded = "superseded" // Replaced by a newer key; bundles it signed are still valid
+	SigningKeyRetired    = "retired"    // Period over; clients may stop trusting it
+)
+
+// SigningKeyHistoryResponse is the response of /api/v1/keys/client-bundle/history:
+// every configured signing key, oldest first.
+type SigningKeyHistoryResponse struct {
+	Keys []SigningKeyInfo `json:"keys"`
+}
+
+// SigningKeyInfo describes one signing key. Every key but the first carries an
+// endorsement by the key before it, so a client that trusts one key can
+// verify each later key in turn without a client release.
+type SigningKeyInfo struct {
+	KeyID       string          `json:"key_id"`
+	PublicKey   string          `json:"public_key"` // PEM-encoded PKIX public key
+	Status      string          `json:"status"`
+	NotBefore   *time.Time      `json:"not_before,omitempty"`
+	NotAfter    *time.Time      `json:"not_after,omitempty"`
+	Endorsement *KeyEndorsement `json:"endorsement,omitempty"`
+}
+
+// KeyEndorsement is a signed EndorsedKey. Payload is the JSON-encoded
+// EndorsedKey exactly as signed, like the payload of a SignedClientBundle.
+type KeyEndorsement struct {
+	Payload   string `json:"payload"`   // Standard base64 of the EndorsedKey JSON
+	Signature string `json:"signature"` // Standard base64 of the endorsing key's signature
+	KeyID     string `json:"key_id"`    // The endorsing key
+}
+
+// EndorsedKey is what an endorsement vouches for: a key and its period.
+type EndorsedKey struct {
+	KeyID     string     `json:"key_id"`
+	PublicKey string     `json:"public_key"` // Standard base64 of the raw 32-byte Ed25519 key
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+}
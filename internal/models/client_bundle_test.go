@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientBundleConfig_Validate(t *testing.T) {
+	jan2027 := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	const testGCPKMSKey = "projects/p/locations/global/keyRings/updater/cryptoKeys/bundle/cryptoKeyVersions/1"
+	valid := func() ClientBundleConfig {
+		return ClientBundleConfig{
+			Enabled:        true,
+			SigningKeyFile: "/etc/updater/client-bundle.pem",
+			Version:        1,
+			Validity:       7 * 24 * time.Hour,
+			FeedURLs:       []string{"https://updates.example.com"},
+			PublicKeys:     []ClientPublicKey{{ID: "release-2026", Use: "release", Key: "-----BEGIN PUBLIC KEY-----"}},
+			Flags:          map[string]bool{"disable_updates": false},
+		}
+	}
+	tests := []struct {
+		name    string
+		modify  func(*ClientBundleConfig)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*ClientBundleConfig) {}},
+		{name: "disabled", modify: func(c *ClientBundleConfig) { *c = ClientBundleConfig{} }},
+		{name: "missing key file", modify: func(c *ClientBundleConfig) { c.SigningKeyFile = "" }, wantErr: "signing_key_file or signing_keys is required"},
+		{name: "rotation", modify: func(c *ClientBundleConfig) {
+			c.SigningKeyFile = ""
+			c.SigningKeys = []SigningKeyConfig{{File: "/etc/updater/2026.pem", NotAfter: jan2027.AddDate(0, 2, 0)}, {File: "/etc/updater/2027.pem", NotBefore: jan2027}}
+		}},
+		{name: "key file and rotation", modify: func(c *ClientBundleConfig) { c.SigningKeys = []SigningKeyConfig{{File: "/etc/updater/2027.pem"}} }, wantErr: "cannot both be set"},
+		{name: "rotation key without file", modify: func(c *ClientBundleConfig) {
+			c.SigningKeyFile = ""
+			c.SigningKeys = []SigningKeyConfig{{}}
+		}, wantErr: "signing_keys[0]: file or gcp_kms_key is required"},
+		{name: "rotation to cloud KMS", modify: func(c *ClientBundleConfig) {
+			c.SigningKeyFile = ""
+			c.SigningKeys = []SigningKeyConfig{{File: "/etc/updater/2026.pem"}, {GCPKMSKey: testGCPKMSKey, NotBefore: jan2027}}
+		}},
+		{name: "rotation key with file and cloud KMS", modify: func(c *ClientBundleConfig) {
+			c.SigningKeyFile = ""
+			c.SigningKeys = []SigningKeyConfig{{File: "/etc/updater/2027.pem", GCPKMSKey: testGCPKMSKey}}
+		}, wantErr: "signing_keys[0]: file and gcp_kms_key cannot both be set"},
+		{name: "cloud KMS key without version", modify: func(c *ClientBundleConfig) {
+			c.SigningKeyFile = ""
+			c.SigningKeys = []SigningKeyConfig{{GCPKMSKey: "projects/p/locations/global/keyRings/updater/cryptoKeys/bundle"}}
+		}, wantErr: "signing_keys[0]: gcp_kms_key must be"},
+		{name: "rotation key ends before it starts", modify: func(c *ClientBundleConfig) {
+			c.SigningKeyFile = ""
+			c.SigningKeys = []SigningKeyConfig{{File: "/etc/updater/2027.pem", NotBefore: jan2027, NotAfter: jan2027}}
+		}, wantErr: "signing_keys[0]: not_after must be after not_before"},
+		{name: "rotation out of order", modify: func(c *ClientBundleConfig) {
+			c.SigningKeyFile = ""
+			c.SigningKeys = []SigningKeyConfig{{File: "/etc/updater/2027.pem", NotBefore: jan2027}, {File: "/etc/updater/2026.pem"}}
+		}, wantErr: "signing_keys[1]: not_before must be after the previous key's"},
+		{name: "zero version", modify: func(c *ClientBundleConfig) { c.Version = 0 }, wantErr: "version must be at least 1"},
+		{name: "no validity", modify: func(c *ClientBundleConfig) { c.Validity = 0 }, wantErr: "validity must be positive"},
+		{name: "validity too long", modify: func(c *ClientBundleConfig) { c.Validity = MaxClientBundleValidity + time.Hour }, wantErr: "validity must be positive"},
+		{name: "negative check interval", modify: func(c *ClientBundleConfig) { c.CheckInterval = -time.Minute }, wantErr: "check_interval cannot be negative"},
+		{name: "relative feed URL", modify: func(c *ClientBundleConfig) { c.FeedURLs = []string{"/updates"} }, wantErr: "feed_urls[0] must be"},
+		{name: "key without id", modify: func(c *ClientBundleConfig) { c.PublicKeys[0].ID = "" }, wantErr: "public_keys[0]: id is required"},
+		{name: "duplicate key id", modify: func(c *ClientBundleConfig) { c.PublicKeys = append(c.PublicKeys, c.PublicKeys[0]) }, wantErr: `public_keys[1]: duplicate id "release-2026"`},
+		{name: "key without use", modify: func(c *ClientBundleConfig) { c.PublicKeys[0].Use = "" }, wantErr: "public_keys[0]: use is required"},
+		{name: "empty key", modify: func(c *ClientBundleConfig) { c.PublicKeys[0].Key = "" }, wantErr: "public_keys[0]: key is required"},
+		{name: "empty flag name", modify: func(c *ClientBundleConfig) { c.Flags[""] = true }, wantErr: "flags cannot have an empty name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid()
+			tt.modify(&config)
+			err := config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
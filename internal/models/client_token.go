@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Bounds on ClientTokenConfig.Difficulty, in leading zero bits of the solution
+// hash. Each extra bit doubles the expected work: 20 bits takes a client
+// around a million hashes, well under a second on current hardware.
+const (
+	MinClientTokenDifficulty = 8
+	MaxClientTokenDifficulty = 32
+)
+
+// ClientTokenChallengeTTL is how long a client has to solve a challenge.
+const ClientTokenChallengeTTL = 5 * time.Minute
+
+// MaxClientTokenTTL bounds how long an issued client token stays valid.
+const MaxClientTokenTTL = 7 * 24 * time.Hour
+
+// MaxClientTokenNonceLength bounds the nonce a client may submit.
+const MaxClientTokenNonceLength = 64
+
+// ClientTokenConfig requires anonymous clients to present a token on the
+// public update check endpoints. Tokens are earned by solving a proof-of-work
+// challenge, which makes scraping and check floods cost the caller CPU time
+// without giving every client an API key.
+type ClientTokenConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Secret is the HMAC key challenges and tokens are signed with. Replicas
+	// behind one load balancer need the same secret; when empty a random key
+	// is generated at startup and tokens do not survive a restart.
+	Secret     string        `yaml:"secret" json:"-"`
+	Difficulty int           `yaml:"difficulty" json:"difficulty"` // Leading zero bits of the solution hash
+	TokenTTL   time.Duration `yaml:"token_ttl" json:"token_ttl"`   // How long an issued token is accepted
+}
+
+// Validate checks the settings when client tokens are enabled.
+func (c *ClientTokenConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	if c.Secret != "" && len(c.Secret) < 32 {
+		errs = append(errs, errors.New("secret must be at least 32 bytes"))
+	}
+	if c.Difficulty < MinClientTokenDifficulty || c.Difficulty > MaxClientTokenDifficulty {
+		errs = append(errs, fmt.Errorf("difficulty must be between %d and %d", MinClientTokenDifficulty, MaxClientTokenDifficulty))
+	}
+	if c.TokenTTL <= 0 || c.TokenTTL > MaxClientTokenTTL {
+		errs = append(errs, fmt.Errorf("token_ttl must be positive and at most %s", MaxClientTokenTTL))
+	}
+	return errors.Join(errs...)
+}
+
+// ClientTokenChallengeResponse is a proof-of-work challenge. The client finds
+// a nonce such that SHA-256(challenge + nonce) starts with Difficulty zero
+// bits and redeems it before ExpiresAt.
+type ClientTokenChallengeResponse struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// ClientTokenRequest redeems a solved challenge for a client token.
+type ClientTokenRequest struct {
+	Challenge string `json:"challenge"`
+	Nonce     string `json:"nonce"`
+}
+
+// Validate checks that both fields are present and the nonce is bounded.
+func (r *ClientTokenRequest) Validate() error {
+	if r.Challenge == "" {
+		return errors.New("challenge is required")
+	}
+	if r.Nonce == "" {
+		return errors.New("nonce is required")
+	}
+	if len(r.Nonce) > MaxClientTokenNonceLength {
+		return fmt.Errorf("nonce cannot exceed %d bytes", MaxClientTokenNonceLength)
+	}
+	return nil
+}
+
+// ClientTokenResponse is an issued client token, sent by the client in the
+// X-Client-Token header of update checks until ExpiresAt.
+type ClientTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
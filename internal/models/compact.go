@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompactField is one key/value pair of a compact response. Value is a
+// string, int64 or bool.
+type CompactField struct {
+	Key   string
+	Value any
+}
+
+// CompactFields is an ordered list of response fields for clients that cannot
+// afford JSON: embedded devices on the OTA endpoint and the CoAP gateway. It
+// encodes as flat key=value lines here; the api/encoding package encodes it
+// as a CBOR or MessagePack map with the same keys.
+type CompactFields []CompactField
+
+func (f CompactFields) addString(key, v string) CompactFields {
+	if v == "" {
+		return f
+	}
+	return append(f, CompactField{key, v})
+}
+
+func (f CompactFields) addInt(key string, v int64) CompactFields {
+	if v == 0 {
+		return f
+	}
+	return append(f, CompactField{key, v})
+}
+
+// addBool appends a boolean field. False values are omitted unless always is set.
+func (f CompactFields) addBool(key string, v, always bool) CompactFields {
+	if !v && !always {
+		return f
+	}
+	return append(f, CompactField{key, v})
+}
+
+// MarshalFlat encodes the fields as key=value lines, one field per line.
+func (f CompactFields) MarshalFlat() []byte {
+	var b strings.Builder
+	for _, field := range f {
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		switch v := field.Value.(type) {
+		case string:
+			b.WriteString(v)
+		case int64:
+			b.WriteString(strconv.FormatInt(v, 10))
+		case bool:
+			b.WriteString(strconv.FormatBool(v))
+		}
+		b.WriteByte('\n')
+	}
+	return []byte(b.String())
+}
+
+// CompactFields returns the release fields a constrained client needs to
+// download and verify the latest version. Release notes, dates and metadata
+// are left out.
+func (r *LatestVersionResponse) CompactFields() CompactFields {
+	var f CompactFields
+	f = f.addString("version", r.Version)
+	f = f.addString("download_url", r.DownloadURL)
+	f = f.addString("checksum", r.Checksum)
+	f = f.addString("checksum_type", r.ChecksumType)
+	f = f.addInt("file_size", r.FileSize)
+	f = f.addBool("required", r.Required, true)
+	return f
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestVersionResponse_CompactFields(t *testing.T) {
+	resp := &LatestVersionResponse{
+		Version:      "1.1.0",
+		DownloadURL:  "https://example.com/app.bin",
+		Checksum:     "abc",
+		ChecksumType: "sha256",
+		FileSize:     42,
+		ReleaseNotes: "left out",
+		ReleaseDate:  time.Now(),
+	}
+
+	assert.Equal(t, "version=1.1.0\ndownload_url=https://example.com/app.bin\nchecksum=abc\nchecksum_type=sha256\nfile_size=42\nrequired=false\n",
+		string(resp.CompactFields().MarshalFlat()))
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Release comparison statuses, one per platform/architecture target.
+const (
+	CompareStatusChanged   = "changed"   // Both versions have a release and some fields differ
+	CompareStatusUnchanged = "unchanged" // Both versions have a release with identical fields
+	CompareStatusAdded     = "added"     // Only the "to" version has a release
+	CompareStatusRemoved   = "removed"   // Only the "from" version has a release
+)
+
+// CompareReleasesRequest asks for the differences between the releases of two
+// versions of an application. Platform and Architecture optionally restrict
+// the comparison to matching targets.
+type CompareReleasesRequest struct {
+	ApplicationID string `json:"application_id" validate:"required"`
+	From          string `json:"from" validate:"required"`
+	To            string `json:"to" validate:"required"`
+	Platform      string `json:"platform,omitempty"`
+	Architecture  string `json:"architecture,omitempty"`
+}
+
+func (r *CompareReleasesRequest) Validate() error {
+	if r.ApplicationID == "" {
+		return errors.New("application_id is required")
+	}
+	if r.From == "" || r.To == "" {
+		return errors.New("from and to versions are required")
+	}
+	if err := validateVersion(strings.TrimSpace(r.From)); err != nil {
+		return fmt.Errorf("invalid from version: %w", err)
+	}
+	if err := validateVersion(strings.TrimSpace(r.To)); err != nil {
+		return fmt.Errorf("invalid to version: %w", err)
+	}
+	if r.Platform != "" && !isValidPlatform(r.Platform) {
+		return fmt.Errorf("invalid platform: %s", r.Platform)
+	}
+	if r.Architecture != "" && !isValidArchitecture(r.Architecture) {
+		return fmt.Errorf("invalid architecture: %s", r.Architecture)
+	}
+	return nil
+}
+
+func (r *CompareReleasesRequest) Normalize() {
+	r.ApplicationID = strings.TrimSpace(r.ApplicationID)
+	r.From = strings.TrimSpace(r.From)
+	r.To = strings.TrimSpace(r.To)
+	r.Platform = NormalizePlatform(strings.TrimSpace(r.Platform))
+	r.Architecture = NormalizeArchitecture(strings.TrimSpace(r.Architecture))
+}
+
+// CompareReleasesResponse lists one comparison per platform/architecture
+// target that either version has a release for, sorted by platform and
+// architecture.
+type CompareReleasesResponse struct {
+	ApplicationID string          `json:"application_id"`
+	From          string          `json:"from"`
+	To            string          `json:"to"`
+	Targets       []ReleaseTarget `json:"targets"`
+}
+
+// ReleaseTarget compares the releases of two versions on one platform and
+// architecture. FileSizeDelta is the "to" size minus the "from" size and is
+// only set when both releases exist.
+type ReleaseTarget struct {
+	Platform      string        `json:"platform"`
+	Architecture  string        `json:"architecture"`
+	Status        string        `json:"status"`
+	FileSizeDelta int64         `json:"file_size_delta"`
+	Changes       []FieldChange `json:"changes"`
+	From          *ReleaseInfo  `json:"from,omitempty"`
+	To            *ReleaseInfo  `json:"to,omitempty"`
+}
+
+// FieldChange is one release field whose value differs between two releases.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// DiffReleases returns the fields that differ between two releases of the
+// same target, in a fixed order. Identifiers, the version and audit
+// timestamps are not compared.
+func DiffReleases(from, to *Release) []FieldChange {
+	changes := []FieldChange{}
+	add := func(field string, a, b any, equal bool) {
+		if !equal {
+			changes = append(changes, FieldChange{Field: field, From: a, To: b})
+		}
+	}
+
+	add("download_url", from.DownloadURL, to.DownloadURL, from.DownloadURL == to.DownloadURL)
+	add("checksum", from.Checksum, to.Checksum, from.Checksum == to.Checksum)
+	add("checksum_type", from.ChecksumType, to.ChecksumType, from.ChecksumType == to.ChecksumType)
+	add("checksums", copyChecksums(from.Checksums), copyChecksums(to.Checksums), maps.Equal(from.Checksums, to.Checksums))
+	add("pgp_signature", from.PGPSignature, to.PGPSignature, from.PGPSignature == to.PGPSignature)
+	add("file_size", from.FileSize, to.FileSize, from.FileSize == to.FileSize)
+	add("release_notes", from.ReleaseNotes, to.ReleaseNotes, from.ReleaseNotes == to.ReleaseNotes)
+	add("release_date", from.ReleaseDate, to.ReleaseDate, from.ReleaseDate.Equal(to.ReleaseDate))
+	add("required", from.Required, to.Required, from.Required == to.Required)
+	add("minimum_version", from.MinimumVersion, to.MinimumVersion, from.MinimumVersion == to.MinimumVersion)
+	add("tags", copyTags(from.Tags), copyTags(to.Tags), slices.Equal(NormalizeTags(from.Tags), NormalizeTags(to.Tags)))
+	add("metadata", copyMetadata(from.Metadata), copyMetadata(to.Metadata), maps.Equal(from.Metadata, to.Metadata))
+	add("host_version_constraint", from.HostVersionConstraint, to.HostVersionConstraint, from.HostVersionConstraint == to.HostVersionConstraint)
+	add("required_entitlement", from.RequiredEntitlement, to.RequiredEntitlement, from.RequiredEntitlement == to.RequiredEntitlement)
+	add("editions", copyEditions(from.Editions), copyEditions(to.Editions), maps.Equal(from.Editions, to.Editions))
+	add("channel", from.EffectiveChannel(), to.EffectiveChannel(), from.EffectiveChannel() == to.EffectiveChannel())
+	add("targeting", from.Targeting, to.Targeting, slices.EqualFunc(from.Targeting, to.Targeting, TargetingRule.Equal))
+	add("variant", from.Variant, to.Variant, from.Variant == to.Variant)
+	add("paused", from.Paused, to.Paused, from.Paused == to.Paused)
+	add("yanked", from.Yanked, to.Yanked, from.Yanked == to.Yanked)
+	add("version_code", from.VersionCode, to.VersionCode, from.VersionCode == to.VersionCode)
+	add("signing_cert_sha256", from.SigningCertSHA256, to.SigningCertSHA256, from.SigningCertSHA256 == to.SigningCertSHA256)
+	add("variants", copyVariants(from.Variants), copyVariants(to.Variants), maps.Equal(from.Variants, to.Variants))
+
+	return changes
+}
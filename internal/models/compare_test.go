@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareReleasesRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  CompareReleasesRequest
+		errorMsg string
+	}{
+		{name: "valid", request: CompareReleasesRequest{ApplicationID: "app", From: "1.0.0", To: "1.1.0"}},
+		{name: "with target", request: CompareReleasesRequest{ApplicationID: "app", From: "1.0.0", To: "1.1.0", Platform: "Windows", Architecture: "amd64"}},
+		{name: "missing to", request: CompareReleasesRequest{ApplicationID: "app", From: "1.0.0"}, errorMsg: "from and to versions are required"},
+		{name: "invalid from", request: CompareReleasesRequest{ApplicationID: "app", From: "latest", To: "1.1.0"}, errorMsg: "invalid from version"},
+		{name: "invalid platform", request: CompareReleasesRequest{ApplicationID: "app", From: "1.0.0", To: "1.1.0", Platform: "beos"}, errorMsg: "invalid platform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDiffReleases(t *testing.T) {
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	from := NewRelease("app", "1.0.0", "windows", "amd64", "https://example.com/1.0.0/app.exe")
+	from.Checksum = "aaa"
+	from.FileSize = 1000
+	from.ReleaseDate = date
+	from.Tags = []string{"lts"}
+
+	t.Run("identical releases", func(t *testing.T) {
+		to := *from
+		to.ID = "other-id"
+		to.Version = "1.0.1"
+		to.CreatedAt = date.Add(time.Hour)
+		to.Metadata = nil
+		assert.Empty(t, DiffReleases(from, &to))
+	})
+
+	t.Run("changed fields in order", func(t *testing.T) {
+		to := *from
+		to.DownloadURL = "https://example.com/1.1.0/app.exe"
+		to.Checksum = "bbb"
+		to.FileSize = 1500
+		to.Required = true
+		to.Metadata = map[string]string{"signed": "true"}
+
+		changes := DiffReleases(from, &to)
+		fields := make([]string, len(changes))
+		for i, c := range changes {
+			fields[i] = c.Field
+		}
+		assert.Equal(t, []string{"download_url", "checksum", "file_size", "required", "metadata"}, fields)
+		assert.Equal(t, FieldChange{Field: "file_size", From: int64(1000), To: int64(1500)}, changes[2])
+	})
+}
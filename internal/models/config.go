@@ -12,7 +12,11 @@ package models
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net"
+	"net/netip"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -31,6 +35,12 @@ const (
 // - Security: Authentication and authorization
 // - Logging: Structured logging and output configuration
 // - Metrics: Monitoring and observability
+// - Entitlements: License checks for releases offered to paying clients only
+// - AutoFill: Fetching artifacts to fill in size and checksum on registration
+// - Artifacts: Uploaded release artifacts kept in blob storage
+// - NotesDrafts: External service drafting release notes from commits
+// - ClientBundle: Signed client settings served to clients
+// - ApplicationTemplates: Named defaults for creating applications
 //
 // Design Benefits:
 // - Single source of truth for all configuration
@@ -44,18 +54,85 @@ type Config struct {
 	Logging       LoggingConfig       `yaml:"logging" json:"logging"`             // Logging and output configuration
 	Metrics       MetricsConfig       `yaml:"metrics" json:"metrics"`             // Monitoring and metrics
 	Observability ObservabilityConfig `yaml:"observability" json:"observability"` // OpenTelemetry observability
+	CoAP          CoAPConfig          `yaml:"coap" json:"coap"`                   // Optional CoAP gateway for constrained devices
+	Entitlements  EntitlementsConfig  `yaml:"entitlements" json:"entitlements"`   // License token checks for gated releases
+	AutoFill      AutoFillConfig      `yaml:"auto_fill" json:"auto_fill"`         // Server-side artifact measurement for registrations
+	Artifacts     ArtifactsConfig     `yaml:"artifacts" json:"artifacts"`         // Artifact uploads and hosting
+	NotesDrafts   NotesDraftConfig    `yaml:"notes_drafts" json:"notes_drafts"`   // Release notes drafted from commits for review
+	ClientBundle  ClientBundleConfig  `yaml:"client_bundle" json:"client_bundle"` // Signed client settings bundle
+	Seed          SeedConfig          `yaml:"seed" json:"seed"`                   // Fixtures created at startup
+
+	ApplicationTemplates []ApplicationTemplate `yaml:"application_templates" json:"application_templates,omitempty"` // Named defaults for application creation
 }
 
 type ServerConfig struct {
-	Port            int           `yaml:"port" json:"port"`
-	Host            string        `yaml:"host" json:"host"`
-	ReadTimeout     time.Duration `yaml:"read_timeout" json:"read_timeout"`
-	WriteTimeout    time.Duration `yaml:"write_timeout" json:"write_timeout"`
-	IdleTimeout     time.Duration `yaml:"idle_timeout" json:"idle_timeout"`
-	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" json:"shutdown_timeout"`
-	TLSEnabled      bool          `yaml:"tls_enabled" json:"tls_enabled"`
-	TLSCertFile     string        `yaml:"tls_cert_file" json:"tls_cert_file"`
-	TLSKeyFile      string        `yaml:"tls_key_file" json:"tls_key_file"`
+	Port            int                 `yaml:"port" json:"port"`
+	Host            string              `yaml:"host" json:"host"`
+	ReadTimeout     time.Duration       `yaml:"read_timeout" json:"read_timeout"`
+	WriteTimeout    time.Duration       `yaml:"write_timeout" json:"write_timeout"`
+	IdleTimeout     time.Duration       `yaml:"idle_timeout" json:"idle_timeout"`
+	ShutdownTimeout time.Duration       `yaml:"shutdown_timeout" json:"shutdown_timeout"`
+	TLSEnabled      bool                `yaml:"tls_enabled" json:"tls_enabled"`
+	TLSCertFile     string              `yaml:"tls_cert_file" json:"tls_cert_file"`
+	TLSKeyFile      string              `yaml:"tls_key_file" json:"tls_key_file"`
+	Concurrency     ConcurrencyConfig   `yaml:"concurrency" json:"concurrency"`
+	SelfCheck       SelfCheckConfig     `yaml:"self_check" json:"self_check"`
+	AdminListener   AdminListenerConfig `yaml:"admin_listener" json:"admin_listener"`
+	SLO             SLOConfig           `yaml:"slo" json:"slo"`
+	// VanityHosts maps custom hostnames, such as updates.myproduct.com, to the
+	// application their update checks are for, so clients of that host can
+	// call /check instead of /api/v1/updates/{app_id}/check.
+	VanityHosts map[string]string `yaml:"vanity_hosts" json:"vanity_hosts,omitempty"`
+}
+
+// AdminListenerConfig moves the admin and key-management API to a listener of
+// its own, such as a loopback port or a Unix socket reachable only from inside
+// the host, so the public listener does not serve those routes at all. Socket,
+// when set, is used instead of Host and Port.
+type AdminListenerConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Host    string `yaml:"host" json:"host"`
+	Port    int    `yaml:"port" json:"port"`
+	Socket  string `yaml:"socket" json:"socket"` // Path of a Unix socket
+}
+
+// ConcurrencyConfig caps the requests served at once per route class, so a
+// spike of client checks cannot starve release and admin operations. Requests
+// over a class's limit wait in its queue for up to QueueTimeout and are
+// answered with 503 when the queue is full or the wait runs out. Update checks
+// shed from the public lane get a second chance in the Priority lane, where
+// they are only answered if they offer a required or security release.
+// Requests that clients mark as background checks are shed first.
+type ConcurrencyConfig struct {
+	Enabled       bool             `yaml:"enabled" json:"enabled"`
+	QueueTimeout  time.Duration    `yaml:"queue_timeout" json:"queue_timeout"`
+	Public        ConcurrencyLimit `yaml:"public" json:"public"`               // Unauthenticated client endpoints
+	Authenticated ConcurrencyLimit `yaml:"authenticated" json:"authenticated"` // Read and write key endpoints
+	Admin         ConcurrencyLimit `yaml:"admin" json:"admin"`                 // Admin endpoints and dry runs
+	Priority      ConcurrencyLimit `yaml:"priority" json:"priority"`           // Shed checks that may offer a security update
+	// BackgroundReserve is the share of public slots background checks may
+	// not take, so interactive checks still get through while scheduled
+	// checks saturate the lane.
+	BackgroundReserve    float64       `yaml:"background_reserve" json:"background_reserve"`
+	BackgroundRetryAfter time.Duration `yaml:"background_retry_after" json:"background_retry_after"` // Retry-After sent to shed background checks
+}
+
+// ConcurrencyLimit is the size of one route class's lane.
+type ConcurrencyLimit struct {
+	MaxInFlight int `yaml:"max_in_flight" json:"max_in_flight"`
+	MaxQueue    int `yaml:"max_queue" json:"max_queue"`
+}
+
+// SelfCheckConfig controls the startup self-check, which probes storage, the
+// database schema, the TLS certificate, the clock and the log directory before
+// the server accepts traffic and logs one line per check. Failed checks only
+// log unless RefuseOnFailure is set; warnings, such as a certificate close to
+// expiry, never stop startup.
+type SelfCheckConfig struct {
+	Enabled         bool          `yaml:"enabled" json:"enabled"`
+	RefuseOnFailure bool          `yaml:"refuse_on_failure" json:"refuse_on_failure"`
+	Timeout         time.Duration `yaml:"timeout" json:"timeout"`                   // Limit for the whole self-check
+	CertExpiryWarn  time.Duration `yaml:"cert_expiry_warn" json:"cert_expiry_warn"` // Warn when the TLS certificate expires sooner
 }
 
 type StorageConfig struct {
@@ -63,6 +140,9 @@ type StorageConfig struct {
 	Path     string            `yaml:"path" json:"path"`
 	Database DatabaseConfig    `yaml:"database" json:"database"`
 	Options  map[string]string `yaml:"options" json:"options"`
+	// IDStrategy generates the IDs of new releases: ulid (the default) or
+	// uuid. Stored releases keep their IDs.
+	IDStrategy string `yaml:"id_strategy" json:"id_strategy"`
 }
 
 type DatabaseConfig struct {
@@ -83,6 +163,41 @@ type SecurityConfig struct {
 	BootstrapKey string `yaml:"bootstrap_key" json:"-"`
 	// EnableAuth toggles API key authentication. When false all endpoints are public.
 	EnableAuth bool `yaml:"enable_auth" json:"enable_auth"`
+	// DownloadURLs restricts the download URLs releases can be registered with.
+	DownloadURLs DownloadURLPolicy `yaml:"download_urls" json:"download_urls"`
+	// RejectWeakChecksums refuses new releases with md5 or sha1 checksums.
+	RejectWeakChecksums bool `yaml:"reject_weak_checksums" json:"reject_weak_checksums"`
+	// PGPPublicKeyFile is an ASCII-armored public key served at /api/v1/keys/pgp
+	// so users can verify release signatures with gpg.
+	PGPPublicKeyFile string `yaml:"pgp_public_key_file" json:"pgp_public_key_file"`
+	// ClientTokens requires anonymous clients to earn a token by proof of work
+	// before calling the public update check endpoints.
+	ClientTokens ClientTokenConfig `yaml:"client_tokens" json:"client_tokens"`
+	// AnomalyDetection temporarily blocks clients whose update checks look
+	// like scraping or abuse.
+	AnomalyDetection AnomalyDetectionConfig `yaml:"anomaly_detection" json:"anomaly_detection"`
+	// TrustedProxies lists the proxies, as CIDRs or single addresses, whose
+	// X-Forwarded-For and X-Real-IP headers are believed. Requests from other
+	// peers are attributed to the peer itself. When empty, no peer is
+	// trusted and the headers are ignored.
+	TrustedProxies []string `yaml:"trusted_proxies" json:"trusted_proxies"`
+	// Credentials accepts API keys outside the Authorization header for
+	// legacy clients.
+	Credentials CredentialsConfig `yaml:"credentials" json:"credentials"`
+}
+
+// ParseTrustedProxy parses a trusted proxy entry: a CIDR such as 10.0.0.0/8
+// or a single address, which is treated as a /32 or /128.
+func ParseTrustedProxy(entry string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(entry); err == nil {
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid trusted proxy %q: must be a CIDR or IP address", entry)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
 }
 
 type LoggingConfig struct {
@@ -98,13 +213,49 @@ type MetricsConfig struct {
 	Port    int    `yaml:"port" json:"port"`
 }
 
+// CoAPConfig configures the optional CoAP gateway. The gateway listens on UDP
+// and serves unauthenticated check and latest requests, like the public HTTP
+// endpoints.
+type CoAPConfig struct {
+	Enabled    bool   `yaml:"enabled" json:"enabled"`
+	Host       string `yaml:"host" json:"host"`
+	Port       int    `yaml:"port" json:"port"`
+	Workers    int    `yaml:"workers" json:"workers"`         // Requests handled at once
+	QueueDepth int    `yaml:"queue_depth" json:"queue_depth"` // Requests waiting for a worker; more are dropped
+}
+
 // ObservabilityConfig holds configuration for OpenTelemetry-based observability.
 // Note: ServiceVersion is now set at build time via ldflags, not via configuration.
 type ObservabilityConfig struct {
-	ServiceName string        `yaml:"service_name" json:"service_name"`
-	Tracing     TracingConfig `yaml:"tracing" json:"tracing"`
+	ServiceName   string              `yaml:"service_name" json:"service_name"`
+	Tracing       TracingConfig       `yaml:"tracing" json:"tracing"`
+	DecisionLog   DecisionLogConfig   `yaml:"decision_log" json:"decision_log"`
+	HealthHistory HealthHistoryConfig `yaml:"health_history" json:"health_history"`
+	StatusPage    StatusPageConfig    `yaml:"status_page" json:"status_page"`
+}
+
+// DecisionLogConfig enables the in-memory log of update check decision traces.
+// Size is the number of checks kept; older checks are dropped first.
+type DecisionLogConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	Size    int  `yaml:"size" json:"size"`
 }
 
+// MaxDecisionLogSize bounds the memory the decision log can hold.
+const MaxDecisionLogSize = 100000
+
+// HealthHistoryConfig enables periodic health sampling. A sample is taken every
+// Interval and samples older than Retention are dropped.
+type HealthHistoryConfig struct {
+	Enabled   bool          `yaml:"enabled" json:"enabled"`
+	Interval  time.Duration `yaml:"interval" json:"interval"`
+	Retention time.Duration `yaml:"retention" json:"retention"`
+}
+
+// MaxHealthSamples bounds the number of health samples kept, which is
+// retention divided by interval.
+const MaxHealthSamples = 10080
+
 // TracingConfig holds configuration for distributed tracing.
 type TracingConfig struct {
 	Enabled      bool    `yaml:"enabled" json:"enabled"`
@@ -138,10 +289,43 @@ func NewDefaultConfig() *Config {
 			IdleTimeout:     60 * time.Second,
 			ShutdownTimeout: 30 * time.Second,
 			TLSEnabled:      false,
+			Concurrency: ConcurrencyConfig{
+				Enabled:       false,
+				QueueTimeout:  time.Second,
+				Public:        ConcurrencyLimit{MaxInFlight: 512, MaxQueue: 1024},
+				Authenticated: ConcurrencyLimit{MaxInFlight: 64, MaxQueue: 128},
+				Admin:         ConcurrencyLimit{MaxInFlight: 16, MaxQueue: 32},
+				Priority:      ConcurrencyLimit{MaxInFlight: 32, MaxQueue: 64},
+
+				BackgroundReserve:    0.125,
+				BackgroundRetryAfter: 30 * time.Second,
+			},
+			SelfCheck: SelfCheckConfig{
+				Enabled:        true,
+				Timeout:        10 * time.Second,
+				CertExpiryWarn: 14 * 24 * time.Hour,
+			},
+			AdminListener: AdminListenerConfig{
+				Enabled: false,
+				Host:    "127.0.0.1",
+				Port:    8081,
+			},
+			SLO: SLOConfig{
+				Enabled:     false,
+				Window:      5 * time.Minute,
+				MinRequests: 100,
+				Degrade: SLODegradeConfig{
+					Enabled:    false,
+					BurnRate:   1,
+					StaleFor:   10 * time.Minute,
+					MaxEntries: 1024,
+				},
+			},
 		},
 		Storage: StorageConfig{
-			Type: "sqlite",
-			Path: "./data/updater.db",
+			Type:       "sqlite",
+			Path:       "./data/updater.db",
+			IDStrategy: IDStrategyULID,
 			Database: DatabaseConfig{
 				Driver:          "sqlite3",
 				DSN:             "./data/updater.db",
@@ -154,6 +338,22 @@ func NewDefaultConfig() *Config {
 		},
 		Security: SecurityConfig{
 			EnableAuth: false,
+			ClientTokens: ClientTokenConfig{
+				Difficulty: 20,
+				TokenTTL:   time.Hour,
+			},
+			AnomalyDetection: AnomalyDetectionConfig{
+				Window:                  time.Minute,
+				BlockDuration:           15 * time.Minute,
+				UnknownApplicationLimit: 20,
+				FutureVersionLimit:      10,
+			},
+			Credentials: CredentialsConfig{
+				QueryToken: QueryTokenConfig{
+					Param:  DefaultQueryTokenParam,
+					MaxTTL: 30 * 24 * time.Hour,
+				},
+			},
 		},
 		Logging: LoggingConfig{
 			Level:  "info",
@@ -172,6 +372,49 @@ func NewDefaultConfig() *Config {
 				Exporter:   "stdout",
 				SampleRate: 1.0,
 			},
+			DecisionLog: DecisionLogConfig{
+				Enabled: false,
+				Size:    10000,
+			},
+			HealthHistory: HealthHistoryConfig{
+				Enabled:   false,
+				Interval:  time.Minute,
+				Retention: 24 * time.Hour,
+			},
+			StatusPage: StatusPageConfig{
+				Enabled:         false,
+				CheckWindow:     time.Hour,
+				CacheTTL:        30 * time.Second,
+				RateLimit:       60,
+				RateLimitWindow: time.Minute,
+			},
+		},
+		CoAP: CoAPConfig{
+			Enabled:    false,
+			Host:       "0.0.0.0",
+			Port:       5683,
+			Workers:    16,
+			QueueDepth: 256,
+		},
+		Entitlements: EntitlementsConfig{
+			JWT:  JWTEntitlementsConfig{Claim: "entitlements"},
+			HTTP: HTTPEntitlementsConfig{Timeout: 5 * time.Second},
+		},
+		AutoFill: AutoFillConfig{
+			Timeout: 10 * time.Minute,
+			MaxSize: 4 << 30,
+		},
+		Artifacts: ArtifactsConfig{
+			Driver:  ArtifactDriverLocal,
+			MaxSize: 4 << 30,
+			Timeout: time.Hour,
+		},
+		NotesDrafts: NotesDraftConfig{
+			Timeout: 20 * time.Second,
+		},
+		ClientBundle: ClientBundleConfig{
+			Version:  1,
+			Validity: 7 * 24 * time.Hour,
 		},
 	}
 }
@@ -197,11 +440,35 @@ func (c *Config) Validate() error {
 	if err := c.Observability.Validate(); err != nil {
 		errs = append(errs, fmt.Errorf("invalid observability config: %w", err))
 	}
+	if err := c.CoAP.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid coap config: %w", err))
+	}
+	if err := c.Entitlements.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid entitlements config: %w", err))
+	}
+	if err := c.AutoFill.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid auto_fill config: %w", err))
+	}
+	if err := c.Artifacts.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid artifacts config: %w", err))
+	}
+	if err := c.NotesDrafts.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid notes_drafts config: %w", err))
+	}
+	if err := c.ClientBundle.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid client_bundle config: %w", err))
+	}
+	if err := ValidateApplicationTemplates(c.ApplicationTemplates); err != nil {
+		errs = append(errs, fmt.Errorf("invalid application templates: %w", err))
+	}
 
 	// Cross-field: server and metrics ports must not conflict.
 	if c.Metrics.Enabled && c.Server.Port > 0 && c.Metrics.Port > 0 && c.Server.Port == c.Metrics.Port {
 		errs = append(errs, fmt.Errorf("server port and metrics port must not be the same (both are %d)", c.Server.Port))
 	}
+	if c.Metrics.Enabled && c.Server.AdminListener.Enabled && c.Server.AdminListener.Socket == "" && c.Server.AdminListener.Port == c.Metrics.Port {
+		errs = append(errs, fmt.Errorf("admin listener port and metrics port must not be the same (both are %d)", c.Metrics.Port))
+	}
 
 	return errors.Join(errs...)
 }
@@ -235,7 +502,63 @@ func (sc *ServerConfig) Validate() error {
 			errs = append(errs, errors.New("TLS key file is required when TLS is enabled"))
 		}
 	}
+	if sc.Concurrency.Enabled {
+		errs = append(errs, sc.Concurrency.Validate())
+	}
+	if err := sc.SLO.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("slo: %w", err))
+	}
+	if sc.AdminListener.Enabled && sc.AdminListener.Socket == "" {
+		if sc.AdminListener.Port <= 0 || sc.AdminListener.Port > 65535 {
+			errs = append(errs, errors.New("admin listener port must be between 1 and 65535"))
+		} else if sc.AdminListener.Port == sc.Port {
+			errs = append(errs, fmt.Errorf("admin listener port must differ from the server port (both are %d)", sc.Port))
+		}
+	}
+	for _, host := range slices.Sorted(maps.Keys(sc.VanityHosts)) {
+		appID := sc.VanityHosts[host]
+		if host == "" || host != strings.ToLower(host) || strings.ContainsAny(host, ":/ ") {
+			errs = append(errs, fmt.Errorf("vanity host %q must be a lower-case hostname without scheme or port", host))
+		}
+		if appID == "" {
+			errs = append(errs, fmt.Errorf("vanity host %q must map to an application ID", host))
+		}
+	}
+	if sc.SelfCheck.Enabled {
+		if sc.SelfCheck.Timeout <= 0 {
+			errs = append(errs, errors.New("self-check timeout must be positive"))
+		}
+		if sc.SelfCheck.CertExpiryWarn < 0 {
+			errs = append(errs, errors.New("self-check cert_expiry_warn cannot be negative"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
 
+func (cc *ConcurrencyConfig) Validate() error {
+	var errs []error
+	if cc.QueueTimeout < 0 {
+		errs = append(errs, errors.New("concurrency queue timeout cannot be negative"))
+	}
+	lanes := []struct {
+		class string
+		limit ConcurrencyLimit
+	}{{"public", cc.Public}, {"authenticated", cc.Authenticated}, {"admin", cc.Admin}, {"priority", cc.Priority}}
+	for _, lane := range lanes {
+		if lane.limit.MaxInFlight < 1 {
+			errs = append(errs, fmt.Errorf("concurrency %s max_in_flight must be at least 1", lane.class))
+		}
+		if lane.limit.MaxQueue < 0 {
+			errs = append(errs, fmt.Errorf("concurrency %s max_queue cannot be negative", lane.class))
+		}
+	}
+	if cc.BackgroundReserve < 0 || cc.BackgroundReserve >= 1 {
+		errs = append(errs, errors.New("concurrency background_reserve must be at least 0 and less than 1"))
+	}
+	if cc.BackgroundRetryAfter < time.Second {
+		errs = append(errs, errors.New("concurrency background_retry_after must be at least 1s"))
+	}
 	return errors.Join(errs...)
 }
 
@@ -258,6 +581,11 @@ func (stc *StorageConfig) Validate() error {
 	if found && stc.Type != StorageTypeMemory && stc.Database.DSN == "" {
 		errs = append(errs, errors.New("database DSN is required for database storage"))
 	}
+	if stc.IDStrategy != "" {
+		if _, err := NewIDGenerator(stc.IDStrategy); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	return errors.Join(errs...)
 }
@@ -268,6 +596,23 @@ func (sec *SecurityConfig) Validate() error {
 	if sec.EnableAuth && sec.BootstrapKey == "" {
 		errs = append(errs, errors.New("bootstrap key is required when auth is enabled"))
 	}
+	if err := sec.DownloadURLs.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("download_urls: %w", err))
+	}
+	if err := sec.ClientTokens.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("client_tokens: %w", err))
+	}
+	if err := sec.AnomalyDetection.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("anomaly_detection: %w", err))
+	}
+	for _, proxy := range sec.TrustedProxies {
+		if _, err := ParseTrustedProxy(proxy); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := sec.Credentials.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("credentials: %w", err))
+	}
 
 	return errors.Join(errs...)
 }
@@ -335,13 +680,55 @@ func (mc *MetricsConfig) Validate() error {
 	return errors.Join(errs...)
 }
 
-func (oc *ObservabilityConfig) Validate() error {
-	if !oc.Tracing.Enabled {
+func (cc *CoAPConfig) Validate() error {
+	if !cc.Enabled {
 		return nil
 	}
 
 	var errs []error
 
+	if cc.Host == "" {
+		errs = append(errs, errors.New("coap host cannot be empty"))
+	}
+	if cc.Port <= 0 || cc.Port > 65535 {
+		errs = append(errs, errors.New("coap port must be between 1 and 65535"))
+	}
+	if cc.Workers < 1 {
+		errs = append(errs, errors.New("coap workers must be at least 1"))
+	}
+	if cc.QueueDepth < 0 {
+		errs = append(errs, errors.New("coap queue_depth cannot be negative"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (oc *ObservabilityConfig) Validate() error {
+	var errs []error
+
+	if oc.DecisionLog.Enabled && (oc.DecisionLog.Size <= 0 || oc.DecisionLog.Size > MaxDecisionLogSize) {
+		errs = append(errs, fmt.Errorf("decision log size must be between 1 and %d", MaxDecisionLogSize))
+	}
+
+	if hh := oc.HealthHistory; hh.Enabled {
+		switch {
+		case hh.Interval < time.Second:
+			errs = append(errs, errors.New("health history interval must be at least 1s"))
+		case hh.Retention < hh.Interval:
+			errs = append(errs, errors.New("health history retention must be at least the interval"))
+		case hh.Retention/hh.Interval > MaxHealthSamples:
+			errs = append(errs, fmt.Errorf("health history retention cannot exceed %d intervals", MaxHealthSamples))
+		}
+	}
+
+	if err := oc.StatusPage.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("status page: %w", err))
+	}
+
+	if !oc.Tracing.Enabled {
+		return errors.Join(errs...)
+	}
+
 	validExporters := []string{"stdout", "otlp"}
 	found := false
 	for _, ve := range validExporters {
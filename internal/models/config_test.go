@@ -1,10 +1,12 @@
 package models
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDefaultConfig(t *testing.T) {
@@ -238,6 +240,74 @@ func TestServerConfig_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "shutdown timeout cannot be negative",
 		},
+		{
+			name: "concurrency limits enabled",
+			config: ServerConfig{
+				Port:        8080,
+				Host:        "localhost",
+				Concurrency: NewDefaultConfig().Server.Concurrency,
+			},
+			expectError: false,
+		},
+		{
+			name: "concurrency lane without slots",
+			config: ServerConfig{
+				Port: 8080,
+				Host: "localhost",
+				Concurrency: ConcurrencyConfig{
+					Enabled:       true,
+					Public:        ConcurrencyLimit{MaxInFlight: 10},
+					Authenticated: ConcurrencyLimit{MaxInFlight: 0},
+					Admin:         ConcurrencyLimit{MaxInFlight: 1, MaxQueue: -1},
+				},
+			},
+			expectError: true,
+			errorMsg:    "concurrency authenticated max_in_flight must be at least 1",
+		},
+		{
+			name: "background checks reserving the whole public lane",
+			config: ServerConfig{
+				Port: 8080,
+				Host: "localhost",
+				Concurrency: func() ConcurrencyConfig {
+					cc := NewDefaultConfig().Server.Concurrency
+					cc.Enabled = true
+					cc.BackgroundReserve = 1
+					return cc
+				}(),
+			},
+			expectError: true,
+			errorMsg:    "concurrency background_reserve must be at least 0 and less than 1",
+		},
+		{
+			name: "admin listener on a Unix socket",
+			config: ServerConfig{
+				Port:          8080,
+				Host:          "localhost",
+				AdminListener: AdminListenerConfig{Enabled: true, Socket: "/run/updater/admin.sock"},
+			},
+			expectError: false,
+		},
+		{
+			name: "admin listener on the server port",
+			config: ServerConfig{
+				Port:          8080,
+				Host:          "localhost",
+				AdminListener: AdminListenerConfig{Enabled: true, Host: "127.0.0.1", Port: 8080},
+			},
+			expectError: true,
+			errorMsg:    "admin listener port must differ from the server port",
+		},
+		{
+			name: "vanity host with a port",
+			config: ServerConfig{
+				Port:        8080,
+				Host:        "localhost",
+				VanityHosts: map[string]string{"updates.myproduct.com:443": "myproduct"},
+			},
+			expectError: true,
+			errorMsg:    "must be a lower-case hostname without scheme or port",
+		},
 		{
 			name: "TLS enabled without cert file",
 			config: ServerConfig{
@@ -374,6 +444,60 @@ func TestSecurityConfig_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "invalid allowed download host",
+			config: SecurityConfig{
+				DownloadURLs: DownloadURLPolicy{AllowedHosts: []string{"https://cdn.example.com"}},
+			},
+			expectError: true,
+			errorMsg:    "download_urls: invalid host pattern",
+		},
+		{
+			name: "client tokens enabled with defaults",
+			config: SecurityConfig{
+				ClientTokens: ClientTokenConfig{Enabled: true, Difficulty: 20, TokenTTL: time.Hour},
+			},
+			expectError: false,
+		},
+		{
+			name: "client token secret too short",
+			config: SecurityConfig{
+				ClientTokens: ClientTokenConfig{Enabled: true, Secret: "short", Difficulty: 20, TokenTTL: time.Hour},
+			},
+			expectError: true,
+			errorMsg:    "client_tokens: secret must be at least 32 bytes",
+		},
+		{
+			name: "client token difficulty out of range",
+			config: SecurityConfig{
+				ClientTokens: ClientTokenConfig{Enabled: true, Difficulty: 40, TokenTTL: time.Hour},
+			},
+			expectError: true,
+			errorMsg:    "client_tokens: difficulty must be between 8 and 32",
+		},
+		{
+			name: "anomaly detection without a window",
+			config: SecurityConfig{
+				AnomalyDetection: AnomalyDetectionConfig{Enabled: true, BlockDuration: time.Minute},
+			},
+			expectError: true,
+			errorMsg:    "anomaly_detection: window must be positive",
+		},
+		{
+			name: "anomaly detection with a negative limit",
+			config: SecurityConfig{
+				AnomalyDetection: AnomalyDetectionConfig{Enabled: true, Window: time.Minute, BlockDuration: time.Minute, RepeatedCheckLimit: -1},
+			},
+			expectError: true,
+			errorMsg:    "anomaly_detection: limits cannot be negative",
+		},
+		{
+			name: "disabled client tokens are not validated",
+			config: SecurityConfig{
+				ClientTokens: ClientTokenConfig{Difficulty: 99},
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -541,6 +665,61 @@ func TestMetricsConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestCoAPConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CoAPConfig
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:   "coap disabled ignores port",
+			config: CoAPConfig{Enabled: false, Port: -1},
+		},
+		{
+			name:   "valid coap config",
+			config: CoAPConfig{Enabled: true, Host: "0.0.0.0", Port: 5683, Workers: 16, QueueDepth: 256},
+		},
+		{
+			name:        "empty host",
+			config:      CoAPConfig{Enabled: true, Port: 5683, Workers: 16},
+			expectError: true,
+			errorMsg:    "coap host cannot be empty",
+		},
+		{
+			name:        "invalid port",
+			config:      CoAPConfig{Enabled: true, Host: "0.0.0.0", Port: 70000, Workers: 16},
+			expectError: true,
+			errorMsg:    "coap port must be between 1 and 65535",
+		},
+		{
+			name:        "no workers",
+			config:      CoAPConfig{Enabled: true, Host: "0.0.0.0", Port: 5683},
+			expectError: true,
+			errorMsg:    "coap workers must be at least 1",
+		},
+		{
+			name:        "negative queue depth",
+			config:      CoAPConfig{Enabled: true, Host: "0.0.0.0", Port: 5683, Workers: 16, QueueDepth: -1},
+			expectError: true,
+			errorMsg:    "coap queue_depth cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestObservabilityConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -555,6 +734,81 @@ func TestObservabilityConfig_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "decision log enabled",
+			config: ObservabilityConfig{
+				DecisionLog: DecisionLogConfig{Enabled: true, Size: 1000},
+			},
+			expectError: false,
+		},
+		{
+			name: "decision log too large",
+			config: ObservabilityConfig{
+				DecisionLog: DecisionLogConfig{Enabled: true, Size: MaxDecisionLogSize + 1},
+			},
+			expectError: true,
+			errorMsg:    "decision log size must be between 1 and 100000",
+		},
+		{
+			name: "health history enabled",
+			config: ObservabilityConfig{
+				HealthHistory: HealthHistoryConfig{Enabled: true, Interval: time.Minute, Retention: 24 * time.Hour},
+			},
+			expectError: false,
+		},
+		{
+			name: "health history interval too short",
+			config: ObservabilityConfig{
+				HealthHistory: HealthHistoryConfig{Enabled: true, Interval: 500 * time.Millisecond, Retention: time.Hour},
+			},
+			expectError: true,
+			errorMsg:    "health history interval must be at least 1s",
+		},
+		{
+			name: "health history retention shorter than interval",
+			config: ObservabilityConfig{
+				HealthHistory: HealthHistoryConfig{Enabled: true, Interval: time.Hour, Retention: time.Minute},
+			},
+			expectError: true,
+			errorMsg:    "health history retention must be at least the interval",
+		},
+		{
+			name: "health history too many samples",
+			config: ObservabilityConfig{
+				HealthHistory: HealthHistoryConfig{Enabled: true, Interval: time.Second, Retention: 24 * time.Hour},
+			},
+			expectError: true,
+			errorMsg:    "health history retention cannot exceed 10080 intervals",
+		},
+		{
+			name: "status page enabled",
+			config: ObservabilityConfig{
+				StatusPage: StatusPageConfig{
+					Enabled: true, Applications: []string{"my-app"},
+					CheckWindow: time.Hour, CacheTTL: 30 * time.Second, RateLimit: 60, RateLimitWindow: time.Minute,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "status page without rate limit",
+			config: ObservabilityConfig{
+				StatusPage: StatusPageConfig{Enabled: true, CheckWindow: time.Hour, CacheTTL: 30 * time.Second, RateLimitWindow: time.Minute},
+			},
+			expectError: true,
+			errorMsg:    "status page: rate_limit must be at least 1",
+		},
+		{
+			name: "status page lists an application twice",
+			config: ObservabilityConfig{
+				StatusPage: StatusPageConfig{
+					Enabled: true, Applications: []string{"my-app", "my-app"},
+					CheckWindow: time.Hour, CacheTTL: 30 * time.Second, RateLimit: 60, RateLimitWindow: time.Minute,
+				},
+			},
+			expectError: true,
+			errorMsg:    `status page: application "my-app" is listed more than once`,
+		},
 		{
 			name: "valid stdout tracing",
 			config: ObservabilityConfig{
@@ -791,3 +1045,48 @@ func TestDatabaseConfig_Structure(t *testing.T) {
 	assert.Equal(t, 1*time.Hour, dbConfig.ConnMaxLifetime)
 	assert.Equal(t, 30*time.Minute, dbConfig.ConnMaxIdleTime)
 }
+
+func TestParseTrustedProxy(t *testing.T) {
+	prefix, err := ParseTrustedProxy("10.1.2.3/8")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", prefix.String())
+
+	prefix, err = ParseTrustedProxy("::ffff:192.0.2.10")
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.10/32", prefix.String())
+
+	_, err = ParseTrustedProxy("proxy.internal")
+	assert.ErrorContains(t, err, "must be a CIDR or IP address")
+
+	sec := SecurityConfig{TrustedProxies: []string{"10.0.0.0/8", "nope"}}
+	assert.ErrorContains(t, sec.Validate(), `invalid trusted proxy "nope"`)
+}
+
+func TestCredentialsConfigValidate(t *testing.T) {
+	valid := QueryTokenConfig{Enabled: true, Param: "access_token", Secret: strings.Repeat("s", 32), MaxTTL: time.Hour}
+
+	tests := []struct {
+		name    string
+		config  CredentialsConfig
+		wantErr string
+	}{
+		{name: "disabled", config: CredentialsConfig{}},
+		{name: "header and query token", config: CredentialsConfig{Header: "X-API-Key", QueryToken: valid}},
+		{name: "invalid header name", config: CredentialsConfig{Header: "X API Key"}, wantErr: "not a valid header name"},
+		{name: "authorization header", config: CredentialsConfig{Header: "authorization"}, wantErr: "must not be Authorization"},
+		{name: "short secret", config: CredentialsConfig{QueryToken: QueryTokenConfig{Enabled: true, Param: "t", Secret: "short", MaxTTL: time.Hour}}, wantErr: "secret must be at least 32 bytes"},
+		{name: "bad param", config: CredentialsConfig{QueryToken: QueryTokenConfig{Enabled: true, Param: "a&b", Secret: valid.Secret, MaxTTL: time.Hour}}, wantErr: "not a valid query parameter name"},
+		{name: "zero max ttl", config: CredentialsConfig{QueryToken: QueryTokenConfig{Enabled: true, Param: "t", Secret: valid.Secret}}, wantErr: "max_ttl must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
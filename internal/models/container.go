@@ -0,0 +1,267 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxImageRepositoryLength is the maximum length of a container image repository reference.
+const MaxImageRepositoryLength = 255
+
+// Container image update reasons reported by ContainerImageCheckResponse.
+const (
+	ImageUpdateReasonNewerTag      = "newer_tag"      // A higher version tag is published
+	ImageUpdateReasonDigestChanged = "digest_changed" // The pinned tag was re-pushed with new content
+)
+
+var (
+	// imageRepositoryPattern matches a registry host (with optional port) and
+	// repository path, without tag or digest, e.g. "ghcr.io/acme/agent".
+	imageRepositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.-][a-z0-9]+)*(?::[0-9]+)?(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+
+	// imageDigestPattern matches an OCI content digest.
+	imageDigestPattern = regexp.MustCompile(`^(?:sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+)
+
+// ContainerImage is a published container image tag for an application. Edge
+// agents that run the application as a container use it to learn when a newer
+// tag, or a rebuilt image under the same tag, is available for their platform.
+//
+// The tag is a semantic version so images can be ordered like releases. The
+// digest pins the exact content; for multi-platform images it is the digest of
+// the image index, and Platforms lists the platforms the index contains.
+type ContainerImage struct {
+	ID            string    `json:"id"`             // Unique image identifier (app-tag)
+	ApplicationID string    `json:"application_id"` // Parent application identifier
+	Repository    string    `json:"repository"`     // Registry and repository, e.g. ghcr.io/acme/agent
+	Tag           string    `json:"tag"`            // Semantic version tag
+	Digest        string    `json:"digest"`         // Content digest, e.g. sha256:...
+	Platforms     []string  `json:"platforms"`      // OCI platforms, e.g. linux/amd64, linux/arm/v7
+	ReleaseNotes  string    `json:"release_notes,omitempty"`
+	Required      bool      `json:"required"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewContainerImage creates a ContainerImage with a generated ID and current timestamps.
+func NewContainerImage(appID, repository, tag, digest string, platforms []string) *ContainerImage {
+	now := time.Now()
+	return &ContainerImage{
+		ID:            generateContainerImageID(appID, tag),
+		ApplicationID: appID,
+		Repository:    repository,
+		Tag:           tag,
+		Digest:        digest,
+		Platforms:     platforms,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func (c *ContainerImage) Validate() error {
+	if c.ID == "" {
+		return errors.New("image ID cannot be empty")
+	}
+	if c.ApplicationID == "" {
+		return errors.New("application ID cannot be empty")
+	}
+	if err := ValidateImageRepository(c.Repository); err != nil {
+		return err
+	}
+	if err := validateVersion(c.Tag); err != nil {
+		return fmt.Errorf("invalid tag: %w", err)
+	}
+	if !imageDigestPattern.MatchString(c.Digest) {
+		return fmt.Errorf("invalid digest: %s", c.Digest)
+	}
+	return ValidateImagePlatforms(c.Platforms)
+}
+
+// Reference returns a pull reference that names the tag for readability and
+// pins the digest, e.g. "ghcr.io/acme/agent:2.1.0@sha256:...".
+func (c *ContainerImage) Reference() string {
+	return fmt.Sprintf("%s:%s@%s", c.Repository, c.Tag, c.Digest)
+}
+
+// SupportsPlatform reports whether the image contains the given OCI platform.
+// A platform without a variant matches any variant of the same OS and
+// architecture, so "linux/arm" matches an image built for "linux/arm/v7".
+func (c *ContainerImage) SupportsPlatform(platform string) bool {
+	platform = NormalizeImagePlatform(platform)
+	for _, p := range c.Platforms {
+		if p == platform || strings.HasPrefix(p, platform+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateImageRepository checks that a repository reference names a registry
+// repository without a tag or digest.
+func ValidateImageRepository(repository string) error {
+	if repository == "" {
+		return errors.New("repository is required")
+	}
+	if len(repository) > MaxImageRepositoryLength {
+		return fmt.Errorf("repository exceeds maximum length of %d", MaxImageRepositoryLength)
+	}
+	if strings.Contains(repository, "@") {
+		return errors.New("repository must not include a digest")
+	}
+	if !imageRepositoryPattern.MatchString(repository) {
+		return fmt.Errorf("invalid repository: %s", repository)
+	}
+	return nil
+}
+
+// ValidateImagePlatforms checks that platforms is a non-empty list of distinct
+// OCI platforms in os/arch[/variant] form using supported OS and architecture names.
+func ValidateImagePlatforms(platforms []string) error {
+	if len(platforms) == 0 {
+		return errors.New("at least one platform is required")
+	}
+	seen := make(map[string]bool, len(platforms))
+	for _, p := range platforms {
+		if err := validateImagePlatform(p); err != nil {
+			return err
+		}
+		if seen[p] {
+			return fmt.Errorf("duplicate platform: %s", p)
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
+func validateImagePlatform(platform string) error {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+		return fmt.Errorf("invalid platform %q: expected os/arch[/variant]", platform)
+	}
+	if !isValidPlatform(parts[0]) {
+		return fmt.Errorf("invalid platform: %s", platform)
+	}
+	if !isValidArchitecture(parts[1]) {
+		return fmt.Errorf("invalid architecture in platform: %s", platform)
+	}
+	return nil
+}
+
+// NormalizeImagePlatform trims and lowercases an OCI platform string.
+func NormalizeImagePlatform(platform string) string {
+	return strings.ToLower(strings.TrimSpace(platform))
+}
+
+// ImagePlatformOS returns the operating system part of an OCI platform string.
+func ImagePlatformOS(platform string) string {
+	goos, _, _ := strings.Cut(platform, "/")
+	return goos
+}
+
+func generateContainerImageID(appID, tag string) string {
+	return fmt.Sprintf("%s-%s", appID, tag)
+}
+
+// RegisterContainerImageRequest registers or replaces a container image tag.
+type RegisterContainerImageRequest struct {
+	ApplicationID string   `json:"application_id"`
+	Repository    string   `json:"repository" validate:"required"`
+	Tag           string   `json:"tag" validate:"required"`
+	Digest        string   `json:"digest" validate:"required"`
+	Platforms     []string `json:"platforms" validate:"required,min=1"`
+	ReleaseNotes  string   `json:"release_notes"`
+	Required      bool     `json:"required"`
+}
+
+func (r *RegisterContainerImageRequest) Validate() error {
+	if r.ApplicationID == "" {
+		return errors.New("application_id is required")
+	}
+	if err := ValidateImageRepository(r.Repository); err != nil {
+		return err
+	}
+	if err := validateVersion(r.Tag); err != nil {
+		return fmt.Errorf("invalid tag: %w", err)
+	}
+	if !imageDigestPattern.MatchString(r.Digest) {
+		return fmt.Errorf("invalid digest: %s", r.Digest)
+	}
+	return ValidateImagePlatforms(r.Platforms)
+}
+
+func (r *RegisterContainerImageRequest) Normalize() {
+	r.ApplicationID = strings.TrimSpace(r.ApplicationID)
+	r.Repository = strings.ToLower(strings.TrimSpace(r.Repository))
+	r.Tag = strings.TrimSpace(r.Tag)
+	r.Digest = strings.ToLower(strings.TrimSpace(r.Digest))
+	for i, p := range r.Platforms {
+		r.Platforms[i] = NormalizeImagePlatform(p)
+	}
+}
+
+// ContainerImageCheckRequest asks whether a newer image is available for an
+// agent running the given tag, and optionally the given digest, on a platform.
+type ContainerImageCheckRequest struct {
+	ApplicationID   string `json:"application_id"`
+	Tag             string `json:"tag"`
+	Digest          string `json:"digest,omitempty"`   // Digest the agent is pinned to (optional)
+	Platform        string `json:"platform,omitempty"` // OCI platform, e.g. linux/arm64 (optional)
+	AllowPrerelease bool   `json:"allow_prerelease"`
+}
+
+func (r *ContainerImageCheckRequest) Validate() error {
+	if r.ApplicationID == "" {
+		return errors.New("application_id is required")
+	}
+	if err := validateVersion(r.Tag); err != nil {
+		return fmt.Errorf("invalid tag: %w", err)
+	}
+	if r.Digest != "" && !imageDigestPattern.MatchString(r.Digest) {
+		return fmt.Errorf("invalid digest: %s", r.Digest)
+	}
+	if r.Platform != "" {
+		if err := validateImagePlatform(r.Platform); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ContainerImageCheckRequest) Normalize() {
+	r.ApplicationID = strings.TrimSpace(r.ApplicationID)
+	r.Tag = strings.TrimSpace(r.Tag)
+	r.Digest = strings.ToLower(strings.TrimSpace(r.Digest))
+	r.Platform = NormalizeImagePlatform(r.Platform)
+}
+
+// ContainerImageCheckResponse reports whether the agent should pull a
+// different image. When UpdateAvailable is true, Reference is the digest-pinned
+// pull reference of the image to run.
+type ContainerImageCheckResponse struct {
+	UpdateAvailable bool     `json:"update_available"`
+	Reason          string   `json:"reason,omitempty"` // newer_tag or digest_changed
+	CurrentTag      string   `json:"current_tag"`
+	CurrentDigest   string   `json:"current_digest,omitempty"`
+	LatestTag       string   `json:"latest_tag,omitempty"`
+	Digest          string   `json:"digest,omitempty"`
+	Reference       string   `json:"reference,omitempty"`
+	Platforms       []string `json:"platforms,omitempty"`
+	ReleaseNotes    string   `json:"release_notes,omitempty"`
+	Required        bool     `json:"required,omitempty"`
+}
+
+// ListContainerImagesResponse lists an application's images, newest tag first.
+type ListContainerImagesResponse struct {
+	ApplicationID string           `json:"application_id"`
+	Images        []ContainerImage `json:"images"`
+}
+
+// DeleteContainerImageResponse confirms removal of an image tag.
+type DeleteContainerImageResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
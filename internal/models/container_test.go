@@ -0,0 +1,142 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testImageDigest = "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func TestValidateImageRepository(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository string
+		wantErr    bool
+	}{
+		{name: "registry with path", repository: "ghcr.io/acme/agent"},
+		{name: "registry with port", repository: "registry.local:5000/edge/agent"},
+		{name: "docker hub short name", repository: "nginx"},
+		{name: "double underscore separator", repository: "docker.io/acme/edge__agent"},
+		{name: "empty", repository: "", wantErr: true},
+		{name: "with tag", repository: "ghcr.io/acme/agent:1.0.0", wantErr: true},
+		{name: "with digest", repository: "ghcr.io/acme/agent@" + testImageDigest, wantErr: true},
+		{name: "uppercase", repository: "ghcr.io/Acme/agent", wantErr: true},
+		{name: "scheme", repository: "https://ghcr.io/acme/agent", wantErr: true},
+		{name: "too long", repository: "ghcr.io/" + strings.Repeat("a", MaxImageRepositoryLength), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateImageRepository(tt.repository)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateImagePlatforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		platforms []string
+		wantErr   bool
+	}{
+		{name: "single", platforms: []string{"linux/amd64"}},
+		{name: "with variant", platforms: []string{"linux/arm64", "linux/arm/v7"}},
+		{name: "empty", platforms: nil, wantErr: true},
+		{name: "missing arch", platforms: []string{"linux"}, wantErr: true},
+		{name: "unknown os", platforms: []string{"plan9/amd64"}, wantErr: true},
+		{name: "unknown arch", platforms: []string{"linux/s390x"}, wantErr: true},
+		{name: "too many parts", platforms: []string{"linux/arm/v7/extra"}, wantErr: true},
+		{name: "duplicate", platforms: []string{"linux/amd64", "linux/amd64"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateImagePlatforms(tt.platforms)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestContainerImage_Validate(t *testing.T) {
+	valid := NewContainerImage("agent", "ghcr.io/acme/agent", "2.1.0", testImageDigest, []string{"linux/amd64"})
+	assert.NoError(t, valid.Validate())
+	assert.Equal(t, "agent-2.1.0", valid.ID)
+
+	badTag := *valid
+	badTag.Tag = "latest"
+	assert.Error(t, badTag.Validate())
+
+	badDigest := *valid
+	badDigest.Digest = "sha256:abc"
+	assert.Error(t, badDigest.Validate())
+}
+
+func TestContainerImage_Reference(t *testing.T) {
+	image := NewContainerImage("agent", "ghcr.io/acme/agent", "2.1.0", testImageDigest, []string{"linux/amd64"})
+	assert.Equal(t, "ghcr.io/acme/agent:2.1.0@"+testImageDigest, image.Reference())
+}
+
+func TestContainerImage_SupportsPlatform(t *testing.T) {
+	image := &ContainerImage{Platforms: []string{"linux/amd64", "linux/arm/v7"}}
+
+	assert.True(t, image.SupportsPlatform("linux/amd64"))
+	assert.True(t, image.SupportsPlatform("Linux/AMD64"))
+	assert.True(t, image.SupportsPlatform("linux/arm/v7"))
+	assert.True(t, image.SupportsPlatform("linux/arm"), "platform without variant matches any variant")
+	assert.False(t, image.SupportsPlatform("linux/arm/v6"))
+	assert.False(t, image.SupportsPlatform("linux/arm64"))
+}
+
+func TestRegisterContainerImageRequest_Normalize(t *testing.T) {
+	req := &RegisterContainerImageRequest{
+		ApplicationID: " agent ",
+		Repository:    " GHCR.io/Acme/Agent ",
+		Tag:           " 2.1.0 ",
+		Digest:        strings.ToUpper(testImageDigest),
+		Platforms:     []string{"Linux/ARM64"},
+	}
+	req.Normalize()
+
+	assert.Equal(t, "agent", req.ApplicationID)
+	assert.Equal(t, "ghcr.io/acme/agent", req.Repository)
+	assert.Equal(t, "2.1.0", req.Tag)
+	assert.Equal(t, testImageDigest, req.Digest)
+	assert.Equal(t, []string{"linux/arm64"}, req.Platforms)
+	assert.NoError(t, req.Validate())
+}
+
+func TestContainerImageCheckRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ContainerImageCheckRequest
+		wantErr bool
+	}{
+		{name: "tag only", req: ContainerImageCheckRequest{ApplicationID: "agent", Tag: "1.0.0"}},
+		{name: "tag digest and platform", req: ContainerImageCheckRequest{ApplicationID: "agent", Tag: "1.0.0", Digest: testImageDigest, Platform: "linux/arm64"}},
+		{name: "missing application", req: ContainerImageCheckRequest{Tag: "1.0.0"}, wantErr: true},
+		{name: "missing tag", req: ContainerImageCheckRequest{ApplicationID: "agent"}, wantErr: true},
+		{name: "bad digest", req: ContainerImageCheckRequest{ApplicationID: "agent", Tag: "1.0.0", Digest: "md5:abc"}, wantErr: true},
+		{name: "bad platform", req: ContainerImageCheckRequest{ApplicationID: "agent", Tag: "1.0.0", Platform: "arm64"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
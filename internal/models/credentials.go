@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultQueryTokenParam is the query parameter a signed API key token is read
+// from when QueryTokenConfig.Param is empty.
+const DefaultQueryTokenParam = "access_token"
+
+// QueryTokenRequest asks for a signed query token for an API key. TTLSeconds
+// defaults to the configured maximum.
+type QueryTokenRequest struct {
+	TTLSeconds int64 `json:"ttl_seconds,omitempty"`
+}
+
+// QueryTokenResponse is a signed token that authenticates as an API key when
+// passed in the Param query parameter until ExpiresAt.
+type QueryTokenResponse struct {
+	Param     string    `json:"param"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CredentialsConfig adds places besides the Authorization header where an API
+// key is accepted, for legacy clients that cannot set that header.
+type CredentialsConfig struct {
+	// Header names a request header carrying the raw API key, such as
+	// X-API-Key. Empty disables it.
+	Header string `yaml:"header" json:"header"`
+	// QueryToken accepts a signed, expiring token for an API key in a query
+	// parameter. The raw key never appears in URLs.
+	QueryToken QueryTokenConfig `yaml:"query_token" json:"query_token"`
+}
+
+// QueryTokenConfig configures signed API key tokens in a query parameter.
+// Tokens are issued by POST /api/v1/admin/keys/{id}/query-token.
+type QueryTokenConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Param   string `yaml:"param" json:"param"`
+	// Secret is the HMAC key tokens are signed with. It is required so tokens
+	// survive restarts; replicas need the same secret.
+	Secret string        `yaml:"secret" json:"-"`
+	MaxTTL time.Duration `yaml:"max_ttl" json:"max_ttl"` // Longest lifetime a token can be issued with
+}
+
+// Validate checks the header name and, when enabled, the query token settings.
+func (c *CredentialsConfig) Validate() error {
+	var errs []error
+	if c.Header != "" {
+		if !isHeaderName(c.Header) {
+			errs = append(errs, fmt.Errorf("header %q is not a valid header name", c.Header))
+		} else if strings.EqualFold(c.Header, "Authorization") {
+			errs = append(errs, errors.New("header must not be Authorization, which is always accepted"))
+		}
+	}
+	if err := c.QueryToken.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("query_token: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the settings when query tokens are enabled.
+func (c *QueryTokenConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	if c.Param == "" || strings.ContainsAny(c.Param, "&=#?+% ") {
+		errs = append(errs, fmt.Errorf("param %q is not a valid query parameter name", c.Param))
+	}
+	if len(c.Secret) < 32 {
+		errs = append(errs, errors.New("secret must be at least 32 bytes"))
+	}
+	if c.MaxTTL <= 0 {
+		errs = append(errs, errors.New("max_ttl must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// isHeaderName reports whether name is a valid HTTP header field name.
+func isHeaderName(name string) bool {
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
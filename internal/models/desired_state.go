@@ -0,0 +1,95 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// ApplicationDesiredState is the complete configuration of one application, as
+// kept in version control by GitOps tooling such as a Terraform provider.
+// Unlike UpdateApplicationRequest it is not a patch: a field left out of the
+// document is reset to its zero value, so applying the same document always
+// leaves the application in the same state.
+type ApplicationDesiredState struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Platforms   []string          `json:"platforms"`
+	Config      ApplicationConfig `json:"config"`
+	Tags        []string          `json:"tags"`
+	Group       string            `json:"group"`
+	ParentID    string            `json:"parent_id"`
+}
+
+// Normalize trims and lowercases the document the way application creation
+// does, so that equivalent documents compare equal.
+func (s *ApplicationDesiredState) Normalize() {
+	s.Name = strings.TrimSpace(s.Name)
+	s.Description = strings.TrimSpace(s.Description)
+	for i, platform := range s.Platforms {
+		s.Platforms[i] = NormalizePlatform(platform)
+	}
+	s.Tags = NormalizeTags(s.Tags)
+	s.Group = NormalizeGroup(s.Group)
+	s.ParentID = strings.TrimSpace(s.ParentID)
+}
+
+// Application returns the application the document describes. Timestamps are
+// left for the caller to set.
+func (s *ApplicationDesiredState) Application(id string) *Application {
+	app := NewApplication(id, s.Name, s.Platforms)
+	app.Description = s.Description
+	app.Config = s.Config
+	if app.Config.CustomFields == nil {
+		app.Config.CustomFields = make(map[string]string)
+	}
+	app.Tags = s.Tags
+	app.Group = s.Group
+	app.ParentID = s.ParentID
+	return app
+}
+
+// DesiredStateChanges lists the fields, by JSON name, in which app differs
+// from desired. An empty result means applying desired changes nothing.
+func DesiredStateChanges(app, desired *Application) []string {
+	var changes []string
+	if app.Name != desired.Name {
+		changes = append(changes, "name")
+	}
+	if app.Description != desired.Description {
+		changes = append(changes, "description")
+	}
+	if !slices.Equal(app.Platforms, desired.Platforms) {
+		changes = append(changes, "platforms")
+	}
+	if !configEqual(app.Config, desired.Config) {
+		changes = append(changes, "config")
+	}
+	if !slices.Equal(app.Tags, desired.Tags) {
+		changes = append(changes, "tags")
+	}
+	if app.Group != desired.Group {
+		changes = append(changes, "group")
+	}
+	if app.ParentID != desired.ParentID {
+		changes = append(changes, "parent_id")
+	}
+	return changes
+}
+
+// configEqual compares configs by their stored form, so that an absent and an
+// empty map or list are the same.
+func configEqual(a, b ApplicationConfig) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
+
+// ApplyDesiredStateResponse reports the outcome of applying a desired state
+// document. Changes is empty when the application already matched it.
+type ApplyDesiredStateResponse struct {
+	ID      string   `json:"id"`
+	Created bool     `json:"created"`
+	Changes []string `json:"changes"`
+	Message string   `json:"message"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicationDesiredState_Application(t *testing.T) {
+	state := &ApplicationDesiredState{
+		Name:      "  My App ",
+		Platforms: []string{"Linux"},
+		Tags:      []string{"Beta", "beta"},
+		Group:     " Tools ",
+	}
+	state.Normalize()
+	app := state.Application("my-app")
+
+	assert.Equal(t, "my-app", app.ID)
+	assert.Equal(t, "My App", app.Name)
+	assert.Equal(t, []string{"linux"}, app.Platforms)
+	assert.Equal(t, []string{"beta"}, app.Tags)
+	assert.NotNil(t, app.Config.CustomFields)
+	assert.NoError(t, app.Validate())
+}
+
+func TestDesiredStateChanges(t *testing.T) {
+	current := NewApplication("my-app", "My App", []string{"linux"})
+	current.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	same := NewApplication("my-app", "My App", []string{"linux"})
+	same.Config.CustomFields = nil
+	assert.Empty(t, DesiredStateChanges(current, same), "timestamps and empty maps are not changes")
+
+	changed := NewApplication("my-app", "Renamed", []string{"linux", "windows"})
+	changed.Config.AllowedDownloadHosts = []string{"releases.example.com"}
+	changed.ParentID = "host-app"
+	assert.Equal(t, []string{"name", "platforms", "config", "parent_id"}, DesiredStateChanges(current, changed))
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DownloadURLPolicy restricts where release download URLs may point. Clients
+// fetch these URLs, so by default any http or https URL is accepted; operators
+// who expose the service to untrusted publishers can refuse internal targets
+// and limit URLs to known hosts.
+type DownloadURLPolicy struct {
+	// DenyPrivateNetworks rejects loopback, private, link-local and
+	// unspecified IP literals and localhost names. Host names are not resolved,
+	// so a public name that resolves to an internal address is not caught here.
+	DenyPrivateNetworks bool `yaml:"deny_private_networks" json:"deny_private_networks"`
+	// AllowedHosts, when set, is the list of host patterns download URLs must
+	// match. A pattern is a host name or "*.example.com" for any subdomain.
+	AllowedHosts []string `yaml:"allowed_hosts" json:"allowed_hosts"`
+}
+
+// hostPattern matches a host name, optionally prefixed with "*." for any
+// subdomain.
+var hostPattern = regexp.MustCompile(`^(\*\.)?[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// ValidateHostPatterns checks that each pattern is a lowercase host name or a
+// "*." wildcard.
+func ValidateHostPatterns(patterns []string) error {
+	for _, p := range patterns {
+		if !hostPattern.MatchString(p) {
+			return fmt.Errorf("invalid host pattern %q: must be a lowercase host name, optionally prefixed with *.", p)
+		}
+	}
+	return nil
+}
+
+// MatchHostPattern reports whether host matches pattern. "*.example.com"
+// matches any subdomain of example.com but not example.com itself.
+func MatchHostPattern(pattern, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	return host == pattern
+}
+
+// MatchAnyHostPattern reports whether host matches one of patterns.
+func MatchAnyHostPattern(patterns []string, host string) bool {
+	for _, p := range patterns {
+		if MatchHostPattern(p, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *DownloadURLPolicy) Validate() error {
+	return ValidateHostPatterns(p.AllowedHosts)
+}
+
+// Check returns an error when rawURL is not allowed by the policy. The URL is
+// expected to have passed Release.ValidateDownloadURL.
+func (p *DownloadURLPolicy) Check(rawURL string) error {
+	if !p.DenyPrivateNetworks && len(p.AllowedHosts) == 0 {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("malformed URL: %w", err)
+	}
+	host := u.Hostname()
+
+	if p.DenyPrivateNetworks && isInternalHost(host) {
+		return fmt.Errorf("download URL host %s is a private, loopback or link-local address", host)
+	}
+	if len(p.AllowedHosts) > 0 && !MatchAnyHostPattern(p.AllowedHosts, host) {
+		return fmt.Errorf("download URL host %s is not in the allowed hosts", host)
+	}
+	return nil
+}
+
+// CheckDownloadURL returns an error when rawURL's host does not match the
+// application's allowed download hosts. An application without allowed hosts
+// accepts any host.
+func (ac *ApplicationConfig) CheckDownloadURL(rawURL string) error {
+	if len(ac.AllowedDownloadHosts) == 0 {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("malformed URL: %w", err)
+	}
+	if !MatchAnyHostPattern(ac.AllowedDownloadHosts, u.Hostname()) {
+		return fmt.Errorf("download URL host %s is not in the application's allowed download hosts", u.Hostname())
+	}
+	return nil
+}
+
+// numericLabel matches a decimal or hex label. Browsers and curl parse a host
+// whose last label is numeric as an IPv4 address, including forms netip does
+// not accept such as "2130706433" or "0x7f.1".
+var numericLabel = regexp.MustCompile(`^([0-9]+|0x[0-9a-f]*)$`)
+
+// isInternalHost reports whether host names the local machine or an address
+// that is not publicly routable.
+func isInternalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		// A numeric last label that netip cannot parse is an obfuscated IPv4
+		// address, which cannot be checked and is treated as internal.
+		return numericLabel.MatchString(host[strings.LastIndex(host, ".")+1:])
+	}
+	return IsInternalAddr(addr)
+}
+
+// IsInternalAddr reports whether addr is loopback, private, link-local,
+// unspecified or in the carrier-grade NAT range, and so not publicly routable.
+func IsInternalAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsUnspecified() ||
+		cgnatPrefix.Contains(addr)
+}
+
+// cgnatPrefix is the shared address space of RFC 6598, used by carrier-grade
+// NAT and by some cloud providers for internal services.
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
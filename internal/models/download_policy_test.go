@@ -0,0 +1,72 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadURLPolicy_Check(t *testing.T) {
+	deny := DownloadURLPolicy{DenyPrivateNetworks: true}
+	allow := DownloadURLPolicy{AllowedHosts: []string{"releases.example.com", "*.cdn.example.com"}}
+
+	tests := []struct {
+		name    string
+		policy  DownloadURLPolicy
+		url     string
+		wantErr bool
+	}{
+		{"no policy", DownloadURLPolicy{}, "http://127.0.0.1/app.exe", false},
+		{"public host", deny, "https://example.com/app.exe", false},
+		{"public IP", deny, "https://93.184.216.34/app.exe", false},
+		{"localhost", deny, "http://localhost:8080/app.exe", true},
+		{"localhost subdomain", deny, "http://api.localhost/app.exe", true},
+		{"loopback", deny, "http://127.0.0.1/app.exe", true},
+		{"private", deny, "http://10.1.2.3/app.exe", true},
+		{"metadata endpoint", deny, "http://169.254.169.254/latest/meta-data/", true},
+		{"cgnat", deny, "http://100.64.0.1/app.exe", true},
+		{"unspecified", deny, "http://0.0.0.0/app.exe", true},
+		{"ipv6 loopback", deny, "http://[::1]/app.exe", true},
+		{"ipv6 unique local", deny, "http://[fd00::1]/app.exe", true},
+		{"ipv4-mapped ipv6", deny, "http://[::ffff:10.0.0.1]/app.exe", true},
+		{"decimal ipv4", deny, "http://2130706433/app.exe", true},
+		{"hex ipv4", deny, "http://0x7f.1/app.exe", true},
+		{"name ending in hex letters", deny, "https://cafe.de/app.exe", false},
+		{"allowed host", allow, "https://releases.example.com/app.exe", false},
+		{"allowed subdomain", allow, "https://eu.cdn.example.com/app.exe", false},
+		{"wildcard excludes apex", allow, "https://cdn.example.com/app.exe", true},
+		{"host not allowed", allow, "https://evil.example.net/app.exe", true},
+		{"suffix is not a subdomain", allow, "https://evilcdn.example.com/app.exe", true},
+	}
+
+	for _, tt := range tests {
+		err := tt.policy.Check(tt.url)
+		if tt.wantErr {
+			assert.Error(t, err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+}
+
+func TestValidateHostPatterns(t *testing.T) {
+	assert.NoError(t, ValidateHostPatterns([]string{"example.com", "*.cdn.example.com", "localhost"}))
+	assert.Error(t, ValidateHostPatterns([]string{"Example.com"}))
+	assert.Error(t, ValidateHostPatterns([]string{"*"}))
+	assert.Error(t, ValidateHostPatterns([]string{"cdn.*.example.com"}))
+	assert.Error(t, ValidateHostPatterns([]string{"https://example.com"}))
+}
+
+func TestApplicationConfig_CheckDownloadURL(t *testing.T) {
+	config := ApplicationConfig{AllowedDownloadHosts: []string{"releases.example.com", "*.cdn.example.com"}}
+	assert.NoError(t, config.CheckDownloadURL("https://releases.example.com/app.exe"))
+	assert.NoError(t, config.CheckDownloadURL("https://eu.cdn.example.com/app.exe"))
+	assert.Error(t, config.CheckDownloadURL("https://example.com/app.exe"))
+	assert.Error(t, config.CheckDownloadURL("https://releases.example.com.attacker.test/app.exe"))
+
+	open := ApplicationConfig{}
+	assert.NoError(t, open.CheckDownloadURL("https://attacker.test/app.exe"))
+
+	invalid := ApplicationConfig{AllowedDownloadHosts: []string{"https://example.com"}}
+	assert.ErrorContains(t, invalid.Validate(), "allowed_download_hosts")
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// An application can ship several editions, such as community, pro and
+// enterprise, from one release instead of one application per edition. The
+// release's own artifact is the base edition; Editions holds the artifacts
+// built for other editions, keyed by edition name. A client that reports an
+// edition is offered that edition's artifact when the release has one and its
+// license allows it, and the base artifact otherwise.
+
+// MaxEditionLength is the maximum length of an edition name.
+const MaxEditionLength = 50
+
+// EditionArtifact is the build of a release for one edition. It replaces the
+// release's download, checksum and file size for clients of that edition.
+type EditionArtifact struct {
+	DownloadURL  string `json:"download_url" yaml:"download_url"`
+	Checksum     string `json:"checksum" yaml:"checksum"`
+	ChecksumType string `json:"checksum_type" yaml:"checksum_type"`
+	FileSize     int64  `json:"file_size,omitempty" yaml:"file_size,omitempty"`
+
+	// RequiredEntitlement is needed, in addition to the release's own, for
+	// the client to be offered this artifact rather than the base one.
+	RequiredEntitlement string `json:"required_entitlement,omitempty" yaml:"required_entitlement,omitempty"`
+}
+
+// NormalizeEdition lowercases and trims an edition name.
+func NormalizeEdition(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateEdition checks an edition name, which uses the tag format.
+func ValidateEdition(name string) error {
+	if len(name) > MaxEditionLength {
+		return fmt.Errorf("edition %q exceeds maximum length of %d", name, MaxEditionLength)
+	}
+	if !tagPattern.MatchString(name) {
+		return fmt.Errorf("invalid edition %q: must contain only lowercase letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// ValidateEditions checks a release's normalized edition artifacts.
+func ValidateEditions(editions map[string]EditionArtifact) error {
+	for _, edition := range slices.Sorted(maps.Keys(editions)) {
+		if err := ValidateEdition(edition); err != nil {
+			return err
+		}
+		if err := editions[edition].validate(); err != nil {
+			return fmt.Errorf("editions.%s: %w", edition, err)
+		}
+	}
+	return nil
+}
+
+func (a EditionArtifact) validate() error {
+	if err := validateArtifact(a.DownloadURL, a.Checksum, a.ChecksumType, a.FileSize); err != nil {
+		return err
+	}
+	if a.RequiredEntitlement != "" {
+		if err := ValidateEntitlement(a.RequiredEntitlement); err != nil {
+			return fmt.Errorf("invalid required_entitlement: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateArtifact checks the download and integrity fields of an artifact
+// that replaces a release's own, such as an edition or variant artifact.
+func validateArtifact(downloadURL, checksum, checksumType string, fileSize int64) error {
+	if downloadURL == "" {
+		return errors.New("download_url is required")
+	}
+	parsedURL, err := url.Parse(downloadURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return errors.New("download_url must be an absolute HTTP or HTTPS URL")
+	}
+	if checksum == "" {
+		return errors.New("checksum is required")
+	}
+	if !isValidChecksumType(checksumType) {
+		return fmt.Errorf("invalid checksum_type: %s", checksumType)
+	}
+	if fileSize < 0 {
+		return errors.New("file_size cannot be negative")
+	}
+	return nil
+}
+
+// NormalizeEditions returns a copy of editions with normalized names and
+// fields, or nil when there are none.
+func NormalizeEditions(editions map[string]EditionArtifact) map[string]EditionArtifact {
+	if len(editions) == 0 {
+		return nil
+	}
+	out := make(map[string]EditionArtifact, len(editions))
+	for edition, a := range editions {
+		out[NormalizeEdition(edition)] = EditionArtifact{
+			DownloadURL:         strings.TrimSpace(a.DownloadURL),
+			Checksum:            strings.ToLower(strings.TrimSpace(a.Checksum)),
+			ChecksumType:        strings.ToLower(strings.TrimSpace(a.ChecksumType)),
+			FileSize:            a.FileSize,
+			RequiredEntitlement: NormalizeEntitlement(a.RequiredEntitlement),
+		}
+	}
+	return out
+}
+
+// ForEdition returns a copy of the release that serves edition's artifact, or
+// the release itself when it has no artifact for edition. The base artifact's
+// additional checksums and PGP signature do not describe the edition's file,
+// so the copy has neither.
+func (r *Release) ForEdition(edition string) *Release {
+	a, ok := r.Editions[edition]
+	if !ok {
+		return r
+	}
+	release := *r
+	release.DownloadURL = a.DownloadURL
+	release.Checksum = a.Checksum
+	release.ChecksumType = a.ChecksumType
+	release.FileSize = a.FileSize
+	release.Checksums = nil
+	release.PGPSignature = ""
+	return &release
+}
+
+// copyEditions returns a copy of editions, or nil when there are none.
+func copyEditions(editions map[string]EditionArtifact) map[string]EditionArtifact {
+	if len(editions) == 0 {
+		return nil
+	}
+	return maps.Clone(editions)
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEditions(t *testing.T) {
+	valid := EditionArtifact{DownloadURL: "https://example.com/app-pro", Checksum: "abc123", ChecksumType: "sha256"}
+
+	tests := []struct {
+		name     string
+		editions map[string]EditionArtifact
+		wantErr  bool
+	}{
+		{"none", nil, false},
+		{"valid", map[string]EditionArtifact{"pro": valid}, false},
+		{"bad edition name", map[string]EditionArtifact{"Pro Edition": valid}, true},
+		{"missing url", map[string]EditionArtifact{"pro": {Checksum: "abc123", ChecksumType: "sha256"}}, true},
+		{"relative url", map[string]EditionArtifact{"pro": {DownloadURL: "/app-pro", Checksum: "abc123", ChecksumType: "sha256"}}, true},
+		{"missing checksum", map[string]EditionArtifact{"pro": {DownloadURL: "https://example.com/app-pro", ChecksumType: "sha256"}}, true},
+		{"bad checksum type", map[string]EditionArtifact{"pro": {DownloadURL: "https://example.com/app-pro", Checksum: "abc123", ChecksumType: "crc32"}}, true},
+		{"bad entitlement", map[string]EditionArtifact{"pro": {DownloadURL: "https://example.com/app-pro", Checksum: "abc123", ChecksumType: "sha256", RequiredEntitlement: "pro tier"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEditions(tt.editions)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeEditions(t *testing.T) {
+	assert.Nil(t, NormalizeEditions(map[string]EditionArtifact{}))
+
+	got := NormalizeEditions(map[string]EditionArtifact{
+		" Pro ": {DownloadURL: " https://example.com/app-pro ", Checksum: "ABC123", ChecksumType: "SHA256", RequiredEntitlement: "Pro"},
+	})
+	assert.Equal(t, map[string]EditionArtifact{
+		"pro": {DownloadURL: "https://example.com/app-pro", Checksum: "abc123", ChecksumType: "sha256", RequiredEntitlement: "pro"},
+	}, got)
+}
+
+func TestRelease_ForEdition(t *testing.T) {
+	release := NewRelease("app", "1.0.0", "linux", "amd64", "https://example.com/app")
+	release.Checksum = "abc123"
+	release.Checksums = map[string]string{"sha512": "def456"}
+	release.PGPSignature = "-----BEGIN PGP SIGNATURE-----\n\niHUEABYKAB0=\n-----END PGP SIGNATURE-----"
+	release.Editions = map[string]EditionArtifact{
+		"pro": {DownloadURL: "https://example.com/app-pro", Checksum: "fed321", ChecksumType: "blake3", FileSize: 42},
+	}
+
+	assert.Same(t, release, release.ForEdition("enterprise"))
+
+	pro := release.ForEdition("pro")
+	require.NotSame(t, release, pro)
+	assert.Equal(t, "https://example.com/app-pro", pro.DownloadURL)
+	assert.Equal(t, "fed321", pro.Checksum)
+	assert.Equal(t, "blake3", pro.ChecksumType)
+	assert.Equal(t, int64(42), pro.FileSize)
+	assert.Nil(t, pro.Checksums)
+	assert.Empty(t, pro.PGPSignature)
+	assert.Equal(t, "https://example.com/app", release.DownloadURL, "the stored release is unchanged")
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+// Electron apps update through electron-updater, which reads a YAML file
+// describing the newest release from the URL in the app's publish
+// configuration, or through Electron's built-in Squirrel.Windows updater,
+// which reads a RELEASES file. Both are rendered from stored releases, so an
+// Electron app points at this service without a separate static feed.
+// electron-updater compares versions itself; the service only names the
+// newest release the app may be offered.
+
+// ElectronUpdateInfo is the content of an electron-updater channel file such
+// as latest.yml or latest-mac.yml.
+type ElectronUpdateInfo struct {
+	Version      string             `yaml:"version"`
+	Files        []ElectronFileInfo `yaml:"files"`
+	Path         string             `yaml:"path"`
+	SHA512       string             `yaml:"sha512,omitempty"`
+	ReleaseDate  string             `yaml:"releaseDate"`
+	ReleaseNotes string             `yaml:"releaseNotes,omitempty"`
+}
+
+// ElectronFileInfo is a downloadable file of an electron-updater release.
+// electron-updater verifies the download with SHA512, a base64 SHA-512 digest,
+// or falls back to SHA2, a hex SHA-256 digest.
+type ElectronFileInfo struct {
+	URL    string `yaml:"url"`
+	SHA512 string `yaml:"sha512,omitempty"`
+	SHA2   string `yaml:"sha2,omitempty"`
+	Size   int64  `yaml:"size,omitempty"`
+}
+
+// ErrNoElectronChecksum is returned for a release that electron-updater could
+// not verify because it has neither a SHA-512 nor a SHA-256 checksum.
+var ErrNoElectronChecksum = errors.New("release has no sha512 or sha256 checksum")
+
+// ErrNoSquirrelChecksum is returned for a release that Squirrel.Windows could
+// not verify because it has no SHA-1 checksum.
+var ErrNoSquirrelChecksum = errors.New("release has no sha1 checksum")
+
+// NewElectronFileInfo describes the download of a latest-version lookup.
+func NewElectronFileInfo(latest *LatestVersionResponse) (ElectronFileInfo, error) {
+	file := ElectronFileInfo{URL: latest.DownloadURL, Size: latest.FileSize}
+	checksums := latestChecksums(latest)
+	if sum, ok := checksums[ChecksumTypeSHA512]; ok {
+		digest, err := hex.DecodeString(sum)
+		if err != nil {
+			return ElectronFileInfo{}, fmt.Errorf("invalid sha512 checksum: %w", err)
+		}
+		file.SHA512 = base64.StdEncoding.EncodeToString(digest)
+	} else if sum, ok := checksums[ChecksumTypeSHA256]; ok {
+		file.SHA2 = sum
+	} else {
+		return ElectronFileInfo{}, ErrNoElectronChecksum
+	}
+	return file, nil
+}
+
+// NewElectronUpdateInfo builds a channel file offering the files, the first
+// of which is the primary download.
+func NewElectronUpdateInfo(version string, releaseDate time.Time, releaseNotes string, files []ElectronFileInfo) *ElectronUpdateInfo {
+	return &ElectronUpdateInfo{
+		Version:      version,
+		Files:        files,
+		Path:         files[0].URL,
+		SHA512:       files[0].SHA512,
+		ReleaseDate:  releaseDate.UTC().Format(time.RFC3339),
+		ReleaseNotes: releaseNotes,
+	}
+}
+
+// SquirrelReleasesLine renders the download of a latest-version lookup as a
+// line of a Squirrel.Windows RELEASES file: the package's uppercase SHA-1, its
+// URL and its size.
+func SquirrelReleasesLine(latest *LatestVersionResponse) (string, error) {
+	sum, ok := latestChecksums(latest)[ChecksumTypeSHA1]
+	if !ok {
+		return "", ErrNoSquirrelChecksum
+	}
+	return fmt.Sprintf("%s %s %d", strings.ToUpper(sum), latest.DownloadURL, latest.FileSize), nil
+}
+
+// latestChecksums returns every checksum of a latest-version lookup keyed by
+// type, including the primary one.
+func latestChecksums(latest *LatestVersionResponse) map[string]string {
+	all := make(map[string]string, len(latest.Checksums)+1)
+	maps.Copy(all, latest.Checksums)
+	all[latest.ChecksumType] = latest.Checksum
+	return all
+}
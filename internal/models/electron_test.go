@@ -0,0 +1,85 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewElectronFileInfo(t *testing.T) {
+	sha512Hex := strings.Repeat("ab", 64)
+	sha512Base64 := "q6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6urqw=="
+	sha256Hex := strings.Repeat("cd", 32)
+
+	tests := []struct {
+		name    string
+		latest  LatestVersionResponse
+		want    ElectronFileInfo
+		wantErr error
+	}{
+		{
+			name:   "primary sha512 is base64 encoded",
+			latest: LatestVersionResponse{DownloadURL: "https://cdn.example.com/app.exe", Checksum: sha512Hex, ChecksumType: ChecksumTypeSHA512, FileSize: 10},
+			want:   ElectronFileInfo{URL: "https://cdn.example.com/app.exe", SHA512: sha512Base64, Size: 10},
+		},
+		{
+			name: "additional sha512 is preferred over sha256",
+			latest: LatestVersionResponse{DownloadURL: "https://cdn.example.com/app.exe", Checksum: sha256Hex, ChecksumType: ChecksumTypeSHA256,
+				Checksums: map[string]string{ChecksumTypeSHA512: sha512Hex}},
+			want: ElectronFileInfo{URL: "https://cdn.example.com/app.exe", SHA512: sha512Base64},
+		},
+		{
+			name:   "sha256 falls back to sha2",
+			latest: LatestVersionResponse{DownloadURL: "https://cdn.example.com/app.exe", Checksum: sha256Hex, ChecksumType: ChecksumTypeSHA256},
+			want:   ElectronFileInfo{URL: "https://cdn.example.com/app.exe", SHA2: sha256Hex},
+		},
+		{
+			name:    "no usable checksum",
+			latest:  LatestVersionResponse{DownloadURL: "https://cdn.example.com/app.exe", Checksum: "abc", ChecksumType: ChecksumTypeBLAKE3},
+			wantErr: ErrNoElectronChecksum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewElectronFileInfo(&tt.latest)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewElectronUpdateInfo(t *testing.T) {
+	files := []ElectronFileInfo{{URL: "https://cdn.example.com/app-1.2.0.zip", SHA512: "x"}, {URL: "https://cdn.example.com/app-1.2.0-arm64.zip", SHA512: "y"}}
+	released := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	info := NewElectronUpdateInfo("1.2.0", released, "Fixes", files)
+
+	assert.Equal(t, "1.2.0", info.Version)
+	assert.Equal(t, files, info.Files)
+	assert.Equal(t, "https://cdn.example.com/app-1.2.0.zip", info.Path)
+	assert.Equal(t, "x", info.SHA512)
+	assert.Equal(t, "2026-03-01T11:00:00Z", info.ReleaseDate)
+	assert.Equal(t, "Fixes", info.ReleaseNotes)
+}
+
+func TestSquirrelReleasesLine(t *testing.T) {
+	sha1Hex := strings.Repeat("0a", 20)
+
+	line, err := SquirrelReleasesLine(&LatestVersionResponse{
+		DownloadURL: "https://cdn.example.com/App-1.2.0-full.nupkg", Checksum: strings.Repeat("cd", 32), ChecksumType: ChecksumTypeSHA256,
+		Checksums: map[string]string{ChecksumTypeSHA1: sha1Hex}, FileSize: 4096,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToUpper(sha1Hex)+" https://cdn.example.com/App-1.2.0-full.nupkg 4096", line)
+
+	_, err = SquirrelReleasesLine(&LatestVersionResponse{Checksum: strings.Repeat("cd", 32), ChecksumType: ChecksumTypeSHA256})
+	assert.ErrorIs(t, err, ErrNoSquirrelChecksum)
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Entitlement provider names for EntitlementsConfig.Provider.
+const (
+	EntitlementProviderStatic = "static" // License tokens listed in the configuration
+	EntitlementProviderJWT    = "jwt"    // Signed JWTs carrying an entitlements claim
+	EntitlementProviderHTTP   = "http"   // An external licensing service
+)
+
+// JWT signing algorithms accepted by the jwt entitlement provider.
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// MaxEntitlementLength is the maximum length of an entitlement name.
+const MaxEntitlementLength = 50
+
+// MaxLicenseTokenLength bounds the license token a client may send, which is
+// enough for a JWT with a few dozen entitlements.
+const MaxLicenseTokenLength = 4096
+
+// EntitlementsConfig selects how license tokens sent with update checks are
+// turned into entitlements. Releases with a required entitlement are only
+// offered to clients whose token grants it; with no provider configured they
+// are offered to nobody.
+type EntitlementsConfig struct {
+	Provider string                 `yaml:"provider" json:"provider"`
+	Static   []StaticLicense        `yaml:"static" json:"-"`
+	JWT      JWTEntitlementsConfig  `yaml:"jwt" json:"jwt"`
+	HTTP     HTTPEntitlementsConfig `yaml:"http" json:"http"`
+}
+
+// StaticLicense grants entitlements to one license token. Only the SHA-256 hex
+// digest of the token is configured, as with API keys.
+type StaticLicense struct {
+	TokenSHA256  string   `yaml:"token_sha256" json:"-"`
+	Entitlements []string `yaml:"entitlements" json:"entitlements"`
+}
+
+// JWTEntitlementsConfig verifies license tokens that are JWTs signed by a
+// licensing system. The token's Claim lists its entitlements.
+type JWTEntitlementsConfig struct {
+	Algorithm     string `yaml:"algorithm" json:"algorithm"`
+	Secret        string `yaml:"secret" json:"-"`                        // HS256 shared secret
+	PublicKeyFile string `yaml:"public_key_file" json:"public_key_file"` // PEM public key for RS256 and EdDSA
+	Issuer        string `yaml:"issuer" json:"issuer"`                   // Required iss claim, when set
+	Audience      string `yaml:"audience" json:"audience"`               // Required aud claim, when set
+	Claim         string `yaml:"claim" json:"claim"`                     // Claim holding the entitlements list
+}
+
+// HTTPEntitlementsConfig asks an external licensing service for a token's
+// entitlements on every check that considers a gated release.
+type HTTPEntitlementsConfig struct {
+	URL     string        `yaml:"url" json:"url"`
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+func (ec *EntitlementsConfig) Validate() error {
+	switch ec.Provider {
+	case "":
+		return nil
+	case EntitlementProviderStatic:
+		var errs []error
+		for i, license := range ec.Static {
+			if digest, err := hex.DecodeString(license.TokenSHA256); err != nil || len(digest) != 32 {
+				errs = append(errs, fmt.Errorf("static[%d]: token_sha256 must be a SHA-256 hex digest", i))
+			}
+			if err := ValidateEntitlements(license.Entitlements); err != nil {
+				errs = append(errs, fmt.Errorf("static[%d]: %w", i, err))
+			}
+		}
+		return errors.Join(errs...)
+	case EntitlementProviderJWT:
+		return ec.JWT.Validate()
+	case EntitlementProviderHTTP:
+		return ec.HTTP.Validate()
+	default:
+		return fmt.Errorf("invalid entitlement provider: %s (must be static, jwt or http)", ec.Provider)
+	}
+}
+
+func (jc *JWTEntitlementsConfig) Validate() error {
+	switch jc.Algorithm {
+	case JWTAlgorithmHS256:
+		if len(jc.Secret) < 32 {
+			return errors.New("jwt secret must be at least 32 bytes for HS256")
+		}
+	case JWTAlgorithmRS256, JWTAlgorithmEdDSA:
+		if jc.PublicKeyFile == "" {
+			return fmt.Errorf("jwt public_key_file is required for %s", jc.Algorithm)
+		}
+	default:
+		return fmt.Errorf("invalid jwt algorithm: %s (must be HS256, RS256 or EdDSA)", jc.Algorithm)
+	}
+	if jc.Claim == "" {
+		return errors.New("jwt claim cannot be empty")
+	}
+	return nil
+}
+
+func (hc *HTTPEntitlementsConfig) Validate() error {
+	u, err := url.Parse(hc.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.New("http url must be an absolute http or https URL")
+	}
+	if hc.Timeout <= 0 {
+		return errors.New("http timeout must be positive")
+	}
+	return nil
+}
+
+// NormalizeEntitlement lowercases and trims an entitlement name.
+func NormalizeEntitlement(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateEntitlement checks an entitlement name. Entitlements use the tag
+// format so they read the same in configuration, tokens and release payloads.
+func ValidateEntitlement(name string) error {
+	if len(name) > MaxEntitlementLength {
+		return fmt.Errorf("entitlement %q exceeds maximum length of %d", name, MaxEntitlementLength)
+	}
+	if !tagPattern.MatchString(name) {
+		return fmt.Errorf("invalid entitlement %q: must contain only lowercase letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// ValidateEntitlements checks every entitlement in names.
+func ValidateEntitlements(names []string) error {
+	for _, name := range names {
+		if err := ValidateEntitlement(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
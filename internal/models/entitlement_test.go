@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntitlementsConfig_Validate(t *testing.T) {
+	validHash := HashAPIKey("token")
+
+	tests := []struct {
+		name    string
+		config  EntitlementsConfig
+		wantErr bool
+	}{
+		{"disabled", EntitlementsConfig{}, false},
+		{"unknown provider", EntitlementsConfig{Provider: "ldap"}, true},
+		{"static", EntitlementsConfig{Provider: "static", Static: []StaticLicense{{TokenSHA256: validHash, Entitlements: []string{"pro"}}}}, false},
+		{"static plain token", EntitlementsConfig{Provider: "static", Static: []StaticLicense{{TokenSHA256: "token", Entitlements: []string{"pro"}}}}, true},
+		{"static bad entitlement", EntitlementsConfig{Provider: "static", Static: []StaticLicense{{TokenSHA256: validHash, Entitlements: []string{"Pro Tier"}}}}, true},
+		{"jwt hs256", EntitlementsConfig{Provider: "jwt", JWT: JWTEntitlementsConfig{Algorithm: "HS256", Secret: strings.Repeat("s", 32), Claim: "entitlements"}}, false},
+		{"jwt short secret", EntitlementsConfig{Provider: "jwt", JWT: JWTEntitlementsConfig{Algorithm: "HS256", Secret: "short", Claim: "entitlements"}}, true},
+		{"jwt rs256 without key", EntitlementsConfig{Provider: "jwt", JWT: JWTEntitlementsConfig{Algorithm: "RS256", Claim: "entitlements"}}, true},
+		{"jwt eddsa", EntitlementsConfig{Provider: "jwt", JWT: JWTEntitlementsConfig{Algorithm: "EdDSA", PublicKeyFile: "/etc/updater/license.pem", Claim: "entitlements"}}, false},
+		{"jwt none", EntitlementsConfig{Provider: "jwt", JWT: JWTEntitlementsConfig{Algorithm: "none", Claim: "entitlements"}}, true},
+		{"jwt empty claim", EntitlementsConfig{Provider: "jwt", JWT: JWTEntitlementsConfig{Algorithm: "EdDSA", PublicKeyFile: "/etc/updater/license.pem"}}, true},
+		{"http", EntitlementsConfig{Provider: "http", HTTP: HTTPEntitlementsConfig{URL: "https://licensing.example.com/check", Timeout: time.Second}}, false},
+		{"http relative url", EntitlementsConfig{Provider: "http", HTTP: HTTPEntitlementsConfig{URL: "/check", Timeout: time.Second}}, true},
+		{"http no timeout", EntitlementsConfig{Provider: "http", HTTP: HTTPEntitlementsConfig{URL: "https://licensing.example.com/check"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegisterReleaseRequest_RequiredEntitlement(t *testing.T) {
+	req := RegisterReleaseRequest{
+		ApplicationID:       "app",
+		Version:             "1.0.0",
+		Platform:            "linux",
+		Architecture:        "amd64",
+		DownloadURL:         "https://example.com/app",
+		Checksum:            "abc123",
+		ChecksumType:        "sha256",
+		RequiredEntitlement: " Pro ",
+	}
+	assert.NoError(t, req.Validate())
+	req.Normalize()
+	assert.Equal(t, "pro", req.RequiredEntitlement)
+
+	req.RequiredEntitlement = "pro tier"
+	assert.Error(t, req.Validate())
+
+	req.RequiredEntitlement = strings.Repeat("p", MaxEntitlementLength+1)
+	assert.Error(t, req.Validate())
+}
+
+func TestUpdateCheckRequest_LicenseTokenLength(t *testing.T) {
+	req := UpdateCheckRequest{ApplicationID: "app", CurrentVersion: "1.0.0", Platform: "linux", Architecture: "amd64"}
+	req.LicenseToken = strings.Repeat("t", MaxLicenseTokenLength)
+	assert.NoError(t, req.Validate())
+	req.LicenseToken += "t"
+	assert.Error(t, req.Validate())
+}
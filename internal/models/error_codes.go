@@ -0,0 +1,106 @@
+package models
+
+import "net/http"
+
+// ErrorCodeDictionaryVersion is raised whenever a code is added or the advice
+// for a code changes, so clients that cache the dictionary know to refresh it.
+const ErrorCodeDictionaryVersion = 1
+
+// Client actions suggested for an error code.
+const (
+	ClientActionRetry          = "retry"           // Transient; retry with exponential backoff and jitter
+	ClientActionBackOff        = "back_off"        // Overloaded; wait for Retry-After, or back off, before any request
+	ClientActionReauthenticate = "reauthenticate"  // Obtain a new API key or client token, then retry
+	ClientActionFixRequest     = "fix_request"     // Client bug; do not resend the request unchanged
+	ClientActionSurfaceToUser  = "surface_to_user" // Report it and wait for the next scheduled check
+)
+
+// ErrorCodeInfo describes one error code and how clients should react to it.
+type ErrorCodeInfo struct {
+	Code        string `json:"code"`
+	Statuses    []int  `json:"http_statuses"` // HTTP statuses the code is returned with
+	Description string `json:"description"`
+	Action      string `json:"action"`
+	Retryable   bool   `json:"retryable"`   // Whether resending the same request can succeed
+	RetryAfter  bool   `json:"retry_after"` // Whether responses may carry a Retry-After header clients must honour
+}
+
+// ErrorCodeDictionary is the response of /api/v1/error-codes.
+type ErrorCodeDictionary struct {
+	Version int             `json:"version"`
+	Codes   []ErrorCodeInfo `json:"codes"`
+}
+
+// ErrorCodes returns the dictionary of every code in ErrorResponse.Code.
+func ErrorCodes() *ErrorCodeDictionary {
+	return &ErrorCodeDictionary{
+		Version: ErrorCodeDictionaryVersion,
+		Codes: []ErrorCodeInfo{
+			{
+				Code:        ErrorCodeNotFound,
+				Statuses:    []int{http.StatusNotFound},
+				Description: "The release, key or other resource does not exist",
+				Action:      ClientActionSurfaceToUser,
+			},
+			{
+				Code:        ErrorCodeApplicationNotFound,
+				Statuses:    []int{http.StatusNotFound},
+				Description: "The application ID is not registered, usually a misconfigured client. Checking sooner than scheduled will not help",
+				Action:      ClientActionSurfaceToUser,
+			},
+			{
+				Code:        ErrorCodeBadRequest,
+				Statuses:    []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType},
+				Description: "The request is malformed: unparseable body, wrong Content-Type or too large",
+				Action:      ClientActionFixRequest,
+			},
+			{
+				Code:        ErrorCodeInvalidRequest,
+				Statuses:    []int{http.StatusBadRequest},
+				Description: "The request is well-formed but asks for something the service does not support, such as an unsupported platform",
+				Action:      ClientActionFixRequest,
+			},
+			{
+				Code:        ErrorCodeValidation,
+				Statuses:    []int{http.StatusUnprocessableEntity},
+				Description: "A field failed validation, such as a version that is not semantic",
+				Action:      ClientActionFixRequest,
+			},
+			{
+				Code:        ErrorCodeInternalError,
+				Statuses:    []int{http.StatusInternalServerError},
+				Description: "The service failed to handle the request",
+				Action:      ClientActionRetry,
+				Retryable:   true,
+			},
+			{
+				Code:        ErrorCodeUnauthorized,
+				Statuses:    []int{http.StatusUnauthorized},
+				Description: "The API key or client token is missing, invalid or expired",
+				Action:      ClientActionReauthenticate,
+				Retryable:   true,
+			},
+			{
+				Code:        ErrorCodeForbidden,
+				Statuses:    []int{http.StatusForbidden},
+				Description: "The API key lacks the permission, or the client is temporarily blocked for suspicious traffic. A block carries Retry-After; without it the request will keep failing",
+				Action:      ClientActionSurfaceToUser,
+				RetryAfter:  true,
+			},
+			{
+				Code:        ErrorCodeConflict,
+				Statuses:    []int{http.StatusConflict},
+				Description: "The resource already exists or was changed concurrently",
+				Action:      ClientActionSurfaceToUser,
+			},
+			{
+				Code:        ErrorCodeServiceUnavailable,
+				Statuses:    []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+				Description: "The service is overloaded or a dependency is down, or the client sent more requests than a rate-limited endpoint allows",
+				Action:      ClientActionBackOff,
+				Retryable:   true,
+				RetryAfter:  true,
+			},
+		},
+	}
+}
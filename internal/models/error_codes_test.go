@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodes(t *testing.T) {
+	// Every code in ErrorResponse.Code needs advice for clients
+	want := []string{
+		ErrorCodeNotFound,
+		ErrorCodeApplicationNotFound,
+		ErrorCodeBadRequest,
+		ErrorCodeInvalidRequest,
+		ErrorCodeValidation,
+		ErrorCodeInternalError,
+		ErrorCodeUnauthorized,
+		ErrorCodeForbidden,
+		ErrorCodeConflict,
+		ErrorCodeServiceUnavailable,
+	}
+	actions := []string{ClientActionRetry, ClientActionBackOff, ClientActionReauthenticate, ClientActionFixRequest, ClientActionSurfaceToUser}
+
+	dictionary := ErrorCodes()
+	var codes []string
+	for _, info := range dictionary.Codes {
+		codes = append(codes, info.Code)
+		assert.NotEmpty(t, info.Statuses, info.Code)
+		assert.NotEmpty(t, info.Description, info.Code)
+		assert.Contains(t, actions, info.Action, info.Code)
+		if info.Action == ClientActionFixRequest {
+			assert.False(t, info.Retryable, "%s: a request that needs fixing cannot succeed unchanged", info.Code)
+		}
+	}
+	assert.ElementsMatch(t, want, codes)
+}
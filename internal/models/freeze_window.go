@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Freeze windows block publishing to the stable channel for a period, such as
+// Black Friday week, when a bad release would cost the most. An application
+// keeps its change calendar in config.freeze_windows. While a window is
+// active, registering a release on the stable channel or moving one to it is
+// refused. A window that allows break glass lets a request that gives a
+// reason through, when its key holds the break_glass permission; one that does
+// not is a hard freeze no key can pass. Other channels, pausing and yanking
+// are never frozen, so a bad release can still be stopped during a freeze.
+
+const (
+	// MaxFreezeWindows is the maximum number of freeze windows an application can keep.
+	MaxFreezeWindows = 50
+	// MaxFreezeWindowNameLength is the maximum length of a freeze window name.
+	MaxFreezeWindowNameLength = 100
+	// MaxFreezeReasonLength is the maximum length of a freeze window or break-glass reason.
+	MaxFreezeReasonLength = 500
+)
+
+// FreezeWindow is a period during which publishing to the stable channel is
+// blocked.
+type FreezeWindow struct {
+	Name       string    `json:"name"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"` // Exclusive
+	Reason     string    `json:"reason,omitempty"`
+	BreakGlass bool      `json:"break_glass,omitempty"` // Keys with the break_glass permission may still publish
+}
+
+// Active reports whether the window is in force at now.
+func (w FreezeWindow) Active(now time.Time) bool {
+	return !now.Before(w.StartsAt) && now.Before(w.EndsAt)
+}
+
+// ValidateFreezeWindows checks an application's freeze windows.
+func ValidateFreezeWindows(windows []FreezeWindow) error {
+	if len(windows) > MaxFreezeWindows {
+		return fmt.Errorf("cannot keep more than %d freeze windows", MaxFreezeWindows)
+	}
+	seen := make(map[string]bool, len(windows))
+	for _, w := range windows {
+		name := strings.TrimSpace(w.Name)
+		if name == "" {
+			return errors.New("freeze window name is required")
+		}
+		if len(name) > MaxFreezeWindowNameLength {
+			return fmt.Errorf("freeze window name %q exceeds maximum length of %d", name, MaxFreezeWindowNameLength)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate freeze window %q", name)
+		}
+		seen[name] = true
+		if w.StartsAt.IsZero() || w.EndsAt.IsZero() {
+			return fmt.Errorf("freeze window %q needs starts_at and ends_at", name)
+		}
+		if !w.EndsAt.After(w.StartsAt) {
+			return fmt.Errorf("freeze window %q must end after it starts", name)
+		}
+		if len(w.Reason) > MaxFreezeReasonLength {
+			return fmt.Errorf("freeze window %q reason exceeds maximum length of %d", name, MaxFreezeReasonLength)
+		}
+	}
+	return nil
+}
+
+// ActiveFreezeWindow returns the window in force at now, or nil when there is
+// none. Of overlapping windows, one that does not allow break glass wins,
+// since it cannot be passed.
+func ActiveFreezeWindow(windows []FreezeWindow, now time.Time) *FreezeWindow {
+	var active *FreezeWindow
+	for i := range windows {
+		w := &windows[i]
+		if !w.Active(now) {
+			continue
+		}
+		if !w.BreakGlass {
+			return w
+		}
+		if active == nil {
+			active = w
+		}
+	}
+	return active
+}
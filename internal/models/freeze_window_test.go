@@ -0,0 +1,55 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFreezeWindows(t *testing.T) {
+	start := time.Date(2026, 11, 23, 0, 0, 0, 0, time.UTC)
+	window := func(name string, start, end time.Time) FreezeWindow {
+		return FreezeWindow{Name: name, StartsAt: start, EndsAt: end}
+	}
+
+	assert.NoError(t, ValidateFreezeWindows(nil))
+	assert.NoError(t, ValidateFreezeWindows([]FreezeWindow{
+		window("black-friday", start, start.AddDate(0, 0, 7)),
+		window("year-end", start.AddDate(0, 1, 0), start.AddDate(0, 1, 14)),
+	}))
+	assert.ErrorContains(t, ValidateFreezeWindows([]FreezeWindow{window(" ", start, start.Add(time.Hour))}), "name is required")
+	assert.ErrorContains(t, ValidateFreezeWindows([]FreezeWindow{window(strings.Repeat("a", 101), start, start.Add(time.Hour))}), "maximum length")
+	assert.ErrorContains(t, ValidateFreezeWindows([]FreezeWindow{
+		window("black-friday", start, start.Add(time.Hour)),
+		window("black-friday", start, start.Add(time.Hour)),
+	}), "duplicate")
+	assert.ErrorContains(t, ValidateFreezeWindows([]FreezeWindow{window("open-ended", start, time.Time{})}), "needs starts_at and ends_at")
+	assert.ErrorContains(t, ValidateFreezeWindows([]FreezeWindow{window("backwards", start, start)}), "must end after it starts")
+	assert.ErrorContains(t, ValidateFreezeWindows([]FreezeWindow{{
+		Name: "long-reason", StartsAt: start, EndsAt: start.Add(time.Hour), Reason: strings.Repeat("a", MaxFreezeReasonLength+1),
+	}}), "reason exceeds")
+	assert.ErrorContains(t, ValidateFreezeWindows(make([]FreezeWindow, MaxFreezeWindows+1)), "more than")
+}
+
+func TestActiveFreezeWindow(t *testing.T) {
+	start := time.Date(2026, 11, 23, 0, 0, 0, 0, time.UTC)
+	windows := []FreezeWindow{
+		{Name: "black-friday", StartsAt: start, EndsAt: start.AddDate(0, 0, 7), BreakGlass: true},
+		{Name: "cyber-monday", StartsAt: start.AddDate(0, 0, 7), EndsAt: start.AddDate(0, 0, 8)},
+		{Name: "peak-hours", StartsAt: start.AddDate(0, 0, 6), EndsAt: start.AddDate(0, 0, 10), BreakGlass: true},
+	}
+
+	assert.Nil(t, ActiveFreezeWindow(windows, start.Add(-time.Second)))
+	assert.Equal(t, "black-friday", ActiveFreezeWindow(windows, start).Name, "the start is inclusive")
+	assert.Equal(t, "black-friday", ActiveFreezeWindow(windows, start.AddDate(0, 0, 6)).Name, "the first of overlapping break-glass windows")
+	assert.Equal(t, "cyber-monday", ActiveFreezeWindow(windows, start.AddDate(0, 0, 7)).Name, "a hard freeze wins over break-glass windows")
+	assert.Equal(t, "peak-hours", ActiveFreezeWindow(windows, start.AddDate(0, 0, 8)).Name, "the end is exclusive")
+	assert.Nil(t, ActiveFreezeWindow(windows, start.AddDate(0, 0, 10)))
+}
+
+func TestApplicationConfig_ValidateFreezeWindows(t *testing.T) {
+	config := ApplicationConfig{FreezeWindows: []FreezeWindow{{Name: "backwards", StartsAt: time.Now(), EndsAt: time.Now().Add(-time.Hour)}}}
+	assert.ErrorContains(t, config.Validate(), "invalid freeze_windows")
+}
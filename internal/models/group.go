@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxGroupLength is the maximum length of an application group name.
+const MaxGroupLength = 100
+
+// ApplicationGroup is an organizational grouping of applications, such as a
+// team or product family, together with the number of member applications.
+type ApplicationGroup struct {
+	Name             string `json:"name"`
+	ApplicationCount int    `json:"application_count"`
+}
+
+// NormalizeGroup trims surrounding whitespace from a group name.
+// Group names are display strings, so case is preserved.
+func NormalizeGroup(group string) string {
+	return strings.TrimSpace(group)
+}
+
+// ValidateGroup checks that a group name is within the length limit and
+// contains no control characters. An empty name means "ungrouped" and is valid.
+func ValidateGroup(group string) error {
+	if len(group) > MaxGroupLength {
+		return fmt.Errorf("group exceeds maximum length of %d", MaxGroupLength)
+	}
+	for _, r := range group {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("group %q contains control characters", group)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		wantErr bool
+	}{
+		{name: "empty means ungrouped", group: ""},
+		{name: "display name", group: "Payments Team"},
+		{name: "max length", group: strings.Repeat("a", MaxGroupLength)},
+		{name: "too long", group: strings.Repeat("a", MaxGroupLength+1), wantErr: true},
+		{name: "control character", group: "Pay\nments", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGroup(tt.group)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateApplicationRequest_Group(t *testing.T) {
+	group := "  Payments  "
+	req := &UpdateApplicationRequest{Group: &group}
+	assert.NoError(t, req.Validate())
+	req.Normalize()
+	assert.Equal(t, "Payments", *req.Group)
+
+	req = &UpdateApplicationRequest{}
+	req.Normalize()
+	assert.Nil(t, req.Group)
+}
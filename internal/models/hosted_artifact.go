@@ -0,0 +1,37 @@
+package models
+
+import (
+	"net/url"
+	"time"
+)
+
+// Hosted artifacts are uploaded to the server instead of being hosted
+// elsewhere. A release registered with hosted_artifact gets a download URL
+// under the configured artifacts.public_url and waits, marked
+// artifact_pending, until its artifact is uploaded to ArtifactUploadPath. The
+// upload is checked against the checksums the release was registered with,
+// its sha256 and sha512 checksums and size are recorded, and the release is
+// offered from then on. Uploading to a release registered with a download URL
+// moves its artifact to the server the same way.
+
+// ArtifactUploadField is the multipart form field carrying an uploaded
+// artifact.
+const ArtifactUploadField = "file"
+
+// ArtifactUploadPath returns the API path a release's artifact is uploaded to,
+// addressed by the release's ID.
+func ArtifactUploadPath(appID, releaseID string) string {
+	return "/api/v1/updates/" + url.PathEscape(appID) + "/releases/" + url.PathEscape(releaseID) + "/artifact"
+}
+
+// UploadArtifactResponse describes a release after its artifact was stored.
+type UploadArtifactResponse struct {
+	ID           string            `json:"id"`
+	Message      string            `json:"message"`
+	DownloadURL  string            `json:"download_url"`
+	Checksum     string            `json:"checksum"`
+	ChecksumType string            `json:"checksum_type"`
+	Checksums    map[string]string `json:"checksums,omitempty"`
+	FileSize     int64             `json:"file_size"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ID strategies for release IDs. Both give opaque IDs that sort by creation
+// time; neither encodes the application, version, platform or architecture,
+// which identify a release through their own unique index.
+const (
+	IDStrategyULID = "ulid" // 26-character ULID, the default
+	IDStrategyUUID = "uuid" // UUIDv7
+)
+
+// IDGenerator returns a new unique ID for something created at now, so IDs
+// can follow the service's clock.
+type IDGenerator func(now time.Time) string
+
+// NewIDGenerator returns the generator of an ID strategy.
+func NewIDGenerator(strategy string) (IDGenerator, error) {
+	switch strategy {
+	case IDStrategyULID:
+		return NewULID, nil
+	case IDStrategyUUID:
+		return NewUUIDv7, nil
+	default:
+		return nil, fmt.Errorf("unknown ID strategy %q (want %s or %s)", strategy, IDStrategyULID, IDStrategyUUID)
+	}
+}
+
+// crockford is the Crockford base32 alphabet ULIDs are written in.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewULID returns a ULID for now: a 48-bit millisecond timestamp followed by
+// 80 random bits, in Crockford base32. ULIDs sort by creation time to the
+// millisecond.
+func NewULID(now time.Time) string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(now.UnixMilli())<<16)
+	rand.Read(b[6:])
+
+	// 26 characters of 5 bits hold the 128 bits with two to spare at the top
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// NewUUIDv7 returns a UUIDv7. Its timestamp comes from the system clock, not
+// from now: the uuid package takes no time, and it keeps UUIDs from one
+// process in order within a millisecond.
+func NewUUIDv7(time.Time) string {
+	return uuid.Must(uuid.NewV7()).String()
+}
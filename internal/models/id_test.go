@@ -0,0 +1,43 @@
+package models
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewULID(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	first := NewULID(now)
+	require.Len(t, first, 26)
+	assert.Regexp(t, `^[0-9A-HJKMNP-TV-Z]{26}$`, first)
+	assert.LessOrEqual(t, first[0], byte('7'), "the top two bits are zero")
+	assert.Equal(t, "0000000000", NewULID(time.UnixMilli(0))[:10], "the timestamp comes from now")
+
+	second := NewULID(now.Add(time.Millisecond))
+	assert.NotEqual(t, first, second)
+	assert.NotEqual(t, first, NewULID(now), "the random bits differ within a millisecond")
+	ids := []string{second, first}
+	sort.Strings(ids)
+	assert.Equal(t, []string{first, second}, ids, "ULIDs sort by creation time")
+}
+
+func TestNewIDGenerator(t *testing.T) {
+	now := time.Now()
+	ulid, err := NewIDGenerator(IDStrategyULID)
+	require.NoError(t, err)
+	assert.Len(t, ulid(now), 26)
+
+	uuidGen, err := NewIDGenerator(IDStrategyUUID)
+	require.NoError(t, err)
+	id, err := uuid.Parse(uuidGen(now))
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), id.Version())
+
+	_, err = NewIDGenerator("composite")
+	assert.ErrorContains(t, err, "unknown ID strategy")
+}
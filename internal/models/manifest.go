@@ -0,0 +1,130 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxManifestArtifacts caps the number of artifacts a single release manifest
+// may carry.
+const MaxManifestArtifacts = 100
+
+// ReleaseManifest describes one version of an application built for several
+// platforms, as produced by a CI pipeline. Ingesting a manifest registers every
+// artifact as a release in a single atomic operation. The same field names are
+// used for the JSON and YAML encodings.
+type ReleaseManifest struct {
+	ApplicationID  string             `json:"application_id" yaml:"application_id"`
+	Version        string             `json:"version" yaml:"version"`
+	ReleaseNotes   string             `json:"release_notes,omitempty" yaml:"release_notes,omitempty"`
+	Required       bool               `json:"required,omitempty" yaml:"required,omitempty"`
+	MinimumVersion string             `json:"minimum_version,omitempty" yaml:"minimum_version,omitempty"`
+	Metadata       map[string]string  `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Tags           []string           `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Artifacts      []ManifestArtifact `json:"artifacts" yaml:"artifacts"`
+
+	HostVersionConstraint string          `json:"host_version_constraint,omitempty" yaml:"host_version_constraint,omitempty"`
+	RequiredEntitlement   string          `json:"required_entitlement,omitempty" yaml:"required_entitlement,omitempty"`
+	Channel               string          `json:"channel,omitempty" yaml:"channel,omitempty"`
+	Targeting             []TargetingRule `json:"targeting,omitempty" yaml:"targeting,omitempty"`
+}
+
+// ManifestArtifact is a single platform/architecture build within a
+// ReleaseManifest. Artifact metadata is merged over the manifest metadata,
+// with artifact keys taking precedence.
+type ManifestArtifact struct {
+	Platform     string            `json:"platform" yaml:"platform"`
+	Architecture string            `json:"architecture" yaml:"architecture"`
+	DownloadURL  string            `json:"download_url" yaml:"download_url"`
+	Checksum     string            `json:"checksum" yaml:"checksum"`
+	ChecksumType string            `json:"checksum_type" yaml:"checksum_type"`
+	Checksums    map[string]string `json:"checksums,omitempty" yaml:"checksums,omitempty"`
+	PGPSignature string            `json:"pgp_signature,omitempty" yaml:"pgp_signature,omitempty"`
+	FileSize     int64             `json:"file_size" yaml:"file_size"`
+	Metadata     map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Editions map[string]EditionArtifact `json:"editions,omitempty" yaml:"editions,omitempty"`
+	Variant  string                     `json:"variant,omitempty" yaml:"variant,omitempty"`
+	Variants map[string]VariantArtifact `json:"variants,omitempty" yaml:"variants,omitempty"`
+
+	VersionCode       int64  `json:"version_code,omitempty" yaml:"version_code,omitempty"`
+	SigningCertSHA256 string `json:"signing_cert_sha256,omitempty" yaml:"signing_cert_sha256,omitempty"`
+}
+
+// Validate checks the manifest and every artifact in it. Each artifact is
+// validated as the release registration it expands to, and no two artifacts
+// may target the same platform and architecture.
+func (m *ReleaseManifest) Validate() error {
+	if len(m.Artifacts) == 0 {
+		return errors.New("artifacts must contain at least one entry")
+	}
+	if len(m.Artifacts) > MaxManifestArtifacts {
+		return fmt.Errorf("artifacts cannot contain more than %d entries", MaxManifestArtifacts)
+	}
+
+	seen := make(map[string]int, len(m.Artifacts))
+	for i, req := range m.RegisterRequests() {
+		if err := req.Validate(); err != nil {
+			return fmt.Errorf("artifacts[%d]: %w", i, err)
+		}
+		key := NormalizePlatform(req.Platform) + "/" + NormalizeArchitecture(req.Architecture)
+		if j, dup := seen[key]; dup {
+			return fmt.Errorf("artifacts[%d]: duplicates platform and architecture of artifacts[%d] (%s)", i, j, key)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// RegisterRequests expands the manifest into one release registration per
+// artifact, in artifact order. The requests are not normalized.
+func (m *ReleaseManifest) RegisterRequests() []RegisterReleaseRequest {
+	reqs := make([]RegisterReleaseRequest, len(m.Artifacts))
+	for i, a := range m.Artifacts {
+		var metadata map[string]string
+		if len(m.Metadata) > 0 || len(a.Metadata) > 0 {
+			metadata = make(map[string]string, len(m.Metadata)+len(a.Metadata))
+			for k, v := range m.Metadata {
+				metadata[k] = v
+			}
+			for k, v := range a.Metadata {
+				metadata[k] = v
+			}
+		}
+		reqs[i] = RegisterReleaseRequest{
+			ApplicationID:         m.ApplicationID,
+			Version:               m.Version,
+			Platform:              a.Platform,
+			Architecture:          a.Architecture,
+			DownloadURL:           a.DownloadURL,
+			Checksum:              a.Checksum,
+			ChecksumType:          a.ChecksumType,
+			Checksums:             a.Checksums,
+			PGPSignature:          a.PGPSignature,
+			FileSize:              a.FileSize,
+			ReleaseNotes:          m.ReleaseNotes,
+			Required:              m.Required,
+			MinimumVersion:        m.MinimumVersion,
+			Metadata:              metadata,
+			Tags:                  m.Tags,
+			HostVersionConstraint: m.HostVersionConstraint,
+			RequiredEntitlement:   m.RequiredEntitlement,
+			Editions:              a.Editions,
+			Variant:               a.Variant,
+			Variants:              a.Variants,
+			VersionCode:           a.VersionCode,
+			SigningCertSHA256:     a.SigningCertSHA256,
+			Channel:               m.Channel,
+			Targeting:             m.Targeting,
+		}
+	}
+	return reqs
+}
+
+// IngestManifestResponse reports the releases created from a release manifest.
+type IngestManifestResponse struct {
+	ApplicationID string        `json:"application_id"`
+	Version       string        `json:"version"`
+	Releases      []ReleaseInfo `json:"releases"`
+	Message       string        `json:"message"`
+}
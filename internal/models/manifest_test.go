@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testManifest() ReleaseManifest {
+	return ReleaseManifest{
+		ApplicationID: "my-app",
+		Version:       "1.0.0",
+		Metadata:      map[string]string{"commit_sha": "abc123", "channel": "stable"},
+		Artifacts: []ManifestArtifact{
+			{Platform: "windows", Architecture: "amd64", DownloadURL: "https://example.com/w.exe", Checksum: "abc", ChecksumType: "sha256"},
+			{Platform: "linux", Architecture: "arm64", DownloadURL: "https://example.com/l.tgz", Checksum: "def", ChecksumType: "sha256",
+				Metadata: map[string]string{"channel": "edge"}},
+		},
+	}
+}
+
+func TestReleaseManifest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(m *ReleaseManifest)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(m *ReleaseManifest) {}},
+		{name: "no artifacts", mutate: func(m *ReleaseManifest) { m.Artifacts = nil }, wantErr: "at least one"},
+		{name: "too many artifacts", mutate: func(m *ReleaseManifest) {
+			m.Artifacts = make([]ManifestArtifact, MaxManifestArtifacts+1)
+		}, wantErr: "more than"},
+		{name: "invalid version", mutate: func(m *ReleaseManifest) { m.Version = "one" }, wantErr: "artifacts[0]"},
+		{name: "missing checksum", mutate: func(m *ReleaseManifest) { m.Artifacts[1].Checksum = "" }, wantErr: "artifacts[1]"},
+		{name: "duplicate platform", mutate: func(m *ReleaseManifest) {
+			m.Artifacts[1].Platform, m.Artifacts[1].Architecture = "Windows", "AMD64"
+		}, wantErr: "duplicates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testManifest()
+			tt.mutate(&m)
+			err := m.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestReleaseManifest_RegisterRequests(t *testing.T) {
+	m := testManifest()
+	reqs := m.RegisterRequests()
+	require.Len(t, reqs, 2)
+
+	assert.Equal(t, "my-app", reqs[0].ApplicationID)
+	assert.Equal(t, "1.0.0", reqs[1].Version)
+	assert.Equal(t, map[string]string{"commit_sha": "abc123", "channel": "stable"}, reqs[0].Metadata)
+	assert.Equal(t, map[string]string{"commit_sha": "abc123", "channel": "edge"}, reqs[1].Metadata,
+		"artifact metadata overrides manifest metadata")
+	assert.Equal(t, "stable", m.Metadata["channel"], "manifest metadata must not be mutated")
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// MaxDraftCommits bounds the commits a registration can send for drafting
+	// release notes.
+	MaxDraftCommits = 500
+	// MaxCommitMessageLength bounds one commit message.
+	MaxCommitMessageLength = 10000
+)
+
+// CommitInfo describes a commit that went into a release. Registrations can
+// send commits so that a drafting service writes release notes from them.
+type CommitInfo struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// ValidateCommits checks the commits sent with a registration.
+func ValidateCommits(commits []CommitInfo) error {
+	if len(commits) > MaxDraftCommits {
+		return fmt.Errorf("commits cannot exceed %d entries", MaxDraftCommits)
+	}
+	for i, c := range commits {
+		if c.SHA == "" {
+			return fmt.Errorf("commits[%d]: sha is required", i)
+		}
+		if len(c.Message) > MaxCommitMessageLength {
+			return fmt.Errorf("commits[%d]: message exceeds maximum length of %d", i, MaxCommitMessageLength)
+		}
+	}
+	return nil
+}
+
+// NotesDraftConfig configures the external service that drafts release notes
+// from the commits sent with a registration. Drafts are stored on the release
+// for an admin to publish; they are never shown to clients on their own.
+type NotesDraftConfig struct {
+	Enabled bool          `yaml:"enabled" json:"enabled"`
+	URL     string        `yaml:"url" json:"url"`
+	Timeout time.Duration `yaml:"timeout" json:"timeout"` // Limit on one drafting request, which registration waits for
+	Token   string        `yaml:"token" json:"-"`         // Sent as a bearer token, when set
+}
+
+// Validate checks the settings when drafting is enabled.
+func (c *NotesDraftConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		errs = append(errs, errors.New("url must be an absolute http or https URL"))
+	}
+	if c.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// ReleaseNotesDraftRequest is what the drafting service is sent for a
+// registration that carries commits.
+type ReleaseNotesDraftRequest struct {
+	ApplicationID string       `json:"application_id"`
+	Version       string       `json:"version"`
+	Platform      string       `json:"platform"`
+	Architecture  string       `json:"architecture"`
+	ReleaseNotes  string       `json:"release_notes,omitempty"` // Notes the release was registered with
+	Commits       []CommitInfo `json:"commits"`
+}
+
+// ReleaseNotesDraftResponse shows a release's drafted notes next to its
+// published ones, for review.
+type ReleaseNotesDraftResponse struct {
+	ReleaseID    string `json:"release_id"`
+	Draft        string `json:"draft"`
+	ReleaseNotes string `json:"release_notes"`
+}
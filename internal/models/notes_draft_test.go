@@ -0,0 +1,41 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCommits(t *testing.T) {
+	assert.NoError(t, ValidateCommits(nil))
+	assert.NoError(t, ValidateCommits([]CommitInfo{{SHA: "a1b2c3d", Message: "Fix crash"}}))
+	assert.ErrorContains(t, ValidateCommits([]CommitInfo{{Message: "Fix crash"}}), "commits[0]: sha is required")
+	assert.ErrorContains(t, ValidateCommits([]CommitInfo{{SHA: "a1b2c3d", Message: strings.Repeat("x", MaxCommitMessageLength+1)}}), "maximum length")
+	assert.ErrorContains(t, ValidateCommits(make([]CommitInfo, MaxDraftCommits+1)), "cannot exceed")
+}
+
+func TestNotesDraftConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  NotesDraftConfig
+		wantErr string
+	}{
+		{name: "disabled", config: NotesDraftConfig{}},
+		{name: "valid", config: NotesDraftConfig{Enabled: true, URL: "https://notes.example.com/draft", Timeout: 20 * time.Second}},
+		{name: "missing URL", config: NotesDraftConfig{Enabled: true, Timeout: time.Second}, wantErr: "url must be"},
+		{name: "relative URL", config: NotesDraftConfig{Enabled: true, URL: "/draft", Timeout: time.Second}, wantErr: "url must be"},
+		{name: "no timeout", config: NotesDraftConfig{Enabled: true, URL: "https://notes.example.com/draft"}, wantErr: "timeout must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
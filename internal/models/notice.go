@@ -0,0 +1,68 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// An application's notice is an operational message, such as an outage notice
+// or a request not to update yet, that the service returns with every update
+// check, so clients can show it without a new release being shipped. A
+// notice only informs: it does not change what is offered, which pausing a
+// release does. It is set in config.notice and lapses at expires_at, so a
+// notice left behind after an incident does not linger.
+
+// Notice severities.
+const (
+	NoticeInfo    = "info"    // General information, such as planned maintenance
+	NoticeWarning = "warning" // Clients should hold off, such as "don't update yet"
+	NoticeOutage  = "outage"  // Updates or the product are known to be broken
+)
+
+// MaxNoticeMessageLength is the maximum length of a notice message.
+const MaxNoticeMessageLength = 500
+
+// Notice is an operational message shown to an application's clients.
+type Notice struct {
+	Severity  string     `json:"severity"`
+	Message   string     `json:"message"`
+	URL       string     `json:"url,omitempty"`        // Where to read more, such as an incident page
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // When the notice lapses; without it the notice stays until cleared
+}
+
+// ValidateNotice checks an application's notice; nil is valid.
+func ValidateNotice(n *Notice) error {
+	if n == nil {
+		return nil
+	}
+	switch n.Severity {
+	case NoticeInfo, NoticeWarning, NoticeOutage:
+	default:
+		return fmt.Errorf("invalid severity %q: must be info, warning or outage", n.Severity)
+	}
+	if n.Message == "" {
+		return errors.New("message is required")
+	}
+	if len(n.Message) > MaxNoticeMessageLength {
+		return fmt.Errorf("message exceeds maximum length of %d", MaxNoticeMessageLength)
+	}
+	if n.URL != "" {
+		u, err := url.Parse(n.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("url must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+// ActiveAt returns a copy of the notice to show at now, or nil when there is
+// no notice or it has expired.
+func (n *Notice) ActiveAt(now time.Time) *Notice {
+	if n == nil || (n.ExpiresAt != nil && !now.Before(*n.ExpiresAt)) {
+		return nil
+	}
+	active := *n
+	return &active
+}
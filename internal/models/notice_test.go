@@ -0,0 +1,39 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNotice(t *testing.T) {
+	assert.NoError(t, ValidateNotice(nil))
+	assert.NoError(t, ValidateNotice(&Notice{Severity: NoticeOutage, Message: "Downloads are failing", URL: "https://status.example.com/incidents/42"}))
+	assert.ErrorContains(t, ValidateNotice(&Notice{Message: "No severity"}), "invalid severity")
+	assert.ErrorContains(t, ValidateNotice(&Notice{Severity: "urgent", Message: "Unknown severity"}), "invalid severity")
+	assert.ErrorContains(t, ValidateNotice(&Notice{Severity: NoticeInfo}), "message is required")
+	assert.ErrorContains(t, ValidateNotice(&Notice{Severity: NoticeInfo, Message: strings.Repeat("a", MaxNoticeMessageLength+1)}), "maximum length")
+	assert.ErrorContains(t, ValidateNotice(&Notice{Severity: NoticeInfo, Message: "Bad link", URL: "javascript:alert(1)"}), "http or https")
+
+	config := ApplicationConfig{Notice: &Notice{Severity: NoticeWarning}}
+	assert.ErrorContains(t, config.Validate(), "invalid notice")
+}
+
+func TestNotice_ActiveAt(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	notice := &Notice{Severity: NoticeWarning, Message: "Don't update yet", ExpiresAt: &expires}
+
+	active := notice.ActiveAt(now)
+	if assert.NotNil(t, active) {
+		assert.Equal(t, "Don't update yet", active.Message)
+		assert.NotSame(t, notice, active, "the stored notice is not shared")
+	}
+	assert.Nil(t, notice.ActiveAt(expires), "the notice lapses at expires_at")
+	assert.NotNil(t, (&Notice{Severity: NoticeInfo, Message: "Maintenance"}).ActiveAt(now), "a notice without expiry stays until cleared")
+
+	var none *Notice
+	assert.Nil(t, none.ActiveAt(now))
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ApplicationProfileOTA marks an application as firmware for embedded devices.
+// Applications with this profile can be checked through the compact OTA endpoint.
+const ApplicationProfileOTA = "ota"
+
+// A/B slot identifiers. Devices with two firmware partitions report the slot
+// they booted from; the update is written to the other one.
+const (
+	OTASlotA = "a"
+	OTASlotB = "b"
+)
+
+// OTA network hints tell a device which links it may download over.
+const (
+	OTANetworkAny       = "any"       // Any link, including metered cellular
+	OTANetworkUnmetered = "unmetered" // Wi-Fi or Ethernet only
+)
+
+// OTA download chunk limits in bytes. Devices fetch the image in HTTP Range
+// requests no larger than the chunk size so a download fits in a small buffer
+// and can resume after a dropped link.
+const (
+	DefaultOTAChunkSize int64 = 64 * 1024
+	MinOTAChunkSize     int64 = 256
+	MaxOTAChunkSize     int64 = 16 * 1024 * 1024
+)
+
+// OTAConfig holds per-application delivery hints for OTA devices. The server
+// does not enforce the battery and network hints; they are passed to the
+// device, which decides whether to start the download.
+type OTAConfig struct {
+	ChunkSize         int64  `json:"chunk_size,omitempty"`          // Preferred Range request size; DefaultOTAChunkSize when zero
+	MinBatteryPercent int    `json:"min_battery_percent,omitempty"` // Battery level required before updating
+	Network           string `json:"network,omitempty"`             // any or unmetered
+}
+
+func (c *OTAConfig) Validate() error {
+	if err := validateOTAChunkSize(c.ChunkSize); err != nil {
+		return err
+	}
+	if c.MinBatteryPercent < 0 || c.MinBatteryPercent > 100 {
+		return fmt.Errorf("min_battery_percent must be between 0 and 100, got %d", c.MinBatteryPercent)
+	}
+	switch c.Network {
+	case "", OTANetworkAny, OTANetworkUnmetered:
+	default:
+		return fmt.Errorf("invalid network: %s", c.Network)
+	}
+	return nil
+}
+
+func validateOTAChunkSize(size int64) error {
+	if size != 0 && (size < MinOTAChunkSize || size > MaxOTAChunkSize) {
+		return fmt.Errorf("chunk_size must be between %d and %d bytes, got %d", MinOTAChunkSize, MaxOTAChunkSize, size)
+	}
+	return nil
+}
+
+// OTACheckRequest is an update check from an embedded device.
+type OTACheckRequest struct {
+	ApplicationID   string `json:"application_id"`
+	CurrentVersion  string `json:"current_version"`
+	Platform        string `json:"platform"`
+	Architecture    string `json:"architecture"`
+	Slot            string `json:"slot,omitempty"`       // Slot the device booted from (a or b)
+	ChunkSize       int64  `json:"chunk_size,omitempty"` // Largest chunk the device can buffer
+	AllowPrerelease bool   `json:"allow_prerelease"`
+}
+
+func (r *OTACheckRequest) Validate() error {
+	if err := validateRequiredFields(r.ApplicationID, r.Platform, r.Architecture); err != nil {
+		return err
+	}
+	if err := validateVersion(r.CurrentVersion); err != nil {
+		return fmt.Errorf("invalid current_version: %w", err)
+	}
+	switch r.Slot {
+	case "", OTASlotA, OTASlotB:
+	default:
+		return fmt.Errorf("invalid slot: %s", r.Slot)
+	}
+	return validateOTAChunkSize(r.ChunkSize)
+}
+
+func (r *OTACheckRequest) Normalize() {
+	normalizeCommonFields(&r.ApplicationID, &r.Platform, &r.Architecture)
+	r.CurrentVersion = strings.TrimSpace(r.CurrentVersion)
+	r.Slot = strings.ToLower(strings.TrimSpace(r.Slot))
+}
+
+// OTACheckResponse is a compact update check result for embedded devices. It
+// omits release notes and metadata, and is encoded as JSON or, through
+// CompactFields, as CBOR or flat key=value lines with the same field names.
+//
+// When an update is available the device downloads Chunks ranges of at most
+// ChunkSize bytes, writes them to TargetSlot, and verifies Checksum before
+// switching slots.
+type OTACheckResponse struct {
+	UpdateAvailable bool   `json:"update"`
+	Version         string `json:"version,omitempty"`
+	URL             string `json:"url,omitempty"`
+	Checksum        string `json:"checksum,omitempty"`
+	ChecksumType    string `json:"checksum_type,omitempty"`
+	Size            int64  `json:"size,omitempty"`
+	Required        bool   `json:"required,omitempty"`
+	TargetSlot      string `json:"slot,omitempty"`        // Slot to write the update to
+	ChunkSize       int64  `json:"chunk_size,omitempty"`  // Range request size in bytes
+	Chunks          int64  `json:"chunks,omitempty"`      // Number of ranges covering Size
+	MinBattery      int    `json:"min_battery,omitempty"` // Battery percent hint
+	Network         string `json:"network,omitempty"`     // Network hint
+}
+
+// NewOTACheckResponse builds the compact response for an OTA check from the
+// result of a regular update check and the application's OTA settings.
+func NewOTACheckResponse(check *UpdateCheckResponse, cfg *OTAConfig, req *OTACheckRequest) *OTACheckResponse {
+	resp := &OTACheckResponse{UpdateAvailable: check.UpdateAvailable}
+	if !check.UpdateAvailable {
+		return resp
+	}
+
+	resp.Version = check.LatestVersion
+	resp.URL = check.DownloadURL
+	resp.Checksum = check.Checksum
+	resp.ChecksumType = check.ChecksumType
+	resp.Size = check.FileSize
+	resp.Required = check.Required
+	resp.TargetSlot = OtherOTASlot(req.Slot)
+
+	if cfg == nil {
+		cfg = &OTAConfig{}
+	}
+	resp.MinBattery = cfg.MinBatteryPercent
+	resp.Network = cfg.Network
+
+	if resp.Size > 0 {
+		chunk := cfg.ChunkSize
+		if chunk == 0 {
+			chunk = DefaultOTAChunkSize
+		}
+		if req.ChunkSize > 0 && req.ChunkSize < chunk {
+			chunk = req.ChunkSize
+		}
+		resp.ChunkSize = chunk
+		resp.Chunks = (resp.Size + chunk - 1) / chunk
+	}
+	return resp
+}
+
+// OtherOTASlot returns the slot an update should be written to when the device
+// booted from slot. It returns "" when the device did not report a slot.
+func OtherOTASlot(slot string) string {
+	switch slot {
+	case OTASlotA:
+		return OTASlotB
+	case OTASlotB:
+		return OTASlotA
+	}
+	return ""
+}
+
+// CompactFields lists the populated response fields in a fixed order, so every
+// encoding emits them identically. The update flag is always present.
+func (r *OTACheckResponse) CompactFields() CompactFields {
+	var f CompactFields
+	f = f.addBool("update", r.UpdateAvailable, true)
+	f = f.addString("version", r.Version)
+	f = f.addString("url", r.URL)
+	f = f.addString("checksum", r.Checksum)
+	f = f.addString("checksum_type", r.ChecksumType)
+	f = f.addInt("size", r.Size)
+	f = f.addBool("required", r.Required, false)
+	f = f.addString("slot", r.TargetSlot)
+	f = f.addInt("chunk_size", r.ChunkSize)
+	f = f.addInt("chunks", r.Chunks)
+	f = f.addInt("min_battery", int64(r.MinBattery))
+	f = f.addString("network", r.Network)
+	return f
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicationConfig_Validate_OTA(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ApplicationConfig
+		wantErr bool
+	}{
+		{name: "no profile", config: ApplicationConfig{}},
+		{name: "ota profile without settings", config: ApplicationConfig{Profile: ApplicationProfileOTA}},
+		{
+			name: "ota profile with settings",
+			config: ApplicationConfig{Profile: ApplicationProfileOTA, OTA: &OTAConfig{
+				ChunkSize: 4096, MinBatteryPercent: 30, Network: OTANetworkUnmetered,
+			}},
+		},
+		{name: "unknown profile", config: ApplicationConfig{Profile: "kiosk"}, wantErr: true},
+		{name: "ota settings without profile", config: ApplicationConfig{OTA: &OTAConfig{}}, wantErr: true},
+		{name: "chunk too small", config: ApplicationConfig{Profile: ApplicationProfileOTA, OTA: &OTAConfig{ChunkSize: 16}}, wantErr: true},
+		{name: "battery over 100", config: ApplicationConfig{Profile: ApplicationProfileOTA, OTA: &OTAConfig{MinBatteryPercent: 101}}, wantErr: true},
+		{name: "unknown network", config: ApplicationConfig{Profile: ApplicationProfileOTA, OTA: &OTAConfig{Network: "satellite"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOTACheckRequest_Validate(t *testing.T) {
+	valid := func() OTACheckRequest {
+		return OTACheckRequest{ApplicationID: "fw", CurrentVersion: "1.0.0", Platform: "linux", Architecture: "arm"}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *OTACheckRequest)
+		wantErr bool
+	}{
+		{name: "minimal", modify: func(r *OTACheckRequest) {}},
+		{name: "slot and chunk", modify: func(r *OTACheckRequest) { r.Slot = OTASlotB; r.ChunkSize = 1024 }},
+		{name: "missing version", modify: func(r *OTACheckRequest) { r.CurrentVersion = "" }, wantErr: true},
+		{name: "bad slot", modify: func(r *OTACheckRequest) { r.Slot = "c" }, wantErr: true},
+		{name: "chunk too large", modify: func(r *OTACheckRequest) { r.ChunkSize = MaxOTAChunkSize + 1 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			err := req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewOTACheckResponse(t *testing.T) {
+	check := &UpdateCheckResponse{
+		UpdateAvailable: true,
+		LatestVersion:   "1.1.0",
+		DownloadURL:     "https://fw.example.com/1.1.0.bin",
+		Checksum:        "abc",
+		ChecksumType:    "sha256",
+		FileSize:        10000,
+		ReleaseNotes:    "not sent to devices",
+	}
+
+	t.Run("uses application chunk size and hints", func(t *testing.T) {
+		cfg := &OTAConfig{ChunkSize: 4096, MinBatteryPercent: 40, Network: OTANetworkUnmetered}
+		resp := NewOTACheckResponse(check, cfg, &OTACheckRequest{Slot: OTASlotA})
+
+		assert.True(t, resp.UpdateAvailable)
+		assert.Equal(t, "1.1.0", resp.Version)
+		assert.Equal(t, OTASlotB, resp.TargetSlot)
+		assert.Equal(t, int64(4096), resp.ChunkSize)
+		assert.Equal(t, int64(3), resp.Chunks)
+		assert.Equal(t, 40, resp.MinBattery)
+		assert.Equal(t, OTANetworkUnmetered, resp.Network)
+	})
+
+	t.Run("device buffer limits chunk size", func(t *testing.T) {
+		resp := NewOTACheckResponse(check, nil, &OTACheckRequest{ChunkSize: 1000})
+		assert.Equal(t, int64(1000), resp.ChunkSize)
+		assert.Equal(t, int64(10), resp.Chunks)
+		assert.Empty(t, resp.TargetSlot)
+	})
+
+	t.Run("default chunk size", func(t *testing.T) {
+		resp := NewOTACheckResponse(check, nil, &OTACheckRequest{})
+		assert.Equal(t, DefaultOTAChunkSize, resp.ChunkSize)
+		assert.Equal(t, int64(1), resp.Chunks)
+	})
+
+	t.Run("no update", func(t *testing.T) {
+		resp := NewOTACheckResponse(&UpdateCheckResponse{CurrentVersion: "1.1.0"}, &OTAConfig{MinBatteryPercent: 40}, &OTACheckRequest{Slot: OTASlotA})
+		assert.Equal(t, &OTACheckResponse{}, resp)
+	})
+}
+
+func TestOTACheckResponse_CompactFields(t *testing.T) {
+	resp := &OTACheckResponse{
+		UpdateAvailable: true,
+		Version:         "1.1.0",
+		URL:             "https://fw.example.com/1.1.0.bin",
+		Size:            10000,
+		TargetSlot:      OTASlotB,
+	}
+
+	assert.Equal(t, "update=true\nversion=1.1.0\nurl=https://fw.example.com/1.1.0.bin\nsize=10000\nslot=b\n", string(resp.CompactFields().MarshalFlat()))
+	assert.Equal(t, "update=false\n", string((&OTACheckResponse{}).CompactFields().MarshalFlat()))
+}
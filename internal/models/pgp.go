@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// MaxPGPSignatureSize bounds a stored detached signature. An armored
+// signature made with an RSA-4096 key is under 1 KiB.
+const MaxPGPSignatureSize = 8 << 10
+
+const (
+	pgpSignatureHeader  = "-----BEGIN PGP SIGNATURE-----"
+	pgpSignatureFooter  = "-----END PGP SIGNATURE-----"
+	pgpPublicKeyHeader  = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	pgpPublicKeyFooter  = "-----END PGP PUBLIC KEY BLOCK-----"
+	pgpPrivateKeyMarker = "PRIVATE KEY BLOCK"
+)
+
+// ValidatePGPSignature checks that signature is a single ASCII-armored
+// detached OpenPGP signature, as written by gpg --armor --detach-sign. The
+// signature is not verified against the file or a key.
+func ValidatePGPSignature(signature string) error {
+	if len(signature) > MaxPGPSignatureSize {
+		return fmt.Errorf("pgp_signature cannot exceed %d bytes", MaxPGPSignatureSize)
+	}
+	signature = strings.TrimSpace(signature)
+	if !strings.HasPrefix(signature, pgpSignatureHeader) || !strings.HasSuffix(signature, pgpSignatureFooter) ||
+		strings.Count(signature, pgpSignatureHeader) != 1 {
+		return errors.New("pgp_signature must be one ASCII-armored detached signature")
+	}
+	return nil
+}
+
+// ValidatePGPPublicKey checks that key is an ASCII-armored OpenPGP public key
+// block. Private key blocks are rejected so a signing key is never published
+// by mistake.
+func ValidatePGPPublicKey(key []byte) error {
+	armored := strings.TrimSpace(string(key))
+	if strings.Contains(armored, pgpPrivateKeyMarker) {
+		return errors.New("file contains a private key block")
+	}
+	if !strings.HasPrefix(armored, pgpPublicKeyHeader) || !strings.HasSuffix(armored, pgpPublicKeyFooter) {
+		return errors.New("file is not an ASCII-armored public key block")
+	}
+	return nil
+}
+
+// PGPSignaturePath returns the API path a release's detached signature is
+// served at, relative to the service's base URL.
+func PGPSignaturePath(release *Release) string {
+	return "/api/v1/updates/" + url.PathEscape(release.ApplicationID) +
+		"/releases/" + url.PathEscape(release.Version) +
+		"/" + url.PathEscape(release.Platform) +
+		"/" + url.PathEscape(release.Architecture) + "/signature"
+}
+
+// pgpSignatureURL returns PGPSignaturePath for releases that have a signature
+// and "" otherwise.
+func pgpSignatureURL(release *Release) string {
+	if release.PGPSignature == "" {
+		return ""
+	}
+	return PGPSignaturePath(release)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ValidateHostVersionConstraint checks that a plugin release's host version
+// constraint parses as a semver constraint (e.g. ">= 2.0.0, < 3.0.0").
+// An empty constraint means the release is compatible with every host version.
+func ValidateHostVersionConstraint(constraint string) error {
+	if constraint == "" {
+		return nil
+	}
+	if _, err := semver.NewConstraint(constraint); err != nil {
+		return fmt.Errorf("invalid host version constraint %q: %w", constraint, err)
+	}
+	return nil
+}
+
+// IsCompatibleWithHost reports whether the release may be installed into the
+// given host application version. Releases without a constraint are always
+// compatible.
+func (r *Release) IsCompatibleWithHost(hostVersion string) (bool, error) {
+	if r.HostVersionConstraint == "" {
+		return true, nil
+	}
+
+	constraint, err := semver.NewConstraint(r.HostVersionConstraint)
+	if err != nil {
+		return false, fmt.Errorf("invalid host version constraint: %w", err)
+	}
+
+	host, err := semver.NewVersion(hostVersion)
+	if err != nil {
+		return false, fmt.Errorf("invalid host version: %w", err)
+	}
+
+	return constraint.Check(host), nil
+}
+
+// PluginUpdatesRequest asks for the newest release of every plugin of a host
+// application that is compatible with the given host version.
+type PluginUpdatesRequest struct {
+	HostApplicationID string `json:"host_application_id" validate:"required"`
+	HostVersion       string `json:"host_version" validate:"required"`
+	Platform          string `json:"platform" validate:"required"`
+	Architecture      string `json:"architecture" validate:"required"`
+	AllowPrerelease   bool   `json:"allow_prerelease"`
+	LicenseToken      string `json:"license_token,omitempty"` // License token for plugin releases that require an entitlement
+}
+
+func (r *PluginUpdatesRequest) Validate() error {
+	if err := validateRequiredFields(r.HostApplicationID, r.Platform, r.Architecture); err != nil {
+		return err
+	}
+	if r.HostVersion == "" {
+		return errors.New("host_version is required")
+	}
+	if err := validateVersion(r.HostVersion); err != nil {
+		return fmt.Errorf("invalid host_version: %w", err)
+	}
+	if len(r.LicenseToken) > MaxLicenseTokenLength {
+		return fmt.Errorf("license_token cannot exceed %d bytes", MaxLicenseTokenLength)
+	}
+	return nil
+}
+
+func (r *PluginUpdatesRequest) Normalize() {
+	normalizeCommonFields(&r.HostApplicationID, &r.Platform, &r.Architecture)
+	r.HostVersion = strings.TrimSpace(r.HostVersion)
+}
+
+// PluginUpdatesResponse lists the plugins of a host application with the
+// newest release of each that is compatible with the requested host version.
+type PluginUpdatesResponse struct {
+	HostApplicationID string          `json:"host_application_id"`
+	HostVersion       string          `json:"host_version"`
+	Plugins           []PluginRelease `json:"plugins"`
+}
+
+// PluginRelease is a single plugin entry in a PluginUpdatesResponse. Release
+// is nil when the plugin has no release compatible with the host version on
+// the requested platform, which tells clients to keep or disable the plugin.
+type PluginRelease struct {
+	ApplicationID string                 `json:"application_id"`
+	Name          string                 `json:"name"`
+	Release       *LatestVersionResponse `json:"release,omitempty"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateHostVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		wantErr    bool
+	}{
+		{name: "empty means any host", constraint: ""},
+		{name: "range", constraint: ">= 2.0.0, < 3.0.0"},
+		{name: "caret", constraint: "^2.1"},
+		{name: "garbage", constraint: "not a constraint", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHostVersionConstraint(tt.constraint)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRelease_IsCompatibleWithHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraint  string
+		hostVersion string
+		want        bool
+		wantErr     bool
+	}{
+		{name: "no constraint", constraint: "", hostVersion: "9.9.9", want: true},
+		{name: "inside range", constraint: ">= 2.0.0, < 3.0.0", hostVersion: "2.4.1", want: true},
+		{name: "below range", constraint: ">= 2.0.0, < 3.0.0", hostVersion: "1.9.0", want: false},
+		{name: "above range", constraint: ">= 2.0.0, < 3.0.0", hostVersion: "3.0.0", want: false},
+		{name: "invalid host version", constraint: ">= 2.0.0", hostVersion: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Release{HostVersionConstraint: tt.constraint}
+			got, err := r.IsCompatibleWithHost(tt.hostVersion)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPluginUpdatesRequest_Validate(t *testing.T) {
+	valid := PluginUpdatesRequest{HostApplicationID: "host", HostVersion: "2.0.0", Platform: "windows", Architecture: "amd64"}
+	assert.NoError(t, valid.Validate())
+
+	missingHost := valid
+	missingHost.HostVersion = ""
+	assert.Error(t, missingHost.Validate())
+
+	badHost := valid
+	badHost.HostVersion = "two"
+	assert.Error(t, badHost.Validate())
+
+	missingPlatform := valid
+	missingPlatform.Platform = ""
+	assert.Error(t, missingPlatform.Validate())
+}
+
+func TestApplication_Validate_ParentID(t *testing.T) {
+	app := NewApplication("plugin", "Plugin", []string{"windows"})
+	app.ParentID = "host"
+	assert.NoError(t, app.Validate())
+
+	app.ParentID = "plugin"
+	assert.Error(t, app.Validate(), "an application cannot be its own parent")
+
+	app.ParentID = "bad id!"
+	assert.Error(t, app.Validate())
+}
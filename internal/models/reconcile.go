@@ -0,0 +1,160 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// A companion Kubernetes operator manages applications and releases as custom
+// resources and hands each one to the reconcile endpoint, which applies it to
+// storage and returns the status the operator writes back to the resource.
+// Specs use the same snake_case fields as the REST API, so a spec is the body
+// of the matching API request.
+
+// ResourceAPIVersion is the custom resource API version the reconcile endpoint
+// understands.
+const ResourceAPIVersion = "updater.griffinskudder.io/v1alpha1"
+
+// Custom resource kinds.
+const (
+	ResourceKindApplication = "Application" // Spec is an ApplicationDesiredState; metadata.name is the application ID
+	ResourceKindRelease     = "Release"     // Spec is a RegisterReleaseRequest
+)
+
+// Condition types, statuses and reasons reported in a ResourceStatus.
+const (
+	ConditionReady = "Ready"
+
+	ConditionTrue  = "True"
+	ConditionFalse = "False"
+
+	ReasonCreated     = "Created"
+	ReasonUpdated     = "Updated"
+	ReasonUpToDate    = "UpToDate"
+	ReasonInvalidSpec = "InvalidSpec"
+	ReasonNotFound    = "NotFound"
+	ReasonConflict    = "Conflict"
+)
+
+// Resource is a custom resource as the operator sends it. Fields of the
+// Kubernetes object other than these, such as status, are ignored.
+type Resource struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Metadata   ResourceMetadata `json:"metadata"`
+	Spec       json.RawMessage  `json:"spec"`
+}
+
+// ResourceMetadata is the part of a resource's metadata the reconciler uses.
+type ResourceMetadata struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	Generation int64  `json:"generation,omitempty"`
+}
+
+// Validate checks the resource envelope; the spec is checked when it is
+// converted.
+func (r *Resource) Validate() error {
+	if r.APIVersion != ResourceAPIVersion {
+		return fmt.Errorf("unsupported apiVersion %q: expected %s", r.APIVersion, ResourceAPIVersion)
+	}
+	if r.Kind != ResourceKindApplication && r.Kind != ResourceKindRelease {
+		return fmt.Errorf("unsupported kind %q: expected %s or %s", r.Kind, ResourceKindApplication, ResourceKindRelease)
+	}
+	if r.Metadata.Name == "" {
+		return errors.New("metadata.name is required")
+	}
+	if len(r.Spec) == 0 {
+		return errors.New("spec is required")
+	}
+	return nil
+}
+
+// ApplicationState converts an Application resource's spec.
+func (r *Resource) ApplicationState() (*ApplicationDesiredState, error) {
+	var state ApplicationDesiredState
+	if err := decodeSpec(r.Spec, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// ReleaseRequest converts a Release resource's spec.
+func (r *Resource) ReleaseRequest() (*RegisterReleaseRequest, error) {
+	var req RegisterReleaseRequest
+	if err := decodeSpec(r.Spec, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// decodeSpec decodes a spec, rejecting fields the server does not know so a
+// resource written for a newer server is not partly applied.
+func decodeSpec(spec json.RawMessage, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(spec))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+	return nil
+}
+
+// ResourceStatus is the status of a reconciled resource, in the shape of a
+// Kubernetes status subresource.
+type ResourceStatus struct {
+	ObservedGeneration int64       `json:"observedGeneration"`
+	Conditions         []Condition `json:"conditions"`
+}
+
+// Condition is a Kubernetes-style status condition.
+type Condition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason"`
+	Message            string    `json:"message"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// ReconcileResponse carries the status to write back to the resource.
+type ReconcileResponse struct {
+	Status ResourceStatus `json:"status"`
+}
+
+// ReleaseStateChanges lists the fields, by JSON name, in which a stored
+// release differs from desired, ignoring its ID and timestamps.
+func ReleaseStateChanges(release, desired *Release) []string {
+	current, next := releaseFields(release), releaseFields(desired)
+	var changes []string
+	for _, field := range slices.Sorted(maps.Keys(next)) {
+		if !bytes.Equal(current[field], next[field]) {
+			changes = append(changes, field)
+		}
+	}
+	for _, field := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := next[field]; !ok {
+			changes = append(changes, field)
+		}
+	}
+	return changes
+}
+
+// releaseFields returns a release's fields by JSON name, without those the
+// server sets. Whether a release is paused or yanked, and its status checks,
+// are an operational state, not part of its desired state.
+func releaseFields(release *Release) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	data, _ := json.Marshal(release)
+	_ = json.Unmarshal(data, &fields)
+	for _, field := range []string{"id", "release_date", "created_at", "updated_at", "paused", "yanked", "yank_reason", "status_checks"} {
+		delete(fields, field)
+	}
+	if string(fields["tags"]) == "null" {
+		fields["tags"] = json.RawMessage("[]")
+	}
+	return fields
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_Validate(t *testing.T) {
+	valid := Resource{
+		APIVersion: ResourceAPIVersion,
+		Kind:       ResourceKindRelease,
+		Metadata:   ResourceMetadata{Name: "my-app-1-0-0"},
+		Spec:       json.RawMessage(`{}`),
+	}
+	assert.NoError(t, valid.Validate())
+
+	for name, mutate := range map[string]func(*Resource){
+		"api version": func(r *Resource) { r.APIVersion = "updater.griffinskudder.io/v2" },
+		"kind":        func(r *Resource) { r.Kind = "Channel" },
+		"name":        func(r *Resource) { r.Metadata.Name = "" },
+		"spec":        func(r *Resource) { r.Spec = nil },
+	} {
+		r := valid
+		mutate(&r)
+		assert.Error(t, r.Validate(), name)
+	}
+}
+
+func TestResource_ReleaseRequest(t *testing.T) {
+	r := Resource{Spec: json.RawMessage(`{"application_id":"my-app","version":"1.0.0"}`)}
+	req, err := r.ReleaseRequest()
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", req.ApplicationID)
+
+	r.Spec = json.RawMessage(`{"application_id":"my-app","stage":"beta"}`)
+	_, err = r.ReleaseRequest()
+	assert.Error(t, err, "unknown spec fields are rejected")
+}
+
+func TestReleaseStateChanges(t *testing.T) {
+	stored := NewRelease("my-app", "1.0.0", "linux", "amd64", "https://example.com/app.tar.gz")
+	desired := NewRelease("my-app", "1.0.0", "linux", "amd64", "https://example.com/app.tar.gz")
+	desired.CreatedAt = stored.CreatedAt.Add(time.Hour)
+	desired.Tags = nil
+	assert.Empty(t, ReleaseStateChanges(stored, desired), "timestamps are not changes")
+
+	desired.Required = true
+	desired.Metadata = map[string]string{"build": "42"}
+	assert.Equal(t, []string{"metadata", "required"}, ReleaseStateChanges(stored, desired))
+}
@@ -10,13 +10,17 @@
 package models
 
 import (
+	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 	"time"
+	"updater/internal/blake3"
 
 	"github.com/Masterminds/semver/v3"
 )
@@ -25,20 +29,42 @@ import (
 //
 // Security Considerations:
 // - SHA256 is preferred for strong cryptographic integrity
-// - MD5 and SHA1 are supported for legacy compatibility but discouraged
+// - MD5 and SHA1 are deprecated and flagged as checksum_deprecated in responses
 // - Future algorithms can be added without breaking existing releases
 const (
 	ChecksumTypeSHA256 = "sha256" // Recommended: Strong cryptographic hash
-	ChecksumTypeMD5    = "md5"    // Legacy: Weak, use only for compatibility
-	ChecksumTypeSHA1   = "sha1"   // Legacy: Weak, use only for compatibility
+	ChecksumTypeSHA512 = "sha512" // Strong cryptographic hash
+	ChecksumTypeBLAKE3 = "blake3" // Strong cryptographic hash
+	ChecksumTypeMD5    = "md5"    // Deprecated: Weak, use only for compatibility
+	ChecksumTypeSHA1   = "sha1"   // Deprecated: Weak, use only for compatibility
 )
 
 var SupportedChecksumTypes = []string{
 	ChecksumTypeSHA256,
+	ChecksumTypeSHA512,
+	ChecksumTypeBLAKE3,
 	ChecksumTypeMD5,
 	ChecksumTypeSHA1,
 }
 
+// WeakChecksumTypes are the deprecated checksum types. They no longer protect
+// against a deliberately crafted artifact.
+var WeakChecksumTypes = []string{
+	ChecksumTypeMD5,
+	ChecksumTypeSHA1,
+}
+
+// IsWeakChecksumType reports whether checksumType is deprecated.
+func IsWeakChecksumType(checksumType string) bool {
+	checksumType = strings.ToLower(checksumType)
+	for _, ct := range WeakChecksumTypes {
+		if ct == checksumType {
+			return true
+		}
+	}
+	return false
+}
+
 // Release represents a software release with complete metadata and security information.
 //
 // Design Rationale:
@@ -51,14 +77,14 @@ var SupportedChecksumTypes = []string{
 // - Extensible metadata for future needs (signatures, mirrors, etc.)
 // - Audit trail with creation and update timestamps
 type Release struct {
-	ID             string            `json:"id" validate:"required"`               // Unique release identifier (app-version-platform-arch)
+	ID             string            `json:"id" validate:"required"`               // Opaque unique ID (see id.go); older releases may have app-version-platform-arch IDs
 	ApplicationID  string            `json:"application_id" validate:"required"`   // Parent application identifier
 	Version        string            `json:"version" validate:"required"`          // Semantic version string
 	Platform       string            `json:"platform" validate:"required"`         // Target operating system
 	Architecture   string            `json:"architecture" validate:"required"`     // Target CPU architecture
 	DownloadURL    string            `json:"download_url" validate:"required,url"` // External download location
 	Checksum       string            `json:"checksum" validate:"required"`         // Cryptographic hash for integrity
-	ChecksumType   string            `json:"checksum_type" validate:"required"`    // Hash algorithm (sha256, md5, sha1)
+	ChecksumType   string            `json:"checksum_type" validate:"required"`    // Hash algorithm (sha256, sha512, blake3, md5, sha1)
 	FileSize       int64             `json:"file_size" validate:"min=0"`           // File size in bytes
 	ReleaseNotes   string            `json:"release_notes"`                        // Human-readable change description
 	ReleaseDate    time.Time         `json:"release_date"`                         // Official release timestamp
@@ -67,12 +93,31 @@ type Release struct {
 	Metadata       map[string]string `json:"metadata,omitempty"`                   // Extensible key-value metadata
 	CreatedAt      time.Time         `json:"created_at"`                           // Record creation timestamp
 	UpdatedAt      time.Time         `json:"updated_at"`                           // Last modification timestamp
+	Tags           []string          `json:"tags"`                                 // Free-form labels (e.g. "hotfix", "security")
+
+	HostVersionConstraint string                     `json:"host_version_constraint,omitempty"` // Semver constraint on the host version (plugins only)
+	Checksums             map[string]string          `json:"checksums,omitempty"`               // Additional checksums keyed by type (see checksums.go)
+	PGPSignature          string                     `json:"pgp_signature,omitempty"`           // ASCII-armored detached OpenPGP signature (see pgp.go)
+	RequiredEntitlement   string                     `json:"required_entitlement,omitempty"`    // Entitlement a client's license must grant (see entitlement.go)
+	Editions              map[string]EditionArtifact `json:"editions,omitempty"`                // Artifacts of other editions keyed by edition (see edition.go)
+	ReleaseNotesDraft     string                     `json:"release_notes_draft,omitempty"`     // Drafted notes awaiting review (see notes_draft.go)
+	Channel               string                     `json:"channel,omitempty"`                 // Track the release is published to (see channel.go)
+	Targeting             []TargetingRule            `json:"targeting,omitempty"`               // Client attributes the release is restricted to (see targeting.go)
+	Variant               string                     `json:"variant,omitempty"`                 // Packaging of the release's own artifact (see variant.go)
+	Variants              map[string]VariantArtifact `json:"variants,omitempty"`                // Artifacts of other packagings keyed by variant
+	Paused                bool                       `json:"paused,omitempty"`                  // Rollout halted: the release is not offered until it is resumed
+	Yanked                bool                       `json:"yanked,omitempty"`                  // Withdrawn: not offered, and clients on it are offered the release before it
+	VersionCode           int64                      `json:"version_code,omitempty"`            // Android versionCode of the APK (see android.go)
+	SigningCertSHA256     string                     `json:"signing_cert_sha256,omitempty"`     // SHA-256 digest of the APK's signing certificate
+	YankReason            string                     `json:"yank_reason,omitempty"`             // Why the release was yanked, for operators and clients
+	StatusChecks          map[string]StatusCheck     `json:"status_checks,omitempty"`           // Required external checks; not offered until all succeed (see status_check.go)
+	ArtifactPending       bool                       `json:"artifact_pending,omitempty"`        // Hosted artifact not uploaded yet; not offered until it is (see hosted_artifact.go)
 }
 
 // NewRelease creates a new Release with secure defaults.
 //
 // Security Defaults:
-// - Generated composite ID for uniqueness and predictability
+// - Generated ULID, unique and free of the release's other fields
 // - SHA256 checksum algorithm for strong integrity verification
 // - Current timestamp for audit trails
 // - Non-required update (safety first - let users choose)
@@ -82,7 +127,7 @@ func NewRelease(appID, version, platform, arch, downloadURL string) *Release {
 	normalizedPlatform := NormalizePlatform(platform)
 	normalizedArch := NormalizeArchitecture(arch)
 	return &Release{
-		ID:            generateReleaseID(appID, version, normalizedPlatform, normalizedArch),
+		ID:            NewULID(now),
 		ApplicationID: appID,
 		Version:       version,
 		Platform:      normalizedPlatform,
@@ -94,6 +139,7 @@ func NewRelease(appID, version, platform, arch, downloadURL string) *Release {
 		CreatedAt:     now,
 		UpdatedAt:     now,
 		Metadata:      make(map[string]string),
+		Tags:          []string{},
 	}
 }
 
@@ -130,7 +176,8 @@ func (r *Release) Validate() error {
 		return fmt.Errorf("invalid download URL: %w", err)
 	}
 
-	if r.Checksum == "" {
+	// A hosted artifact's checksum is computed when it is uploaded
+	if r.Checksum == "" && !r.ArtifactPending {
 		return errors.New("checksum cannot be empty")
 	}
 
@@ -138,6 +185,44 @@ func (r *Release) Validate() error {
 		return fmt.Errorf("invalid checksum type: %s", r.ChecksumType)
 	}
 
+	if err := ValidateChecksums(r.Checksums, r.ChecksumType); err != nil {
+		return err
+	}
+
+	if r.PGPSignature != "" {
+		if err := ValidatePGPSignature(r.PGPSignature); err != nil {
+			return err
+		}
+	}
+
+	if r.RequiredEntitlement != "" {
+		if err := ValidateEntitlement(r.RequiredEntitlement); err != nil {
+			return err
+		}
+	}
+
+	if r.Channel != "" {
+		if err := ValidateChannel(r.Channel); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateEditions(r.Editions); err != nil {
+		return err
+	}
+
+	if err := ValidateTargeting(r.Targeting); err != nil {
+		return err
+	}
+
+	if err := ValidateVariants(r.Platform, r.Variant, r.Variants); err != nil {
+		return err
+	}
+
+	if err := ValidateAndroidFields(r.Platform, r.VersionCode, r.SigningCertSHA256); err != nil {
+		return err
+	}
+
 	if r.FileSize < 0 {
 		return errors.New("file size cannot be negative")
 	}
@@ -148,6 +233,14 @@ func (r *Release) Validate() error {
 		}
 	}
 
+	if err := ValidateTags(r.Tags); err != nil {
+		return err
+	}
+
+	if err := ValidateHostVersionConstraint(r.HostVersionConstraint); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -212,21 +305,54 @@ func (r *Release) MeetsMinimumVersion(currentVersion string) (bool, error) {
 	return current.GreaterThan(minimum) || current.Equal(minimum), nil
 }
 
+// GenerateChecksum returns the hex checksum of data using the release's
+// checksum type, or sha256 when the type is not supported.
 func (r *Release) GenerateChecksum(data []byte) string {
-	switch r.ChecksumType {
-	case ChecksumTypeSHA256:
-		hash := sha256.Sum256(data)
-		return hex.EncodeToString(hash[:])
-	default:
-		hash := sha256.Sum256(data)
-		return hex.EncodeToString(hash[:])
+	if checksum, ok := computeChecksum(r.ChecksumType, data); ok {
+		return checksum
 	}
+	checksum, _ := computeChecksum(ChecksumTypeSHA256, data)
+	return checksum
 }
 
+// VerifyChecksum reports whether data matches the release's checksums. Every
+// checksum of a supported type must match, and there must be at least one.
 func (r *Release) VerifyChecksum(data []byte) bool {
-	expectedChecksum := strings.ToLower(r.Checksum)
-	actualChecksum := strings.ToLower(r.GenerateChecksum(data))
-	return expectedChecksum == actualChecksum
+	verified := false
+	for checksumType, expected := range r.AllChecksums() {
+		actual, ok := computeChecksum(checksumType, data)
+		if !ok {
+			continue
+		}
+		if !strings.EqualFold(expected, actual) {
+			return false
+		}
+		verified = true
+	}
+	return verified
+}
+
+// computeChecksum returns the hex checksum of data, or false when the service
+// cannot compute checksumType.
+func computeChecksum(checksumType string, data []byte) (string, bool) {
+	switch checksumType {
+	case ChecksumTypeSHA256:
+		hash := sha256.Sum256(data)
+		return hex.EncodeToString(hash[:]), true
+	case ChecksumTypeSHA512:
+		hash := sha512.Sum512(data)
+		return hex.EncodeToString(hash[:]), true
+	case ChecksumTypeMD5:
+		hash := md5.Sum(data)
+		return hex.EncodeToString(hash[:]), true
+	case ChecksumTypeSHA1:
+		hash := sha1.Sum(data)
+		return hex.EncodeToString(hash[:]), true
+	case ChecksumTypeBLAKE3:
+		hash := blake3.Sum256(data)
+		return hex.EncodeToString(hash[:]), true
+	}
+	return "", false
 }
 
 func (r *Release) SetMetadata(key, value string) {
@@ -245,10 +371,6 @@ func (r *Release) GetMetadata(key string) (string, bool) {
 	return value, exists
 }
 
-func generateReleaseID(appID, version, platform, arch string) string {
-	return fmt.Sprintf("%s-%s-%s-%s", appID, version, platform, arch)
-}
-
 func isValidChecksumType(checksumType string) bool {
 	checksumType = strings.ToLower(checksumType)
 	for _, ct := range SupportedChecksumTypes {
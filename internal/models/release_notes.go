@@ -0,0 +1,52 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// releaseNotesVariable matches a {{name}} or {{name:platform-arch}} placeholder.
+var releaseNotesVariable = regexp.MustCompile(`\{\{\s*([a-z_]+)(?::([a-z0-9]+)-([a-z0-9]+))?\s*\}\}`)
+
+// HasReleaseNotesVariables reports whether notes contain a placeholder, so
+// callers can skip sibling lookups for plain notes.
+func HasReleaseNotesVariables(notes string) bool {
+	return strings.Contains(notes, "{{") && releaseNotesVariable.MatchString(notes)
+}
+
+// ExpandReleaseNotes replaces the placeholders in a release's notes with
+// values from the release. {{download_url:<platform>-<arch>}} resolves to the
+// download URL of the same version on another platform through downloadURL,
+// which returns "" when there is no such release. Placeholders with an unknown
+// name are left as written.
+func ExpandReleaseNotes(release *Release, downloadURL func(platform, arch string) string) string {
+	return releaseNotesVariable.ReplaceAllStringFunc(release.ReleaseNotes, func(match string) string {
+		parts := releaseNotesVariable.FindStringSubmatch(match)
+		name, platform, arch := parts[1], parts[2], parts[3]
+		if platform != "" {
+			if name != "download_url" {
+				return match
+			}
+			if platform == release.Platform && arch == release.Architecture {
+				return release.DownloadURL
+			}
+			return downloadURL(platform, arch)
+		}
+
+		switch name {
+		case "app_id":
+			return release.ApplicationID
+		case "version":
+			return release.Version
+		case "date":
+			return release.ReleaseDate.Format("2006-01-02")
+		case "platform":
+			return release.Platform
+		case "architecture":
+			return release.Architecture
+		case "download_url":
+			return release.DownloadURL
+		}
+		return match
+	})
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandReleaseNotes(t *testing.T) {
+	release := NewRelease("my-app", "2.1.0", "windows", "amd64", "https://example.com/2.1.0/app.exe")
+	release.ReleaseDate = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	lookups := map[string]string{"darwin-arm64": "https://example.com/2.1.0/app.dmg"}
+	downloadURL := func(platform, arch string) string {
+		return lookups[platform+"-"+arch]
+	}
+
+	tests := []struct {
+		name  string
+		notes string
+		want  string
+	}{
+		{"plain notes", "Bug fixes", "Bug fixes"},
+		{"release fields", "{{app_id}} {{version}} ({{date}}) for {{platform}}/{{architecture}}", "my-app 2.1.0 (2026-10-16) for windows/amd64"},
+		{"own download link", "Get it: {{ download_url }}", "Get it: https://example.com/2.1.0/app.exe"},
+		{"other platform", "Mac: {{download_url:darwin-arm64}}", "Mac: https://example.com/2.1.0/app.dmg"},
+		{"same platform by name", "{{download_url:windows-amd64}}", "https://example.com/2.1.0/app.exe"},
+		{"missing platform", "Linux: {{download_url:linux-arm64}}", "Linux: "},
+		{"unknown variable", "{{codename}} and {{version:linux-amd64}}", "{{codename}} and {{version:linux-amd64}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release.ReleaseNotes = tt.notes
+			assert.Equal(t, tt.want, ExpandReleaseNotes(release, downloadURL))
+		})
+	}
+}
+
+func TestHasReleaseNotesVariables(t *testing.T) {
+	assert.False(t, HasReleaseNotesVariables("Bug fixes"))
+	assert.False(t, HasReleaseNotesVariables("{{ not a variable }}"))
+	assert.True(t, HasReleaseNotesVariables("Version {{version}}"))
+}
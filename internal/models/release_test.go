@@ -17,7 +17,8 @@ func TestNewRelease(t *testing.T) {
 
 	release := NewRelease(appID, version, platform, arch, downloadURL)
 
-	assert.Equal(t, "test-app-1.2.3-windows-amd64", release.ID)
+	assert.Len(t, release.ID, 26, "a ULID")
+	assert.NotEqual(t, release.ID, NewRelease(appID, version, platform, arch, downloadURL).ID)
 	assert.Equal(t, appID, release.ApplicationID)
 	assert.Equal(t, version, release.Version)
 	assert.Equal(t, "windows", release.Platform)   // Should be normalized
@@ -186,6 +187,20 @@ func TestRelease_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "checksum cannot be empty",
 		},
+		{
+			name: "hosted artifact awaiting upload",
+			release: &Release{
+				ID:              "test-app-1.2.3-windows-amd64",
+				ApplicationID:   "test-app",
+				Version:         "1.2.3",
+				Platform:        "windows",
+				Architecture:    "amd64",
+				DownloadURL:     "https://downloads.example.com/test-app/01J0000000000000000000000",
+				ChecksumType:    "sha256",
+				ArtifactPending: true,
+			},
+			expectError: false,
+		},
 		{
 			name: "invalid checksum type",
 			release: &Release{
@@ -519,6 +534,31 @@ func TestRelease_GenerateChecksum(t *testing.T) {
 	assert.Equal(t, checksum, checksum4) // Should be the same as SHA256
 }
 
+func TestRelease_GenerateChecksum_Types(t *testing.T) {
+	data := []byte("test data")
+	tests := []struct {
+		checksumType string
+		want         string
+	}{
+		{ChecksumTypeSHA512, "0e1e21ecf105ec853d24d728867ad70613c21663a4693074b2a3619c1bd39d66b588c33723bb466c72424e80e3ca63c249078ab347bab9428500e7ee43059d0d"},
+		{ChecksumTypeSHA1, "f48dd853820860816c75d54d0f584dc863327a7c"},
+		{ChecksumTypeMD5, "eb733a00c0c9d336e65691a37ab54293"},
+		{ChecksumTypeBLAKE3, "6a953581d60dbebc9749b56d2383277fb02b58d260b4ccf6f119108fa0f1d4ef"},
+	}
+	for _, tt := range tests {
+		release := &Release{ChecksumType: tt.checksumType, Checksum: tt.want}
+		assert.Equal(t, tt.want, release.GenerateChecksum(data), tt.checksumType)
+		assert.True(t, release.VerifyChecksum(data), tt.checksumType)
+	}
+}
+
+func TestIsWeakChecksumType(t *testing.T) {
+	assert.True(t, IsWeakChecksumType("md5"))
+	assert.True(t, IsWeakChecksumType("SHA1"))
+	assert.False(t, IsWeakChecksumType(ChecksumTypeSHA256))
+	assert.False(t, IsWeakChecksumType(ChecksumTypeBLAKE3))
+}
+
 func TestRelease_VerifyChecksum(t *testing.T) {
 	data := []byte("test data")
 	release := &Release{ChecksumType: ChecksumTypeSHA256}
@@ -589,49 +629,6 @@ func TestRelease_GetMetadata(t *testing.T) {
 	assert.Equal(t, "", value)
 }
 
-func TestGenerateReleaseID(t *testing.T) {
-	tests := []struct {
-		name     string
-		appID    string
-		version  string
-		platform string
-		arch     string
-		expected string
-	}{
-		{
-			name:     "standard release ID",
-			appID:    "my-app",
-			version:  "1.2.3",
-			platform: "windows",
-			arch:     "amd64",
-			expected: "my-app-1.2.3-windows-amd64",
-		},
-		{
-			name:     "with pre-release version",
-			appID:    "test-app",
-			version:  "1.0.0-alpha",
-			platform: "linux",
-			arch:     "arm64",
-			expected: "test-app-1.0.0-alpha-linux-arm64",
-		},
-		{
-			name:     "empty components",
-			appID:    "",
-			version:  "",
-			platform: "",
-			arch:     "",
-			expected: "---",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := generateReleaseID(tt.appID, tt.version, tt.platform, tt.arch)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 func TestIsValidChecksumType(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -659,6 +656,8 @@ func TestIsValidChecksumType(t *testing.T) {
 func TestSupportedChecksumTypes(t *testing.T) {
 	expectedTypes := []string{
 		ChecksumTypeSHA256,
+		ChecksumTypeSHA512,
+		ChecksumTypeBLAKE3,
 		ChecksumTypeMD5,
 		ChecksumTypeSHA1,
 	}
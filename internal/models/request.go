@@ -15,6 +15,7 @@ import (
 	"math"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 )
@@ -22,6 +23,13 @@ import (
 // MaxPageSize is the maximum number of items that can be requested per page.
 const MaxPageSize = 500
 
+// MaxBatchChecks is the maximum number of update checks accepted in a single batch request.
+const MaxBatchChecks = 50
+
+// MaxUpdateCheckWait is the longest a long-polling update check may be held
+// open. Reverse proxies must allow upstream responses to take this long.
+const MaxUpdateCheckWait = 2 * time.Minute
+
 // validReleaseSortFields lists the permitted values for the sort_by field
 // in release list requests and cursors.
 var validReleaseSortFields = []string{"version", "release_date", "platform", "architecture", "created_at"}
@@ -38,20 +46,36 @@ var validSortOrders = []string{"asc", "desc"}
 // - AllowPrerelease enables beta testing workflows
 // - IncludeMetadata controls response size (metadata can be large)
 // - UserAgent and ClientID support analytics and debugging (optional)
+// - OSVersion, Locale and ClientTags are matched against release targeting rules (optional)
 //
 // Security Notes:
 // - No sensitive information should be included
 // - All fields are validated before processing
 // - Version format is strictly validated to prevent injection
 type UpdateCheckRequest struct {
-	ApplicationID   string `json:"application_id" validate:"required"`  // Target application identifier
-	CurrentVersion  string `json:"current_version" validate:"required"` // Client's current version
-	Platform        string `json:"platform" validate:"required"`        // Target OS (windows, linux, darwin)
-	Architecture    string `json:"architecture" validate:"required"`    // Target arch (amd64, arm64, 386, arm)
-	AllowPrerelease bool   `json:"allow_prerelease"`                    // Include pre-release versions
-	IncludeMetadata bool   `json:"include_metadata"`                    // Include release metadata in response
-	UserAgent       string `json:"user_agent,omitempty"`                // Client identification (optional)
-	ClientID        string `json:"client_id,omitempty"`                 // Unique client ID (optional analytics)
+	ApplicationID   string   `json:"application_id" validate:"required"`  // Target application identifier
+	CurrentVersion  string   `json:"current_version" validate:"required"` // Client's current version
+	Platform        string   `json:"platform" validate:"required"`        // Target OS (windows, linux, darwin)
+	Architecture    string   `json:"architecture" validate:"required"`    // Target arch (amd64, arm64, 386, arm)
+	AllowPrerelease bool     `json:"allow_prerelease"`                    // Include pre-release versions
+	IncludeMetadata bool     `json:"include_metadata"`                    // Include release metadata in response
+	UserAgent       string   `json:"user_agent,omitempty"`                // Client identification (optional)
+	ClientID        string   `json:"client_id,omitempty"`                 // Unique client ID (optional analytics)
+	HostVersion     string   `json:"host_version,omitempty"`              // Host application version (plugin checks only)
+	LicenseToken    string   `json:"license_token,omitempty"`             // License token for releases that require an entitlement
+	Edition         string   `json:"edition,omitempty"`                   // Edition the client runs, for releases with per-edition artifacts
+	Channel         string   `json:"channel,omitempty"`                   // Channel the client follows; replaces allow_prerelease when set
+	OSVersion       string   `json:"os_version,omitempty"`                // Client's operating system version, for targeting
+	Locale          string   `json:"locale,omitempty"`                    // Client's locale, such as de-AT, for targeting
+	ClientTags      []string `json:"client_tags,omitempty"`               // Client-reported tags, such as a ring or hardware model, for targeting
+	Variant         string   `json:"variant,omitempty"`                   // Artifact variant the client was installed from, such as msi or portable-zip
+	VersionCode     int64    `json:"version_code,omitempty"`              // Android versionCode of the installed APK
+}
+
+// BatchUpdateCheckRequest bundles several update checks into one round trip,
+// for agents that manage updates for multiple bundled components.
+type BatchUpdateCheckRequest struct {
+	Checks []UpdateCheckRequest `json:"checks" validate:"required,min=1"`
 }
 
 type LatestVersionRequest struct {
@@ -60,6 +84,10 @@ type LatestVersionRequest struct {
 	Architecture    string `json:"architecture" validate:"required"`
 	AllowPrerelease bool   `json:"allow_prerelease"`
 	IncludeMetadata bool   `json:"include_metadata"`
+	LicenseToken    string `json:"license_token,omitempty"` // License token for releases that require an entitlement
+	Edition         string `json:"edition,omitempty"`       // Edition the client runs, for releases with per-edition artifacts
+	Channel         string `json:"channel,omitempty"`       // Channel the client follows; replaces allow_prerelease when set
+	Variant         string `json:"variant,omitempty"`       // Artifact variant to return, such as msi or portable-zip
 }
 
 type ListReleasesRequest struct {
@@ -73,6 +101,7 @@ type ListReleasesRequest struct {
 	SortBy        string   `json:"sort_by,omitempty"`
 	SortOrder     string   `json:"sort_order,omitempty"`
 	Platforms     []string `json:"platforms,omitempty"`
+	Tags          []string `json:"tags,omitempty"` // Releases must carry every listed tag
 }
 
 // RegisterReleaseRequest represents a request to register a new release (admin operation).
@@ -103,6 +132,32 @@ type RegisterReleaseRequest struct {
 	Required       bool              `json:"required"`                             // Force update flag
 	MinimumVersion string            `json:"minimum_version,omitempty"`            // Required current version
 	Metadata       map[string]string `json:"metadata,omitempty"`                   // Additional metadata
+	Tags           []string          `json:"tags,omitempty"`                       // Free-form labels
+
+	HostVersionConstraint string                     `json:"host_version_constraint,omitempty"` // Semver constraint on the host version (plugins only)
+	Checksums             map[string]string          `json:"checksums,omitempty"`               // Additional checksums keyed by type
+	PGPSignature          string                     `json:"pgp_signature,omitempty"`           // ASCII-armored detached OpenPGP signature
+	RequiredEntitlement   string                     `json:"required_entitlement,omitempty"`    // Entitlement a client's license must grant
+	Editions              map[string]EditionArtifact `json:"editions,omitempty"`                // Artifacts of other editions keyed by edition
+	Channel               string                     `json:"channel,omitempty"`                 // Defaults to beta for pre-release versions and stable otherwise
+	Targeting             []TargetingRule            `json:"targeting,omitempty"`               // Restrict the release to clients matching every rule
+	Variant               string                     `json:"variant,omitempty"`                 // Packaging of download_url, such as msi or portable-zip
+	Variants              map[string]VariantArtifact `json:"variants,omitempty"`                // Artifacts of other packagings keyed by variant
+	VersionCode           int64                      `json:"version_code,omitempty"`            // Android versionCode of the APK
+	SigningCertSHA256     string                     `json:"signing_cert_sha256,omitempty"`     // SHA-256 digest of the APK's signing certificate
+
+	// AutoFill asks the server to fetch the artifact and fill in FileSize and
+	// Checksum when they are omitted. ChecksumType defaults to sha256.
+	AutoFill bool `json:"auto_fill,omitempty"`
+
+	// HostedArtifact registers a release whose artifact is to be uploaded to
+	// the server, which generates DownloadURL. Checksum may be omitted, and
+	// is checked against the upload when given.
+	HostedArtifact bool `json:"hosted_artifact,omitempty"`
+
+	// Commits are sent to the release notes drafting service, when one is
+	// configured. They are not stored.
+	Commits []CommitInfo `json:"commits,omitempty"`
 }
 
 type CreateApplicationRequest struct {
@@ -111,19 +166,48 @@ type CreateApplicationRequest struct {
 	Description string            `json:"description"`
 	Platforms   []string          `json:"platforms" validate:"required,min=1"`
 	Config      ApplicationConfig `json:"config"`
+	Tags        []string          `json:"tags,omitempty"`
+	Group       string            `json:"group,omitempty"`
+	ParentID    string            `json:"parent_id,omitempty"`
+	Template    string            `json:"template,omitempty"` // Name of a configured template that supplies defaults
+	Slug        string            `json:"slug,omitempty"`     // Renameable URL identifier; defaults to the ID
 }
 
+// UpdateApplicationRequest applies a partial update. A nil Tags slice leaves
+// tags unchanged; an empty, non-nil slice clears them. Likewise a nil Group
+// leaves the group unchanged and an empty string removes the application from
+// its group. The same applies to ParentID, where an empty string detaches a
+// plugin from its host. A new Slug renames the application; the slug it
+// replaces keeps redirecting to it.
 type UpdateApplicationRequest struct {
 	Name        *string            `json:"name,omitempty"`
 	Description *string            `json:"description,omitempty"`
 	Platforms   []string           `json:"platforms,omitempty"`
 	Config      *ApplicationConfig `json:"config,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Group       *string            `json:"group,omitempty"`
+	ParentID    *string            `json:"parent_id,omitempty"`
+	Slug        *string            `json:"slug,omitempty"`
+}
+
+// MaxCloneReleaseVersions is the maximum number of recent versions a clone can copy.
+const MaxCloneReleaseVersions = 20
+
+// CloneApplicationRequest copies an existing application to a new ID. An empty
+// Name keeps the source's name. Releases is the number of most recent versions
+// whose releases are copied along with the application; zero copies none.
+type CloneApplicationRequest struct {
+	ID       string `json:"id" validate:"required"`
+	Name     string `json:"name,omitempty"`
+	Releases int    `json:"releases,omitempty"`
 }
 
 // ListApplicationsRequest represents a request to list applications with keyset pagination.
 type ListApplicationsRequest struct {
-	Limit int    `json:"limit,omitempty"` // Maximum items per page (1–500); 0 means use default (50)
-	After string `json:"after,omitempty"` // Opaque keyset cursor from a previous response
+	Limit int      `json:"limit,omitempty"` // Maximum items per page (1–500); 0 means use default (50)
+	After string   `json:"after,omitempty"` // Opaque keyset cursor from a previous response
+	Tags  []string `json:"tags,omitempty"`  // Applications must carry every listed tag
+	Group string   `json:"group,omitempty"` // Applications must belong to this group
 }
 
 func (r *ListApplicationsRequest) Validate() error {
@@ -133,6 +217,12 @@ func (r *ListApplicationsRequest) Validate() error {
 	if r.Limit > MaxPageSize {
 		return fmt.Errorf("limit cannot exceed %d", MaxPageSize)
 	}
+	if err := ValidateTags(NormalizeTags(r.Tags)); err != nil {
+		return err
+	}
+	if err := ValidateGroup(NormalizeGroup(r.Group)); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -140,6 +230,10 @@ func (r *ListApplicationsRequest) Normalize() {
 	if r.Limit == 0 {
 		r.Limit = 50
 	}
+	if r.Tags != nil {
+		r.Tags = NormalizeTags(r.Tags)
+	}
+	r.Group = NormalizeGroup(r.Group)
 }
 
 type DeleteReleaseRequest struct {
@@ -156,6 +250,16 @@ type ReleaseFilters struct {
 	Architecture string
 	Version      string
 	Required     *bool
+	Tags         []string // A release matches only if it carries every listed tag.
+}
+
+// ApplicationFilters specifies optional filters for paginated application queries.
+// Tags is an AND filter: an application matches only if it carries every listed tag.
+// An empty Group or ParentID means no filter is applied for that field.
+type ApplicationFilters struct {
+	Tags     []string
+	Group    string
+	ParentID string
 }
 
 func (r *UpdateCheckRequest) Validate() error {
@@ -167,20 +271,117 @@ func (r *UpdateCheckRequest) Validate() error {
 		return fmt.Errorf("invalid current_version: %w", err)
 	}
 
+	if r.HostVersion != "" {
+		if err := validateVersion(r.HostVersion); err != nil {
+			return fmt.Errorf("invalid host_version: %w", err)
+		}
+	}
+
+	if len(r.LicenseToken) > MaxLicenseTokenLength {
+		return fmt.Errorf("license_token cannot exceed %d bytes", MaxLicenseTokenLength)
+	}
+
+	if r.Edition != "" {
+		if err := ValidateEdition(NormalizeEdition(r.Edition)); err != nil {
+			return err
+		}
+	}
+
+	if r.Channel != "" {
+		if err := ValidateChannel(NormalizeChannel(r.Channel)); err != nil {
+			return err
+		}
+	}
+
+	if len(r.OSVersion) > MaxOSVersionLength {
+		return fmt.Errorf("os_version cannot exceed %d characters", MaxOSVersionLength)
+	}
+
+	if r.Locale != "" {
+		if err := ValidateLocale(NormalizeLocale(r.Locale)); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateTags(NormalizeTags(r.ClientTags)); err != nil {
+		return fmt.Errorf("invalid client_tags: %w", err)
+	}
+
+	if r.Variant != "" {
+		if err := ValidateVariant(NormalizeVariant(r.Variant)); err != nil {
+			return err
+		}
+	}
+
+	if r.VersionCode < 0 || r.VersionCode > MaxVersionCode {
+		return fmt.Errorf("version_code must be between 1 and %d", MaxVersionCode)
+	}
+
 	return nil
 }
 
 func (r *UpdateCheckRequest) Normalize() {
 	normalizeCommonFields(&r.ApplicationID, &r.Platform, &r.Architecture)
 	r.CurrentVersion = strings.TrimSpace(r.CurrentVersion)
+	r.HostVersion = strings.TrimSpace(r.HostVersion)
+	r.Edition = NormalizeEdition(r.Edition)
+	r.Channel = NormalizeChannel(r.Channel)
+	r.OSVersion = strings.TrimSpace(r.OSVersion)
+	r.Locale = NormalizeLocale(r.Locale)
+	if len(r.ClientTags) > 0 {
+		r.ClientTags = NormalizeTags(r.ClientTags)
+	}
+	r.Variant = NormalizeVariant(r.Variant)
+}
+
+// Attributes returns what the client reported about itself for targeting and
+// artifact selection.
+func (r *UpdateCheckRequest) Attributes() ClientAttributes {
+	return ClientAttributes{OSVersion: r.OSVersion, Locale: r.Locale, Tags: r.ClientTags, Variant: r.Variant}
+}
+
+// Validate checks the batch size only. Individual checks are validated as they
+// are processed so that one malformed entry does not fail the whole batch.
+func (r *BatchUpdateCheckRequest) Validate() error {
+	if len(r.Checks) == 0 {
+		return errors.New("at least one check is required")
+	}
+	if len(r.Checks) > MaxBatchChecks {
+		return fmt.Errorf("checks cannot exceed %d entries", MaxBatchChecks)
+	}
+	return nil
 }
 
 func (r *LatestVersionRequest) Validate() error {
-	return validateRequiredFields(r.ApplicationID, r.Platform, r.Architecture)
+	if err := validateRequiredFields(r.ApplicationID, r.Platform, r.Architecture); err != nil {
+		return err
+	}
+	if len(r.LicenseToken) > MaxLicenseTokenLength {
+		return fmt.Errorf("license_token cannot exceed %d bytes", MaxLicenseTokenLength)
+	}
+	if r.Edition != "" {
+		if err := ValidateEdition(NormalizeEdition(r.Edition)); err != nil {
+			return err
+		}
+	}
+	if r.Channel != "" {
+		if err := ValidateChannel(NormalizeChannel(r.Channel)); err != nil {
+			return err
+		}
+	}
+	if r.Variant != "" {
+		if err := ValidateVariant(NormalizeVariant(r.Variant)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *LatestVersionRequest) Normalize() {
 	normalizeCommonFields(&r.ApplicationID, &r.Platform, &r.Architecture)
+	r.Edition = NormalizeEdition(r.Edition)
+	r.Channel = NormalizeChannel(r.Channel)
+	r.Variant = NormalizeVariant(r.Variant)
 }
 
 func (r *ListReleasesRequest) Validate() error {
@@ -224,6 +425,10 @@ func (r *ListReleasesRequest) Validate() error {
 		}
 	}
 
+	if err := ValidateTags(NormalizeTags(r.Tags)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -236,6 +441,10 @@ func (r *ListReleasesRequest) Normalize() {
 		r.Platforms[i] = NormalizePlatform(platform)
 	}
 
+	if r.Tags != nil {
+		r.Tags = NormalizeTags(r.Tags)
+	}
+
 	if r.Limit == 0 {
 		r.Limit = 50 // Default limit
 	}
@@ -256,22 +465,71 @@ func (r *RegisterReleaseRequest) Validate() error {
 		return fmt.Errorf("invalid version: %w", err)
 	}
 
-	if r.DownloadURL == "" {
+	if r.HostedArtifact {
+		if r.DownloadURL != "" {
+			return errors.New("download_url is generated for hosted_artifact releases; omit it")
+		}
+		if r.AutoFill {
+			return errors.New("auto_fill and hosted_artifact cannot be combined")
+		}
+	} else if r.DownloadURL == "" {
 		return errors.New("download_url is required")
 	}
 
-	if r.Checksum == "" {
+	if r.Checksum == "" && !r.AutoFill && !r.HostedArtifact {
 		return errors.New("checksum is required")
 	}
 
-	if r.ChecksumType == "" {
+	if r.ChecksumType == "" && !r.computesChecksum() {
 		return errors.New("checksum_type is required")
 	}
 
-	if !isValidChecksumType(r.ChecksumType) {
+	if r.ChecksumType != "" && !isValidChecksumType(r.ChecksumType) {
 		return fmt.Errorf("invalid checksum_type: %s", r.ChecksumType)
 	}
 
+	if err := ValidateChecksums(r.Checksums, r.ChecksumType); err != nil {
+		return err
+	}
+
+	if r.PGPSignature != "" {
+		if err := ValidatePGPSignature(r.PGPSignature); err != nil {
+			return err
+		}
+	}
+
+	if r.RequiredEntitlement != "" {
+		if err := ValidateEntitlement(NormalizeEntitlement(r.RequiredEntitlement)); err != nil {
+			return fmt.Errorf("invalid required_entitlement: %w", err)
+		}
+	}
+
+	if err := ValidateEditions(NormalizeEditions(r.Editions)); err != nil {
+		return err
+	}
+
+	if r.Channel != "" {
+		if err := ValidateChannel(NormalizeChannel(r.Channel)); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateTargeting(NormalizeTargeting(r.Targeting)); err != nil {
+		return err
+	}
+
+	if err := ValidateVariants(strings.ToLower(strings.TrimSpace(r.Platform)), NormalizeVariant(r.Variant), NormalizeVariants(r.Variants)); err != nil {
+		return err
+	}
+
+	if err := ValidateAndroidFields(strings.ToLower(strings.TrimSpace(r.Platform)), r.VersionCode, NormalizeSigningCertDigest(r.SigningCertSHA256)); err != nil {
+		return err
+	}
+
+	if err := ValidateCommits(r.Commits); err != nil {
+		return err
+	}
+
 	if r.FileSize < 0 {
 		return errors.New("file_size cannot be negative")
 	}
@@ -282,15 +540,43 @@ func (r *RegisterReleaseRequest) Validate() error {
 		}
 	}
 
+	if err := ValidateTags(NormalizeTags(r.Tags)); err != nil {
+		return err
+	}
+
+	if err := ValidateHostVersionConstraint(strings.TrimSpace(r.HostVersionConstraint)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// computesChecksum reports whether the server is to compute the release's
+// checksum, from the artifact it fetches or the one uploaded to it.
+func (r *RegisterReleaseRequest) computesChecksum() bool {
+	return (r.AutoFill || r.HostedArtifact) && r.Checksum == ""
+}
+
 func (r *RegisterReleaseRequest) Normalize() {
 	normalizeCommonFields(&r.ApplicationID, &r.Platform, &r.Architecture)
 	r.ChecksumType = strings.ToLower(r.ChecksumType)
+	if r.computesChecksum() && r.ChecksumType == "" {
+		r.ChecksumType = ChecksumTypeSHA256
+	}
 	r.Version = strings.TrimSpace(r.Version)
 	r.DownloadURL = strings.TrimSpace(r.DownloadURL)
 	r.Checksum = strings.TrimSpace(strings.ToLower(r.Checksum))
+	r.Checksums = NormalizeChecksums(r.Checksums)
+	r.PGPSignature = strings.TrimSpace(r.PGPSignature)
+	r.RequiredEntitlement = NormalizeEntitlement(r.RequiredEntitlement)
+	r.Editions = NormalizeEditions(r.Editions)
+	r.Channel = NormalizeChannel(r.Channel)
+	r.Targeting = NormalizeTargeting(r.Targeting)
+	r.Variant = NormalizeVariant(r.Variant)
+	r.Variants = NormalizeVariants(r.Variants)
+	r.SigningCertSHA256 = NormalizeSigningCertDigest(r.SigningCertSHA256)
+	r.Tags = NormalizeTags(r.Tags)
+	r.HostVersionConstraint = strings.TrimSpace(r.HostVersionConstraint)
 }
 
 func (r *CreateApplicationRequest) Validate() error {
@@ -320,6 +606,29 @@ func (r *CreateApplicationRequest) Validate() error {
 		return fmt.Errorf("invalid config: %w", err)
 	}
 
+	if err := ValidateTags(NormalizeTags(r.Tags)); err != nil {
+		return err
+	}
+
+	if err := ValidateGroup(NormalizeGroup(r.Group)); err != nil {
+		return err
+	}
+
+	if r.ParentID != "" {
+		if !isValidID(strings.TrimSpace(r.ParentID)) {
+			return errors.New("parent_id must contain only alphanumeric characters, hyphens, and underscores")
+		}
+		if strings.TrimSpace(r.ParentID) == strings.TrimSpace(r.ID) {
+			return errors.New("application cannot be its own parent")
+		}
+	}
+
+	if r.Slug != "" {
+		if err := ValidateSlug(strings.TrimSpace(r.Slug)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -331,6 +640,11 @@ func (r *CreateApplicationRequest) Normalize() {
 	for i, platform := range r.Platforms {
 		r.Platforms[i] = NormalizePlatform(platform)
 	}
+
+	r.Tags = NormalizeTags(r.Tags)
+	r.Group = NormalizeGroup(r.Group)
+	r.ParentID = strings.TrimSpace(r.ParentID)
+	r.Slug = strings.TrimSpace(r.Slug)
 }
 
 func (r *UpdateApplicationRequest) Validate() error {
@@ -352,6 +666,26 @@ func (r *UpdateApplicationRequest) Validate() error {
 		}
 	}
 
+	if err := ValidateTags(NormalizeTags(r.Tags)); err != nil {
+		return err
+	}
+
+	if r.Group != nil {
+		if err := ValidateGroup(NormalizeGroup(*r.Group)); err != nil {
+			return err
+		}
+	}
+
+	if r.ParentID != nil && *r.ParentID != "" && !isValidID(strings.TrimSpace(*r.ParentID)) {
+		return errors.New("parent_id must contain only alphanumeric characters, hyphens, and underscores")
+	}
+
+	if r.Slug != nil {
+		if err := ValidateSlug(strings.TrimSpace(*r.Slug)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -371,6 +705,50 @@ func (r *UpdateApplicationRequest) Normalize() {
 			r.Platforms[i] = NormalizePlatform(platform)
 		}
 	}
+
+	if r.Tags != nil {
+		r.Tags = NormalizeTags(r.Tags)
+	}
+
+	if r.Group != nil {
+		group := NormalizeGroup(*r.Group)
+		r.Group = &group
+	}
+
+	if r.ParentID != nil {
+		parentID := strings.TrimSpace(*r.ParentID)
+		r.ParentID = &parentID
+	}
+
+	if r.Slug != nil {
+		slug := strings.TrimSpace(*r.Slug)
+		r.Slug = &slug
+	}
+}
+
+func (r *CloneApplicationRequest) Validate() error {
+	if r.ID == "" {
+		return errors.New("id is required")
+	}
+
+	if !isValidID(strings.TrimSpace(r.ID)) {
+		return errors.New("id must contain only alphanumeric characters, hyphens, and underscores")
+	}
+
+	if r.Releases < 0 {
+		return errors.New("releases cannot be negative")
+	}
+
+	if r.Releases > MaxCloneReleaseVersions {
+		return fmt.Errorf("releases cannot exceed %d", MaxCloneReleaseVersions)
+	}
+
+	return nil
+}
+
+func (r *CloneApplicationRequest) Normalize() {
+	r.ID = strings.TrimSpace(r.ID)
+	r.Name = strings.TrimSpace(r.Name)
 }
 
 // validateRequiredFields validates common required fields across request types
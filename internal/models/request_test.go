@@ -571,6 +571,80 @@ func TestRegisterReleaseRequest_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid minimum_version format",
 		},
+		{
+			name: "auto_fill without checksum",
+			request: RegisterReleaseRequest{
+				ApplicationID: "test-app",
+				Version:       "1.2.3",
+				Platform:      "windows",
+				Architecture:  "amd64",
+				DownloadURL:   "https://example.com/download",
+				AutoFill:      true,
+			},
+			expectError: false,
+		},
+		{
+			name: "auto_fill computes blake3",
+			request: RegisterReleaseRequest{
+				ApplicationID: "test-app",
+				Version:       "1.2.3",
+				Platform:      "windows",
+				Architecture:  "amd64",
+				DownloadURL:   "https://example.com/download",
+				ChecksumType:  "blake3",
+				AutoFill:      true,
+			},
+			expectError: false,
+		},
+		{
+			name: "hosted_artifact without download URL or checksum",
+			request: RegisterReleaseRequest{
+				ApplicationID:  "test-app",
+				Version:        "1.2.3",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				HostedArtifact: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "hosted_artifact with download URL",
+			request: RegisterReleaseRequest{
+				ApplicationID:  "test-app",
+				Version:        "1.2.3",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				DownloadURL:    "https://example.com/download",
+				HostedArtifact: true,
+			},
+			expectError: true,
+			errorMsg:    "download_url is generated",
+		},
+		{
+			name: "hosted_artifact with auto_fill",
+			request: RegisterReleaseRequest{
+				ApplicationID:  "test-app",
+				Version:        "1.2.3",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				AutoFill:       true,
+				HostedArtifact: true,
+			},
+			expectError: true,
+			errorMsg:    "cannot be combined",
+		},
+		{
+			name: "hosted_artifact computes blake3",
+			request: RegisterReleaseRequest{
+				ApplicationID:  "test-app",
+				Version:        "1.2.3",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				ChecksumType:   "blake3",
+				HostedArtifact: true,
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -712,6 +786,35 @@ func TestCreateApplicationRequest_Normalize(t *testing.T) {
 	assert.Equal(t, []string{"windows", "linux"}, request.Platforms)
 }
 
+func TestCloneApplicationRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     CloneApplicationRequest
+		expectError bool
+		errorMsg    string
+	}{
+		{name: "valid request", request: CloneApplicationRequest{ID: "test-app-copy", Releases: 3}},
+		{name: "padded ID", request: CloneApplicationRequest{ID: " test-app-copy "}},
+		{name: "empty ID", request: CloneApplicationRequest{}, expectError: true, errorMsg: "id is required"},
+		{name: "invalid ID", request: CloneApplicationRequest{ID: "test app"}, expectError: true, errorMsg: "id must contain only"},
+		{name: "negative releases", request: CloneApplicationRequest{ID: "copy", Releases: -1}, expectError: true, errorMsg: "releases cannot be negative"},
+		{name: "too many releases", request: CloneApplicationRequest{ID: "copy", Releases: MaxCloneReleaseVersions + 1}, expectError: true, errorMsg: "releases cannot exceed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestUpdateApplicationRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
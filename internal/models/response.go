@@ -11,6 +11,8 @@
 package models
 
 import (
+	"maps"
+	"net/http"
 	"time"
 )
 
@@ -36,13 +38,49 @@ type UpdateCheckResponse struct {
 	DownloadURL         string            `json:"download_url,omitempty"`         // Download location (if update exists)
 	Checksum            string            `json:"checksum,omitempty"`             // File integrity hash
 	ChecksumType        string            `json:"checksum_type,omitempty"`        // Hash algorithm
+	ChecksumDeprecated  bool              `json:"checksum_deprecated,omitempty"`  // Checksum type is md5 or sha1
+	Checksums           map[string]string `json:"checksums,omitempty"`            // Additional checksums keyed by type
+	PGPSignatureURL     string            `json:"pgp_signature_url,omitempty"`    // Path of the detached OpenPGP signature
 	FileSize            int64             `json:"file_size,omitempty"`            // File size for progress tracking
 	ReleaseNotes        string            `json:"release_notes,omitempty"`        // Human-readable changes
 	ReleaseDate         *time.Time        `json:"release_date,omitempty"`         // Release timestamp
 	Required            bool              `json:"required"`                       // Critical update flag
+	Security            bool              `json:"security,omitempty"`             // Release is tagged "security"
 	MinimumVersion      string            `json:"minimum_version,omitempty"`      // Required current version
 	Metadata            map[string]string `json:"metadata,omitempty"`             // Extended metadata (optional)
 	UpgradeInstructions string            `json:"upgrade_instructions,omitempty"` // Custom upgrade steps
+	NextCheckSeconds    int64             `json:"next_check_seconds,omitempty"`   // Seconds until the client's next scheduled check
+	Variant             string            `json:"variant,omitempty"`              // Packaging of the download, when the release declares one
+	Downgrade           bool              `json:"downgrade,omitempty"`            // The offered release is older than the client's, which was yanked
+	VersionCode         int64             `json:"version_code,omitempty"`         // Android versionCode of the offered APK
+	SigningCertSHA256   string            `json:"signing_cert_sha256,omitempty"`  // SHA-256 digest of the offered APK's signing certificate
+	Notice              *Notice           `json:"notice,omitempty"`               // The application's operational message, if any
+}
+
+// IsPriority reports whether the check offers a required or security update,
+// or a downgrade off a yanked release, which is still served while the
+// service sheds load.
+func (r *UpdateCheckResponse) IsPriority() bool {
+	return r.UpdateAvailable && (r.Required || r.Security || r.Downgrade)
+}
+
+// BatchUpdateCheckResponse holds one result per check, in request order.
+type BatchUpdateCheckResponse struct {
+	Results []BatchUpdateCheckResult `json:"results"`
+}
+
+// BatchUpdateCheckResult is the outcome of a single check within a batch.
+// Exactly one of Result and Error is set.
+type BatchUpdateCheckResult struct {
+	ApplicationID string               `json:"application_id"`
+	Result        *UpdateCheckResponse `json:"result,omitempty"`
+	Error         *BatchCheckError     `json:"error,omitempty"`
+}
+
+// BatchCheckError describes why a single check within a batch failed.
+type BatchCheckError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type LatestVersionResponse struct {
@@ -55,6 +93,13 @@ type LatestVersionResponse struct {
 	ReleaseDate  time.Time         `json:"release_date"`
 	Required     bool              `json:"required"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
+
+	ChecksumDeprecated bool              `json:"checksum_deprecated,omitempty"`
+	Checksums          map[string]string `json:"checksums,omitempty"`
+	PGPSignatureURL    string            `json:"pgp_signature_url,omitempty"`
+	Variant            string            `json:"variant,omitempty"`             // Packaging of the download, when the release declares one
+	VersionCode        int64             `json:"version_code,omitempty"`        // Android versionCode of the APK
+	SigningCertSHA256  string            `json:"signing_cert_sha256,omitempty"` // SHA-256 digest of the APK's signing certificate
 }
 
 type ListReleasesResponse struct {
@@ -74,15 +119,40 @@ type ReleaseInfo struct {
 	FileSize       int64             `json:"file_size"`
 	ReleaseNotes   string            `json:"release_notes"`
 	ReleaseDate    time.Time         `json:"release_date"`
+	UpdatedAt      time.Time         `json:"updated_at"`
 	Required       bool              `json:"required"`
 	MinimumVersion string            `json:"minimum_version,omitempty"`
 	Metadata       map[string]string `json:"metadata,omitempty"`
+	Tags           []string          `json:"tags"`
+
+	HostVersionConstraint string                     `json:"host_version_constraint,omitempty"`
+	ChecksumDeprecated    bool                       `json:"checksum_deprecated,omitempty"`
+	Checksums             map[string]string          `json:"checksums,omitempty"`
+	PGPSignatureURL       string                     `json:"pgp_signature_url,omitempty"`
+	RequiredEntitlement   string                     `json:"required_entitlement,omitempty"`
+	Editions              map[string]EditionArtifact `json:"editions,omitempty"`
+	Channel               string                     `json:"channel"`
+	Targeting             []TargetingRule            `json:"targeting,omitempty"`
+	Variant               string                     `json:"variant,omitempty"`
+	Variants              map[string]VariantArtifact `json:"variants,omitempty"`
+	Paused                bool                       `json:"paused,omitempty"`
+	Yanked                bool                       `json:"yanked,omitempty"`
+	YankReason            string                     `json:"yank_reason,omitempty"`
+	VersionCode           int64                      `json:"version_code,omitempty"`
+	SigningCertSHA256     string                     `json:"signing_cert_sha256,omitempty"`
+	StatusChecks          map[string]StatusCheck     `json:"status_checks,omitempty"`
+	ArtifactPending       bool                       `json:"artifact_pending,omitempty"`
 }
 
 type RegisterReleaseResponse struct {
 	ID        string    `json:"id"`
 	Message   string    `json:"message"`
 	CreatedAt time.Time `json:"created_at"`
+
+	// Set for releases registered with hosted_artifact: where the artifact
+	// is to be uploaded, relative to the API, and where clients will download it
+	UploadPath  string `json:"upload_path,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
 }
 
 type CreateApplicationResponse struct {
@@ -91,6 +161,16 @@ type CreateApplicationResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// CloneApplicationResponse reports a cloned application and how many releases
+// were copied to it.
+type CloneApplicationResponse struct {
+	ID             string    `json:"id"`
+	SourceID       string    `json:"source_id"`
+	ReleasesCopied int       `json:"releases_copied"`
+	Message        string    `json:"message"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 type UpdateApplicationResponse struct {
 	ID        string    `json:"id"`
 	Message   string    `json:"message"`
@@ -102,6 +182,25 @@ type DeleteReleaseResponse struct {
 	Message string `json:"message"`
 }
 
+// YankReleaseResponse is the response of yanking a release or withdrawing
+// its yank.
+type YankReleaseResponse struct {
+	ID         string    `json:"id"`
+	Yanked     bool      `json:"yanked"`
+	YankReason string    `json:"yank_reason,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Message    string    `json:"message"`
+}
+
+// PauseReleaseResponse is the response of pausing or resuming a release's
+// rollout.
+type PauseReleaseResponse struct {
+	ID        string    `json:"id"`
+	Paused    bool      `json:"paused"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Message   string    `json:"message"`
+}
+
 // ErrorResponse provides structured error information with debugging context.
 //
 // Error Handling Design:
@@ -126,6 +225,18 @@ type ErrorResponse struct {
 	RequestID string            `json:"request_id,omitempty"` // Unique request identifier
 }
 
+// ProblemResponse is an RFC 9457 problem details body. It embeds the usual
+// ErrorResponse fields as extension members so clients that read error,
+// message and code keep working.
+type ProblemResponse struct {
+	Type     string `json:"type"`               // Problem type URI; "about:blank" for plain HTTP errors
+	Title    string `json:"title"`              // Short summary of the problem type
+	Status   int    `json:"status"`             // HTTP status code
+	Detail   string `json:"detail,omitempty"`   // Explanation of this occurrence
+	Instance string `json:"instance,omitempty"` // Request path the problem occurred on
+	*ErrorResponse
+}
+
 type HealthCheckResponse struct {
 	Status     string                     `json:"status"`
 	Timestamp  time.Time                  `json:"timestamp"`
@@ -135,6 +246,29 @@ type HealthCheckResponse struct {
 	Metrics    map[string]interface{}     `json:"metrics,omitempty"`
 }
 
+// HealthSample is one periodic health measurement. Requests and ServerErrors
+// count the HTTP requests completed since the previous sample, and ErrorRate is
+// their ratio.
+type HealthSample struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Status           string    `json:"status"`
+	StorageStatus    string    `json:"storage_status"`
+	StorageLatencyMS float64   `json:"storage_latency_ms"`
+	Requests         int64     `json:"requests"`
+	ServerErrors     int64     `json:"server_errors"`
+	ErrorRate        float64   `json:"error_rate"`
+}
+
+// HealthHistoryResponse lists health samples, oldest first. Availability is
+// the fraction of the listed samples that were healthy.
+type HealthHistoryResponse struct {
+	StartedAt     time.Time      `json:"started_at"`
+	UptimeSeconds int64          `json:"uptime_seconds"`
+	Interval      string         `json:"interval"`
+	Availability  float64        `json:"availability"`
+	Samples       []HealthSample `json:"samples"`
+}
+
 type ComponentHealth struct {
 	Status    string                 `json:"status"`
 	Message   string                 `json:"message,omitempty"`
@@ -148,7 +282,14 @@ type ApplicationInfoResponse struct {
 	Description string            `json:"description"`
 	Platforms   []string          `json:"platforms"`
 	Config      ApplicationConfig `json:"config"`
+	Tags        []string          `json:"tags"`
+	Group       string            `json:"group,omitempty"`
+	ParentID    string            `json:"parent_id,omitempty"`
+	Slug        string            `json:"slug,omitempty"`
+	FormerSlugs []string          `json:"former_slugs,omitempty"`
 	Stats       ApplicationStats  `json:"stats"`
+	Schedule    *CheckSchedule    `json:"check_schedule,omitempty"` // Set when the application has an update interval
+	Bandwidth   *BandwidthUsage   `json:"bandwidth,omitempty"`      // Set when the application has a bandwidth budget
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 }
@@ -172,10 +313,37 @@ type ApplicationSummary struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Platforms   []string  `json:"platforms"`
+	Tags        []string  `json:"tags"`
+	Group       string    `json:"group,omitempty"`
+	ParentID    string    `json:"parent_id,omitempty"`
+	Slug        string    `json:"slug,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// ListApplicationGroupsResponse lists the named application groups with their
+// member counts. Ungrouped counts applications that belong to no group.
+type ListApplicationGroupsResponse struct {
+	Groups    []ApplicationGroup `json:"groups"`
+	Ungrouped int                `json:"ungrouped"`
+}
+
+// ListApplicationTemplatesResponse lists the configured application templates
+// in configuration order.
+type ListApplicationTemplatesResponse struct {
+	Templates []ApplicationTemplate `json:"templates"`
+}
+
+// BadgeResponse is the JSON badge description consumed by the shields.io
+// endpoint badge (https://shields.io/badges/endpoint-badge).
+type BadgeResponse struct {
+	SchemaVersion int    `json:"schemaVersion"`     // Always 1
+	Label         string `json:"label"`             // Left-hand text
+	Message       string `json:"message"`           // Right-hand text
+	Color         string `json:"color"`             // Right-hand background colour
+	IsError       bool   `json:"isError,omitempty"` // Marks the badge as an error state
+}
+
 // Health Status Constants
 //
 // Health Monitoring:
@@ -219,6 +387,19 @@ func NewErrorResponse(message string, code string) *ErrorResponse {
 	}
 }
 
+// NewProblemResponse creates an "about:blank" problem for an HTTP status,
+// titled with the status text.
+func NewProblemResponse(status int, message, code, instance string) *ProblemResponse {
+	return &ProblemResponse{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        message,
+		Instance:      instance,
+		ErrorResponse: NewErrorResponse(message, code),
+	}
+}
+
 // copyMetadata returns a shallow copy of m, or nil if m is nil.
 // This prevents callers from mutating the original release's metadata map via a response.
 func copyMetadata(m map[string]string) map[string]string {
@@ -232,18 +413,33 @@ func copyMetadata(m map[string]string) map[string]string {
 	return out
 }
 
+// copyTags returns a copy of tags, substituting an empty slice for nil so
+// that responses always serialise tags as a JSON array.
+func copyTags(tags []string) []string {
+	out := make([]string, len(tags))
+	copy(out, tags)
+	return out
+}
+
 func (r *UpdateCheckResponse) SetUpdateAvailable(release *Release) {
 	r.UpdateAvailable = true
 	r.LatestVersion = release.Version
 	r.DownloadURL = release.DownloadURL
 	r.Checksum = release.Checksum
 	r.ChecksumType = release.ChecksumType
+	r.ChecksumDeprecated = IsWeakChecksumType(release.ChecksumType)
+	r.Checksums = copyChecksums(release.Checksums)
+	r.PGPSignatureURL = pgpSignatureURL(release)
 	r.FileSize = release.FileSize
 	r.ReleaseNotes = release.ReleaseNotes
 	r.ReleaseDate = &release.ReleaseDate
 	r.Required = release.Required
+	r.Security = HasTag(release.Tags, TagSecurity)
 	r.MinimumVersion = release.MinimumVersion
 	r.Metadata = copyMetadata(release.Metadata)
+	r.Variant = release.Variant
+	r.VersionCode = release.VersionCode
+	r.SigningCertSHA256 = release.SigningCertSHA256
 }
 
 func (r *UpdateCheckResponse) SetNoUpdateAvailable(currentVersion string) {
@@ -256,11 +452,17 @@ func (r *LatestVersionResponse) FromRelease(release *Release) {
 	r.DownloadURL = release.DownloadURL
 	r.Checksum = release.Checksum
 	r.ChecksumType = release.ChecksumType
+	r.ChecksumDeprecated = IsWeakChecksumType(release.ChecksumType)
+	r.Checksums = copyChecksums(release.Checksums)
+	r.PGPSignatureURL = pgpSignatureURL(release)
 	r.FileSize = release.FileSize
 	r.ReleaseNotes = release.ReleaseNotes
 	r.ReleaseDate = release.ReleaseDate
 	r.Required = release.Required
 	r.Metadata = copyMetadata(release.Metadata)
+	r.Variant = release.Variant
+	r.VersionCode = release.VersionCode
+	r.SigningCertSHA256 = release.SigningCertSHA256
 }
 
 func (ri *ReleaseInfo) FromRelease(release *Release) {
@@ -274,9 +476,28 @@ func (ri *ReleaseInfo) FromRelease(release *Release) {
 	ri.FileSize = release.FileSize
 	ri.ReleaseNotes = release.ReleaseNotes
 	ri.ReleaseDate = release.ReleaseDate
+	ri.UpdatedAt = release.UpdatedAt
 	ri.Required = release.Required
 	ri.MinimumVersion = release.MinimumVersion
 	ri.Metadata = copyMetadata(release.Metadata)
+	ri.Tags = copyTags(release.Tags)
+	ri.HostVersionConstraint = release.HostVersionConstraint
+	ri.ChecksumDeprecated = IsWeakChecksumType(release.ChecksumType)
+	ri.Checksums = copyChecksums(release.Checksums)
+	ri.PGPSignatureURL = pgpSignatureURL(release)
+	ri.RequiredEntitlement = release.RequiredEntitlement
+	ri.Editions = copyEditions(release.Editions)
+	ri.Channel = release.EffectiveChannel()
+	ri.Targeting = release.Targeting
+	ri.Variant = release.Variant
+	ri.Variants = copyVariants(release.Variants)
+	ri.Paused = release.Paused
+	ri.Yanked = release.Yanked
+	ri.YankReason = release.YankReason
+	ri.VersionCode = release.VersionCode
+	ri.SigningCertSHA256 = release.SigningCertSHA256
+	ri.StatusChecks = maps.Clone(release.StatusChecks)
+	ri.ArtifactPending = release.ArtifactPending
 }
 
 func (as *ApplicationSummary) FromApplication(app *Application) {
@@ -284,6 +505,12 @@ func (as *ApplicationSummary) FromApplication(app *Application) {
 	as.Name = app.Name
 	as.Description = app.Description
 	as.Platforms = app.Platforms
+	as.Tags = copyTags(app.Tags)
+	as.Group = app.Group
+	as.ParentID = app.ParentID
+	as.Slug = app.Slug
+	as.CreatedAt = app.CreatedAt
+	as.UpdatedAt = app.UpdatedAt
 }
 
 func NewHealthCheckResponse(status string) *HealthCheckResponse {
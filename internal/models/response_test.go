@@ -40,6 +40,25 @@ func TestUpdateCheckResponse_SetUpdateAvailable(t *testing.T) {
 	assert.Equal(t, map[string]string{"key": "value"}, response.Metadata)
 }
 
+func TestUpdateCheckResponse_IsPriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		release *Release
+		want    bool
+	}{
+		{"plain release", &Release{Version: "1.1.0", Tags: []string{"hotfix"}}, false},
+		{"security tag", &Release{Version: "1.1.0", Tags: []string{"hotfix", TagSecurity}}, true},
+		{"required", &Release{Version: "1.1.0", Required: true}, true},
+	}
+	for _, tt := range tests {
+		response := &UpdateCheckResponse{}
+		response.SetUpdateAvailable(tt.release)
+		assert.Equal(t, tt.want, response.IsPriority(), tt.name)
+	}
+
+	assert.False(t, (&UpdateCheckResponse{Required: true}).IsPriority(), "no update is never priority")
+}
+
 func TestUpdateCheckResponse_SetNoUpdateAvailable(t *testing.T) {
 	currentVersion := "1.2.3"
 	response := &UpdateCheckResponse{}
@@ -90,6 +109,7 @@ func TestReleaseInfo_FromRelease(t *testing.T) {
 		FileSize:       54321,
 		ReleaseNotes:   "Initial release",
 		ReleaseDate:    time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:      time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC),
 		Required:       true,
 		MinimumVersion: "",
 		Metadata:       map[string]string{"type": "stable"},
@@ -108,9 +128,15 @@ func TestReleaseInfo_FromRelease(t *testing.T) {
 	assert.Equal(t, int64(54321), releaseInfo.FileSize)
 	assert.Equal(t, "Initial release", releaseInfo.ReleaseNotes)
 	assert.Equal(t, release.ReleaseDate, releaseInfo.ReleaseDate)
+	assert.Equal(t, release.UpdatedAt, releaseInfo.UpdatedAt)
 	assert.True(t, releaseInfo.Required)
 	assert.Equal(t, "", releaseInfo.MinimumVersion)
 	assert.Equal(t, map[string]string{"type": "stable"}, releaseInfo.Metadata)
+	assert.False(t, releaseInfo.ChecksumDeprecated)
+
+	release.ChecksumType = ChecksumTypeSHA1
+	releaseInfo.FromRelease(release)
+	assert.True(t, releaseInfo.ChecksumDeprecated)
 }
 
 func TestApplicationSummary_FromApplication(t *testing.T) {
@@ -119,8 +145,8 @@ func TestApplicationSummary_FromApplication(t *testing.T) {
 		Name:        "Test Application",
 		Description: "A test application for unit tests",
 		Platforms:   []string{"windows", "linux", "darwin"},
-		CreatedAt:   "2024-01-01T00:00:00Z",
-		UpdatedAt:   "2024-01-15T12:00:00Z",
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
 	}
 
 	summary := &ApplicationSummary{}
@@ -130,8 +156,8 @@ func TestApplicationSummary_FromApplication(t *testing.T) {
 	assert.Equal(t, "Test Application", summary.Name)
 	assert.Equal(t, "A test application for unit tests", summary.Description)
 	assert.Equal(t, []string{"windows", "linux", "darwin"}, summary.Platforms)
-	// Note: CreatedAt and UpdatedAt are not copied in the FromApplication method
-	// This might be intentional design - the summary might set these separately
+	assert.Equal(t, app.CreatedAt, summary.CreatedAt)
+	assert.Equal(t, app.UpdatedAt, summary.UpdatedAt)
 }
 
 func TestNewErrorResponse(t *testing.T) {
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// An application's update_interval sets how often its clients check for
+// updates. Clients that start together, or that all check on the hour, would
+// otherwise check in step and hit the service in bursts. Each client is
+// instead given a fixed slot within the interval, derived from a hash of the
+// application and client IDs, and every check response tells it how long to
+// wait for its next slot. The slots of a large fleet are spread evenly over
+// the interval, and a client keeps its slot across restarts.
+
+// Bounds of ApplicationConfig.UpdateInterval, in seconds.
+const (
+	MinUpdateInterval = 60
+	MaxUpdateInterval = 7 * 24 * 60 * 60
+)
+
+// CheckSpreadClientHash names the way check slots are assigned, in
+// CheckSchedule.Spread.
+const CheckSpreadClientHash = "client_hash"
+
+// ValidateUpdateInterval checks an update interval in seconds; zero disables
+// check scheduling.
+func ValidateUpdateInterval(seconds int) error {
+	if seconds != 0 && (seconds < MinUpdateInterval || seconds > MaxUpdateInterval) {
+		return fmt.Errorf("update_interval must be 0 or between %d and %d seconds", MinUpdateInterval, MaxUpdateInterval)
+	}
+	return nil
+}
+
+// CheckOffset returns the client's slot within the interval: the offset from
+// the start of each interval, counted from the Unix epoch, at which it checks.
+func CheckOffset(appID, clientID string, interval time.Duration) time.Duration {
+	h := fnv.New64a()
+	h.Write([]byte(appID))
+	h.Write([]byte{0})
+	h.Write([]byte(clientID))
+	return time.Duration(h.Sum64() % uint64(interval))
+}
+
+// NextCheck returns the first time after now at which the client's slot
+// comes round.
+func NextCheck(appID, clientID string, interval time.Duration, now time.Time) time.Time {
+	offset := CheckOffset(appID, clientID, interval)
+	elapsed := (time.Duration(now.UnixNano())%interval - offset + interval) % interval
+	return now.Add(interval - elapsed)
+}
+
+// NextCheckSeconds returns the whole seconds until the client's next check,
+// at least 1, for UpdateCheckResponse.NextCheckSeconds.
+func NextCheckSeconds(appID, clientID string, interval time.Duration, now time.Time) int64 {
+	wait := NextCheck(appID, clientID, interval, now).Sub(now)
+	return max(int64((wait+time.Second-1)/time.Second), 1)
+}
+
+// CheckSchedule describes how an application's clients are scheduled, in
+// ApplicationInfoResponse. The client fields are set when the request names a
+// client.
+type CheckSchedule struct {
+	IntervalSeconds int64      `json:"interval_seconds"`
+	Spread          string     `json:"spread"`                   // How clients are assigned slots; always client_hash
+	ClientID        string     `json:"client_id,omitempty"`      // Client the slot below belongs to
+	OffsetSeconds   *int64     `json:"offset_seconds,omitempty"` // Client's slot, in seconds from the start of each interval
+	NextCheckAt     *time.Time `json:"next_check_at,omitempty"`  // Client's next slot
+}
+
+// NewCheckSchedule returns the schedule of an application's clients, or nil
+// when it has no update interval.
+func NewCheckSchedule(config ApplicationConfig) *CheckSchedule {
+	if config.UpdateInterval == 0 {
+		return nil
+	}
+	return &CheckSchedule{IntervalSeconds: int64(config.UpdateInterval), Spread: CheckSpreadClientHash}
+}
+
+// ForClient fills in the slot and next check of one client.
+func (c *CheckSchedule) ForClient(appID, clientID string, now time.Time) {
+	interval := time.Duration(c.IntervalSeconds) * time.Second
+	offset := int64(CheckOffset(appID, clientID, interval) / time.Second)
+	next := NextCheck(appID, clientID, interval, now).UTC()
+	c.ClientID = clientID
+	c.OffsetSeconds = &offset
+	c.NextCheckAt = &next
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUpdateInterval(t *testing.T) {
+	assert.NoError(t, ValidateUpdateInterval(0))
+	assert.NoError(t, ValidateUpdateInterval(3600))
+	assert.Error(t, ValidateUpdateInterval(30))
+	assert.Error(t, ValidateUpdateInterval(MaxUpdateInterval+1))
+
+	config := ApplicationConfig{UpdateInterval: -1}
+	assert.Error(t, config.Validate())
+}
+
+func TestNextCheck(t *testing.T) {
+	interval := time.Hour
+	offset := CheckOffset("app", "client-1", interval)
+	assert.Equal(t, offset, CheckOffset("app", "client-1", interval), "a client keeps its slot")
+	assert.NotEqual(t, offset, CheckOffset("other-app", "client-1", interval), "slots differ per application")
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	next := NextCheck("app", "client-1", interval, now)
+	require.True(t, next.After(now))
+	assert.LessOrEqual(t, next.Sub(now), interval)
+	assert.Equal(t, offset, next.Sub(now.Truncate(interval))%interval, "the next check falls on the client's slot")
+
+	// Checking on its slot schedules the following one
+	assert.Equal(t, next.Add(interval), NextCheck("app", "client-1", interval, next))
+	assert.Equal(t, int64(3600), NextCheckSeconds("app", "client-1", interval, next))
+}
+
+func TestCheckOffset_SpreadsFleet(t *testing.T) {
+	interval := time.Hour
+	var buckets [6]int
+	for i := range 6000 {
+		offset := CheckOffset("app", fmt.Sprintf("device-%d", i), interval)
+		buckets[offset/(10*time.Minute)]++
+	}
+	for i, n := range buckets {
+		assert.InDelta(t, 1000, n, 150, "ten-minute bucket %d", i)
+	}
+}
+
+func TestCheckSchedule(t *testing.T) {
+	assert.Nil(t, NewCheckSchedule(ApplicationConfig{}))
+
+	schedule := NewCheckSchedule(ApplicationConfig{UpdateInterval: 3600})
+	require.NotNil(t, schedule)
+	assert.Equal(t, int64(3600), schedule.IntervalSeconds)
+	assert.Nil(t, schedule.OffsetSeconds)
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	schedule.ForClient("app", "client-1", now)
+	assert.Equal(t, "client-1", schedule.ClientID)
+	assert.Equal(t, int64(CheckOffset("app", "client-1", time.Hour)/time.Second), *schedule.OffsetSeconds)
+	assert.Equal(t, NextCheck("app", "client-1", time.Hour, now), *schedule.NextCheckAt)
+}
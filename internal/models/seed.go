@@ -0,0 +1,109 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SeedConfig points at a fixtures file applied at startup. File can also be
+// given with the --seed flag, which takes precedence.
+type SeedConfig struct {
+	File string `yaml:"file" json:"file"` // Path to a SeedFixtures YAML or JSON file
+}
+
+// SeedFixtures lists the applications, releases and API keys a fresh
+// instance should start with, for local development, demos and integration
+// environments. Seeding only creates what is missing: an entry whose
+// application, release or key already exists is left alone, so the same file
+// can be applied on every start.
+type SeedFixtures struct {
+	Applications []SeedApplication `yaml:"applications" json:"applications"`
+	// Releases are given as release manifests, one version of one
+	// application each, and are seeded per artifact.
+	Releases []ReleaseManifest `yaml:"releases" json:"releases"`
+	APIKeys  []SeedAPIKey      `yaml:"api_keys" json:"api_keys"`
+}
+
+// SeedApplication is an application to create when it does not exist. Its
+// fields are those of CreateApplicationRequest.
+type SeedApplication struct {
+	ID          string   `yaml:"id" json:"id"`
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description,omitempty"`
+	Platforms   []string `yaml:"platforms" json:"platforms"`
+	Tags        []string `yaml:"tags" json:"tags,omitempty"`
+	Group       string   `yaml:"group" json:"group,omitempty"`
+	ParentID    string   `yaml:"parent_id" json:"parent_id,omitempty"`
+	Template    string   `yaml:"template" json:"template,omitempty"`
+	Slug        string   `yaml:"slug" json:"slug,omitempty"`
+}
+
+// CreateRequest returns the request that creates the application.
+func (a *SeedApplication) CreateRequest() *CreateApplicationRequest {
+	return &CreateApplicationRequest{
+		ID:          a.ID,
+		Name:        a.Name,
+		Description: a.Description,
+		Platforms:   a.Platforms,
+		Tags:        a.Tags,
+		Group:       a.Group,
+		ParentID:    a.ParentID,
+		Template:    a.Template,
+		Slug:        a.Slug,
+	}
+}
+
+// SeedAPIKey is an API key to store when no key with the same raw value
+// exists. Unlike keys created through the API the raw key is chosen by the
+// fixture author, so clients and scripts can be configured with it in
+// advance. Only its hash is stored.
+type SeedAPIKey struct {
+	Name        string   `yaml:"name" json:"name"`
+	Key         string   `yaml:"key" json:"-"`
+	Permissions []string `yaml:"permissions" json:"permissions"`
+}
+
+// Validate checks what the service does not check when the fixtures are
+// applied: that every key is complete and that no entry is listed twice.
+// Applications and releases are validated as they are created.
+func (f *SeedFixtures) Validate() error {
+	var errs []error
+
+	apps := make(map[string]int, len(f.Applications))
+	for i, app := range f.Applications {
+		id := strings.TrimSpace(app.ID)
+		if id == "" {
+			errs = append(errs, fmt.Errorf("applications[%d]: id is required", i))
+			continue
+		}
+		if j, dup := apps[id]; dup {
+			errs = append(errs, fmt.Errorf("applications[%d]: duplicates applications[%d] (%s)", i, j, id))
+		}
+		apps[id] = i
+	}
+
+	for i, release := range f.Releases {
+		if err := release.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("releases[%d]: %w", i, err))
+		}
+	}
+
+	keys := make(map[string]int, len(f.APIKeys))
+	for i, key := range f.APIKeys {
+		switch {
+		case key.Name == "":
+			errs = append(errs, fmt.Errorf("api_keys[%d]: name is required", i))
+		case key.Key == "":
+			errs = append(errs, fmt.Errorf("api_keys[%d]: key is required", i))
+		case len(key.Permissions) == 0:
+			errs = append(errs, fmt.Errorf("api_keys[%d]: permissions is required", i))
+		}
+		if j, dup := keys[key.Key]; dup && key.Key != "" {
+			errs = append(errs, fmt.Errorf("api_keys[%d]: key duplicates api_keys[%d]", i, j))
+		}
+		keys[key.Key] = i
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SLOConfig sets latency and error objectives for individual routes. The
+// share of requests that miss an objective is tracked over a rolling Window
+// and exported as a burn rate: 1 means the error budget is being spent
+// exactly as fast as the objective allows, and anything above means the
+// budget runs out before the window does.
+type SLOConfig struct {
+	Enabled bool          `yaml:"enabled" json:"enabled"`
+	Window  time.Duration `yaml:"window" json:"window"`
+	// MinRequests is the number of requests a route needs in the window
+	// before its burn rate is reported, so a few slow requests on a quiet
+	// route do not trip degraded mode.
+	MinRequests int              `yaml:"min_requests" json:"min_requests"`
+	Routes      []RouteSLO       `yaml:"routes" json:"routes"`
+	Degrade     SLODegradeConfig `yaml:"degrade" json:"degrade"`
+}
+
+// RouteSLO is the objective of one route. A request meets it when it is
+// answered without a server error within Latency; Objective is the share of
+// requests that must meet it, such as 0.99.
+type RouteSLO struct {
+	Route     string        `yaml:"route" json:"route"`   // Path template, such as /api/v1/updates/{app_id}/latest
+	Method    string        `yaml:"method" json:"method"` // Every method when empty
+	Latency   time.Duration `yaml:"latency" json:"latency"`
+	Objective float64       `yaml:"objective" json:"objective"`
+}
+
+// Name identifies the objective in metrics and logs.
+func (r RouteSLO) Name() string {
+	if r.Method == "" {
+		return r.Route
+	}
+	return r.Method + " " + r.Route
+}
+
+// SLODegradeConfig serves the last good response of a latest-version route
+// from memory while the route's burn rate is at or above BurnRate, instead of
+// adding load to a backend that is already missing its objective. Responses
+// older than StaleFor are not served; those requests go to the backend.
+type SLODegradeConfig struct {
+	Enabled    bool          `yaml:"enabled" json:"enabled"`
+	BurnRate   float64       `yaml:"burn_rate" json:"burn_rate"`
+	StaleFor   time.Duration `yaml:"stale_for" json:"stale_for"`
+	MaxEntries int           `yaml:"max_entries" json:"max_entries"` // Cached responses kept across all latest routes
+}
+
+// Validate checks the settings when SLOs are enabled.
+func (c *SLOConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	if c.Window <= 0 {
+		errs = append(errs, errors.New("window must be positive"))
+	}
+	if c.MinRequests < 1 {
+		errs = append(errs, errors.New("min_requests must be at least 1"))
+	}
+	if len(c.Routes) == 0 {
+		errs = append(errs, errors.New("routes are required when SLOs are enabled"))
+	}
+	seen := make(map[string]bool)
+	for _, route := range c.Routes {
+		if !strings.HasPrefix(route.Route, "/") {
+			errs = append(errs, fmt.Errorf("route %q must be a path template starting with /", route.Route))
+		}
+		if !isHTTPMethod(route.Method) {
+			errs = append(errs, fmt.Errorf("route %s: invalid method %q", route.Route, route.Method))
+		}
+		if route.Latency <= 0 {
+			errs = append(errs, fmt.Errorf("route %s: latency must be positive", route.Name()))
+		}
+		if route.Objective <= 0 || route.Objective >= 1 {
+			errs = append(errs, fmt.Errorf("route %s: objective must be between 0 and 1, such as 0.99", route.Name()))
+		}
+		if seen[route.Name()] {
+			errs = append(errs, fmt.Errorf("route %s is listed more than once", route.Name()))
+		}
+		seen[route.Name()] = true
+	}
+	if c.Degrade.Enabled {
+		if c.Degrade.BurnRate <= 0 {
+			errs = append(errs, errors.New("degrade burn_rate must be positive"))
+		}
+		if c.Degrade.StaleFor <= 0 {
+			errs = append(errs, errors.New("degrade stale_for must be positive"))
+		}
+		if c.Degrade.MaxEntries < 1 {
+			errs = append(errs, errors.New("degrade max_entries must be at least 1"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isHTTPMethod reports whether method is empty or a method routes are
+// registered with.
+func isHTTPMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validSLOConfig() SLOConfig {
+	return SLOConfig{
+		Enabled:     true,
+		Window:      5 * time.Minute,
+		MinRequests: 100,
+		Routes: []RouteSLO{
+			{Route: "/api/v1/updates/{app_id}/latest", Method: "GET", Latency: 200 * time.Millisecond, Objective: 0.99},
+			{Route: "/api/v1/check", Latency: 500 * time.Millisecond, Objective: 0.995},
+		},
+		Degrade: SLODegradeConfig{Enabled: true, BurnRate: 1, StaleFor: 10 * time.Minute, MaxEntries: 1024},
+	}
+}
+
+func TestSLOConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*SLOConfig)
+		errorMsg string
+	}{
+		{name: "valid", modify: func(*SLOConfig) {}},
+		{name: "disabled is not validated", modify: func(c *SLOConfig) { *c = SLOConfig{} }},
+		{name: "no window", modify: func(c *SLOConfig) { c.Window = 0 }, errorMsg: "window must be positive"},
+		{name: "no minimum", modify: func(c *SLOConfig) { c.MinRequests = 0 }, errorMsg: "min_requests must be at least 1"},
+		{name: "no routes", modify: func(c *SLOConfig) { c.Routes = nil }, errorMsg: "routes are required"},
+		{name: "relative route", modify: func(c *SLOConfig) { c.Routes[0].Route = "latest" }, errorMsg: `route "latest" must be a path template`},
+		{name: "lower-case method", modify: func(c *SLOConfig) { c.Routes[0].Method = "get" }, errorMsg: `invalid method "get"`},
+		{name: "no latency", modify: func(c *SLOConfig) { c.Routes[1].Latency = 0 }, errorMsg: "route /api/v1/check: latency must be positive"},
+		{name: "objective as a percentage", modify: func(c *SLOConfig) { c.Routes[0].Objective = 99 }, errorMsg: "objective must be between 0 and 1"},
+		{
+			name:     "duplicate route",
+			modify:   func(c *SLOConfig) { c.Routes = append(c.Routes, c.Routes[0]) },
+			errorMsg: "route GET /api/v1/updates/{app_id}/latest is listed more than once",
+		},
+		{name: "degrade without a burn rate", modify: func(c *SLOConfig) { c.Degrade.BurnRate = 0 }, errorMsg: "degrade burn_rate must be positive"},
+		{name: "degrade without stale_for", modify: func(c *SLOConfig) { c.Degrade.StaleFor = 0 }, errorMsg: "degrade stale_for must be positive"},
+		{name: "degrade without entries", modify: func(c *SLOConfig) { c.Degrade.MaxEntries = 0 }, errorMsg: "degrade max_entries must be at least 1"},
+		{name: "disabled degrade is not validated", modify: func(c *SLOConfig) { c.Degrade = SLODegradeConfig{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validSLOConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestRouteSLO_Name(t *testing.T) {
+	assert.Equal(t, "/api/v1/check", RouteSLO{Route: "/api/v1/check"}.Name())
+	assert.Equal(t, "POST /api/v1/check", RouteSLO{Route: "/api/v1/check", Method: "POST"}.Name())
+}
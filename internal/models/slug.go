@@ -0,0 +1,48 @@
+package models
+
+import "errors"
+
+// ValidateSlug checks that a slug can stand in for an application ID in URLs:
+// it follows the same rules as IDs.
+func ValidateSlug(slug string) error {
+	if !isValidID(slug) {
+		return errors.New("slug must contain only alphanumeric characters, hyphens, and underscores")
+	}
+	return nil
+}
+
+// HasSlug reports whether slug is the application's current or a former slug.
+func (a *Application) HasSlug(slug string) bool {
+	if slug == "" {
+		return false
+	}
+	if a.Slug == slug {
+		return true
+	}
+	for _, former := range a.FormerSlugs {
+		if former == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// Rename makes slug the application's current slug. The slug it replaces is
+// kept as a former slug, so URLs and client configurations using it can still
+// be redirected; one equal to the ID is not kept, as the ID always resolves.
+// Taking back a former slug removes it from the former slugs.
+func (a *Application) Rename(slug string) {
+	if slug == a.Slug {
+		return
+	}
+	former := make([]string, 0, len(a.FormerSlugs)+1)
+	for _, s := range a.FormerSlugs {
+		if s != slug {
+			former = append(former, s)
+		}
+	}
+	if a.Slug != "" && a.Slug != a.ID {
+		former = append(former, a.Slug)
+	}
+	a.Slug, a.FormerSlugs = slug, former
+}
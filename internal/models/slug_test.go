@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplication_Rename(t *testing.T) {
+	app := NewApplication("acme-notes", "Notes", []string{"linux"})
+	app.Slug = app.ID
+
+	// The ID always resolves, so it is not kept as a former slug
+	app.Rename("notes")
+	assert.Equal(t, "notes", app.Slug)
+	assert.Empty(t, app.FormerSlugs)
+
+	app.Rename("jotter")
+	assert.Equal(t, []string{"notes"}, app.FormerSlugs)
+	assert.True(t, app.HasSlug("notes"))
+	assert.True(t, app.HasSlug("jotter"))
+	assert.False(t, app.HasSlug("acme-notes"))
+	assert.False(t, app.HasSlug(""))
+
+	app.Rename("notes")
+	assert.Equal(t, "notes", app.Slug)
+	assert.Equal(t, []string{"jotter"}, app.FormerSlugs)
+}
+
+func TestValidateSlug(t *testing.T) {
+	assert.NoError(t, ValidateSlug("my-app_2"))
+	assert.Error(t, ValidateSlug(""))
+	assert.Error(t, ValidateSlug("my app"))
+	assert.Error(t, ValidateSlug("my/app"))
+}
@@ -0,0 +1,198 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// IntegrationSnippetsRequest asks for client integration snippets for an
+// application. BaseURL is the address clients reach the service at; Platform
+// and Architecture pick the target the snippets check for.
+type IntegrationSnippetsRequest struct {
+	ApplicationID string `json:"application_id" validate:"required"`
+	BaseURL       string `json:"base_url" validate:"required"`
+	Platform      string `json:"platform,omitempty"`
+	Architecture  string `json:"architecture,omitempty"`
+}
+
+func (r *IntegrationSnippetsRequest) Validate() error {
+	if r.ApplicationID == "" {
+		return errors.New("application_id is required")
+	}
+	u, err := url.Parse(r.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("base_url must be an absolute http or https URL")
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return errors.New("base_url cannot contain credentials, a query or a fragment")
+	}
+	if r.Platform != "" && !isValidPlatform(r.Platform) {
+		return fmt.Errorf("invalid platform: %s", r.Platform)
+	}
+	if r.Architecture != "" && !isValidArchitecture(r.Architecture) {
+		return fmt.Errorf("invalid architecture: %s", r.Architecture)
+	}
+	return nil
+}
+
+func (r *IntegrationSnippetsRequest) Normalize() {
+	r.ApplicationID = strings.TrimSpace(r.ApplicationID)
+	r.BaseURL = strings.TrimRight(strings.TrimSpace(r.BaseURL), "/")
+	r.Platform = NormalizePlatform(strings.TrimSpace(r.Platform))
+	r.Architecture = NormalizeArchitecture(strings.TrimSpace(r.Architecture))
+}
+
+// IntegrationSnippet is one ready-to-paste client integration example.
+type IntegrationSnippet struct {
+	Name        string `json:"name"`
+	Language    string `json:"language"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+}
+
+// IntegrationSnippetsResponse lists the snippets generated for an application
+// and the values they were generated with.
+type IntegrationSnippetsResponse struct {
+	ApplicationID string               `json:"application_id"`
+	BaseURL       string               `json:"base_url"`
+	Platform      string               `json:"platform"`
+	Architecture  string               `json:"architecture"`
+	Snippets      []IntegrationSnippet `json:"snippets"`
+}
+
+// snippetData is the input of the snippet templates.
+type snippetData struct {
+	BaseURL      string
+	AppID        string
+	Platform     string
+	Architecture string
+	Plugin       bool
+}
+
+type snippetTemplate struct {
+	name, language, description string
+	ota                         bool // only generated for applications with the ota profile
+	tmpl                        *template.Template
+}
+
+func newSnippetTemplate(name, language, description string, ota bool, text string) snippetTemplate {
+	return snippetTemplate{
+		name:        name,
+		language:    language,
+		description: description,
+		ota:         ota,
+		tmpl:        template.Must(template.New(name).Parse(text)),
+	}
+}
+
+// snippetTemplates are generated in this order. Application IDs, platforms and
+// architectures are restricted to URL-safe characters by validation, so they
+// are inserted without escaping.
+var snippetTemplates = []snippetTemplate{
+	newSnippetTemplate("curl", "shell", "Check for an update with a GET request", false,
+		`curl -fsS "{{.BaseURL}}/api/v1/updates/{{.AppID}}/check?current_version=${CURRENT_VERSION}&platform={{.Platform}}&architecture={{.Architecture}}{{if .Plugin}}&host_version=${HOST_VERSION}{{end}}"
+`),
+	newSnippetTemplate("curl-post", "shell", "Check for an update with a JSON body", false,
+		`curl -fsS -X POST "{{.BaseURL}}/api/v1/check" \
+  -H "Content-Type: application/json" \
+  -d '{"application_id":"{{.AppID}}","current_version":"'"${CURRENT_VERSION}"'","platform":"{{.Platform}}","architecture":"{{.Architecture}}"{{if .Plugin}},"host_version":"'"${HOST_VERSION}"'"{{end}}}'
+`),
+	newSnippetTemplate("go", "go", "Minimal Go client using only the standard library", false,
+		`package updatecheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const checkURL = "{{.BaseURL}}/api/v1/updates/{{.AppID}}/check"
+
+// UpdateInfo is the part of the update check response a client acts on.
+type UpdateInfo struct {
+	UpdateAvailable bool   `+"`json:\"update_available\"`"+`
+	LatestVersion   string `+"`json:\"latest_version\"`"+`
+	DownloadURL     string `+"`json:\"download_url\"`"+`
+	Checksum        string `+"`json:\"checksum\"`"+`
+	ChecksumType    string `+"`json:\"checksum_type\"`"+`
+	Required        bool   `+"`json:\"required\"`"+`
+}
+
+// CheckForUpdate asks the update service whether a newer release is available.
+func CheckForUpdate(ctx context.Context, currentVersion string{{if .Plugin}}, hostVersion string{{end}}) (*UpdateInfo, error) {
+	query := url.Values{}
+	query.Set("current_version", currentVersion)
+	query.Set("platform", "{{.Platform}}")
+	query.Set("architecture", "{{.Architecture}}")
+{{- if .Plugin}}
+	query.Set("host_version", hostVersion)
+{{- end}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update check failed: %s", resp.Status)
+	}
+
+	var info UpdateInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+`),
+	newSnippetTemplate("ota", "shell", "Compact check for embedded devices, as key=value text", true,
+		`curl -fsS -H "Accept: text/plain" "{{.BaseURL}}/api/v1/updates/{{.AppID}}/ota?current_version=${CURRENT_VERSION}&platform={{.Platform}}&architecture={{.Architecture}}"
+`),
+	newSnippetTemplate("electron-builder", "yaml", "electron-builder publish configuration for electron-updater", false,
+		`publish:
+  provider: generic
+  url: {{.BaseURL}}/api/v1/updates/{{.AppID}}/electron
+`),
+	newSnippetTemplate("badge", "markdown", "Latest stable version badge for a README", false,
+		`![version]({{.BaseURL}}/badge/{{.AppID}}/version.svg)
+`),
+}
+
+// BuildIntegrationSnippets renders the integration snippets for an
+// application. The request is expected to be validated and normalized with
+// Platform and Architecture set.
+func BuildIntegrationSnippets(req *IntegrationSnippetsRequest, app *Application) ([]IntegrationSnippet, error) {
+	data := snippetData{
+		BaseURL:      req.BaseURL,
+		AppID:        app.ID,
+		Platform:     req.Platform,
+		Architecture: req.Architecture,
+		Plugin:       app.ParentID != "",
+	}
+
+	snippets := []IntegrationSnippet{}
+	for _, st := range snippetTemplates {
+		if st.ota && app.Config.Profile != ApplicationProfileOTA {
+			continue
+		}
+		var content strings.Builder
+		if err := st.tmpl.Execute(&content, data); err != nil {
+			return nil, fmt.Errorf("render %s snippet: %w", st.name, err)
+		}
+		snippets = append(snippets, IntegrationSnippet{
+			Name:        st.name,
+			Language:    st.language,
+			Description: st.description,
+			Content:     content.String(),
+		})
+	}
+	return snippets, nil
+}
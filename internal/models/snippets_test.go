@@ -0,0 +1,89 @@
+package models
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationSnippetsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     IntegrationSnippetsRequest
+		wantErr string
+	}{
+		{name: "valid", req: IntegrationSnippetsRequest{ApplicationID: "app", BaseURL: "https://updates.example.com/prefix"}},
+		{name: "missing app", req: IntegrationSnippetsRequest{BaseURL: "https://updates.example.com"}, wantErr: "application_id is required"},
+		{name: "relative base URL", req: IntegrationSnippetsRequest{ApplicationID: "app", BaseURL: "/updates"}, wantErr: "absolute http or https URL"},
+		{name: "base URL with query", req: IntegrationSnippetsRequest{ApplicationID: "app", BaseURL: "https://updates.example.com/?x=1"}, wantErr: "cannot contain"},
+		{name: "base URL with credentials", req: IntegrationSnippetsRequest{ApplicationID: "app", BaseURL: "https://user:pw@updates.example.com"}, wantErr: "cannot contain"},
+		{name: "invalid platform", req: IntegrationSnippetsRequest{ApplicationID: "app", BaseURL: "https://updates.example.com", Platform: "beos"}, wantErr: "invalid platform"},
+		{name: "invalid architecture", req: IntegrationSnippetsRequest{ApplicationID: "app", BaseURL: "https://updates.example.com", Architecture: "sparc"}, wantErr: "invalid architecture"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBuildIntegrationSnippets(t *testing.T) {
+	req := &IntegrationSnippetsRequest{BaseURL: "https://updates.example.com", Platform: "linux", Architecture: "arm64"}
+
+	byName := func(snippets []IntegrationSnippet) map[string]IntegrationSnippet {
+		m := make(map[string]IntegrationSnippet, len(snippets))
+		for _, s := range snippets {
+			m[s.Name] = s
+		}
+		return m
+	}
+
+	t.Run("desktop application", func(t *testing.T) {
+		snippets, err := BuildIntegrationSnippets(req, &Application{ID: "my-app", Platforms: []string{"linux"}})
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(snippets))
+		for _, s := range snippets {
+			names = append(names, s.Name)
+		}
+		assert.Equal(t, []string{"curl", "curl-post", "go", "electron-builder", "badge"}, names)
+
+		m := byName(snippets)
+		assert.Equal(t, `curl -fsS "https://updates.example.com/api/v1/updates/my-app/check?current_version=${CURRENT_VERSION}&platform=linux&architecture=arm64"`+"\n", m["curl"].Content)
+		assert.Contains(t, m["curl-post"].Content, `"application_id":"my-app"`)
+		assert.Contains(t, m["electron-builder"].Content, "url: https://updates.example.com/api/v1/updates/my-app/electron\n")
+		assert.Equal(t, "![version](https://updates.example.com/badge/my-app/version.svg)\n", m["badge"].Content)
+		assert.NotContains(t, m["go"].Content, "host_version")
+
+		_, err = parser.ParseFile(token.NewFileSet(), "snippet.go", m["go"].Content, parser.AllErrors)
+		assert.NoError(t, err, m["go"].Content)
+	})
+
+	t.Run("plugin", func(t *testing.T) {
+		snippets, err := BuildIntegrationSnippets(req, &Application{ID: "my-plugin", Platforms: []string{"linux"}, ParentID: "host"})
+		require.NoError(t, err)
+
+		m := byName(snippets)
+		assert.Contains(t, m["curl"].Content, "&host_version=${HOST_VERSION}")
+		assert.Contains(t, m["curl-post"].Content, `"host_version":"'"${HOST_VERSION}"'"`)
+		assert.Contains(t, m["go"].Content, `query.Set("host_version", hostVersion)`)
+		_, err = parser.ParseFile(token.NewFileSet(), "snippet.go", m["go"].Content, parser.AllErrors)
+		assert.NoError(t, err, m["go"].Content)
+	})
+
+	t.Run("ota profile", func(t *testing.T) {
+		snippets, err := BuildIntegrationSnippets(req, &Application{ID: "fw", Platforms: []string{"linux"}, Config: ApplicationConfig{Profile: ApplicationProfileOTA}})
+		require.NoError(t, err)
+		assert.Contains(t, byName(snippets)["ota"].Content, "/api/v1/updates/fw/ota?")
+	})
+}
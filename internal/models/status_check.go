@@ -0,0 +1,181 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Status checks gate releases on results that external systems report, such
+// as CI or a security scanner, the way required commit statuses gate a merge.
+// An application lists the checks its releases need in
+// config.required_checks. A release registered while the list is set starts
+// with each of those checks pending and is not offered until every one of
+// them reports success. A release's checks are an operational state, like a
+// pause: registering the release again keeps them, and changing the
+// application's list only affects releases registered afterwards.
+
+// Status check states, as commit statuses name them.
+const (
+	StatusCheckPending = "pending" // Not reported yet, or running
+	StatusCheckSuccess = "success" // Passed
+	StatusCheckFailure = "failure" // Failed
+	StatusCheckError   = "error"   // Could not run
+)
+
+const (
+	// MaxRequiredChecks is the maximum number of checks an application can require.
+	MaxRequiredChecks = 20
+	// MaxStatusCheckDescriptionLength is the maximum length of a status description.
+	MaxStatusCheckDescriptionLength = 500
+)
+
+var statusCheckNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._/-]{0,99}$`)
+
+// StatusCheck is the latest result an external system reported for one of a
+// release's required checks.
+type StatusCheck struct {
+	State       string    `json:"state"`
+	Description string    `json:"description,omitempty"`
+	TargetURL   string    `json:"target_url,omitempty"` // Where the result can be inspected, such as the CI run
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ValidateStatusCheckName checks the name of a status check, such as ci or
+// security/scan.
+func ValidateStatusCheckName(name string) error {
+	if !statusCheckNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid status check name %q: use up to 100 letters, digits, '.', '_', '/' or '-'", name)
+	}
+	return nil
+}
+
+// ValidateRequiredChecks checks an application's required status checks.
+func ValidateRequiredChecks(names []string) error {
+	if len(names) > MaxRequiredChecks {
+		return fmt.Errorf("cannot require more than %d checks", MaxRequiredChecks)
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if err := ValidateStatusCheckName(name); err != nil {
+			return err
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate status check %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// NewStatusChecks returns the checks a release registered at now starts
+// with: each required check, pending. It returns nil when none are required.
+func NewStatusChecks(required []string, now time.Time) map[string]StatusCheck {
+	if len(required) == 0 {
+		return nil
+	}
+	checks := make(map[string]StatusCheck, len(required))
+	for _, name := range required {
+		checks[name] = StatusCheck{State: StatusCheckPending, UpdatedAt: now}
+	}
+	return checks
+}
+
+// PendingChecks returns the sorted names of the release's status checks that
+// have not succeeded. The release is not offered while there are any.
+func (r *Release) PendingChecks() []string {
+	var pending []string
+	for name, check := range r.StatusChecks {
+		if check.State != StatusCheckSuccess {
+			pending = append(pending, name)
+		}
+	}
+	slices.Sort(pending)
+	return pending
+}
+
+// statusCheckSeverity orders states from passed to failed, to combine the
+// checks of several releases.
+var statusCheckSeverity = map[string]int{
+	StatusCheckSuccess: 0,
+	StatusCheckPending: 1,
+	StatusCheckError:   2,
+	StatusCheckFailure: 3,
+}
+
+// CombineStatusChecks combines the status checks of a version's releases:
+// each check as it stands on the release where it is furthest from passing,
+// and the state of the version, which is success only when every check
+// succeeded, failure when any failed or errored, and pending otherwise.
+func CombineStatusChecks(releases []*Release) (string, map[string]StatusCheck) {
+	checks := make(map[string]StatusCheck)
+	for _, release := range releases {
+		for name, check := range release.StatusChecks {
+			current, ok := checks[name]
+			if !ok || statusCheckSeverity[check.State] > statusCheckSeverity[current.State] {
+				checks[name] = check
+			}
+		}
+	}
+	state := StatusCheckSuccess
+	for _, check := range checks {
+		switch check.State {
+		case StatusCheckFailure, StatusCheckError:
+			return StatusCheckFailure, checks
+		case StatusCheckPending:
+			state = StatusCheckPending
+		}
+	}
+	return state, checks
+}
+
+// ReportStatusCheckRequest reports the result of a required check for every
+// release of a version.
+type ReportStatusCheckRequest struct {
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"target_url,omitempty"`
+}
+
+// Normalize trims the request and lowercases the state.
+func (r *ReportStatusCheckRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.State = strings.ToLower(strings.TrimSpace(r.State))
+	r.Description = strings.TrimSpace(r.Description)
+	r.TargetURL = strings.TrimSpace(r.TargetURL)
+}
+
+// Validate checks the normalized request.
+func (r *ReportStatusCheckRequest) Validate() error {
+	if err := ValidateStatusCheckName(r.Name); err != nil {
+		return err
+	}
+	if _, ok := statusCheckSeverity[r.State]; !ok {
+		return fmt.Errorf("invalid state %q: must be pending, success, failure or error", r.State)
+	}
+	if len(r.Description) > MaxStatusCheckDescriptionLength {
+		return fmt.Errorf("description exceeds maximum length of %d", MaxStatusCheckDescriptionLength)
+	}
+	if r.TargetURL != "" {
+		u, err := url.Parse(r.TargetURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("target_url must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+// ReleaseStatusResponse is the combined status of a version's releases.
+type ReleaseStatusResponse struct {
+	ApplicationID string                 `json:"application_id"`
+	Version       string                 `json:"version"`
+	State         string                 `json:"state"`             // success once every check succeeded; releases are offered only then
+	Checks        map[string]StatusCheck `json:"checks"`            // Each check where it is furthest from passing
+	ReleaseIDs    []string               `json:"release_ids"`       // Releases of the version
+	Message       string                 `json:"message,omitempty"` // Set when reporting
+}
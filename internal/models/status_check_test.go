@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRequiredChecks(t *testing.T) {
+	assert.NoError(t, ValidateRequiredChecks(nil))
+	assert.NoError(t, ValidateRequiredChecks([]string{"ci", "security/scan", "build.linux_amd64"}))
+	assert.ErrorContains(t, ValidateRequiredChecks([]string{"ci", "ci"}), "duplicate")
+	assert.ErrorContains(t, ValidateRequiredChecks([]string{"has space"}), "invalid status check name")
+	assert.ErrorContains(t, ValidateRequiredChecks([]string{"-ci"}), "invalid status check name")
+	assert.ErrorContains(t, ValidateRequiredChecks([]string{strings.Repeat("a", 101)}), "invalid status check name")
+	assert.ErrorContains(t, ValidateRequiredChecks(strings.Split(strings.Repeat("x,", MaxRequiredChecks)+"y", ",")), "more than")
+}
+
+func TestCombineStatusChecks(t *testing.T) {
+	release := func(checks map[string]StatusCheck) *Release { return &Release{StatusChecks: checks} }
+
+	state, checks := CombineStatusChecks([]*Release{release(nil)})
+	assert.Equal(t, StatusCheckSuccess, state, "releases without checks pass")
+	assert.Empty(t, checks)
+
+	state, checks = CombineStatusChecks([]*Release{
+		release(map[string]StatusCheck{"ci": {State: StatusCheckSuccess}, "scan": {State: StatusCheckSuccess}}),
+		release(map[string]StatusCheck{"ci": {State: StatusCheckPending}, "scan": {State: StatusCheckSuccess}}),
+	})
+	assert.Equal(t, StatusCheckPending, state)
+	assert.Equal(t, StatusCheckPending, checks["ci"].State, "a check is shown where it is furthest from passing")
+
+	state, _ = CombineStatusChecks([]*Release{
+		release(map[string]StatusCheck{"ci": {State: StatusCheckPending}, "scan": {State: StatusCheckError}}),
+	})
+	assert.Equal(t, StatusCheckFailure, state)
+}
+
+func TestRelease_PendingChecks(t *testing.T) {
+	release := &Release{StatusChecks: map[string]StatusCheck{
+		"scan": {State: StatusCheckFailure},
+		"ci":   {State: StatusCheckPending},
+		"lint": {State: StatusCheckSuccess},
+	}}
+	assert.Equal(t, []string{"ci", "scan"}, release.PendingChecks())
+	assert.Empty(t, (&Release{}).PendingChecks())
+}
+
+func TestReportStatusCheckRequest_Validate(t *testing.T) {
+	valid := ReportStatusCheckRequest{Name: " ci ", State: " Success ", TargetURL: "https://ci.example.com/runs/1"}
+	valid.Normalize()
+	assert.NoError(t, valid.Validate())
+	assert.Equal(t, "ci", valid.Name)
+	assert.Equal(t, StatusCheckSuccess, valid.State)
+
+	for name, req := range map[string]ReportStatusCheckRequest{
+		"state":       {Name: "ci", State: "green"},
+		"name":        {Name: "", State: StatusCheckSuccess},
+		"target_url":  {Name: "ci", State: StatusCheckSuccess, TargetURL: "ftp://ci.example.com"},
+		"description": {Name: "ci", State: StatusCheckSuccess, Description: strings.Repeat("a", MaxStatusCheckDescriptionLength+1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, req.Validate())
+		})
+	}
+}
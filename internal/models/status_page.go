@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxStatusPageApplications bounds the applications listed on the status
+// page, since each is looked up whenever the summary is rebuilt.
+const MaxStatusPageApplications = 50
+
+// StatusPageConfig serves a small, unauthenticated summary of the service for
+// embedding in a public status page: the latest stable version offered for
+// the listed applications, uptime, and the share of update checks answered without a
+// server error. Nothing else is exposed, unlike the Prometheus endpoint. The
+// summary is rebuilt at most once per CacheTTL, and each client IP may fetch
+// it RateLimit times per RateLimitWindow.
+type StatusPageConfig struct {
+	Enabled         bool          `yaml:"enabled" json:"enabled"`
+	Applications    []string      `yaml:"applications" json:"applications"`           // Applications whose latest stable version is listed
+	CheckWindow     time.Duration `yaml:"check_window" json:"check_window"`           // Period the check success rate covers
+	CacheTTL        time.Duration `yaml:"cache_ttl" json:"cache_ttl"`                 // How long one summary is served to every client
+	RateLimit       int           `yaml:"rate_limit" json:"rate_limit"`               // Requests per client IP per window
+	RateLimitWindow time.Duration `yaml:"rate_limit_window" json:"rate_limit_window"` // Fixed window the rate limit counts in
+}
+
+// Validate checks the settings when the status page is enabled.
+func (c *StatusPageConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	if c.CheckWindow < time.Minute {
+		errs = append(errs, errors.New("check_window must be at least 1m"))
+	}
+	if c.CacheTTL < time.Second {
+		errs = append(errs, errors.New("cache_ttl must be at least 1s"))
+	}
+	if c.RateLimit < 1 {
+		errs = append(errs, errors.New("rate_limit must be at least 1"))
+	}
+	if c.RateLimitWindow < time.Second {
+		errs = append(errs, errors.New("rate_limit_window must be at least 1s"))
+	}
+	if len(c.Applications) > MaxStatusPageApplications {
+		errs = append(errs, fmt.Errorf("at most %d applications can be listed", MaxStatusPageApplications))
+	}
+	seen := make(map[string]bool)
+	for _, appID := range c.Applications {
+		if appID == "" {
+			errs = append(errs, errors.New("application IDs cannot be empty"))
+			continue
+		}
+		if seen[appID] {
+			errs = append(errs, fmt.Errorf("application %q is listed more than once", appID))
+		}
+		seen[appID] = true
+	}
+	return errors.Join(errs...)
+}
+
+// StatusPageResponse is the public status summary served at /status.json.
+// Uptime and check counts are those of the replica that answered.
+type StatusPageResponse struct {
+	GeneratedAt   time.Time `json:"generated_at"`
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
+	// Availability is the share of healthy health history samples over the
+	// check window; it is left out when health history is not enabled.
+	Availability *float64                `json:"availability,omitempty"`
+	Checks       StatusPageChecks        `json:"checks"`
+	Applications []StatusPageApplication `json:"applications"`
+}
+
+// StatusPageChecks counts the update checks answered over the check window.
+// SuccessRate is 1 when there were no checks.
+type StatusPageChecks struct {
+	Window      string  `json:"window"`
+	Total       int64   `json:"total"`
+	Succeeded   int64   `json:"succeeded"`
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// StatusPageApplication is one application listed on the status page.
+type StatusPageApplication struct {
+	ID            string `json:"id"`
+	LatestVersion string `json:"latest_version,omitempty"` // Empty until a stable release is offered
+}
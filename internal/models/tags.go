@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxTags is the maximum number of tags that may be attached to a single
+	// application or release.
+	MaxTags = 20
+
+	// MaxTagLength is the maximum length of a single tag.
+	MaxTagLength = 50
+
+	// TagSecurity marks a release as a security fix. Checks that offer a
+	// security or required release are served through the priority lane when
+	// the service sheds load.
+	TagSecurity = "security"
+)
+
+// tagPattern restricts tags to lowercase URL-safe identifiers so they can be
+// passed as query parameters without escaping (e.g. "q3-redesign", "hotfix").
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// NormalizeTags lowercases and trims each tag, drops empty entries and
+// duplicates, and returns the result sorted. A nil input yields an empty slice.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ValidateTags checks the tag count and the format of each tag.
+// Tags are expected to have been passed through NormalizeTags first.
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("too many tags: %d (maximum %d)", len(tags), MaxTags)
+	}
+	for _, t := range tags {
+		if t == "" {
+			return errors.New("tag cannot be empty")
+		}
+		if len(t) > MaxTagLength {
+			return fmt.Errorf("tag %q exceeds maximum length of %d", t, MaxTagLength)
+		}
+		if !tagPattern.MatchString(t) {
+			return fmt.Errorf("invalid tag %q: must contain only lowercase letters, digits, '.', '_' and '-'", t)
+		}
+	}
+	return nil
+}
+
+// HasTag reports whether tag is present in tags.
+func HasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllTags reports whether every entry of want is present in tags.
+func HasAllTags(tags, want []string) bool {
+	for _, w := range want {
+		if !HasTag(tags, w) {
+			return false
+		}
+	}
+	return true
+}
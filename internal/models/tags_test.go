@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{name: "nil input", input: nil, expected: []string{}},
+		{name: "lowercases and trims", input: []string{" Hotfix ", "SECURITY"}, expected: []string{"hotfix", "security"}},
+		{name: "drops empty and duplicates", input: []string{"beta", "", "Beta", "  "}, expected: []string{"beta"}},
+		{name: "sorts output", input: []string{"q3-redesign", "hotfix"}, expected: []string{"hotfix", "q3-redesign"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeTags(tt.input))
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	tooMany := make([]string, MaxTags+1)
+	for i := range tooMany {
+		tooMany[i] = "tag" + strings.Repeat("x", i)
+	}
+
+	tests := []struct {
+		name        string
+		tags        []string
+		expectError bool
+		errorMsg    string
+	}{
+		{name: "nil is valid", tags: nil},
+		{name: "valid tags", tags: []string{"hotfix", "q3-redesign", "build_42", "v1.2"}},
+		{name: "too many tags", tags: tooMany, expectError: true, errorMsg: "too many tags"},
+		{name: "empty tag", tags: []string{""}, expectError: true, errorMsg: "tag cannot be empty"},
+		{name: "too long", tags: []string{strings.Repeat("a", MaxTagLength+1)}, expectError: true, errorMsg: "exceeds maximum length"},
+		{name: "uppercase rejected", tags: []string{"Hotfix"}, expectError: true, errorMsg: "invalid tag"},
+		{name: "spaces rejected", tags: []string{"q3 redesign"}, expectError: true, errorMsg: "invalid tag"},
+		{name: "leading hyphen rejected", tags: []string{"-beta"}, expectError: true, errorMsg: "invalid tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTags(tt.tags)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHasAllTags(t *testing.T) {
+	tags := []string{"hotfix", "security"}
+
+	assert.True(t, HasAllTags(tags, nil))
+	assert.True(t, HasAllTags(tags, []string{"hotfix"}))
+	assert.True(t, HasAllTags(tags, []string{"security", "hotfix"}))
+	assert.False(t, HasAllTags(tags, []string{"hotfix", "beta"}))
+	assert.False(t, HasAllTags(nil, []string{"hotfix"}))
+}
+
+func TestRegisterReleaseRequest_Tags(t *testing.T) {
+	req := RegisterReleaseRequest{
+		ApplicationID: "test-app",
+		Version:       "1.0.0",
+		Platform:      "linux",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/app",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+		Tags:          []string{"Hotfix", "hotfix", " Security "},
+	}
+
+	assert.NoError(t, req.Validate())
+	req.Normalize()
+	assert.Equal(t, []string{"hotfix", "security"}, req.Tags)
+
+	req.Tags = []string{"not valid"}
+	assert.Error(t, req.Validate())
+}
@@ -0,0 +1,227 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// A release can be restricted to some clients by targeting rules on the
+// attributes clients report with update checks: their OS version, locale and
+// client tags. A release with rules is offered only to clients that match
+// every rule; other clients are offered the newest release they do match, as
+// with entitlements. A client that does not report an attribute matches only
+// not_in rules on it, so latest-version lookups, which report none, skip
+// releases restricted to an OS version, locale or tag.
+
+// Client attributes targeting rules can test.
+const (
+	TargetOSVersion = "os_version" // Client's operating system version, compared as a semantic version
+	TargetLocale    = "locale"     // Client's locale, such as de-AT; a value without a region matches every region
+	TargetClientTag = "client_tag" // Tags the client reports, such as its ring or hardware model
+)
+
+// Targeting rule operators.
+const (
+	TargetIn    = "in"     // The attribute is one of the values; for client_tag, the client has one of them
+	TargetNotIn = "not_in" // The attribute is none of the values, or is not reported
+	TargetGTE   = "gte"    // os_version only: the version is at least the single value
+	TargetLT    = "lt"     // os_version only: the version is below the single value
+)
+
+// Limits on a release's targeting rules.
+const (
+	MaxTargetingRules  = 20
+	MaxTargetingValues = 100
+	MaxLocaleLength    = 35
+	MaxOSVersionLength = 50
+)
+
+// TargetingRule restricts a release to clients whose attribute satisfies the
+// operator for the values.
+type TargetingRule struct {
+	Attribute string   `json:"attribute" yaml:"attribute"`
+	Operator  string   `json:"operator" yaml:"operator"`
+	Values    []string `json:"values" yaml:"values"`
+}
+
+// ClientAttributes are what a client reports about itself for targeting.
+// Variant is not tested by targeting rules; it picks the artifact the client
+// is offered (see variant.go).
+type ClientAttributes struct {
+	OSVersion string
+	Locale    string
+	Tags      []string
+	Variant   string
+}
+
+// NormalizeLocale lowercases a locale and uses '-' to separate its parts, so
+// de_AT and de-at are the same locale.
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// ValidateLocale checks a normalized locale.
+func ValidateLocale(locale string) error {
+	if len(locale) > MaxLocaleLength {
+		return fmt.Errorf("locale %q exceeds maximum length of %d", locale, MaxLocaleLength)
+	}
+	if !tagPattern.MatchString(locale) {
+		return fmt.Errorf("invalid locale %q", locale)
+	}
+	return nil
+}
+
+// NormalizeTargeting trims the rules' attributes and operators and normalizes
+// their values the way the client's attribute is normalized. It returns nil
+// for no rules.
+func NormalizeTargeting(rules []TargetingRule) []TargetingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	out := make([]TargetingRule, len(rules))
+	for i, rule := range rules {
+		rule.Attribute = strings.ToLower(strings.TrimSpace(rule.Attribute))
+		rule.Operator = strings.ToLower(strings.TrimSpace(rule.Operator))
+		values := make([]string, len(rule.Values))
+		for j, v := range rule.Values {
+			switch rule.Attribute {
+			case TargetLocale:
+				values[j] = NormalizeLocale(v)
+			case TargetClientTag:
+				values[j] = strings.ToLower(strings.TrimSpace(v))
+			default:
+				values[j] = strings.TrimSpace(v)
+			}
+		}
+		rule.Values = values
+		out[i] = rule
+	}
+	return out
+}
+
+// ValidateTargeting checks a release's normalized targeting rules.
+func ValidateTargeting(rules []TargetingRule) error {
+	if len(rules) > MaxTargetingRules {
+		return fmt.Errorf("too many targeting rules: %d (maximum %d)", len(rules), MaxTargetingRules)
+	}
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("targeting[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r TargetingRule) validate() error {
+	switch r.Attribute {
+	case TargetOSVersion, TargetLocale, TargetClientTag:
+	default:
+		return fmt.Errorf("unknown attribute %q: must be one of %s, %s or %s", r.Attribute, TargetOSVersion, TargetLocale, TargetClientTag)
+	}
+	switch r.Operator {
+	case TargetIn, TargetNotIn:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("%s needs at least one value", r.Operator)
+		}
+	case TargetGTE, TargetLT:
+		if r.Attribute != TargetOSVersion {
+			return fmt.Errorf("%s only applies to %s", r.Operator, TargetOSVersion)
+		}
+		if len(r.Values) != 1 {
+			return fmt.Errorf("%s needs exactly one value", r.Operator)
+		}
+		if _, err := semver.NewVersion(r.Values[0]); err != nil {
+			return fmt.Errorf("invalid os_version %q: %w", r.Values[0], err)
+		}
+	default:
+		return fmt.Errorf("unknown operator %q: must be one of %s, %s, %s or %s", r.Operator, TargetIn, TargetNotIn, TargetGTE, TargetLT)
+	}
+	if len(r.Values) > MaxTargetingValues {
+		return fmt.Errorf("too many values: %d (maximum %d)", len(r.Values), MaxTargetingValues)
+	}
+	for _, v := range r.Values {
+		if v == "" {
+			return errors.New("values cannot be empty")
+		}
+		switch r.Attribute {
+		case TargetLocale:
+			if err := ValidateLocale(v); err != nil {
+				return err
+			}
+		case TargetClientTag:
+			if err := ValidateTags([]string{v}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Matches reports whether a client with the attributes satisfies the rule.
+func (r TargetingRule) Matches(client ClientAttributes) bool {
+	var in bool
+	switch r.Attribute {
+	case TargetOSVersion:
+		if client.OSVersion == "" {
+			return r.Operator == TargetNotIn
+		}
+		if r.Operator == TargetGTE || r.Operator == TargetLT {
+			v, err := semver.NewVersion(client.OSVersion)
+			bound, boundErr := semver.NewVersion(r.Values[0])
+			if err != nil || boundErr != nil {
+				return false
+			}
+			return v.LessThan(bound) == (r.Operator == TargetLT)
+		}
+		in = slices.ContainsFunc(r.Values, func(value string) bool { return sameOSVersion(value, client.OSVersion) })
+	case TargetLocale:
+		if client.Locale == "" {
+			return r.Operator == TargetNotIn
+		}
+		in = slices.ContainsFunc(r.Values, func(value string) bool {
+			return value == client.Locale || strings.HasPrefix(client.Locale, value+"-")
+		})
+	case TargetClientTag:
+		in = slices.ContainsFunc(r.Values, func(value string) bool { return slices.Contains(client.Tags, value) })
+	default:
+		return false
+	}
+	return in == (r.Operator == TargetIn)
+}
+
+// sameOSVersion compares OS versions as semantic versions, so 14 and 14.0.0
+// are the same, and falls back to comparing the strings.
+func sameOSVersion(a, b string) bool {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return va.Equal(vb)
+}
+
+// MatchTargeting returns the first of the rules the client does not match,
+// or nil when it matches them all.
+func MatchTargeting(rules []TargetingRule, client ClientAttributes) *TargetingRule {
+	for i := range rules {
+		if !rules[i].Matches(client) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// String describes the rule for decision traces, such as
+// "os_version gte 10.0.19045".
+func (r TargetingRule) String() string {
+	return fmt.Sprintf("%s %s %s", r.Attribute, r.Operator, strings.Join(r.Values, ","))
+}
+
+// Equal reports whether two rules are the same.
+func (r TargetingRule) Equal(other TargetingRule) bool {
+	return r.Attribute == other.Attribute && r.Operator == other.Operator && slices.Equal(r.Values, other.Values)
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetingRule_Matches(t *testing.T) {
+	client := ClientAttributes{OSVersion: "10.0.19045", Locale: "de-at", Tags: []string{"insider", "surface"}}
+	anonymous := ClientAttributes{}
+
+	tests := []struct {
+		name      string
+		rule      TargetingRule
+		client    bool
+		anonymous bool
+	}{
+		{"os gte", TargetingRule{TargetOSVersion, TargetGTE, []string{"10.0.19041"}}, true, false},
+		{"os gte equal", TargetingRule{TargetOSVersion, TargetGTE, []string{"10.0.19045"}}, true, false},
+		{"os lt", TargetingRule{TargetOSVersion, TargetLT, []string{"10.0.19045"}}, false, false},
+		{"os in", TargetingRule{TargetOSVersion, TargetIn, []string{"11", "10.0.19045"}}, true, false},
+		{"os not in", TargetingRule{TargetOSVersion, TargetNotIn, []string{"10.0.19045"}}, false, true},
+		{"locale region", TargetingRule{TargetLocale, TargetIn, []string{"de-at"}}, true, false},
+		{"locale language", TargetingRule{TargetLocale, TargetIn, []string{"de"}}, true, false},
+		{"locale other language", TargetingRule{TargetLocale, TargetIn, []string{"d", "fr"}}, false, false},
+		{"locale not in", TargetingRule{TargetLocale, TargetNotIn, []string{"fr"}}, true, true},
+		{"tag in", TargetingRule{TargetClientTag, TargetIn, []string{"beta-ring", "insider"}}, true, false},
+		{"tag not in", TargetingRule{TargetClientTag, TargetNotIn, []string{"surface"}}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.client, tt.rule.Matches(client))
+			assert.Equal(t, tt.anonymous, tt.rule.Matches(anonymous))
+		})
+	}
+}
+
+func TestMatchTargeting(t *testing.T) {
+	rules := []TargetingRule{
+		{TargetOSVersion, TargetGTE, []string{"14"}},
+		{TargetLocale, TargetIn, []string{"en"}},
+	}
+	assert.Nil(t, MatchTargeting(rules, ClientAttributes{OSVersion: "14.2", Locale: "en-us"}))
+	assert.Nil(t, MatchTargeting(nil, ClientAttributes{}))
+
+	failed := MatchTargeting(rules, ClientAttributes{OSVersion: "14.2", Locale: "ja"})
+	require.NotNil(t, failed)
+	assert.Equal(t, "locale in en", failed.String())
+}
+
+func TestValidateTargeting(t *testing.T) {
+	valid := NormalizeTargeting([]TargetingRule{
+		{" OS_Version ", "GTE", []string{" 10.0 "}},
+		{"locale", "in", []string{"de_AT", "EN"}},
+		{"client_tag", "not_in", []string{"Canary"}},
+	})
+	require.NoError(t, ValidateTargeting(valid))
+	assert.Equal(t, TargetingRule{TargetOSVersion, TargetGTE, []string{"10.0"}}, valid[0])
+	assert.Equal(t, []string{"de-at", "en"}, valid[1].Values)
+	assert.Equal(t, []string{"canary"}, valid[2].Values)
+	assert.Nil(t, NormalizeTargeting([]TargetingRule{}))
+
+	tests := []struct {
+		rule TargetingRule
+		want string
+	}{
+		{TargetingRule{"country", TargetIn, []string{"de"}}, "unknown attribute"},
+		{TargetingRule{TargetLocale, "matches", []string{"de"}}, "unknown operator"},
+		{TargetingRule{TargetLocale, TargetIn, nil}, "at least one value"},
+		{TargetingRule{TargetLocale, TargetGTE, []string{"de"}}, "only applies to os_version"},
+		{TargetingRule{TargetOSVersion, TargetLT, []string{"10", "11"}}, "exactly one value"},
+		{TargetingRule{TargetOSVersion, TargetLT, []string{"ten"}}, "invalid os_version"},
+		{TargetingRule{TargetLocale, TargetIn, []string{"de at"}}, "invalid locale"},
+		{TargetingRule{TargetClientTag, TargetIn, []string{""}}, "cannot be empty"},
+	}
+	for _, tt := range tests {
+		assert.ErrorContains(t, ValidateTargeting([]TargetingRule{tt.rule}), tt.want)
+	}
+	assert.ErrorContains(t, ValidateTargeting(make([]TargetingRule, MaxTargetingRules+1)), "too many targeting rules")
+}
+
+func TestUpdateCheckRequest_Attributes(t *testing.T) {
+	req := &UpdateCheckRequest{
+		ApplicationID:  "app",
+		CurrentVersion: "1.0.0",
+		Platform:       "windows",
+		Architecture:   "amd64",
+		OSVersion:      " 10.0.19045 ",
+		Locale:         "de_AT",
+		ClientTags:     []string{"Insider", "insider"},
+	}
+	require.NoError(t, req.Validate())
+	req.Normalize()
+	assert.Equal(t, ClientAttributes{OSVersion: "10.0.19045", Locale: "de-at", Tags: []string{"insider"}}, req.Attributes())
+
+	req.Locale = "de AT"
+	assert.Error(t, req.Validate())
+}
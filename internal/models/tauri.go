@@ -0,0 +1,84 @@
+package models
+
+// Tauri apps update through the Tauri updater plugin, which reads a JSON
+// manifest from the endpoint in the app's configuration. The manifest names
+// one version and, per target, the download URL and the signature the app
+// verifies with its embedded public key. Tauri signs artifacts with its own
+// minisign-based key when it bundles them, so the signature is recorded in
+// release metadata under TauriSignatureMetadataKey rather than derived here.
+
+// TauriSignatureMetadataKey is the release metadata key holding the content of
+// the .sig file Tauri writes next to a signed updater artifact.
+const TauriSignatureMetadataKey = "tauri_signature"
+
+// TauriUpdateManifest is the static JSON manifest the Tauri v2 updater reads.
+type TauriUpdateManifest struct {
+	Version   string                   `json:"version"`
+	Notes     string                   `json:"notes,omitempty"`
+	PubDate   string                   `json:"pub_date,omitempty"` // RFC 3339
+	Platforms map[string]TauriPlatform `json:"platforms"`          // Keyed by target, such as windows-x86_64
+}
+
+// TauriPlatform is the download of one target in a Tauri manifest.
+type TauriPlatform struct {
+	Signature string `json:"signature"`
+	URL       string `json:"url"`
+}
+
+// tauriOS maps platforms to the operating system names of Tauri targets.
+var tauriOS = map[string]string{
+	PlatformWindows: "windows",
+	PlatformDarwin:  "darwin",
+	PlatformLinux:   "linux",
+}
+
+// TauriArchitectures are the architectures Tauri builds for, in the order
+// their targets are looked up.
+var TauriArchitectures = []string{ArchAMD64, ArchARM64, Arch386, ArchARM}
+
+// tauriArch maps architectures to the architecture names of Tauri targets.
+var tauriArch = map[string]string{
+	ArchAMD64: "x86_64",
+	ArchARM64: "aarch64",
+	Arch386:   "i686",
+	ArchARM:   "armv7",
+}
+
+// tauriInstallers maps variants to the installer names Tauri appends to a
+// target, so an MSI install is offered the MSI before the plain target.
+var tauriInstallers = map[string]string{
+	VariantMSI:          "msi",
+	VariantExeInstaller: "nsis",
+	VariantAppImage:     "appimage",
+	VariantDeb:          "deb",
+	VariantRPM:          "rpm",
+}
+
+// TauriTarget returns the Tauri target of a platform and architecture, such as
+// darwin-aarch64, and whether Tauri builds for it.
+func TauriTarget(platform, arch string) (string, bool) {
+	osName, ok := tauriOS[platform]
+	if !ok {
+		return "", false
+	}
+	archName, ok := tauriArch[arch]
+	if !ok {
+		return "", false
+	}
+	return osName + "-" + archName, true
+}
+
+// TauriTargets returns the keys a latest-version lookup is listed under in a
+// Tauri manifest: the target and, when the download is a variant Tauri
+// installs, the target with the installer name.
+func TauriTargets(platform, arch, variant string) []string {
+	target, ok := TauriTarget(platform, arch)
+	if !ok {
+		return nil
+	}
+	targets := []string{target}
+	if installer, ok := tauriInstallers[variant]; ok {
+		targets = append(targets, target+"-"+installer)
+	}
+	return targets
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTauriTargets(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		arch     string
+		variant  string
+		want     []string
+	}{
+		{name: "plain target", platform: PlatformDarwin, arch: ArchARM64, want: []string{"darwin-aarch64"}},
+		{name: "msi installer", platform: PlatformWindows, arch: ArchAMD64, variant: VariantMSI, want: []string{"windows-x86_64", "windows-x86_64-msi"}},
+		{name: "nsis installer", platform: PlatformWindows, arch: Arch386, variant: VariantExeInstaller, want: []string{"windows-i686", "windows-i686-nsis"}},
+		{name: "appimage", platform: PlatformLinux, arch: ArchARM, variant: VariantAppImage, want: []string{"linux-armv7", "linux-armv7-appimage"}},
+		{name: "variant tauri does not install", platform: PlatformLinux, arch: ArchAMD64, variant: VariantPortableZip, want: []string{"linux-x86_64"}},
+		{name: "unsupported platform", platform: PlatformAndroid, arch: ArchARM64, variant: VariantMSI},
+		{name: "unsupported architecture", platform: PlatformLinux, arch: "riscv64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TauriTargets(tt.platform, tt.arch, tt.variant))
+		})
+	}
+}
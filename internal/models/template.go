@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ApplicationTemplate holds creation defaults shared by a family of
+// applications, such as every desktop product of a team. Templates are defined
+// in the service configuration and selected by name when an application is
+// created; the application does not keep a reference to its template.
+type ApplicationTemplate struct {
+	Name         string            `yaml:"name" json:"name"`                             // Template identifier used in ?template=
+	Description  string            `yaml:"description" json:"description,omitempty"`     // What the template is for
+	Platforms    []string          `yaml:"platforms" json:"platforms,omitempty"`         // Default platforms
+	Profile      string            `yaml:"profile" json:"profile,omitempty"`             // Default client profile
+	CustomFields map[string]string `yaml:"custom_fields" json:"custom_fields,omitempty"` // Default custom config fields
+	Tags         []string          `yaml:"tags" json:"tags,omitempty"`                   // Tags added to every application
+	Group        string            `yaml:"group" json:"group,omitempty"`                 // Default application group
+}
+
+func (t *ApplicationTemplate) Validate() error {
+	if !isValidID(t.Name) {
+		return errors.New("name must contain only alphanumeric characters, hyphens, and underscores")
+	}
+
+	for _, platform := range t.Platforms {
+		if !isValidPlatform(platform) {
+			return fmt.Errorf("invalid platform: %s", platform)
+		}
+	}
+
+	config := ApplicationConfig{Profile: t.Profile}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	if err := ValidateTags(NormalizeTags(t.Tags)); err != nil {
+		return err
+	}
+
+	return ValidateGroup(NormalizeGroup(t.Group))
+}
+
+// Apply fills in the fields of req that the caller left empty from the
+// template. Custom fields are merged with the request's values taking
+// precedence, and the template's tags are added to the request's tags.
+func (t *ApplicationTemplate) Apply(req *CreateApplicationRequest) {
+	if len(req.Platforms) == 0 {
+		req.Platforms = append([]string(nil), t.Platforms...)
+	}
+
+	if req.Config.Profile == "" {
+		req.Config.Profile = t.Profile
+	}
+
+	if len(t.CustomFields) > 0 {
+		fields := make(map[string]string, len(t.CustomFields)+len(req.Config.CustomFields))
+		for k, v := range t.CustomFields {
+			fields[k] = v
+		}
+		for k, v := range req.Config.CustomFields {
+			fields[k] = v
+		}
+		req.Config.CustomFields = fields
+	}
+
+	if len(t.Tags) > 0 {
+		req.Tags = append(append([]string(nil), t.Tags...), req.Tags...)
+	}
+
+	if strings.TrimSpace(req.Group) == "" {
+		req.Group = t.Group
+	}
+}
+
+// ValidateApplicationTemplates validates each template and rejects duplicate names.
+func ValidateApplicationTemplates(templates []ApplicationTemplate) error {
+	var errs []error
+	seen := make(map[string]bool, len(templates))
+	for i := range templates {
+		t := &templates[i]
+		if err := t.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("template %q: %w", t.Name, err))
+			continue
+		}
+		if seen[t.Name] {
+			errs = append(errs, fmt.Errorf("duplicate template name: %s", t.Name))
+		}
+		seen[t.Name] = true
+	}
+	return errors.Join(errs...)
+}
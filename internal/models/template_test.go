@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicationTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template ApplicationTemplate
+		errorMsg string
+	}{
+		{name: "valid", template: ApplicationTemplate{Name: "desktop-app", Platforms: []string{"windows"}, Tags: []string{"desktop"}}},
+		{name: "name only", template: ApplicationTemplate{Name: "empty"}},
+		{name: "invalid name", template: ApplicationTemplate{Name: "desktop app"}, errorMsg: "name must contain only"},
+		{name: "invalid platform", template: ApplicationTemplate{Name: "t", Platforms: []string{"beos"}}, errorMsg: "invalid platform: beos"},
+		{name: "invalid profile", template: ApplicationTemplate{Name: "t", Profile: "tv"}, errorMsg: "invalid profile: tv"},
+		{name: "invalid tag", template: ApplicationTemplate{Name: "t", Tags: []string{"a b"}}, errorMsg: "invalid tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.template.Validate()
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApplicationTemplate_Apply(t *testing.T) {
+	template := ApplicationTemplate{
+		Name:         "desktop-app",
+		Platforms:    []string{"windows", "darwin"},
+		CustomFields: map[string]string{"support_url": "https://support.example.com", "tier": "standard"},
+		Tags:         []string{"desktop"},
+		Group:        "Desktop",
+	}
+
+	t.Run("fills empty fields", func(t *testing.T) {
+		req := CreateApplicationRequest{ID: "app", Name: "App"}
+		template.Apply(&req)
+
+		assert.Equal(t, []string{"windows", "darwin"}, req.Platforms)
+		assert.Equal(t, template.CustomFields, req.Config.CustomFields)
+		assert.Equal(t, []string{"desktop"}, req.Tags)
+		assert.Equal(t, "Desktop", req.Group)
+
+		// The request does not share the template's slices or maps.
+		req.Platforms[0] = "linux"
+		req.Config.CustomFields["tier"] = "enterprise"
+		assert.Equal(t, "windows", template.Platforms[0])
+		assert.Equal(t, "standard", template.CustomFields["tier"])
+	})
+
+	t.Run("request values win", func(t *testing.T) {
+		req := CreateApplicationRequest{
+			ID:        "app",
+			Name:      "App",
+			Platforms: []string{"linux"},
+			Config:    ApplicationConfig{CustomFields: map[string]string{"tier": "enterprise"}},
+			Tags:      []string{"beta"},
+			Group:     "Tools",
+		}
+		template.Apply(&req)
+
+		assert.Equal(t, []string{"linux"}, req.Platforms)
+		assert.Equal(t, map[string]string{"support_url": "https://support.example.com", "tier": "enterprise"}, req.Config.CustomFields)
+		assert.Equal(t, []string{"desktop", "beta"}, req.Tags)
+		assert.Equal(t, "Tools", req.Group)
+	})
+}
+
+func TestValidateApplicationTemplates(t *testing.T) {
+	assert.NoError(t, ValidateApplicationTemplates(nil))
+	assert.NoError(t, ValidateApplicationTemplates([]ApplicationTemplate{{Name: "a"}, {Name: "b"}}))
+
+	err := ValidateApplicationTemplates([]ApplicationTemplate{{Name: "a"}, {Name: "a"}, {Name: "bad name"}})
+	assert.ErrorContains(t, err, "duplicate template name: a")
+	assert.ErrorContains(t, err, `template "bad name"`)
+}
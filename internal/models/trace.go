@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Decision results of a traced update check rule.
+const (
+	DecisionPass = "pass" // The rule allowed the release or request
+	DecisionFail = "fail" // The rule rejected the release or request
+	DecisionSkip = "skip" // The rule did not apply
+)
+
+// Rules recorded in an update check decision trace, in the order the check
+// evaluates them.
+const (
+	RuleApplication       = "application"        // The application exists
+	RulePlatform          = "platform"           // The application supports the client's platform
+	RuleChannel           = "channel"            // The release is on a channel the client follows
+	RulePaused            = "paused"             // The release's rollout is not paused
+	RuleYanked            = "yanked"             // The release is not yanked; clients on a yanked release are offered the one before it
+	RuleStatusChecks      = "status_checks"      // Every required status check of the release succeeded
+	RuleArtifact          = "artifact"           // The release's hosted artifact has been uploaded
+	RuleVersionCode       = "version_code"       // The offered APK's version code is above the client's, so Android can install it
+	RuleTargeting         = "targeting"          // The client matches the release's targeting rules
+	RuleHostCompatibility = "host_compatibility" // A plugin release accepts the client's host version
+	RuleLatestRelease     = "latest_release"     // A release exists for the client's platform and architecture
+	RuleNewerVersion      = "newer_version"      // The release is newer than the client's version
+	RulePrerelease        = "prerelease"         // Pre-releases are only offered with allow_prerelease
+	RuleStableFallback    = "stable_fallback"    // The newest stable release replaces a pre-release
+	RuleMinimumVersion    = "minimum_version"    // The client's version meets the release's minimum version
+	RuleEntitlement       = "entitlement"        // The client's license grants the release's required entitlement
+	RuleEdition           = "edition"            // The artifact of the client's edition is offered when the release has one
+	RuleVariant           = "variant"            // The release is available in the client's artifact variant
+	RuleBandwidthBudget   = "bandwidth_budget"   // The application's hourly bandwidth budget allows another offer
+)
+
+// Dry-run check outcomes.
+const (
+	CheckDecisionUpdate   = "update_available" // The check offers a release
+	CheckDecisionNoUpdate = "no_update"        // The check succeeds without offering a release
+	CheckDecisionRejected = "rejected"         // The check fails with a client error
+	CheckDecisionError    = "error"            // The check fails with a server error
+)
+
+// DecisionStep is one rule evaluated during an update check. Release is the
+// version the rule was evaluated against, if any.
+type DecisionStep struct {
+	Rule    string `json:"rule"`
+	Result  string `json:"result"`
+	Release string `json:"release,omitempty"`
+	Detail  string `json:"detail"`
+}
+
+// DryRunCheckResponse explains the outcome of an update check for a
+// hypothetical client. Result is the response the client would receive and is
+// omitted when the check fails; Error then carries the error message.
+type DryRunCheckResponse struct {
+	Decision string               `json:"decision"`
+	Result   *UpdateCheckResponse `json:"result,omitempty"`
+	Error    string               `json:"error,omitempty"`
+	Trace    []DecisionStep       `json:"trace"`
+}
+
+// DecisionRecord is the decision trace of one update check served to a
+// client, kept in the decision log under the request's ID.
+type DecisionRecord struct {
+	RequestID  string               `json:"request_id"`
+	RecordedAt time.Time            `json:"recorded_at"`
+	Request    UpdateCheckRequest   `json:"request"`
+	Decision   string               `json:"decision"`
+	Result     *UpdateCheckResponse `json:"result,omitempty"`
+	Error      string               `json:"error,omitempty"`
+	Trace      []DecisionStep       `json:"trace"`
+}
+
+// DecisionLogResponse lists the checks recorded for a request ID, oldest
+// first. A long-polled check records one entry per evaluation and a batch
+// check one entry per item.
+type DecisionLogResponse struct {
+	RequestID string           `json:"request_id"`
+	Records   []DecisionRecord `json:"records"`
+}
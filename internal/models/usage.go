@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ApplicationUsageResponse reports what an application stores, for quotas
+// and chargeback. Artifacts are hosted outside the service, so artifact bytes
+// are the file sizes its releases declare, not space used by the service.
+type ApplicationUsageResponse struct {
+	ApplicationID    string    `json:"application_id"`
+	Releases         int       `json:"releases"`
+	Artifacts        int       `json:"artifacts"`         // Base and edition artifacts
+	ArtifactBytes    int64     `json:"artifact_bytes"`    // Sum of the artifacts' declared file sizes
+	UnsizedArtifacts int       `json:"unsized_artifacts"` // Artifacts without a file_size, not in ArtifactBytes
+	ContainerImages  int       `json:"container_images"`
+	MeasuredAt       time.Time `json:"measured_at"`
+}
+
+// AddArtifact counts an artifact of the given declared size; zero means the
+// size is unknown.
+func (u *ApplicationUsageResponse) AddArtifact(size int64) {
+	u.Artifacts++
+	if size > 0 {
+		u.ArtifactBytes += size
+	} else {
+		u.UnsizedArtifacts++
+	}
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// A release can ship one build in several packagings, such as an MSI and a
+// portable zip, or a deb and an AppImage. Variant names the packaging of the
+// release's own artifact and Variants holds the artifacts of the others,
+// keyed by variant. A client reports the variant it was installed from and is
+// offered that variant's artifact, so a portable install is never handed an
+// installer. Releases that do not have the client's variant are skipped, and
+// the client is offered the newest release that has it. Releases that declare
+// no variants at all are offered to every client as they are.
+//
+// An edition artifact replaces the release's own artifact for clients of the
+// edition and has no variants; such clients are offered it whatever variant
+// they report.
+
+// Artifact variants.
+const (
+	VariantMSI          = "msi"
+	VariantExeInstaller = "exe-installer"
+	VariantNupkg        = "nupkg" // Squirrel.Windows full package
+	VariantPortableZip  = "portable-zip"
+	VariantDMG          = "dmg"
+	VariantPKG          = "pkg"
+	VariantAppImage     = "appimage"
+	VariantDeb          = "deb"
+	VariantRPM          = "rpm"
+	VariantAPK          = "apk"
+)
+
+// variantPlatforms lists the platforms each variant can be built for; a
+// variant without an entry suits every platform.
+var variantPlatforms = map[string][]string{
+	VariantMSI:          {PlatformWindows},
+	VariantExeInstaller: {PlatformWindows},
+	VariantNupkg:        {PlatformWindows},
+	VariantDMG:          {PlatformDarwin},
+	VariantPKG:          {PlatformDarwin},
+	VariantAppImage:     {PlatformLinux},
+	VariantDeb:          {PlatformLinux},
+	VariantRPM:          {PlatformLinux},
+	VariantAPK:          {PlatformAndroid},
+}
+
+// SupportedVariants are the artifact variants releases can declare.
+var SupportedVariants = []string{
+	VariantMSI, VariantExeInstaller, VariantNupkg, VariantPortableZip,
+	VariantDMG, VariantPKG,
+	VariantAppImage, VariantDeb, VariantRPM,
+	VariantAPK,
+}
+
+// VariantArtifact is the build of a release in one packaging. It replaces the
+// release's download, checksum and file size for clients of that variant.
+type VariantArtifact struct {
+	DownloadURL  string `json:"download_url" yaml:"download_url"`
+	Checksum     string `json:"checksum" yaml:"checksum"`
+	ChecksumType string `json:"checksum_type" yaml:"checksum_type"`
+	FileSize     int64  `json:"file_size,omitempty" yaml:"file_size,omitempty"`
+}
+
+// NormalizeVariant lowercases and trims a variant name.
+func NormalizeVariant(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateVariant checks that a variant is one of the supported variants.
+func ValidateVariant(name string) error {
+	if !slices.Contains(SupportedVariants, name) {
+		return fmt.Errorf("unsupported variant %q: must be one of %s", name, strings.Join(SupportedVariants, ", "))
+	}
+	return nil
+}
+
+// ValidatePlatformVariant checks that a variant is supported and can be built
+// for platform.
+func ValidatePlatformVariant(platform, name string) error {
+	if err := ValidateVariant(name); err != nil {
+		return err
+	}
+	if platforms, ok := variantPlatforms[name]; ok && !slices.Contains(platforms, platform) {
+		return fmt.Errorf("variant %s is not available on %s", name, platform)
+	}
+	return nil
+}
+
+// ValidateVariants checks a release's normalized variant and variant
+// artifacts for its platform.
+func ValidateVariants(platform, variant string, variants map[string]VariantArtifact) error {
+	if variant != "" {
+		if err := ValidatePlatformVariant(platform, variant); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(variants)) {
+		if err := ValidatePlatformVariant(platform, name); err != nil {
+			return err
+		}
+		if name == variant {
+			return fmt.Errorf("variants.%s: the release's own artifact is already the %s variant", name, name)
+		}
+		a := variants[name]
+		if err := validateArtifact(a.DownloadURL, a.Checksum, a.ChecksumType, a.FileSize); err != nil {
+			return fmt.Errorf("variants.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// NormalizeVariants returns a copy of variants with normalized names and
+// fields, or nil when there are none.
+func NormalizeVariants(variants map[string]VariantArtifact) map[string]VariantArtifact {
+	if len(variants) == 0 {
+		return nil
+	}
+	out := make(map[string]VariantArtifact, len(variants))
+	for name, a := range variants {
+		out[NormalizeVariant(name)] = VariantArtifact{
+			DownloadURL:  strings.TrimSpace(a.DownloadURL),
+			Checksum:     strings.ToLower(strings.TrimSpace(a.Checksum)),
+			ChecksumType: strings.ToLower(strings.TrimSpace(a.ChecksumType)),
+			FileSize:     a.FileSize,
+		}
+	}
+	return out
+}
+
+// HasVariants reports whether the release declares any variant.
+func (r *Release) HasVariants() bool {
+	return r.Variant != "" || len(r.Variants) > 0
+}
+
+// ReleaseVariants returns the variants a release is available in, sorted.
+func (r *Release) ReleaseVariants() []string {
+	names := slices.Collect(maps.Keys(r.Variants))
+	if r.Variant != "" {
+		names = append(names, r.Variant)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ForVariant returns the release as it serves variant: the release itself
+// when its own artifact is that variant, or a copy serving the variant's
+// artifact. It returns nil when the release is not available in variant. As
+// with editions, the copy has neither additional checksums nor a PGP
+// signature, since those describe the release's own file.
+func (r *Release) ForVariant(variant string) *Release {
+	if variant == r.Variant {
+		return r
+	}
+	a, ok := r.Variants[variant]
+	if !ok {
+		return nil
+	}
+	release := *r
+	release.Variant = variant
+	release.DownloadURL = a.DownloadURL
+	release.Checksum = a.Checksum
+	release.ChecksumType = a.ChecksumType
+	release.FileSize = a.FileSize
+	release.Checksums = nil
+	release.PGPSignature = ""
+	return &release
+}
+
+// copyVariants returns a copy of variants, or nil when there are none.
+func copyVariants(variants map[string]VariantArtifact) map[string]VariantArtifact {
+	if len(variants) == 0 {
+		return nil
+	}
+	return maps.Clone(variants)
+}
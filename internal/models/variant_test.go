@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVariants(t *testing.T) {
+	valid := VariantArtifact{DownloadURL: "https://example.com/app.zip", Checksum: "abc123", ChecksumType: "sha256"}
+
+	tests := []struct {
+		name     string
+		platform string
+		variant  string
+		variants map[string]VariantArtifact
+		wantErr  bool
+	}{
+		{"none", PlatformWindows, "", nil, false},
+		{"base variant only", PlatformWindows, VariantMSI, nil, false},
+		{"valid", PlatformWindows, VariantMSI, map[string]VariantArtifact{VariantPortableZip: valid}, false},
+		{"unknown variant", PlatformWindows, "snap", nil, true},
+		{"unknown variants key", PlatformWindows, VariantMSI, map[string]VariantArtifact{"appx": valid}, true},
+		{"wrong platform", PlatformLinux, VariantMSI, nil, true},
+		{"variants key on wrong platform", PlatformDarwin, VariantDMG, map[string]VariantArtifact{VariantDeb: valid}, true},
+		{"duplicates base variant", PlatformLinux, VariantDeb, map[string]VariantArtifact{VariantDeb: valid}, true},
+		{"missing url", PlatformLinux, VariantDeb, map[string]VariantArtifact{VariantAppImage: {Checksum: "abc123", ChecksumType: "sha256"}}, true},
+		{"bad checksum type", PlatformLinux, VariantDeb, map[string]VariantArtifact{VariantRPM: {DownloadURL: "https://example.com/app.rpm", Checksum: "abc123", ChecksumType: "crc32"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVariants(tt.platform, tt.variant, tt.variants)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeVariants(t *testing.T) {
+	assert.Nil(t, NormalizeVariants(map[string]VariantArtifact{}))
+	assert.Equal(t, VariantPortableZip, NormalizeVariant(" Portable-ZIP "))
+
+	got := NormalizeVariants(map[string]VariantArtifact{
+		" MSI ": {DownloadURL: " https://example.com/app.msi ", Checksum: "ABC123", ChecksumType: "SHA256", FileSize: 7},
+	})
+	assert.Equal(t, map[string]VariantArtifact{
+		VariantMSI: {DownloadURL: "https://example.com/app.msi", Checksum: "abc123", ChecksumType: "sha256", FileSize: 7},
+	}, got)
+}
+
+func TestRelease_ForVariant(t *testing.T) {
+	release := NewRelease("app", "1.0.0", "windows", "amd64", "https://example.com/app.msi")
+	release.Checksum = "abc123"
+	release.Checksums = map[string]string{"sha512": "def456"}
+	release.PGPSignature = "-----BEGIN PGP SIGNATURE-----\n\niHUEABYKAB0=\n-----END PGP SIGNATURE-----"
+	release.Variant = VariantMSI
+	release.Variants = map[string]VariantArtifact{
+		VariantPortableZip: {DownloadURL: "https://example.com/app.zip", Checksum: "fed321", ChecksumType: "blake3", FileSize: 42},
+	}
+
+	assert.True(t, release.HasVariants())
+	assert.Equal(t, []string{VariantMSI, VariantPortableZip}, release.ReleaseVariants())
+	assert.Same(t, release, release.ForVariant(VariantMSI))
+	assert.Nil(t, release.ForVariant(VariantExeInstaller))
+
+	portable := release.ForVariant(VariantPortableZip)
+	require.NotNil(t, portable)
+	require.NotSame(t, release, portable)
+	assert.Equal(t, VariantPortableZip, portable.Variant)
+	assert.Equal(t, "https://example.com/app.zip", portable.DownloadURL)
+	assert.Equal(t, "fed321", portable.Checksum)
+	assert.Equal(t, "blake3", portable.ChecksumType)
+	assert.Equal(t, int64(42), portable.FileSize)
+	assert.Nil(t, portable.Checksums)
+	assert.Empty(t, portable.PGPSignature)
+	assert.Equal(t, "https://example.com/app.msi", release.DownloadURL, "the stored release is unchanged")
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxChecksumLength is the hex length of the longest supported checksum,
+// SHA-512.
+const maxChecksumLength = 128
+
+// VerifyChecksumRequest is a client's report of the checksum it computed for
+// a downloaded artifact, sent to POST /api/v1/verify.
+type VerifyChecksumRequest struct {
+	ApplicationID string `json:"application_id"`
+	Version       string `json:"version"`
+	Platform      string `json:"platform"`
+	Architecture  string `json:"architecture"`
+	Checksum      string `json:"checksum"`                // Hex checksum of the downloaded file
+	ChecksumType  string `json:"checksum_type,omitempty"` // The release's primary checksum type when empty
+}
+
+func (r *VerifyChecksumRequest) Validate() error {
+	if err := validateRequiredFields(r.ApplicationID, r.Platform, r.Architecture); err != nil {
+		return err
+	}
+	if err := validateVersion(r.Version); err != nil {
+		return fmt.Errorf("invalid version: %w", err)
+	}
+	if r.Checksum == "" {
+		return errors.New("checksum is required")
+	}
+	if len(r.Checksum) > maxChecksumLength {
+		return fmt.Errorf("checksum cannot exceed %d characters", maxChecksumLength)
+	}
+	if _, err := hex.DecodeString(r.Checksum); err != nil {
+		return errors.New("checksum must be hex-encoded")
+	}
+	if r.ChecksumType != "" && !isValidChecksumType(r.ChecksumType) {
+		return fmt.Errorf("invalid checksum type: %s", r.ChecksumType)
+	}
+	return nil
+}
+
+func (r *VerifyChecksumRequest) Normalize() {
+	normalizeCommonFields(&r.ApplicationID, &r.Platform, &r.Architecture)
+	r.Version = strings.TrimSpace(r.Version)
+	r.Checksum = strings.ToLower(strings.TrimSpace(r.Checksum))
+	r.ChecksumType = strings.ToLower(strings.TrimSpace(r.ChecksumType))
+}
+
+// VerifyChecksumResponse tells a client whether its download matches the
+// registered release. A client that gets Verified false must discard the file.
+type VerifyChecksumResponse struct {
+	Verified     bool   `json:"verified"`
+	ReleaseID    string `json:"release_id"`
+	ChecksumType string `json:"checksum_type"` // The checksum type that was compared
+	Message      string `json:"message"`
+}
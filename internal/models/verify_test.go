@@ -0,0 +1,47 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyChecksumRequest_Validate(t *testing.T) {
+	valid := func() VerifyChecksumRequest {
+		return VerifyChecksumRequest{ApplicationID: "app", Version: "1.2.0", Platform: "linux", Architecture: "amd64", Checksum: "abc123"}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *VerifyChecksumRequest)
+		wantErr bool
+	}{
+		{name: "minimal", modify: func(r *VerifyChecksumRequest) {}},
+		{name: "checksum type", modify: func(r *VerifyChecksumRequest) { r.ChecksumType = ChecksumTypeSHA512 }},
+		{name: "missing version", modify: func(r *VerifyChecksumRequest) { r.Version = "" }, wantErr: true},
+		{name: "missing checksum", modify: func(r *VerifyChecksumRequest) { r.Checksum = "" }, wantErr: true},
+		{name: "checksum not hex", modify: func(r *VerifyChecksumRequest) { r.Checksum = "xyz" }, wantErr: true},
+		{name: "checksum too long", modify: func(r *VerifyChecksumRequest) { r.Checksum = strings.Repeat("a", 130) }, wantErr: true},
+		{name: "unknown checksum type", modify: func(r *VerifyChecksumRequest) { r.ChecksumType = "crc32" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			err := req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksumRequest_Normalize(t *testing.T) {
+	req := VerifyChecksumRequest{ApplicationID: " app ", Version: " 1.2.0 ", Platform: "Linux", Architecture: "AMD64", Checksum: " ABC123 ", ChecksumType: "SHA256"}
+	req.Normalize()
+	assert.Equal(t, VerifyChecksumRequest{ApplicationID: "app", Version: "1.2.0", Platform: "linux", Architecture: "amd64", Checksum: "abc123", ChecksumType: "sha256"}, req)
+}
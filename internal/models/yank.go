@@ -0,0 +1,33 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A release is yanked when it turns out to be broken after it shipped. A
+// yanked release is no longer offered, like a paused one, and a client that
+// already installed it is offered the newest release older than it that the
+// client would otherwise be offered, marked as a downgrade. Yanking is an
+// operational state: registering the release again keeps it.
+
+// MaxYankReasonLength is the maximum length of the reason a release was yanked.
+const MaxYankReasonLength = 500
+
+// YankReleaseRequest is the optional body of yanking a release.
+type YankReleaseRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// Normalize trims the reason.
+func (r *YankReleaseRequest) Normalize() {
+	r.Reason = strings.TrimSpace(r.Reason)
+}
+
+// Validate checks the normalized request.
+func (r *YankReleaseRequest) Validate() error {
+	if len(r.Reason) > MaxYankReasonLength {
+		return fmt.Errorf("reason exceeds maximum length of %d", MaxYankReasonLength)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package notesdraft asks an external service to draft release notes from
+// the commits sent with a release registration. The service is any HTTP
+// endpoint that accepts the documented request; drafts are stored for review
+// and never published without an admin.
+package notesdraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"updater/internal/models"
+)
+
+// maxResponseSize bounds the drafting service response that is read.
+const maxResponseSize = 64 << 10
+
+// HTTP drafts release notes through an external service. The service
+// receives a POST with a models.ReleaseNotesDraftRequest body and answers 200
+// with {"notes": "..."}.
+type HTTP struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// New creates a drafter from cfg, which is expected to have been validated.
+func New(cfg models.NotesDraftConfig) *HTTP {
+	return NewHTTP(cfg.URL, cfg.Token, &http.Client{Timeout: cfg.Timeout})
+}
+
+// NewHTTP creates a drafter that posts to url with client, sending token as a
+// bearer token when it is not empty.
+func NewHTTP(url, token string, client *http.Client) *HTTP {
+	return &HTTP{url: url, token: token, client: client}
+}
+
+type httpDraftResponse struct {
+	Notes string `json:"notes"`
+}
+
+// Draft asks the service for release notes describing req's commits.
+func (d *HTTP) Draft(ctx context.Context, req *models.ReleaseNotesDraftRequest) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	if d.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+d.token)
+	}
+
+	resp, err := d.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("drafting service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("drafting service returned status %d", resp.StatusCode)
+	}
+
+	var result httpDraftResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&result); err != nil {
+		return "", fmt.Errorf("invalid drafting service response: %w", err)
+	}
+	notes := strings.TrimSpace(result.Notes)
+	if notes == "" {
+		return "", errors.New("drafting service returned empty notes")
+	}
+	return notes, nil
+}
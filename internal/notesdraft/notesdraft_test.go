@@ -0,0 +1,58 @@
+package notesdraft
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPDraft(t *testing.T) {
+	var got models.ReleaseNotesDraftRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer drafts-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		switch got.Version {
+		case "1.0.0":
+			w.Write([]byte(`{"notes": "  - Fixed the crash on startup\n"}`))
+		case "2.0.0":
+			w.Write([]byte(`{"notes": ""}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	drafter := New(models.NotesDraftConfig{Enabled: true, URL: server.URL, Timeout: time.Second, Token: "drafts-token"})
+	req := &models.ReleaseNotesDraftRequest{
+		ApplicationID: "my-app",
+		Version:       "1.0.0",
+		Commits:       []models.CommitInfo{{SHA: "abc123", Message: "Fix crash on startup"}},
+	}
+
+	notes, err := drafter.Draft(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "- Fixed the crash on startup", notes)
+	assert.Equal(t, "my-app", got.ApplicationID)
+	assert.Equal(t, "abc123", got.Commits[0].SHA)
+
+	req.Version = "2.0.0"
+	_, err = drafter.Draft(context.Background(), req)
+	assert.ErrorContains(t, err, "empty notes")
+
+	req.Version = "3.0.0"
+	_, err = drafter.Draft(context.Background(), req)
+	assert.ErrorContains(t, err, "status 502")
+
+	_, err = NewHTTP(server.URL, "", server.Client()).Draft(context.Background(), req)
+	assert.ErrorContains(t, err, "status 401")
+}
@@ -0,0 +1,134 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+	"updater/internal/models"
+)
+
+// healthPingTimeout bounds each storage ping, matching the /health endpoint.
+const healthPingTimeout = 2 * time.Second
+
+// HealthHistory samples service health at a fixed interval: storage ping
+// latency and the share of HTTP requests that failed with a server error since
+// the previous sample. Samples are kept in memory for the retention window, so
+// the history starts again when the process restarts.
+type HealthHistory struct {
+	interval  time.Duration
+	ping      func(context.Context) error
+	startedAt time.Time
+
+	requests     atomic.Int64
+	serverErrors atomic.Int64
+
+	mu      sync.Mutex
+	samples []models.HealthSample
+	next    int
+}
+
+// NewHealthHistory creates a health history that pings storage with ping every
+// interval and keeps retention worth of samples.
+func NewHealthHistory(interval, retention time.Duration, ping func(context.Context) error) *HealthHistory {
+	size := max(int(retention/interval), 1)
+	return &HealthHistory{
+		interval:  interval,
+		ping:      ping,
+		startedAt: time.Now().UTC(),
+		samples:   make([]models.HealthSample, 0, size),
+	}
+}
+
+// Middleware counts completed HTTP requests and server errors for the next
+// sample.
+func (h *HealthHistory) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		h.requests.Add(1)
+		if sw.status >= http.StatusInternalServerError {
+			h.serverErrors.Add(1)
+		}
+	})
+}
+
+// Run takes a sample every interval until ctx is cancelled.
+func (h *HealthHistory) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.sample(ctx)
+		}
+	}
+}
+
+// sample measures storage and the request counters and stores the result,
+// overwriting the oldest sample when the history is full.
+func (h *HealthHistory) sample(ctx context.Context) {
+	s := models.HealthSample{
+		Timestamp:     time.Now().UTC(),
+		Status:        models.StatusHealthy,
+		StorageStatus: models.StatusHealthy,
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+	start := time.Now()
+	err := h.ping(pingCtx)
+	s.StorageLatencyMS = float64(time.Since(start).Microseconds()) / 1000
+	cancel()
+	if err != nil {
+		s.StorageStatus = models.StatusUnhealthy
+		s.Status = models.StatusDegraded
+	}
+
+	s.Requests = h.requests.Swap(0)
+	s.ServerErrors = h.serverErrors.Swap(0)
+	if s.Requests > 0 {
+		s.ErrorRate = float64(s.ServerErrors) / float64(s.Requests)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.samples) < cap(h.samples) {
+		h.samples = append(h.samples, s)
+		return
+	}
+	h.samples[h.next] = s
+	h.next = (h.next + 1) % len(h.samples)
+}
+
+// History returns the samples taken at or after since, oldest first.
+func (h *HealthHistory) History(since time.Time) *models.HealthHistoryResponse {
+	h.mu.Lock()
+	samples := []models.HealthSample{}
+	healthy := 0
+	for i := range h.samples {
+		s := h.samples[(h.next+i)%len(h.samples)]
+		if s.Timestamp.Before(since) {
+			continue
+		}
+		samples = append(samples, s)
+		if s.Status == models.StatusHealthy {
+			healthy++
+		}
+	}
+	h.mu.Unlock()
+
+	resp := &models.HealthHistoryResponse{
+		StartedAt:     h.startedAt,
+		UptimeSeconds: int64(time.Since(h.startedAt).Seconds()),
+		Interval:      h.interval.String(),
+		Availability:  1,
+		Samples:       samples,
+	}
+	if len(samples) > 0 {
+		resp.Availability = float64(healthy) / float64(len(samples))
+	}
+	return resp
+}
@@ -0,0 +1,84 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHistory_Sample(t *testing.T) {
+	var pingErr error
+	history := NewHealthHistory(time.Minute, 2*time.Minute, func(context.Context) error { return pingErr })
+
+	handler := history.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	for _, path := range []string{"/ok", "/ok", "/ok", "/fail"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	history.sample(context.Background())
+	pingErr = errors.New("connection refused")
+	history.sample(context.Background())
+	pingErr = nil
+	history.sample(context.Background())
+
+	resp := history.History(time.Time{})
+	require.Len(t, resp.Samples, 2, "retention holds two samples")
+	assert.Equal(t, "1m0s", resp.Interval)
+
+	degraded, recovered := resp.Samples[0], resp.Samples[1]
+	assert.Equal(t, models.StatusDegraded, degraded.Status)
+	assert.Equal(t, models.StatusUnhealthy, degraded.StorageStatus)
+	assert.Zero(t, degraded.Requests, "counters reset after each sample")
+	assert.Equal(t, models.StatusHealthy, recovered.Status)
+	assert.InDelta(t, 0.5, resp.Availability, 0.001)
+}
+
+func TestHealthHistory_ErrorRate(t *testing.T) {
+	history := NewHealthHistory(time.Minute, time.Hour, func(context.Context) error { return nil })
+	handler := history.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	for _, path := range []string{"/ok", "/ok", "/ok", "/fail"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	history.sample(context.Background())
+
+	samples := history.History(time.Time{}).Samples
+	require.Len(t, samples, 1)
+	assert.Equal(t, int64(4), samples[0].Requests)
+	assert.Equal(t, int64(1), samples[0].ServerErrors)
+	assert.InDelta(t, 0.25, samples[0].ErrorRate, 0.001)
+
+	assert.Empty(t, history.History(time.Now().Add(time.Minute)).Samples)
+}
+
+func TestHealthHistory_Run(t *testing.T) {
+	history := NewHealthHistory(10*time.Millisecond, time.Second, func(context.Context) error { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		history.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return len(history.History(time.Time{}).Samples) > 0 }, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -82,10 +82,21 @@ func (sw *statusWriter) WriteHeader(code int) {
 	sw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController, so handlers
+// can extend their write deadline.
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
 // AppMetrics holds application-level business metrics.
 type AppMetrics struct {
 	UpdateChecks       metric.Int64Counter
 	ReleasesRegistered metric.Int64Counter
+	Panics             metric.Int64Counter
+	RequestsShed       metric.Int64Counter
+	PriorityChecks     metric.Int64Counter
+	ClientsBlocked     metric.Int64Counter
+	ChecksumReports    metric.Int64Counter
 }
 
 // NewAppMetrics creates application-level business metric instruments.
@@ -112,8 +123,53 @@ func NewAppMetrics(provider *Provider) (*AppMetrics, error) {
 		return nil, fmt.Errorf("create releases_registered counter: %w", err)
 	}
 
+	panics, err := meter.Int64Counter("updater_http_panics_total",
+		metric.WithDescription("Total handler panics recovered"),
+		metric.WithUnit("{panic}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create panics counter: %w", err)
+	}
+
+	requestsShed, err := meter.Int64Counter("updater_requests_shed_total",
+		metric.WithDescription("Total requests rejected by the concurrency limiter"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create requests_shed counter: %w", err)
+	}
+
+	priorityChecks, err := meter.Int64Counter("updater_priority_checks_total",
+		metric.WithDescription("Total update checks that offered a required or security release"),
+		metric.WithUnit("{check}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create priority_checks counter: %w", err)
+	}
+
+	clientsBlocked, err := meter.Int64Counter("updater_clients_blocked_total",
+		metric.WithDescription("Total clients temporarily blocked for suspicious update check traffic"),
+		metric.WithUnit("{client}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create clients_blocked counter: %w", err)
+	}
+
+	checksumReports, err := meter.Int64Counter("updater_checksum_verifications_total",
+		metric.WithDescription("Total client checksum reports, by whether the download matched its release"),
+		metric.WithUnit("{report}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create checksum_verifications counter: %w", err)
+	}
+
 	return &AppMetrics{
 		UpdateChecks:       updateChecks,
 		ReleasesRegistered: releasesRegistered,
+		Panics:             panics,
+		RequestsShed:       requestsShed,
+		PriorityChecks:     priorityChecks,
+		ClientsBlocked:     clientsBlocked,
+		ChecksumReports:    checksumReports,
 	}, nil
 }
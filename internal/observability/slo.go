@@ -0,0 +1,261 @@
+package observability
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// sloBuckets is the number of buckets an SLO window is split into; the window
+// slides one bucket at a time.
+const sloBuckets = 10
+
+// Outcomes of a request measured against its route's objective, reported in
+// updater_slo_requests_total.
+const (
+	sloResultGood  = "good"
+	sloResultSlow  = "slow"
+	sloResultError = "error"
+)
+
+// SLOTracker measures requests against the objectives of their routes over a
+// rolling window and exports the burn rate of each. Counts are kept in
+// memory per replica and start again when the process restarts.
+type SLOTracker struct {
+	objectives  []*routeObjective
+	bucket      time.Duration
+	minRequests int64
+	// degradeAt is the burn rate from which a route is degraded, or 0 when
+	// degraded mode is off.
+	degradeAt float64
+	now       func() time.Time
+
+	requests metric.Int64Counter
+	burnRate metric.Float64ObservableGauge
+	degraded metric.Int64ObservableGauge
+	reg      metric.Registration
+}
+
+// routeObjective holds the window of one route's objective.
+type routeObjective struct {
+	models.RouteSLO
+	attrs metric.MeasurementOption
+
+	mu         sync.Mutex
+	buckets    [sloBuckets]sloBucket
+	isDegraded bool
+}
+
+// sloBucket counts the requests of one slice of the window.
+type sloBucket struct {
+	index     int64 // Start of the slice, in bucket widths since the Unix epoch
+	good, bad int64
+}
+
+// NewSLOTracker creates a tracker for the objectives in cfg and registers its
+// metrics with the global meter provider.
+func NewSLOTracker(cfg models.SLOConfig) (*SLOTracker, error) {
+	t := &SLOTracker{
+		bucket:      max(cfg.Window/sloBuckets, time.Millisecond),
+		minRequests: int64(cfg.MinRequests),
+		now:         time.Now,
+	}
+	if cfg.Degrade.Enabled {
+		t.degradeAt = cfg.Degrade.BurnRate
+	}
+	for _, route := range cfg.Routes {
+		method := route.Method
+		if method == "" {
+			method = "any"
+		}
+		t.objectives = append(t.objectives, &routeObjective{
+			RouteSLO: route,
+			attrs: metric.WithAttributeSet(attribute.NewSet(
+				attribute.String("route", route.Route),
+				attribute.String("method", method),
+			)),
+		})
+	}
+	if err := t.initMetrics(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *SLOTracker) initMetrics() error {
+	meter := otel.Meter("updater/slo")
+	var err error
+	if t.requests, err = meter.Int64Counter("updater_slo_requests_total",
+		metric.WithDescription("Requests measured against their route's objective, by result"),
+		metric.WithUnit("{request}"),
+	); err != nil {
+		return err
+	}
+	if t.burnRate, err = meter.Float64ObservableGauge("updater_slo_burn_rate",
+		metric.WithDescription("Share of requests missing the route's objective over the window, divided by the share the objective allows"),
+	); err != nil {
+		return err
+	}
+	if t.degraded, err = meter.Int64ObservableGauge("updater_slo_degraded",
+		metric.WithDescription("1 while the route's burn rate is at or above the degraded mode threshold"),
+	); err != nil {
+		return err
+	}
+	t.reg, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		now := t.now()
+		for _, obj := range t.objectives {
+			burn, ok := t.evaluate(obj, now)
+			if ok {
+				o.ObserveFloat64(t.burnRate, burn, obj.attrs)
+			}
+			var degraded int64
+			if t.isDegraded(obj, burn, ok) {
+				degraded = 1
+			}
+			o.ObserveInt64(t.degraded, degraded, obj.attrs)
+		}
+		return nil
+	}, t.burnRate, t.degraded)
+	return err
+}
+
+// Close unregisters the tracker's gauges.
+func (t *SLOTracker) Close() error {
+	return t.reg.Unregister()
+}
+
+// sloSampleKey carries the sample of a request being measured.
+type sloSampleKey struct{}
+
+// sloSample lets handlers further down the chain exclude a request.
+type sloSample struct {
+	excluded bool
+}
+
+// ExcludeFromSLO stops the request from counting towards its route's
+// objective, for responses that did not exercise the backend, such as one
+// served from cache in degraded mode.
+func ExcludeFromSLO(r *http.Request) {
+	if s, ok := r.Context().Value(sloSampleKey{}).(*sloSample); ok {
+		s.excluded = true
+	}
+}
+
+// Middleware measures requests to routes with an objective.
+func (t *SLOTracker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		obj := t.objective(r.Method, routeTemplate(r))
+		if obj == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		sample := &sloSample{}
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), sloSampleKey{}, sample)))
+		if !sample.excluded {
+			t.record(r.Context(), obj, sw.status, time.Since(start))
+		}
+	})
+}
+
+// Degraded reports whether the route's burn rate has reached the degraded
+// mode threshold. It is always false when degraded mode is off or the window
+// holds fewer than the minimum number of requests.
+func (t *SLOTracker) Degraded(method, route string) bool {
+	obj := t.objective(method, route)
+	if obj == nil || t.degradeAt == 0 {
+		return false
+	}
+	burn, ok := t.evaluate(obj, t.now())
+	return t.isDegraded(obj, burn, ok)
+}
+
+// objective returns the first objective matching the request, or nil.
+func (t *SLOTracker) objective(method, route string) *routeObjective {
+	for _, obj := range t.objectives {
+		if obj.Route == route && (obj.Method == "" || obj.Method == method) {
+			return obj
+		}
+	}
+	return nil
+}
+
+// record counts one request in the current bucket.
+func (t *SLOTracker) record(ctx context.Context, obj *routeObjective, status int, elapsed time.Duration) {
+	result := sloResultGood
+	switch {
+	case status >= http.StatusInternalServerError:
+		result = sloResultError
+	case elapsed > obj.Latency:
+		result = sloResultSlow
+	}
+	t.requests.Add(ctx, 1, obj.attrs, metric.WithAttributes(attribute.String("result", result)))
+
+	index := t.now().UnixNano() / int64(t.bucket)
+	obj.mu.Lock()
+	defer obj.mu.Unlock()
+	b := &obj.buckets[index%sloBuckets]
+	if b.index != index {
+		*b = sloBucket{index: index}
+	}
+	if result == sloResultGood {
+		b.good++
+	} else {
+		b.bad++
+	}
+}
+
+// evaluate returns the burn rate over the window ending at now, and false
+// when the window holds fewer than the minimum number of requests.
+func (t *SLOTracker) evaluate(obj *routeObjective, now time.Time) (float64, bool) {
+	current := now.UnixNano() / int64(t.bucket)
+	var good, bad int64
+	obj.mu.Lock()
+	for _, b := range obj.buckets {
+		if b.index > current-sloBuckets && b.index <= current {
+			good += b.good
+			bad += b.bad
+		}
+	}
+	obj.mu.Unlock()
+	total := good + bad
+	if total == 0 || total < t.minRequests {
+		return 0, false
+	}
+	return float64(bad) / float64(total) / (1 - obj.Objective), true
+}
+
+// isDegraded applies the degraded mode threshold to a burn rate and logs when
+// the route enters or leaves degraded mode.
+func (t *SLOTracker) isDegraded(obj *routeObjective, burn float64, ok bool) bool {
+	degraded := t.degradeAt > 0 && ok && burn >= t.degradeAt
+	obj.mu.Lock()
+	changed := degraded != obj.isDegraded
+	obj.isDegraded = degraded
+	obj.mu.Unlock()
+	if changed && degraded {
+		slog.Warn("SLO burn rate reached the degraded mode threshold", "route", obj.Name(), "burn_rate", burn)
+	} else if changed {
+		slog.Info("SLO burn rate recovered, leaving degraded mode", "route", obj.Name())
+	}
+	return degraded
+}
+
+// routeTemplate returns the path template of the matched route.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return ""
+}
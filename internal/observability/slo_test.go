@@ -0,0 +1,115 @@
+package observability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSLOTracker(t *testing.T, degrade bool) (*SLOTracker, http.Handler) {
+	t.Helper()
+	tracker, err := NewSLOTracker(models.SLOConfig{
+		Enabled:     true,
+		Window:      time.Minute,
+		MinRequests: 10,
+		Routes: []models.RouteSLO{
+			{Route: "/latest/{app_id}", Method: http.MethodGet, Latency: time.Minute, Objective: 0.9},
+			{Route: "/slow", Latency: time.Nanosecond, Objective: 0.9},
+		},
+		Degrade: models.SLODegradeConfig{Enabled: degrade, BurnRate: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tracker.Close() })
+
+	router := mux.NewRouter()
+	router.Use(tracker.Middleware)
+	router.HandleFunc("/latest/{app_id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("result") {
+		case "error":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "cached":
+			ExcludeFromSLO(r)
+			w.WriteHeader(http.StatusInternalServerError)
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond)
+	})
+	return tracker, router
+}
+
+func sendSLORequests(handler http.Handler, method, target string, n int) {
+	for range n {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, target, nil))
+	}
+}
+
+func TestSLOTracker_BurnRate(t *testing.T) {
+	tracker, handler := newTestSLOTracker(t, true)
+	obj := tracker.objective(http.MethodGet, "/latest/{app_id}")
+	require.NotNil(t, obj)
+
+	sendSLORequests(handler, http.MethodGet, "/latest/app?result=error", 1)
+	_, ok := tracker.evaluate(obj, tracker.now())
+	assert.False(t, ok, "a window under min_requests has no burn rate")
+	assert.False(t, tracker.Degraded(http.MethodGet, "/latest/{app_id}"))
+
+	// Client errors meet the objective; 2 errors in 10 requests burn a 10%
+	// budget twice as fast as allowed
+	sendSLORequests(handler, http.MethodGet, "/latest/app?result=missing", 8)
+	sendSLORequests(handler, http.MethodGet, "/latest/app?result=error", 1)
+	burn, ok := tracker.evaluate(obj, tracker.now())
+	require.True(t, ok)
+	assert.InDelta(t, 2, burn, 0.001)
+	assert.True(t, tracker.Degraded(http.MethodGet, "/latest/{app_id}"))
+	assert.False(t, tracker.Degraded(http.MethodPost, "/latest/{app_id}"), "the objective is for GET only")
+
+	sendSLORequests(handler, http.MethodGet, "/latest/app?result=cached", 10)
+	burn, _ = tracker.evaluate(obj, tracker.now())
+	assert.InDelta(t, 2, burn, 0.001, "excluded requests are not counted")
+
+	sendSLORequests(handler, http.MethodGet, "/latest/app", 30)
+	assert.False(t, tracker.Degraded(http.MethodGet, "/latest/{app_id}"), "good requests dilute the bad ones")
+}
+
+func TestSLOTracker_Latency(t *testing.T) {
+	tracker, handler := newTestSLOTracker(t, true)
+	sendSLORequests(handler, http.MethodGet, "/slow", 10)
+
+	burn, ok := tracker.evaluate(tracker.objective(http.MethodGet, "/slow"), tracker.now())
+	require.True(t, ok)
+	assert.InDelta(t, 10, burn, 0.001, "every request missed the latency objective")
+}
+
+func TestSLOTracker_WindowSlides(t *testing.T) {
+	tracker, handler := newTestSLOTracker(t, true)
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+	sendSLORequests(handler, http.MethodGet, "/latest/app?result=error", 10)
+	assert.True(t, tracker.Degraded(http.MethodGet, "/latest/{app_id}"))
+
+	now = now.Add(30 * time.Second)
+	assert.True(t, tracker.Degraded(http.MethodGet, "/latest/{app_id}"))
+
+	now = now.Add(31 * time.Second)
+	assert.False(t, tracker.Degraded(http.MethodGet, "/latest/{app_id}"), "the errors have left the window")
+}
+
+func TestSLOTracker_DegradeDisabled(t *testing.T) {
+	tracker, handler := newTestSLOTracker(t, false)
+	sendSLORequests(handler, http.MethodGet, "/latest/app?result=error", 10)
+
+	burn, ok := tracker.evaluate(tracker.objective(http.MethodGet, "/latest/{app_id}"), tracker.now())
+	require.True(t, ok)
+	assert.InDelta(t, 10, burn, 0.001)
+	assert.False(t, tracker.Degraded(http.MethodGet, "/latest/{app_id}"))
+	assert.False(t, tracker.Degraded(http.MethodGet, "/unknown"))
+}
@@ -88,6 +88,14 @@ func (s *InstrumentedStorage) GetApplication(ctx context.Context, appID string)
 	return result, err
 }
 
+func (s *InstrumentedStorage) GetApplicationBySlug(ctx context.Context, slug string) (*models.Application, error) {
+	ctx, span := s.startSpan(ctx, "GetApplicationBySlug", attribute.String("slug", slug))
+	start := time.Now()
+	result, err := s.inner.GetApplicationBySlug(ctx, slug)
+	s.record(ctx, span, "GetApplicationBySlug", start, err)
+	return result, err
+}
+
 func (s *InstrumentedStorage) SaveApplication(ctx context.Context, app *models.Application) error {
 	ctx, span := s.startSpan(ctx, "SaveApplication", attribute.String("app_id", app.ID))
 	start := time.Now()
@@ -119,6 +127,31 @@ func (s *InstrumentedStorage) GetRelease(ctx context.Context, appID, version, pl
 	return result, err
 }
 
+func (s *InstrumentedStorage) GetReleaseByID(ctx context.Context, id string) (*models.Release, error) {
+	ctx, span := s.startSpan(ctx, "GetReleaseByID",
+		attribute.String("release_id", id),
+	)
+	start := time.Now()
+	result, err := s.inner.GetReleaseByID(ctx, id)
+	s.record(ctx, span, "GetReleaseByID", start, err)
+	return result, err
+}
+
+func (s *InstrumentedStorage) SaveReleases(ctx context.Context, releases []*models.Release) error {
+	attrs := []attribute.KeyValue{attribute.Int("count", len(releases))}
+	if len(releases) > 0 {
+		attrs = append(attrs,
+			attribute.String("app_id", releases[0].ApplicationID),
+			attribute.String("version", releases[0].Version),
+		)
+	}
+	ctx, span := s.startSpan(ctx, "SaveReleases", attrs...)
+	start := time.Now()
+	err := s.inner.SaveReleases(ctx, releases)
+	s.record(ctx, span, "SaveReleases", start, err)
+	return err
+}
+
 func (s *InstrumentedStorage) SaveRelease(ctx context.Context, release *models.Release) error {
 	ctx, span := s.startSpan(ctx, "SaveRelease",
 		attribute.String("app_id", release.ApplicationID),
@@ -222,14 +255,22 @@ func (s *InstrumentedStorage) DeleteAPIKey(ctx context.Context, keyID string) er
 	return err
 }
 
-func (s *InstrumentedStorage) ListApplicationsPaged(ctx context.Context, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
+func (s *InstrumentedStorage) ListApplicationsPaged(ctx context.Context, filters models.ApplicationFilters, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
 	ctx, span := s.startSpan(ctx, "ListApplicationsPaged")
 	start := time.Now()
-	apps, total, err := s.inner.ListApplicationsPaged(ctx, limit, cursor)
+	apps, total, err := s.inner.ListApplicationsPaged(ctx, filters, limit, cursor)
 	s.record(ctx, span, "ListApplicationsPaged", start, err)
 	return apps, total, err
 }
 
+func (s *InstrumentedStorage) ListApplicationGroups(ctx context.Context) ([]models.ApplicationGroup, error) {
+	ctx, span := s.startSpan(ctx, "ListApplicationGroups")
+	start := time.Now()
+	groups, err := s.inner.ListApplicationGroups(ctx)
+	s.record(ctx, span, "ListApplicationGroups", start, err)
+	return groups, err
+}
+
 func (s *InstrumentedStorage) ListReleasesPaged(ctx context.Context, appID string, filters models.ReleaseFilters, sortBy, sortOrder string, limit int, cursor *models.ReleaseCursor) ([]*models.Release, int, error) {
 	ctx, span := s.startSpan(ctx, "ListReleasesPaged", attribute.String("app_id", appID))
 	start := time.Now()
@@ -257,3 +298,33 @@ func (s *InstrumentedStorage) GetApplicationStats(ctx context.Context, appID str
 	s.record(ctx, span, "GetApplicationStats", start, err)
 	return stats, err
 }
+
+func (s *InstrumentedStorage) SaveContainerImage(ctx context.Context, image *models.ContainerImage) error {
+	ctx, span := s.startSpan(ctx, "SaveContainerImage",
+		attribute.String("app_id", image.ApplicationID),
+		attribute.String("tag", image.Tag),
+	)
+	start := time.Now()
+	err := s.inner.SaveContainerImage(ctx, image)
+	s.record(ctx, span, "SaveContainerImage", start, err)
+	return err
+}
+
+func (s *InstrumentedStorage) ListContainerImages(ctx context.Context, appID string) ([]*models.ContainerImage, error) {
+	ctx, span := s.startSpan(ctx, "ListContainerImages", attribute.String("app_id", appID))
+	start := time.Now()
+	images, err := s.inner.ListContainerImages(ctx, appID)
+	s.record(ctx, span, "ListContainerImages", start, err)
+	return images, err
+}
+
+func (s *InstrumentedStorage) DeleteContainerImage(ctx context.Context, appID, tag string) error {
+	ctx, span := s.startSpan(ctx, "DeleteContainerImage",
+		attribute.String("app_id", appID),
+		attribute.String("tag", tag),
+	)
+	start := time.Now()
+	err := s.inner.DeleteContainerImage(ctx, appID, tag)
+	s.record(ctx, span, "DeleteContainerImage", start, err)
+	return err
+}
@@ -67,7 +67,7 @@ func TestInstrumentedStorage_ApplicationOperations(t *testing.T) {
 	ctx := context.Background()
 
 	// SaveApplication
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := time.Now().UTC()
 	app := &models.Application{
 		ID:        "test-app",
 		Name:      "Test App",
@@ -83,7 +83,7 @@ func TestInstrumentedStorage_ApplicationOperations(t *testing.T) {
 	assert.Equal(t, "test-app", result.ID)
 
 	// ListApplicationsPaged
-	apps, total, err := instrumented.ListApplicationsPaged(ctx, 50, nil)
+	apps, total, err := instrumented.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Len(t, apps, 1)
@@ -0,0 +1,55 @@
+// Package security carries the authenticated caller of a request in its
+// context. Middleware stores the caller with NewContext once the request is
+// authenticated, and handlers, audit logging and the service layer read it
+// with FromContext instead of reaching for a context key of their own.
+package security
+
+import (
+	"context"
+	"updater/internal/models"
+)
+
+// contextKey is the context key of the Principal. It is unexported so no
+// other package can read or overwrite the value except through this package.
+type contextKey struct{}
+
+// Principal is the authenticated caller of a request. Attributes of the
+// caller beyond its API key, such as an organisation or scopes, belong here
+// so that every reader picks them up from the same place.
+type Principal struct {
+	APIKey *models.APIKey // The key the request authenticated with
+}
+
+// KeyID returns the ID of the caller's API key, or "" for a nil principal.
+func (p *Principal) KeyID() string {
+	if p == nil || p.APIKey == nil {
+		return ""
+	}
+	return p.APIKey.ID
+}
+
+// HasPermission reports whether the caller's key grants the permission. A nil
+// principal has no permissions.
+func (p *Principal) HasPermission(permission string) bool {
+	return p != nil && p.APIKey != nil && p.APIKey.HasPermission(permission)
+}
+
+// NewContext returns a copy of ctx carrying p.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal stored by NewContext, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// APIKeyFromContext returns the API key of the request's principal, or nil
+// for an unauthenticated request.
+func APIKeyFromContext(ctx context.Context) *models.APIKey {
+	if p, ok := FromContext(ctx); ok {
+		return p.APIKey
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipalContext(t *testing.T) {
+	ctx := context.Background()
+	p, ok := FromContext(ctx)
+	assert.False(t, ok)
+	assert.Nil(t, p)
+	assert.Nil(t, APIKeyFromContext(ctx))
+	assert.False(t, p.HasPermission("read"), "a nil principal has no permissions")
+	assert.Empty(t, p.KeyID())
+
+	key := models.NewAPIKey("key-1", "Reader", "raw-key", []string{"read"})
+	ctx = NewContext(ctx, &Principal{APIKey: key})
+	p, ok = FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "key-1", p.KeyID())
+	assert.Same(t, key, APIKeyFromContext(ctx))
+	assert.True(t, p.HasPermission("read"))
+	assert.False(t, p.HasPermission("write"))
+
+	// Other packages' keys of the same underlying type do not collide
+	type otherKey struct{}
+	ctx = context.WithValue(ctx, otherKey{}, "not a principal")
+	assert.Same(t, key, APIKeyFromContext(ctx))
+
+	_, ok = FromContext(NewContext(context.Background(), nil))
+	assert.False(t, ok, "a nil principal is not authenticated")
+}
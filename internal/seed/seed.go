@@ -0,0 +1,108 @@
+// Package seed creates the applications, releases and API keys of a fixtures
+// file at startup, so local development, demos and integration environments
+// start with data instead of being bootstrapped by hand. Seeding only adds
+// what is missing and never changes or removes existing data.
+package seed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"updater/internal/models"
+	"updater/internal/storage"
+	"updater/internal/update"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads and validates a fixtures file. JSON files are read as YAML.
+// Unknown keys are an error, so a misspelt field is not silently skipped.
+func Load(path string) (*models.SeedFixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures models.SeedFixtures
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fixtures); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := fixtures.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixtures in %s: %w", path, err)
+	}
+	return &fixtures, nil
+}
+
+// Result counts the fixtures that were created and those that already
+// existed.
+type Result struct {
+	Created int
+	Existed int
+}
+
+// Seeder applies fixtures. Applications and releases are created through the
+// update service, so they pass the same validation and policies as API
+// requests; keys are stored directly, as the bootstrap key is.
+type Seeder struct {
+	service update.ServiceInterface
+	storage storage.Storage
+}
+
+// New creates a seeder for service and the storage behind it.
+func New(service update.ServiceInterface, store storage.Storage) *Seeder {
+	return &Seeder{service: service, storage: store}
+}
+
+// Apply creates the applications, then the releases, then the keys of
+// fixtures that do not exist yet, in file order. It stops at the first
+// fixture that cannot be created; what was created before it is kept, and
+// applying the file again continues from there.
+func (s *Seeder) Apply(ctx context.Context, fixtures *models.SeedFixtures) (Result, error) {
+	var result Result
+
+	for _, app := range fixtures.Applications {
+		if _, err := s.storage.GetApplication(ctx, app.ID); err == nil {
+			result.Existed++
+			continue
+		}
+		if _, err := s.service.CreateApplication(ctx, app.CreateRequest()); err != nil {
+			return result, fmt.Errorf("application %s: %w", app.ID, err)
+		}
+		slog.Info("Seeded application", "app_id", app.ID)
+		result.Created++
+	}
+
+	for _, manifest := range fixtures.Releases {
+		for _, req := range manifest.RegisterRequests() {
+			req.Normalize()
+			if _, err := s.storage.GetRelease(ctx, req.ApplicationID, req.Version, req.Platform, req.Architecture); err == nil {
+				result.Existed++
+				continue
+			}
+			if _, err := s.service.RegisterRelease(ctx, &req); err != nil {
+				return result, fmt.Errorf("release %s %s %s/%s: %w", req.ApplicationID, req.Version, req.Platform, req.Architecture, err)
+			}
+			slog.Info("Seeded release", "app_id", req.ApplicationID, "version", req.Version,
+				"platform", req.Platform, "architecture", req.Architecture)
+			result.Created++
+		}
+	}
+
+	for _, k := range fixtures.APIKeys {
+		if _, err := s.storage.GetAPIKeyByHash(ctx, models.HashAPIKey(k.Key)); err == nil {
+			result.Existed++
+			continue
+		}
+		key := models.NewAPIKey(models.NewKeyID(), k.Name, k.Key, k.Permissions)
+		if err := s.storage.CreateAPIKey(ctx, key); err != nil {
+			return result, fmt.Errorf("api key %s: %w", k.Name, err)
+		}
+		slog.Info("Seeded API key", "id", key.ID, "name", key.Name, "prefix", key.Prefix)
+		result.Created++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/storage"
+	"updater/internal/update"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFixtures = `
+applications:
+  - id: demo-app
+    name: Demo App
+    platforms: [linux, windows]
+releases:
+  - application_id: demo-app
+    version: 1.0.0
+    release_notes: First release
+    artifacts:
+      - platform: linux
+        architecture: amd64
+        download_url: https://example.com/demo-app-1.0.0-linux-amd64.tar.gz
+        checksum: 0000000000000000000000000000000000000000000000000000000000000000
+        checksum_type: sha256
+        file_size: 1024
+      - platform: windows
+        architecture: amd64
+        download_url: https://example.com/demo-app-1.0.0-windows-amd64.zip
+        checksum: 1111111111111111111111111111111111111111111111111111111111111111
+        checksum_type: sha256
+        file_size: 2048
+api_keys:
+  - name: demo-publisher
+    key: upd_demo_publisher_key
+    permissions: [write]
+`
+
+func writeFixtures(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	fixtures, err := Load(writeFixtures(t, testFixtures))
+	require.NoError(t, err)
+	require.Len(t, fixtures.Applications, 1)
+	assert.Equal(t, []string{"linux", "windows"}, fixtures.Applications[0].Platforms)
+	require.Len(t, fixtures.Releases, 1)
+	assert.Len(t, fixtures.Releases[0].Artifacts, 2)
+	require.Len(t, fixtures.APIKeys, 1)
+	assert.Equal(t, "upd_demo_publisher_key", fixtures.APIKeys[0].Key)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown key", content: "applications:\n  - id: a\n    nmae: A\n", wantErr: "nmae"},
+		{name: "duplicate application", content: "applications:\n  - id: a\n  - id: a\n", wantErr: "duplicates applications[0]"},
+		{name: "key without permissions", content: "api_keys:\n  - name: k\n    key: secret\n", wantErr: "permissions is required"},
+		{name: "release without artifacts", content: "releases:\n  - application_id: a\n    version: 1.0.0\n", wantErr: "releases[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeFixtures(t, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSeeder_Apply(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	seeder := New(update.NewService(store), store)
+	fixtures, err := Load(writeFixtures(t, testFixtures))
+	require.NoError(t, err)
+
+	result, err := seeder.Apply(ctx, fixtures)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Created: 4}, result)
+
+	app, err := store.GetApplication(ctx, "demo-app")
+	require.NoError(t, err)
+	assert.Equal(t, "Demo App", app.Name)
+	release, err := store.GetRelease(ctx, "demo-app", "1.0.0", "windows", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "First release", release.ReleaseNotes)
+	key, err := store.GetAPIKeyByHash(ctx, models.HashAPIKey("upd_demo_publisher_key"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"write"}, key.Permissions)
+
+	// Applying again creates nothing and leaves edits alone
+	app.Name = "Renamed"
+	require.NoError(t, store.SaveApplication(ctx, app))
+	result, err = seeder.Apply(ctx, fixtures)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Existed: 4}, result)
+	app, err = store.GetApplication(ctx, "demo-app")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", app.Name)
+	keys, err := store.ListAPIKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestSeeder_ApplyStopsAtInvalidFixture(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	fixtures := &models.SeedFixtures{
+		Applications: []models.SeedApplication{{ID: "demo-app", Name: "Demo App", Platforms: []string{"linux"}}},
+		Releases: []models.ReleaseManifest{{
+			ApplicationID: "demo-app",
+			Version:       "1.0.0",
+			Artifacts: []models.ManifestArtifact{{
+				Platform:     "windows",
+				Architecture: "amd64",
+				DownloadURL:  "https://example.com/demo-app.zip",
+				Checksum:     "0000000000000000000000000000000000000000000000000000000000000000",
+				ChecksumType: "sha256",
+				FileSize:     1024,
+			}},
+		}},
+	}
+
+	result, err := New(update.NewService(store), store).Apply(ctx, fixtures)
+	assert.ErrorContains(t, err, "does not support platform windows")
+	assert.Equal(t, Result{Created: 1}, result)
+}
+
+func TestLoad_ExampleFixtures(t *testing.T) {
+	// The shipped example must stay loadable and apply cleanly
+	fixtures, err := Load(filepath.Join("..", "..", "examples", "fixtures.yaml"))
+	require.NoError(t, err)
+
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	result, err := New(update.NewService(store), store).Apply(context.Background(), fixtures)
+	require.NoError(t, err)
+	assert.Equal(t, 6, result.Created)
+}
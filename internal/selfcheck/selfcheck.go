@@ -0,0 +1,236 @@
+// Package selfcheck probes the dependencies the server needs at startup, so a
+// bad DSN, an unmigrated database or an expired certificate shows up in the
+// boot log instead of on the first request.
+package selfcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+	"updater/internal/blob"
+	"updater/internal/models"
+	"updater/internal/storage"
+)
+
+// Status is the outcome of one check.
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn" // Worth attention, never stops startup
+	StatusFail Status = "fail" // Hard failure; stops startup when configured to
+	StatusSkip Status = "skip" // Not applicable to this configuration
+)
+
+// Result is the outcome of one named check.
+type Result struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Message  string        `json:"message,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the outcome of every check, in the order they ran.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Failed returns the checks that failed.
+func (r Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if result.Status == StatusFail {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Log writes one structured line per check and a summary line.
+func (r Report) Log(logger *slog.Logger) {
+	counts := make(map[Status]int)
+	for _, result := range r.Results {
+		counts[result.Status]++
+		level := slog.LevelInfo
+		switch result.Status {
+		case StatusWarn:
+			level = slog.LevelWarn
+		case StatusFail:
+			level = slog.LevelError
+		}
+		logger.Log(context.Background(), level, "Startup self-check",
+			"check", result.Name,
+			"status", result.Status,
+			"message", result.Message,
+			"duration", result.Duration)
+	}
+	logger.Info("Startup self-check complete",
+		"ok", counts[StatusOK],
+		"warn", counts[StatusWarn],
+		"fail", counts[StatusFail],
+		"skip", counts[StatusSkip])
+}
+
+// Checker runs the self-check against a loaded configuration and storage.
+type Checker struct {
+	cfg       *models.Config
+	store     storage.Storage
+	now       func() time.Time
+	buildDate string
+}
+
+// Option configures a Checker.
+type Option func(*Checker)
+
+// WithClock sets the time source of the clock and certificate checks.
+func WithClock(now func() time.Time) Option {
+	return func(c *Checker) { c.now = now }
+}
+
+// WithBuildDate sets the binary's RFC 3339 build date, which the clock check
+// treats as the earliest plausible time. Without it, or when it does not
+// parse, the clock check is skipped.
+func WithBuildDate(buildDate string) Option {
+	return func(c *Checker) { c.buildDate = buildDate }
+}
+
+// New returns a Checker for cfg and store.
+func New(cfg *models.Config, store storage.Storage, opts ...Option) *Checker {
+	c := &Checker{cfg: cfg, store: store, now: time.Now}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run runs every check within the configured self-check timeout. Checks never
+// stop each other, so the report shows every problem at once.
+func (c *Checker) Run(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Server.SelfCheck.Timeout)
+	defer cancel()
+
+	checks := []struct {
+		name string
+		fn   func(context.Context) (Status, string)
+	}{
+		{"storage.reachable", c.checkStorage},
+		{"storage.migrations", c.checkMigrations},
+		{"tls.certificate", c.checkCertificate},
+		{"artifacts.writable", c.checkArtifacts},
+		{"clock", c.checkClock},
+	}
+	var report Report
+	for _, check := range checks {
+		start := time.Now()
+		status, message := check.fn(ctx)
+		report.Results = append(report.Results, Result{
+			Name:     check.name,
+			Status:   status,
+			Message:  message,
+			Duration: time.Since(start),
+		})
+	}
+	return report
+}
+
+// checkStorage pings the storage backend.
+func (c *Checker) checkStorage(ctx context.Context) (Status, string) {
+	if err := c.store.Ping(ctx); err != nil {
+		return StatusFail, fmt.Sprintf("storage is not reachable: %v", err)
+	}
+	return StatusOK, ""
+}
+
+// checkMigrations verifies that the database schema is up to date.
+func (c *Checker) checkMigrations(ctx context.Context) (Status, string) {
+	schema, ok := c.store.(storage.SchemaChecker)
+	if !ok {
+		return StatusSkip, fmt.Sprintf("%s storage has no schema", c.cfg.Storage.Type)
+	}
+	pending, err := schema.HasPendingMigrations(ctx)
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	if pending {
+		return StatusFail, "database has unapplied migrations; run the migrate command with up"
+	}
+	return StatusOK, ""
+}
+
+// checkCertificate verifies that the TLS certificate is currently valid and
+// warns when it expires soon.
+func (c *Checker) checkCertificate(context.Context) (Status, string) {
+	server := c.cfg.Server
+	if !server.TLSEnabled {
+		return StatusSkip, "TLS is disabled"
+	}
+	pair, err := tls.LoadX509KeyPair(server.TLSCertFile, server.TLSKeyFile)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("cannot load TLS key pair: %v", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return StatusFail, fmt.Sprintf("cannot parse TLS certificate: %v", err)
+	}
+
+	now := c.now()
+	switch {
+	case now.Before(cert.NotBefore):
+		return StatusFail, fmt.Sprintf("TLS certificate is not valid until %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	case now.After(cert.NotAfter):
+		return StatusFail, fmt.Sprintf("TLS certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	case cert.NotAfter.Sub(now) < server.SelfCheck.CertExpiryWarn:
+		return StatusWarn, fmt.Sprintf("TLS certificate expires at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return StatusOK, fmt.Sprintf("TLS certificate expires at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+}
+
+// checkArtifacts writes a probe object to the artifact store and deletes it
+// again, so a missing bucket, wrong credentials or a read-only directory show
+// up before the first upload.
+func (c *Checker) checkArtifacts(ctx context.Context) (Status, string) {
+	cfg := c.cfg.Artifacts
+	if !cfg.Enabled {
+		return StatusSkip, "artifact uploads are disabled"
+	}
+	store, err := blob.New(cfg)
+	if err != nil {
+		return StatusFail, fmt.Sprintf("cannot open %s artifact store: %v", cfg.Driver, err)
+	}
+
+	body := []byte("updater self-check\n")
+	sum := sha256.Sum256(body)
+	key := fmt.Sprintf("selfcheck/probe-%d", c.now().UnixNano())
+	if err := store.Put(ctx, key, blob.Object{
+		Body:   bytes.NewReader(body),
+		Size:   int64(len(body)),
+		SHA256: hex.EncodeToString(sum[:]),
+	}); err != nil {
+		return StatusFail, fmt.Sprintf("cannot write to %s artifact store: %v", cfg.Driver, err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		return StatusWarn, fmt.Sprintf("cannot delete probe object %s: %v", key, err)
+	}
+	return StatusOK, ""
+}
+
+// checkClock fails when the system clock is earlier than the build date, as
+// on hosts that boot without a real-time clock. Such a clock breaks
+// certificate validation and release dates.
+func (c *Checker) checkClock(context.Context) (Status, string) {
+	built, err := time.Parse(time.RFC3339, c.buildDate)
+	if err != nil {
+		return StatusSkip, "build date is unknown"
+	}
+	if now := c.now(); now.Before(built) {
+		return StatusFail, fmt.Sprintf("system clock %s is earlier than the build date %s",
+			now.UTC().Format(time.RFC3339), built.UTC().Format(time.RFC3339))
+	}
+	return StatusOK, ""
+}
@@ -0,0 +1,179 @@
+package selfcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unreachableStorage fails every ping.
+type unreachableStorage struct {
+	storage.Storage
+}
+
+func (unreachableStorage) Ping(context.Context) error {
+	return errors.New("connection refused")
+}
+
+// writeCert writes a self-signed certificate valid from notBefore to notAfter
+// and its key, and returns their paths.
+func writeCert(t *testing.T, notBefore, notAfter time.Time) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certPath, keyPath
+}
+
+func resultByName(t *testing.T, report Report, name string) Result {
+	t.Helper()
+	for _, result := range report.Results {
+		if result.Name == name {
+			return result
+		}
+	}
+	t.Fatalf("no %s check in report", name)
+	return Result{}
+}
+
+func TestChecker_Run_MemoryStorage(t *testing.T) {
+	cfg := models.NewDefaultConfig()
+	cfg.Storage.Type = "memory"
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+
+	report := New(cfg, store).Run(context.Background())
+
+	assert.Empty(t, report.Failed())
+	assert.Equal(t, StatusOK, resultByName(t, report, "storage.reachable").Status)
+	assert.Equal(t, StatusSkip, resultByName(t, report, "storage.migrations").Status)
+	assert.Equal(t, StatusSkip, resultByName(t, report, "tls.certificate").Status)
+	assert.Equal(t, StatusSkip, resultByName(t, report, "artifacts.writable").Status)
+	assert.Equal(t, StatusSkip, resultByName(t, report, "clock").Status)
+}
+
+func TestChecker_Artifacts(t *testing.T) {
+	writable := t.TempDir()
+	notDir := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(notDir, nil, 0o600))
+
+	tests := []struct {
+		name string
+		dir  string
+		want Status
+	}{
+		{"writable", writable, StatusOK},
+		{"not a directory", notDir, StatusFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := models.NewDefaultConfig()
+			cfg.Artifacts.Enabled = true
+			cfg.Artifacts.Driver = models.ArtifactDriverLocal
+			cfg.Artifacts.Local.Path = tt.dir
+			cfg.Artifacts.PublicURL = "https://updates.example.com/artifacts"
+
+			status, message := New(cfg, nil).checkArtifacts(context.Background())
+			assert.Equal(t, tt.want, status, message)
+		})
+	}
+
+	entries, err := os.ReadDir(filepath.Join(writable, "selfcheck"))
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the probe object is deleted")
+}
+
+func TestChecker_Run_Failures(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	certPath, keyPath := writeCert(t, now.Add(-48*time.Hour), now.Add(-time.Hour))
+
+	cfg := models.NewDefaultConfig()
+	cfg.Server.TLSEnabled = true
+	cfg.Server.TLSCertFile = certPath
+	cfg.Server.TLSKeyFile = keyPath
+
+	report := New(cfg, unreachableStorage{},
+		WithClock(func() time.Time { return now }),
+		WithBuildDate("2026-11-01T00:00:00Z"),
+	).Run(context.Background())
+
+	failed := report.Failed()
+	require.Len(t, failed, 3)
+	assert.Contains(t, resultByName(t, report, "storage.reachable").Message, "connection refused")
+	assert.Contains(t, resultByName(t, report, "tls.certificate").Message, "expired")
+	assert.Contains(t, resultByName(t, report, "clock").Message, "earlier than the build date")
+}
+
+func TestChecker_CertificateExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		want      Status
+	}{
+		{"valid", now.Add(-time.Hour), now.Add(90 * 24 * time.Hour), StatusOK},
+		{"expires soon", now.Add(-time.Hour), now.Add(3 * 24 * time.Hour), StatusWarn},
+		{"not yet valid", now.Add(time.Hour), now.Add(90 * 24 * time.Hour), StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certPath, keyPath := writeCert(t, tt.notBefore, tt.notAfter)
+			cfg := models.NewDefaultConfig()
+			cfg.Server.TLSEnabled = true
+			cfg.Server.TLSCertFile = certPath
+			cfg.Server.TLSKeyFile = keyPath
+
+			status, _ := New(cfg, nil, WithClock(func() time.Time { return now })).checkCertificate(context.Background())
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestReport_Log(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	Report{Results: []Result{
+		{Name: "storage.reachable", Status: StatusOK},
+		{Name: "storage.migrations", Status: StatusFail, Message: "database has unapplied migrations"},
+	}}.Log(logger)
+
+	out := buf.String()
+	assert.Contains(t, out, `"level":"ERROR","msg":"Startup self-check","check":"storage.migrations"`)
+	assert.Contains(t, out, `"msg":"Startup self-check complete","ok":1,"warn":0,"fail":1,"skip":0`)
+}
@@ -0,0 +1,317 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gcpKMSEndpoint      = "https://cloudkms.googleapis.com/v1/"
+	gcpKMSScope         = "https://www.googleapis.com/auth/cloudkms"
+	gcpKMSAlgorithm     = "EC_SIGN_ED25519"
+	gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	gcpRequestTimeout   = 10 * time.Second
+	// tokenRefreshMargin renews an access token this long before it expires.
+	tokenRefreshMargin = time.Minute
+	// maxGCPResponseSize bounds the KMS and token responses that are read.
+	maxGCPResponseSize = 64 << 10
+)
+
+// GCPKMS signs with an Ed25519 key version in Google Cloud KMS
+// (EC_SIGN_ED25519), named by its resource name:
+// projects/P/locations/L/keyRings/R/cryptoKeys/K/cryptoKeyVersions/V.
+//
+// Requests are authorised as the service account in the file named by
+// GOOGLE_APPLICATION_CREDENTIALS, or otherwise as the instance's service
+// account from the metadata server, as on GKE, Cloud Run and Compute Engine.
+// The account needs roles/cloudkms.signerVerifier on the key.
+type GCPKMS struct {
+	name     string
+	endpoint string
+	client   *http.Client
+	tokens   tokenSource
+	pub      ed25519.PublicKey
+}
+
+// NewGCPKMS opens the key version name and fetches its public key. A nil
+// client uses one with a 10 second timeout.
+func NewGCPKMS(ctx context.Context, name string, client *http.Client) (*GCPKMS, error) {
+	if client == nil {
+		client = &http.Client{Timeout: gcpRequestTimeout}
+	}
+	tokens, err := googleTokenSource(client)
+	if err != nil {
+		return nil, err
+	}
+	return newGCPKMS(ctx, name, client, gcpKMSEndpoint, tokens)
+}
+
+func newGCPKMS(ctx context.Context, name string, client *http.Client, endpoint string, tokens tokenSource) (*GCPKMS, error) {
+	k := &GCPKMS{name: name, endpoint: endpoint, client: client, tokens: tokens}
+	var resp struct {
+		PEM       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := k.call(ctx, http.MethodGet, name+"/publicKey", nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Algorithm != gcpKMSAlgorithm {
+		return nil, fmt.Errorf("key algorithm is %s, want %s", resp.Algorithm, gcpKMSAlgorithm)
+	}
+	pub, err := parsePublicKeyPEM([]byte(resp.PEM))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key from Cloud KMS: %w", err)
+	}
+	k.pub = pub
+	return k, nil
+}
+
+// Public returns the key version's public key.
+func (k *GCPKMS) Public() ed25519.PublicKey {
+	return k.pub
+}
+
+// Sign asks Cloud KMS to sign message. The signature is checked against the
+// public key before it is returned, so a corrupted response is never used.
+func (k *GCPKMS) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	var resp struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := k.call(ctx, http.MethodPost, k.name+":asymmetricSign", map[string][]byte{"data": message}, &resp); err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(k.pub, message, resp.Signature) {
+		return nil, errors.New("cloud KMS returned a signature that does not verify")
+	}
+	return resp.Signature, nil
+}
+
+// call sends a Cloud KMS request and decodes its JSON response into out.
+func (k *GCPKMS) call(ctx context.Context, method, path string, body, out any) error {
+	token, err := k.tokens.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get Google access token: %w", err)
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, k.endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cloud KMS request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, maxGCPResponseSize)).Decode(&failure)
+		return fmt.Errorf("cloud KMS returned status %d: %s", resp.StatusCode, failure.Error.Message)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxGCPResponseSize)).Decode(out); err != nil {
+		return fmt.Errorf("invalid cloud KMS response: %w", err)
+	}
+	return nil
+}
+
+// tokenSource hands out OAuth2 access tokens for Google APIs.
+type tokenSource interface {
+	token(ctx context.Context) (string, error)
+}
+
+// cachedTokens reuses an access token until shortly before it expires.
+type cachedTokens struct {
+	fetch func(ctx context.Context) (token string, expiresIn time.Duration, err error)
+	now   func() time.Time
+
+	mu     sync.Mutex
+	cached string
+	expiry time.Time
+}
+
+func (c *cachedTokens) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached != "" && c.now().Before(c.expiry.Add(-tokenRefreshMargin)) {
+		return c.cached, nil
+	}
+	token, expiresIn, err := c.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.cached, c.expiry = token, c.now().Add(expiresIn)
+	return token, nil
+}
+
+// googleTokenSource returns tokens for the service account in
+// GOOGLE_APPLICATION_CREDENTIALS, or from the metadata server when it is
+// unset.
+func googleTokenSource(client *http.Client) (tokenSource, error) {
+	path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if path == "" {
+		return &cachedTokens{fetch: metadataTokens(client, gcpMetadataTokenURL), now: time.Now}, nil
+	}
+	account, err := loadServiceAccount(path)
+	if err != nil {
+		return nil, fmt.Errorf("GOOGLE_APPLICATION_CREDENTIALS: %w", err)
+	}
+	return &cachedTokens{fetch: account.tokens(client, time.Now), now: time.Now}, nil
+}
+
+// tokenResponse is the OAuth2 token response of both the metadata server and
+// the token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// decodeToken reads a token response.
+func decodeToken(resp *http.Response) (string, time.Duration, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("token request returned status %d", resp.StatusCode)
+	}
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxGCPResponseSize)).Decode(&token); err != nil {
+		return "", 0, fmt.Errorf("invalid token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", 0, errors.New("token response has no access_token")
+	}
+	return token.AccessToken, time.Duration(token.ExpiresIn) * time.Second, nil
+}
+
+// metadataTokens fetches the instance service account's token from the
+// metadata server at tokenURL.
+func metadataTokens(client *http.Client, tokenURL string) func(ctx context.Context) (string, time.Duration, error) {
+	return func(ctx context.Context) (string, time.Duration, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+		if err != nil {
+			return "", 0, err
+		}
+		req.Header.Set("Metadata-Flavor", "Google")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", 0, fmt.Errorf("metadata server request failed: %w", err)
+		}
+		return decodeToken(resp)
+	}
+}
+
+// serviceAccount is a Google service account key file.
+type serviceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+	key         *rsa.PrivateKey
+}
+
+func loadServiceAccount(path string) (*serviceAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var account serviceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("invalid service account file: %w", err)
+	}
+	if account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, errors.New("service account file needs client_email and token_uri")
+	}
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, errors.New("service account private_key is not PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account private_key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("service account private_key is %T, want RSA", parsed)
+	}
+	account.key = key
+	return &account, nil
+}
+
+// tokens exchanges a signed JWT assertion for an access token (RFC 7523).
+func (a *serviceAccount) tokens(client *http.Client, now func() time.Time) func(ctx context.Context) (string, time.Duration, error) {
+	return func(ctx context.Context) (string, time.Duration, error) {
+		assertion, err := a.assertion(now())
+		if err != nil {
+			return "", 0, err
+		}
+		form := url.Values{
+			"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+			"assertion":  {assertion},
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.TokenURI, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", 0, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", 0, fmt.Errorf("token request failed: %w", err)
+		}
+		return decodeToken(resp)
+	}
+}
+
+// assertion builds the RS256 JWT a service account trades for a token.
+func (a *serviceAccount) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iss":   a.ClientEmail,
+		"scope": gcpKMSScope,
+		"aud":   a.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,136 @@
+// Package signing makes Ed25519 signatures for the service's signed
+// documents. A Signer hides where the private key lives: in a key file read
+// at startup, or in a cloud key management service that signs on the
+// service's behalf so the key never reaches the host.
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"updater/internal/models"
+)
+
+// Signer makes Ed25519 signatures with a key it may not hold itself.
+type Signer interface {
+	// Public returns the key signatures are verified with.
+	Public() ed25519.PublicKey
+	// Sign signs message. Remote signers honour ctx.
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// New opens the signer cfg names: a key file, or a Google Cloud KMS key
+// version, whose public key is fetched before New returns.
+func New(ctx context.Context, cfg models.SigningKeyConfig) (Signer, error) {
+	switch {
+	case cfg.File != "":
+		key, err := LoadKey(cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.File, err)
+		}
+		return NewLocal(key), nil
+	case cfg.GCPKMSKey != "":
+		signer, err := NewGCPKMS(ctx, cfg.GCPKMSKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.GCPKMSKey, err)
+		}
+		return signer, nil
+	default:
+		return nil, errors.New("no signing key configured")
+	}
+}
+
+// Local signs with a private key held in memory.
+type Local struct {
+	key ed25519.PrivateKey
+}
+
+// NewLocal creates a signer for key.
+func NewLocal(key ed25519.PrivateKey) *Local {
+	return &Local{key: key}
+}
+
+// Public returns the public half of the key.
+func (l *Local) Public() ed25519.PublicKey {
+	return l.key.Public().(ed25519.PublicKey)
+}
+
+// Sign signs message with the key.
+func (l *Local) Sign(_ context.Context, message []byte) ([]byte, error) {
+	return ed25519.Sign(l.key, message), nil
+}
+
+// LoadKey reads a PEM-encoded PKCS#8 Ed25519 private key.
+func LoadKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("no PEM PRIVATE KEY block found")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key is %T, want an Ed25519 key", parsed)
+	}
+	return key, nil
+}
+
+// GenerateKey creates an Ed25519 key and returns it PEM-encoded as PKCS#8,
+// the format LoadKey reads.
+func GenerateKey() (ed25519.PrivateKey, []byte, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// KeyID identifies a public key by the first 8 bytes of its SHA-256 hash, in
+// hex.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// PublicKeyPEM encodes pub as a PEM-encoded PKIX public key.
+func PublicKeyPEM(pub ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// parsePublicKeyPEM parses a PEM-encoded PKIX Ed25519 public key.
+func parsePublicKeyPEM(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("no PEM PUBLIC KEY block found")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("key is %T, want an Ed25519 key", parsed)
+	}
+	return pub, nil
+}
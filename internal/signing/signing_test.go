@@ -0,0 +1,220 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyName = "projects/p/locations/global/keyRings/updater/cryptoKeys/bundle/cryptoKeyVersions/1"
+
+// staticToken is a token source that always returns the same token.
+type staticToken string
+
+func (s staticToken) token(context.Context) (string, error) { return string(s), nil }
+
+// fakeKMS serves the Cloud KMS publicKey and asymmetricSign methods for key.
+func fakeKMS(t *testing.T, key ed25519.PrivateKey) *httptest.Server {
+	t.Helper()
+	pub, err := PublicKeyPEM(key.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"missing credentials"}}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/"+testKeyName+"/publicKey":
+			_ = json.NewEncoder(w).Encode(map[string]string{"pem": string(pub), "algorithm": gcpKMSAlgorithm})
+		case r.Method == http.MethodPost && r.URL.Path == "/"+testKeyName+":asymmetricSign":
+			var req struct {
+				Data []byte `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"signature": ed25519.Sign(key, req.Data)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"key not found"}}`))
+		}
+	}))
+}
+
+func TestLocal(t *testing.T) {
+	key, encoded, err := GenerateKey()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, encoded, 0600))
+
+	signer, err := New(context.Background(), models.SigningKeyConfig{File: path})
+	require.NoError(t, err)
+	assert.True(t, key.Public().(ed25519.PublicKey).Equal(signer.Public()))
+	signature, err := signer.Sign(context.Background(), []byte("message"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(signer.Public(), []byte("message"), signature))
+}
+
+func TestLoadKey(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "key.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a key"), 0600))
+	_, err := LoadKey(notPEM)
+	assert.Error(t, err)
+
+	_, err = New(context.Background(), models.SigningKeyConfig{File: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorContains(t, err, "missing.pem")
+
+	_, err = New(context.Background(), models.SigningKeyConfig{})
+	assert.Error(t, err)
+}
+
+func TestGCPKMS(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	server := fakeKMS(t, key)
+	defer server.Close()
+
+	signer, err := newGCPKMS(context.Background(), testKeyName, server.Client(), server.URL+"/", staticToken("test-token"))
+	require.NoError(t, err)
+	assert.True(t, key.Public().(ed25519.PublicKey).Equal(signer.Public()))
+
+	signature, err := signer.Sign(context.Background(), []byte("message"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(signer.Public(), []byte("message"), signature))
+}
+
+func TestGCPKMS_Errors(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	server := fakeKMS(t, key)
+	defer server.Close()
+
+	_, err = newGCPKMS(context.Background(), testKeyName, server.Client(), server.URL+"/", staticToken("wrong"))
+	assert.ErrorContains(t, err, "status 401: missing credentials")
+
+	_, err = newGCPKMS(context.Background(), "projects/p/locations/global/keyRings/updater/cryptoKeys/other/cryptoKeyVersions/1",
+		server.Client(), server.URL+"/", staticToken("test-token"))
+	assert.ErrorContains(t, err, "key not found")
+
+	// A signature by another key is caught before it is used
+	signer, err := newGCPKMS(context.Background(), testKeyName, server.Client(), server.URL+"/", staticToken("test-token"))
+	require.NoError(t, err)
+	other, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer.pub = other
+	_, err = signer.Sign(context.Background(), []byte("message"))
+	assert.ErrorContains(t, err, "does not verify")
+}
+
+func TestGCPKMS_RejectsOtherAlgorithms(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"pem": "", "algorithm": "EC_SIGN_P256_SHA256"})
+	}))
+	defer server.Close()
+
+	_, err := newGCPKMS(context.Background(), testKeyName, server.Client(), server.URL+"/", staticToken("test-token"))
+	assert.ErrorContains(t, err, "want EC_SIGN_ED25519")
+}
+
+func TestCachedTokens(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	fetches := 0
+	tokens := &cachedTokens{
+		fetch: func(context.Context) (string, time.Duration, error) {
+			fetches++
+			return "token", time.Hour, nil
+		},
+		now: func() time.Time { return now },
+	}
+
+	for range 2 {
+		token, err := tokens.token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token", token)
+	}
+	assert.Equal(t, 1, fetches)
+
+	now = now.Add(59 * time.Minute)
+	_, err := tokens.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches, "the token is renewed shortly before it expires")
+}
+
+func TestMetadataTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"instance-token","expires_in":3599,"token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	token, expiresIn, err := metadataTokens(server.Client(), server.URL)(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "instance-token", token)
+	assert.Equal(t, 3599*time.Second, expiresIn)
+}
+
+func TestServiceAccountTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+
+	var assertion string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+		assertion = r.PostForm.Get("assertion")
+		_, _ = w.Write([]byte(`{"access_token":"account-token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "account.json")
+	account, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "updater@p.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    server.URL,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, account, 0600))
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+
+	tokens, err := googleTokenSource(server.Client())
+	require.NoError(t, err)
+	token, err := tokens.token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "account-token", token)
+	parts := strings.Split(assertion, ".")
+	require.Len(t, parts, 3, "the assertion is a JWT")
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], signature))
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(claims), gcpKMSScope)
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(t.TempDir(), "missing.json"))
+	_, err = googleTokenSource(server.Client())
+	assert.ErrorContains(t, err, "GOOGLE_APPLICATION_CREDENTIALS")
+}
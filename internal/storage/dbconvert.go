@@ -87,6 +87,143 @@ func unmarshalMetadataFromString(data string) (map[string]string, error) {
 	return unmarshalMetadata([]byte(data))
 }
 
+// marshalChecksums converts a release's additional checksums to JSON bytes.
+func marshalChecksums(checksums map[string]string) ([]byte, error) {
+	return marshalMetadata(checksums)
+}
+
+// unmarshalChecksums converts JSON bytes to additional checksums, returning
+// nil when there are none so single-checksum releases round-trip unchanged.
+func unmarshalChecksums(data []byte) (map[string]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var checksums map[string]string
+	if err := json.Unmarshal(data, &checksums); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checksums: %w", err)
+	}
+	if len(checksums) == 0 {
+		return nil, nil
+	}
+	return checksums, nil
+}
+
+// marshalEditions converts a release's edition artifacts to JSON bytes,
+// storing an empty object when there are none.
+func marshalEditions(editions map[string]models.EditionArtifact) ([]byte, error) {
+	if editions == nil {
+		editions = map[string]models.EditionArtifact{}
+	}
+	data, err := json.Marshal(editions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal editions: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalEditions converts JSON bytes to edition artifacts, returning nil
+// when there are none so single-edition releases round-trip unchanged.
+func unmarshalEditions(data []byte) (map[string]models.EditionArtifact, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var editions map[string]models.EditionArtifact
+	if err := json.Unmarshal(data, &editions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal editions: %w", err)
+	}
+	if len(editions) == 0 {
+		return nil, nil
+	}
+	return editions, nil
+}
+
+// marshalVariants converts a release's variant artifacts to JSON bytes,
+// storing an empty object when there are none.
+func marshalVariants(variants map[string]models.VariantArtifact) ([]byte, error) {
+	if variants == nil {
+		variants = map[string]models.VariantArtifact{}
+	}
+	data, err := json.Marshal(variants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variants: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalVariants converts JSON bytes to variant artifacts, returning nil
+// when there are none so releases without variants round-trip unchanged.
+func unmarshalVariants(data []byte) (map[string]models.VariantArtifact, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var variants map[string]models.VariantArtifact
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variants: %w", err)
+	}
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	return variants, nil
+}
+
+// marshalStatusChecks converts a release's status checks to JSON bytes,
+// storing an empty object when there are none.
+func marshalStatusChecks(checks map[string]models.StatusCheck) ([]byte, error) {
+	if checks == nil {
+		checks = map[string]models.StatusCheck{}
+	}
+	data, err := json.Marshal(checks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status checks: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalStatusChecks converts JSON bytes to status checks, returning nil
+// when there are none so ungated releases round-trip unchanged.
+func unmarshalStatusChecks(data []byte) (map[string]models.StatusCheck, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var checks map[string]models.StatusCheck
+	if err := json.Unmarshal(data, &checks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status checks: %w", err)
+	}
+	if len(checks) == 0 {
+		return nil, nil
+	}
+	return checks, nil
+}
+
+// marshalTargeting converts a release's targeting rules to JSON bytes,
+// storing an empty array when there are none.
+func marshalTargeting(rules []models.TargetingRule) ([]byte, error) {
+	if rules == nil {
+		rules = []models.TargetingRule{}
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal targeting: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalTargeting converts JSON bytes to targeting rules, returning nil
+// when there are none so untargeted releases round-trip unchanged.
+func unmarshalTargeting(data []byte) ([]models.TargetingRule, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var rules []models.TargetingRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal targeting: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return rules, nil
+}
+
 // marshalPermissions serialises a permissions slice to a JSON string.
 func marshalPermissions(perms []string) (string, error) {
 	if perms == nil {
@@ -114,6 +251,54 @@ func unmarshalPermissions(data string) ([]string, error) {
 	return perms, nil
 }
 
+// marshalTags converts a tag slice to JSON bytes.
+func marshalTags(tags []string) ([]byte, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	return json.Marshal(tags)
+}
+
+// unmarshalTags converts JSON bytes to a tag slice.
+func unmarshalTags(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return []string{}, nil
+	}
+	var tags []string
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return tags, nil
+}
+
+// unmarshalTagsFromString converts a JSON string to a tag slice.
+func unmarshalTagsFromString(data string) ([]string, error) {
+	return unmarshalTags([]byte(data))
+}
+
+// marshalSlugs converts former slugs to a JSON array.
+func marshalSlugs(slugs []string) ([]byte, error) {
+	return marshalTags(slugs)
+}
+
+// unmarshalSlugs converts a JSON array to former slugs, nil when there are none.
+func unmarshalSlugs(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var slugs []string
+	if err := json.Unmarshal(data, &slugs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal former slugs: %w", err)
+	}
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+	return slugs, nil
+}
+
 // parseSemverParts extracts major, minor, patch, and pre-release from a semver string.
 // Returns zeros and empty string if the version cannot be parsed.
 // Version components are capped at math.MaxInt64 to safely convert from uint64.
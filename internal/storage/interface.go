@@ -12,6 +12,10 @@ type Storage interface {
 	// GetApplication retrieves an application by its ID
 	GetApplication(ctx context.Context, appID string) (*models.Application, error)
 
+	// GetApplicationBySlug retrieves the application whose current or former
+	// slug is slug. Returns storage.ErrNotFound if no application has it.
+	GetApplicationBySlug(ctx context.Context, slug string) (*models.Application, error)
+
 	// SaveApplication stores or updates an application
 	SaveApplication(ctx context.Context, app *models.Application) error
 
@@ -21,9 +25,16 @@ type Storage interface {
 	// GetRelease retrieves a specific release by application ID, version, platform, and architecture
 	GetRelease(ctx context.Context, appID, version, platform, arch string) (*models.Release, error)
 
+	// GetReleaseByID retrieves a release by its ID
+	GetReleaseByID(ctx context.Context, id string) (*models.Release, error)
+
 	// SaveRelease stores or updates a release
 	SaveRelease(ctx context.Context, release *models.Release) error
 
+	// SaveReleases stores or updates several releases atomically: either every
+	// release is saved or none is.
+	SaveReleases(ctx context.Context, releases []*models.Release) error
+
 	// DeleteRelease removes a release
 	DeleteRelease(ctx context.Context, appID, version, platform, arch string) error
 
@@ -55,10 +66,15 @@ type Storage interface {
 	// DeleteAPIKey permanently removes an API key by ID.
 	DeleteAPIKey(ctx context.Context, id string) error
 
-	// ListApplicationsPaged returns a page of applications sorted by created_at DESC, id DESC,
-	// and the total count of all applications.
+	// ListApplicationsPaged returns a filtered page of applications sorted by created_at DESC, id DESC,
+	// and the total count of matching applications.
 	// cursor, when non-nil, positions the query after the given item for keyset pagination.
-	ListApplicationsPaged(ctx context.Context, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error)
+	ListApplicationsPaged(ctx context.Context, filters models.ApplicationFilters, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error)
+
+	// ListApplicationGroups returns every distinct application group with the number of
+	// applications in it, sorted by name. Ungrouped applications are reported under the
+	// empty group name.
+	ListApplicationGroups(ctx context.Context) ([]models.ApplicationGroup, error)
 
 	// ListReleasesPaged returns a filtered, sorted page of releases for an application,
 	// and the total count of matching releases.
@@ -74,4 +90,14 @@ type Storage interface {
 
 	// GetApplicationStats returns aggregate statistics for an application.
 	GetApplicationStats(ctx context.Context, appID string) (models.ApplicationStats, error)
+
+	// SaveContainerImage stores or replaces a container image tag for an application.
+	SaveContainerImage(ctx context.Context, image *models.ContainerImage) error
+
+	// ListContainerImages returns every container image tag of an application.
+	ListContainerImages(ctx context.Context, appID string) ([]*models.ContainerImage, error)
+
+	// DeleteContainerImage removes a container image tag.
+	// Returns storage.ErrNotFound if the tag does not exist.
+	DeleteContainerImage(ctx context.Context, appID, tag string) error
 }
@@ -17,9 +17,10 @@ import (
 type MemoryStorage struct {
 	mu           sync.RWMutex
 	applications map[string]*models.Application
-	releases     map[string][]*models.Release // key: applicationID
-	apiKeys      map[string]*models.APIKey    // keyed by ID
-	apiKeyHashes map[string]string            // hash -> ID
+	releases     map[string][]*models.Release        // key: applicationID
+	apiKeys      map[string]*models.APIKey           // keyed by ID
+	apiKeyHashes map[string]string                   // hash -> ID
+	images       map[string][]*models.ContainerImage // key: applicationID
 }
 
 // NewMemoryStorage creates a new memory-based storage instance
@@ -29,6 +30,7 @@ func NewMemoryStorage() (*MemoryStorage, error) {
 		releases:     make(map[string][]*models.Release),
 		apiKeys:      make(map[string]*models.APIKey),
 		apiKeyHashes: make(map[string]string),
+		images:       make(map[string][]*models.ContainerImage),
 	}, nil
 }
 
@@ -39,7 +41,7 @@ func (m *MemoryStorage) GetApplication(ctx context.Context, appID string) (*mode
 
 	app, exists := m.applications[appID]
 	if !exists {
-		return nil, fmt.Errorf("application %s not found", appID)
+		return nil, fmt.Errorf("application %s %w", appID, ErrNotFound)
 	}
 
 	// Return a copy
@@ -47,6 +49,20 @@ func (m *MemoryStorage) GetApplication(ctx context.Context, appID string) (*mode
 	return &appCopy, nil
 }
 
+// GetApplicationBySlug retrieves the application whose current or former slug is slug.
+func (m *MemoryStorage) GetApplicationBySlug(ctx context.Context, slug string) (*models.Application, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, app := range m.applications {
+		if app.HasSlug(slug) {
+			appCopy := *app
+			return &appCopy, nil
+		}
+	}
+	return nil, fmt.Errorf("application with slug %s: %w", slug, ErrNotFound)
+}
+
 // SaveApplication stores or updates an application
 func (m *MemoryStorage) SaveApplication(ctx context.Context, app *models.Application) error {
 	m.mu.Lock()
@@ -60,7 +76,7 @@ func (m *MemoryStorage) SaveApplication(ctx context.Context, app *models.Applica
 }
 
 // DeleteApplication removes an application by its ID.
-// Returns ErrHasDependencies if the application has existing releases.
+// Returns ErrHasDependencies if the application has existing releases or container images.
 func (m *MemoryStorage) DeleteApplication(ctx context.Context, appID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -69,7 +85,7 @@ func (m *MemoryStorage) DeleteApplication(ctx context.Context, appID string) err
 		return fmt.Errorf("application %s not found", appID)
 	}
 
-	if len(m.releases[appID]) > 0 {
+	if len(m.releases[appID]) > 0 || len(m.images[appID]) > 0 {
 		return ErrHasDependencies
 	}
 
@@ -100,11 +116,47 @@ func (m *MemoryStorage) GetRelease(ctx context.Context, appID, version, platform
 	return nil, fmt.Errorf("release not found: %s@%s-%s-%s", appID, version, platform, arch)
 }
 
+// GetReleaseByID retrieves a release by its ID.
+func (m *MemoryStorage) GetReleaseByID(ctx context.Context, id string) (*models.Release, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, releases := range m.releases {
+		for _, release := range releases {
+			if release.ID == id {
+				// Return a copy
+				releaseCopy := *release
+				return &releaseCopy, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("release not found: %s", id)
+}
+
 // SaveRelease stores or updates a release
 func (m *MemoryStorage) SaveRelease(ctx context.Context, release *models.Release) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.saveReleaseLocked(release)
+	return nil
+}
+
+// SaveReleases stores or updates several releases under a single lock so that
+// readers never observe a partially saved set.
+func (m *MemoryStorage) SaveReleases(ctx context.Context, releases []*models.Release) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, release := range releases {
+		m.saveReleaseLocked(release)
+	}
+	return nil
+}
+
+// saveReleaseLocked inserts or replaces a release. The caller must hold m.mu.
+func (m *MemoryStorage) saveReleaseLocked(release *models.Release) {
 	// Get existing releases for the application
 	releases := m.releases[release.ApplicationID]
 
@@ -113,18 +165,18 @@ func (m *MemoryStorage) SaveRelease(ctx context.Context, release *models.Release
 		if existingRelease.Version == release.Version &&
 			existingRelease.Platform == release.Platform &&
 			existingRelease.Architecture == release.Architecture {
-			// Update existing release
+			// Update existing release. As with the SQL upserts, the
+			// stored ID is kept.
 			releaseCopy := *release
+			releaseCopy.ID = existingRelease.ID
 			releases[i] = &releaseCopy
-			return nil
+			return
 		}
 	}
 
 	// Add new release
 	releaseCopy := *release
 	m.releases[release.ApplicationID] = append(releases, &releaseCopy)
-
-	return nil
 }
 
 // DeleteRelease removes a release
@@ -245,6 +297,7 @@ func (m *MemoryStorage) Close() error {
 	m.releases = make(map[string][]*models.Release)
 	m.apiKeys = make(map[string]*models.APIKey)
 	m.apiKeyHashes = make(map[string]string)
+	m.images = make(map[string][]*models.ContainerImage)
 
 	return nil
 }
@@ -321,45 +374,34 @@ func (m *MemoryStorage) DeleteAPIKey(ctx context.Context, id string) error {
 	return nil
 }
 
-// ListApplicationsPaged returns a page of applications sorted by created_at DESC, id DESC,
-// and the total count of all applications.
+// ListApplicationsPaged returns a filtered page of applications sorted by created_at DESC, id DESC,
+// and the total count of matching applications.
 // cursor, when non-nil, positions the query after the given item.
-func (m *MemoryStorage) ListApplicationsPaged(ctx context.Context, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
+func (m *MemoryStorage) ListApplicationsPaged(ctx context.Context, filters models.ApplicationFilters, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	apps := make([]*models.Application, 0, len(m.applications))
 	for _, app := range m.applications {
-		copied := *app
-		apps = append(apps, &copied)
-	}
-	// Pre-parse timestamps so sort errors can be returned. Malformed timestamps indicate
-	// data corruption; Application.Validate() rejects them at write time.
-	parsed := make([]struct {
-		app       *models.Application
-		createdAt time.Time
-	}, len(apps))
-	for i, app := range apps {
-		parsed[i].app = app
-		if app.CreatedAt == "" {
+		if !models.HasAllTags(app.Tags, filters.Tags) {
 			continue
 		}
-		t, err := time.Parse(time.RFC3339, app.CreatedAt)
-		if err != nil {
-			return nil, 0, fmt.Errorf("corrupt created_at for application %s: %w", app.ID, err)
+		if filters.Group != "" && app.Group != filters.Group {
+			continue
+		}
+		if filters.ParentID != "" && app.ParentID != filters.ParentID {
+			continue
 		}
-		parsed[i].createdAt = t
+		copied := *app
+		apps = append(apps, &copied)
 	}
 	// Sort by created_at DESC, id DESC to match the DB ordering.
-	sort.Slice(parsed, func(i, j int) bool {
-		if !parsed[i].createdAt.Equal(parsed[j].createdAt) {
-			return parsed[i].createdAt.After(parsed[j].createdAt)
+	sort.Slice(apps, func(i, j int) bool {
+		if !apps[i].CreatedAt.Equal(apps[j].CreatedAt) {
+			return apps[i].CreatedAt.After(apps[j].CreatedAt)
 		}
-		return parsed[i].app.ID > parsed[j].app.ID
+		return apps[i].ID > apps[j].ID
 	})
-	for i, p := range parsed {
-		apps[i] = p.app
-	}
 
 	total := len(apps)
 
@@ -389,6 +431,24 @@ func (m *MemoryStorage) ListApplicationsPaged(ctx context.Context, limit int, cu
 	return apps[start:end], total, nil
 }
 
+// ListApplicationGroups returns every distinct application group with its member count,
+// sorted by name. Ungrouped applications are reported under the empty name.
+func (m *MemoryStorage) ListApplicationGroups(ctx context.Context) ([]models.ApplicationGroup, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, app := range m.applications {
+		counts[app.Group]++
+	}
+	groups := make([]models.ApplicationGroup, 0, len(counts))
+	for name, n := range counts {
+		groups = append(groups, models.ApplicationGroup{Name: name, ApplicationCount: n})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
 // memorySortReleases sorts a slice of releases in-place.
 // sortBy must be one of: release_date (default), version, platform, architecture, created_at.
 // sortOrder must be "asc" or "desc".
@@ -454,6 +514,9 @@ func (m *MemoryStorage) ListReleasesPaged(ctx context.Context, appID string, fil
 				continue
 			}
 		}
+		if !models.HasAllTags(r.Tags, filters.Tags) {
+			continue
+		}
 		copied := *r
 		filtered = append(filtered, &copied)
 	}
@@ -553,3 +616,65 @@ func (m *MemoryStorage) GetApplicationStats(ctx context.Context, appID string) (
 	stats.LatestReleaseDate = latestDate
 	return stats, nil
 }
+
+// copyContainerImage returns a deep copy of a ContainerImage, including its Platforms slice.
+func copyContainerImage(c *models.ContainerImage) *models.ContainerImage {
+	cp := *c
+	cp.Platforms = append([]string(nil), c.Platforms...)
+	return &cp
+}
+
+// SaveContainerImage stores or replaces a container image tag. The original
+// creation time is kept when an existing tag is replaced.
+func (m *MemoryStorage) SaveContainerImage(ctx context.Context, image *models.ContainerImage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	images := m.images[image.ApplicationID]
+	for i, existing := range images {
+		if existing.Tag == image.Tag {
+			replacement := copyContainerImage(image)
+			replacement.CreatedAt = existing.CreatedAt
+			images[i] = replacement
+			return nil
+		}
+	}
+
+	m.images[image.ApplicationID] = append(images, copyContainerImage(image))
+	return nil
+}
+
+// ListContainerImages returns every container image tag of an application,
+// most recently created first.
+func (m *MemoryStorage) ListContainerImages(ctx context.Context, appID string) ([]*models.ContainerImage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	images := make([]*models.ContainerImage, 0, len(m.images[appID]))
+	for _, image := range m.images[appID] {
+		images = append(images, copyContainerImage(image))
+	}
+	sort.SliceStable(images, func(i, j int) bool {
+		if !images[i].CreatedAt.Equal(images[j].CreatedAt) {
+			return images[i].CreatedAt.After(images[j].CreatedAt)
+		}
+		return images[i].ID > images[j].ID
+	})
+	return images, nil
+}
+
+// DeleteContainerImage removes a container image tag.
+// Returns ErrNotFound if the tag does not exist.
+func (m *MemoryStorage) DeleteContainerImage(ctx context.Context, appID, tag string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	images := m.images[appID]
+	for i, image := range images {
+		if image.Tag == tag {
+			m.images[appID] = append(images[:i], images[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
@@ -24,7 +24,7 @@ func TestMemoryStorage(t *testing.T) {
 	// Test application operations
 	t.Run("Application Operations", func(t *testing.T) {
 		// Test empty applications list
-		apps, _, err := storage.ListApplicationsPaged(ctx, 50, nil)
+		apps, _, err := storage.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 		if err != nil {
 			t.Errorf("Failed to get applications: %v", err)
 		}
@@ -38,8 +38,8 @@ func TestMemoryStorage(t *testing.T) {
 			Name:        "Test Application",
 			Description: "A test application",
 			Platforms:   []string{"windows"},
-			CreatedAt:   time.Now().Format(time.RFC3339),
-			UpdatedAt:   time.Now().Format(time.RFC3339),
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
 		}
 
 		err = storage.SaveApplication(ctx, app)
@@ -60,7 +60,7 @@ func TestMemoryStorage(t *testing.T) {
 		}
 
 		// Test applications list
-		apps, _, err = storage.ListApplicationsPaged(ctx, 50, nil)
+		apps, _, err = storage.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 		if err != nil {
 			t.Errorf("Failed to get applications: %v", err)
 		}
@@ -89,6 +89,7 @@ func TestMemoryStorage(t *testing.T) {
 		// Test save release
 		releaseDate := time.Now()
 		release := &models.Release{
+			ID:            "01JQZ0000000000000000000AA",
 			ApplicationID: "test-app",
 			Version:       "1.0.0",
 			Platform:      "windows",
@@ -116,6 +117,17 @@ func TestMemoryStorage(t *testing.T) {
 			t.Errorf("Expected version '1.0.0', got '%s'", retrievedRelease.Version)
 		}
 
+		// Test get release by ID
+		retrievedRelease, err = storage.GetReleaseByID(ctx, release.ID)
+		if err != nil {
+			t.Errorf("Failed to get release by ID: %v", err)
+		} else if retrievedRelease.Version != "1.0.0" {
+			t.Errorf("Expected version '1.0.0', got '%s'", retrievedRelease.Version)
+		}
+		if _, err := storage.GetReleaseByID(ctx, "missing"); err == nil {
+			t.Error("Expected error for non-existent release ID")
+		}
+
 		// Test releases list
 		releases, _, err = storage.ListReleasesPaged(ctx, "test-app", models.ReleaseFilters{}, "release_date", "desc", 50, nil)
 		if err != nil {
@@ -268,8 +280,8 @@ func TestMemoryStorageConcurrency(t *testing.T) {
 		ID:          "concurrent-test",
 		Name:        "Concurrent Test",
 		Description: "Testing concurrent access",
-		CreatedAt:   time.Now().Format(time.RFC3339),
-		UpdatedAt:   time.Now().Format(time.RFC3339),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
 	// Save initial application
@@ -421,7 +433,7 @@ func TestMemoryStorage_ListApplicationsPaged(t *testing.T) {
 					require.NoError(t, s.SaveApplication(ctx, &models.Application{
 						ID:        fmt.Sprintf("app-%d", i),
 						Name:      fmt.Sprintf("App %d", i),
-						CreatedAt: time.Now().Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+						CreatedAt: time.Now().Add(time.Duration(i) * time.Second),
 					}))
 				}
 			},
@@ -436,7 +448,7 @@ func TestMemoryStorage_ListApplicationsPaged(t *testing.T) {
 				require.NoError(t, s.SaveApplication(ctx, &models.Application{
 					ID:        "app-1",
 					Name:      "App 1",
-					CreatedAt: time.Now().Format(time.RFC3339),
+					CreatedAt: time.Now(),
 				}))
 			},
 			limit:     0,
@@ -455,7 +467,7 @@ func TestMemoryStorage_ListApplicationsPaged(t *testing.T) {
 
 			tt.setup(s)
 
-			apps, total, err := s.ListApplicationsPaged(ctx, tt.limit, tt.cursor)
+			apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, tt.limit, tt.cursor)
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantTotal, total)
 			assert.Len(t, apps, tt.wantCount)
@@ -723,8 +735,8 @@ func TestMemoryStorage_ListApplicationsPaged_CursorNotFound(t *testing.T) {
 			Name:      fmt.Sprintf("App %d", i),
 			Platforms: []string{"windows"},
 			Config:    models.ApplicationConfig{},
-			CreatedAt: now.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
-			UpdatedAt: now.Format(time.RFC3339),
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+			UpdatedAt: now,
 		}
 		require.NoError(t, store.SaveApplication(ctx, app))
 	}
@@ -734,7 +746,7 @@ func TestMemoryStorage_ListApplicationsPaged_CursorNotFound(t *testing.T) {
 		CreatedAt: now.Add(-1 * time.Hour),
 		ID:        "does-not-exist",
 	}
-	results, _, err := store.ListApplicationsPaged(ctx, 10, cursor)
+	results, _, err := store.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 10, cursor)
 	require.NoError(t, err)
 	assert.Empty(t, results, "cursor pointing to a deleted item must return empty slice, not restart pagination")
 }
@@ -750,8 +762,8 @@ func TestMemoryStorage_ListReleasesPaged_CursorNotFound(t *testing.T) {
 		Name:      "App1",
 		Platforms: []string{"windows"},
 		Config:    models.ApplicationConfig{},
-		CreatedAt: time.Now().Format(time.RFC3339),
-		UpdatedAt: time.Now().Format(time.RFC3339),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}
 	require.NoError(t, store.SaveApplication(ctx, app))
 
@@ -783,19 +795,136 @@ func TestMemoryStorage_ListReleasesPaged_CursorNotFound(t *testing.T) {
 	assert.Empty(t, results, "cursor pointing to a deleted item must return empty slice, not restart pagination")
 }
 
-func TestMemoryListApplicationsPaged_CorruptTimestamp(t *testing.T) {
-	store, err := NewMemoryStorage()
+func TestMemoryStorage_TagFilters(t *testing.T) {
+	s, err := NewMemoryStorage()
 	require.NoError(t, err)
-	defer store.Close()
+	ctx := context.Background()
+
+	tagged := models.NewApplication("tagged-app", "Tagged", []string{"linux"})
+	tagged.Tags = []string{"internal"}
+	require.NoError(t, s.SaveApplication(ctx, tagged))
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("plain-app", "Plain", []string{"linux"})))
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{Tags: []string{"internal"}}, 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "tagged-app", apps[0].ID)
+
+	for i, tags := range [][]string{{"hotfix", "security"}, {"hotfix"}, nil} {
+		r := models.NewRelease("tagged-app", fmt.Sprintf("1.0.%d", i), "linux", "amd64", "https://example.com/app")
+		r.Tags = tags
+		require.NoError(t, s.SaveRelease(ctx, r))
+	}
+
+	releases, total, err := s.ListReleasesPaged(ctx, "tagged-app", models.ReleaseFilters{Tags: []string{"hotfix"}}, "release_date", "desc", 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, releases, 2)
+}
 
+func TestMemoryStorage_ApplicationGroups(t *testing.T) {
+	s, err := NewMemoryStorage()
+	require.NoError(t, err)
 	ctx := context.Background()
 
-	// Bypass validation by writing directly to the internal map.
-	app := models.NewApplication("bad-app", "Bad App", []string{"windows"})
-	app.CreatedAt = "not-a-timestamp"
-	store.applications["bad-app"] = app
+	for id, group := range map[string]string{"api": "Payments", "web": "Payments", "chat": "Messaging", "misc": ""} {
+		app := models.NewApplication(id, id, []string{"linux"})
+		app.Group = group
+		require.NoError(t, s.SaveApplication(ctx, app))
+	}
+
+	groups, err := s.ListApplicationGroups(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ApplicationGroup{
+		{Name: "", ApplicationCount: 1},
+		{Name: "Messaging", ApplicationCount: 1},
+		{Name: "Payments", ApplicationCount: 2},
+	}, groups)
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{Group: "Payments"}, 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, apps, 2)
+}
+
+func TestMemoryStorage_ApplicationSlugs(t *testing.T) {
+	s, err := NewMemoryStorage()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	app := models.NewApplication("acme-notes", "Acme Notes", []string{"linux"})
+	app.Slug = "jotter"
+	app.FormerSlugs = []string{"notes"}
+	require.NoError(t, s.SaveApplication(ctx, app))
+
+	for _, slug := range []string{"jotter", "notes"} {
+		got, err := s.GetApplicationBySlug(ctx, slug)
+		require.NoError(t, err, slug)
+		assert.Equal(t, "acme-notes", got.ID)
+	}
+	_, err = s.GetApplicationBySlug(ctx, "acme-notes")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryStorage_ParentFilter(t *testing.T) {
+	s, err := NewMemoryStorage()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("host", "Host", []string{"linux"})))
+	plugin := models.NewApplication("plugin", "Plugin", []string{"linux"})
+	plugin.ParentID = "host"
+	require.NoError(t, s.SaveApplication(ctx, plugin))
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{ParentID: "host"}, 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "plugin", apps[0].ID)
+}
+
+func TestMemoryStorage_SaveReleases(t *testing.T) {
+	s, err := NewMemoryStorage()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveReleases(ctx, []*models.Release{
+		models.NewRelease("multi", "1.0.0", "windows", "amd64", "https://example.com/w"),
+		models.NewRelease("multi", "1.0.0", "linux", "amd64", "https://example.com/l"),
+	}))
+
+	updated := models.NewRelease("multi", "1.0.0", "linux", "amd64", "https://example.com/l-fixed")
+	require.NoError(t, s.SaveReleases(ctx, []*models.Release{updated}))
+
+	got, err := s.GetRelease(ctx, "multi", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/l-fixed", got.DownloadURL)
+	_, err = s.GetRelease(ctx, "multi", "1.0.0", "windows", "amd64")
+	assert.NoError(t, err)
+}
+
+func TestMemoryStorage_ContainerImages(t *testing.T) {
+	s, err := NewMemoryStorage()
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("agent", "Agent", []string{"linux"})))
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	image := models.NewContainerImage("agent", "ghcr.io/acme/agent", "1.0.0", digest, []string{"linux/amd64"})
+	require.NoError(t, s.SaveContainerImage(ctx, image))
+
+	// Mutating the caller's slice must not affect the stored image.
+	image.Platforms[0] = "linux/arm64"
+	images, err := s.ListContainerImages(ctx, "agent")
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, []string{"linux/amd64"}, images[0].Platforms)
+
+	// Images are dependencies of the application.
+	assert.ErrorIs(t, s.DeleteApplication(ctx, "agent"), ErrHasDependencies)
 
-	_, _, err = store.ListApplicationsPaged(ctx, 10, nil)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "corrupt created_at")
+	require.NoError(t, s.DeleteContainerImage(ctx, "agent", "1.0.0"))
+	assert.ErrorIs(t, s.DeleteContainerImage(ctx, "agent", "1.0.0"), ErrNotFound)
+	assert.NoError(t, s.DeleteApplication(ctx, "agent"))
 }
@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- Free-form tags on applications and releases, stored as a JSON array of strings.
+ALTER TABLE applications ADD COLUMN tags JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE releases ADD COLUMN tags JSONB NOT NULL DEFAULT '[]';
+
+-- Tag indexes (containment queries use the @> operator)
+CREATE INDEX idx_applications_tags ON applications USING GIN(tags);
+CREATE INDEX idx_releases_tags ON releases USING GIN(tags);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_releases_tags;
+DROP INDEX IF EXISTS idx_applications_tags;
+ALTER TABLE releases DROP COLUMN IF EXISTS tags;
+ALTER TABLE applications DROP COLUMN IF EXISTS tags;
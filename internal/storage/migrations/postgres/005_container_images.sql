@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Container image tags published for an application. Platforms is a JSON array
+-- of OCI platform strings (e.g. "linux/arm/v7").
+CREATE TABLE container_images (
+    id TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL,
+    repository TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    digest TEXT NOT NULL,
+    platforms JSONB NOT NULL DEFAULT '[]',
+    release_notes TEXT NOT NULL DEFAULT '',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE RESTRICT,
+    UNIQUE(application_id, tag)
+);
+
+CREATE TRIGGER update_container_images_updated_at
+    BEFORE UPDATE ON container_images
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS update_container_images_updated_at ON container_images;
+DROP TABLE IF EXISTS container_images;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- ASCII-armored detached OpenPGP signature of a release's file. Empty when the
+-- release is unsigned.
+ALTER TABLE releases ADD COLUMN pgp_signature TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN pgp_signature;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Entitlement a client's license must grant before an update check offers the
+-- release. Empty for releases every client is offered.
+ALTER TABLE releases ADD COLUMN required_entitlement TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN required_entitlement;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Release notes drafted from the commits of a registration, held for an admin
+-- to publish or discard. Empty when there is no draft.
+ALTER TABLE releases ADD COLUMN release_notes_draft TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN release_notes_draft;
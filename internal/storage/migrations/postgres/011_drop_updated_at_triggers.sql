@@ -0,0 +1,34 @@
+-- +goose Up
+
+-- Every write sets updated_at from the storage clock, which tests can fix.
+-- The triggers overwrote it with the database time.
+DROP TRIGGER IF EXISTS update_container_images_updated_at ON container_images;
+DROP TRIGGER IF EXISTS update_api_keys_updated_at ON api_keys;
+DROP TRIGGER IF EXISTS update_applications_updated_at ON applications;
+DROP FUNCTION IF EXISTS update_updated_at_column();
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+-- +goose StatementEnd
+
+CREATE TRIGGER update_applications_updated_at
+    BEFORE UPDATE ON applications
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_api_keys_updated_at
+    BEFORE UPDATE ON api_keys
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_container_images_updated_at
+    BEFORE UPDATE ON container_images
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- Renameable URL identifier, resolved in place of the immutable ID.
+-- Existing applications start with their ID as slug.
+ALTER TABLE applications ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+UPDATE applications SET slug = id;
+CREATE UNIQUE INDEX idx_applications_slug ON applications(slug) WHERE slug <> '';
+
+-- Earlier slugs, redirected to the current one, stored as a JSON array of strings.
+ALTER TABLE applications ADD COLUMN former_slugs JSONB NOT NULL DEFAULT '[]';
+CREATE INDEX idx_applications_former_slugs ON applications USING GIN(former_slugs);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_applications_former_slugs;
+DROP INDEX IF EXISTS idx_applications_slug;
+ALTER TABLE applications DROP COLUMN IF EXISTS former_slugs;
+ALTER TABLE applications DROP COLUMN IF EXISTS slug;
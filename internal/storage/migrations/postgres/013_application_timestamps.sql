@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Application timestamps are TIMESTAMPTZ columns from 001, so there is nothing
+-- to convert; this keeps the migration versions aligned with SQLite.
+SELECT 1;
+
+-- +goose Down
+SELECT 1;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Last modification time of a release, set on every save. Existing releases
+-- start from their creation time.
+ALTER TABLE releases ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+UPDATE releases SET updated_at = created_at;
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Channel a release is published to. Empty for releases stored before
+-- channels, which are on beta when their version is a pre-release and on
+-- stable otherwise.
+ALTER TABLE releases ADD COLUMN channel TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN channel;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Targeting rules restricting a release to clients with certain attributes,
+-- stored as a JSON array. Empty for releases offered to every client.
+ALTER TABLE releases ADD COLUMN targeting JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN targeting;
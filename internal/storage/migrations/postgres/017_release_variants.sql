@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Packaging of a release's own artifact, such as msi or portable-zip. Empty
+-- for releases that declare no variant.
+ALTER TABLE releases ADD COLUMN variant TEXT NOT NULL DEFAULT '';
+
+-- Artifacts of other packagings of a release, stored as a JSON object keyed
+-- by variant.
+ALTER TABLE releases ADD COLUMN variants JSONB NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN variants;
+ALTER TABLE releases DROP COLUMN variant;
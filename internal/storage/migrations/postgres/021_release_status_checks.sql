@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Required status checks of a release and the latest result reported for
+-- each, stored as a JSON object keyed by check name. Empty for releases
+-- registered while their application required no checks.
+ALTER TABLE releases ADD COLUMN status_checks JSONB NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN status_checks;
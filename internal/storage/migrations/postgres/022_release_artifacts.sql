@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Whether a release waits for its hosted artifact to be uploaded. Such
+-- releases have a generated download URL but are not offered until the
+-- artifact is stored.
+ALTER TABLE releases ADD COLUMN artifact_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN artifact_pending;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Free-form tags on applications and releases, stored as a JSON array of strings.
+ALTER TABLE applications ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE releases ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN tags;
+ALTER TABLE applications DROP COLUMN tags;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Organizational grouping (team or product family). Empty means ungrouped.
+ALTER TABLE applications ADD COLUMN group_name TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_applications_group_name ON applications(group_name);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_applications_group_name;
+ALTER TABLE applications DROP COLUMN group_name;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Plugin applications reference their host application. Empty means standalone.
+ALTER TABLE applications ADD COLUMN parent_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_applications_parent_id ON applications(parent_id);
+
+-- Semver constraint on the host version a plugin release is compatible with.
+ALTER TABLE releases ADD COLUMN host_version_constraint TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN host_version_constraint;
+DROP INDEX IF EXISTS idx_applications_parent_id;
+ALTER TABLE applications DROP COLUMN parent_id;
@@ -0,0 +1,32 @@
+-- +goose Up
+
+-- Container image tags published for an application. Platforms is a JSON array
+-- of OCI platform strings (e.g. "linux/arm/v7").
+CREATE TABLE container_images (
+    id TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL,
+    repository TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    digest TEXT NOT NULL,
+    platforms TEXT NOT NULL DEFAULT '[]',
+    release_notes TEXT NOT NULL DEFAULT '',
+    required BOOLEAN NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+
+    FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE CASCADE,
+    UNIQUE(application_id, tag)
+);
+
+-- +goose StatementBegin
+CREATE TRIGGER update_container_images_updated_at
+    AFTER UPDATE ON container_images
+    FOR EACH ROW
+BEGIN
+    UPDATE container_images SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS update_container_images_updated_at;
+DROP TABLE IF EXISTS container_images;
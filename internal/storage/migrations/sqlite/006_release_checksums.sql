@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Additional checksums of a release's file, stored as a JSON object keyed by
+-- checksum type. The primary checksum stays in checksum and checksum_type.
+ALTER TABLE releases ADD COLUMN checksums TEXT NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN checksums;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Artifacts of other editions of a release, stored as a JSON object keyed by
+-- edition. The release's own artifact is the base edition.
+ALTER TABLE releases ADD COLUMN editions TEXT NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN editions;
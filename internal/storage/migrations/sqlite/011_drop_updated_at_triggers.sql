@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- Every write sets updated_at from the storage clock, which tests can fix.
+-- The triggers overwrote it with the database time.
+DROP TRIGGER IF EXISTS update_container_images_updated_at;
+DROP TRIGGER IF EXISTS update_api_keys_updated_at;
+DROP TRIGGER IF EXISTS update_applications_updated_at;
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TRIGGER update_applications_updated_at
+    AFTER UPDATE ON applications
+    FOR EACH ROW
+BEGIN
+    UPDATE applications SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_api_keys_updated_at
+    AFTER UPDATE ON api_keys
+    FOR EACH ROW
+BEGIN
+    UPDATE api_keys SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_container_images_updated_at
+    AFTER UPDATE ON container_images
+    FOR EACH ROW
+BEGIN
+    UPDATE container_images SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
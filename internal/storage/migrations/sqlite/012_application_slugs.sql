@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- Renameable URL identifier, resolved in place of the immutable ID.
+-- Existing applications start with their ID as slug.
+ALTER TABLE applications ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+UPDATE applications SET slug = id;
+CREATE UNIQUE INDEX idx_applications_slug ON applications(slug) WHERE slug <> '';
+
+-- Earlier slugs, redirected to the current one, stored as a JSON array of strings.
+ALTER TABLE applications ADD COLUMN former_slugs TEXT NOT NULL DEFAULT '[]';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_applications_slug;
+ALTER TABLE applications DROP COLUMN former_slugs;
+ALTER TABLE applications DROP COLUMN slug;
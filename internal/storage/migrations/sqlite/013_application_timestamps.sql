@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- Application timestamps are read back as time.Time, so rewrite any stored
+-- in another format or offset as UTC RFC3339, the format the storage writes.
+-- Values SQLite cannot parse are reset to the migration time.
+UPDATE applications SET
+    created_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', created_at), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', updated_at), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+
+-- +goose Down
+-- Normalised timestamps remain valid; nothing to undo.
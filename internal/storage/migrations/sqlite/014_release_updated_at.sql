@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Last modification time of a release, set on every save. Existing releases
+-- start from their creation time.
+ALTER TABLE releases ADD COLUMN updated_at TEXT NOT NULL DEFAULT '';
+UPDATE releases SET updated_at = created_at;
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN updated_at;
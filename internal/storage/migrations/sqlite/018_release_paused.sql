@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Whether a release's rollout is paused. Paused releases are kept but not
+-- offered to clients until they are resumed.
+ALTER TABLE releases ADD COLUMN paused BOOLEAN NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN paused;
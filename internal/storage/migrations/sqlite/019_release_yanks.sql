@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Whether a release is yanked, and why. Yanked releases are kept but not
+-- offered, and clients on them are offered the release before them.
+ALTER TABLE releases ADD COLUMN yanked BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE releases ADD COLUMN yank_reason TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN yank_reason;
+ALTER TABLE releases DROP COLUMN yanked;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Android versionCode of a release's APK and the SHA-256 digest of its
+-- signing certificate. Zero and empty for other platforms.
+ALTER TABLE releases ADD COLUMN version_code INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE releases ADD COLUMN signing_cert_sha256 TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN signing_cert_sha256;
+ALTER TABLE releases DROP COLUMN version_code;
@@ -0,0 +1,27 @@
+package storage
+
+import "time"
+
+// Option configures a SQL storage backend.
+type Option func(*options)
+
+type options struct {
+	now func() time.Time
+}
+
+// WithClock sets the time source of the timestamps storage writes itself,
+// such as the creation time of API keys. Tests use it to get deterministic
+// timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
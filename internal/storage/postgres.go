@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 	"updater/internal/models"
 	sqlcpg "updater/internal/storage/sqlc/postgres"
@@ -19,10 +20,11 @@ import (
 type PostgresStorage struct {
 	pool    *pgxpool.Pool
 	queries *sqlcpg.Queries
+	now     func() time.Time
 }
 
 // NewPostgresStorage creates a new PostgreSQL storage instance.
-func NewPostgresStorage(dsn string) (Storage, error) {
+func NewPostgresStorage(dsn string, opts ...Option) (Storage, error) {
 	if dsn == "" {
 		return nil, fmt.Errorf("connection string is required for PostgreSQL storage")
 	}
@@ -38,6 +40,7 @@ func NewPostgresStorage(dsn string) (Storage, error) {
 	}
 
 	return &PostgresStorage{
+		now:     newOptions(opts).now,
 		pool:    pool,
 		queries: sqlcpg.New(pool),
 	}, nil
@@ -48,7 +51,23 @@ func (ps *PostgresStorage) GetApplication(ctx context.Context, appID string) (*m
 	row, err := ps.queries.GetApplicationByID(ctx, appID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("application %s not found", appID)
+			return nil, fmt.Errorf("application %s %w", appID, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return pgAppToModel(row)
+}
+
+// GetApplicationBySlug retrieves the application whose current or former slug is slug.
+func (ps *PostgresStorage) GetApplicationBySlug(ctx context.Context, slug string) (*models.Application, error) {
+	row, err := ps.queries.GetApplicationBySlug(ctx, slug)
+	if errors.Is(err, pgx.ErrNoRows) {
+		row, err = ps.queries.GetApplicationByFormerSlug(ctx, slug)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("application with slug %s: %w", slug, ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get application: %w", err)
 	}
@@ -58,7 +77,7 @@ func (ps *PostgresStorage) GetApplication(ctx context.Context, appID string) (*m
 
 // SaveApplication stores or updates an application (upsert pattern).
 func (ps *PostgresStorage) SaveApplication(ctx context.Context, app *models.Application) error {
-	params, err := modelToPgUpsertApp(app)
+	params, err := modelToPgUpsertApp(app, ps.now())
 	if err != nil {
 		return fmt.Errorf("failed to convert application for upsert: %w", err)
 	}
@@ -95,6 +114,19 @@ func (ps *PostgresStorage) GetRelease(ctx context.Context, appID, version, platf
 	return pgReleaseToModel(row)
 }
 
+// GetReleaseByID retrieves a release by its ID.
+func (ps *PostgresStorage) GetReleaseByID(ctx context.Context, id string) (*models.Release, error) {
+	row, err := ps.queries.GetReleaseByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("release not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+
+	return pgReleaseToModel(row)
+}
+
 // SaveRelease stores or updates a release (upsert pattern).
 func (ps *PostgresStorage) SaveRelease(ctx context.Context, release *models.Release) error {
 	params, err := modelToPgUpsertRelease(release)
@@ -107,6 +139,31 @@ func (ps *PostgresStorage) SaveRelease(ctx context.Context, release *models.Rele
 	return nil
 }
 
+// SaveReleases stores or updates several releases in a single transaction.
+func (ps *PostgresStorage) SaveReleases(ctx context.Context, releases []*models.Release) error {
+	tx, err := ps.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := ps.queries.WithTx(tx)
+	for _, release := range releases {
+		params, err := modelToPgUpsertRelease(release)
+		if err != nil {
+			return fmt.Errorf("failed to convert release for upsert: %w", err)
+		}
+		if err := q.UpsertRelease(ctx, params); err != nil {
+			return fmt.Errorf("failed to upsert release %s: %w", release.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit releases: %w", err)
+	}
+	return nil
+}
+
 // DeleteRelease removes a release.
 func (ps *PostgresStorage) DeleteRelease(ctx context.Context, appID, version, platform, arch string) error {
 	// Verify release exists first
@@ -231,25 +288,40 @@ func pgAppToModel(row sqlcpg.Application) (*models.Application, error) {
 		return nil, err
 	}
 
+	tags, err := unmarshalTags(row.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	formerSlugs, err := unmarshalSlugs(row.FormerSlugs)
+	if err != nil {
+		return nil, err
+	}
+
 	app := &models.Application{
 		ID:          row.ID,
 		Name:        row.Name,
 		Description: pgTextToString(row.Description),
 		Platforms:   platforms,
 		Config:      config,
+		Tags:        tags,
+		Group:       row.GroupName,
+		ParentID:    row.ParentID,
+		Slug:        row.Slug,
+		FormerSlugs: formerSlugs,
 	}
 
 	if row.CreatedAt.Valid {
-		app.CreatedAt = row.CreatedAt.Time.Format(time.RFC3339)
+		app.CreatedAt = row.CreatedAt.Time
 	}
 	if row.UpdatedAt.Valid {
-		app.UpdatedAt = row.UpdatedAt.Time.Format(time.RFC3339)
+		app.UpdatedAt = row.UpdatedAt.Time
 	}
 
 	return app, nil
 }
 
-func modelToPgUpsertApp(app *models.Application) (sqlcpg.UpsertApplicationParams, error) {
+func modelToPgUpsertApp(app *models.Application, now time.Time) (sqlcpg.UpsertApplicationParams, error) {
 	platforms, err := marshalPlatforms(app.Platforms)
 	if err != nil {
 		return sqlcpg.UpsertApplicationParams{}, err
@@ -260,7 +332,16 @@ func modelToPgUpsertApp(app *models.Application) (sqlcpg.UpsertApplicationParams
 		return sqlcpg.UpsertApplicationParams{}, err
 	}
 
-	now := time.Now()
+	tags, err := marshalTags(app.Tags)
+	if err != nil {
+		return sqlcpg.UpsertApplicationParams{}, err
+	}
+
+	formerSlugs, err := marshalSlugs(app.FormerSlugs)
+	if err != nil {
+		return sqlcpg.UpsertApplicationParams{}, err
+	}
+
 	return sqlcpg.UpsertApplicationParams{
 		ID:          app.ID,
 		Name:        app.Name,
@@ -269,6 +350,11 @@ func modelToPgUpsertApp(app *models.Application) (sqlcpg.UpsertApplicationParams
 		Config:      config,
 		CreatedAt:   timeToPgTimestamptz(now),
 		UpdatedAt:   timeToPgTimestamptz(now),
+		Tags:        tags,
+		GroupName:   app.Group,
+		ParentID:    app.ParentID,
+		Slug:        app.Slug,
+		FormerSlugs: formerSlugs,
 	}, nil
 }
 
@@ -278,6 +364,36 @@ func pgReleaseToModel(row sqlcpg.Release) (*models.Release, error) {
 		return nil, err
 	}
 
+	tags, err := unmarshalTags(row.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums, err := unmarshalChecksums(row.Checksums)
+	if err != nil {
+		return nil, err
+	}
+
+	editions, err := unmarshalEditions(row.Editions)
+	if err != nil {
+		return nil, err
+	}
+
+	targeting, err := unmarshalTargeting(row.Targeting)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := unmarshalVariants(row.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	statusChecks, err := unmarshalStatusChecks(row.StatusChecks)
+	if err != nil {
+		return nil, err
+	}
+
 	release := &models.Release{
 		ID:             row.ID,
 		ApplicationID:  row.ApplicationID,
@@ -292,6 +408,25 @@ func pgReleaseToModel(row sqlcpg.Release) (*models.Release, error) {
 		Required:       row.Required,
 		MinimumVersion: pgTextToString(row.MinimumVersion),
 		Metadata:       metadata,
+		Tags:           tags,
+
+		HostVersionConstraint: row.HostVersionConstraint,
+		Checksums:             checksums,
+		PGPSignature:          row.PgpSignature,
+		RequiredEntitlement:   row.RequiredEntitlement,
+		Editions:              editions,
+		ReleaseNotesDraft:     row.ReleaseNotesDraft,
+		Channel:               row.Channel,
+		Targeting:             targeting,
+		Variant:               row.Variant,
+		Variants:              variants,
+		Paused:                row.Paused,
+		Yanked:                row.Yanked,
+		YankReason:            row.YankReason,
+		VersionCode:           row.VersionCode,
+		SigningCertSHA256:     row.SigningCertSha256,
+		StatusChecks:          statusChecks,
+		ArtifactPending:       row.ArtifactPending,
 	}
 
 	if row.ReleaseDate.Valid {
@@ -299,7 +434,9 @@ func pgReleaseToModel(row sqlcpg.Release) (*models.Release, error) {
 	}
 	if row.CreatedAt.Valid {
 		release.CreatedAt = row.CreatedAt.Time
-		release.UpdatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		release.UpdatedAt = row.UpdatedAt.Time
 	}
 
 	return release, nil
@@ -311,7 +448,41 @@ func modelToPgUpsertRelease(r *models.Release) (sqlcpg.UpsertReleaseParams, erro
 		return sqlcpg.UpsertReleaseParams{}, err
 	}
 
+	tags, err := marshalTags(r.Tags)
+	if err != nil {
+		return sqlcpg.UpsertReleaseParams{}, err
+	}
+
+	checksums, err := marshalChecksums(r.Checksums)
+	if err != nil {
+		return sqlcpg.UpsertReleaseParams{}, err
+	}
+
+	editions, err := marshalEditions(r.Editions)
+	if err != nil {
+		return sqlcpg.UpsertReleaseParams{}, err
+	}
+
+	targeting, err := marshalTargeting(r.Targeting)
+	if err != nil {
+		return sqlcpg.UpsertReleaseParams{}, err
+	}
+
+	variants, err := marshalVariants(r.Variants)
+	if err != nil {
+		return sqlcpg.UpsertReleaseParams{}, err
+	}
+
+	statusChecks, err := marshalStatusChecks(r.StatusChecks)
+	if err != nil {
+		return sqlcpg.UpsertReleaseParams{}, err
+	}
+
 	major, minor, patch, pre := parseSemverParts(r.Version)
+	updatedAt := r.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = r.CreatedAt
+	}
 
 	return sqlcpg.UpsertReleaseParams{
 		ID:                r.ID,
@@ -333,6 +504,26 @@ func modelToPgUpsertRelease(r *models.Release) (sqlcpg.UpsertReleaseParams, erro
 		VersionMinor:      minor,
 		VersionPatch:      patch,
 		VersionPreRelease: pgtype.Text{String: pre, Valid: pre != ""},
+		Tags:              tags,
+
+		HostVersionConstraint: r.HostVersionConstraint,
+		Checksums:             checksums,
+		PgpSignature:          r.PGPSignature,
+		RequiredEntitlement:   r.RequiredEntitlement,
+		Editions:              editions,
+		ReleaseNotesDraft:     r.ReleaseNotesDraft,
+		Channel:               r.Channel,
+		Targeting:             targeting,
+		Variant:               r.Variant,
+		Variants:              variants,
+		Paused:                r.Paused,
+		Yanked:                r.Yanked,
+		YankReason:            r.YankReason,
+		VersionCode:           r.VersionCode,
+		SigningCertSha256:     r.SigningCertSHA256,
+		StatusChecks:          statusChecks,
+		ArtifactPending:       r.ArtifactPending,
+		UpdatedAt:             timeToPgTimestamptz(updatedAt),
 	}, nil
 }
 
@@ -449,7 +640,7 @@ func (ps *PostgresStorage) UpdateAPIKey(ctx context.Context, key *models.APIKey)
 		Name:        key.Name,
 		Permissions: []byte(permsJSON),
 		Enabled:     key.Enabled,
-		UpdatedAt:   timeToPgTimestamptz(time.Now().UTC()),
+		UpdatedAt:   timeToPgTimestamptz(ps.now().UTC()),
 	})
 	if err != nil {
 		return fmt.Errorf("update api key: %w", err)
@@ -474,8 +665,30 @@ func (ps *PostgresStorage) DeleteAPIKey(ctx context.Context, id string) error {
 
 // ListApplicationsPaged returns a page of applications sorted by created_at DESC, id DESC
 // and the total count. cursor, when non-nil, positions the query after the given item.
-func (ps *PostgresStorage) ListApplicationsPaged(ctx context.Context, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
+func (ps *PostgresStorage) ListApplicationsPaged(ctx context.Context, filters models.ApplicationFilters, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
 	args := []interface{}{}
+	conds := []string{}
+	if len(filters.Tags) > 0 {
+		tags, err := marshalTags(filters.Tags)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal tag filter: %w", err)
+		}
+		args = append(args, string(tags))
+		conds = append(conds, fmt.Sprintf("tags @> $%d::jsonb", len(args)))
+	}
+	if filters.Group != "" {
+		args = append(args, filters.Group)
+		conds = append(conds, fmt.Sprintf("group_name = $%d", len(args)))
+	}
+	if filters.ParentID != "" {
+		args = append(args, filters.ParentID)
+		conds = append(conds, fmt.Sprintf("parent_id = $%d", len(args)))
+	}
+	businessWhere := ""
+	if len(conds) > 0 {
+		businessWhere = "WHERE " + strings.Join(conds, " AND ")
+	}
+
 	keysetWhere := ""
 	if cursor != nil {
 		args = append(args,
@@ -488,16 +701,17 @@ func (ps *PostgresStorage) ListApplicationsPaged(ctx context.Context, limit int,
 	args = append(args, int64(limit))
 
 	query := fmt.Sprintf(`
-		SELECT id, name, description, platforms, config, created_at, updated_at, total_count
+		SELECT id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs, total_count
 		FROM (
-		    SELECT id, name, description, platforms, config, created_at, updated_at,
+		    SELECT id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs,
 		           COUNT(*) OVER() AS total_count
 		    FROM applications
+		    %s
 		) AS counted
 		%s
 		ORDER BY created_at DESC, id DESC
 		LIMIT $%d`,
-		keysetWhere, len(args))
+		businessWhere, keysetWhere, len(args))
 
 	pgxRows, err := ps.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -510,12 +724,15 @@ func (ps *PostgresStorage) ListApplicationsPaged(ctx context.Context, limit int,
 	for pgxRows.Next() {
 		var (
 			id, name             string
+			groupName, parentID  string
+			slug                 string
 			description          pgtype.Text
 			platforms, config    []byte
 			createdAt, updatedAt pgtype.Timestamptz
+			tags, formerSlugs    []byte
 			totalCount           int64
 		)
-		if err := pgxRows.Scan(&id, &name, &description, &platforms, &config, &createdAt, &updatedAt, &totalCount); err != nil {
+		if err := pgxRows.Scan(&id, &name, &description, &platforms, &config, &createdAt, &updatedAt, &tags, &groupName, &parentID, &slug, &formerSlugs, &totalCount); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan application: %w", err)
 		}
 		if total == 0 {
@@ -529,6 +746,11 @@ func (ps *PostgresStorage) ListApplicationsPaged(ctx context.Context, limit int,
 			Config:      config,
 			CreatedAt:   createdAt,
 			UpdatedAt:   updatedAt,
+			Tags:        tags,
+			GroupName:   groupName,
+			ParentID:    parentID,
+			Slug:        slug,
+			FormerSlugs: formerSlugs,
 		})
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to convert application %s: %w", id, err)
@@ -541,6 +763,19 @@ func (ps *PostgresStorage) ListApplicationsPaged(ctx context.Context, limit int,
 	return apps, total, nil
 }
 
+// ListApplicationGroups returns every distinct application group with its member count.
+func (ps *PostgresStorage) ListApplicationGroups(ctx context.Context) ([]models.ApplicationGroup, error) {
+	rows, err := ps.queries.ListApplicationGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application groups: %w", err)
+	}
+	groups := make([]models.ApplicationGroup, len(rows))
+	for i, row := range rows {
+		groups[i] = models.ApplicationGroup{Name: row.GroupName, ApplicationCount: int(row.ApplicationCount)}
+	}
+	return groups, nil
+}
+
 // pgReleaseListSortCols maps sortBy values to safe SQL ORDER BY fragments.
 // Version sort uses the split numeric columns for correct semver ordering.
 // Using an allowlist prevents SQL injection from untrusted sortBy values.
@@ -588,6 +823,14 @@ func (ps *PostgresStorage) ListReleasesPaged(ctx context.Context, appID string,
 		args = append(args, filters.Platforms)
 		businessWhere += fmt.Sprintf(" AND platform = ANY($%d::text[])", len(args))
 	}
+	if len(filters.Tags) > 0 {
+		tags, err := marshalTags(filters.Tags)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal tag filter: %w", err)
+		}
+		args = append(args, string(tags))
+		businessWhere += fmt.Sprintf(" AND tags @> $%d::jsonb", len(args))
+	}
 
 	// Keyset cursor condition (applied to outer query only).
 	keysetWhere := ""
@@ -660,14 +903,14 @@ func (ps *PostgresStorage) ListReleasesPaged(ctx context.Context, appID string,
 		SELECT id, application_id, version, platform, architecture, download_url,
 		       checksum, checksum_type, file_size, release_notes, release_date,
 		       required, minimum_version, metadata, created_at,
-		       version_major, version_minor, version_patch, version_pre_release,
-		       total_count
+		       version_major, version_minor, version_patch, version_pre_release, tags,
+		       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending, total_count
 		FROM (
 		    SELECT id, application_id, version, platform, architecture, download_url,
 		           checksum, checksum_type, file_size, release_notes, release_date,
 		           required, minimum_version, metadata, created_at,
-		           version_major, version_minor, version_patch, version_pre_release,
-		           COUNT(*) OVER() AS total_count
+		           version_major, version_minor, version_patch, version_pre_release, tags,
+		           host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending, COUNT(*) OVER() AS total_count
 		    FROM releases
 		    %s
 		) AS counted
@@ -698,6 +941,25 @@ func (ps *PostgresStorage) ListReleasesPaged(ctx context.Context, appID string,
 			createdAt                                            pgtype.Timestamptz
 			versionMajor, versionMinor, versionPatch             int64
 			versionPreRelease                                    pgtype.Text
+			tags                                                 []byte
+			hostVersionConstraint                                string
+			checksums                                            []byte
+			pgpSignature                                         string
+			requiredEntitlement                                  string
+			editions                                             []byte
+			releaseNotesDraft                                    string
+			updatedAt                                            pgtype.Timestamptz
+			channel                                              string
+			targeting                                            []byte
+			variant                                              string
+			variants                                             []byte
+			paused                                               bool
+			yanked                                               bool
+			yankReason                                           string
+			versionCode                                          int64
+			signingCertSHA256                                    string
+			statusChecks                                         []byte
+			artifactPending                                      bool
 			totalCount                                           int64
 		)
 		if err := pgxRows.Scan(
@@ -706,7 +968,7 @@ func (ps *PostgresStorage) ListReleasesPaged(ctx context.Context, appID string,
 			&releaseNotes, &releaseDate, &required, &minimumVersion,
 			&metadata, &createdAt,
 			&versionMajor, &versionMinor, &versionPatch, &versionPreRelease,
-			&totalCount,
+			&tags, &hostVersionConstraint, &checksums, &pgpSignature, &requiredEntitlement, &editions, &releaseNotesDraft, &updatedAt, &channel, &targeting, &variant, &variants, &paused, &yanked, &yankReason, &versionCode, &signingCertSHA256, &statusChecks, &artifactPending, &totalCount,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan release: %w", err)
 		}
@@ -733,6 +995,26 @@ func (ps *PostgresStorage) ListReleasesPaged(ctx context.Context, appID string,
 			VersionMinor:      versionMinor,
 			VersionPatch:      versionPatch,
 			VersionPreRelease: versionPreRelease,
+			Tags:              tags,
+
+			HostVersionConstraint: hostVersionConstraint,
+			Checksums:             checksums,
+			PgpSignature:          pgpSignature,
+			RequiredEntitlement:   requiredEntitlement,
+			Editions:              editions,
+			ReleaseNotesDraft:     releaseNotesDraft,
+			Channel:               channel,
+			Targeting:             targeting,
+			Variant:               variant,
+			Variants:              variants,
+			Paused:                paused,
+			Yanked:                yanked,
+			YankReason:            yankReason,
+			VersionCode:           versionCode,
+			SigningCertSha256:     signingCertSHA256,
+			StatusChecks:          statusChecks,
+			ArtifactPending:       artifactPending,
+			UpdatedAt:             updatedAt,
 		}
 		release, err := pgReleaseToModel(row)
 		if err != nil {
@@ -789,3 +1071,91 @@ func (ps *PostgresStorage) GetApplicationStats(ctx context.Context, appID string
 
 	return stats, nil
 }
+
+// SaveContainerImage stores or replaces a container image tag. The original
+// creation time is kept when an existing tag is replaced.
+func (ps *PostgresStorage) SaveContainerImage(ctx context.Context, image *models.ContainerImage) error {
+	platforms, err := marshalPlatforms(image.Platforms)
+	if err != nil {
+		return err
+	}
+
+	if err := ps.queries.UpsertContainerImage(ctx, sqlcpg.UpsertContainerImageParams{
+		ID:            image.ID,
+		ApplicationID: image.ApplicationID,
+		Repository:    image.Repository,
+		Tag:           image.Tag,
+		Digest:        image.Digest,
+		Platforms:     platforms,
+		ReleaseNotes:  image.ReleaseNotes,
+		Required:      image.Required,
+		CreatedAt:     timeToPgTimestamptz(image.CreatedAt),
+		UpdatedAt:     timeToPgTimestamptz(ps.now()),
+	}); err != nil {
+		return fmt.Errorf("failed to save container image: %w", err)
+	}
+	return nil
+}
+
+// ListContainerImages returns every container image tag of an application,
+// most recently created first.
+func (ps *PostgresStorage) ListContainerImages(ctx context.Context, appID string) ([]*models.ContainerImage, error) {
+	rows, err := ps.queries.ListContainerImages(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list container images: %w", err)
+	}
+
+	images := make([]*models.ContainerImage, 0, len(rows))
+	for _, row := range rows {
+		image, err := pgContainerImageToModel(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert container image %s: %w", row.ID, err)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// DeleteContainerImage removes a container image tag.
+// Returns ErrNotFound if the tag does not exist.
+func (ps *PostgresStorage) DeleteContainerImage(ctx context.Context, appID, tag string) error {
+	rows, err := ps.queries.DeleteContainerImage(ctx, sqlcpg.DeleteContainerImageParams{
+		ApplicationID: appID,
+		Tag:           tag,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete container image: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// pgContainerImageToModel converts a sqlcpg.ContainerImage row to a *models.ContainerImage.
+func pgContainerImageToModel(row sqlcpg.ContainerImage) (*models.ContainerImage, error) {
+	platforms, err := unmarshalPlatforms(row.Platforms)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &models.ContainerImage{
+		ID:            row.ID,
+		ApplicationID: row.ApplicationID,
+		Repository:    row.Repository,
+		Tag:           row.Tag,
+		Digest:        row.Digest,
+		Platforms:     platforms,
+		ReleaseNotes:  row.ReleaseNotes,
+		Required:      row.Required,
+	}
+
+	if row.CreatedAt.Valid {
+		image.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		image.UpdatedAt = row.UpdatedAt.Time
+	}
+
+	return image, nil
+}
@@ -2,7 +2,9 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 	"updater/internal/models"
@@ -84,7 +86,7 @@ func TestPostgresStorageApplicationCRUD(t *testing.T) {
 	}
 
 	// List applications
-	apps, _, err := s.ListApplicationsPaged(ctx, 50, nil)
+	apps, _, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 	if err != nil {
 		t.Fatalf("ListApplicationsPaged failed: %v", err)
 	}
@@ -140,6 +142,18 @@ func TestPostgresStorageReleaseCRUD(t *testing.T) {
 		t.Errorf("expected release notes, got %q", got.ReleaseNotes)
 	}
 
+	// Get release by ID
+	got, err = s.GetReleaseByID(ctx, release.ID)
+	if err != nil {
+		t.Fatalf("GetReleaseByID failed: %v", err)
+	}
+	if got.Version != "1.0.0" || got.Platform != "windows" {
+		t.Errorf("expected release 1.0.0 on windows, got %s on %s", got.Version, got.Platform)
+	}
+	if _, err := s.GetReleaseByID(ctx, "missing"); err == nil {
+		t.Error("expected error for non-existent release ID")
+	}
+
 	// Update release
 	release.DownloadURL = "https://example.com/v1.0.0-updated"
 	release.ReleaseNotes = "Updated release"
@@ -405,7 +419,7 @@ func TestPostgresStorage_ListApplicationsPaged(t *testing.T) {
 	}
 
 	t.Run("first page returns 2 apps total>=3", func(t *testing.T) {
-		apps, total, err := s.ListApplicationsPaged(ctx, 2, nil)
+		apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 2, nil)
 		if err != nil {
 			t.Fatalf("ListApplicationsPaged failed: %v", err)
 		}
@@ -418,7 +432,7 @@ func TestPostgresStorage_ListApplicationsPaged(t *testing.T) {
 	})
 
 	t.Run("all apps returned with large limit", func(t *testing.T) {
-		apps, total, err := s.ListApplicationsPaged(ctx, 1000, nil)
+		apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 1000, nil)
 		if err != nil {
 			t.Fatalf("ListApplicationsPaged large limit failed: %v", err)
 		}
@@ -711,3 +725,258 @@ func TestPostgresStorageSaveRelease_VersionSortColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestPostgresStorage_Tags(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+
+	app := models.NewApplication("pg-tagged-app", "Tagged", []string{"linux"})
+	app.Tags = []string{"pg-tag-filter"}
+	if err := s.SaveApplication(ctx, app); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{Tags: []string{"pg-tag-filter"}}, 50, nil)
+	if err != nil {
+		t.Fatalf("ListApplicationsPaged failed: %v", err)
+	}
+	if total != 1 || len(apps) != 1 {
+		t.Fatalf("expected 1 tagged app, got total=%d len=%d", total, len(apps))
+	}
+	if len(apps[0].Tags) != 1 || apps[0].Tags[0] != "pg-tag-filter" {
+		t.Errorf("expected tags [pg-tag-filter], got %v", apps[0].Tags)
+	}
+
+	for i, tags := range [][]string{{"hotfix", "security"}, {"hotfix"}} {
+		r := models.NewRelease("pg-tagged-app", "1.0."+string(rune('0'+i)), "linux", "amd64", "https://example.com/app")
+		r.Checksum = "abc123"
+		r.Tags = tags
+		if err := s.SaveRelease(ctx, r); err != nil {
+			t.Fatalf("SaveRelease failed: %v", err)
+		}
+	}
+
+	releases, total, err := s.ListReleasesPaged(ctx, "pg-tagged-app", models.ReleaseFilters{Tags: []string{"hotfix", "security"}}, "release_date", "desc", 50, nil)
+	if err != nil {
+		t.Fatalf("ListReleasesPaged failed: %v", err)
+	}
+	if total != 1 || len(releases) != 1 {
+		t.Fatalf("expected 1 release carrying both tags, got total=%d len=%d", total, len(releases))
+	}
+	if releases[0].Version != "1.0.0" {
+		t.Errorf("expected version 1.0.0, got %s", releases[0].Version)
+	}
+}
+
+func TestPostgresStorage_ApplicationGroups(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"pg-group-a", "pg-group-b"} {
+		app := models.NewApplication(id, id, []string{"linux"})
+		app.Group = "pg-test-group"
+		if err := s.SaveApplication(ctx, app); err != nil {
+			t.Fatalf("SaveApplication failed: %v", err)
+		}
+	}
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{Group: "pg-test-group"}, 50, nil)
+	if err != nil {
+		t.Fatalf("ListApplicationsPaged failed: %v", err)
+	}
+	if total != 2 || len(apps) != 2 {
+		t.Fatalf("expected 2 grouped apps, got total=%d len=%d", total, len(apps))
+	}
+
+	groups, err := s.ListApplicationGroups(ctx)
+	if err != nil {
+		t.Fatalf("ListApplicationGroups failed: %v", err)
+	}
+	found := false
+	for _, g := range groups {
+		if g.Name == "pg-test-group" {
+			found = true
+			if g.ApplicationCount != 2 {
+				t.Errorf("expected 2 applications in pg-test-group, got %d", g.ApplicationCount)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected pg-test-group in group listing")
+	}
+}
+
+func TestPostgresStorage_ApplicationSlugs(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+
+	app := models.NewApplication("pg-slug-app", "PG Slug App", []string{"linux"})
+	app.Slug = "pg-slug-current"
+	app.FormerSlugs = []string{"pg-slug-former"}
+	if err := s.SaveApplication(ctx, app); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+
+	for _, slug := range []string{"pg-slug-current", "pg-slug-former"} {
+		got, err := s.GetApplicationBySlug(ctx, slug)
+		if err != nil {
+			t.Fatalf("GetApplicationBySlug(%s) failed: %v", slug, err)
+		}
+		if got.ID != "pg-slug-app" || got.Slug != "pg-slug-current" {
+			t.Errorf("GetApplicationBySlug(%s) = %s/%s, want pg-slug-app/pg-slug-current", slug, got.ID, got.Slug)
+		}
+	}
+	if _, err := s.GetApplicationBySlug(ctx, "pg-slug-unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown slug, got %v", err)
+	}
+}
+
+func TestPostgresStorage_Plugins(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+
+	if err := s.SaveApplication(ctx, models.NewApplication("pg-plugin-host", "Host", []string{"linux"})); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+	plugin := models.NewApplication("pg-plugin", "Plugin", []string{"linux"})
+	plugin.ParentID = "pg-plugin-host"
+	if err := s.SaveApplication(ctx, plugin); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{ParentID: "pg-plugin-host"}, 50, nil)
+	if err != nil {
+		t.Fatalf("ListApplicationsPaged failed: %v", err)
+	}
+	if total != 1 || len(apps) != 1 || apps[0].ParentID != "pg-plugin-host" {
+		t.Fatalf("expected 1 plugin of pg-plugin-host, got total=%d len=%d", total, len(apps))
+	}
+
+	release := models.NewRelease("pg-plugin", "1.0.0", "linux", "amd64", "https://example.com/plugin")
+	release.HostVersionConstraint = ">= 2.0.0"
+	if err := s.SaveRelease(ctx, release); err != nil {
+		t.Fatalf("SaveRelease failed: %v", err)
+	}
+	got, err := s.GetRelease(ctx, "pg-plugin", "1.0.0", "linux", "amd64")
+	if err != nil {
+		t.Fatalf("GetRelease failed: %v", err)
+	}
+	if got.HostVersionConstraint != ">= 2.0.0" {
+		t.Errorf("expected host version constraint >= 2.0.0, got %q", got.HostVersionConstraint)
+	}
+}
+
+func TestPostgresStorage_ReleaseChecksums(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+
+	if err := s.SaveApplication(ctx, models.NewApplication("pg-checksums", "Checksums", []string{"linux"})); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+	release := models.NewRelease("pg-checksums", "1.0.0", "linux", "amd64", "https://example.com/app")
+	release.Checksums = map[string]string{"sha512": "def456", "blake3": "789abc"}
+	if err := s.SaveRelease(ctx, release); err != nil {
+		t.Fatalf("SaveRelease failed: %v", err)
+	}
+	got, err := s.GetRelease(ctx, "pg-checksums", "1.0.0", "linux", "amd64")
+	if err != nil {
+		t.Fatalf("GetRelease failed: %v", err)
+	}
+	if len(got.Checksums) != 2 || got.Checksums["sha512"] != "def456" || got.Checksums["blake3"] != "789abc" {
+		t.Errorf("expected sha512 and blake3 checksums, got %v", got.Checksums)
+	}
+}
+
+func TestPostgresStorage_SaveReleases(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+
+	if err := s.SaveApplication(ctx, models.NewApplication("pg-multi", "Multi", []string{"windows", "linux"})); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+	if err := s.SaveReleases(ctx, []*models.Release{
+		models.NewRelease("pg-multi", "1.0.0", "windows", "amd64", "https://example.com/w"),
+		models.NewRelease("pg-multi", "1.0.0", "linux", "amd64", "https://example.com/l"),
+	}); err != nil {
+		t.Fatalf("SaveReleases failed: %v", err)
+	}
+
+	err := s.SaveReleases(ctx, []*models.Release{
+		models.NewRelease("pg-multi", "2.0.0", "windows", "amd64", "https://example.com/w2"),
+		models.NewRelease("pg-missing-app", "2.0.0", "linux", "amd64", "https://example.com/l2"),
+	})
+	if err == nil {
+		t.Fatal("expected foreign key violation to fail the batch")
+	}
+	if _, err := s.GetRelease(ctx, "pg-multi", "2.0.0", "windows", "amd64"); err == nil {
+		t.Error("expected first release of a failed batch to be rolled back")
+	}
+}
+
+func TestPostgresStorage_ContainerImages(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+
+	if err := s.SaveApplication(ctx, models.NewApplication("pg-agent", "Agent", []string{"linux"})); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+	image := models.NewContainerImage("pg-agent", "ghcr.io/acme/agent", "1.0.0", "sha256:"+strings.Repeat("a", 64), []string{"linux/amd64", "linux/arm/v7"})
+	if err := s.SaveContainerImage(ctx, image); err != nil {
+		t.Fatalf("SaveContainerImage failed: %v", err)
+	}
+	repushed := models.NewContainerImage("pg-agent", "ghcr.io/acme/agent", "1.0.0", "sha256:"+strings.Repeat("b", 64), []string{"linux/amd64"})
+	if err := s.SaveContainerImage(ctx, repushed); err != nil {
+		t.Fatalf("SaveContainerImage (re-push) failed: %v", err)
+	}
+
+	images, err := s.ListContainerImages(ctx, "pg-agent")
+	if err != nil {
+		t.Fatalf("ListContainerImages failed: %v", err)
+	}
+	if len(images) != 1 {
+		t.Fatalf("expected 1 image, got %d", len(images))
+	}
+	if images[0].Digest != repushed.Digest {
+		t.Errorf("expected re-pushed digest %s, got %s", repushed.Digest, images[0].Digest)
+	}
+
+	if err := s.DeleteContainerImage(ctx, "pg-agent", "1.0.0"); err != nil {
+		t.Fatalf("DeleteContainerImage failed: %v", err)
+	}
+	if err := s.DeleteContainerImage(ctx, "pg-agent", "1.0.0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting a missing image, got %v", err)
+	}
+}
+
+func TestPostgresStorage_ReleaseUpdatedAt(t *testing.T) {
+	s := newPostgresTestStorage(t)
+	ctx := context.Background()
+	if err := s.SaveApplication(ctx, models.NewApplication("pg-upd-app", "PG Upd App", []string{"linux"})); err != nil {
+		t.Fatalf("SaveApplication failed: %v", err)
+	}
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	release := models.NewRelease("pg-upd-app", "1.0.0", "linux", "amd64", "https://example.com/pg-upd-app.tar.gz")
+	release.Checksum, release.ChecksumType = "abc123", "sha256"
+	release.ReleaseDate, release.CreatedAt, release.UpdatedAt = created, created, created
+	if err := s.SaveRelease(ctx, release); err != nil {
+		t.Fatalf("SaveRelease failed: %v", err)
+	}
+
+	updated := created.Add(time.Hour)
+	release.UpdatedAt = updated
+	if err := s.SaveRelease(ctx, release); err != nil {
+		t.Fatalf("SaveRelease failed: %v", err)
+	}
+
+	got, err := s.GetRelease(ctx, "pg-upd-app", "1.0.0", "linux", "amd64")
+	if err != nil {
+		t.Fatalf("GetRelease failed: %v", err)
+	}
+	if !got.CreatedAt.Equal(created) {
+		t.Errorf("expected created_at %v, got %v", created, got.CreatedAt)
+	}
+	if !got.UpdatedAt.Equal(updated) {
+		t.Errorf("expected updated_at %v, got %v", updated, got.UpdatedAt)
+	}
+}
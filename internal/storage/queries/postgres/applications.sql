@@ -1,30 +1,57 @@
 -- name: GetAllApplications :many
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 ORDER BY name;
 
 -- name: GetApplicationByID :one
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 WHERE id = $1;
 
+-- name: GetApplicationBySlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE slug = $1;
+
+-- name: GetApplicationByFormerSlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE former_slugs @> jsonb_build_array(sqlc.arg(slug)::text)
+LIMIT 1;
+
 -- name: UpsertApplication :exec
-INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 ON CONFLICT (id) DO UPDATE SET
     name = EXCLUDED.name,
     description = EXCLUDED.description,
     platforms = EXCLUDED.platforms,
     config = EXCLUDED.config,
-    updated_at = EXCLUDED.updated_at;
+    updated_at = EXCLUDED.updated_at,
+    tags = EXCLUDED.tags,
+    group_name = EXCLUDED.group_name,
+    parent_id = EXCLUDED.parent_id,
+    slug = EXCLUDED.slug,
+    former_slugs = EXCLUDED.former_slugs;
 
 -- name: DeleteApplication :exec
 DELETE FROM applications
 WHERE id = $1;
 
 -- name: GetApplicationsPaged :many
-SELECT id, name, description, platforms, config, created_at, updated_at,
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs,
        COUNT(*) OVER() AS total_count
 FROM applications
 ORDER BY name
-LIMIT $1::bigint OFFSET $2::bigint;
\ No newline at end of file
+LIMIT $1::bigint OFFSET $2::bigint;
+
+-- name: ListApplicationGroups :many
+SELECT group_name, COUNT(*) AS application_count
+FROM applications
+GROUP BY group_name
+ORDER BY group_name;
@@ -0,0 +1,24 @@
+-- name: UpsertContainerImage :exec
+INSERT INTO container_images (
+    id, application_id, repository, tag, digest, platforms,
+    release_notes, required, created_at, updated_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (application_id, tag) DO UPDATE SET
+    repository    = excluded.repository,
+    digest        = excluded.digest,
+    platforms     = excluded.platforms,
+    release_notes = excluded.release_notes,
+    required      = excluded.required,
+    updated_at    = excluded.updated_at;
+
+-- name: ListContainerImages :many
+SELECT id, application_id, repository, tag, digest, platforms,
+       release_notes, required, created_at, updated_at
+FROM container_images
+WHERE application_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: DeleteContainerImage :execrows
+DELETE FROM container_images
+WHERE application_id = $1 AND tag = $2;
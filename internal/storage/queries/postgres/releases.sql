@@ -2,7 +2,8 @@
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1
 ORDER BY release_date DESC;
@@ -11,7 +12,8 @@ ORDER BY release_date DESC;
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE id = $1;
 
@@ -19,7 +21,8 @@ WHERE id = $1;
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1 AND version = $2 AND platform = $3 AND architecture = $4;
 
@@ -27,7 +30,8 @@ WHERE application_id = $1 AND version = $2 AND platform = $3 AND architecture =
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1 AND platform = $2 AND architecture = $3
 ORDER BY release_date DESC;
@@ -37,23 +41,43 @@ INSERT INTO releases (
     id, application_id, version, platform, architecture, download_url,
     checksum, checksum_type, file_size, release_notes, release_date,
     required, minimum_version, metadata, created_at,
-    version_major, version_minor, version_patch, version_pre_release
+    version_major, version_minor, version_patch, version_pre_release, tags,
+    host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38)
 ON CONFLICT (application_id, version, platform, architecture) DO UPDATE SET
-    download_url        = EXCLUDED.download_url,
-    checksum            = EXCLUDED.checksum,
-    checksum_type       = EXCLUDED.checksum_type,
-    file_size           = EXCLUDED.file_size,
-    release_notes       = EXCLUDED.release_notes,
-    release_date        = EXCLUDED.release_date,
-    required            = EXCLUDED.required,
-    minimum_version     = EXCLUDED.minimum_version,
-    metadata            = EXCLUDED.metadata,
-    version_major       = EXCLUDED.version_major,
-    version_minor       = EXCLUDED.version_minor,
-    version_patch       = EXCLUDED.version_patch,
-    version_pre_release = EXCLUDED.version_pre_release;
+    download_url            = EXCLUDED.download_url,
+    checksum                = EXCLUDED.checksum,
+    checksum_type           = EXCLUDED.checksum_type,
+    file_size               = EXCLUDED.file_size,
+    release_notes           = EXCLUDED.release_notes,
+    release_date            = EXCLUDED.release_date,
+    required                = EXCLUDED.required,
+    minimum_version         = EXCLUDED.minimum_version,
+    metadata                = EXCLUDED.metadata,
+    version_major           = EXCLUDED.version_major,
+    version_minor           = EXCLUDED.version_minor,
+    version_patch           = EXCLUDED.version_patch,
+    version_pre_release     = EXCLUDED.version_pre_release,
+    tags                    = EXCLUDED.tags,
+    host_version_constraint = EXCLUDED.host_version_constraint,
+    checksums               = EXCLUDED.checksums,
+    pgp_signature           = EXCLUDED.pgp_signature,
+    required_entitlement    = EXCLUDED.required_entitlement,
+    editions                = EXCLUDED.editions,
+    release_notes_draft     = EXCLUDED.release_notes_draft,
+    updated_at              = EXCLUDED.updated_at,
+    channel                 = EXCLUDED.channel,
+    targeting               = EXCLUDED.targeting,
+    variant                 = EXCLUDED.variant,
+    variants                = EXCLUDED.variants,
+    paused                  = EXCLUDED.paused,
+    yanked                  = EXCLUDED.yanked,
+    yank_reason             = EXCLUDED.yank_reason,
+    version_code            = EXCLUDED.version_code,
+    signing_cert_sha256     = EXCLUDED.signing_cert_sha256,
+    status_checks           = EXCLUDED.status_checks,
+    artifact_pending        = EXCLUDED.artifact_pending;
 
 -- name: DeleteRelease :exec
 DELETE FROM releases
@@ -63,7 +87,8 @@ WHERE application_id = $1 AND version = $2 AND platform = $3 AND architecture =
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1 AND platform = $2 AND architecture = $3
   AND version_pre_release IS NULL
@@ -1,30 +1,57 @@
 -- name: GetAllApplications :many
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 ORDER BY name;
 
 -- name: GetApplicationByID :one
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 WHERE id = ?;
 
+-- name: GetApplicationBySlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE slug = ?;
+
+-- name: GetApplicationByFormerSlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE EXISTS (SELECT 1 FROM json_each(former_slugs) WHERE json_each.value = CAST(sqlc.arg(slug) AS TEXT))
+LIMIT 1;
+
 -- name: UpsertApplication :exec
-INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT (id) DO UPDATE SET
     name = excluded.name,
     description = excluded.description,
     platforms = excluded.platforms,
     config = excluded.config,
-    updated_at = excluded.updated_at;
+    updated_at = excluded.updated_at,
+    tags = excluded.tags,
+    group_name = excluded.group_name,
+    parent_id = excluded.parent_id,
+    slug = excluded.slug,
+    former_slugs = excluded.former_slugs;
 
 -- name: DeleteApplication :exec
 DELETE FROM applications
 WHERE id = ?;
 
 -- name: GetApplicationsPaged :many
-SELECT id, name, description, platforms, config, created_at, updated_at,
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs,
        COUNT(*) OVER() AS total_count
 FROM applications
 ORDER BY name
-LIMIT ? OFFSET ?;
\ No newline at end of file
+LIMIT ? OFFSET ?;
+
+-- name: ListApplicationGroups :many
+SELECT group_name, COUNT(*) AS application_count
+FROM applications
+GROUP BY group_name
+ORDER BY group_name;
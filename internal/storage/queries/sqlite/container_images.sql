@@ -0,0 +1,24 @@
+-- name: UpsertContainerImage :exec
+INSERT INTO container_images (
+    id, application_id, repository, tag, digest, platforms,
+    release_notes, required, created_at, updated_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (application_id, tag) DO UPDATE SET
+    repository    = excluded.repository,
+    digest        = excluded.digest,
+    platforms     = excluded.platforms,
+    release_notes = excluded.release_notes,
+    required      = excluded.required,
+    updated_at    = excluded.updated_at;
+
+-- name: ListContainerImages :many
+SELECT id, application_id, repository, tag, digest, platforms,
+       release_notes, required, created_at, updated_at
+FROM container_images
+WHERE application_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: DeleteContainerImage :execrows
+DELETE FROM container_images
+WHERE application_id = ? AND tag = ?;
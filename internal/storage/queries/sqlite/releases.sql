@@ -2,7 +2,8 @@
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ?
 ORDER BY release_date DESC;
@@ -11,7 +12,8 @@ ORDER BY release_date DESC;
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE id = ?;
 
@@ -19,7 +21,8 @@ WHERE id = ?;
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ? AND version = ? AND platform = ? AND architecture = ?;
 
@@ -27,7 +30,8 @@ WHERE application_id = ? AND version = ? AND platform = ? AND architecture = ?;
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ? AND platform = ? AND architecture = ?
 ORDER BY release_date DESC;
@@ -37,23 +41,43 @@ INSERT INTO releases (
     id, application_id, version, platform, architecture, download_url,
     checksum, checksum_type, file_size, release_notes, release_date,
     required, minimum_version, metadata, created_at,
-    version_major, version_minor, version_patch, version_pre_release
+    version_major, version_minor, version_patch, version_pre_release, tags,
+    host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT (application_id, version, platform, architecture) DO UPDATE SET
-    download_url        = excluded.download_url,
-    checksum            = excluded.checksum,
-    checksum_type       = excluded.checksum_type,
-    file_size           = excluded.file_size,
-    release_notes       = excluded.release_notes,
-    release_date        = excluded.release_date,
-    required            = excluded.required,
-    minimum_version     = excluded.minimum_version,
-    metadata            = excluded.metadata,
-    version_major       = excluded.version_major,
-    version_minor       = excluded.version_minor,
-    version_patch       = excluded.version_patch,
-    version_pre_release = excluded.version_pre_release;
+    download_url            = excluded.download_url,
+    checksum                = excluded.checksum,
+    checksum_type           = excluded.checksum_type,
+    file_size               = excluded.file_size,
+    release_notes           = excluded.release_notes,
+    release_date            = excluded.release_date,
+    required                = excluded.required,
+    minimum_version         = excluded.minimum_version,
+    metadata                = excluded.metadata,
+    version_major           = excluded.version_major,
+    version_minor           = excluded.version_minor,
+    version_patch           = excluded.version_patch,
+    version_pre_release     = excluded.version_pre_release,
+    tags                    = excluded.tags,
+    host_version_constraint = excluded.host_version_constraint,
+    checksums               = excluded.checksums,
+    pgp_signature           = excluded.pgp_signature,
+    required_entitlement    = excluded.required_entitlement,
+    editions                = excluded.editions,
+    release_notes_draft     = excluded.release_notes_draft,
+    updated_at              = excluded.updated_at,
+    channel                 = excluded.channel,
+    targeting               = excluded.targeting,
+    variant                 = excluded.variant,
+    variants                = excluded.variants,
+    paused                  = excluded.paused,
+    yanked                  = excluded.yanked,
+    yank_reason             = excluded.yank_reason,
+    version_code            = excluded.version_code,
+    signing_cert_sha256     = excluded.signing_cert_sha256,
+    status_checks           = excluded.status_checks,
+    artifact_pending        = excluded.artifact_pending;
 
 -- name: DeleteRelease :exec
 DELETE FROM releases
@@ -63,7 +87,8 @@ WHERE application_id = ? AND version = ? AND platform = ? AND architecture = ?;
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ? AND platform = ? AND architecture = ?
   AND version_pre_release IS NULL
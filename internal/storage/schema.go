@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"updater/internal/storage/migrations"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// SchemaChecker is implemented by storage backends whose schema is managed by
+// the embedded migrations.
+type SchemaChecker interface {
+	// HasPendingMigrations reports whether any embedded migration has not been
+	// applied to the database. Like the migrate command, it creates the empty
+	// goose version table in a database that was never migrated.
+	HasPendingMigrations(ctx context.Context) (bool, error)
+}
+
+// HasPendingMigrations reports whether the SQLite database is behind the
+// embedded migrations.
+func (ss *SQLiteStorage) HasPendingMigrations(ctx context.Context) (bool, error) {
+	return hasPendingMigrations(ctx, goose.DialectSQLite3, ss.db, migrations.SQLiteFS, "sqlite")
+}
+
+// HasPendingMigrations reports whether the PostgreSQL database is behind the
+// embedded migrations.
+func (ps *PostgresStorage) HasPendingMigrations(ctx context.Context) (bool, error) {
+	// Closing a *sql.DB opened from the pool leaves the pool open
+	db := stdlib.OpenDBFromPool(ps.pool)
+	defer db.Close()
+	return hasPendingMigrations(ctx, goose.DialectPostgres, db, migrations.PostgresFS, "postgres")
+}
+
+// hasPendingMigrations compares the goose version table with the migrations
+// in dir of fsys.
+func hasPendingMigrations(ctx context.Context, dialect goose.Dialect, db *sql.DB, fsys fs.FS, dir string) (bool, error) {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return false, err
+	}
+	provider, err := goose.NewProvider(dialect, db, sub)
+	if err != nil {
+		return false, fmt.Errorf("failed to create migration provider: %w", err)
+	}
+	pending, err := provider.HasPending(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to read migration versions: %w", err)
+	}
+	return pending, nil
+}
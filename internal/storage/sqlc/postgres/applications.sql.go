@@ -22,7 +22,8 @@ func (q *Queries) DeleteApplication(ctx context.Context, id string) error {
 }
 
 const getAllApplications = `-- name: GetAllApplications :many
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 ORDER BY name
 `
@@ -44,6 +45,11 @@ func (q *Queries) GetAllApplications(ctx context.Context) ([]Application, error)
 			&i.Config,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
+			&i.GroupName,
+			&i.ParentID,
+			&i.Slug,
+			&i.FormerSlugs,
 		); err != nil {
 			return nil, err
 		}
@@ -55,8 +61,37 @@ func (q *Queries) GetAllApplications(ctx context.Context) ([]Application, error)
 	return items, nil
 }
 
+const getApplicationByFormerSlug = `-- name: GetApplicationByFormerSlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE former_slugs @> jsonb_build_array($1::text)
+LIMIT 1
+`
+
+func (q *Queries) GetApplicationByFormerSlug(ctx context.Context, slug string) (Application, error) {
+	row := q.db.QueryRow(ctx, getApplicationByFormerSlug, slug)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Platforms,
+		&i.Config,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
+		&i.GroupName,
+		&i.ParentID,
+		&i.Slug,
+		&i.FormerSlugs,
+	)
+	return i, err
+}
+
 const getApplicationByID = `-- name: GetApplicationByID :one
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 WHERE id = $1
 `
@@ -72,12 +107,45 @@ func (q *Queries) GetApplicationByID(ctx context.Context, id string) (Applicatio
 		&i.Config,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tags,
+		&i.GroupName,
+		&i.ParentID,
+		&i.Slug,
+		&i.FormerSlugs,
+	)
+	return i, err
+}
+
+const getApplicationBySlug = `-- name: GetApplicationBySlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE slug = $1
+`
+
+func (q *Queries) GetApplicationBySlug(ctx context.Context, slug string) (Application, error) {
+	row := q.db.QueryRow(ctx, getApplicationBySlug, slug)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Platforms,
+		&i.Config,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
+		&i.GroupName,
+		&i.ParentID,
+		&i.Slug,
+		&i.FormerSlugs,
 	)
 	return i, err
 }
 
 const getApplicationsPaged = `-- name: GetApplicationsPaged :many
-SELECT id, name, description, platforms, config, created_at, updated_at,
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs,
        COUNT(*) OVER() AS total_count
 FROM applications
 ORDER BY name
@@ -97,6 +165,11 @@ type GetApplicationsPagedRow struct {
 	Config      []byte             `json:"config"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Tags        []byte             `json:"tags"`
+	GroupName   string             `json:"group_name"`
+	ParentID    string             `json:"parent_id"`
+	Slug        string             `json:"slug"`
+	FormerSlugs []byte             `json:"former_slugs"`
 	TotalCount  int64              `json:"total_count"`
 }
 
@@ -117,6 +190,11 @@ func (q *Queries) GetApplicationsPaged(ctx context.Context, arg GetApplicationsP
 			&i.Config,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
+			&i.GroupName,
+			&i.ParentID,
+			&i.Slug,
+			&i.FormerSlugs,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -129,15 +207,52 @@ func (q *Queries) GetApplicationsPaged(ctx context.Context, arg GetApplicationsP
 	return items, nil
 }
 
+const listApplicationGroups = `-- name: ListApplicationGroups :many
+SELECT group_name, COUNT(*) AS application_count
+FROM applications
+GROUP BY group_name
+ORDER BY group_name
+`
+
+type ListApplicationGroupsRow struct {
+	GroupName        string `json:"group_name"`
+	ApplicationCount int64  `json:"application_count"`
+}
+
+func (q *Queries) ListApplicationGroups(ctx context.Context) ([]ListApplicationGroupsRow, error) {
+	rows, err := q.db.Query(ctx, listApplicationGroups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListApplicationGroupsRow{}
+	for rows.Next() {
+		var i ListApplicationGroupsRow
+		if err := rows.Scan(&i.GroupName, &i.ApplicationCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertApplication = `-- name: UpsertApplication :exec
-INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 ON CONFLICT (id) DO UPDATE SET
     name = EXCLUDED.name,
     description = EXCLUDED.description,
     platforms = EXCLUDED.platforms,
     config = EXCLUDED.config,
-    updated_at = EXCLUDED.updated_at
+    updated_at = EXCLUDED.updated_at,
+    tags = EXCLUDED.tags,
+    group_name = EXCLUDED.group_name,
+    parent_id = EXCLUDED.parent_id,
+    slug = EXCLUDED.slug,
+    former_slugs = EXCLUDED.former_slugs
 `
 
 type UpsertApplicationParams struct {
@@ -148,6 +263,11 @@ type UpsertApplicationParams struct {
 	Config      []byte             `json:"config"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Tags        []byte             `json:"tags"`
+	GroupName   string             `json:"group_name"`
+	ParentID    string             `json:"parent_id"`
+	Slug        string             `json:"slug"`
+	FormerSlugs []byte             `json:"former_slugs"`
 }
 
 func (q *Queries) UpsertApplication(ctx context.Context, arg UpsertApplicationParams) error {
@@ -159,6 +279,11 @@ func (q *Queries) UpsertApplication(ctx context.Context, arg UpsertApplicationPa
 		arg.Config,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Tags,
+		arg.GroupName,
+		arg.ParentID,
+		arg.Slug,
+		arg.FormerSlugs,
 	)
 	return err
 }
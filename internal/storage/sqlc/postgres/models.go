@@ -27,26 +27,63 @@ type Application struct {
 	Config      []byte             `json:"config"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Tags        []byte             `json:"tags"`
+	GroupName   string             `json:"group_name"`
+	ParentID    string             `json:"parent_id"`
+	Slug        string             `json:"slug"`
+	FormerSlugs []byte             `json:"former_slugs"`
+}
+
+type ContainerImage struct {
+	ID            string             `json:"id"`
+	ApplicationID string             `json:"application_id"`
+	Repository    string             `json:"repository"`
+	Tag           string             `json:"tag"`
+	Digest        string             `json:"digest"`
+	Platforms     []byte             `json:"platforms"`
+	ReleaseNotes  string             `json:"release_notes"`
+	Required      bool               `json:"required"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Release struct {
-	ID                string             `json:"id"`
-	ApplicationID     string             `json:"application_id"`
-	Version           string             `json:"version"`
-	Platform          string             `json:"platform"`
-	Architecture      string             `json:"architecture"`
-	DownloadUrl       string             `json:"download_url"`
-	Checksum          string             `json:"checksum"`
-	ChecksumType      string             `json:"checksum_type"`
-	FileSize          int64              `json:"file_size"`
-	ReleaseNotes      pgtype.Text        `json:"release_notes"`
-	ReleaseDate       pgtype.Timestamptz `json:"release_date"`
-	Required          bool               `json:"required"`
-	MinimumVersion    pgtype.Text        `json:"minimum_version"`
-	Metadata          []byte             `json:"metadata"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	VersionMajor      int64              `json:"version_major"`
-	VersionMinor      int64              `json:"version_minor"`
-	VersionPatch      int64              `json:"version_patch"`
-	VersionPreRelease pgtype.Text        `json:"version_pre_release"`
+	ID                    string             `json:"id"`
+	ApplicationID         string             `json:"application_id"`
+	Version               string             `json:"version"`
+	Platform              string             `json:"platform"`
+	Architecture          string             `json:"architecture"`
+	DownloadUrl           string             `json:"download_url"`
+	Checksum              string             `json:"checksum"`
+	ChecksumType          string             `json:"checksum_type"`
+	FileSize              int64              `json:"file_size"`
+	ReleaseNotes          pgtype.Text        `json:"release_notes"`
+	ReleaseDate           pgtype.Timestamptz `json:"release_date"`
+	Required              bool               `json:"required"`
+	MinimumVersion        pgtype.Text        `json:"minimum_version"`
+	Metadata              []byte             `json:"metadata"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	VersionMajor          int64              `json:"version_major"`
+	VersionMinor          int64              `json:"version_minor"`
+	VersionPatch          int64              `json:"version_patch"`
+	VersionPreRelease     pgtype.Text        `json:"version_pre_release"`
+	Tags                  []byte             `json:"tags"`
+	HostVersionConstraint string             `json:"host_version_constraint"`
+	Checksums             []byte             `json:"checksums"`
+	PgpSignature          string             `json:"pgp_signature"`
+	RequiredEntitlement   string             `json:"required_entitlement"`
+	Editions              []byte             `json:"editions"`
+	ReleaseNotesDraft     string             `json:"release_notes_draft"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	Channel               string             `json:"channel"`
+	Targeting             []byte             `json:"targeting"`
+	Variant               string             `json:"variant"`
+	Variants              []byte             `json:"variants"`
+	Paused                bool               `json:"paused"`
+	Yanked                bool               `json:"yanked"`
+	YankReason            string             `json:"yank_reason"`
+	VersionCode           int64              `json:"version_code"`
+	SigningCertSha256     string             `json:"signing_cert_sha256"`
+	StatusChecks          []byte             `json:"status_checks"`
+	ArtifactPending       bool               `json:"artifact_pending"`
 }
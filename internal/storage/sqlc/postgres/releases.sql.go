@@ -35,7 +35,7 @@ func (q *Queries) DeleteRelease(ctx context.Context, arg DeleteReleaseParams) er
 
 const getApplicationStats = `-- name: GetApplicationStats :one
 WITH app_releases AS (
-    SELECT id, application_id, version, platform, architecture, download_url, checksum, checksum_type, file_size, release_notes, release_date, required, minimum_version, metadata, created_at, version_major, version_minor, version_patch, version_pre_release FROM releases WHERE application_id = $1
+    SELECT id, application_id, version, platform, architecture, download_url, checksum, checksum_type, file_size, release_notes, release_date, required, minimum_version, metadata, created_at, version_major, version_minor, version_patch, version_pre_release, tags, host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending FROM releases WHERE application_id = $1
 )
 SELECT
     COUNT(*) AS total_releases,
@@ -77,7 +77,8 @@ const getLatestStableRelease = `-- name: GetLatestStableRelease :one
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1 AND platform = $2 AND architecture = $3
   AND version_pre_release IS NULL
@@ -114,6 +115,25 @@ func (q *Queries) GetLatestStableRelease(ctx context.Context, arg GetLatestStabl
 		&i.VersionMinor,
 		&i.VersionPatch,
 		&i.VersionPreRelease,
+		&i.Tags,
+		&i.HostVersionConstraint,
+		&i.Checksums,
+		&i.PgpSignature,
+		&i.RequiredEntitlement,
+		&i.Editions,
+		&i.ReleaseNotesDraft,
+		&i.UpdatedAt,
+		&i.Channel,
+		&i.Targeting,
+		&i.Variant,
+		&i.Variants,
+		&i.Paused,
+		&i.Yanked,
+		&i.YankReason,
+		&i.VersionCode,
+		&i.SigningCertSha256,
+		&i.StatusChecks,
+		&i.ArtifactPending,
 	)
 	return i, err
 }
@@ -122,7 +142,8 @@ const getRelease = `-- name: GetRelease :one
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1 AND version = $2 AND platform = $3 AND architecture = $4
 `
@@ -162,6 +183,25 @@ func (q *Queries) GetRelease(ctx context.Context, arg GetReleaseParams) (Release
 		&i.VersionMinor,
 		&i.VersionPatch,
 		&i.VersionPreRelease,
+		&i.Tags,
+		&i.HostVersionConstraint,
+		&i.Checksums,
+		&i.PgpSignature,
+		&i.RequiredEntitlement,
+		&i.Editions,
+		&i.ReleaseNotesDraft,
+		&i.UpdatedAt,
+		&i.Channel,
+		&i.Targeting,
+		&i.Variant,
+		&i.Variants,
+		&i.Paused,
+		&i.Yanked,
+		&i.YankReason,
+		&i.VersionCode,
+		&i.SigningCertSha256,
+		&i.StatusChecks,
+		&i.ArtifactPending,
 	)
 	return i, err
 }
@@ -170,7 +210,8 @@ const getReleaseByID = `-- name: GetReleaseByID :one
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE id = $1
 `
@@ -198,6 +239,25 @@ func (q *Queries) GetReleaseByID(ctx context.Context, id string) (Release, error
 		&i.VersionMinor,
 		&i.VersionPatch,
 		&i.VersionPreRelease,
+		&i.Tags,
+		&i.HostVersionConstraint,
+		&i.Checksums,
+		&i.PgpSignature,
+		&i.RequiredEntitlement,
+		&i.Editions,
+		&i.ReleaseNotesDraft,
+		&i.UpdatedAt,
+		&i.Channel,
+		&i.Targeting,
+		&i.Variant,
+		&i.Variants,
+		&i.Paused,
+		&i.Yanked,
+		&i.YankReason,
+		&i.VersionCode,
+		&i.SigningCertSha256,
+		&i.StatusChecks,
+		&i.ArtifactPending,
 	)
 	return i, err
 }
@@ -206,7 +266,8 @@ const getReleasesByAppID = `-- name: GetReleasesByAppID :many
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1
 ORDER BY release_date DESC
@@ -241,6 +302,25 @@ func (q *Queries) GetReleasesByAppID(ctx context.Context, applicationID string)
 			&i.VersionMinor,
 			&i.VersionPatch,
 			&i.VersionPreRelease,
+			&i.Tags,
+			&i.HostVersionConstraint,
+			&i.Checksums,
+			&i.PgpSignature,
+			&i.RequiredEntitlement,
+			&i.Editions,
+			&i.ReleaseNotesDraft,
+			&i.UpdatedAt,
+			&i.Channel,
+			&i.Targeting,
+			&i.Variant,
+			&i.Variants,
+			&i.Paused,
+			&i.Yanked,
+			&i.YankReason,
+			&i.VersionCode,
+			&i.SigningCertSha256,
+			&i.StatusChecks,
+			&i.ArtifactPending,
 		); err != nil {
 			return nil, err
 		}
@@ -256,7 +336,8 @@ const getReleasesByPlatformArch = `-- name: GetReleasesByPlatformArch :many
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = $1 AND platform = $2 AND architecture = $3
 ORDER BY release_date DESC
@@ -297,6 +378,25 @@ func (q *Queries) GetReleasesByPlatformArch(ctx context.Context, arg GetReleases
 			&i.VersionMinor,
 			&i.VersionPatch,
 			&i.VersionPreRelease,
+			&i.Tags,
+			&i.HostVersionConstraint,
+			&i.Checksums,
+			&i.PgpSignature,
+			&i.RequiredEntitlement,
+			&i.Editions,
+			&i.ReleaseNotesDraft,
+			&i.UpdatedAt,
+			&i.Channel,
+			&i.Targeting,
+			&i.Variant,
+			&i.Variants,
+			&i.Paused,
+			&i.Yanked,
+			&i.YankReason,
+			&i.VersionCode,
+			&i.SigningCertSha256,
+			&i.StatusChecks,
+			&i.ArtifactPending,
 		); err != nil {
 			return nil, err
 		}
@@ -313,45 +413,84 @@ INSERT INTO releases (
     id, application_id, version, platform, architecture, download_url,
     checksum, checksum_type, file_size, release_notes, release_date,
     required, minimum_version, metadata, created_at,
-    version_major, version_minor, version_patch, version_pre_release
+    version_major, version_minor, version_patch, version_pre_release, tags,
+    host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38)
 ON CONFLICT (application_id, version, platform, architecture) DO UPDATE SET
-    download_url        = EXCLUDED.download_url,
-    checksum            = EXCLUDED.checksum,
-    checksum_type       = EXCLUDED.checksum_type,
-    file_size           = EXCLUDED.file_size,
-    release_notes       = EXCLUDED.release_notes,
-    release_date        = EXCLUDED.release_date,
-    required            = EXCLUDED.required,
-    minimum_version     = EXCLUDED.minimum_version,
-    metadata            = EXCLUDED.metadata,
-    version_major       = EXCLUDED.version_major,
-    version_minor       = EXCLUDED.version_minor,
-    version_patch       = EXCLUDED.version_patch,
-    version_pre_release = EXCLUDED.version_pre_release
+    download_url            = EXCLUDED.download_url,
+    checksum                = EXCLUDED.checksum,
+    checksum_type           = EXCLUDED.checksum_type,
+    file_size               = EXCLUDED.file_size,
+    release_notes           = EXCLUDED.release_notes,
+    release_date            = EXCLUDED.release_date,
+    required                = EXCLUDED.required,
+    minimum_version         = EXCLUDED.minimum_version,
+    metadata                = EXCLUDED.metadata,
+    version_major           = EXCLUDED.version_major,
+    version_minor           = EXCLUDED.version_minor,
+    version_patch           = EXCLUDED.version_patch,
+    version_pre_release     = EXCLUDED.version_pre_release,
+    tags                    = EXCLUDED.tags,
+    host_version_constraint = EXCLUDED.host_version_constraint,
+    checksums               = EXCLUDED.checksums,
+    pgp_signature           = EXCLUDED.pgp_signature,
+    required_entitlement    = EXCLUDED.required_entitlement,
+    editions                = EXCLUDED.editions,
+    release_notes_draft     = EXCLUDED.release_notes_draft,
+    updated_at              = EXCLUDED.updated_at,
+    channel                 = EXCLUDED.channel,
+    targeting               = EXCLUDED.targeting,
+    variant                 = EXCLUDED.variant,
+    variants                = EXCLUDED.variants,
+    paused                  = EXCLUDED.paused,
+    yanked                  = EXCLUDED.yanked,
+    yank_reason             = EXCLUDED.yank_reason,
+    version_code            = EXCLUDED.version_code,
+    signing_cert_sha256     = EXCLUDED.signing_cert_sha256,
+    status_checks           = EXCLUDED.status_checks,
+    artifact_pending        = EXCLUDED.artifact_pending
 `
 
 type UpsertReleaseParams struct {
-	ID                string             `json:"id"`
-	ApplicationID     string             `json:"application_id"`
-	Version           string             `json:"version"`
-	Platform          string             `json:"platform"`
-	Architecture      string             `json:"architecture"`
-	DownloadUrl       string             `json:"download_url"`
-	Checksum          string             `json:"checksum"`
-	ChecksumType      string             `json:"checksum_type"`
-	FileSize          int64              `json:"file_size"`
-	ReleaseNotes      pgtype.Text        `json:"release_notes"`
-	ReleaseDate       pgtype.Timestamptz `json:"release_date"`
-	Required          bool               `json:"required"`
-	MinimumVersion    pgtype.Text        `json:"minimum_version"`
-	Metadata          []byte             `json:"metadata"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	VersionMajor      int64              `json:"version_major"`
-	VersionMinor      int64              `json:"version_minor"`
-	VersionPatch      int64              `json:"version_patch"`
-	VersionPreRelease pgtype.Text        `json:"version_pre_release"`
+	ID                    string             `json:"id"`
+	ApplicationID         string             `json:"application_id"`
+	Version               string             `json:"version"`
+	Platform              string             `json:"platform"`
+	Architecture          string             `json:"architecture"`
+	DownloadUrl           string             `json:"download_url"`
+	Checksum              string             `json:"checksum"`
+	ChecksumType          string             `json:"checksum_type"`
+	FileSize              int64              `json:"file_size"`
+	ReleaseNotes          pgtype.Text        `json:"release_notes"`
+	ReleaseDate           pgtype.Timestamptz `json:"release_date"`
+	Required              bool               `json:"required"`
+	MinimumVersion        pgtype.Text        `json:"minimum_version"`
+	Metadata              []byte             `json:"metadata"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	VersionMajor          int64              `json:"version_major"`
+	VersionMinor          int64              `json:"version_minor"`
+	VersionPatch          int64              `json:"version_patch"`
+	VersionPreRelease     pgtype.Text        `json:"version_pre_release"`
+	Tags                  []byte             `json:"tags"`
+	HostVersionConstraint string             `json:"host_version_constraint"`
+	Checksums             []byte             `json:"checksums"`
+	PgpSignature          string             `json:"pgp_signature"`
+	RequiredEntitlement   string             `json:"required_entitlement"`
+	Editions              []byte             `json:"editions"`
+	ReleaseNotesDraft     string             `json:"release_notes_draft"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	Channel               string             `json:"channel"`
+	Targeting             []byte             `json:"targeting"`
+	Variant               string             `json:"variant"`
+	Variants              []byte             `json:"variants"`
+	Paused                bool               `json:"paused"`
+	Yanked                bool               `json:"yanked"`
+	YankReason            string             `json:"yank_reason"`
+	VersionCode           int64              `json:"version_code"`
+	SigningCertSha256     string             `json:"signing_cert_sha256"`
+	StatusChecks          []byte             `json:"status_checks"`
+	ArtifactPending       bool               `json:"artifact_pending"`
 }
 
 func (q *Queries) UpsertRelease(ctx context.Context, arg UpsertReleaseParams) error {
@@ -375,6 +514,25 @@ func (q *Queries) UpsertRelease(ctx context.Context, arg UpsertReleaseParams) er
 		arg.VersionMinor,
 		arg.VersionPatch,
 		arg.VersionPreRelease,
+		arg.Tags,
+		arg.HostVersionConstraint,
+		arg.Checksums,
+		arg.PgpSignature,
+		arg.RequiredEntitlement,
+		arg.Editions,
+		arg.ReleaseNotesDraft,
+		arg.UpdatedAt,
+		arg.Channel,
+		arg.Targeting,
+		arg.Variant,
+		arg.Variants,
+		arg.Paused,
+		arg.Yanked,
+		arg.YankReason,
+		arg.VersionCode,
+		arg.SigningCertSha256,
+		arg.StatusChecks,
+		arg.ArtifactPending,
 	)
 	return err
 }
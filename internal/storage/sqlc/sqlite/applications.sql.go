@@ -21,7 +21,8 @@ func (q *Queries) DeleteApplication(ctx context.Context, id string) error {
 }
 
 const getAllApplications = `-- name: GetAllApplications :many
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 ORDER BY name
 `
@@ -43,6 +44,11 @@ func (q *Queries) GetAllApplications(ctx context.Context) ([]Application, error)
 			&i.Config,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
+			&i.GroupName,
+			&i.ParentID,
+			&i.Slug,
+			&i.FormerSlugs,
 		); err != nil {
 			return nil, err
 		}
@@ -57,8 +63,37 @@ func (q *Queries) GetAllApplications(ctx context.Context) ([]Application, error)
 	return items, nil
 }
 
+const getApplicationByFormerSlug = `-- name: GetApplicationByFormerSlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE EXISTS (SELECT 1 FROM json_each(former_slugs) WHERE json_each.value = CAST(? AS TEXT))
+LIMIT 1
+`
+
+func (q *Queries) GetApplicationByFormerSlug(ctx context.Context, slug string) (Application, error) {
+	row := q.db.QueryRowContext(ctx, getApplicationByFormerSlug, slug)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Platforms,
+		&i.Config,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
+		&i.GroupName,
+		&i.ParentID,
+		&i.Slug,
+		&i.FormerSlugs,
+	)
+	return i, err
+}
+
 const getApplicationByID = `-- name: GetApplicationByID :one
-SELECT id, name, description, platforms, config, created_at, updated_at
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
 FROM applications
 WHERE id = ?
 `
@@ -74,12 +109,45 @@ func (q *Queries) GetApplicationByID(ctx context.Context, id string) (Applicatio
 		&i.Config,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tags,
+		&i.GroupName,
+		&i.ParentID,
+		&i.Slug,
+		&i.FormerSlugs,
+	)
+	return i, err
+}
+
+const getApplicationBySlug = `-- name: GetApplicationBySlug :one
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs
+FROM applications
+WHERE slug = ?
+`
+
+func (q *Queries) GetApplicationBySlug(ctx context.Context, slug string) (Application, error) {
+	row := q.db.QueryRowContext(ctx, getApplicationBySlug, slug)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Platforms,
+		&i.Config,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
+		&i.GroupName,
+		&i.ParentID,
+		&i.Slug,
+		&i.FormerSlugs,
 	)
 	return i, err
 }
 
 const getApplicationsPaged = `-- name: GetApplicationsPaged :many
-SELECT id, name, description, platforms, config, created_at, updated_at,
+SELECT id, name, description, platforms, config, created_at, updated_at, tags,
+       group_name, parent_id, slug, former_slugs,
        COUNT(*) OVER() AS total_count
 FROM applications
 ORDER BY name
@@ -99,6 +167,11 @@ type GetApplicationsPagedRow struct {
 	Config      string         `json:"config"`
 	CreatedAt   string         `json:"created_at"`
 	UpdatedAt   string         `json:"updated_at"`
+	Tags        string         `json:"tags"`
+	GroupName   string         `json:"group_name"`
+	ParentID    string         `json:"parent_id"`
+	Slug        string         `json:"slug"`
+	FormerSlugs string         `json:"former_slugs"`
 	TotalCount  int64          `json:"total_count"`
 }
 
@@ -119,6 +192,11 @@ func (q *Queries) GetApplicationsPaged(ctx context.Context, arg GetApplicationsP
 			&i.Config,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
+			&i.GroupName,
+			&i.ParentID,
+			&i.Slug,
+			&i.FormerSlugs,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -134,15 +212,55 @@ func (q *Queries) GetApplicationsPaged(ctx context.Context, arg GetApplicationsP
 	return items, nil
 }
 
+const listApplicationGroups = `-- name: ListApplicationGroups :many
+SELECT group_name, COUNT(*) AS application_count
+FROM applications
+GROUP BY group_name
+ORDER BY group_name
+`
+
+type ListApplicationGroupsRow struct {
+	GroupName        string `json:"group_name"`
+	ApplicationCount int64  `json:"application_count"`
+}
+
+func (q *Queries) ListApplicationGroups(ctx context.Context) ([]ListApplicationGroupsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listApplicationGroups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListApplicationGroupsRow{}
+	for rows.Next() {
+		var i ListApplicationGroupsRow
+		if err := rows.Scan(&i.GroupName, &i.ApplicationCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertApplication = `-- name: UpsertApplication :exec
-INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO applications (id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT (id) DO UPDATE SET
     name = excluded.name,
     description = excluded.description,
     platforms = excluded.platforms,
     config = excluded.config,
-    updated_at = excluded.updated_at
+    updated_at = excluded.updated_at,
+    tags = excluded.tags,
+    group_name = excluded.group_name,
+    parent_id = excluded.parent_id,
+    slug = excluded.slug,
+    former_slugs = excluded.former_slugs
 `
 
 type UpsertApplicationParams struct {
@@ -153,6 +271,11 @@ type UpsertApplicationParams struct {
 	Config      string         `json:"config"`
 	CreatedAt   string         `json:"created_at"`
 	UpdatedAt   string         `json:"updated_at"`
+	Tags        string         `json:"tags"`
+	GroupName   string         `json:"group_name"`
+	ParentID    string         `json:"parent_id"`
+	Slug        string         `json:"slug"`
+	FormerSlugs string         `json:"former_slugs"`
 }
 
 func (q *Queries) UpsertApplication(ctx context.Context, arg UpsertApplicationParams) error {
@@ -164,6 +287,11 @@ func (q *Queries) UpsertApplication(ctx context.Context, arg UpsertApplicationPa
 		arg.Config,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Tags,
+		arg.GroupName,
+		arg.ParentID,
+		arg.Slug,
+		arg.FormerSlugs,
 	)
 	return err
 }
@@ -34,7 +34,7 @@ func (q *Queries) DeleteRelease(ctx context.Context, arg DeleteReleaseParams) er
 
 const getApplicationStats = `-- name: GetApplicationStats :one
 WITH app_releases AS (
-    SELECT id, application_id, version, platform, architecture, download_url, checksum, checksum_type, file_size, release_notes, release_date, required, minimum_version, metadata, created_at, version_major, version_minor, version_patch, version_pre_release FROM releases WHERE application_id = ?
+    SELECT id, application_id, version, platform, architecture, download_url, checksum, checksum_type, file_size, release_notes, release_date, required, minimum_version, metadata, created_at, version_major, version_minor, version_patch, version_pre_release, tags, host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending FROM releases WHERE application_id = ?
 )
 SELECT
     COUNT(*) AS total_releases,
@@ -76,7 +76,8 @@ const getLatestStableRelease = `-- name: GetLatestStableRelease :one
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ? AND platform = ? AND architecture = ?
   AND version_pre_release IS NULL
@@ -113,6 +114,25 @@ func (q *Queries) GetLatestStableRelease(ctx context.Context, arg GetLatestStabl
 		&i.VersionMinor,
 		&i.VersionPatch,
 		&i.VersionPreRelease,
+		&i.Tags,
+		&i.HostVersionConstraint,
+		&i.Checksums,
+		&i.PgpSignature,
+		&i.RequiredEntitlement,
+		&i.Editions,
+		&i.ReleaseNotesDraft,
+		&i.UpdatedAt,
+		&i.Channel,
+		&i.Targeting,
+		&i.Variant,
+		&i.Variants,
+		&i.Paused,
+		&i.Yanked,
+		&i.YankReason,
+		&i.VersionCode,
+		&i.SigningCertSha256,
+		&i.StatusChecks,
+		&i.ArtifactPending,
 	)
 	return i, err
 }
@@ -121,7 +141,8 @@ const getRelease = `-- name: GetRelease :one
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ? AND version = ? AND platform = ? AND architecture = ?
 `
@@ -161,6 +182,25 @@ func (q *Queries) GetRelease(ctx context.Context, arg GetReleaseParams) (Release
 		&i.VersionMinor,
 		&i.VersionPatch,
 		&i.VersionPreRelease,
+		&i.Tags,
+		&i.HostVersionConstraint,
+		&i.Checksums,
+		&i.PgpSignature,
+		&i.RequiredEntitlement,
+		&i.Editions,
+		&i.ReleaseNotesDraft,
+		&i.UpdatedAt,
+		&i.Channel,
+		&i.Targeting,
+		&i.Variant,
+		&i.Variants,
+		&i.Paused,
+		&i.Yanked,
+		&i.YankReason,
+		&i.VersionCode,
+		&i.SigningCertSha256,
+		&i.StatusChecks,
+		&i.ArtifactPending,
 	)
 	return i, err
 }
@@ -169,7 +209,8 @@ const getReleaseByID = `-- name: GetReleaseByID :one
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE id = ?
 `
@@ -197,6 +238,25 @@ func (q *Queries) GetReleaseByID(ctx context.Context, id string) (Release, error
 		&i.VersionMinor,
 		&i.VersionPatch,
 		&i.VersionPreRelease,
+		&i.Tags,
+		&i.HostVersionConstraint,
+		&i.Checksums,
+		&i.PgpSignature,
+		&i.RequiredEntitlement,
+		&i.Editions,
+		&i.ReleaseNotesDraft,
+		&i.UpdatedAt,
+		&i.Channel,
+		&i.Targeting,
+		&i.Variant,
+		&i.Variants,
+		&i.Paused,
+		&i.Yanked,
+		&i.YankReason,
+		&i.VersionCode,
+		&i.SigningCertSha256,
+		&i.StatusChecks,
+		&i.ArtifactPending,
 	)
 	return i, err
 }
@@ -205,7 +265,8 @@ const getReleasesByAppID = `-- name: GetReleasesByAppID :many
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ?
 ORDER BY release_date DESC
@@ -240,6 +301,25 @@ func (q *Queries) GetReleasesByAppID(ctx context.Context, applicationID string)
 			&i.VersionMinor,
 			&i.VersionPatch,
 			&i.VersionPreRelease,
+			&i.Tags,
+			&i.HostVersionConstraint,
+			&i.Checksums,
+			&i.PgpSignature,
+			&i.RequiredEntitlement,
+			&i.Editions,
+			&i.ReleaseNotesDraft,
+			&i.UpdatedAt,
+			&i.Channel,
+			&i.Targeting,
+			&i.Variant,
+			&i.Variants,
+			&i.Paused,
+			&i.Yanked,
+			&i.YankReason,
+			&i.VersionCode,
+			&i.SigningCertSha256,
+			&i.StatusChecks,
+			&i.ArtifactPending,
 		); err != nil {
 			return nil, err
 		}
@@ -258,7 +338,8 @@ const getReleasesByPlatformArch = `-- name: GetReleasesByPlatformArch :many
 SELECT id, application_id, version, platform, architecture, download_url,
        checksum, checksum_type, file_size, release_notes, release_date,
        required, minimum_version, metadata, created_at,
-       version_major, version_minor, version_patch, version_pre_release
+       version_major, version_minor, version_patch, version_pre_release, tags,
+       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 FROM releases
 WHERE application_id = ? AND platform = ? AND architecture = ?
 ORDER BY release_date DESC
@@ -299,6 +380,25 @@ func (q *Queries) GetReleasesByPlatformArch(ctx context.Context, arg GetReleases
 			&i.VersionMinor,
 			&i.VersionPatch,
 			&i.VersionPreRelease,
+			&i.Tags,
+			&i.HostVersionConstraint,
+			&i.Checksums,
+			&i.PgpSignature,
+			&i.RequiredEntitlement,
+			&i.Editions,
+			&i.ReleaseNotesDraft,
+			&i.UpdatedAt,
+			&i.Channel,
+			&i.Targeting,
+			&i.Variant,
+			&i.Variants,
+			&i.Paused,
+			&i.Yanked,
+			&i.YankReason,
+			&i.VersionCode,
+			&i.SigningCertSha256,
+			&i.StatusChecks,
+			&i.ArtifactPending,
 		); err != nil {
 			return nil, err
 		}
@@ -318,45 +418,84 @@ INSERT INTO releases (
     id, application_id, version, platform, architecture, download_url,
     checksum, checksum_type, file_size, release_notes, release_date,
     required, minimum_version, metadata, created_at,
-    version_major, version_minor, version_patch, version_pre_release
+    version_major, version_minor, version_patch, version_pre_release, tags,
+    host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT (application_id, version, platform, architecture) DO UPDATE SET
-    download_url        = excluded.download_url,
-    checksum            = excluded.checksum,
-    checksum_type       = excluded.checksum_type,
-    file_size           = excluded.file_size,
-    release_notes       = excluded.release_notes,
-    release_date        = excluded.release_date,
-    required            = excluded.required,
-    minimum_version     = excluded.minimum_version,
-    metadata            = excluded.metadata,
-    version_major       = excluded.version_major,
-    version_minor       = excluded.version_minor,
-    version_patch       = excluded.version_patch,
-    version_pre_release = excluded.version_pre_release
+    download_url            = excluded.download_url,
+    checksum                = excluded.checksum,
+    checksum_type           = excluded.checksum_type,
+    file_size               = excluded.file_size,
+    release_notes           = excluded.release_notes,
+    release_date            = excluded.release_date,
+    required                = excluded.required,
+    minimum_version         = excluded.minimum_version,
+    metadata                = excluded.metadata,
+    version_major           = excluded.version_major,
+    version_minor           = excluded.version_minor,
+    version_patch           = excluded.version_patch,
+    version_pre_release     = excluded.version_pre_release,
+    tags                    = excluded.tags,
+    host_version_constraint = excluded.host_version_constraint,
+    checksums               = excluded.checksums,
+    pgp_signature           = excluded.pgp_signature,
+    required_entitlement    = excluded.required_entitlement,
+    editions                = excluded.editions,
+    release_notes_draft     = excluded.release_notes_draft,
+    updated_at              = excluded.updated_at,
+    channel                 = excluded.channel,
+    targeting               = excluded.targeting,
+    variant                 = excluded.variant,
+    variants                = excluded.variants,
+    paused                  = excluded.paused,
+    yanked                  = excluded.yanked,
+    yank_reason             = excluded.yank_reason,
+    version_code            = excluded.version_code,
+    signing_cert_sha256     = excluded.signing_cert_sha256,
+    status_checks           = excluded.status_checks,
+    artifact_pending        = excluded.artifact_pending
 `
 
 type UpsertReleaseParams struct {
-	ID                string         `json:"id"`
-	ApplicationID     string         `json:"application_id"`
-	Version           string         `json:"version"`
-	Platform          string         `json:"platform"`
-	Architecture      string         `json:"architecture"`
-	DownloadUrl       string         `json:"download_url"`
-	Checksum          string         `json:"checksum"`
-	ChecksumType      string         `json:"checksum_type"`
-	FileSize          int64          `json:"file_size"`
-	ReleaseNotes      sql.NullString `json:"release_notes"`
-	ReleaseDate       string         `json:"release_date"`
-	Required          bool           `json:"required"`
-	MinimumVersion    sql.NullString `json:"minimum_version"`
-	Metadata          sql.NullString `json:"metadata"`
-	CreatedAt         string         `json:"created_at"`
-	VersionMajor      int64          `json:"version_major"`
-	VersionMinor      int64          `json:"version_minor"`
-	VersionPatch      int64          `json:"version_patch"`
-	VersionPreRelease sql.NullString `json:"version_pre_release"`
+	ID                    string         `json:"id"`
+	ApplicationID         string         `json:"application_id"`
+	Version               string         `json:"version"`
+	Platform              string         `json:"platform"`
+	Architecture          string         `json:"architecture"`
+	DownloadUrl           string         `json:"download_url"`
+	Checksum              string         `json:"checksum"`
+	ChecksumType          string         `json:"checksum_type"`
+	FileSize              int64          `json:"file_size"`
+	ReleaseNotes          sql.NullString `json:"release_notes"`
+	ReleaseDate           string         `json:"release_date"`
+	Required              bool           `json:"required"`
+	MinimumVersion        sql.NullString `json:"minimum_version"`
+	Metadata              sql.NullString `json:"metadata"`
+	CreatedAt             string         `json:"created_at"`
+	VersionMajor          int64          `json:"version_major"`
+	VersionMinor          int64          `json:"version_minor"`
+	VersionPatch          int64          `json:"version_patch"`
+	VersionPreRelease     sql.NullString `json:"version_pre_release"`
+	Tags                  string         `json:"tags"`
+	HostVersionConstraint string         `json:"host_version_constraint"`
+	Checksums             string         `json:"checksums"`
+	PgpSignature          string         `json:"pgp_signature"`
+	RequiredEntitlement   string         `json:"required_entitlement"`
+	Editions              string         `json:"editions"`
+	ReleaseNotesDraft     string         `json:"release_notes_draft"`
+	UpdatedAt             string         `json:"updated_at"`
+	Channel               string         `json:"channel"`
+	Targeting             string         `json:"targeting"`
+	Variant               string         `json:"variant"`
+	Variants              string         `json:"variants"`
+	Paused                bool           `json:"paused"`
+	Yanked                bool           `json:"yanked"`
+	YankReason            string         `json:"yank_reason"`
+	VersionCode           int64          `json:"version_code"`
+	SigningCertSha256     string         `json:"signing_cert_sha256"`
+	StatusChecks          string         `json:"status_checks"`
+	ArtifactPending       bool           `json:"artifact_pending"`
 }
 
 func (q *Queries) UpsertRelease(ctx context.Context, arg UpsertReleaseParams) error {
@@ -380,6 +519,25 @@ func (q *Queries) UpsertRelease(ctx context.Context, arg UpsertReleaseParams) er
 		arg.VersionMinor,
 		arg.VersionPatch,
 		arg.VersionPreRelease,
+		arg.Tags,
+		arg.HostVersionConstraint,
+		arg.Checksums,
+		arg.PgpSignature,
+		arg.RequiredEntitlement,
+		arg.Editions,
+		arg.ReleaseNotesDraft,
+		arg.UpdatedAt,
+		arg.Channel,
+		arg.Targeting,
+		arg.Variant,
+		arg.Variants,
+		arg.Paused,
+		arg.Yanked,
+		arg.YankReason,
+		arg.VersionCode,
+		arg.SigningCertSha256,
+		arg.StatusChecks,
+		arg.ArtifactPending,
 	)
 	return err
 }
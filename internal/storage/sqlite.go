@@ -19,11 +19,12 @@ import (
 type SQLiteStorage struct {
 	db      *sql.DB
 	queries *sqlcite.Queries
+	now     func() time.Time
 }
 
 // NewSQLiteStorage creates a new SQLite storage instance.
 // The database must be migrated before use (e.g. via the migrate binary or goose).
-func NewSQLiteStorage(dsn string) (Storage, error) {
+func NewSQLiteStorage(dsn string, opts ...Option) (Storage, error) {
 	if dsn == "" {
 		return nil, fmt.Errorf("connection string is required for SQLite storage")
 	}
@@ -56,6 +57,7 @@ func NewSQLiteStorage(dsn string) (Storage, error) {
 	}
 
 	return &SQLiteStorage{
+		now:     newOptions(opts).now,
 		db:      db,
 		queries: sqlcite.New(db),
 	}, nil
@@ -66,7 +68,23 @@ func (ss *SQLiteStorage) GetApplication(ctx context.Context, appID string) (*mod
 	row, err := ss.queries.GetApplicationByID(ctx, appID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("application %s not found", appID)
+			return nil, fmt.Errorf("application %s %w", appID, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return sqliteAppToModel(row)
+}
+
+// GetApplicationBySlug retrieves the application whose current or former slug is slug.
+func (ss *SQLiteStorage) GetApplicationBySlug(ctx context.Context, slug string) (*models.Application, error) {
+	row, err := ss.queries.GetApplicationBySlug(ctx, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		row, err = ss.queries.GetApplicationByFormerSlug(ctx, slug)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("application with slug %s: %w", slug, ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get application: %w", err)
 	}
@@ -76,7 +94,7 @@ func (ss *SQLiteStorage) GetApplication(ctx context.Context, appID string) (*mod
 
 // SaveApplication stores or updates an application (upsert pattern).
 func (ss *SQLiteStorage) SaveApplication(ctx context.Context, app *models.Application) error {
-	params, err := modelToSqliteUpsertApp(app)
+	params, err := modelToSqliteUpsertApp(app, ss.now())
 	if err != nil {
 		return fmt.Errorf("failed to convert application for upsert: %w", err)
 	}
@@ -113,6 +131,19 @@ func (ss *SQLiteStorage) GetRelease(ctx context.Context, appID, version, platfor
 	return sqliteReleaseToModel(row)
 }
 
+// GetReleaseByID retrieves a release by its ID.
+func (ss *SQLiteStorage) GetReleaseByID(ctx context.Context, id string) (*models.Release, error) {
+	row, err := ss.queries.GetReleaseByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("release not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+
+	return sqliteReleaseToModel(row)
+}
+
 // SaveRelease stores or updates a release (upsert pattern).
 func (ss *SQLiteStorage) SaveRelease(ctx context.Context, release *models.Release) error {
 	params, err := modelToSqliteUpsertRelease(release)
@@ -125,6 +156,31 @@ func (ss *SQLiteStorage) SaveRelease(ctx context.Context, release *models.Releas
 	return nil
 }
 
+// SaveReleases stores or updates several releases in a single transaction.
+func (ss *SQLiteStorage) SaveReleases(ctx context.Context, releases []*models.Release) error {
+	tx, err := ss.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := ss.queries.WithTx(tx)
+	for _, release := range releases {
+		params, err := modelToSqliteUpsertRelease(release)
+		if err != nil {
+			return fmt.Errorf("failed to convert release for upsert: %w", err)
+		}
+		if err := q.UpsertRelease(ctx, params); err != nil {
+			return fmt.Errorf("failed to upsert release %s: %w", release.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit releases: %w", err)
+	}
+	return nil
+}
+
 // DeleteRelease removes a release.
 func (ss *SQLiteStorage) DeleteRelease(ctx context.Context, appID, version, platform, arch string) error {
 	// Verify release exists first
@@ -248,18 +304,42 @@ func sqliteAppToModel(row sqlcite.Application) (*models.Application, error) {
 		return nil, err
 	}
 
+	tags, err := unmarshalTagsFromString(row.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	formerSlugs, err := unmarshalSlugs([]byte(row.FormerSlugs))
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt created_at for application %s: %w", row.ID, err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, row.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt updated_at for application %s: %w", row.ID, err)
+	}
+
 	return &models.Application{
 		ID:          row.ID,
 		Name:        row.Name,
 		Description: nullStringToString(row.Description),
 		Platforms:   platforms,
 		Config:      config,
-		CreatedAt:   row.CreatedAt,
-		UpdatedAt:   row.UpdatedAt,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		Tags:        tags,
+		Group:       row.GroupName,
+		ParentID:    row.ParentID,
+		Slug:        row.Slug,
+		FormerSlugs: formerSlugs,
 	}, nil
 }
 
-func modelToSqliteUpsertApp(app *models.Application) (sqlcite.UpsertApplicationParams, error) {
+func modelToSqliteUpsertApp(app *models.Application, now time.Time) (sqlcite.UpsertApplicationParams, error) {
 	platforms, err := marshalPlatforms(app.Platforms)
 	if err != nil {
 		return sqlcite.UpsertApplicationParams{}, err
@@ -270,15 +350,30 @@ func modelToSqliteUpsertApp(app *models.Application) (sqlcite.UpsertApplicationP
 		return sqlcite.UpsertApplicationParams{}, err
 	}
 
-	now := time.Now().UTC().Format(time.RFC3339)
+	tags, err := marshalTags(app.Tags)
+	if err != nil {
+		return sqlcite.UpsertApplicationParams{}, err
+	}
+
+	formerSlugs, err := marshalSlugs(app.FormerSlugs)
+	if err != nil {
+		return sqlcite.UpsertApplicationParams{}, err
+	}
+
+	timestamp := now.UTC().Format(time.RFC3339)
 	return sqlcite.UpsertApplicationParams{
 		ID:          app.ID,
 		Name:        app.Name,
 		Description: stringToNullString(app.Description),
 		Platforms:   string(platforms),
 		Config:      string(config),
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		CreatedAt:   timestamp,
+		UpdatedAt:   timestamp,
+		Tags:        string(tags),
+		GroupName:   app.Group,
+		ParentID:    app.ParentID,
+		Slug:        app.Slug,
+		FormerSlugs: string(formerSlugs),
 	}, nil
 }
 
@@ -288,6 +383,36 @@ func sqliteReleaseToModel(row sqlcite.Release) (*models.Release, error) {
 		return nil, err
 	}
 
+	tags, err := unmarshalTagsFromString(row.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums, err := unmarshalChecksums([]byte(row.Checksums))
+	if err != nil {
+		return nil, err
+	}
+
+	editions, err := unmarshalEditions([]byte(row.Editions))
+	if err != nil {
+		return nil, err
+	}
+
+	targeting, err := unmarshalTargeting([]byte(row.Targeting))
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := unmarshalVariants([]byte(row.Variants))
+	if err != nil {
+		return nil, err
+	}
+
+	statusChecks, err := unmarshalStatusChecks([]byte(row.StatusChecks))
+	if err != nil {
+		return nil, err
+	}
+
 	releaseDate, err := time.Parse(time.RFC3339, row.ReleaseDate)
 	if err != nil {
 		return nil, fmt.Errorf("corrupt release_date for release %s: %w", row.ID, err)
@@ -296,6 +421,10 @@ func sqliteReleaseToModel(row sqlcite.Release) (*models.Release, error) {
 	if err != nil {
 		return nil, fmt.Errorf("corrupt created_at for release %s: %w", row.ID, err)
 	}
+	updatedAt, err := time.Parse(time.RFC3339, row.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt updated_at for release %s: %w", row.ID, err)
+	}
 
 	return &models.Release{
 		ID:             row.ID,
@@ -313,7 +442,26 @@ func sqliteReleaseToModel(row sqlcite.Release) (*models.Release, error) {
 		MinimumVersion: nullStringToString(row.MinimumVersion),
 		Metadata:       metadata,
 		CreatedAt:      createdAt,
-		UpdatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		Tags:           tags,
+
+		HostVersionConstraint: row.HostVersionConstraint,
+		Checksums:             checksums,
+		PGPSignature:          row.PgpSignature,
+		RequiredEntitlement:   row.RequiredEntitlement,
+		Editions:              editions,
+		ReleaseNotesDraft:     row.ReleaseNotesDraft,
+		Channel:               row.Channel,
+		Targeting:             targeting,
+		Variant:               row.Variant,
+		Variants:              variants,
+		Paused:                row.Paused,
+		Yanked:                row.Yanked,
+		YankReason:            row.YankReason,
+		VersionCode:           row.VersionCode,
+		SigningCertSHA256:     row.SigningCertSha256,
+		StatusChecks:          statusChecks,
+		ArtifactPending:       row.ArtifactPending,
 	}, nil
 }
 
@@ -323,7 +471,41 @@ func modelToSqliteUpsertRelease(r *models.Release) (sqlcite.UpsertReleaseParams,
 		return sqlcite.UpsertReleaseParams{}, err
 	}
 
+	tags, err := marshalTags(r.Tags)
+	if err != nil {
+		return sqlcite.UpsertReleaseParams{}, err
+	}
+
+	checksums, err := marshalChecksums(r.Checksums)
+	if err != nil {
+		return sqlcite.UpsertReleaseParams{}, err
+	}
+
+	editions, err := marshalEditions(r.Editions)
+	if err != nil {
+		return sqlcite.UpsertReleaseParams{}, err
+	}
+
+	targeting, err := marshalTargeting(r.Targeting)
+	if err != nil {
+		return sqlcite.UpsertReleaseParams{}, err
+	}
+
+	variants, err := marshalVariants(r.Variants)
+	if err != nil {
+		return sqlcite.UpsertReleaseParams{}, err
+	}
+
+	statusChecks, err := marshalStatusChecks(r.StatusChecks)
+	if err != nil {
+		return sqlcite.UpsertReleaseParams{}, err
+	}
+
 	major, minor, patch, pre := parseSemverParts(r.Version)
+	updatedAt := r.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = r.CreatedAt
+	}
 
 	return sqlcite.UpsertReleaseParams{
 		ID:                r.ID,
@@ -345,6 +527,26 @@ func modelToSqliteUpsertRelease(r *models.Release) (sqlcite.UpsertReleaseParams,
 		VersionMinor:      minor,
 		VersionPatch:      patch,
 		VersionPreRelease: sql.NullString{String: pre, Valid: pre != ""},
+		Tags:              string(tags),
+
+		HostVersionConstraint: r.HostVersionConstraint,
+		Checksums:             string(checksums),
+		PgpSignature:          r.PGPSignature,
+		RequiredEntitlement:   r.RequiredEntitlement,
+		Editions:              string(editions),
+		ReleaseNotesDraft:     r.ReleaseNotesDraft,
+		Channel:               r.Channel,
+		Targeting:             string(targeting),
+		Variant:               r.Variant,
+		Variants:              string(variants),
+		Paused:                r.Paused,
+		Yanked:                r.Yanked,
+		YankReason:            r.YankReason,
+		VersionCode:           r.VersionCode,
+		SigningCertSha256:     r.SigningCertSHA256,
+		StatusChecks:          string(statusChecks),
+		ArtifactPending:       r.ArtifactPending,
+		UpdatedAt:             updatedAt.UTC().Format(time.RFC3339),
 	}, nil
 }
 
@@ -404,7 +606,7 @@ func (ss *SQLiteStorage) CreateAPIKey(ctx context.Context, key *models.APIKey) e
 		enabled = 1
 	}
 
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := ss.now().UTC().Format(time.RFC3339)
 	if err := ss.queries.CreateAPIKey(ctx, sqlcite.CreateAPIKeyParams{
 		ID:          key.ID,
 		Name:        key.Name,
@@ -466,7 +668,7 @@ func (ss *SQLiteStorage) UpdateAPIKey(ctx context.Context, key *models.APIKey) e
 		Name:        key.Name,
 		Permissions: perms,
 		Enabled:     enabled,
-		UpdatedAt:   time.Now().UTC().Format(time.RFC3339),
+		UpdatedAt:   ss.now().UTC().Format(time.RFC3339),
 		ID:          key.ID,
 	})
 	if err != nil {
@@ -490,33 +692,48 @@ func (ss *SQLiteStorage) DeleteAPIKey(ctx context.Context, id string) error {
 	return nil
 }
 
-// ListApplicationsPaged returns a page of applications sorted by created_at DESC, id DESC
+// ListApplicationsPaged returns a filtered page of applications sorted by created_at DESC, id DESC
 // and the total count. cursor, when non-nil, positions the query after the given item.
-func (ss *SQLiteStorage) ListApplicationsPaged(ctx context.Context, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
+func (ss *SQLiteStorage) ListApplicationsPaged(ctx context.Context, filters models.ApplicationFilters, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
 	args := []interface{}{}
+	conds := []string{}
+	for _, tag := range filters.Tags {
+		conds = append(conds, sqliteTagCondition)
+		args = append(args, tag)
+	}
+	if filters.Group != "" {
+		conds = append(conds, "group_name = ?")
+		args = append(args, filters.Group)
+	}
+	if filters.ParentID != "" {
+		conds = append(conds, "parent_id = ?")
+		args = append(args, filters.ParentID)
+	}
+	businessWhere := ""
+	if len(conds) > 0 {
+		businessWhere = "WHERE " + strings.Join(conds, " AND ")
+	}
+
 	where := ""
 	if cursor != nil {
 		createdAtStr := cursor.CreatedAt.UTC().Format(time.RFC3339)
-		args = append(args, createdAtStr, cursor.ID)
 		where = "WHERE (created_at < ? OR (created_at = ? AND id < ?))"
-		// The second occurrence of createdAtStr for the equality check.
-		args = append(args, createdAtStr)
-		// Reorder: createdAtStr, createdAtStr, cursor.ID
-		args = []interface{}{createdAtStr, createdAtStr, cursor.ID}
+		args = append(args, createdAtStr, createdAtStr, cursor.ID)
 	}
 	args = append(args, int64(limit))
 
 	query := fmt.Sprintf(`
-		SELECT id, name, description, platforms, config, created_at, updated_at, total_count
+		SELECT id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs, total_count
 		FROM (
-			SELECT id, name, description, platforms, config, created_at, updated_at,
+			SELECT id, name, description, platforms, config, created_at, updated_at, tags, group_name, parent_id, slug, former_slugs,
 			       COUNT(*) OVER() AS total_count
 			FROM applications
+			%s
 		) AS counted
 		%s
 		ORDER BY created_at DESC, id DESC
 		LIMIT ?`,
-		where)
+		businessWhere, where)
 
 	sqlRows, err := ss.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -528,11 +745,11 @@ func (ss *SQLiteStorage) ListApplicationsPaged(ctx context.Context, limit int, c
 	apps := make([]*models.Application, 0)
 	for sqlRows.Next() {
 		var (
-			id, name, platforms, config, createdAt, updatedAt string
-			description                                       sql.NullString
-			totalCount                                        int64
+			id, name, platforms, config, createdAt, updatedAt, tags, groupName, parentID, slug, formerSlugs string
+			description                                                                                     sql.NullString
+			totalCount                                                                                      int64
 		)
-		if err := sqlRows.Scan(&id, &name, &description, &platforms, &config, &createdAt, &updatedAt, &totalCount); err != nil {
+		if err := sqlRows.Scan(&id, &name, &description, &platforms, &config, &createdAt, &updatedAt, &tags, &groupName, &parentID, &slug, &formerSlugs, &totalCount); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan application: %w", err)
 		}
 		if total == 0 {
@@ -546,6 +763,11 @@ func (ss *SQLiteStorage) ListApplicationsPaged(ctx context.Context, limit int, c
 			Config:      config,
 			CreatedAt:   createdAt,
 			UpdatedAt:   updatedAt,
+			Tags:        tags,
+			GroupName:   groupName,
+			ParentID:    parentID,
+			Slug:        slug,
+			FormerSlugs: formerSlugs,
 		})
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to convert application %s: %w", id, err)
@@ -558,6 +780,23 @@ func (ss *SQLiteStorage) ListApplicationsPaged(ctx context.Context, limit int, c
 	return apps, total, nil
 }
 
+// ListApplicationGroups returns every distinct application group with its member count.
+func (ss *SQLiteStorage) ListApplicationGroups(ctx context.Context) ([]models.ApplicationGroup, error) {
+	rows, err := ss.queries.ListApplicationGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application groups: %w", err)
+	}
+	groups := make([]models.ApplicationGroup, len(rows))
+	for i, row := range rows {
+		groups[i] = models.ApplicationGroup{Name: row.GroupName, ApplicationCount: int(row.ApplicationCount)}
+	}
+	return groups, nil
+}
+
+// sqliteTagCondition matches rows whose JSON tags array contains the bound value.
+// It is ANDed once per requested tag so that rows must carry every tag.
+const sqliteTagCondition = "EXISTS (SELECT 1 FROM json_each(tags) WHERE json_each.value = ?)"
+
 // sqliteReleaseListSortCols maps sortBy values to safe SQL ORDER BY fragments.
 // Version sort uses the split numeric columns for correct semver ordering.
 // Using an allowlist prevents SQL injection from untrusted sortBy values.
@@ -609,6 +848,10 @@ func (ss *SQLiteStorage) ListReleasesPaged(ctx context.Context, appID string, fi
 		}
 		businessWhere += " AND platform IN (" + strings.Join(placeholders, ",") + ")"
 	}
+	for _, tag := range filters.Tags {
+		args = append(args, tag)
+		businessWhere += " AND " + sqliteTagCondition
+	}
 
 	// Keyset cursor condition — applied to the outer query so COUNT(*) OVER()
 	// counts all business-filtered rows, not just the remaining page rows.
@@ -675,14 +918,14 @@ func (ss *SQLiteStorage) ListReleasesPaged(ctx context.Context, appID string, fi
 		SELECT id, application_id, version, platform, architecture, download_url,
 		       checksum, checksum_type, file_size, release_notes, release_date,
 		       required, minimum_version, metadata, created_at,
-		       version_major, version_minor, version_patch, version_pre_release,
-		       total_count
+		       version_major, version_minor, version_patch, version_pre_release, tags,
+		       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending, total_count
 		FROM (
 			SELECT id, application_id, version, platform, architecture, download_url,
 			       checksum, checksum_type, file_size, release_notes, release_date,
 			       required, minimum_version, metadata, created_at,
-			       version_major, version_minor, version_patch, version_pre_release,
-			       COUNT(*) OVER() AS total_count
+			       version_major, version_minor, version_patch, version_pre_release, tags,
+			       host_version_constraint, checksums, pgp_signature, required_entitlement, editions, release_notes_draft, updated_at, channel, targeting, variant, variants, paused, yanked, yank_reason, version_code, signing_cert_sha256, status_checks, artifact_pending, COUNT(*) OVER() AS total_count
 			FROM releases
 			%s
 		) AS counted
@@ -713,6 +956,25 @@ func (ss *SQLiteStorage) ListReleasesPaged(ctx context.Context, appID string, fi
 			createdAt                                            string
 			versionMajor, versionMinor, versionPatch             int64
 			versionPreRelease                                    sql.NullString
+			tags                                                 string
+			hostVersionConstraint                                string
+			checksums                                            string
+			pgpSignature                                         string
+			requiredEntitlement                                  string
+			editions                                             string
+			releaseNotesDraft                                    string
+			updatedAt                                            string
+			channel                                              string
+			targeting                                            string
+			variant                                              string
+			variants                                             string
+			paused                                               bool
+			yanked                                               bool
+			yankReason                                           string
+			versionCode                                          int64
+			signingCertSHA256                                    string
+			statusChecks                                         string
+			artifactPending                                      bool
 			totalCount                                           int64
 		)
 		if err := sqlRows.Scan(
@@ -721,7 +983,7 @@ func (ss *SQLiteStorage) ListReleasesPaged(ctx context.Context, appID string, fi
 			&releaseNotes, &releaseDate, &required, &minimumVersion,
 			&metadata, &createdAt,
 			&versionMajor, &versionMinor, &versionPatch, &versionPreRelease,
-			&totalCount,
+			&tags, &hostVersionConstraint, &checksums, &pgpSignature, &requiredEntitlement, &editions, &releaseNotesDraft, &updatedAt, &channel, &targeting, &variant, &variants, &paused, &yanked, &yankReason, &versionCode, &signingCertSHA256, &statusChecks, &artifactPending, &totalCount,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan release: %w", err)
 		}
@@ -748,6 +1010,26 @@ func (ss *SQLiteStorage) ListReleasesPaged(ctx context.Context, appID string, fi
 			VersionMinor:      versionMinor,
 			VersionPatch:      versionPatch,
 			VersionPreRelease: versionPreRelease,
+			Tags:              tags,
+
+			HostVersionConstraint: hostVersionConstraint,
+			Checksums:             checksums,
+			PgpSignature:          pgpSignature,
+			RequiredEntitlement:   requiredEntitlement,
+			Editions:              editions,
+			ReleaseNotesDraft:     releaseNotesDraft,
+			Channel:               channel,
+			Targeting:             targeting,
+			Variant:               variant,
+			Variants:              variants,
+			Paused:                paused,
+			Yanked:                yanked,
+			YankReason:            yankReason,
+			VersionCode:           versionCode,
+			SigningCertSha256:     signingCertSHA256,
+			StatusChecks:          statusChecks,
+			ArtifactPending:       artifactPending,
+			UpdatedAt:             updatedAt,
 		}
 		release, err := sqliteReleaseToModel(row)
 		if err != nil {
@@ -813,11 +1095,107 @@ func (ss *SQLiteStorage) GetApplicationStats(ctx context.Context, appID string)
 	return stats, nil
 }
 
+// SaveContainerImage stores or replaces a container image tag. The original
+// creation time is kept when an existing tag is replaced.
+func (ss *SQLiteStorage) SaveContainerImage(ctx context.Context, image *models.ContainerImage) error {
+	platforms, err := marshalPlatforms(image.Platforms)
+	if err != nil {
+		return err
+	}
+
+	now := ss.now().UTC().Format(time.RFC3339)
+	createdAt := now
+	if !image.CreatedAt.IsZero() {
+		createdAt = image.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if err := ss.queries.UpsertContainerImage(ctx, sqlcite.UpsertContainerImageParams{
+		ID:            image.ID,
+		ApplicationID: image.ApplicationID,
+		Repository:    image.Repository,
+		Tag:           image.Tag,
+		Digest:        image.Digest,
+		Platforms:     string(platforms),
+		ReleaseNotes:  image.ReleaseNotes,
+		Required:      image.Required,
+		CreatedAt:     createdAt,
+		UpdatedAt:     now,
+	}); err != nil {
+		return fmt.Errorf("failed to save container image: %w", err)
+	}
+	return nil
+}
+
+// ListContainerImages returns every container image tag of an application,
+// most recently created first.
+func (ss *SQLiteStorage) ListContainerImages(ctx context.Context, appID string) ([]*models.ContainerImage, error) {
+	rows, err := ss.queries.ListContainerImages(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list container images: %w", err)
+	}
+
+	images := make([]*models.ContainerImage, 0, len(rows))
+	for _, row := range rows {
+		image, err := sqliteContainerImageToModel(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert container image %s: %w", row.ID, err)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// DeleteContainerImage removes a container image tag.
+// Returns ErrNotFound if the tag does not exist.
+func (ss *SQLiteStorage) DeleteContainerImage(ctx context.Context, appID, tag string) error {
+	rows, err := ss.queries.DeleteContainerImage(ctx, sqlcite.DeleteContainerImageParams{
+		ApplicationID: appID,
+		Tag:           tag,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete container image: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// sqliteContainerImageToModel converts a sqlcite.ContainerImage row to a *models.ContainerImage.
+func sqliteContainerImageToModel(row sqlcite.ContainerImage) (*models.ContainerImage, error) {
+	platforms, err := unmarshalPlatformsFromString(row.Platforms)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt created_at: %w", err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, row.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt updated_at: %w", err)
+	}
+
+	return &models.ContainerImage{
+		ID:            row.ID,
+		ApplicationID: row.ApplicationID,
+		Repository:    row.Repository,
+		Tag:           row.Tag,
+		Digest:        row.Digest,
+		Platforms:     platforms,
+		ReleaseNotes:  row.ReleaseNotes,
+		Required:      row.Required,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}, nil
+}
+
 // newSQLiteStorageFromDB creates a SQLiteStorage from an existing *sql.DB.
 // Used by tests to share a pre-migrated in-memory database connection.
-func newSQLiteStorageFromDB(db *sql.DB) *SQLiteStorage {
+func newSQLiteStorageFromDB(db *sql.DB, opts ...Option) *SQLiteStorage {
 	return &SQLiteStorage{
 		db:      db,
 		queries: sqlcite.New(db),
+		now:     newOptions(opts).now,
 	}
 }
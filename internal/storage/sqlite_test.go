@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"io/fs"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -17,7 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func newSQLiteTestStorage(t *testing.T) Storage {
+func newSQLiteTestStorage(t *testing.T, opts ...Option) Storage {
 	t.Helper()
 
 	db, err := sql.Open("sqlite", ":memory:")
@@ -45,7 +46,7 @@ func newSQLiteTestStorage(t *testing.T) Storage {
 		t.Fatalf("failed to run migrations: %v", err)
 	}
 
-	s := newSQLiteStorageFromDB(db)
+	s := newSQLiteStorageFromDB(db, opts...)
 	t.Cleanup(func() { s.Close() })
 	return s
 }
@@ -62,7 +63,7 @@ func TestSQLiteStorageSchemaCreation(t *testing.T) {
 	ctx := context.Background()
 
 	// Verify tables exist by performing operations
-	apps, _, err := s.ListApplicationsPaged(ctx, 50, nil)
+	apps, _, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 	if err != nil {
 		t.Fatalf("ListApplicationsPaged failed: %v", err)
 	}
@@ -127,7 +128,7 @@ func TestSQLiteStorageApplicationCRUD(t *testing.T) {
 	}
 
 	// List applications
-	apps, _, err := s.ListApplicationsPaged(ctx, 50, nil)
+	apps, _, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 	if err != nil {
 		t.Fatalf("ListApplicationsPaged failed: %v", err)
 	}
@@ -141,7 +142,7 @@ func TestSQLiteStorageApplicationCRUD(t *testing.T) {
 		t.Fatalf("SaveApplication (second) failed: %v", err)
 	}
 
-	apps, _, err = s.ListApplicationsPaged(ctx, 50, nil)
+	apps, _, err = s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 	if err != nil {
 		t.Fatalf("ListApplicationsPaged failed: %v", err)
 	}
@@ -199,6 +200,18 @@ func TestSQLiteStorageReleaseCRUD(t *testing.T) {
 		t.Errorf("expected metadata build=123, got %v", got.Metadata)
 	}
 
+	// Get release by ID
+	got, err = s.GetReleaseByID(ctx, release.ID)
+	if err != nil {
+		t.Fatalf("GetReleaseByID failed: %v", err)
+	}
+	if got.Version != "1.0.0" || got.Platform != "windows" {
+		t.Errorf("expected release 1.0.0 on windows, got %s on %s", got.Version, got.Platform)
+	}
+	if _, err := s.GetReleaseByID(ctx, "missing"); err == nil {
+		t.Error("expected error for non-existent release ID")
+	}
+
 	// Update release
 	release.DownloadURL = "https://example.com/v1.0.0-updated"
 	release.ReleaseNotes = "Updated release"
@@ -377,7 +390,7 @@ func TestSQLiteStorageConcurrency(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			for j := 0; j < 10; j++ {
-				_, _, err := s.ListApplicationsPaged(ctx, 50, nil)
+				_, _, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
 				if err != nil {
 					errs <- err
 					return
@@ -527,6 +540,58 @@ func TestSQLiteStorage_APIKeyCRUD(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNotFound)
 }
 
+func TestSQLiteStorage_WithClock(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := newSQLiteTestStorage(t, WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("clock-app", "Clock App", []string{"linux"})))
+	key := models.NewAPIKey(models.NewKeyID(), "deploy", "upd_clock_test_key", []string{"write"})
+	require.NoError(t, s.CreateAPIKey(ctx, key))
+
+	created := now
+	now = now.Add(time.Hour)
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("clock-app", "Renamed", []string{"linux"})))
+	require.NoError(t, s.UpdateAPIKey(ctx, key))
+
+	app, err := s.GetApplication(ctx, "clock-app")
+	require.NoError(t, err)
+	assert.True(t, created.Equal(app.CreatedAt))
+	assert.True(t, now.Equal(app.UpdatedAt))
+
+	got, err := s.GetAPIKeyByHash(ctx, key.KeyHash)
+	require.NoError(t, err)
+	assert.True(t, created.Equal(got.CreatedAt))
+	assert.True(t, now.Equal(got.UpdatedAt))
+}
+
+func TestSQLiteStorage_ReleaseUpdatedAt(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("upd-app", "Upd App", []string{"linux"})))
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	release := models.NewRelease("upd-app", "1.0.0", "linux", "amd64", "https://example.com/upd-app.tar.gz")
+	release.Checksum, release.ChecksumType = "abc123", "sha256"
+	release.ReleaseDate, release.CreatedAt, release.UpdatedAt = created, created, created
+	require.NoError(t, s.SaveRelease(ctx, release))
+
+	updated := created.Add(time.Hour)
+	release.ReleaseNotes = "Edited"
+	release.UpdatedAt = updated
+	require.NoError(t, s.SaveRelease(ctx, release))
+
+	got, err := s.GetRelease(ctx, "upd-app", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.True(t, created.Equal(got.CreatedAt))
+	assert.True(t, updated.Equal(got.UpdatedAt))
+
+	releases, _, err := s.ListReleasesPaged(ctx, "upd-app", models.ReleaseFilters{}, "release_date", "desc", 50, nil)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.True(t, updated.Equal(releases[0].UpdatedAt))
+}
+
 func TestSQLiteStorage_GetAPIKeyByHash_NotFound(t *testing.T) {
 	s := newSQLiteTestStorage(t)
 	_, err := s.GetAPIKeyByHash(context.Background(), "nonexistent")
@@ -560,7 +625,7 @@ func TestSQLiteStorage_ListApplicationsPaged(t *testing.T) {
 	}
 
 	t.Run("first page returns 2 apps total=3", func(t *testing.T) {
-		apps, total, err := s.ListApplicationsPaged(ctx, 2, nil)
+		apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 2, nil)
 		if err != nil {
 			t.Fatalf("ListApplicationsPaged failed: %v", err)
 		}
@@ -573,7 +638,7 @@ func TestSQLiteStorage_ListApplicationsPaged(t *testing.T) {
 	})
 
 	t.Run("all apps returned with large limit", func(t *testing.T) {
-		apps, total, err := s.ListApplicationsPaged(ctx, 1000, nil)
+		apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 1000, nil)
 		if err != nil {
 			t.Fatalf("ListApplicationsPaged large limit failed: %v", err)
 		}
@@ -801,23 +866,21 @@ func TestSQLiteStorage_ListApplicationsPaged_TotalCountStable(t *testing.T) {
 			Name:      fmt.Sprintf("App %d", i),
 			Platforms: []string{"windows"},
 			Config:    models.ApplicationConfig{},
-			CreatedAt: now.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
-			UpdatedAt: now.Format(time.RFC3339),
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+			UpdatedAt: now,
 		}
 		require.NoError(t, store.SaveApplication(ctx, app))
 	}
 
 	// Page 1: limit=2, no cursor
-	page1, total1, err := store.ListApplicationsPaged(ctx, 2, nil)
+	page1, total1, err := store.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 2, nil)
 	require.NoError(t, err)
 	assert.Len(t, page1, 2)
 	assert.Equal(t, 5, total1, "total_count on page 1 should be 5")
 
 	// Page 2: using cursor from last item on page 1
-	createdAt1, err := time.Parse(time.RFC3339, page1[len(page1)-1].CreatedAt)
-	require.NoError(t, err)
-	cursor := &models.ApplicationCursor{CreatedAt: createdAt1, ID: page1[len(page1)-1].ID}
-	page2, total2, err := store.ListApplicationsPaged(ctx, 2, cursor)
+	cursor := &models.ApplicationCursor{CreatedAt: page1[len(page1)-1].CreatedAt, ID: page1[len(page1)-1].ID}
+	page2, total2, err := store.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 2, cursor)
 	require.NoError(t, err)
 	assert.Len(t, page2, 2)
 	assert.Equal(t, 5, total2, "total_count on page 2 must equal total_count on page 1")
@@ -832,8 +895,8 @@ func TestSQLiteStorage_ListReleasesPaged_TotalCountStable(t *testing.T) {
 		Name:      "App1",
 		Platforms: []string{"windows"},
 		Config:    models.ApplicationConfig{},
-		CreatedAt: time.Now().UTC().Format(time.RFC3339),
-		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
 	}
 	require.NoError(t, store.SaveApplication(ctx, app))
 
@@ -883,8 +946,8 @@ func TestSQLiteStorage_ListReleasesPaged_VersionSortPreReleaseOrder(t *testing.T
 		Name:      "App1",
 		Platforms: []string{"windows"},
 		Config:    models.ApplicationConfig{},
-		CreatedAt: time.Now().UTC().Format(time.RFC3339),
-		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
 	}
 	require.NoError(t, store.SaveApplication(ctx, app))
 
@@ -1026,3 +1089,365 @@ func TestSQLiteReleaseToModel_CorruptTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLiteAppToModel_CorruptTimestamp(t *testing.T) {
+	validTime := time.Now().UTC().Format(time.RFC3339)
+	tests := []struct {
+		name       string
+		createdAt  string
+		updatedAt  string
+		wantErrMsg string
+	}{
+		{
+			name:       "corrupt created_at",
+			createdAt:  "not-a-timestamp",
+			updatedAt:  validTime,
+			wantErrMsg: "corrupt created_at",
+		},
+		{
+			name:       "corrupt updated_at",
+			createdAt:  validTime,
+			updatedAt:  "not-a-timestamp",
+			wantErrMsg: "corrupt updated_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := sqlcite.Application{
+				ID:          "app-1",
+				Name:        "App",
+				Platforms:   `["linux"]`,
+				Config:      `{}`,
+				Tags:        `[]`,
+				FormerSlugs: `[]`,
+				CreatedAt:   tt.createdAt,
+				UpdatedAt:   tt.updatedAt,
+			}
+
+			_, err := sqliteAppToModel(row)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrMsg)
+		})
+	}
+}
+
+func TestSQLiteStorage_Tags(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+
+	tagged := models.NewApplication("tagged-app", "Tagged", []string{"linux"})
+	tagged.Tags = []string{"internal", "q3-redesign"}
+	require.NoError(t, s.SaveApplication(ctx, tagged))
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("plain-app", "Plain", []string{"linux"})))
+
+	got, err := s.GetApplication(ctx, "tagged-app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"internal", "q3-redesign"}, got.Tags)
+
+	plain, err := s.GetApplication(ctx, "plain-app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, plain.Tags)
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{Tags: []string{"q3-redesign"}}, 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "tagged-app", apps[0].ID)
+
+	for i, tags := range [][]string{{"hotfix", "security"}, {"hotfix"}, nil} {
+		r := models.NewRelease("tagged-app", fmt.Sprintf("1.0.%d", i), "linux", "amd64", "https://example.com/app")
+		r.Checksum = "abc123"
+		r.Tags = tags
+		require.NoError(t, s.SaveRelease(ctx, r))
+	}
+
+	rel, err := s.GetRelease(ctx, "tagged-app", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hotfix", "security"}, rel.Tags)
+
+	tests := []struct {
+		name     string
+		tags     []string
+		expected int
+	}{
+		{name: "no filter", tags: nil, expected: 3},
+		{name: "single tag", tags: []string{"hotfix"}, expected: 2},
+		{name: "all tags required", tags: []string{"hotfix", "security"}, expected: 1},
+		{name: "unknown tag", tags: []string{"beta"}, expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			releases, total, err := s.ListReleasesPaged(ctx, "tagged-app", models.ReleaseFilters{Tags: tt.tags}, "release_date", "desc", 50, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, total)
+			assert.Len(t, releases, tt.expected)
+		})
+	}
+}
+
+func TestSQLiteStorage_ApplicationGroups(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+
+	for id, group := range map[string]string{"api": "Payments", "web": "Payments", "chat": "Messaging", "misc": ""} {
+		app := models.NewApplication(id, id, []string{"linux"})
+		app.Group = group
+		require.NoError(t, s.SaveApplication(ctx, app))
+	}
+
+	got, err := s.GetApplication(ctx, "chat")
+	require.NoError(t, err)
+	assert.Equal(t, "Messaging", got.Group)
+
+	groups, err := s.ListApplicationGroups(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ApplicationGroup{
+		{Name: "", ApplicationCount: 1},
+		{Name: "Messaging", ApplicationCount: 1},
+		{Name: "Payments", ApplicationCount: 2},
+	}, groups)
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{Group: "Payments"}, 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, apps, 2)
+	for _, app := range apps {
+		assert.Equal(t, "Payments", app.Group)
+	}
+}
+
+func TestSQLiteStorage_ApplicationSlugs(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+
+	app := models.NewApplication("acme-notes", "Acme Notes", []string{"linux"})
+	app.Slug = "jotter"
+	app.FormerSlugs = []string{"notes"}
+	require.NoError(t, s.SaveApplication(ctx, app))
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("other", "Other", []string{"linux"})))
+
+	for _, slug := range []string{"jotter", "notes"} {
+		got, err := s.GetApplicationBySlug(ctx, slug)
+		require.NoError(t, err, slug)
+		assert.Equal(t, "acme-notes", got.ID)
+		assert.Equal(t, "jotter", got.Slug)
+		assert.Equal(t, []string{"notes"}, got.FormerSlugs)
+	}
+	_, err := s.GetApplicationBySlug(ctx, "acme-notes")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	apps, _, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{}, 50, nil)
+	require.NoError(t, err)
+	for _, a := range apps {
+		if a.ID == "acme-notes" {
+			assert.Equal(t, "jotter", a.Slug)
+		}
+	}
+
+	// Current slugs are unique
+	dup := models.NewApplication("dup", "Dup", []string{"linux"})
+	dup.Slug = "jotter"
+	assert.Error(t, s.SaveApplication(ctx, dup))
+}
+
+func TestSQLiteStorage_Plugins(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("host", "Host", []string{"linux"})))
+	for _, id := range []string{"plugin-a", "plugin-b"} {
+		app := models.NewApplication(id, id, []string{"linux"})
+		app.ParentID = "host"
+		require.NoError(t, s.SaveApplication(ctx, app))
+	}
+
+	got, err := s.GetApplication(ctx, "plugin-a")
+	require.NoError(t, err)
+	assert.Equal(t, "host", got.ParentID)
+
+	apps, total, err := s.ListApplicationsPaged(ctx, models.ApplicationFilters{ParentID: "host"}, 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	for _, app := range apps {
+		assert.Equal(t, "host", app.ParentID)
+	}
+
+	release := models.NewRelease("plugin-a", "1.0.0", "linux", "amd64", "https://example.com/plugin-a")
+	release.HostVersionConstraint = ">= 2.0.0, < 3.0.0"
+	require.NoError(t, s.SaveRelease(ctx, release))
+
+	gotRelease, err := s.GetRelease(ctx, "plugin-a", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, ">= 2.0.0, < 3.0.0", gotRelease.HostVersionConstraint)
+
+	releases, _, err := s.ListReleasesPaged(ctx, "plugin-a", models.ReleaseFilters{}, "release_date", "desc", 50, nil)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, ">= 2.0.0, < 3.0.0", releases[0].HostVersionConstraint)
+}
+
+func TestSQLiteStorage_ReleaseChecksums(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("app", "App", []string{"linux"})))
+
+	release := models.NewRelease("app", "1.0.0", "linux", "amd64", "https://example.com/app")
+	release.Checksum = "abc123"
+	release.Checksums = map[string]string{"sha512": "def456", "blake3": "789abc"}
+	release.PGPSignature = "-----BEGIN PGP SIGNATURE-----\n\niHUEABYKAB0=\n-----END PGP SIGNATURE-----"
+	release.RequiredEntitlement = "pro"
+	release.Editions = map[string]models.EditionArtifact{
+		"enterprise": {DownloadURL: "https://example.com/app-enterprise", Checksum: "fed321", ChecksumType: "sha256", FileSize: 2048, RequiredEntitlement: "enterprise"},
+	}
+	release.ReleaseNotesDraft = "- Fixed a crash on startup"
+	release.Channel = "lts"
+	release.Targeting = []models.TargetingRule{{Attribute: models.TargetOSVersion, Operator: models.TargetGTE, Values: []string{"10.0.19045"}}}
+	release.Variant = models.VariantDeb
+	release.Variants = map[string]models.VariantArtifact{
+		models.VariantAppImage: {DownloadURL: "https://example.com/app.AppImage", Checksum: "aaa111", ChecksumType: "sha256", FileSize: 4096},
+	}
+	release.Paused = true
+	release.Yanked = true
+	release.YankReason = "crashes on start"
+	release.VersionCode = 42
+	release.SigningCertSHA256 = strings.Repeat("ab", 32)
+	release.StatusChecks = map[string]models.StatusCheck{
+		"ci": {State: models.StatusCheckSuccess, TargetURL: "https://ci.example.com/runs/1", UpdatedAt: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)},
+	}
+	release.ArtifactPending = true
+	require.NoError(t, s.SaveRelease(ctx, release))
+	plain := models.NewRelease("app", "1.1.0", "linux", "amd64", "https://example.com/app")
+	plain.Checksum = "abc123"
+	require.NoError(t, s.SaveRelease(ctx, plain))
+
+	got, err := s.GetRelease(ctx, "app", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, release.Checksums, got.Checksums)
+	assert.Equal(t, release.PGPSignature, got.PGPSignature)
+	assert.Equal(t, "pro", got.RequiredEntitlement)
+	assert.Equal(t, release.Editions, got.Editions)
+	assert.Equal(t, release.ReleaseNotesDraft, got.ReleaseNotesDraft)
+	assert.Equal(t, "lts", got.Channel)
+	assert.Equal(t, release.Targeting, got.Targeting)
+	assert.Equal(t, models.VariantDeb, got.Variant)
+	assert.Equal(t, release.Variants, got.Variants)
+	assert.True(t, got.Paused)
+	assert.True(t, got.Yanked)
+	assert.Equal(t, "crashes on start", got.YankReason)
+	assert.Equal(t, int64(42), got.VersionCode)
+	assert.Equal(t, release.SigningCertSHA256, got.SigningCertSHA256)
+	assert.Equal(t, release.StatusChecks, got.StatusChecks)
+	assert.True(t, got.ArtifactPending)
+
+	releases, _, err := s.ListReleasesPaged(ctx, "app", models.ReleaseFilters{}, "release_date", "asc", 50, nil)
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+	for _, r := range releases {
+		if r.Version == "1.0.0" {
+			assert.Equal(t, release.Checksums, r.Checksums)
+			assert.Equal(t, release.PGPSignature, r.PGPSignature)
+			assert.Equal(t, "pro", r.RequiredEntitlement)
+			assert.Equal(t, release.Editions, r.Editions)
+			assert.Equal(t, release.ReleaseNotesDraft, r.ReleaseNotesDraft)
+			assert.Equal(t, "lts", r.Channel)
+			assert.Equal(t, release.Targeting, r.Targeting)
+			assert.Equal(t, models.VariantDeb, r.Variant)
+			assert.Equal(t, release.Variants, r.Variants)
+			assert.True(t, r.Paused)
+			assert.True(t, r.Yanked)
+			assert.Equal(t, "crashes on start", r.YankReason)
+			assert.Equal(t, int64(42), r.VersionCode)
+			assert.Equal(t, release.SigningCertSHA256, r.SigningCertSHA256)
+			assert.Equal(t, release.StatusChecks, r.StatusChecks)
+			assert.True(t, r.ArtifactPending)
+		} else {
+			assert.Nil(t, r.Checksums)
+			assert.Empty(t, r.RequiredEntitlement)
+			assert.Nil(t, r.Editions)
+			assert.Empty(t, r.ReleaseNotesDraft)
+			assert.Empty(t, r.Channel)
+			assert.Nil(t, r.Targeting)
+			assert.Empty(t, r.Variant)
+			assert.Nil(t, r.Variants)
+			assert.False(t, r.Paused)
+			assert.False(t, r.Yanked)
+			assert.Empty(t, r.YankReason)
+			assert.Zero(t, r.VersionCode)
+			assert.Empty(t, r.SigningCertSHA256)
+			assert.Nil(t, r.StatusChecks)
+			assert.False(t, r.ArtifactPending)
+		}
+	}
+}
+
+func TestSQLiteStorage_SaveReleases(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("multi", "Multi", []string{"windows", "linux"})))
+
+	require.NoError(t, s.SaveReleases(ctx, []*models.Release{
+		models.NewRelease("multi", "1.0.0", "windows", "amd64", "https://example.com/w"),
+		models.NewRelease("multi", "1.0.0", "linux", "amd64", "https://example.com/l"),
+	}))
+	_, total, err := s.ListReleasesPaged(ctx, "multi", models.ReleaseFilters{}, "release_date", "desc", 50, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	// The second release violates the application foreign key, so the first
+	// must be rolled back with it.
+	err = s.SaveReleases(ctx, []*models.Release{
+		models.NewRelease("multi", "2.0.0", "windows", "amd64", "https://example.com/w2"),
+		models.NewRelease("missing-app", "2.0.0", "linux", "amd64", "https://example.com/l2"),
+	})
+	require.Error(t, err)
+	_, err = s.GetRelease(ctx, "multi", "2.0.0", "windows", "amd64")
+	assert.Error(t, err, "first release of a failed batch must be rolled back")
+}
+
+func TestSQLiteStorage_ContainerImages(t *testing.T) {
+	s := newSQLiteTestStorage(t)
+	ctx := context.Background()
+	require.NoError(t, s.SaveApplication(ctx, models.NewApplication("agent", "Agent", []string{"linux"})))
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	image := models.NewContainerImage("agent", "ghcr.io/acme/agent", "1.0.0", digest, []string{"linux/amd64", "linux/arm/v7"})
+	image.ReleaseNotes = "First image"
+	image.Required = true
+	require.NoError(t, s.SaveContainerImage(ctx, image))
+
+	images, err := s.ListContainerImages(ctx, "agent")
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, "agent-1.0.0", images[0].ID)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm/v7"}, images[0].Platforms)
+	assert.Equal(t, "First image", images[0].ReleaseNotes)
+	assert.True(t, images[0].Required)
+
+	// Re-pushing the tag replaces the digest rather than adding a row.
+	repushed := models.NewContainerImage("agent", "ghcr.io/acme/agent", "1.0.0", "sha256:"+strings.Repeat("b", 64), []string{"linux/amd64"})
+	require.NoError(t, s.SaveContainerImage(ctx, repushed))
+	images, err = s.ListContainerImages(ctx, "agent")
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, repushed.Digest, images[0].Digest)
+
+	require.NoError(t, s.DeleteContainerImage(ctx, "agent", "1.0.0"))
+	assert.ErrorIs(t, s.DeleteContainerImage(ctx, "agent", "1.0.0"), ErrNotFound)
+}
+
+func TestSQLiteStorage_HasPendingMigrations(t *testing.T) {
+	s := newSQLiteTestStorage(t).(*SQLiteStorage)
+	pending, err := s.HasPendingMigrations(context.Background())
+	require.NoError(t, err)
+	assert.False(t, pending)
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	unmigrated := newSQLiteStorageFromDB(db)
+	t.Cleanup(func() { unmigrated.Close() })
+	pending, err = unmigrated.HasPendingMigrations(context.Background())
+	require.NoError(t, err)
+	assert.True(t, pending)
+}
@@ -0,0 +1,64 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"updater/internal/models"
+)
+
+// GetAndroidIndex lists the newest APK of each Android architecture of an
+// application on channel. Every architecture is looked up as a latest-version
+// lookup, so pausing, yanking, targeting and entitlements apply as usual, and
+// architectures without a release are left out. The in-app updater picks the
+// package for its ABI and compares the version code and signing digest itself.
+func (s *Service) GetAndroidIndex(ctx context.Context, appID, channel, licenseToken string) (*models.AndroidIndex, error) {
+	app, err := s.storage.GetApplication(ctx, appID)
+	if err != nil {
+		return nil, NewApplicationNotFoundError(appID)
+	}
+	if channel == "" {
+		channel = models.ChannelStable
+	}
+	if !slices.Contains(app.Platforms, models.PlatformAndroid) {
+		return nil, NewNotFoundError(fmt.Sprintf("application %s does not support android", appID))
+	}
+
+	index := &models.AndroidIndex{ApplicationID: appID, Channel: channel, Packages: []models.AndroidPackage{}}
+	for _, arch := range models.AndroidArchitectures {
+		latest, err := s.GetLatestVersion(ctx, &models.LatestVersionRequest{
+			ApplicationID: appID,
+			Platform:      models.PlatformAndroid,
+			Architecture:  arch,
+			LicenseToken:  licenseToken,
+			Channel:       channel,
+		})
+		if err != nil {
+			var serviceError *ServiceError
+			if errors.As(err, &serviceError) && serviceError.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		index.Packages = append(index.Packages, models.AndroidPackage{
+			ABI:               models.AndroidABI(arch),
+			Architecture:      arch,
+			VersionName:       latest.Version,
+			VersionCode:       latest.VersionCode,
+			SigningCertSHA256: latest.SigningCertSHA256,
+			DownloadURL:       latest.DownloadURL,
+			Checksum:          latest.Checksum,
+			ChecksumType:      latest.ChecksumType,
+			FileSize:          latest.FileSize,
+			ReleaseNotes:      latest.ReleaseNotes,
+			ReleaseDate:       latest.ReleaseDate,
+			Required:          latest.Required,
+		})
+	}
+	if len(index.Packages) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("no android releases of %s on channel %s", appID, channel))
+	}
+	return index, nil
+}
@@ -0,0 +1,83 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_GetAndroidIndex(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "droid-app", Name: "Droid App", Platforms: []string{"android"}})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "empty-app", Name: "Empty App", Platforms: []string{"android"}})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "desktop-app", Name: "Desktop App", Platforms: []string{"linux"}})
+	save := func(version, arch string, versionCode int64) {
+		release := createTestReleaseForUpdate("droid-app", version, "android", arch)
+		release.DownloadURL = "https://cdn.example.com/app-" + arch + "-" + version + ".apk"
+		release.VersionCode = versionCode
+		release.SigningCertSHA256 = "a40da80a59d170caa950cf15c18c454d47a39b26989d8b640ecd745ba71bf5dc"
+		mockStorage.SaveRelease(ctx, release)
+	}
+	save("1.0.0", "arm64", 10)
+	save("1.1.0", "arm64", 11)
+	save("1.2.0-beta.1", "arm64", 12)
+	save("1.0.0", "arm", 10)
+
+	t.Run("newest release per architecture", func(t *testing.T) {
+		index, err := service.GetAndroidIndex(ctx, "droid-app", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, "droid-app", index.ApplicationID)
+		assert.Equal(t, models.ChannelStable, index.Channel)
+		require.Len(t, index.Packages, 2)
+		arm64 := index.Packages[0]
+		assert.Equal(t, "arm64-v8a", arm64.ABI)
+		assert.Equal(t, "arm64", arm64.Architecture)
+		assert.Equal(t, "1.1.0", arm64.VersionName)
+		assert.Equal(t, int64(11), arm64.VersionCode)
+		assert.Equal(t, "a40da80a59d170caa950cf15c18c454d47a39b26989d8b640ecd745ba71bf5dc", arm64.SigningCertSHA256)
+		assert.Equal(t, "https://cdn.example.com/app-arm64-1.1.0.apk", arm64.DownloadURL)
+		assert.Equal(t, "abc123", arm64.Checksum)
+		assert.Equal(t, "armeabi-v7a", index.Packages[1].ABI)
+		assert.Equal(t, "1.0.0", index.Packages[1].VersionName)
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		index, err := service.GetAndroidIndex(ctx, "droid-app", models.ChannelBeta, "")
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.0-beta.1", index.Packages[0].VersionName)
+		assert.Equal(t, int64(12), index.Packages[0].VersionCode)
+	})
+
+	t.Run("paused release is left out", func(t *testing.T) {
+		_, err := service.PauseRelease(ctx, "droid-app", "1.0.0", "android", "arm")
+		require.NoError(t, err)
+		index, err := service.GetAndroidIndex(ctx, "droid-app", "", "")
+		require.NoError(t, err)
+		require.Len(t, index.Packages, 1)
+		assert.Equal(t, "arm64", index.Packages[0].Architecture)
+	})
+
+	t.Run("no releases", func(t *testing.T) {
+		_, err := service.GetAndroidIndex(ctx, "empty-app", "", "")
+		assertServiceError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("not an android application", func(t *testing.T) {
+		_, err := service.GetAndroidIndex(ctx, "desktop-app", "", "")
+		assertServiceError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		_, err := service.GetAndroidIndex(ctx, "missing", "", "")
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+	})
+}
@@ -0,0 +1,37 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"updater/internal/models"
+	"updater/internal/storage"
+)
+
+type anomalyReporterContextKey struct{}
+
+// AnomalyReporter receives the suspicious traits of an update check, one of
+// the models.Anomaly kinds, and the application the check was for.
+type AnomalyReporter func(kind, appID string)
+
+// WithAnomalyReporter returns a context whose update checks report anomalies
+// to report.
+func WithAnomalyReporter(ctx context.Context, report AnomalyReporter) context.Context {
+	return context.WithValue(ctx, anomalyReporterContextKey{}, report)
+}
+
+// reportAnomaly passes an anomaly to the context's reporter, if any.
+func reportAnomaly(ctx context.Context, kind, appID string) {
+	if report, ok := ctx.Value(anomalyReporterContextKey{}).(AnomalyReporter); ok {
+		report(kind, appID)
+	}
+}
+
+// reportUnknownApplication reports a check for an application that does not
+// exist, given the error of looking it up. Other lookup errors, such as a
+// storage outage, are not the client's doing and are not reported, so a
+// storage blip does not block every client that checks during it.
+func reportUnknownApplication(ctx context.Context, appID string, err error) {
+	if errors.Is(err, storage.ErrNotFound) {
+		reportAnomaly(ctx, models.AnomalyUnknownApplication, appID)
+	}
+}
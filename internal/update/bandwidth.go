@@ -0,0 +1,113 @@
+package update
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+	"updater/internal/models"
+)
+
+// bandwidthMeter tracks the bytes charged to each application's bandwidth
+// budget in the current hour. Charges are kept in memory, so each replica
+// enforces the budget on its own share of the checks.
+type bandwidthMeter struct {
+	mu      sync.Mutex
+	windows map[string]*bandwidthWindow
+}
+
+// bandwidthWindow is the charges of one application in one hour.
+type bandwidthWindow struct {
+	start time.Time
+	used  int64
+}
+
+func newBandwidthMeter() *bandwidthMeter {
+	return &bandwidthMeter{windows: make(map[string]*bandwidthWindow)}
+}
+
+// window returns appID's window for the hour containing now, starting a new
+// one when the hour has changed. The caller must hold m.mu.
+func (m *bandwidthMeter) window(appID string, now time.Time) *bandwidthWindow {
+	start := now.Truncate(models.BandwidthWindow)
+	w := m.windows[appID]
+	if w == nil || !w.start.Equal(start) {
+		w = &bandwidthWindow{start: start}
+		m.windows[appID] = w
+	}
+	return w
+}
+
+// offer decides whether an offer of size bytes fits appID's budget and, when
+// charge is set, charges it. Priority offers always fit. It returns the
+// hour's charges and whether this offer spent the budget.
+func (m *bandwidthMeter) offer(appID string, budget, size int64, priority, charge bool, now time.Time) (ok bool, used int64, spent bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := m.window(appID, now)
+	if w.used >= budget && !priority {
+		return false, w.used, false
+	}
+	if charge {
+		spent = w.used < budget && w.used+size >= budget
+		w.used += size
+	}
+	return true, w.used, spent
+}
+
+// usage returns appID's charges in the hour containing now and the hour's
+// start.
+func (m *bandwidthMeter) usage(appID string, now time.Time) (int64, time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := m.window(appID, now)
+	return w.used, w.start
+}
+
+// bandwidthUsage reports the application's bandwidth budget and the current
+// hour's charges, or nil when it has no budget.
+func (s *Service) bandwidthUsage(app *models.Application) *models.BandwidthUsage {
+	if app.Config.BandwidthBudget == 0 {
+		return nil
+	}
+	used, start := s.bandwidth.usage(app.ID, s.now())
+	return models.NewBandwidthUsage(app.Config.BandwidthBudget, used, start)
+}
+
+type dryRunContextKey struct{}
+
+// withDryRun marks the checks run with ctx as dry runs, which are not
+// charged to bandwidth budgets.
+func withDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, true)
+}
+
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunContextKey{}).(bool)
+	return dryRun
+}
+
+// chargeBandwidth charges an offer to the application's bandwidth budget and
+// replaces it with a no-update response when the budget is spent.
+func (s *Service) chargeBandwidth(ctx context.Context, app *models.Application, result *models.UpdateCheckResponse, trace *decisionTrace) *models.UpdateCheckResponse {
+	budget := app.Config.BandwidthBudget
+	now := s.now()
+	ok, used, spent := s.bandwidth.offer(app.ID, budget, result.FileSize, result.IsPriority(), !isDryRun(ctx), now)
+	if spent {
+		slog.WarnContext(ctx, "Bandwidth budget spent; pausing update offers until the next hour",
+			"application_id", app.ID, "budget_bytes", budget, "used_bytes", used)
+	}
+	if !ok {
+		resume := now.Truncate(models.BandwidthWindow).Add(models.BandwidthWindow).UTC()
+		trace.add(models.RuleBandwidthBudget, models.DecisionFail, result.LatestVersion, "%d of %d bytes offered this hour; offers resume at %s", used, budget, resume.Format(time.RFC3339))
+		paused := &models.UpdateCheckResponse{}
+		paused.SetNoUpdateAvailable(result.CurrentVersion)
+		return paused
+	}
+	if result.IsPriority() {
+		trace.add(models.RuleBandwidthBudget, models.DecisionPass, result.LatestVersion, "required or security release; offered regardless of the budget")
+	} else {
+		trace.add(models.RuleBandwidthBudget, models.DecisionPass, result.LatestVersion, "%d of %d bytes offered this hour", used, budget)
+	}
+	return result
+}
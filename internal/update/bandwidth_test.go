@@ -0,0 +1,89 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CheckForUpdate_BandwidthBudget(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	mockStorage := NewMockStorage()
+	app := &models.Application{ID: "cdn-app", Name: "CDN App", Platforms: []string{"windows"}, Config: models.ApplicationConfig{BandwidthBudget: 2_000_000}}
+	require.NoError(t, mockStorage.SaveApplication(ctx, app))
+	require.NoError(t, mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("cdn-app", "2.0.0", "windows", "amd64")))
+	service := NewService(mockStorage, WithClock(func() time.Time { return now }))
+
+	req := func() *models.UpdateCheckRequest {
+		return &models.UpdateCheckRequest{ApplicationID: "cdn-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64"}
+	}
+	check := func() *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, req())
+		require.NoError(t, err)
+		return resp
+	}
+
+	// Each offer is charged the 1234567-byte file; the second spends the budget
+	assert.True(t, check().UpdateAvailable)
+	assert.True(t, check().UpdateAvailable)
+	paused := check()
+	assert.False(t, paused.UpdateAvailable)
+	assert.Empty(t, paused.DownloadURL)
+	assert.Equal(t, "1.0.0", paused.CurrentVersion)
+
+	dryRun, err := service.DryRunCheckForUpdate(ctx, req())
+	require.NoError(t, err)
+	assert.Equal(t, models.CheckDecisionNoUpdate, dryRun.Decision)
+	last := dryRun.Trace[len(dryRun.Trace)-1]
+	assert.Equal(t, models.RuleBandwidthBudget, last.Rule)
+	assert.Equal(t, models.DecisionFail, last.Result)
+	assert.Contains(t, last.Detail, "offers resume at 2026-10-16T13:00:00Z")
+
+	info, err := service.GetApplication(ctx, "cdn-app")
+	require.NoError(t, err)
+	require.NotNil(t, info.Bandwidth)
+	assert.Equal(t, models.BandwidthUsage{
+		BudgetBytes: 2_000_000,
+		UsedBytes:   2 * 1234567,
+		WindowStart: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		Paused:      true,
+	}, *info.Bandwidth)
+
+	// Required releases are offered while paused
+	required := createTestReleaseForUpdate("cdn-app", "2.0.1", "windows", "amd64")
+	required.Required = true
+	require.NoError(t, mockStorage.SaveRelease(ctx, required))
+	resp := check()
+	assert.True(t, resp.UpdateAvailable)
+	assert.Equal(t, "2.0.1", resp.LatestVersion)
+
+	now = now.Add(30 * time.Minute)
+	info, err = service.GetApplication(ctx, "cdn-app")
+	require.NoError(t, err)
+	assert.Zero(t, info.Bandwidth.UsedBytes)
+	assert.False(t, info.Bandwidth.Paused, "a new hour starts a new budget")
+}
+
+func TestService_DryRunCheckForUpdate_NotCharged(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := NewMockStorage()
+	app := &models.Application{ID: "cdn-app", Name: "CDN App", Platforms: []string{"windows"}, Config: models.ApplicationConfig{BandwidthBudget: 1}}
+	require.NoError(t, mockStorage.SaveApplication(ctx, app))
+	require.NoError(t, mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("cdn-app", "2.0.0", "windows", "amd64")))
+	service := NewService(mockStorage)
+
+	req := &models.UpdateCheckRequest{ApplicationID: "cdn-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64"}
+	for range 3 {
+		resp, err := service.DryRunCheckForUpdate(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, models.CheckDecisionUpdate, resp.Decision)
+	}
+	info, err := service.GetApplication(ctx, "cdn-app")
+	require.NoError(t, err)
+	assert.Zero(t, info.Bandwidth.UsedBytes)
+}
@@ -0,0 +1,146 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"updater/internal/events"
+	"updater/internal/models"
+)
+
+// checkChannelUpdate offers the newest release newer than the client's
+// current version on a channel the client follows. The channel replaces
+// allow_prerelease: a beta client is offered pre-releases published to beta
+// and a stable client is not offered a pre-release published to stable.
+func (s *Service) checkChannelUpdate(ctx context.Context, app *models.Application, req *models.UpdateCheckRequest, trace *decisionTrace) (*models.UpdateCheckResponse, error) {
+	candidates, err := s.storage.GetReleasesAfterVersion(ctx, req.ApplicationID, req.CurrentVersion, req.Platform, req.Architecture)
+	if err != nil {
+		return nil, NewInternalError("failed to get newer releases", err)
+	}
+	candidates = channelReleases(candidates, req.Channel, trace)
+
+	filter := s.newOfferFilter(req.ApplicationID, req.Edition, req.LicenseToken, req.Channel, req.Attributes())
+	var release *models.Release
+	if app.ParentID != "" && req.HostVersion != "" {
+		trace.add(models.RuleHostCompatibility, models.DecisionPass, "", "plugin of %s; only releases compatible with host version %s are considered", app.ParentID, req.HostVersion)
+		release, err = newestCompatibleRelease(ctx, candidates, req.HostVersion, true, filter, trace)
+		if err != nil {
+			return nil, NewInternalError("failed to check host compatibility", err)
+		}
+	} else {
+		release = newestAllowedRelease(ctx, candidates, true, filter, trace)
+	}
+
+	response := &models.UpdateCheckResponse{
+		CurrentVersion: req.CurrentVersion,
+	}
+	if release == nil {
+		trace.add(models.RuleNewerVersion, models.DecisionFail, "", "no release on channel %s newer than current version %s", req.Channel, req.CurrentVersion)
+		response.SetNoUpdateAvailable(req.CurrentVersion)
+		return response, nil
+	}
+	trace.add(models.RuleNewerVersion, models.DecisionPass, release.Version, "newer than current version %s", req.CurrentVersion)
+
+	if err := checkMinimumVersion(release, req.CurrentVersion, trace); err != nil {
+		return nil, err
+	}
+
+	response.SetUpdateAvailable(release)
+	response.ReleaseNotes = s.expandReleaseNotes(ctx, release)
+	if !req.IncludeMetadata {
+		response.Metadata = nil
+	}
+	return response, nil
+}
+
+// clientChannel returns the channel whose releases a client is offered: the
+// one it follows or, for clients that follow none, stable, which also covers
+// beta when they allow pre-releases. Releases published to nightly or a
+// custom channel are thus offered only to the clients that follow it.
+func clientChannel(channel string, allowPrerelease bool) string {
+	switch {
+	case channel != "":
+		return channel
+	case allowPrerelease:
+		return models.ChannelBeta
+	default:
+		return models.ChannelStable
+	}
+}
+
+// channelReleases returns the releases a client following channel is offered.
+func channelReleases(releases []*models.Release, channel string, trace *decisionTrace) []*models.Release {
+	var offered []*models.Release
+	for _, release := range releases {
+		if !models.ChannelOffers(channel, release) {
+			trace.add(models.RuleChannel, models.DecisionFail, release.Version, "on channel %s, which %s clients are not offered", release.EffectiveChannel(), channel)
+			continue
+		}
+		offered = append(offered, release)
+	}
+	return offered
+}
+
+// ListChannels summarizes the channels an application's releases are
+// published to. Releases are read a page at a time, as in
+// GetApplicationUsage.
+func (s *Service) ListChannels(ctx context.Context, appID string) (*models.ChannelListResponse, error) {
+	if _, err := s.storage.GetApplication(ctx, appID); err != nil {
+		return nil, NewApplicationNotFoundError(appID)
+	}
+
+	var all []*models.Release
+	var cursor *models.ReleaseCursor
+	for {
+		releases, _, err := s.storage.ListReleasesPaged(ctx, appID, models.ReleaseFilters{}, "created_at", "asc", models.MaxPageSize, cursor)
+		if err != nil {
+			return nil, NewInternalError("failed to list releases", err)
+		}
+		all = append(all, releases...)
+		if len(releases) < models.MaxPageSize {
+			break
+		}
+		last := releases[len(releases)-1]
+		cursor = &models.ReleaseCursor{SortBy: "created_at", SortOrder: "asc", ID: last.ID, CreatedAt: last.CreatedAt}
+	}
+	return models.ListChannels(appID, all), nil
+}
+
+// PublishToChannel moves every release of a version, across platforms, to a
+// channel, such as promoting a beta to stable. The releases are saved
+// atomically.
+func (s *Service) PublishToChannel(ctx context.Context, appID, channel, version string) (*models.PublishToChannelResponse, error) {
+	channel = models.NormalizeChannel(channel)
+	if err := models.ValidateChannel(channel); err != nil {
+		return nil, NewValidationError("invalid channel", err)
+	}
+	app, err := s.storage.GetApplication(ctx, appID)
+	if err != nil {
+		return nil, NewApplicationNotFoundError(appID)
+	}
+	if err := s.checkFreeze(ctx, app, channel); err != nil {
+		return nil, err
+	}
+
+	releases, _, err := s.storage.ListReleasesPaged(ctx, appID, models.ReleaseFilters{Version: version}, "created_at", "asc", models.MaxPageSize, nil)
+	if err != nil {
+		return nil, NewInternalError("failed to list releases", err)
+	}
+	if len(releases) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("no releases of %s version %s", appID, version))
+	}
+
+	now := s.now().UTC()
+	resp := &models.PublishToChannelResponse{ApplicationID: appID, Channel: channel, Version: version}
+	for _, release := range releases {
+		release.Channel = channel
+		release.UpdatedAt = now
+		resp.ReleaseIDs = append(resp.ReleaseIDs, release.ID)
+	}
+	if err := s.storage.SaveReleases(ctx, releases); err != nil {
+		return nil, NewInternalError("failed to save releases", err)
+	}
+	for _, release := range releases {
+		s.publishRelease(events.ReleaseUpdated, release)
+	}
+	return resp, nil
+}
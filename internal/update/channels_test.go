@@ -0,0 +1,222 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupChannelTest(t *testing.T) (*Service, storage.Storage) {
+	t.Helper()
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("channel-app", "Channel App", []string{"windows", "linux"})))
+
+	for _, r := range []struct{ version, channel string }{
+		{"1.1.0", ""},
+		{"1.2.0-beta.1", ""},
+		{"1.3.0-nightly.1", models.ChannelNightly},
+		{"1.1.5", "lts"},
+	} {
+		for _, platform := range []string{"windows", "linux"} {
+			release := models.NewRelease("channel-app", r.version, platform, "amd64", "https://example.com/app-"+r.version)
+			release.Channel = r.channel
+			require.NoError(t, store.SaveRelease(ctx, release))
+		}
+	}
+	return NewService(store), store
+}
+
+func TestService_CheckForUpdate_Channel(t *testing.T) {
+	service, _ := setupChannelTest(t)
+
+	tests := []struct {
+		channel     string
+		wantVersion string
+	}{
+		{"stable", "1.1.0"},
+		{"Beta", "1.2.0-beta.1"},
+		{"nightly", "1.3.0-nightly.1"},
+		{"lts", "1.1.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			resp, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+				ApplicationID:  "channel-app",
+				CurrentVersion: "1.0.0",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				Channel:        tt.channel,
+			})
+			require.NoError(t, err)
+			assert.True(t, resp.UpdateAvailable)
+			assert.Equal(t, tt.wantVersion, resp.LatestVersion)
+		})
+	}
+
+	t.Run("no newer release on the channel", func(t *testing.T) {
+		resp, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+			ApplicationID:  "channel-app",
+			CurrentVersion: "1.1.5",
+			Platform:       "windows",
+			Architecture:   "amd64",
+			Channel:        "lts",
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.UpdateAvailable)
+	})
+
+	t.Run("invalid channel", func(t *testing.T) {
+		_, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+			ApplicationID:  "channel-app",
+			CurrentVersion: "1.0.0",
+			Platform:       "windows",
+			Architecture:   "amd64",
+			Channel:        "not a channel",
+		})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+}
+
+func TestService_GetLatestVersion_Channel(t *testing.T) {
+	service, _ := setupChannelTest(t)
+
+	resp, err := service.GetLatestVersion(context.Background(), &models.LatestVersionRequest{
+		ApplicationID: "channel-app",
+		Platform:      "windows",
+		Architecture:  "amd64",
+		Channel:       "beta",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0-beta.1", resp.Version)
+
+	_, err = service.GetLatestVersion(context.Background(), &models.LatestVersionRequest{
+		ApplicationID: "channel-app",
+		Platform:      "windows",
+		Architecture:  "amd64",
+		Channel:       "canary",
+	})
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+}
+
+func TestService_CheckForUpdate_NoChannel(t *testing.T) {
+	service, store := setupChannelTest(t)
+
+	tests := []struct {
+		name            string
+		allowPrerelease bool
+		wantVersion     string
+	}{
+		{"stable only", false, "1.1.0"},
+		{"pre-releases allowed", true, "1.2.0-beta.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+				ApplicationID:   "channel-app",
+				CurrentVersion:  "1.0.0",
+				Platform:        "windows",
+				Architecture:    "amd64",
+				AllowPrerelease: tt.allowPrerelease,
+			})
+			require.NoError(t, err)
+			assert.True(t, resp.UpdateAvailable)
+			assert.Equal(t, tt.wantVersion, resp.LatestVersion, "lts and nightly releases are only offered to their clients")
+
+			latest, err := service.GetLatestVersion(context.Background(), &models.LatestVersionRequest{
+				ApplicationID:   "channel-app",
+				Platform:        "windows",
+				Architecture:    "amd64",
+				AllowPrerelease: tt.allowPrerelease,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, latest.Version)
+		})
+	}
+
+	t.Run("decision trace", func(t *testing.T) {
+		dryRun, err := service.DryRunCheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+			ApplicationID: "channel-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, dryRun.Trace, models.DecisionStep{
+			Rule: models.RuleChannel, Result: models.DecisionFail, Release: "1.1.5", Detail: "on channel lts, which stable clients are not offered",
+		})
+	})
+
+	t.Run("yanked current version", func(t *testing.T) {
+		ctx := context.Background()
+		require.NoError(t, store.SaveRelease(ctx, models.NewRelease("channel-app", "1.1.8", "windows", "amd64", "https://example.com/app-1.1.8")))
+		_, err := service.YankRelease(ctx, "channel-app", "1.1.8", "windows", "amd64", &models.YankReleaseRequest{})
+		require.NoError(t, err)
+
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "channel-app", CurrentVersion: "1.1.8", Platform: "windows", Architecture: "amd64",
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Downgrade)
+		assert.Equal(t, "1.1.0", resp.LatestVersion, "the lts release is not offered as a downgrade")
+	})
+}
+
+func TestService_PublishToChannel(t *testing.T) {
+	ctx := context.Background()
+	service, store := setupChannelTest(t)
+
+	resp, err := service.PublishToChannel(ctx, "channel-app", " Stable ", "1.2.0-beta.1")
+	require.NoError(t, err)
+	assert.Equal(t, "stable", resp.Channel)
+	assert.Len(t, resp.ReleaseIDs, 2, "every platform of the version moves")
+
+	stored, err := store.GetRelease(ctx, "channel-app", "1.2.0-beta.1", "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, models.ChannelStable, stored.Channel)
+
+	check, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID:  "channel-app",
+		CurrentVersion: "1.1.0",
+		Platform:       "linux",
+		Architecture:   "amd64",
+		Channel:        "stable",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0-beta.1", check.LatestVersion, "the promoted release is offered to stable clients")
+
+	_, err = service.PublishToChannel(ctx, "channel-app", "stable", "9.9.9")
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeNotFound, serviceErr.Code)
+
+	_, err = service.PublishToChannel(ctx, "channel-app", "bad channel", "1.1.0")
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+}
+
+func TestService_ListChannels(t *testing.T) {
+	service, _ := setupChannelTest(t)
+
+	resp, err := service.ListChannels(context.Background(), "channel-app")
+	require.NoError(t, err)
+	require.Len(t, resp.Channels, 4)
+	names := make([]string, 0, len(resp.Channels))
+	for _, c := range resp.Channels {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"stable", "beta", "nightly", "lts"}, names)
+	assert.Equal(t, "1.2.0-beta.1", resp.Channels[1].LatestVersion)
+	assert.Equal(t, 2, resp.Channels[1].Releases)
+
+	_, err = service.ListChannels(context.Background(), "missing-app")
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+}
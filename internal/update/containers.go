@@ -0,0 +1,174 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// RegisterContainerImage stores a container image tag for an application.
+// Registering an existing tag replaces its digest and platforms, which is how
+// a rebuilt image under the same tag is published.
+func (s *Service) RegisterContainerImage(ctx context.Context, req *models.RegisterContainerImageRequest) (*models.ContainerImage, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+
+	app, err := s.storage.GetApplication(ctx, req.ApplicationID)
+	if err != nil {
+		return nil, NewApplicationNotFoundError(req.ApplicationID)
+	}
+
+	for _, platform := range req.Platforms {
+		if goos := models.ImagePlatformOS(platform); !app.SupportsPlatform(goos) {
+			return nil, NewInvalidRequestError(fmt.Sprintf("application %s does not support platform %s", app.ID, goos), nil)
+		}
+	}
+
+	image := models.NewContainerImage(app.ID, req.Repository, req.Tag, req.Digest, req.Platforms)
+	image.CreatedAt = s.now()
+	image.UpdatedAt = image.CreatedAt
+	image.ReleaseNotes = req.ReleaseNotes
+	image.Required = req.Required
+	if err := image.Validate(); err != nil {
+		return nil, NewValidationError("invalid container image", err)
+	}
+
+	if err := s.storage.SaveContainerImage(ctx, image); err != nil {
+		return nil, NewInternalError("failed to save container image", err)
+	}
+	return image, nil
+}
+
+// ListContainerImages returns an application's container image tags, highest
+// version first.
+func (s *Service) ListContainerImages(ctx context.Context, appID string) (*models.ListContainerImagesResponse, error) {
+	if _, err := s.storage.GetApplication(ctx, appID); err != nil {
+		return nil, NewApplicationNotFoundError(appID)
+	}
+
+	images, err := s.storage.ListContainerImages(ctx, appID)
+	if err != nil {
+		return nil, NewInternalError("failed to list container images", err)
+	}
+	sortImagesByVersionDesc(images)
+
+	resp := &models.ListContainerImagesResponse{
+		ApplicationID: appID,
+		Images:        make([]models.ContainerImage, len(images)),
+	}
+	for i, image := range images {
+		resp.Images[i] = *image
+	}
+	return resp, nil
+}
+
+// DeleteContainerImage removes a container image tag.
+func (s *Service) DeleteContainerImage(ctx context.Context, appID, tag string) (*models.DeleteContainerImageResponse, error) {
+	if err := s.storage.DeleteContainerImage(ctx, appID, tag); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, NewNotFoundError(fmt.Sprintf("container image '%s:%s' not found", appID, tag))
+		}
+		return nil, NewInternalError("failed to delete container image", err)
+	}
+
+	return &models.DeleteContainerImageResponse{
+		ID:      fmt.Sprintf("%s-%s", appID, tag),
+		Message: fmt.Sprintf("Container image '%s:%s' deleted successfully", appID, tag),
+	}, nil
+}
+
+// CheckContainerImage tells an agent whether to pull a different image. A
+// higher version tag for the agent's platform wins; otherwise, when the agent
+// reports the digest it is running and its tag has since been re-pushed with
+// a different digest, the re-pushed image is offered.
+func (s *Service) CheckContainerImage(ctx context.Context, req *models.ContainerImageCheckRequest) (*models.ContainerImageCheckResponse, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+
+	if _, err := s.storage.GetApplication(ctx, req.ApplicationID); err != nil {
+		reportUnknownApplication(ctx, req.ApplicationID, err)
+		return nil, NewApplicationNotFoundError(req.ApplicationID)
+	}
+
+	images, err := s.storage.ListContainerImages(ctx, req.ApplicationID)
+	if err != nil {
+		return nil, NewInternalError("failed to list container images", err)
+	}
+
+	current, err := semver.NewVersion(req.Tag)
+	if err != nil {
+		return nil, NewValidationError("invalid tag format", err)
+	}
+
+	var (
+		latest    *models.ContainerImage
+		latestVer *semver.Version
+		pinned    *models.ContainerImage
+	)
+	for _, image := range images {
+		if req.Platform != "" && !image.SupportsPlatform(req.Platform) {
+			continue
+		}
+		v, err := semver.NewVersion(image.Tag)
+		if err != nil {
+			continue
+		}
+		if v.Equal(current) {
+			pinned = image
+		}
+		if !req.AllowPrerelease && v.Prerelease() != "" {
+			continue
+		}
+		if latestVer == nil || v.GreaterThan(latestVer) {
+			latest, latestVer = image, v
+		}
+	}
+
+	resp := &models.ContainerImageCheckResponse{
+		CurrentTag:    req.Tag,
+		CurrentDigest: req.Digest,
+	}
+	switch {
+	case latest != nil && latestVer.GreaterThan(current):
+		setImageUpdate(resp, latest, models.ImageUpdateReasonNewerTag)
+	case pinned != nil && req.Digest != "" && pinned.Digest != req.Digest:
+		setImageUpdate(resp, pinned, models.ImageUpdateReasonDigestChanged)
+	case latest != nil:
+		resp.LatestTag = latest.Tag
+	}
+	return resp, nil
+}
+
+// setImageUpdate fills resp with the image the agent should pull.
+func setImageUpdate(resp *models.ContainerImageCheckResponse, image *models.ContainerImage, reason string) {
+	resp.UpdateAvailable = true
+	resp.Reason = reason
+	resp.LatestTag = image.Tag
+	resp.Digest = image.Digest
+	resp.Reference = image.Reference()
+	resp.Platforms = image.Platforms
+	resp.ReleaseNotes = image.ReleaseNotes
+	resp.Required = image.Required
+}
+
+// sortImagesByVersionDesc orders images by semantic version, highest first.
+// Tags that fail to parse sort last.
+func sortImagesByVersionDesc(images []*models.ContainerImage) {
+	sort.SliceStable(images, func(i, j int) bool {
+		vi, erri := semver.NewVersion(images[i].Tag)
+		vj, errj := semver.NewVersion(images[j].Tag)
+		if erri != nil || errj != nil {
+			return erri == nil
+		}
+		return vi.GreaterThan(vj)
+	})
+}
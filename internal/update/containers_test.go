@@ -0,0 +1,207 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	digestA = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	digestB = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	digestC = "sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"
+)
+
+// newContainerTestService returns a service with an "agent" application that
+// has images 1.0.0 (amd64 and arm64), 1.1.0 (amd64 only) and 2.0.0-beta.1.
+func newContainerTestService(t *testing.T) (*Service, *MockStorage) {
+	t.Helper()
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "agent", Name: "Agent", Platforms: []string{"linux"}})
+
+	for _, req := range []models.RegisterContainerImageRequest{
+		{Tag: "1.0.0", Digest: digestA, Platforms: []string{"linux/amd64", "linux/arm64"}},
+		{Tag: "1.1.0", Digest: digestB, Platforms: []string{"linux/amd64"}, Required: true},
+		{Tag: "2.0.0-beta.1", Digest: digestC, Platforms: []string{"linux/amd64", "linux/arm64"}},
+	} {
+		req.ApplicationID = "agent"
+		req.Repository = "ghcr.io/acme/agent"
+		_, err := service.RegisterContainerImage(ctx, &req)
+		require.NoError(t, err)
+	}
+	return service, mockStorage
+}
+
+func TestService_RegisterContainerImage(t *testing.T) {
+	t.Run("replaces an existing tag", func(t *testing.T) {
+		service, mockStorage := newContainerTestService(t)
+
+		image, err := service.RegisterContainerImage(context.Background(), &models.RegisterContainerImageRequest{
+			ApplicationID: "agent",
+			Repository:    "ghcr.io/acme/agent",
+			Tag:           "1.0.0",
+			Digest:        digestC,
+			Platforms:     []string{"linux/amd64"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "agent-1.0.0", image.ID)
+		assert.Len(t, mockStorage.images["agent"], 3)
+	})
+
+	t.Run("unsupported operating system", func(t *testing.T) {
+		service, _ := newContainerTestService(t)
+
+		_, err := service.RegisterContainerImage(context.Background(), &models.RegisterContainerImageRequest{
+			ApplicationID: "agent",
+			Repository:    "ghcr.io/acme/agent",
+			Tag:           "3.0.0",
+			Digest:        digestA,
+			Platforms:     []string{"windows/amd64"},
+		})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeInvalidRequest, serviceErr.Code)
+	})
+
+	t.Run("invalid digest", func(t *testing.T) {
+		service, _ := newContainerTestService(t)
+
+		_, err := service.RegisterContainerImage(context.Background(), &models.RegisterContainerImageRequest{
+			ApplicationID: "agent",
+			Repository:    "ghcr.io/acme/agent",
+			Tag:           "3.0.0",
+			Digest:        "latest",
+			Platforms:     []string{"linux/amd64"},
+		})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		service := NewService(NewMockStorage())
+
+		_, err := service.RegisterContainerImage(context.Background(), &models.RegisterContainerImageRequest{
+			ApplicationID: "missing",
+			Repository:    "ghcr.io/acme/agent",
+			Tag:           "1.0.0",
+			Digest:        digestA,
+			Platforms:     []string{"linux/amd64"},
+		})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+	})
+}
+
+func TestService_CheckContainerImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        models.ContainerImageCheckRequest
+		wantUpdate bool
+		wantReason string
+		wantTag    string
+		wantDigest string
+	}{
+		{
+			name:       "newer tag for platform",
+			req:        models.ContainerImageCheckRequest{Tag: "1.0.0", Platform: "linux/amd64"},
+			wantUpdate: true, wantReason: models.ImageUpdateReasonNewerTag, wantTag: "1.1.0", wantDigest: digestB,
+		},
+		{
+			name:    "newer tag lacks platform",
+			req:     models.ContainerImageCheckRequest{Tag: "1.0.0", Digest: digestA, Platform: "linux/arm64"},
+			wantTag: "1.0.0",
+		},
+		{
+			name:       "prerelease when allowed",
+			req:        models.ContainerImageCheckRequest{Tag: "1.0.0", Platform: "linux/arm64", AllowPrerelease: true},
+			wantUpdate: true, wantReason: models.ImageUpdateReasonNewerTag, wantTag: "2.0.0-beta.1", wantDigest: digestC,
+		},
+		{
+			name:       "same tag re-pushed",
+			req:        models.ContainerImageCheckRequest{Tag: "1.1.0", Digest: digestA, Platform: "linux/amd64"},
+			wantUpdate: true, wantReason: models.ImageUpdateReasonDigestChanged, wantTag: "1.1.0", wantDigest: digestB,
+		},
+		{
+			name:    "up to date",
+			req:     models.ContainerImageCheckRequest{Tag: "1.1.0", Digest: digestB},
+			wantTag: "1.1.0",
+		},
+		{
+			name:    "tag without digest is not compared",
+			req:     models.ContainerImageCheckRequest{Tag: "1.1.0"},
+			wantTag: "1.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newContainerTestService(t)
+			tt.req.ApplicationID = "agent"
+
+			resp, err := service.CheckContainerImage(context.Background(), &tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUpdate, resp.UpdateAvailable)
+			assert.Equal(t, tt.wantReason, resp.Reason)
+			assert.Equal(t, tt.wantTag, resp.LatestTag)
+			assert.Equal(t, tt.wantDigest, resp.Digest)
+			if tt.wantUpdate {
+				assert.Equal(t, "ghcr.io/acme/agent:"+tt.wantTag+"@"+tt.wantDigest, resp.Reference)
+			}
+		})
+	}
+
+	t.Run("required flag comes from offered image", func(t *testing.T) {
+		service, _ := newContainerTestService(t)
+		resp, err := service.CheckContainerImage(context.Background(), &models.ContainerImageCheckRequest{ApplicationID: "agent", Tag: "1.0.0"})
+		require.NoError(t, err)
+		assert.True(t, resp.Required)
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		service, _ := newContainerTestService(t)
+		_, err := service.CheckContainerImage(context.Background(), &models.ContainerImageCheckRequest{ApplicationID: "agent", Tag: "latest"})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		service := NewService(NewMockStorage())
+		_, err := service.CheckContainerImage(context.Background(), &models.ContainerImageCheckRequest{ApplicationID: "missing", Tag: "1.0.0"})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+	})
+}
+
+func TestService_ListContainerImages(t *testing.T) {
+	service, _ := newContainerTestService(t)
+
+	resp, err := service.ListContainerImages(context.Background(), "agent")
+	require.NoError(t, err)
+	require.Len(t, resp.Images, 3)
+	assert.Equal(t, "2.0.0-beta.1", resp.Images[0].Tag)
+	assert.Equal(t, "1.1.0", resp.Images[1].Tag)
+	assert.Equal(t, "1.0.0", resp.Images[2].Tag)
+}
+
+func TestService_DeleteContainerImage(t *testing.T) {
+	service, mockStorage := newContainerTestService(t)
+
+	resp, err := service.DeleteContainerImage(context.Background(), "agent", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1.0.0", resp.ID)
+	assert.Len(t, mockStorage.images["agent"], 2)
+
+	_, err = service.DeleteContainerImage(context.Background(), "agent", "1.0.0")
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeNotFound, serviceErr.Code)
+}
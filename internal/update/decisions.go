@@ -0,0 +1,106 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"updater/internal/models"
+)
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a context carrying the ID under which update checks
+// made with it are recorded in the decision log.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID set by WithRequestID, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// DecisionLog keeps the decision traces of the most recent update checks in a
+// fixed-size ring, so support can look up why a client was or was not offered
+// a release after the fact. It is process-local: with several replicas a
+// request ID is only found on the instance that served it.
+type DecisionLog struct {
+	mu      sync.Mutex
+	records []models.DecisionRecord
+	next    int
+}
+
+// NewDecisionLog creates a decision log holding up to size checks.
+func NewDecisionLog(size int) *DecisionLog {
+	return &DecisionLog{
+		records: make([]models.DecisionRecord, 0, size),
+	}
+}
+
+// add records a check, overwriting the oldest record when the log is full.
+// Records without a time are stamped with the current time.
+func (l *DecisionLog) add(record models.DecisionRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if record.RecordedAt.IsZero() {
+		record.RecordedAt = time.Now().UTC()
+	}
+	if len(l.records) < cap(l.records) {
+		l.records = append(l.records, record)
+		return
+	}
+	l.records[l.next] = record
+	l.next = (l.next + 1) % len(l.records)
+}
+
+// find returns the records for requestID, oldest first.
+func (l *DecisionLog) find(requestID string) []models.DecisionRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []models.DecisionRecord
+	for i := range l.records {
+		record := l.records[(l.next+i)%len(l.records)]
+		if record.RequestID == requestID {
+			found = append(found, record)
+		}
+	}
+	return found
+}
+
+// WithDecisionLog records the decision trace of every update check made with
+// a request ID (see WithRequestID) in log.
+func WithDecisionLog(log *DecisionLog) ServiceOption {
+	return func(s *Service) {
+		s.decisions = log
+	}
+}
+
+// recordDecision adds a traced check to the decision log.
+func (s *Service) recordDecision(requestID string, req *models.UpdateCheckRequest, result *models.UpdateCheckResponse, err error, trace *decisionTrace) {
+	outcome := decide(result, err, trace)
+	request := *req
+	request.LicenseToken = "" // Decision records are served to admins; tokens are credentials
+	s.decisions.add(models.DecisionRecord{
+		RequestID:  requestID,
+		Request:    request,
+		Decision:   outcome.Decision,
+		Result:     outcome.Result,
+		Error:      outcome.Error,
+		Trace:      outcome.Trace,
+		RecordedAt: s.now().UTC(),
+	})
+}
+
+// GetDecisionLog returns the update checks recorded for a request ID.
+func (s *Service) GetDecisionLog(ctx context.Context, requestID string) (*models.DecisionLogResponse, error) {
+	if s.decisions == nil {
+		return nil, NewNotFoundError("decision log is not enabled")
+	}
+	records := s.decisions.find(requestID)
+	if len(records) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("no update checks recorded for request %s", requestID))
+	}
+	return &models.DecisionLogResponse{RequestID: requestID, Records: records}, nil
+}
@@ -0,0 +1,62 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionLog_EvictsOldest(t *testing.T) {
+	log := NewDecisionLog(2)
+	log.add(models.DecisionRecord{RequestID: "a"})
+	log.add(models.DecisionRecord{RequestID: "b", Decision: models.CheckDecisionNoUpdate})
+	log.add(models.DecisionRecord{RequestID: "c"})
+	log.add(models.DecisionRecord{RequestID: "b", Decision: models.CheckDecisionUpdate})
+
+	assert.Empty(t, log.find("a"))
+	require.Len(t, log.find("c"), 1)
+	records := log.find("b")
+	require.Len(t, records, 1)
+	assert.Equal(t, models.CheckDecisionUpdate, records[0].Decision)
+	assert.False(t, records[0].RecordedAt.IsZero())
+}
+
+func TestService_DecisionLog(t *testing.T) {
+	mockStorage := NewMockStorage()
+	ctx := context.Background()
+	require.NoError(t, mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test", Platforms: []string{"windows"}}))
+	require.NoError(t, mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("test-app", "1.1.0", "windows", "amd64")))
+	service := NewService(mockStorage, WithDecisionLog(NewDecisionLog(10)))
+
+	check := func(currentVersion string) *models.UpdateCheckRequest {
+		return &models.UpdateCheckRequest{ApplicationID: "test-app", CurrentVersion: currentVersion, Platform: "windows", Architecture: "amd64"}
+	}
+
+	_, err := service.CheckForUpdate(WithRequestID(ctx, "req-1"), check("1.0.0"))
+	require.NoError(t, err)
+	_, err = service.CheckForUpdate(WithRequestID(ctx, "req-1"), &models.UpdateCheckRequest{ApplicationID: "other-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64"})
+	require.Error(t, err)
+	_, err = service.CheckForUpdate(ctx, check("1.1.0"))
+	require.NoError(t, err)
+
+	resp, err := service.GetDecisionLog(ctx, "req-1")
+	require.NoError(t, err)
+	require.Len(t, resp.Records, 2)
+	assert.Equal(t, models.CheckDecisionUpdate, resp.Records[0].Decision)
+	assert.Equal(t, "1.0.0", resp.Records[0].Request.CurrentVersion)
+	assert.NotEmpty(t, resp.Records[0].Trace)
+	assert.Equal(t, models.CheckDecisionRejected, resp.Records[1].Decision)
+	assert.Equal(t, "application other-app not found", resp.Records[1].Trace[0].Detail)
+
+	_, err = service.GetDecisionLog(ctx, "req-2")
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeNotFound, serviceErr.Code)
+
+	_, err = NewService(mockStorage).GetDecisionLog(ctx, "req-1")
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, "decision log is not enabled", serviceErr.Message)
+}
@@ -0,0 +1,63 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"updater/internal/entitlement"
+	"updater/internal/models"
+)
+
+// WithEntitlementProvider resolves the license tokens clients send with update
+// checks. Without a provider, releases that require an entitlement are offered
+// to nobody.
+func WithEntitlementProvider(provider entitlement.Provider) ServiceOption {
+	return func(s *Service) {
+		s.entitlements = provider
+	}
+}
+
+// grants reports whether the client's license grants required, which is
+// trivially true when nothing is required.
+func (c *offerFilter) grants(ctx context.Context, version, required string, trace *decisionTrace) bool {
+	if required == "" {
+		return true
+	}
+	if !c.resolved {
+		c.resolve(ctx)
+	}
+	if slices.Contains(c.granted, required) {
+		trace.add(models.RuleEntitlement, models.DecisionPass, version, "license grants required entitlement %s", required)
+		return true
+	}
+	reason := c.reason
+	if reason == "" {
+		reason = "license does not grant it"
+	}
+	trace.add(models.RuleEntitlement, models.DecisionFail, version, "requires entitlement %s; %s", required, reason)
+	return false
+}
+
+func (c *offerFilter) resolve(ctx context.Context) {
+	c.resolved = true
+	switch {
+	case c.token == "":
+		c.reason = "no license token was sent"
+	case c.provider == nil:
+		c.reason = "no entitlement provider is configured"
+	default:
+		granted, err := c.provider.Entitlements(ctx, c.appID, c.token)
+		switch {
+		case errors.Is(err, entitlement.ErrInvalidToken):
+			c.reason = "license token is invalid"
+		case err != nil:
+			// Fail closed: the client keeps the releases every client is
+			// offered and misses gated ones until the provider recovers.
+			slog.WarnContext(ctx, "Entitlement lookup failed", "application_id", c.appID, "error", err)
+			c.reason = "entitlement lookup failed"
+		default:
+			c.granted = granted
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"updater/internal/entitlement"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubEntitlements grants fixed entitlements per token and counts lookups.
+type stubEntitlements struct {
+	tokens  map[string][]string
+	err     error
+	lookups int
+}
+
+func (p *stubEntitlements) Entitlements(_ context.Context, _, token string) ([]string, error) {
+	p.lookups++
+	if p.err != nil {
+		return nil, p.err
+	}
+	granted, ok := p.tokens[token]
+	if !ok {
+		return nil, entitlement.ErrInvalidToken
+	}
+	return granted, nil
+}
+
+func setupEntitlementTest(t *testing.T, opts ...ServiceOption) (*Service, *MockStorage) {
+	t.Helper()
+	mockStorage := NewMockStorage()
+	ctx := context.Background()
+	require.NoError(t, mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}}))
+
+	free := createTestReleaseForUpdate("test-app", "1.1.0", "windows", "amd64")
+	pro := createTestReleaseForUpdate("test-app", "1.2.0", "windows", "amd64")
+	pro.RequiredEntitlement = "pro"
+	require.NoError(t, mockStorage.SaveRelease(ctx, free))
+	require.NoError(t, mockStorage.SaveRelease(ctx, pro))
+	return NewService(mockStorage, opts...), mockStorage
+}
+
+func TestService_CheckForUpdate_Entitlements(t *testing.T) {
+	provider := &stubEntitlements{tokens: map[string][]string{"pro-token": {"pro"}, "basic-token": {"basic"}}}
+
+	tests := []struct {
+		name        string
+		token       string
+		wantVersion string
+	}{
+		{"entitled client gets the gated release", "pro-token", "1.2.0"},
+		{"client without the entitlement falls back", "basic-token", "1.1.0"},
+		{"invalid token falls back", "bogus", "1.1.0"},
+		{"no token falls back", "", "1.1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := setupEntitlementTest(t, WithEntitlementProvider(provider))
+			resp, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+				ApplicationID:  "test-app",
+				CurrentVersion: "1.0.0",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				LicenseToken:   tt.token,
+			})
+			require.NoError(t, err)
+			assert.True(t, resp.UpdateAvailable)
+			assert.Equal(t, tt.wantVersion, resp.LatestVersion)
+		})
+	}
+}
+
+func TestService_CheckForUpdate_EntitlementsNoAllowedRelease(t *testing.T) {
+	service, _ := setupEntitlementTest(t)
+
+	resp, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+		ApplicationID:  "test-app",
+		CurrentVersion: "1.1.0",
+		Platform:       "windows",
+		Architecture:   "amd64",
+		LicenseToken:   "pro-token",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.UpdateAvailable, "gated releases are offered to nobody without a provider")
+}
+
+func TestService_CheckForUpdate_EntitlementLookupFailure(t *testing.T) {
+	provider := &stubEntitlements{err: errors.New("licensing service unavailable")}
+	service, _ := setupEntitlementTest(t, WithEntitlementProvider(provider))
+
+	resp, err := service.DryRunCheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+		ApplicationID:  "test-app",
+		CurrentVersion: "1.0.0",
+		Platform:       "windows",
+		Architecture:   "amd64",
+		LicenseToken:   "pro-token",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Result)
+	assert.Equal(t, "1.1.0", resp.Result.LatestVersion, "lookup failures fail closed")
+	assert.Equal(t, 1, provider.lookups, "the token is resolved once per check")
+
+	var found bool
+	for _, step := range resp.Trace {
+		if step.Rule == models.RuleEntitlement && step.Result == models.DecisionFail {
+			found = true
+			assert.Contains(t, step.Detail, "entitlement lookup failed")
+		}
+	}
+	assert.True(t, found, "trace records the entitlement decision")
+}
+
+func TestService_CheckForUpdate_UngatedSkipsLookup(t *testing.T) {
+	provider := &stubEntitlements{tokens: map[string][]string{"pro-token": {"pro"}}}
+	service, _ := setupEntitlementTest(t, WithEntitlementProvider(provider))
+
+	_, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+		ApplicationID:  "test-app",
+		CurrentVersion: "1.2.0",
+		Platform:       "windows",
+		Architecture:   "amd64",
+		LicenseToken:   "pro-token",
+	})
+	require.NoError(t, err)
+	assert.Zero(t, provider.lookups)
+}
+
+func TestService_GetLatestVersion_Entitlements(t *testing.T) {
+	provider := &stubEntitlements{tokens: map[string][]string{"pro-token": {"pro"}}}
+	service, _ := setupEntitlementTest(t, WithEntitlementProvider(provider))
+	ctx := context.Background()
+
+	resp, err := service.GetLatestVersion(ctx, &models.LatestVersionRequest{ApplicationID: "test-app", Platform: "windows", Architecture: "amd64", LicenseToken: "pro-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", resp.Version)
+
+	resp, err = service.GetLatestVersion(ctx, &models.LatestVersionRequest{ApplicationID: "test-app", Platform: "windows", Architecture: "amd64"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", resp.Version)
+}
+
+func TestService_RecordDecision_DropsLicenseToken(t *testing.T) {
+	service, _ := setupEntitlementTest(t, WithDecisionLog(NewDecisionLog(10)))
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	_, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID:  "test-app",
+		CurrentVersion: "1.0.0",
+		Platform:       "windows",
+		Architecture:   "amd64",
+		LicenseToken:   "secret-token",
+	})
+	require.NoError(t, err)
+
+	log, err := service.GetDecisionLog(ctx, "req-1")
+	require.NoError(t, err)
+	require.Len(t, log.Records, 1)
+	assert.Empty(t, log.Records[0].Request.LicenseToken)
+}
+
+func TestService_CheckForUpdate_Editions(t *testing.T) {
+	provider := &stubEntitlements{tokens: map[string][]string{"pro-token": {"pro"}}}
+	service, mockStorage := setupEntitlementTest(t, WithEntitlementProvider(provider))
+	release := createTestReleaseForUpdate("test-app", "1.3.0", "windows", "amd64")
+	release.Checksums = map[string]string{"sha512": "def456"}
+	release.Editions = map[string]models.EditionArtifact{
+		"pro":        {DownloadURL: "https://example.com/download-pro", Checksum: "bbb222", ChecksumType: "sha256", RequiredEntitlement: "pro"},
+		"enterprise": {DownloadURL: "https://example.com/download-enterprise", Checksum: "ccc333", ChecksumType: "sha512"},
+	}
+	require.NoError(t, mockStorage.SaveRelease(context.Background(), release))
+
+	tests := []struct {
+		name    string
+		edition string
+		token   string
+		wantURL string
+	}{
+		{"no edition gets the base artifact", "", "pro-token", "https://example.com/download"},
+		{"edition without entitlement", "enterprise", "", "https://example.com/download-enterprise"},
+		{"entitled edition", "pro", "pro-token", "https://example.com/download-pro"},
+		{"edition the license does not grant", "pro", "", "https://example.com/download"},
+		{"edition the release does not ship", "trial", "", "https://example.com/download"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.CheckForUpdate(context.Background(), &models.UpdateCheckRequest{
+				ApplicationID:  "test-app",
+				CurrentVersion: "1.0.0",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				LicenseToken:   tt.token,
+				Edition:        tt.edition,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "1.3.0", resp.LatestVersion)
+			assert.Equal(t, tt.wantURL, resp.DownloadURL)
+			if tt.wantURL != "https://example.com/download" {
+				assert.Nil(t, resp.Checksums, "base checksums do not describe an edition artifact")
+			}
+		})
+	}
+}
+
+func TestService_GetLatestVersion_Edition(t *testing.T) {
+	service, mockStorage := setupEntitlementTest(t)
+	release := createTestReleaseForUpdate("test-app", "1.3.0", "windows", "amd64")
+	release.Editions = map[string]models.EditionArtifact{
+		"enterprise": {DownloadURL: "https://example.com/download-enterprise", Checksum: "ccc333", ChecksumType: "sha256"},
+	}
+	require.NoError(t, mockStorage.SaveRelease(context.Background(), release))
+
+	resp, err := service.GetLatestVersion(context.Background(), &models.LatestVersionRequest{ApplicationID: "test-app", Platform: "windows", Architecture: "amd64", Edition: "Enterprise"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/download-enterprise", resp.DownloadURL)
+	assert.Equal(t, "ccc333", resp.Checksum)
+}
+
+func TestService_RegisterRelease_EditionDownloadURLPolicy(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage, WithDownloadURLPolicy(models.DownloadURLPolicy{AllowedHosts: []string{"*.example.com"}}), WithRejectWeakChecksums(true))
+	ctx := context.Background()
+	require.NoError(t, mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}}))
+
+	register := func(artifact models.EditionArtifact) error {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "test-app",
+			Version:       "1.0.0",
+			Platform:      "windows",
+			Architecture:  "amd64",
+			DownloadURL:   "https://cdn.example.com/app.exe",
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+			Editions:      map[string]models.EditionArtifact{"pro": artifact},
+		})
+		return err
+	}
+
+	require.NoError(t, register(models.EditionArtifact{DownloadURL: "https://cdn.example.com/app-pro.exe", Checksum: "def456", ChecksumType: "sha256"}))
+	saved := mockStorage.releases["test-app"][0]
+	assert.Equal(t, "https://cdn.example.com/app-pro.exe", saved.Editions["pro"].DownloadURL)
+
+	var serviceErr *ServiceError
+	err := register(models.EditionArtifact{DownloadURL: "https://attacker.test/app-pro.exe", Checksum: "def456", ChecksumType: "sha256"})
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+
+	err = register(models.EditionArtifact{DownloadURL: "https://cdn.example.com/app-pro.exe", Checksum: "def456", ChecksumType: "md5"})
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Contains(t, serviceErr.Message, "md5")
+}
+
+func TestService_CheckForUpdate_Targeting(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := NewMockStorage()
+	require.NoError(t, mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}}))
+	broad := createTestReleaseForUpdate("test-app", "1.1.0", "windows", "amd64")
+	targeted := createTestReleaseForUpdate("test-app", "1.2.0", "windows", "amd64")
+	targeted.Targeting = []models.TargetingRule{
+		{Attribute: models.TargetOSVersion, Operator: models.TargetGTE, Values: []string{"10.0.22000"}},
+		{Attribute: models.TargetClientTag, Operator: models.TargetIn, Values: []string{"insider"}},
+	}
+	require.NoError(t, mockStorage.SaveRelease(ctx, broad))
+	require.NoError(t, mockStorage.SaveRelease(ctx, targeted))
+	service := NewService(mockStorage)
+
+	tests := []struct {
+		name        string
+		osVersion   string
+		tags        []string
+		wantVersion string
+	}{
+		{"matching client gets the targeted release", "10.0.22631", []string{"insider"}, "1.2.0"},
+		{"older OS falls back", "10.0.19045", []string{"insider"}, "1.1.0"},
+		{"missing tag falls back", "10.0.22631", nil, "1.1.0"},
+		{"client without attributes falls back", "", nil, "1.1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+				ApplicationID:  "test-app",
+				CurrentVersion: "1.0.0",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				OSVersion:      tt.osVersion,
+				ClientTags:     tt.tags,
+			})
+			require.NoError(t, err)
+			assert.True(t, resp.UpdateAvailable)
+			assert.Equal(t, tt.wantVersion, resp.LatestVersion)
+		})
+	}
+
+	dryRun, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "test-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64", OSVersion: "10.0.19045",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, dryRun.Trace, models.DecisionStep{
+		Rule: models.RuleTargeting, Result: models.DecisionFail, Release: "1.2.0",
+		Detail: "client does not match targeting rule os_version gte 10.0.22000",
+	})
+
+	latest, err := service.GetLatestVersion(ctx, &models.LatestVersionRequest{ApplicationID: "test-app", Platform: "windows", Architecture: "amd64"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", latest.Version, "latest lookups report no attributes")
+}
+
+func TestService_CheckForUpdate_Variants(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := NewMockStorage()
+	require.NoError(t, mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}}))
+	both := createTestReleaseForUpdate("test-app", "1.1.0", "windows", "amd64")
+	both.Variant = models.VariantMSI
+	both.Variants = map[string]models.VariantArtifact{
+		models.VariantPortableZip: {DownloadURL: "https://example.com/app-1.1.0.zip", Checksum: "abc123", ChecksumType: "sha256", FileSize: 42},
+	}
+	installerOnly := createTestReleaseForUpdate("test-app", "1.2.0", "windows", "amd64")
+	installerOnly.Variant = models.VariantMSI
+	require.NoError(t, mockStorage.SaveRelease(ctx, both))
+	require.NoError(t, mockStorage.SaveRelease(ctx, installerOnly))
+	service := NewService(mockStorage)
+
+	tests := []struct {
+		name        string
+		variant     string
+		wantUpdate  bool
+		wantVersion string
+		wantURL     string
+	}{
+		{"installer client gets the newest installer", models.VariantMSI, true, "1.2.0", installerOnly.DownloadURL},
+		{"portable client falls back to the newest portable build", models.VariantPortableZip, true, "1.1.0", "https://example.com/app-1.1.0.zip"},
+		{"client without a variant gets the newest release", "", true, "1.2.0", installerOnly.DownloadURL},
+		{"client of a variant never published gets nothing", models.VariantExeInstaller, false, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+				ApplicationID:  "test-app",
+				CurrentVersion: "1.0.0",
+				Platform:       "windows",
+				Architecture:   "amd64",
+				Variant:        tt.variant,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUpdate, resp.UpdateAvailable)
+			if tt.wantUpdate {
+				assert.Equal(t, tt.wantVersion, resp.LatestVersion)
+				assert.Equal(t, tt.wantURL, resp.DownloadURL)
+			}
+		})
+	}
+
+	dryRun, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "test-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64", Variant: models.VariantPortableZip,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, dryRun.Trace, models.DecisionStep{
+		Rule: models.RuleVariant, Result: models.DecisionFail, Release: "1.2.0",
+		Detail: "not available as portable-zip; available as msi",
+	})
+	assert.Equal(t, models.VariantPortableZip, dryRun.Result.Variant)
+
+	latest, err := service.GetLatestVersion(ctx, &models.LatestVersionRequest{ApplicationID: "test-app", Platform: "windows", Architecture: "amd64", Variant: models.VariantPortableZip})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", latest.Version)
+	assert.Equal(t, "https://example.com/app-1.1.0.zip", latest.DownloadURL)
+}
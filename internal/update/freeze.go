@@ -0,0 +1,56 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"updater/internal/models"
+)
+
+type breakGlassContextKey struct{}
+
+// WithBreakGlass returns a context whose publishing passes the freeze windows
+// that allow break glass. The caller has checked that the request may break
+// glass; reason is recorded when it does.
+func WithBreakGlass(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, breakGlassContextKey{}, reason)
+}
+
+// BreakGlassFromContext returns the reason set by WithBreakGlass, and whether
+// one was set.
+func BreakGlassFromContext(ctx context.Context) (string, bool) {
+	reason, ok := ctx.Value(breakGlassContextKey{}).(string)
+	return reason, ok
+}
+
+// checkFreeze refuses publishing a release of an application to channel while
+// one of the application's freeze windows is active. Only the stable channel
+// is frozen, which covers every client that follows no channel and does not
+// allow pre-releases (see clientChannel).
+func (s *Service) checkFreeze(ctx context.Context, app *models.Application, channel string) error {
+	if channel != models.ChannelStable {
+		return nil
+	}
+	window := models.ActiveFreezeWindow(app.Config.FreezeWindows, s.now())
+	if window == nil {
+		return nil
+	}
+	if reason, ok := BreakGlassFromContext(ctx); ok && window.BreakGlass {
+		slog.WarnContext(ctx, "Publishing to the stable channel during a freeze window",
+			"event", "security_audit",
+			"application_id", app.ID,
+			"freeze_window", window.Name,
+			"break_glass_reason", reason)
+		return nil
+	}
+
+	message := fmt.Sprintf("publishing to the stable channel of %s is frozen by %q until %s", app.ID, window.Name, window.EndsAt.UTC().Format(time.RFC3339))
+	if window.Reason != "" {
+		message += ": " + window.Reason
+	}
+	if window.BreakGlass {
+		message += "; a key with the break_glass permission can publish by giving a reason in the X-Break-Glass header"
+	}
+	return NewConflictError(message)
+}
@@ -0,0 +1,91 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_FreezeWindows(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 11, 27, 12, 0, 0, 0, time.UTC)
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	app := models.NewApplication("frozen-app", "Frozen App", []string{"windows", "linux"})
+	app.Config.FreezeWindows = []models.FreezeWindow{{
+		Name:       "black-friday",
+		StartsAt:   time.Date(2026, 11, 23, 0, 0, 0, 0, time.UTC),
+		EndsAt:     time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+		Reason:     "Peak sales week",
+		BreakGlass: true,
+	}}
+	require.NoError(t, store.SaveApplication(ctx, app))
+	service := NewService(store, WithClock(func() time.Time { return now }))
+
+	register := func(ctx context.Context, version, channel string) error {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "frozen-app",
+			Version:       version,
+			Platform:      "windows",
+			Architecture:  "amd64",
+			DownloadURL:   "https://example.com/app-" + version,
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+			Channel:       channel,
+		})
+		return err
+	}
+
+	err = register(ctx, "1.1.0", "")
+	assertServiceError(t, err, http.StatusConflict)
+	assert.ErrorContains(t, err, `frozen by "black-friday" until 2026-11-30T00:00:00Z: Peak sales week`)
+	assert.ErrorContains(t, err, "X-Break-Glass")
+	assertServiceError(t, register(ctx, "1.1.0", models.ChannelStable), http.StatusConflict)
+
+	assert.NoError(t, register(ctx, "1.2.0-beta.1", ""), "other channels are not frozen")
+	assertServiceError(t, func() error {
+		_, err := service.PublishToChannel(ctx, "frozen-app", "Stable", "1.2.0-beta.1")
+		return err
+	}(), http.StatusConflict)
+	_, err = service.PublishToChannel(ctx, "frozen-app", models.ChannelNightly, "1.2.0-beta.1")
+	assert.NoError(t, err)
+
+	// A release published to another channel during the freeze does not
+	// reach clients that follow no channel
+	require.NoError(t, register(ctx, "1.1.5", models.ChannelBeta))
+	resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "frozen-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.UpdateAvailable)
+
+	_, err = service.IngestReleaseManifest(ctx, &models.ReleaseManifest{
+		ApplicationID: "frozen-app",
+		Version:       "1.1.0",
+		Artifacts: []models.ManifestArtifact{{
+			Platform: "linux", Architecture: "amd64", DownloadURL: "https://example.com/app-1.1.0.tar.gz", Checksum: "abc123", ChecksumType: "sha256",
+		}},
+	})
+	assertServiceError(t, err, http.StatusConflict)
+
+	breakGlass := WithBreakGlass(ctx, "hotfix for checkout outage")
+	assert.NoError(t, register(breakGlass, "1.1.0", ""))
+	_, err = service.PublishToChannel(breakGlass, "frozen-app", models.ChannelStable, "1.2.0-beta.1")
+	assert.NoError(t, err)
+
+	// A hard freeze cannot be broken
+	app.Config.FreezeWindows[0].BreakGlass = false
+	require.NoError(t, store.SaveApplication(ctx, app))
+	err = register(breakGlass, "1.1.1", "")
+	assertServiceError(t, err, http.StatusConflict)
+	assert.NotContains(t, err.Error(), "X-Break-Glass")
+
+	now = time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, register(ctx, "1.1.1", ""), "publishing resumes when the window ends")
+}
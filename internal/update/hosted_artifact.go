@@ -0,0 +1,181 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"updater/internal/blob"
+	"updater/internal/events"
+	"updater/internal/models"
+)
+
+// WithArtifactStore lets releases be registered with hosted_artifact and
+// their artifacts be uploaded to store, up to maxSize bytes each. Without it
+// such registrations and uploads are rejected.
+func WithArtifactStore(store blob.Store, maxSize int64) ServiceOption {
+	return func(s *Service) {
+		s.artifactStore = store
+		s.maxArtifactSize = maxSize
+	}
+}
+
+// artifactKey returns the key a release's hosted artifact is stored under.
+// Releases keep their ID when registered again, so the key and the download
+// URL derived from it are stable.
+func artifactKey(release *models.Release) string {
+	return release.ApplicationID + "/" + release.ID
+}
+
+// hostArtifact points a release registered with hosted_artifact at the
+// download URL its upload will be served from, once it has the ID it is
+// stored with. The release waits for its upload, unless the stored release it
+// replaces already holds an uploaded artifact that matches the registration.
+func (s *Service) hostArtifact(ctx context.Context, release *models.Release) {
+	release.DownloadURL = s.artifactStore.URL(artifactKey(release))
+	release.ArtifactPending = true
+	existing, err := s.storage.GetRelease(ctx, release.ApplicationID, release.Version, release.Platform, release.Architecture)
+	if err == nil && !existing.ArtifactPending && existing.DownloadURL == release.DownloadURL &&
+		(release.Checksum == "" || strings.EqualFold(existing.AllChecksums()[release.ChecksumType], release.Checksum)) {
+		release.Checksum = existing.Checksum
+		release.ChecksumType = existing.ChecksumType
+		release.Checksums = existing.Checksums
+		release.FileSize = existing.FileSize
+		release.ArtifactPending = false
+	}
+}
+
+// UploadReleaseArtifact stores the artifact of a release in the artifact
+// store and points the release at it. The upload must match every checksum
+// and the file size the release was registered with; its sha256 and sha512
+// checksums and size are recorded. A release waiting for its hosted artifact
+// is offered from then on.
+func (s *Service) UploadReleaseArtifact(ctx context.Context, appID, version, platform, arch string, body io.Reader) (*models.UploadArtifactResponse, error) {
+	if s.artifactStore == nil {
+		return nil, NewValidationError("artifact uploads are not enabled on this server", nil)
+	}
+	release, err := s.storage.GetRelease(ctx, appID, version, platform, arch)
+	if err != nil {
+		return nil, NewNotFoundError(fmt.Sprintf("release '%s-%s-%s-%s' not found", appID, version, platform, arch))
+	}
+	return s.uploadArtifact(ctx, release, body)
+}
+
+// UploadReleaseArtifactByID stores the artifact of the release with the given
+// ID, as UploadReleaseArtifact does. The release must belong to appID.
+func (s *Service) UploadReleaseArtifactByID(ctx context.Context, appID, releaseID string, body io.Reader) (*models.UploadArtifactResponse, error) {
+	if s.artifactStore == nil {
+		return nil, NewValidationError("artifact uploads are not enabled on this server", nil)
+	}
+	release, err := s.storage.GetReleaseByID(ctx, releaseID)
+	if err != nil || release.ApplicationID != appID {
+		return nil, NewNotFoundError(fmt.Sprintf("release '%s' of application '%s' not found", releaseID, appID))
+	}
+	return s.uploadArtifact(ctx, release, body)
+}
+
+// uploadArtifact stores body as the artifact of release and saves the release.
+func (s *Service) uploadArtifact(ctx context.Context, release *models.Release, body io.Reader) (*models.UploadArtifactResponse, error) {
+	key := artifactKey(release)
+	if err := blob.ValidateKey(key); err != nil {
+		return nil, NewValidationError(fmt.Sprintf("release '%s' cannot be stored: %v", release.ID, err), err)
+	}
+
+	spooled, err := blob.Spool(body, s.maxArtifactSize)
+	if errors.Is(err, blob.ErrTooLarge) {
+		return nil, NewValidationError(fmt.Sprintf("artifact exceeds the maximum size of %d bytes", s.maxArtifactSize), err)
+	}
+	if err != nil {
+		return nil, NewInvalidRequestError("failed to read artifact", err)
+	}
+	defer spooled.Close()
+	if err := verifyUpload(release, spooled); err != nil {
+		return nil, NewValidationError(err.Error(), err)
+	}
+
+	obj := blob.Object{Body: spooled, Size: spooled.Size, SHA256: spooled.Checksums[models.ChecksumTypeSHA256]}
+	if err := s.artifactStore.Put(ctx, key, obj); err != nil {
+		return nil, NewInternalError("failed to store artifact", err)
+	}
+
+	if release.Checksum == "" {
+		if release.ChecksumType == "" {
+			release.ChecksumType = models.ChecksumTypeSHA256
+		}
+		release.Checksum = spooled.Checksums[release.ChecksumType]
+	}
+	release.Checksums = maps.Clone(release.Checksums)
+	for _, checksumType := range []string{models.ChecksumTypeSHA256, models.ChecksumTypeSHA512} {
+		if checksumType != release.ChecksumType {
+			if release.Checksums == nil {
+				release.Checksums = make(map[string]string)
+			}
+			release.Checksums[checksumType] = spooled.Checksums[checksumType]
+		}
+	}
+	release.FileSize = spooled.Size
+	release.DownloadURL = s.artifactStore.URL(key)
+	release.ArtifactPending = false
+	release.UpdatedAt = s.now().UTC()
+	if err := s.storage.SaveRelease(ctx, release); err != nil {
+		return nil, NewInternalError("failed to save release", err)
+	}
+	// The upload wakes long-poll checks waiting for the release to be offered
+	s.publishRelease(events.ReleaseUpdated, release)
+
+	return &models.UploadArtifactResponse{
+		ID:           release.ID,
+		Message:      fmt.Sprintf("Artifact of release '%s' uploaded successfully", release.ID),
+		DownloadURL:  release.DownloadURL,
+		Checksum:     release.Checksum,
+		ChecksumType: release.ChecksumType,
+		Checksums:    release.Checksums,
+		FileSize:     release.FileSize,
+		UpdatedAt:    release.UpdatedAt,
+	}, nil
+}
+
+// verifyUpload checks an upload against the file size and checksums a release
+// was registered with. A release whose checksums the server cannot compute
+// cannot take an upload, since the upload could not be verified.
+func verifyUpload(release *models.Release, upload *blob.Spooled) error {
+	if release.FileSize != 0 && release.FileSize != upload.Size {
+		return fmt.Errorf("artifact is %d bytes, but the release was registered with file_size %d", upload.Size, release.FileSize)
+	}
+	checksums := release.AllChecksums()
+	verified := false
+	for _, checksumType := range slices.Sorted(maps.Keys(checksums)) {
+		want := checksums[checksumType]
+		got, ok := upload.Checksums[checksumType]
+		if want == "" || !ok {
+			continue
+		}
+		if !strings.EqualFold(got, want) {
+			return fmt.Errorf("artifact does not match the release's %s checksum", checksumType)
+		}
+		verified = true
+	}
+	if release.Checksum != "" && !verified {
+		return fmt.Errorf("%s checksums cannot be verified; register the release with a sha256 or sha512 checksum", release.ChecksumType)
+	}
+	return nil
+}
+
+// deleteHostedArtifact removes the hosted artifact of a deleted release. The
+// release is gone either way, so a failure is only logged.
+func (s *Service) deleteHostedArtifact(ctx context.Context, release *models.Release) {
+	if s.artifactStore == nil {
+		return
+	}
+	key := artifactKey(release)
+	if release.DownloadURL != s.artifactStore.URL(key) {
+		return
+	}
+	if err := s.artifactStore.Delete(ctx, key); err != nil {
+		slog.WarnContext(ctx, "Failed to delete hosted artifact", "release_id", release.ID, "key", key, "error", err)
+	}
+}
@@ -0,0 +1,187 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"updater/internal/blake3"
+	"updater/internal/blob"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHostedArtifactService(t *testing.T, maxSize int64) (*Service, string) {
+	t.Helper()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	app := models.NewApplication("hosted-app", "Hosted App", []string{"windows"})
+	require.NoError(t, store.SaveApplication(context.Background(), app))
+	dir := t.TempDir()
+	artifacts, err := blob.NewLocal(dir, "https://updates.example.com/artifacts")
+	require.NoError(t, err)
+	ids := []string{"01JREL0", "01JREL1", "01JREL2"}
+	newID := func(time.Time) string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}
+	return NewService(store, WithArtifactStore(artifacts, maxSize), WithIDGenerator(newID)), dir
+}
+
+func TestService_UploadReleaseArtifact(t *testing.T) {
+	ctx := context.Background()
+	service, dir := newHostedArtifactService(t, 1<<20)
+	check := func() *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "hosted-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	register := func() *models.RegisterReleaseResponse {
+		resp, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "hosted-app", Version: "1.1.0", Platform: "windows", Architecture: "amd64", HostedArtifact: true,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	registered := register()
+	assert.Equal(t, "/api/v1/updates/hosted-app/releases/01JREL0/artifact", registered.UploadPath)
+	assert.Equal(t, "https://updates.example.com/artifacts/hosted-app/01JREL0", registered.DownloadURL)
+	assert.False(t, check().UpdateAvailable, "the release is held until its artifact is uploaded")
+
+	dryRun, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "hosted-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, dryRun.Trace, models.DecisionStep{
+		Rule: models.RuleArtifact, Result: models.DecisionFail, Release: "1.1.0", Detail: "waiting for its artifact to be uploaded",
+	})
+
+	uploaded, err := service.UploadReleaseArtifact(ctx, "hosted-app", "1.1.0", "windows", "amd64", strings.NewReader("binary"))
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte("binary"))
+	assert.Equal(t, "01JREL0", uploaded.ID)
+	assert.Equal(t, hex.EncodeToString(sum[:]), uploaded.Checksum)
+	assert.Equal(t, models.ChecksumTypeSHA256, uploaded.ChecksumType)
+	assert.Contains(t, uploaded.Checksums, models.ChecksumTypeSHA512)
+	assert.Equal(t, int64(6), uploaded.FileSize)
+	data, err := os.ReadFile(filepath.Join(dir, "hosted-app", "01JREL0"))
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(data))
+
+	resp := check()
+	assert.True(t, resp.UpdateAvailable)
+	assert.Equal(t, registered.DownloadURL, resp.DownloadURL)
+	assert.Equal(t, uploaded.Checksum, resp.Checksum)
+
+	// Registering the release again keeps the uploaded artifact
+	assert.Equal(t, registered.DownloadURL, register().DownloadURL)
+	assert.True(t, check().UpdateAvailable)
+
+	_, err = service.DeleteRelease(ctx, "hosted-app", "1.1.0", "windows", "amd64")
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "hosted-app", "01JREL0"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestService_UploadReleaseArtifact_Verifies(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newHostedArtifactService(t, 8)
+	sum := sha256.Sum256([]byte("binary"))
+	_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "hosted-app", Version: "1.1.0", Platform: "windows", Architecture: "amd64", HostedArtifact: true,
+		Checksum: hex.EncodeToString(sum[:]), ChecksumType: "sha256",
+	})
+	require.NoError(t, err)
+
+	_, err = service.UploadReleaseArtifact(ctx, "hosted-app", "1.1.0", "windows", "amd64", strings.NewReader("tampered"))
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, serviceErr.StatusCode)
+	assert.Contains(t, serviceErr.Message, "does not match the release's sha256 checksum")
+
+	_, err = service.UploadReleaseArtifact(ctx, "hosted-app", "1.1.0", "windows", "amd64", strings.NewReader("too large!"))
+	assert.ErrorIs(t, err, blob.ErrTooLarge)
+
+	_, err = service.UploadReleaseArtifact(ctx, "hosted-app", "2.0.0", "windows", "amd64", strings.NewReader("binary"))
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusNotFound, serviceErr.StatusCode)
+	assert.Contains(t, serviceErr.Message, "not found")
+
+	uploaded, err := service.UploadReleaseArtifact(ctx, "hosted-app", "1.1.0", "windows", "amd64", strings.NewReader("binary"))
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), uploaded.Checksum)
+}
+
+func TestService_UploadReleaseArtifactByID(t *testing.T) {
+	ctx := context.Background()
+	service, dir := newHostedArtifactService(t, 1<<20)
+	registered, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "hosted-app", Version: "1.1.0", Platform: "windows", Architecture: "amd64", HostedArtifact: true,
+	})
+	require.NoError(t, err)
+
+	uploaded, err := service.UploadReleaseArtifactByID(ctx, "hosted-app", registered.ID, strings.NewReader("binary"))
+	require.NoError(t, err)
+	assert.Equal(t, registered.ID, uploaded.ID)
+	assert.Equal(t, int64(6), uploaded.FileSize)
+	data, err := os.ReadFile(filepath.Join(dir, "hosted-app", registered.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(data))
+
+	// The ID must name a release of the application in the path
+	require.NoError(t, service.storage.SaveApplication(ctx, models.NewApplication("other-app", "Other App", []string{"windows"})))
+	_, err = service.UploadReleaseArtifactByID(ctx, "other-app", registered.ID, strings.NewReader("binary"))
+	assertServiceError(t, err, http.StatusNotFound)
+	_, err = service.UploadReleaseArtifactByID(ctx, "hosted-app", "missing", strings.NewReader("binary"))
+	assertServiceError(t, err, http.StatusNotFound)
+}
+
+func TestService_UploadReleaseArtifact_BLAKE3(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newHostedArtifactService(t, 1<<20)
+	_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "hosted-app", Version: "1.1.0", Platform: "windows", Architecture: "amd64", HostedArtifact: true,
+		ChecksumType: "blake3",
+	})
+	require.NoError(t, err)
+
+	uploaded, err := service.UploadReleaseArtifact(ctx, "hosted-app", "1.1.0", "windows", "amd64", strings.NewReader("binary"))
+	require.NoError(t, err)
+	sum := blake3.Sum256([]byte("binary"))
+	assert.Equal(t, models.ChecksumTypeBLAKE3, uploaded.ChecksumType)
+	assert.Equal(t, hex.EncodeToString(sum[:]), uploaded.Checksum)
+	assert.Contains(t, uploaded.Checksums, models.ChecksumTypeSHA256)
+}
+
+func TestService_HostedArtifactNotEnabled(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("hosted-app", "Hosted App", []string{"windows"})))
+	service := NewService(store)
+
+	_, err = service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "hosted-app", Version: "1.1.0", Platform: "windows", Architecture: "amd64", HostedArtifact: true,
+	})
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, serviceErr.StatusCode)
+	assert.Contains(t, serviceErr.Message, "hosted_artifact is not enabled on this server")
+
+	_, err = service.UploadReleaseArtifact(ctx, "hosted-app", "1.1.0", "windows", "amd64", strings.NewReader("binary"))
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, serviceErr.StatusCode)
+	assert.Contains(t, serviceErr.Message, "artifact uploads are not enabled on this server")
+}
@@ -2,41 +2,187 @@ package update
 
 import (
 	"context"
+	"io"
+	"time"
 	"updater/internal/models"
 )
 
-// ServiceInterface defines the interface for update service operations
+// ServiceInterface defines the interface for update service operations: the
+// release and check operations plus application management.
 type ServiceInterface interface {
+	ReleaseService
+	ApplicationService
+}
+
+// ReleaseService covers update checks and the releases and container images
+// they are answered from.
+type ReleaseService interface {
 	// CheckForUpdate determines if an update is available for the given request
 	CheckForUpdate(ctx context.Context, req *models.UpdateCheckRequest) (*models.UpdateCheckResponse, error)
 
+	// WaitForUpdate is a long-polling CheckForUpdate that returns as soon as a matching release is published
+	WaitForUpdate(ctx context.Context, req *models.UpdateCheckRequest, wait time.Duration) (*models.UpdateCheckResponse, error)
+
+	// DryRunCheckForUpdate runs an update check for a hypothetical client and explains the decision
+	DryRunCheckForUpdate(ctx context.Context, req *models.UpdateCheckRequest) (*models.DryRunCheckResponse, error)
+
+	// GetDecisionLog returns the decision traces recorded for a request ID
+	GetDecisionLog(ctx context.Context, requestID string) (*models.DecisionLogResponse, error)
+
+	// BatchCheckForUpdates runs several update checks and reports per-check results
+	BatchCheckForUpdates(ctx context.Context, req *models.BatchUpdateCheckRequest) (*models.BatchUpdateCheckResponse, error)
+
 	// GetLatestVersion returns the latest version information for the given request
 	GetLatestVersion(ctx context.Context, req *models.LatestVersionRequest) (*models.LatestVersionResponse, error)
 
+	// GetTauriManifest returns the Tauri updater manifest of an application's newest release
+	GetTauriManifest(ctx context.Context, appID, channel, licenseToken string) (*models.TauriUpdateManifest, error)
+
+	// GetAndroidIndex returns the newest APK of each architecture of an Android application
+	GetAndroidIndex(ctx context.Context, appID, channel, licenseToken string) (*models.AndroidIndex, error)
+
+	// ListPluginUpdates returns the newest host-compatible release of every plugin of a host application
+	ListPluginUpdates(ctx context.Context, req *models.PluginUpdatesRequest) (*models.PluginUpdatesResponse, error)
+
+	// GetLatestStableVersion returns the highest version offered to clients without a channel across all platforms of an application
+	GetLatestStableVersion(ctx context.Context, appID string) (string, error)
+
 	// ListReleases returns a paginated list of releases for the given request
 	ListReleases(ctx context.Context, req *models.ListReleasesRequest) (*models.ListReleasesResponse, error)
 
+	// CompareReleases reports the differences between the releases of two versions per platform and architecture
+	CompareReleases(ctx context.Context, req *models.CompareReleasesRequest) (*models.CompareReleasesResponse, error)
+
 	// RegisterRelease creates a new release from the given request
 	RegisterRelease(ctx context.Context, req *models.RegisterReleaseRequest) (*models.RegisterReleaseResponse, error)
 
+	// IngestReleaseManifest atomically registers every artifact of a CI release manifest
+	IngestReleaseManifest(ctx context.Context, manifest *models.ReleaseManifest) (*models.IngestManifestResponse, error)
+
+	// CheckContainerImage reports whether a newer or re-pushed container image is available
+	CheckContainerImage(ctx context.Context, req *models.ContainerImageCheckRequest) (*models.ContainerImageCheckResponse, error)
+
+	// RegisterContainerImage stores or replaces a container image tag
+	RegisterContainerImage(ctx context.Context, req *models.RegisterContainerImageRequest) (*models.ContainerImage, error)
+
+	// ListContainerImages returns an application's container image tags, highest version first
+	ListContainerImages(ctx context.Context, appID string) (*models.ListContainerImagesResponse, error)
+
+	// DeleteContainerImage removes a container image tag
+	DeleteContainerImage(ctx context.Context, appID, tag string) (*models.DeleteContainerImageResponse, error)
+
+	// CheckOTAUpdate runs a compact update check for an embedded device
+	CheckOTAUpdate(ctx context.Context, req *models.OTACheckRequest) (*models.OTACheckResponse, error)
+
+	// VerifyChecksum compares a client's checksum of a download with the release
+	VerifyChecksum(ctx context.Context, req *models.VerifyChecksumRequest) (*models.VerifyChecksumResponse, error)
+
+	// GetReleaseSignature returns the detached OpenPGP signature of a release
+	GetReleaseSignature(ctx context.Context, appID, version, platform, arch string) (string, error)
+
+	// GetReleaseNotesDraft returns the drafted notes of a release for review
+	GetReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) (*models.ReleaseNotesDraftResponse, error)
+
+	// PublishReleaseNotesDraft replaces a release's notes with its draft
+	PublishReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) (*models.ReleaseNotesDraftResponse, error)
+
+	// DiscardReleaseNotesDraft removes a release's draft without publishing it
+	DiscardReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) error
+
+	// PublishToChannel moves every release of a version to a channel
+	PublishToChannel(ctx context.Context, appID, channel, version string) (*models.PublishToChannelResponse, error)
+
+	// ReportStatusCheck records the result of a required status check for every release of a version
+	ReportStatusCheck(ctx context.Context, appID, version string, req *models.ReportStatusCheckRequest) (*models.ReleaseStatusResponse, error)
+
+	// GetReleaseStatus combines the status checks of every release of a version
+	GetReleaseStatus(ctx context.Context, appID, version string) (*models.ReleaseStatusResponse, error)
+
+	// PauseRelease halts the rollout of a release without deleting it
+	PauseRelease(ctx context.Context, appID, version, platform, arch string) (*models.PauseReleaseResponse, error)
+
+	// ResumeRelease continues the rollout of a paused release
+	ResumeRelease(ctx context.Context, appID, version, platform, arch string) (*models.PauseReleaseResponse, error)
+
+	// YankRelease withdraws a release, offering clients on it the release before it
+	YankRelease(ctx context.Context, appID, version, platform, arch string, req *models.YankReleaseRequest) (*models.YankReleaseResponse, error)
+
+	// UnyankRelease withdraws the yank of a release, offering it again
+	UnyankRelease(ctx context.Context, appID, version, platform, arch string) (*models.YankReleaseResponse, error)
+
+	// UploadReleaseArtifact stores a release's artifact in the artifact store and points the release at it
+	UploadReleaseArtifact(ctx context.Context, appID, version, platform, arch string, body io.Reader) (*models.UploadArtifactResponse, error)
+
+	// UploadReleaseArtifactByID stores the artifact of the release with the given ID
+	UploadReleaseArtifactByID(ctx context.Context, appID, releaseID string, body io.Reader) (*models.UploadArtifactResponse, error)
+
+	// DeleteRelease removes a specific release
+	DeleteRelease(ctx context.Context, appID, version, platform, arch string) (*models.DeleteReleaseResponse, error)
+}
+
+// ApplicationService manages applications and their desired state.
+type ApplicationService interface {
 	// CreateApplication creates a new application
 	CreateApplication(ctx context.Context, req *models.CreateApplicationRequest) (*models.CreateApplicationResponse, error)
 
+	// ListApplicationTemplates returns the configured application templates
+	ListApplicationTemplates(ctx context.Context) (*models.ListApplicationTemplatesResponse, error)
+
+	// CloneApplication copies an application, and optionally its recent releases, to a new ID
+	CloneApplication(ctx context.Context, sourceID string, req *models.CloneApplicationRequest) (*models.CloneApplicationResponse, error)
+
 	// GetApplication retrieves an application by ID with computed statistics
 	GetApplication(ctx context.Context, appID string) (*models.ApplicationInfoResponse, error)
 
+	// ListChannels summarizes the channels an application's releases are published to
+	ListChannels(ctx context.Context, appID string) (*models.ChannelListResponse, error)
+
+	// GetApplicationUsage totals the releases, artifacts and container images an application stores
+	GetApplicationUsage(ctx context.Context, appID string) (*models.ApplicationUsageResponse, error)
+
+	// GetIntegrationSnippets renders ready-to-paste client integration snippets for an application
+	GetIntegrationSnippets(ctx context.Context, req *models.IntegrationSnippetsRequest) (*models.IntegrationSnippetsResponse, error)
+
 	// ListApplications returns a paginated list of applications
 	ListApplications(ctx context.Context, req *models.ListApplicationsRequest) (*models.ListApplicationsResponse, error)
 
+	// ListApplicationGroups returns application groups with their member counts
+	ListApplicationGroups(ctx context.Context) (*models.ListApplicationGroupsResponse, error)
+
 	// UpdateApplication applies partial updates to an existing application
 	UpdateApplication(ctx context.Context, appID string, req *models.UpdateApplicationRequest) (*models.UpdateApplicationResponse, error)
 
-	// DeleteApplication removes an application that has no existing releases
+	// ApplyApplicationDesiredState creates or replaces an application to match a desired state document
+	ApplyApplicationDesiredState(ctx context.Context, appID string, state *models.ApplicationDesiredState) (*models.ApplyDesiredStateResponse, error)
+
+	// ApplyReleaseDesiredState registers a release unless an identical one is already stored
+	ApplyReleaseDesiredState(ctx context.Context, req *models.RegisterReleaseRequest) (*models.ApplyDesiredStateResponse, error)
+
+	// DeleteApplication removes an application that has no existing releases or plugins
 	DeleteApplication(ctx context.Context, appID string) error
+}
 
-	// DeleteRelease removes a specific release
-	DeleteRelease(ctx context.Context, appID, version, platform, arch string) (*models.DeleteReleaseResponse, error)
+// KeyService manages API keys. Authentication looks keys up in storage by
+// hash and does not go through it.
+type KeyService interface {
+	// ListAPIKeys returns all API keys, enabled or not
+	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+
+	// GetAPIKey returns the API key with the given ID
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+
+	// CreateAPIKey generates and stores a new key, returning it with its raw value, which is not kept
+	CreateAPIKey(ctx context.Context, req *models.CreateAPIKeyRequest) (*models.APIKey, string, error)
+
+	// UpdateAPIKey applies partial updates to an existing key
+	UpdateAPIKey(ctx context.Context, id string, req *models.UpdateAPIKeyRequest) (*models.APIKey, error)
+
+	// DeleteAPIKey permanently revokes a key
+	DeleteAPIKey(ctx context.Context, id string) error
 }
 
-// Ensure Service implements ServiceInterface
-var _ ServiceInterface = (*Service)(nil)
+// Ensure Service implements ServiceInterface and KeyManager implements KeyService
+var (
+	_ ServiceInterface = (*Service)(nil)
+	_ KeyService       = (*KeyManager)(nil)
+)
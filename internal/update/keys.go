@@ -0,0 +1,124 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"time"
+	"updater/internal/events"
+	"updater/internal/models"
+	"updater/internal/storage"
+)
+
+// KeyManager implements KeyService on top of storage. Audit logging of key
+// changes is left to the caller, which knows who made them.
+type KeyManager struct {
+	storage storage.Storage
+	events  *events.Bus
+	now     func() time.Time
+}
+
+// KeyManagerOption configures optional KeyManager behavior.
+type KeyManagerOption func(*KeyManager)
+
+// WithKeyEventBus sets the bus key changes are published to. The events carry
+// the key ID only, never the key or its hash.
+func WithKeyEventBus(bus *events.Bus) KeyManagerOption {
+	return func(m *KeyManager) { m.events = bus }
+}
+
+// NewKeyManager creates a key manager backed by store.
+func NewKeyManager(store storage.Storage, opts ...KeyManagerOption) *KeyManager {
+	m := &KeyManager{storage: store, now: time.Now}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// publish publishes a change to the key id; without a bus it does nothing.
+func (m *KeyManager) publish(t events.Type, id string) {
+	m.events.Publish(events.Event{Type: t, KeyID: id, Time: m.now()})
+}
+
+// ListAPIKeys returns all API keys, enabled or not.
+func (m *KeyManager) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	keys, err := m.storage.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, NewInternalError("failed to list keys", err)
+	}
+	return keys, nil
+}
+
+// GetAPIKey returns a copy of the API key with the given ID. Storage has no
+// lookup by ID, so the key is found by scanning the list.
+func (m *KeyManager) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	keys, err := m.storage.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, NewInternalError("failed to fetch keys", err)
+	}
+	for _, k := range keys {
+		if k.ID == id {
+			c := *k
+			return &c, nil
+		}
+	}
+	return nil, NewNotFoundError("key not found")
+}
+
+// CreateAPIKey generates and stores a new key. The raw key is returned once
+// and only its hash is stored.
+func (m *KeyManager) CreateAPIKey(ctx context.Context, req *models.CreateAPIKeyRequest) (*models.APIKey, string, error) {
+	if req.Name == "" {
+		return nil, "", NewInvalidRequestError("name is required", nil)
+	}
+	if len(req.Permissions) == 0 {
+		return nil, "", NewInvalidRequestError("permissions is required", nil)
+	}
+
+	rawKey, err := models.GenerateAPIKey()
+	if err != nil {
+		return nil, "", NewInternalError("failed to generate key", err)
+	}
+	key := models.NewAPIKey(models.NewKeyID(), req.Name, rawKey, req.Permissions)
+	if err := m.storage.CreateAPIKey(ctx, key); err != nil {
+		return nil, "", NewInternalError("failed to create key", err)
+	}
+	m.publish(events.KeyCreated, key.ID)
+	return key, rawKey, nil
+}
+
+// UpdateAPIKey applies the fields set in req to an existing key.
+func (m *KeyManager) UpdateAPIKey(ctx context.Context, id string, req *models.UpdateAPIKeyRequest) (*models.APIKey, error) {
+	key, err := m.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		key.Name = *req.Name
+	}
+	if req.Permissions != nil {
+		key.Permissions = req.Permissions
+	}
+	if req.Enabled != nil {
+		key.Enabled = *req.Enabled
+	}
+	key.UpdatedAt = m.now().UTC()
+
+	if err := m.storage.UpdateAPIKey(ctx, key); err != nil {
+		return nil, NewInternalError("failed to update key", err)
+	}
+	m.publish(events.KeyUpdated, key.ID)
+	return key, nil
+}
+
+// DeleteAPIKey permanently removes a key.
+func (m *KeyManager) DeleteAPIKey(ctx context.Context, id string) error {
+	if err := m.storage.DeleteAPIKey(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return NewNotFoundError("key not found")
+		}
+		return NewInternalError("failed to delete key", err)
+	}
+	m.publish(events.KeyDeleted, id)
+	return nil
+}
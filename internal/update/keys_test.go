@@ -0,0 +1,82 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"updater/internal/events"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyManager(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	m := NewKeyManager(store)
+	ctx := context.Background()
+
+	_, _, err = m.CreateAPIKey(ctx, &models.CreateAPIKeyRequest{Permissions: []string{"read"}})
+	assertServiceError(t, err, http.StatusBadRequest)
+	_, _, err = m.CreateAPIKey(ctx, &models.CreateAPIKeyRequest{Name: "CI"})
+	assertServiceError(t, err, http.StatusBadRequest)
+
+	key, rawKey, err := m.CreateAPIKey(ctx, &models.CreateAPIKeyRequest{Name: "CI", Permissions: []string{"write"}})
+	require.NoError(t, err)
+	assert.Equal(t, models.HashAPIKey(rawKey), key.KeyHash)
+	assert.True(t, key.Enabled)
+
+	got, err := m.GetAPIKey(ctx, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "CI", got.Name)
+	_, err = m.GetAPIKey(ctx, "missing")
+	assertServiceError(t, err, http.StatusNotFound)
+
+	disabled := false
+	updated, err := m.UpdateAPIKey(ctx, key.ID, &models.UpdateAPIKeyRequest{Enabled: &disabled})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Equal(t, "CI", updated.Name, "unset fields are kept")
+	_, err = m.UpdateAPIKey(ctx, "missing", &models.UpdateAPIKeyRequest{})
+	assertServiceError(t, err, http.StatusNotFound)
+
+	require.NoError(t, m.DeleteAPIKey(ctx, key.ID))
+	assertServiceError(t, m.DeleteAPIKey(ctx, key.ID), http.StatusNotFound)
+	keys, err := m.ListAPIKeys(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func assertServiceError(t *testing.T, err error, status int) {
+	t.Helper()
+	var serviceErr *ServiceError
+	if assert.ErrorAs(t, err, &serviceErr) {
+		assert.Equal(t, status, serviceErr.StatusCode)
+	}
+}
+
+func TestKeyManager_PublishesEvents(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	bus := events.NewBus()
+	var got []events.Event
+	bus.Subscribe(func(e events.Event) { got = append(got, e) })
+	m := NewKeyManager(store, WithKeyEventBus(bus))
+	ctx := context.Background()
+
+	key, _, err := m.CreateAPIKey(ctx, &models.CreateAPIKeyRequest{Name: "CI", Permissions: []string{"write"}})
+	require.NoError(t, err)
+	name := "Deploy"
+	_, err = m.UpdateAPIKey(ctx, key.ID, &models.UpdateAPIKeyRequest{Name: &name})
+	require.NoError(t, err)
+	require.NoError(t, m.DeleteAPIKey(ctx, key.ID))
+	assert.Error(t, m.DeleteAPIKey(ctx, key.ID))
+
+	require.Len(t, got, 3)
+	for i, want := range []events.Type{events.KeyCreated, events.KeyUpdated, events.KeyDeleted} {
+		assert.Equal(t, want, got[i].Type)
+		assert.Equal(t, key.ID, got[i].KeyID)
+	}
+}
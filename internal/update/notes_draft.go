@@ -0,0 +1,100 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"updater/internal/events"
+	"updater/internal/models"
+)
+
+// NotesDrafter drafts release notes from the commits sent with a
+// registration.
+type NotesDrafter interface {
+	Draft(ctx context.Context, req *models.ReleaseNotesDraftRequest) (string, error)
+}
+
+// WithNotesDrafter has registrations that send commits ask d for a draft of
+// their release notes. Without it, commits are ignored.
+func WithNotesDrafter(d NotesDrafter) ServiceOption {
+	return func(s *Service) {
+		s.notesDrafter = d
+	}
+}
+
+// draftNotes asks the drafter for notes for a registration that sent
+// commits. A failure is logged and leaves the release without a draft, so
+// the drafting service being down never blocks a release.
+func (s *Service) draftNotes(ctx context.Context, req *models.RegisterReleaseRequest) string {
+	if s.notesDrafter == nil || len(req.Commits) == 0 {
+		return ""
+	}
+	draft, err := s.notesDrafter.Draft(ctx, &models.ReleaseNotesDraftRequest{
+		ApplicationID: req.ApplicationID,
+		Version:       req.Version,
+		Platform:      req.Platform,
+		Architecture:  req.Architecture,
+		ReleaseNotes:  req.ReleaseNotes,
+		Commits:       req.Commits,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Release notes drafting failed",
+			"application_id", req.ApplicationID,
+			"version", req.Version,
+			"error", err)
+		return ""
+	}
+	return draft
+}
+
+// GetReleaseNotesDraft returns the drafted notes of a release for review.
+func (s *Service) GetReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) (*models.ReleaseNotesDraftResponse, error) {
+	release, err := s.releaseWithDraft(ctx, appID, version, platform, arch)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReleaseNotesDraftResponse{ReleaseID: release.ID, Draft: release.ReleaseNotesDraft, ReleaseNotes: release.ReleaseNotes}, nil
+}
+
+// PublishReleaseNotesDraft replaces a release's notes with its draft.
+func (s *Service) PublishReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) (*models.ReleaseNotesDraftResponse, error) {
+	release, err := s.releaseWithDraft(ctx, appID, version, platform, arch)
+	if err != nil {
+		return nil, err
+	}
+	release.ReleaseNotes = release.ReleaseNotesDraft
+	release.ReleaseNotesDraft = ""
+	release.UpdatedAt = s.now().UTC()
+	if err := s.storage.SaveRelease(ctx, release); err != nil {
+		return nil, NewInternalError("failed to save release", err)
+	}
+	s.publishRelease(events.ReleaseUpdated, release)
+	return &models.ReleaseNotesDraftResponse{ReleaseID: release.ID, ReleaseNotes: release.ReleaseNotes}, nil
+}
+
+// DiscardReleaseNotesDraft removes a release's draft, leaving its notes as
+// they are.
+func (s *Service) DiscardReleaseNotesDraft(ctx context.Context, appID, version, platform, arch string) error {
+	release, err := s.releaseWithDraft(ctx, appID, version, platform, arch)
+	if err != nil {
+		return err
+	}
+	release.ReleaseNotesDraft = ""
+	release.UpdatedAt = s.now().UTC()
+	if err := s.storage.SaveRelease(ctx, release); err != nil {
+		return NewInternalError("failed to save release", err)
+	}
+	s.publishRelease(events.ReleaseUpdated, release)
+	return nil
+}
+
+func (s *Service) releaseWithDraft(ctx context.Context, appID, version, platform, arch string) (*models.Release, error) {
+	release, err := s.storage.GetRelease(ctx, appID, version, platform, arch)
+	if err != nil {
+		return nil, NewNotFoundError(fmt.Sprintf("release '%s-%s-%s-%s' not found", appID, version, platform, arch))
+	}
+	if release.ReleaseNotesDraft == "" {
+		return nil, NewNotFoundError(fmt.Sprintf("release '%s' has no notes draft", release.ID))
+	}
+	return release, nil
+}
@@ -0,0 +1,136 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNotesDrafter returns a fixed draft and records the last request.
+type fakeNotesDrafter struct {
+	draft string
+	err   error
+	req   *models.ReleaseNotesDraftRequest
+}
+
+func (f *fakeNotesDrafter) Draft(_ context.Context, req *models.ReleaseNotesDraftRequest) (string, error) {
+	f.req = req
+	return f.draft, f.err
+}
+
+func TestService_ReleaseNotesDraft(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T, drafter NotesDrafter) *Service {
+		store, err := storage.NewMemoryStorage()
+		require.NoError(t, err)
+		require.NoError(t, store.SaveApplication(ctx, models.NewApplication("notes-app", "Notes App", []string{"windows"})))
+		var opts []ServiceOption
+		if drafter != nil {
+			opts = append(opts, WithNotesDrafter(drafter))
+		}
+		return NewService(store, opts...)
+	}
+	register := func(t *testing.T, service *Service, commits []models.CommitInfo) *models.Release {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "notes-app",
+			Version:       "1.0.0",
+			Platform:      "windows",
+			Architecture:  "amd64",
+			DownloadURL:   "https://example.com/app.exe",
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+			ReleaseNotes:  "Bug fixes",
+			Commits:       commits,
+		})
+		require.NoError(t, err)
+		release, err := service.storage.GetRelease(ctx, "notes-app", "1.0.0", "windows", "amd64")
+		require.NoError(t, err)
+		return release
+	}
+	commits := []models.CommitInfo{{SHA: "a1b2c3d", Message: "Fix crash on startup"}}
+
+	t.Run("stores the draft for review", func(t *testing.T) {
+		drafter := &fakeNotesDrafter{draft: "- Fixed a crash on startup"}
+		service := setup(t, drafter)
+
+		release := register(t, service, commits)
+		assert.Equal(t, "Bug fixes", release.ReleaseNotes, "the draft is not published")
+		assert.Equal(t, "- Fixed a crash on startup", release.ReleaseNotesDraft)
+		require.NotNil(t, drafter.req)
+		assert.Equal(t, commits, drafter.req.Commits)
+		assert.Equal(t, "Bug fixes", drafter.req.ReleaseNotes)
+
+		draft, err := service.GetReleaseNotesDraft(ctx, "notes-app", "1.0.0", "windows", "amd64")
+		require.NoError(t, err)
+		assert.Equal(t, release.ID, draft.ReleaseID)
+		assert.Equal(t, "- Fixed a crash on startup", draft.Draft)
+		assert.Equal(t, "Bug fixes", draft.ReleaseNotes)
+	})
+
+	t.Run("publish replaces the notes", func(t *testing.T) {
+		service := setup(t, &fakeNotesDrafter{draft: "- Fixed a crash on startup"})
+		register(t, service, commits)
+
+		published, err := service.PublishReleaseNotesDraft(ctx, "notes-app", "1.0.0", "windows", "amd64")
+		require.NoError(t, err)
+		assert.Equal(t, "- Fixed a crash on startup", published.ReleaseNotes)
+		assert.Empty(t, published.Draft)
+
+		release, err := service.storage.GetRelease(ctx, "notes-app", "1.0.0", "windows", "amd64")
+		require.NoError(t, err)
+		assert.Equal(t, "- Fixed a crash on startup", release.ReleaseNotes)
+		assert.Empty(t, release.ReleaseNotesDraft)
+
+		_, err = service.PublishReleaseNotesDraft(ctx, "notes-app", "1.0.0", "windows", "amd64")
+		assertServiceError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("discard keeps the notes", func(t *testing.T) {
+		service := setup(t, &fakeNotesDrafter{draft: "- Fixed a crash on startup"})
+		register(t, service, commits)
+
+		require.NoError(t, service.DiscardReleaseNotesDraft(ctx, "notes-app", "1.0.0", "windows", "amd64"))
+		release, err := service.storage.GetRelease(ctx, "notes-app", "1.0.0", "windows", "amd64")
+		require.NoError(t, err)
+		assert.Equal(t, "Bug fixes", release.ReleaseNotes)
+		assert.Empty(t, release.ReleaseNotesDraft)
+	})
+
+	t.Run("drafting failure does not block the release", func(t *testing.T) {
+		service := setup(t, &fakeNotesDrafter{err: errors.New("connection refused")})
+
+		release := register(t, service, commits)
+		assert.Empty(t, release.ReleaseNotesDraft)
+		_, err := service.GetReleaseNotesDraft(ctx, "notes-app", "1.0.0", "windows", "amd64")
+		assertServiceError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("drafter is not called without commits", func(t *testing.T) {
+		drafter := &fakeNotesDrafter{draft: "unused"}
+		service := setup(t, drafter)
+
+		release := register(t, service, nil)
+		assert.Empty(t, release.ReleaseNotesDraft)
+		assert.Nil(t, drafter.req)
+	})
+
+	t.Run("commits are ignored without a drafter", func(t *testing.T) {
+		service := setup(t, nil)
+
+		release := register(t, service, commits)
+		assert.Empty(t, release.ReleaseNotesDraft)
+	})
+
+	t.Run("missing release", func(t *testing.T) {
+		service := setup(t, nil)
+
+		_, err := service.GetReleaseNotesDraft(ctx, "notes-app", "9.9.9", "windows", "amd64")
+		assertServiceError(t, err, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,60 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CheckForUpdate_Notice(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	expires := now.Add(time.Hour)
+	app := models.NewApplication("incident-app", "Incident App", []string{"windows"})
+	app.Config.Notice = &models.Notice{
+		Severity:  models.NoticeWarning,
+		Message:   "Don't update yet: 1.1.0 breaks sign-in on some machines",
+		URL:       "https://status.example.com/incidents/42",
+		ExpiresAt: &expires,
+	}
+	require.NoError(t, store.SaveApplication(ctx, app))
+	service := NewService(store, WithClock(func() time.Time { return now }))
+
+	_, err = service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "incident-app",
+		Version:       "1.1.0",
+		Platform:      "windows",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/app-1.1.0.exe",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+	})
+	require.NoError(t, err)
+	check := func(current string) *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "incident-app", CurrentVersion: current, Platform: "windows", Architecture: "amd64",
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := check("1.0.0")
+	assert.True(t, resp.UpdateAvailable, "a notice does not hold the release back")
+	require.NotNil(t, resp.Notice)
+	assert.Equal(t, models.NoticeWarning, resp.Notice.Severity)
+	assert.Equal(t, "https://status.example.com/incidents/42", resp.Notice.URL)
+
+	resp = check("1.1.0")
+	assert.False(t, resp.UpdateAvailable)
+	assert.NotNil(t, resp.Notice, "clients without an update see the notice too")
+
+	now = expires
+	assert.Nil(t, check("1.0.0").Notice, "the notice lapses at expires_at")
+}
@@ -0,0 +1,161 @@
+package update
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"updater/internal/entitlement"
+	"updater/internal/models"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// offerFilter decides which releases, and which of their edition and variant
+// artifacts, one client may be offered: those on its channel that are being
+// rolled out, whose targeting rules the client matches and whose entitlements
+// its license grants. The client's token is resolved at most once, and only
+// when a release or artifact that requires an entitlement is considered, so
+// checks that never meet one do not call the provider.
+type offerFilter struct {
+	provider entitlement.Provider
+	appID    string
+	token    string
+	edition  string
+	channel  string
+	client   models.ClientAttributes
+
+	resolved bool
+	granted  []string
+	reason   string // Why nothing was granted, for the decision trace
+}
+
+// newOfferFilter returns the filter for one client. channel is the channel
+// the client is offered releases of, as returned by clientChannel.
+func (s *Service) newOfferFilter(appID, edition, token, channel string, client models.ClientAttributes) *offerFilter {
+	return &offerFilter{provider: s.entitlements, appID: appID, token: token, edition: edition, channel: channel, client: client}
+}
+
+// offer returns the release as the client should be offered it, or nil when
+// it is not on the client's channel, it is held back from every client, the
+// client does not match its targeting rules, the license does not allow it or
+// it is not available in the client's variant. A client that reports an
+// edition gets the release's artifact for that edition when there is one and
+// the license allows it; otherwise it gets the artifact of its variant.
+func (c *offerFilter) offer(ctx context.Context, release *models.Release, trace *decisionTrace) *models.Release {
+	if !c.onChannel(release, trace) || !rolledOut(release, trace) || !c.targets(release, trace) ||
+		!c.grants(ctx, release.Version, release.RequiredEntitlement, trace) {
+		return nil
+	}
+	if edition := c.editionArtifact(ctx, release, trace); edition != nil {
+		return edition
+	}
+	return c.variantArtifact(release, trace)
+}
+
+// onChannel reports whether the release is on the client's channel.
+func (c *offerFilter) onChannel(release *models.Release, trace *decisionTrace) bool {
+	if !models.ChannelOffers(c.channel, release) {
+		trace.add(models.RuleChannel, models.DecisionFail, release.Version, "on channel %s, which %s clients are not offered", release.EffectiveChannel(), c.channel)
+		return false
+	}
+	return true
+}
+
+// rolledOut reports whether the release is offered to any client: its rollout
+// is not paused, it is not yanked, its required status checks have succeeded
+// and its hosted artifact has been uploaded.
+func rolledOut(release *models.Release, trace *decisionTrace) bool {
+	switch pending := release.PendingChecks(); {
+	case release.Paused:
+		trace.add(models.RulePaused, models.DecisionFail, release.Version, "rollout is paused")
+	case release.Yanked:
+		trace.add(models.RuleYanked, models.DecisionFail, release.Version, "yanked")
+	case len(pending) > 0:
+		trace.add(models.RuleStatusChecks, models.DecisionFail, release.Version, "waiting for status checks: %s", strings.Join(pending, ", "))
+	case release.ArtifactPending:
+		trace.add(models.RuleArtifact, models.DecisionFail, release.Version, "waiting for its artifact to be uploaded")
+	default:
+		return true
+	}
+	return false
+}
+
+// editionArtifact returns the release serving the client's edition artifact,
+// or nil when the client is to get another artifact.
+func (c *offerFilter) editionArtifact(ctx context.Context, release *models.Release, trace *decisionTrace) *models.Release {
+	if c.edition == "" || len(release.Editions) == 0 {
+		return nil
+	}
+	artifact, ok := release.Editions[c.edition]
+	switch {
+	case !ok:
+		trace.add(models.RuleEdition, models.DecisionSkip, release.Version, "no %s artifact; offering the base artifact", c.edition)
+		return nil
+	case !c.grants(ctx, release.Version, artifact.RequiredEntitlement, trace):
+		trace.add(models.RuleEdition, models.DecisionFail, release.Version, "%s artifact is not allowed; offering the base artifact", c.edition)
+		return nil
+	default:
+		trace.add(models.RuleEdition, models.DecisionPass, release.Version, "offering the %s artifact", c.edition)
+		return release.ForEdition(c.edition)
+	}
+}
+
+// variantArtifact returns the release serving the artifact of the client's
+// variant, or nil when the release is not available in it. Clients that
+// report no variant, and releases that declare none, get the release as it
+// is.
+func (c *offerFilter) variantArtifact(release *models.Release, trace *decisionTrace) *models.Release {
+	if c.client.Variant == "" {
+		return release
+	}
+	if !release.HasVariants() {
+		trace.add(models.RuleVariant, models.DecisionSkip, release.Version, "no variants declared; offering the release's artifact")
+		return release
+	}
+	offered := release.ForVariant(c.client.Variant)
+	if offered == nil {
+		trace.add(models.RuleVariant, models.DecisionFail, release.Version, "not available as %s; available as %s", c.client.Variant, strings.Join(release.ReleaseVariants(), ", "))
+		return nil
+	}
+	trace.add(models.RuleVariant, models.DecisionPass, release.Version, "offering the %s artifact", c.client.Variant)
+	return offered
+}
+
+// targets reports whether the client matches the release's targeting rules.
+func (c *offerFilter) targets(release *models.Release, trace *decisionTrace) bool {
+	if len(release.Targeting) == 0 {
+		return true
+	}
+	if rule := models.MatchTargeting(release.Targeting, c.client); rule != nil {
+		trace.add(models.RuleTargeting, models.DecisionFail, release.Version, "client does not match targeting rule %s", rule)
+		return false
+	}
+	trace.add(models.RuleTargeting, models.DecisionPass, release.Version, "client matches all %d targeting rules", len(release.Targeting))
+	return true
+}
+
+// newestAllowedRelease returns the highest-versioned release filter offers, as
+// offered to the client, or nil when none qualifies. Pre-releases are skipped
+// unless allowPrerelease is set.
+func newestAllowedRelease(ctx context.Context, releases []*models.Release, allowPrerelease bool, filter *offerFilter, trace *decisionTrace) *models.Release {
+	type candidate struct {
+		release *models.Release
+		version *semver.Version
+	}
+	candidates := make([]candidate, 0, len(releases))
+	for _, release := range releases {
+		v, err := semver.NewVersion(release.Version)
+		if err != nil || (!allowPrerelease && v.Prerelease() != "") {
+			continue
+		}
+		candidates = append(candidates, candidate{release, v})
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int { return b.version.Compare(a.version) })
+
+	for _, c := range candidates {
+		if offered := filter.offer(ctx, c.release, trace); offered != nil {
+			return offered
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"updater/internal/models"
+)
+
+// CheckOTAUpdate runs an update check for an embedded device and returns the
+// compact OTA response. The release selection is the same as CheckForUpdate;
+// only applications with the ota profile can be checked this way.
+func (s *Service) CheckOTAUpdate(ctx context.Context, req *models.OTACheckRequest) (*models.OTACheckResponse, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+
+	app, err := s.storage.GetApplication(ctx, req.ApplicationID)
+	if err != nil {
+		reportUnknownApplication(ctx, req.ApplicationID, err)
+		return nil, NewApplicationNotFoundError(req.ApplicationID)
+	}
+	if app.Config.Profile != models.ApplicationProfileOTA {
+		return nil, NewInvalidRequestError(fmt.Sprintf("application %s does not use the ota profile", app.ID), nil)
+	}
+
+	check, err := s.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID:   req.ApplicationID,
+		CurrentVersion:  req.CurrentVersion,
+		Platform:        req.Platform,
+		Architecture:    req.Architecture,
+		AllowPrerelease: req.AllowPrerelease,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewOTACheckResponse(check, app.Config.OTA, req), nil
+}
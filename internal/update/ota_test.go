@@ -0,0 +1,94 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CheckOTAUpdate(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.SaveApplication(ctx, &models.Application{
+		ID:        "sensor-fw",
+		Name:      "Sensor Firmware",
+		Platforms: []string{"linux"},
+		Config: models.ApplicationConfig{
+			Profile: models.ApplicationProfileOTA,
+			OTA:     &models.OTAConfig{ChunkSize: 65536, MinBatteryPercent: 30},
+		},
+	})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "desktop", Name: "Desktop", Platforms: []string{"linux"}})
+	mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("sensor-fw", "1.0.0", "linux", "arm"))
+	mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("sensor-fw", "1.1.0", "linux", "arm"))
+
+	t.Run("update available", func(t *testing.T) {
+		resp, err := service.CheckOTAUpdate(ctx, &models.OTACheckRequest{
+			ApplicationID:  "sensor-fw",
+			CurrentVersion: "1.0.0",
+			Platform:       "linux",
+			Architecture:   "arm",
+			Slot:           "A",
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.UpdateAvailable)
+		assert.Equal(t, "1.1.0", resp.Version)
+		assert.Equal(t, models.OTASlotB, resp.TargetSlot)
+		assert.Equal(t, int64(65536), resp.ChunkSize)
+		assert.Equal(t, int64(19), resp.Chunks)
+		assert.Equal(t, 30, resp.MinBattery)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		resp, err := service.CheckOTAUpdate(ctx, &models.OTACheckRequest{
+			ApplicationID:  "sensor-fw",
+			CurrentVersion: "1.1.0",
+			Platform:       "linux",
+			Architecture:   "arm",
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.UpdateAvailable)
+	})
+
+	t.Run("application without ota profile", func(t *testing.T) {
+		_, err := service.CheckOTAUpdate(ctx, &models.OTACheckRequest{
+			ApplicationID:  "desktop",
+			CurrentVersion: "1.0.0",
+			Platform:       "linux",
+			Architecture:   "arm",
+		})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeInvalidRequest, serviceErr.Code)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		_, err := service.CheckOTAUpdate(ctx, &models.OTACheckRequest{
+			ApplicationID:  "missing",
+			CurrentVersion: "1.0.0",
+			Platform:       "linux",
+			Architecture:   "arm",
+		})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+	})
+
+	t.Run("invalid slot", func(t *testing.T) {
+		_, err := service.CheckOTAUpdate(ctx, &models.OTACheckRequest{
+			ApplicationID:  "sensor-fw",
+			CurrentVersion: "1.0.0",
+			Platform:       "linux",
+			Architecture:   "arm",
+			Slot:           "c",
+		})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+}
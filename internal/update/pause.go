@@ -0,0 +1,55 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"updater/internal/events"
+	"updater/internal/models"
+)
+
+// PauseRelease halts the rollout of a release without deleting it. A paused
+// release is not offered by update checks or latest-version lookups, which
+// offer the newest release before it instead, until it is resumed.
+func (s *Service) PauseRelease(ctx context.Context, appID, version, platform, arch string) (*models.PauseReleaseResponse, error) {
+	return s.setReleasePaused(ctx, appID, version, platform, arch, true)
+}
+
+// ResumeRelease continues the rollout of a paused release.
+func (s *Service) ResumeRelease(ctx context.Context, appID, version, platform, arch string) (*models.PauseReleaseResponse, error) {
+	return s.setReleasePaused(ctx, appID, version, platform, arch, false)
+}
+
+func (s *Service) setReleasePaused(ctx context.Context, appID, version, platform, arch string, paused bool) (*models.PauseReleaseResponse, error) {
+	release, err := s.storage.GetRelease(ctx, appID, version, platform, arch)
+	if err != nil {
+		return nil, NewNotFoundError(fmt.Sprintf("release '%s-%s-%s-%s' not found", appID, version, platform, arch))
+	}
+
+	state := "resumed"
+	if paused {
+		state = "paused"
+	}
+	if release.Paused == paused {
+		return &models.PauseReleaseResponse{
+			ID:        release.ID,
+			Paused:    paused,
+			UpdatedAt: release.UpdatedAt,
+			Message:   fmt.Sprintf("Release '%s' is already %s", release.ID, state),
+		}, nil
+	}
+
+	release.Paused = paused
+	release.UpdatedAt = s.now().UTC()
+	if err := s.storage.SaveRelease(ctx, release); err != nil {
+		return nil, NewInternalError("failed to save release", err)
+	}
+	// Resuming wakes long-poll checks waiting for a release to be offered
+	s.publishRelease(events.ReleaseUpdated, release)
+
+	return &models.PauseReleaseResponse{
+		ID:        release.ID,
+		Paused:    paused,
+		UpdatedAt: release.UpdatedAt,
+		Message:   fmt.Sprintf("Release '%s' %s", release.ID, state),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PauseRelease(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("pause-app", "Pause App", []string{"windows"})))
+	service := NewService(store)
+
+	register := func(version string) {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "pause-app",
+			Version:       version,
+			Platform:      "windows",
+			Architecture:  "amd64",
+			DownloadURL:   "https://example.com/app-" + version + ".exe",
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+		})
+		require.NoError(t, err)
+	}
+	check := func() *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "pause-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	latest := func() string {
+		resp, err := service.GetLatestVersion(ctx, &models.LatestVersionRequest{ApplicationID: "pause-app", Platform: "windows", Architecture: "amd64"})
+		require.NoError(t, err)
+		return resp.Version
+	}
+	register("1.0.0")
+	register("1.1.0")
+	register("1.2.0")
+
+	paused, err := service.PauseRelease(ctx, "pause-app", "1.2.0", "windows", "amd64")
+	require.NoError(t, err)
+	assert.True(t, paused.Paused)
+
+	resp := check()
+	assert.True(t, resp.UpdateAvailable)
+	assert.Equal(t, "1.1.0", resp.LatestVersion, "the paused release is skipped")
+	assert.Equal(t, "1.1.0", latest())
+
+	dryRun, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "pause-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, dryRun.Trace, models.DecisionStep{
+		Rule: models.RulePaused, Result: models.DecisionFail, Release: "1.2.0", Detail: "rollout is paused",
+	})
+
+	// Registering the release again does not resume it
+	register("1.2.0")
+	assert.Equal(t, "1.1.0", check().LatestVersion)
+
+	again, err := service.PauseRelease(ctx, "pause-app", "1.2.0", "windows", "amd64")
+	require.NoError(t, err)
+	assert.Contains(t, again.Message, "already paused")
+
+	resumed, err := service.ResumeRelease(ctx, "pause-app", "1.2.0", "windows", "amd64")
+	require.NoError(t, err)
+	assert.False(t, resumed.Paused)
+	assert.Equal(t, "1.2.0", check().LatestVersion)
+	assert.Equal(t, "1.2.0", latest())
+
+	_, err = service.PauseRelease(ctx, "pause-app", "9.9.9", "windows", "amd64")
+	assertServiceError(t, err, http.StatusNotFound)
+}
@@ -4,7 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
 	"time"
+	"updater/internal/blob"
+	"updater/internal/entitlement"
+	"updater/internal/events"
 	"updater/internal/models"
 	"updater/internal/storage"
 
@@ -13,14 +20,136 @@ import (
 
 // Service handles update checking and version comparison business logic
 type Service struct {
-	storage storage.Storage
+	storage   storage.Storage
+	events    *events.Bus
+	notifier  *releaseNotifier
+	templates []models.ApplicationTemplate
+	decisions *DecisionLog
+	bandwidth *bandwidthMeter
+	urlPolicy models.DownloadURLPolicy
+
+	rejectWeakChecksums bool
+	entitlements        entitlement.Provider
+	artifacts           ArtifactFetcher
+	artifactStore       blob.Store
+	maxArtifactSize     int64
+	notesDrafter        NotesDrafter
+	now                 func() time.Time
+	newID               models.IDGenerator
+}
+
+// ArtifactFetcher measures a release artifact at its download URL, for
+// registrations that set auto_fill.
+type ArtifactFetcher interface {
+	// Size returns the artifact's size in bytes
+	Size(ctx context.Context, url string) (int64, error)
+	// Digest downloads the artifact and returns its size and hex checksum
+	Digest(ctx context.Context, url, checksumType string) (int64, string, error)
+}
+
+// ServiceOption configures optional Service behavior.
+type ServiceOption func(*Service)
+
+// WithApplicationTemplates sets the templates CreateApplication can take
+// defaults from. The templates are expected to have been validated with the
+// rest of the configuration.
+func WithApplicationTemplates(templates []models.ApplicationTemplate) ServiceOption {
+	return func(s *Service) {
+		s.templates = templates
+	}
+}
+
+// WithDownloadURLPolicy restricts the download URLs new releases can be
+// registered with. The policy is expected to have been validated with the rest
+// of the configuration.
+func WithDownloadURLPolicy(policy models.DownloadURLPolicy) ServiceOption {
+	return func(s *Service) {
+		s.urlPolicy = policy
+	}
+}
+
+// WithArtifactFetcher lets RegisterRelease fill in the file size and checksum
+// of registrations that set auto_fill. Without it such registrations are
+// rejected.
+func WithArtifactFetcher(f ArtifactFetcher) ServiceOption {
+	return func(s *Service) {
+		s.artifacts = f
+	}
+}
+
+// WithRejectWeakChecksums makes RegisterRelease and IngestReleaseManifest
+// reject the deprecated md5 and sha1 checksum types. Existing releases are
+// unaffected.
+func WithRejectWeakChecksums(reject bool) ServiceOption {
+	return func(s *Service) {
+		s.rejectWeakChecksums = reject
+	}
+}
+
+// WithClock sets the time source of the timestamps the service writes, such
+// as the creation time of applications and releases. Tests use it to get
+// deterministic timestamps.
+func WithClock(now func() time.Time) ServiceOption {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// WithIDGenerator sets how the IDs of new releases are generated. A release
+// that is registered again keeps the ID it was stored with.
+func WithIDGenerator(newID models.IDGenerator) ServiceOption {
+	return func(s *Service) {
+		s.newID = newID
+	}
+}
+
+// WithEventBus sets the bus the service publishes application and release
+// changes to, so other subsystems can subscribe to them. Without it the
+// service publishes to a bus of its own, which only its long-polling checks
+// subscribe to.
+func WithEventBus(bus *events.Bus) ServiceOption {
+	return func(s *Service) {
+		s.events = bus
+	}
 }
 
 // NewService creates a new update service with the given storage backend
-func NewService(storage storage.Storage) *Service {
-	return &Service{
-		storage: storage,
+func NewService(storage storage.Storage, opts ...ServiceOption) *Service {
+	s := &Service{
+		storage:   storage,
+		notifier:  newReleaseNotifier(),
+		bandwidth: newBandwidthMeter(),
+		now:       time.Now,
+		newID:     models.NewULID,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.events == nil {
+		s.events = events.NewBus()
+	}
+	// Updates wake held checks too, since moving a release to a channel
+	// offers it to more clients
+	s.events.Subscribe(func(e events.Event) { s.notifier.notify(e.ApplicationID) }, events.ReleasePublished, events.ReleaseUpdated)
+	return s
+}
+
+// publishApplication publishes a change to the application appID.
+func (s *Service) publishApplication(t events.Type, appID string) {
+	s.events.Publish(events.Event{Type: t, ApplicationID: appID, Time: s.now()})
+}
+
+// publishRelease publishes a change to a release.
+func (s *Service) publishRelease(t events.Type, release *models.Release) {
+	s.events.Publish(events.Event{
+		Type:          t,
+		ApplicationID: release.ApplicationID,
+		ReleaseID:     release.ID,
+		Version:       release.Version,
+		Platform:      release.Platform,
+		Architecture:  release.Architecture,
+		Time:          s.now(),
+	})
 }
 
 // CheckForUpdate determines if there's an update available for the given request
@@ -31,25 +160,103 @@ func (s *Service) CheckForUpdate(ctx context.Context, req *models.UpdateCheckReq
 	}
 	req.Normalize()
 
+	requestID := RequestIDFromContext(ctx)
+	if s.decisions == nil || requestID == "" {
+		return s.checkForUpdate(ctx, req, nil)
+	}
+	trace := newDecisionTrace()
+	result, err := s.checkForUpdate(ctx, req, trace)
+	s.recordDecision(requestID, req, result, err, trace)
+	return result, err
+}
+
+// checkForUpdate runs a validated update check, recording each decision in
+// trace when it is non-nil.
+func (s *Service) checkForUpdate(ctx context.Context, req *models.UpdateCheckRequest, trace *decisionTrace) (result *models.UpdateCheckResponse, err error) {
 	// Get application to verify it exists and supports the platform
 	app, err := s.storage.GetApplication(ctx, req.ApplicationID)
 	if err != nil {
+		trace.add(models.RuleApplication, models.DecisionFail, "", "application %s not found", req.ApplicationID)
+		reportUnknownApplication(ctx, req.ApplicationID, err)
 		return nil, NewApplicationNotFoundError(req.ApplicationID)
 	}
+	trace.add(models.RuleApplication, models.DecisionPass, "", "application %s found", app.ID)
+
+	// Clients that identify themselves are told when their next check is due
+	if app.Config.UpdateInterval > 0 && req.ClientID != "" {
+		defer func() {
+			if result != nil {
+				interval := time.Duration(app.Config.UpdateInterval) * time.Second
+				result.NextCheckSeconds = models.NextCheckSeconds(app.ID, req.ClientID, interval, s.now())
+			}
+		}()
+	}
+
+	// Every answer carries the application's notice, such as an outage notice
+	if notice := app.Config.Notice.ActiveAt(s.now()); notice != nil {
+		defer func() {
+			if result != nil {
+				result.Notice = notice
+			}
+		}()
+	}
+
+	// Offers are paused once the application's hourly bandwidth budget is spent
+	if app.Config.BandwidthBudget > 0 {
+		defer func() {
+			if result != nil && result.UpdateAvailable {
+				result = s.chargeBandwidth(ctx, app, result, trace)
+			}
+		}()
+	}
+
+	// Android refuses an APK whose version code is not above the installed
+	// one. Deferred before the yank downgrade, so that it runs after it.
+	if req.VersionCode > 0 {
+		defer func() {
+			if err == nil && result != nil && result.UpdateAvailable {
+				result = checkVersionCode(req, result, trace)
+			}
+		}()
+	}
+
+	// Clients on a yanked release are offered the release before it. Deferred
+	// after the bandwidth charge, so that it runs first and the downgrade is
+	// charged.
+	defer func() {
+		if err == nil && result != nil && !result.UpdateAvailable {
+			result, err = s.offerYankDowngrade(ctx, app, req, result, trace)
+		}
+	}()
 
 	// Check if application supports the requested platform
 	if !app.SupportsPlatform(req.Platform) {
+		trace.add(models.RulePlatform, models.DecisionFail, "", "%s is not one of the application's platforms (%s)", req.Platform, strings.Join(app.Platforms, ", "))
 		return nil, NewInvalidRequestError(
 			fmt.Sprintf("application %s does not support platform %s", req.ApplicationID, req.Platform),
 			nil,
 		)
 	}
+	trace.add(models.RulePlatform, models.DecisionPass, "", "%s is supported", req.Platform)
+
+	// Clients following a channel are offered the newest release on it
+	if req.Channel != "" {
+		return s.checkChannelUpdate(ctx, app, req, trace)
+	}
+
+	// Plugins checked with a host version only see releases compatible with that host
+	if app.ParentID != "" && req.HostVersion != "" {
+		trace.add(models.RuleHostCompatibility, models.DecisionPass, "", "plugin of %s; only releases compatible with host version %s are considered", app.ParentID, req.HostVersion)
+		return s.checkForPluginUpdate(ctx, req, trace)
+	}
 
 	// Get the latest available release for this platform/architecture
 	latestRelease, err := s.storage.GetLatestRelease(ctx, req.ApplicationID, req.Platform, req.Architecture)
 	if err != nil {
+		trace.add(models.RuleLatestRelease, models.DecisionFail, "", "no release found for %s-%s", req.Platform, req.Architecture)
 		return nil, NewInternalError("failed to get latest release", err)
 	}
+	trace.add(models.RuleLatestRelease, models.DecisionPass, latestRelease.Version, "latest release for %s-%s", req.Platform, req.Architecture)
 
 	// Parse current and latest versions for comparison
 	currentVersion, err := semver.NewVersion(req.CurrentVersion)
@@ -68,11 +275,15 @@ func (s *Service) CheckForUpdate(ctx context.Context, req *models.UpdateCheckReq
 
 	// Check if an update is available
 	if latestVersion.GreaterThan(currentVersion) {
+		trace.add(models.RuleNewerVersion, models.DecisionPass, latestRelease.Version, "newer than current version %s", req.CurrentVersion)
+
 		// Check pre-release handling
 		if !req.AllowPrerelease && latestVersion.Prerelease() != "" {
+			trace.add(models.RulePrerelease, models.DecisionFail, latestRelease.Version, "pre-release and allow_prerelease is false; looking for a stable release")
 			stableRelease, err := s.storage.GetLatestStableRelease(ctx, req.ApplicationID, req.Platform, req.Architecture)
 			if err != nil {
 				if errors.Is(err, storage.ErrNotFound) {
+					trace.add(models.RuleStableFallback, models.DecisionFail, "", "no stable release for %s-%s", req.Platform, req.Architecture)
 					response.SetNoUpdateAvailable(req.CurrentVersion)
 					return response, nil
 				}
@@ -83,26 +294,41 @@ func (s *Service) CheckForUpdate(ctx context.Context, req *models.UpdateCheckReq
 				return nil, NewInternalError("invalid stable release version", err)
 			}
 			if !stableVer.GreaterThan(currentVersion) {
+				trace.add(models.RuleStableFallback, models.DecisionFail, stableRelease.Version, "latest stable release is not newer than current version %s", req.CurrentVersion)
 				response.SetNoUpdateAvailable(req.CurrentVersion)
 				return response, nil
 			}
+			trace.add(models.RuleStableFallback, models.DecisionPass, stableRelease.Version, "newest stable release is newer than current version %s", req.CurrentVersion)
 			latestRelease = stableRelease
 		}
 
-		// Check minimum version requirement
-		if latestRelease.MinimumVersion != "" {
-			meets, err := latestRelease.MeetsMinimumVersion(req.CurrentVersion)
+		// Clients are offered the artifact of their edition, and releases that
+		// require an entitlement fall back to the newest release the client's
+		// license allows
+		filter := s.newOfferFilter(req.ApplicationID, req.Edition, req.LicenseToken, clientChannel(req.Channel, req.AllowPrerelease), req.Attributes())
+		if offered := filter.offer(ctx, latestRelease, trace); offered != nil {
+			latestRelease = offered
+		} else {
+			candidates, err := s.storage.GetReleasesAfterVersion(ctx, req.ApplicationID, req.CurrentVersion, req.Platform, req.Architecture)
 			if err != nil {
-				return nil, NewInternalError("failed to check minimum version", err)
+				return nil, NewInternalError("failed to get newer releases", err)
 			}
-			if !meets {
-				return nil, NewInvalidRequestError(fmt.Sprintf("current version %s does not meet minimum required version %s for update to %s",
-					req.CurrentVersion, latestRelease.MinimumVersion, latestRelease.Version), nil)
+			allowed := newestAllowedRelease(ctx, candidates, req.AllowPrerelease, filter, trace)
+			if allowed == nil {
+				response.SetNoUpdateAvailable(req.CurrentVersion)
+				return response, nil
 			}
+			latestRelease = allowed
+		}
+
+		// Check minimum version requirement
+		if err := checkMinimumVersion(latestRelease, req.CurrentVersion, trace); err != nil {
+			return nil, err
 		}
 
 		// Update is available
 		response.SetUpdateAvailable(latestRelease)
+		response.ReleaseNotes = s.expandReleaseNotes(ctx, latestRelease)
 
 		// Include metadata if requested
 		if !req.IncludeMetadata {
@@ -110,12 +336,143 @@ func (s *Service) CheckForUpdate(ctx context.Context, req *models.UpdateCheckReq
 		}
 	} else {
 		// No update available
+		trace.add(models.RuleNewerVersion, models.DecisionFail, latestRelease.Version, "not newer than current version %s", req.CurrentVersion)
+		if currentVersion.GreaterThan(latestVersion) {
+			reportAnomaly(ctx, models.AnomalyFutureVersion, req.ApplicationID)
+		}
 		response.SetNoUpdateAvailable(req.CurrentVersion)
 	}
 
 	return response, nil
 }
 
+// checkVersionCode withdraws an offer whose Android version code is not
+// above the client's, since Android would refuse to install it.
+func checkVersionCode(req *models.UpdateCheckRequest, result *models.UpdateCheckResponse, trace *decisionTrace) *models.UpdateCheckResponse {
+	if result.VersionCode == 0 {
+		trace.add(models.RuleVersionCode, models.DecisionSkip, result.LatestVersion, "release has no version code")
+		return result
+	}
+	if result.VersionCode <= req.VersionCode {
+		trace.add(models.RuleVersionCode, models.DecisionFail, result.LatestVersion, "version code %d is not above the client's %d", result.VersionCode, req.VersionCode)
+		response := &models.UpdateCheckResponse{}
+		response.SetNoUpdateAvailable(req.CurrentVersion)
+		return response
+	}
+	trace.add(models.RuleVersionCode, models.DecisionPass, result.LatestVersion, "version code %d is above the client's %d", result.VersionCode, req.VersionCode)
+	return result
+}
+
+// checkMinimumVersion rejects an update whose minimum version the client's
+// current version does not meet.
+func checkMinimumVersion(release *models.Release, currentVersion string, trace *decisionTrace) error {
+	if release.MinimumVersion == "" {
+		trace.add(models.RuleMinimumVersion, models.DecisionSkip, release.Version, "release has no minimum version")
+		return nil
+	}
+	meets, err := release.MeetsMinimumVersion(currentVersion)
+	if err != nil {
+		return NewInternalError("failed to check minimum version", err)
+	}
+	if !meets {
+		trace.add(models.RuleMinimumVersion, models.DecisionFail, release.Version, "current version %s is below minimum version %s", currentVersion, release.MinimumVersion)
+		return NewInvalidRequestError(fmt.Sprintf("current version %s does not meet minimum required version %s for update to %s",
+			currentVersion, release.MinimumVersion, release.Version), nil)
+	}
+	trace.add(models.RuleMinimumVersion, models.DecisionPass, release.Version, "current version %s meets minimum version %s", currentVersion, release.MinimumVersion)
+	return nil
+}
+
+// checkForPluginUpdate offers the newest release of a plugin that is newer than
+// the client's current version and compatible with the client's host version.
+func (s *Service) checkForPluginUpdate(ctx context.Context, req *models.UpdateCheckRequest, trace *decisionTrace) (*models.UpdateCheckResponse, error) {
+	candidates, err := s.storage.GetReleasesAfterVersion(ctx, req.ApplicationID, req.CurrentVersion, req.Platform, req.Architecture)
+	if err != nil {
+		return nil, NewInternalError("failed to get newer releases", err)
+	}
+
+	filter := s.newOfferFilter(req.ApplicationID, req.Edition, req.LicenseToken, clientChannel(req.Channel, req.AllowPrerelease), req.Attributes())
+	release, err := newestCompatibleRelease(ctx, candidates, req.HostVersion, req.AllowPrerelease, filter, trace)
+	if err != nil {
+		return nil, NewInternalError("failed to check host compatibility", err)
+	}
+
+	response := &models.UpdateCheckResponse{
+		CurrentVersion: req.CurrentVersion,
+	}
+	if release == nil {
+		trace.add(models.RuleNewerVersion, models.DecisionFail, "", "no compatible release newer than current version %s", req.CurrentVersion)
+		response.SetNoUpdateAvailable(req.CurrentVersion)
+		return response, nil
+	}
+
+	if err := checkMinimumVersion(release, req.CurrentVersion, trace); err != nil {
+		return nil, err
+	}
+
+	response.SetUpdateAvailable(release)
+	response.ReleaseNotes = s.expandReleaseNotes(ctx, release)
+	if !req.IncludeMetadata {
+		response.Metadata = nil
+	}
+	return response, nil
+}
+
+// newestCompatibleRelease returns the highest-versioned release whose host version
+// constraint accepts hostVersion and that the license allows, as offered to the
+// client, or nil when none qualifies. Pre-releases are skipped unless
+// allowPrerelease is set.
+func newestCompatibleRelease(ctx context.Context, releases []*models.Release, hostVersion string, allowPrerelease bool, filter *offerFilter, trace *decisionTrace) (*models.Release, error) {
+	var (
+		best    *models.Release
+		bestVer *semver.Version
+	)
+	for _, release := range releases {
+		v, err := semver.NewVersion(release.Version)
+		if err != nil {
+			continue
+		}
+		if !allowPrerelease && v.Prerelease() != "" {
+			trace.add(models.RulePrerelease, models.DecisionFail, release.Version, "pre-release and allow_prerelease is false")
+			continue
+		}
+		if bestVer != nil && !v.GreaterThan(bestVer) {
+			continue
+		}
+		offered := filter.offer(ctx, release, trace)
+		if offered == nil {
+			continue
+		}
+		compatible, err := release.IsCompatibleWithHost(hostVersion)
+		if err != nil {
+			return nil, fmt.Errorf("release %s: %w", release.ID, err)
+		}
+		if compatible {
+			trace.add(models.RuleHostCompatibility, models.DecisionPass, release.Version, "host version constraint %q accepts %s", release.HostVersionConstraint, hostVersion)
+			best, bestVer = offered, v
+		} else {
+			trace.add(models.RuleHostCompatibility, models.DecisionFail, release.Version, "host version constraint %q excludes %s", release.HostVersionConstraint, hostVersion)
+		}
+	}
+	return best, nil
+}
+
+// expandReleaseNotes resolves the template variables in a release's notes for
+// a client response. Per-platform download links look up the release of the
+// same version on that platform; a link to a platform without one is empty.
+func (s *Service) expandReleaseNotes(ctx context.Context, release *models.Release) string {
+	if !models.HasReleaseNotesVariables(release.ReleaseNotes) {
+		return release.ReleaseNotes
+	}
+	return models.ExpandReleaseNotes(release, func(platform, arch string) string {
+		sibling, err := s.storage.GetRelease(ctx, release.ApplicationID, release.Version, platform, arch)
+		if err != nil {
+			return ""
+		}
+		return sibling.DownloadURL
+	})
+}
+
 // GetLatestVersion returns the latest version information for the given request
 func (s *Service) GetLatestVersion(ctx context.Context, req *models.LatestVersionRequest) (*models.LatestVersionResponse, error) {
 	// Validate and normalize request
@@ -127,6 +484,7 @@ func (s *Service) GetLatestVersion(ctx context.Context, req *models.LatestVersio
 	// Get application to verify it exists and supports the platform
 	app, err := s.storage.GetApplication(ctx, req.ApplicationID)
 	if err != nil {
+		reportUnknownApplication(ctx, req.ApplicationID, err)
 		return nil, NewApplicationNotFoundError(req.ApplicationID)
 	}
 
@@ -138,6 +496,21 @@ func (s *Service) GetLatestVersion(ctx context.Context, req *models.LatestVersio
 		)
 	}
 
+	filter := s.newOfferFilter(req.ApplicationID, req.Edition, req.LicenseToken, clientChannel(req.Channel, req.AllowPrerelease), models.ClientAttributes{Variant: req.Variant})
+
+	// Clients following a channel get the newest release on it
+	if req.Channel != "" {
+		releases, err := s.storage.GetReleasesAfterVersion(ctx, req.ApplicationID, lowestVersion, req.Platform, req.Architecture)
+		if err != nil {
+			return nil, NewInternalError("failed to get releases", err)
+		}
+		release := newestAllowedRelease(ctx, channelReleases(releases, req.Channel, nil), true, filter, nil)
+		if release == nil {
+			return nil, NewApplicationNotFoundError(fmt.Sprintf("%s on %s-%s (no releases on channel %s)", req.ApplicationID, req.Platform, req.Architecture, req.Channel))
+		}
+		return s.latestVersionResponse(ctx, req, release), nil
+	}
+
 	// Get the latest available release for this platform/architecture
 	latestRelease, err := s.storage.GetLatestRelease(ctx, req.ApplicationID, req.Platform, req.Architecture)
 	if err != nil {
@@ -163,15 +536,209 @@ func (s *Service) GetLatestVersion(ctx context.Context, req *models.LatestVersio
 		}
 	}
 
+	if offered := filter.offer(ctx, latestRelease, nil); offered != nil {
+		latestRelease = offered
+	} else {
+		releases, err := s.storage.GetReleasesAfterVersion(ctx, req.ApplicationID, lowestVersion, req.Platform, req.Architecture)
+		if err != nil {
+			return nil, NewInternalError("failed to get releases", err)
+		}
+		allowed := newestAllowedRelease(ctx, releases, req.AllowPrerelease, filter, nil)
+		if allowed == nil {
+			return nil, NewApplicationNotFoundError(fmt.Sprintf("%s on %s-%s (no releases available to this client)", req.ApplicationID, req.Platform, req.Architecture))
+		}
+		latestRelease = allowed
+	}
+
+	return s.latestVersionResponse(ctx, req, latestRelease), nil
+}
+
+// latestVersionResponse builds the response of a latest version lookup.
+func (s *Service) latestVersionResponse(ctx context.Context, req *models.LatestVersionRequest, release *models.Release) *models.LatestVersionResponse {
 	response := &models.LatestVersionResponse{}
-	response.FromRelease(latestRelease)
+	response.FromRelease(release)
+	response.ReleaseNotes = s.expandReleaseNotes(ctx, release)
 
 	// Include metadata if requested
 	if !req.IncludeMetadata {
 		response.Metadata = nil
 	}
 
-	return response, nil
+	return response
+}
+
+// BatchCheckForUpdates runs each check in the batch independently and returns
+// one result per check in request order. Failures of individual checks are
+// reported in their result rather than failing the batch.
+func (s *Service) BatchCheckForUpdates(ctx context.Context, req *models.BatchUpdateCheckRequest) (*models.BatchUpdateCheckResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+
+	results := make([]models.BatchUpdateCheckResult, len(req.Checks))
+	for i := range req.Checks {
+		check := &req.Checks[i]
+		response, err := s.CheckForUpdate(ctx, check)
+		results[i].ApplicationID = check.ApplicationID
+		if err != nil {
+			var serviceErr *ServiceError
+			if !errors.As(err, &serviceErr) {
+				serviceErr = NewInternalError("failed to check for update", err)
+			}
+			results[i].Error = &models.BatchCheckError{Code: serviceErr.Code, Message: serviceErr.Message}
+			continue
+		}
+		results[i].Result = response
+	}
+
+	return &models.BatchUpdateCheckResponse{Results: results}, nil
+}
+
+// lowestVersion is the smallest valid semantic version. Passing it to
+// GetReleasesAfterVersion returns every release for a platform/architecture.
+const lowestVersion = "0.0.0-0"
+
+// pluginPageSize is the page size used when walking all plugins of a host.
+const pluginPageSize = 100
+
+// ListPluginUpdates returns every plugin of a host application together with the
+// newest release of each plugin that is compatible with the given host version.
+// Plugins without a compatible release are listed with a nil release.
+func (s *Service) ListPluginUpdates(ctx context.Context, req *models.PluginUpdatesRequest) (*models.PluginUpdatesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+	req.Normalize()
+
+	if _, err := s.storage.GetApplication(ctx, req.HostApplicationID); err != nil {
+		reportUnknownApplication(ctx, req.HostApplicationID, err)
+		return nil, NewApplicationNotFoundError(req.HostApplicationID)
+	}
+
+	plugins, err := s.listPlugins(ctx, req.HostApplicationID)
+	if err != nil {
+		return nil, NewInternalError("failed to list plugins", err)
+	}
+
+	resp := &models.PluginUpdatesResponse{
+		HostApplicationID: req.HostApplicationID,
+		HostVersion:       req.HostVersion,
+		Plugins:           make([]models.PluginRelease, 0, len(plugins)),
+	}
+	for _, plugin := range plugins {
+		entry := models.PluginRelease{ApplicationID: plugin.ID, Name: plugin.Name}
+		if plugin.SupportsPlatform(req.Platform) {
+			releases, err := s.storage.GetReleasesAfterVersion(ctx, plugin.ID, lowestVersion, req.Platform, req.Architecture)
+			if err != nil {
+				return nil, NewInternalError(fmt.Sprintf("failed to get releases for plugin %s", plugin.ID), err)
+			}
+			filter := s.newOfferFilter(plugin.ID, "", req.LicenseToken, clientChannel("", req.AllowPrerelease), models.ClientAttributes{})
+			release, err := newestCompatibleRelease(ctx, releases, req.HostVersion, req.AllowPrerelease, filter, nil)
+			if err != nil {
+				return nil, NewInternalError("failed to check host compatibility", err)
+			}
+			if release != nil {
+				entry.Release = &models.LatestVersionResponse{}
+				entry.Release.FromRelease(release)
+				entry.Release.ReleaseNotes = s.expandReleaseNotes(ctx, release)
+			}
+		}
+		resp.Plugins = append(resp.Plugins, entry)
+	}
+	return resp, nil
+}
+
+// listPlugins returns every application whose parent is hostID, sorted by ID.
+func (s *Service) listPlugins(ctx context.Context, hostID string) ([]*models.Application, error) {
+	filters := models.ApplicationFilters{ParentID: hostID}
+	var (
+		plugins []*models.Application
+		cursor  *models.ApplicationCursor
+	)
+	for {
+		page, _, err := s.storage.ListApplicationsPaged(ctx, filters, pluginPageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, page...)
+		if len(page) < pluginPageSize {
+			break
+		}
+		last := page[len(page)-1]
+		cursor = &models.ApplicationCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].ID < plugins[j].ID })
+	return plugins, nil
+}
+
+// hasPlugins reports whether any application names appID as its parent.
+func (s *Service) hasPlugins(ctx context.Context, appID string) (bool, error) {
+	_, total, err := s.storage.ListApplicationsPaged(ctx, models.ApplicationFilters{ParentID: appID}, 1, nil)
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// validateParent checks that parentID may become the parent of appID: the parent
+// must exist and must not itself be a plugin, and appID must not host plugins of
+// its own. Plugin relationships are therefore exactly one level deep.
+func (s *Service) validateParent(ctx context.Context, appID, parentID string) error {
+	parent, err := s.storage.GetApplication(ctx, parentID)
+	if err != nil {
+		return NewValidationError("invalid parent application", fmt.Errorf("parent application '%s' not found", parentID))
+	}
+	if parent.ParentID != "" {
+		return NewValidationError("invalid parent application", fmt.Errorf("parent application '%s' is itself a plugin", parentID))
+	}
+	hosts, err := s.hasPlugins(ctx, appID)
+	if err != nil {
+		return NewInternalError("failed to list plugins", err)
+	}
+	if hosts {
+		return NewValidationError("invalid parent application", fmt.Errorf("application '%s' has plugins and cannot itself be a plugin", appID))
+	}
+	return nil
+}
+
+// GetLatestStableVersion returns the highest version offered to clients that
+// follow no channel, across all of an application's platforms and
+// architectures. Releases are checked by the same offer filter as update
+// checks, for a client with no license or targeting attributes, so paused,
+// yanked and held releases are left out. It returns an empty string when the
+// application exists but offers no stable release.
+func (s *Service) GetLatestStableVersion(ctx context.Context, appID string) (string, error) {
+	app, err := s.storage.GetApplication(ctx, appID)
+	if err != nil {
+		return "", NewApplicationNotFoundError(appID)
+	}
+
+	filter := s.newOfferFilter(appID, "", "", models.ChannelStable, models.ClientAttributes{})
+	var latest *semver.Version
+	for _, platform := range app.Platforms {
+		for _, arch := range models.SupportedArchitectures {
+			releases, err := s.storage.GetReleasesAfterVersion(ctx, appID, lowestVersion, platform, arch)
+			if err != nil {
+				return "", NewInternalError("failed to get releases", err)
+			}
+			release := newestAllowedRelease(ctx, releases, false, filter, nil)
+			if release == nil {
+				continue
+			}
+			v, err := semver.NewVersion(release.Version)
+			if err != nil {
+				return "", NewInternalError("invalid release version", err)
+			}
+			if latest == nil || v.GreaterThan(latest) {
+				latest = v
+			}
+		}
+	}
+
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Original(), nil
 }
 
 // ListReleases returns a filtered list of releases for the given request
@@ -204,6 +771,7 @@ func (s *Service) ListReleases(ctx context.Context, req *models.ListReleasesRequ
 	} else {
 		filters.Platforms = req.Platforms
 	}
+	filters.Tags = req.Tags
 
 	releases, totalCount, err := s.storage.ListReleasesPaged(ctx, req.ApplicationID, filters, req.SortBy, req.SortOrder, req.Limit, cursor)
 	if err != nil {
@@ -259,6 +827,107 @@ func (s *Service) ListReleases(ctx context.Context, req *models.ListReleasesRequ
 	}, nil
 }
 
+// CompareReleases reports the differences between the releases of two
+// versions of an application, per platform and architecture target.
+func (s *Service) CompareReleases(ctx context.Context, req *models.CompareReleasesRequest) (*models.CompareReleasesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+	req.Normalize()
+
+	if _, err := s.storage.GetApplication(ctx, req.ApplicationID); err != nil {
+		return nil, NewApplicationNotFoundError(req.ApplicationID)
+	}
+
+	fromReleases, err := s.versionReleases(ctx, req, req.From)
+	if err != nil {
+		return nil, err
+	}
+	toReleases, err := s.versionReleases(ctx, req, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]*models.ReleaseTarget)
+	target := func(release *models.Release) *models.ReleaseTarget {
+		key := release.Platform + "-" + release.Architecture
+		if targets[key] == nil {
+			targets[key] = &models.ReleaseTarget{Platform: release.Platform, Architecture: release.Architecture}
+		}
+		return targets[key]
+	}
+	for _, release := range fromReleases {
+		t := target(release)
+		t.From = &models.ReleaseInfo{}
+		t.From.FromRelease(release)
+	}
+	for _, release := range toReleases {
+		t := target(release)
+		t.To = &models.ReleaseInfo{}
+		t.To.FromRelease(release)
+	}
+
+	resp := &models.CompareReleasesResponse{
+		ApplicationID: req.ApplicationID,
+		From:          req.From,
+		To:            req.To,
+		Targets:       make([]models.ReleaseTarget, 0, len(targets)),
+	}
+	for _, t := range targets {
+		switch {
+		case t.From == nil:
+			t.Status = models.CompareStatusAdded
+			t.Changes = []models.FieldChange{}
+		case t.To == nil:
+			t.Status = models.CompareStatusRemoved
+			t.Changes = []models.FieldChange{}
+		default:
+			t.FileSizeDelta = t.To.FileSize - t.From.FileSize
+			t.Changes = models.DiffReleases(findRelease(fromReleases, t), findRelease(toReleases, t))
+			t.Status = models.CompareStatusUnchanged
+			if len(t.Changes) > 0 {
+				t.Status = models.CompareStatusChanged
+			}
+		}
+		resp.Targets = append(resp.Targets, *t)
+	}
+	sort.Slice(resp.Targets, func(i, j int) bool {
+		if resp.Targets[i].Platform != resp.Targets[j].Platform {
+			return resp.Targets[i].Platform < resp.Targets[j].Platform
+		}
+		return resp.Targets[i].Architecture < resp.Targets[j].Architecture
+	})
+	return resp, nil
+}
+
+// versionReleases returns the releases of one version that match the
+// comparison's platform and architecture filters. A version without any
+// matching release is reported as not found.
+func (s *Service) versionReleases(ctx context.Context, req *models.CompareReleasesRequest, version string) ([]*models.Release, error) {
+	filters := models.ReleaseFilters{Version: version, Architecture: req.Architecture}
+	if req.Platform != "" {
+		filters.Platforms = []string{req.Platform}
+	}
+	releases, _, err := s.storage.ListReleasesPaged(ctx, req.ApplicationID, filters, "platform", "asc", models.MaxPageSize, nil)
+	if err != nil {
+		return nil, NewInternalError("failed to get releases", err)
+	}
+	if len(releases) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("no releases found for %s version %s", req.ApplicationID, version))
+	}
+	return releases, nil
+}
+
+// findRelease returns the release for a comparison target's platform and architecture.
+func findRelease(releases []*models.Release, t *models.ReleaseTarget) *models.Release {
+	for _, release := range releases {
+		if release.Platform == t.Platform && release.Architecture == t.Architecture {
+			return release
+		}
+	}
+	return nil
+}
+
 // RegisterRelease creates a new release from the given request
 func (s *Service) RegisterRelease(ctx context.Context, req *models.RegisterReleaseRequest) (*models.RegisterReleaseResponse, error) {
 	// Validate and normalize request
@@ -278,14 +947,309 @@ func (s *Service) RegisterRelease(ctx context.Context, req *models.RegisterRelea
 		return nil, NewInvalidRequestError(fmt.Sprintf("application %s does not support platform %s", req.ApplicationID, req.Platform), nil)
 	}
 
-	// Create release from request
+	if req.HostedArtifact && s.artifactStore == nil {
+		return nil, NewValidationError("hosted_artifact is not enabled on this server", nil)
+	}
+	if err := s.checkDownloadURLs(app, req); err != nil {
+		return nil, NewValidationError(err.Error(), err)
+	}
+	if err := s.checkChecksumTypes(req); err != nil {
+		return nil, err
+	}
+	// Fetched only after the URL policies have accepted the URL
+	if req.AutoFill {
+		if err := s.autoFill(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	// Releases without notes start from the application's notes template
+	if req.ReleaseNotes == "" {
+		req.ReleaseNotes = app.Config.ReleaseNotesTemplate
+	}
+
+	// Create and validate release from request
+	release, err := s.newReleaseFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkFreeze(ctx, app, release.Channel); err != nil {
+		return nil, err
+	}
+	release.ReleaseNotesDraft = s.draftNotes(ctx, req)
+	release.StatusChecks = models.NewStatusChecks(app.Config.RequiredChecks, release.CreatedAt)
+	s.keepReleaseState(ctx, release)
+	// The download URL is derived from the ID the release keeps
+	if req.HostedArtifact {
+		s.hostArtifact(ctx, release)
+	}
+
+	// Save the release
+	if err := s.storage.SaveRelease(ctx, release); err != nil {
+		return nil, NewInternalError("failed to save release", err)
+	}
+	s.publishRelease(events.ReleasePublished, release)
+
+	resp := &models.RegisterReleaseResponse{
+		ID:        release.ID,
+		Message:   fmt.Sprintf("Release %s registered successfully", release.Version),
+		CreatedAt: release.CreatedAt,
+	}
+	if req.HostedArtifact {
+		resp.UploadPath = models.ArtifactUploadPath(release.ApplicationID, release.ID)
+		resp.DownloadURL = release.DownloadURL
+	}
+	return resp, nil
+}
+
+// autoFill fetches the artifact of a registration to fill in the checksum and
+// file size it omits. A file size that was given must match the artifact.
+func (s *Service) autoFill(ctx context.Context, req *models.RegisterReleaseRequest) error {
+	if s.artifacts == nil {
+		return NewValidationError("auto_fill is not enabled on this server", nil)
+	}
+	if req.Checksum == "" {
+		size, checksum, err := s.artifacts.Digest(ctx, req.DownloadURL, req.ChecksumType)
+		if err != nil {
+			return NewValidationError(fmt.Sprintf("auto_fill could not fetch the artifact: %v", err), err)
+		}
+		if req.FileSize != 0 && req.FileSize != size {
+			return NewValidationError(fmt.Sprintf("file_size %d does not match the %d bytes fetched", req.FileSize, size), nil)
+		}
+		req.Checksum, req.FileSize = checksum, size
+		return nil
+	}
+	if req.FileSize == 0 {
+		size, err := s.artifacts.Size(ctx, req.DownloadURL)
+		if err != nil {
+			return NewValidationError(fmt.Sprintf("auto_fill could not fetch the artifact: %v", err), err)
+		}
+		req.FileSize = size
+	}
+	return nil
+}
+
+// ApplyReleaseDesiredState registers a release unless an identical one is
+// already stored, so applying the same release again is a no-op.
+func (s *Service) ApplyReleaseDesiredState(ctx context.Context, req *models.RegisterReleaseRequest) (*models.ApplyDesiredStateResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+	if req.AutoFill {
+		// The state must describe the release fully to be compared
+		return nil, NewValidationError("auto_fill is not supported in desired state; give the checksum and file size", nil)
+	}
+	if req.HostedArtifact {
+		return nil, NewValidationError("hosted_artifact is not supported in desired state; register the release and upload its artifact", nil)
+	}
+	req.Normalize()
+
+	app, err := s.storage.GetApplication(ctx, req.ApplicationID)
+	if err != nil {
+		return nil, NewApplicationNotFoundError(req.ApplicationID)
+	}
+	// Compare against the release RegisterRelease would store
+	if req.ReleaseNotes == "" {
+		req.ReleaseNotes = app.Config.ReleaseNotesTemplate
+	}
+	desired, err := s.newReleaseFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []string{}
+	existing, err := s.storage.GetRelease(ctx, req.ApplicationID, req.Version, req.Platform, req.Architecture)
+	if err == nil {
+		changes = append(changes, models.ReleaseStateChanges(existing, desired)...)
+		if len(changes) == 0 {
+			return &models.ApplyDesiredStateResponse{
+				ID:      existing.ID,
+				Changes: changes,
+				Message: fmt.Sprintf("Release %s already matches the desired state", existing.Version),
+			}, nil
+		}
+	}
+
+	registered, err := s.RegisterRelease(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return &models.ApplyDesiredStateResponse{
+			ID:      registered.ID,
+			Created: true,
+			Changes: changes,
+			Message: fmt.Sprintf("Release %s created from the desired state", desired.Version),
+		}, nil
+	}
+	return &models.ApplyDesiredStateResponse{
+		ID:      registered.ID,
+		Changes: changes,
+		Message: fmt.Sprintf("Release %s updated to the desired state", desired.Version),
+	}, nil
+}
+
+// checkDownloadURLs applies the service and application download URL policies
+// to a release's download URL and those of its edition and variant artifacts.
+func (s *Service) checkDownloadURLs(app *models.Application, req *models.RegisterReleaseRequest) error {
+	var urls []string
+	// The server generates the URL of a hosted artifact
+	if !req.HostedArtifact {
+		urls = append(urls, req.DownloadURL)
+	}
+	for _, edition := range slices.Sorted(maps.Keys(req.Editions)) {
+		urls = append(urls, req.Editions[edition].DownloadURL)
+	}
+	for _, variant := range slices.Sorted(maps.Keys(req.Variants)) {
+		urls = append(urls, req.Variants[variant].DownloadURL)
+	}
+	for _, downloadURL := range urls {
+		if err := s.urlPolicy.Check(downloadURL); err != nil {
+			return err
+		}
+		if err := app.Config.CheckDownloadURL(downloadURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkChecksumTypes rejects deprecated checksum types for new releases when
+// the service is configured to, including additional checksums and those of
+// edition and variant artifacts.
+func (s *Service) checkChecksumTypes(req *models.RegisterReleaseRequest) error {
+	if !s.rejectWeakChecksums {
+		return nil
+	}
+	types := []string{req.ChecksumType}
+	for checksumType := range req.Checksums {
+		types = append(types, checksumType)
+	}
+	sort.Strings(types[1:])
+	for _, edition := range slices.Sorted(maps.Keys(req.Editions)) {
+		types = append(types, req.Editions[edition].ChecksumType)
+	}
+	for _, variant := range slices.Sorted(maps.Keys(req.Variants)) {
+		types = append(types, req.Variants[variant].ChecksumType)
+	}
+	for _, checksumType := range types {
+		if models.IsWeakChecksumType(checksumType) {
+			return NewValidationError(fmt.Sprintf("checksum_type %s is deprecated; use sha256, sha512 or blake3", checksumType), nil)
+		}
+	}
+	return nil
+}
+
+// IngestReleaseManifest registers every artifact of a CI release manifest as a
+// release of the same version. All releases are validated before any is saved,
+// and they are saved atomically, so a rejected or failed manifest leaves no
+// partial version behind. Re-ingesting a manifest overwrites the releases it
+// created, which makes CI retries safe.
+func (s *Service) IngestReleaseManifest(ctx context.Context, manifest *models.ReleaseManifest) (*models.IngestManifestResponse, error) {
+	if err := manifest.Validate(); err != nil {
+		return nil, NewValidationError("invalid manifest", err)
+	}
+
+	app, err := s.storage.GetApplication(ctx, strings.TrimSpace(manifest.ApplicationID))
+	if err != nil {
+		return nil, NewApplicationNotFoundError(manifest.ApplicationID)
+	}
+
+	reqs := manifest.RegisterRequests()
+	releases := make([]*models.Release, len(reqs))
+	for i := range reqs {
+		req := &reqs[i]
+		req.Normalize()
+		if !app.SupportsPlatform(req.Platform) {
+			return nil, NewInvalidRequestError(fmt.Sprintf("application %s does not support platform %s", app.ID, req.Platform), nil)
+		}
+		if err := s.checkDownloadURLs(app, req); err != nil {
+			return nil, NewValidationError(fmt.Sprintf("%s-%s: %v", req.Platform, req.Architecture, err), err)
+		}
+		if err := s.checkChecksumTypes(req); err != nil {
+			return nil, err
+		}
+		if req.ReleaseNotes == "" {
+			req.ReleaseNotes = app.Config.ReleaseNotesTemplate
+		}
+		release, err := s.newReleaseFromRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkFreeze(ctx, app, release.Channel); err != nil {
+			return nil, err
+		}
+		release.StatusChecks = models.NewStatusChecks(app.Config.RequiredChecks, release.CreatedAt)
+		s.keepReleaseState(ctx, release)
+		releases[i] = release
+	}
+
+	if err := s.storage.SaveReleases(ctx, releases); err != nil {
+		return nil, NewInternalError("failed to save releases", err)
+	}
+	for _, release := range releases {
+		s.publishRelease(events.ReleasePublished, release)
+	}
+
+	resp := &models.IngestManifestResponse{
+		ApplicationID: app.ID,
+		Version:       releases[0].Version,
+		Releases:      make([]models.ReleaseInfo, len(releases)),
+		Message:       fmt.Sprintf("Release %s registered for %d platform(s)", releases[0].Version, len(releases)),
+	}
+	for i, release := range releases {
+		resp.Releases[i].FromRelease(release)
+	}
+	return resp, nil
+}
+
+// keepReleaseState gives a release that replaces a stored one the stored
+// release's ID, pause state, yank and status checks, so that registering a
+// release again neither changes its ID nor resumes its rollout, offers it
+// again or resets its checks.
+func (s *Service) keepReleaseState(ctx context.Context, release *models.Release) {
+	if existing, err := s.storage.GetRelease(ctx, release.ApplicationID, release.Version, release.Platform, release.Architecture); err == nil {
+		release.ID = existing.ID
+		release.Paused = existing.Paused
+		release.Yanked = existing.Yanked
+		release.YankReason = existing.YankReason
+		release.StatusChecks = existing.StatusChecks
+	}
+}
+
+// newReleaseFromRequest builds and validates a release from a normalized
+// registration request.
+func (s *Service) newReleaseFromRequest(req *models.RegisterReleaseRequest) (*models.Release, error) {
 	release := models.NewRelease(req.ApplicationID, req.Version, req.Platform, req.Architecture, req.DownloadURL)
+	now := s.now()
+	release.ID = s.newID(now)
+	release.ReleaseDate, release.CreatedAt, release.UpdatedAt = now, now, now
 	release.Checksum = req.Checksum
 	release.ChecksumType = req.ChecksumType
+	release.Checksums = models.NormalizeChecksums(req.Checksums)
+	release.PGPSignature = req.PGPSignature
+	release.RequiredEntitlement = req.RequiredEntitlement
+	release.Editions = req.Editions
+	release.Channel = req.Channel
+	if release.Channel == "" {
+		release.Channel = models.DefaultChannel(req.Version)
+	}
+	release.Targeting = req.Targeting
+	release.Variant = req.Variant
+	release.Variants = req.Variants
+	release.VersionCode = req.VersionCode
+	release.SigningCertSHA256 = req.SigningCertSHA256
 	release.FileSize = req.FileSize
 	release.ReleaseNotes = req.ReleaseNotes
 	release.Required = req.Required
 	release.MinimumVersion = req.MinimumVersion
+	release.Tags = req.Tags
+	release.HostVersionConstraint = req.HostVersionConstraint
+
+	if req.HostedArtifact {
+		release.DownloadURL = s.artifactStore.URL(artifactKey(release))
+		release.ArtifactPending = true
+	}
 
 	// Copy metadata
 	if req.Metadata != nil {
@@ -295,25 +1259,23 @@ func (s *Service) RegisterRelease(ctx context.Context, req *models.RegisterRelea
 		}
 	}
 
-	// Validate the created release
 	if err := release.Validate(); err != nil {
 		return nil, NewValidationError("invalid release", err)
 	}
-
-	// Save the release
-	if err := s.storage.SaveRelease(ctx, release); err != nil {
-		return nil, NewInternalError("failed to save release", err)
-	}
-
-	return &models.RegisterReleaseResponse{
-		ID:        release.ID,
-		Message:   fmt.Sprintf("Release %s registered successfully", release.Version),
-		CreatedAt: release.CreatedAt,
-	}, nil
+	return release, nil
 }
 
 // CreateApplication creates a new application after validating and normalizing the request.
 func (s *Service) CreateApplication(ctx context.Context, req *models.CreateApplicationRequest) (*models.CreateApplicationResponse, error) {
+	// Fill in defaults from the selected template before validating
+	if req.Template != "" {
+		template := s.findTemplate(strings.TrimSpace(req.Template))
+		if template == nil {
+			return nil, NewValidationError("invalid request", fmt.Errorf("unknown template: %s", req.Template))
+		}
+		template.Apply(req)
+	}
+
 	// Validate and normalize request
 	if err := req.Validate(); err != nil {
 		return nil, NewValidationError("invalid request", err)
@@ -325,11 +1287,36 @@ func (s *Service) CreateApplication(ctx context.Context, req *models.CreateAppli
 		return nil, NewConflictError(fmt.Sprintf("application '%s' already exists", req.ID))
 	}
 
+	// Neither the ID nor the slug may already resolve to another application
+	slug := req.Slug
+	if slug == "" {
+		slug = req.ID
+	}
+	if err := s.checkSlugFree(ctx, req.ID, req.ID); err != nil {
+		return nil, err
+	}
+	if slug != req.ID {
+		if err := s.checkSlugFree(ctx, slug, req.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Verify the parent when registering a plugin
+	if req.ParentID != "" {
+		if err := s.validateParent(ctx, req.ID, req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create application with defaults
 	app := models.NewApplication(req.ID, req.Name, req.Platforms)
 	app.Description = req.Description
 	app.Config = req.Config
-	now := time.Now().Format(time.RFC3339)
+	app.Tags = req.Tags
+	app.Group = req.Group
+	app.ParentID = req.ParentID
+	app.Slug = slug
+	now := s.now()
 	app.CreatedAt = now
 	app.UpdatedAt = now
 
@@ -337,13 +1324,193 @@ func (s *Service) CreateApplication(ctx context.Context, req *models.CreateAppli
 	if err := s.storage.SaveApplication(ctx, app); err != nil {
 		return nil, NewInternalError("failed to save application", err)
 	}
+	s.publishApplication(events.ApplicationCreated, app.ID)
 
-	// app.CreatedAt was set via time.Now().Format(time.RFC3339) two lines above and is guaranteed valid.
-	createdAt, _ := time.Parse(time.RFC3339, app.CreatedAt)
 	return &models.CreateApplicationResponse{
 		ID:        app.ID,
 		Message:   fmt.Sprintf("Application '%s' created successfully", app.ID),
-		CreatedAt: createdAt,
+		CreatedAt: app.CreatedAt,
+	}, nil
+}
+
+// ListApplicationTemplates returns the configured application templates.
+func (s *Service) ListApplicationTemplates(ctx context.Context) (*models.ListApplicationTemplatesResponse, error) {
+	templates := s.templates
+	if templates == nil {
+		templates = []models.ApplicationTemplate{}
+	}
+	return &models.ListApplicationTemplatesResponse{Templates: templates}, nil
+}
+
+// findTemplate returns the template with the given name, or nil.
+func (s *Service) findTemplate(name string) *models.ApplicationTemplate {
+	for i := range s.templates {
+		if s.templates[i].Name == name {
+			return &s.templates[i]
+		}
+	}
+	return nil
+}
+
+// CloneApplication copies an application's platforms, description, config,
+// tags, group and parent to a new ID, and optionally the releases of its most
+// recent versions. The releases are saved atomically after the application;
+// if they cannot be saved the new application is removed again so a failed
+// clone leaves nothing behind.
+func (s *Service) CloneApplication(ctx context.Context, sourceID string, req *models.CloneApplicationRequest) (*models.CloneApplicationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+	req.Normalize()
+
+	source, err := s.storage.GetApplication(ctx, sourceID)
+	if err != nil {
+		return nil, NewApplicationNotFoundError(sourceID)
+	}
+
+	if _, err := s.storage.GetApplication(ctx, req.ID); err == nil {
+		return nil, NewConflictError(fmt.Sprintf("application '%s' already exists", req.ID))
+	}
+	if err := s.checkSlugFree(ctx, req.ID, req.ID); err != nil {
+		return nil, err
+	}
+
+	// The clone's slug is its own ID; the source's slugs stay with the source
+	app := models.NewApplication(req.ID, source.Name, append([]string(nil), source.Platforms...))
+	app.Slug = app.ID
+	if req.Name != "" {
+		app.Name = req.Name
+	}
+	app.Description = source.Description
+	app.Config = source.Config
+	app.Config.CustomFields = make(map[string]string, len(source.Config.CustomFields))
+	for k, v := range source.Config.CustomFields {
+		app.Config.CustomFields[k] = v
+	}
+	if source.Config.OTA != nil {
+		ota := *source.Config.OTA
+		app.Config.OTA = &ota
+	}
+	app.Tags = append([]string{}, source.Tags...)
+	app.Group = source.Group
+	app.ParentID = source.ParentID
+	now := s.now()
+	app.CreatedAt = now
+	app.UpdatedAt = now
+
+	var releases []*models.Release
+	if req.Releases > 0 {
+		releases, err = s.recentReleases(ctx, sourceID, req.Releases)
+		if err != nil {
+			return nil, err
+		}
+		for i, release := range releases {
+			releases[i] = cloneRelease(release, app.ID, s.newID(now), now)
+		}
+	}
+
+	if err := s.storage.SaveApplication(ctx, app); err != nil {
+		return nil, NewInternalError("failed to save application", err)
+	}
+	if len(releases) > 0 {
+		if err := s.storage.SaveReleases(ctx, releases); err != nil {
+			delErr := s.storage.DeleteApplication(ctx, app.ID)
+			return nil, NewInternalError("failed to save releases", errors.Join(err, delErr))
+		}
+	}
+	s.publishApplication(events.ApplicationCreated, app.ID)
+	for _, release := range releases {
+		s.publishRelease(events.ReleasePublished, release)
+	}
+
+	return &models.CloneApplicationResponse{
+		ID:             app.ID,
+		SourceID:       source.ID,
+		ReleasesCopied: len(releases),
+		Message:        fmt.Sprintf("Application '%s' cloned to '%s'", source.ID, app.ID),
+		CreatedAt:      app.CreatedAt,
+	}, nil
+}
+
+// recentReleases returns every release of an application's n most recently
+// released versions. Only the newest page of releases is considered.
+func (s *Service) recentReleases(ctx context.Context, appID string, n int) ([]*models.Release, error) {
+	releases, _, err := s.storage.ListReleasesPaged(ctx, appID, models.ReleaseFilters{}, "release_date", "desc", models.MaxPageSize, nil)
+	if err != nil {
+		return nil, NewInternalError("failed to get releases", err)
+	}
+
+	versions := make(map[string]bool, n)
+	var recent []*models.Release
+	for _, release := range releases {
+		if !versions[release.Version] {
+			if len(versions) == n {
+				continue
+			}
+			versions[release.Version] = true
+		}
+		recent = append(recent, release)
+	}
+	return recent, nil
+}
+
+// cloneRelease copies a release to another application under a new ID,
+// keeping its release date but starting a new audit trail.
+func cloneRelease(release *models.Release, appID, id string, now time.Time) *models.Release {
+	clone := *release
+	clone.ID = id
+	clone.ApplicationID = appID
+	clone.CreatedAt = now
+	clone.UpdatedAt = now
+	clone.Tags = append([]string{}, release.Tags...)
+	clone.StatusChecks = maps.Clone(release.StatusChecks)
+	if release.Metadata != nil {
+		clone.Metadata = make(map[string]string, len(release.Metadata))
+		for k, v := range release.Metadata {
+			clone.Metadata[k] = v
+		}
+	}
+	return &clone
+}
+
+// GetIntegrationSnippets renders ready-to-paste client snippets for an
+// application. The platform defaults to the application's first platform and
+// the architecture to amd64.
+func (s *Service) GetIntegrationSnippets(ctx context.Context, req *models.IntegrationSnippetsRequest) (*models.IntegrationSnippetsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+	req.Normalize()
+
+	app, err := s.storage.GetApplication(ctx, req.ApplicationID)
+	if err != nil {
+		return nil, NewApplicationNotFoundError(req.ApplicationID)
+	}
+
+	if req.Platform == "" && len(app.Platforms) > 0 {
+		req.Platform = app.Platforms[0]
+	}
+	if !app.SupportsPlatform(req.Platform) {
+		return nil, NewInvalidRequestError(
+			fmt.Sprintf("application %s does not support platform %s", req.ApplicationID, req.Platform),
+			nil,
+		)
+	}
+	if req.Architecture == "" {
+		req.Architecture = models.ArchAMD64
+	}
+
+	snippets, err := models.BuildIntegrationSnippets(req, app)
+	if err != nil {
+		return nil, NewInternalError("failed to render snippets", err)
+	}
+
+	return &models.IntegrationSnippetsResponse{
+		ApplicationID: app.ID,
+		BaseURL:       req.BaseURL,
+		Platform:      req.Platform,
+		Architecture:  req.Architecture,
+		Snippets:      snippets,
 	}, nil
 }
 
@@ -359,30 +1526,22 @@ func (s *Service) GetApplication(ctx context.Context, appID string) (*models.App
 		return nil, NewInternalError("failed to get application stats", err)
 	}
 
-	var createdAt time.Time
-	if app.CreatedAt != "" {
-		createdAt, err = time.Parse(time.RFC3339, app.CreatedAt)
-		if err != nil {
-			return nil, NewInternalError(fmt.Sprintf("corrupt created_at for application %s", appID), err)
-		}
-	}
-	var updatedAt time.Time
-	if app.UpdatedAt != "" {
-		updatedAt, err = time.Parse(time.RFC3339, app.UpdatedAt)
-		if err != nil {
-			return nil, NewInternalError(fmt.Sprintf("corrupt updated_at for application %s", appID), err)
-		}
-	}
-
 	return &models.ApplicationInfoResponse{
 		ID:          app.ID,
 		Name:        app.Name,
 		Description: app.Description,
 		Platforms:   app.Platforms,
 		Config:      app.Config,
+		Tags:        app.Tags,
+		Group:       app.Group,
+		ParentID:    app.ParentID,
+		Slug:        app.Slug,
+		FormerSlugs: app.FormerSlugs,
 		Stats:       stats,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+		Schedule:    models.NewCheckSchedule(app.Config),
+		Bandwidth:   s.bandwidthUsage(app),
+		CreatedAt:   app.CreatedAt,
+		UpdatedAt:   app.UpdatedAt,
 	}, nil
 }
 
@@ -402,7 +1561,7 @@ func (s *Service) ListApplications(ctx context.Context, req *models.ListApplicat
 		}
 	}
 
-	apps, totalCount, err := s.storage.ListApplicationsPaged(ctx, req.Limit, cursor)
+	apps, totalCount, err := s.storage.ListApplicationsPaged(ctx, models.ApplicationFilters{Tags: req.Tags, Group: req.Group}, req.Limit, cursor)
 	if err != nil {
 		return nil, NewInternalError("failed to list applications", err)
 	}
@@ -410,19 +1569,6 @@ func (s *Service) ListApplications(ctx context.Context, req *models.ListApplicat
 	summaries := make([]models.ApplicationSummary, len(apps))
 	for i, app := range apps {
 		summaries[i].FromApplication(app)
-		var parseErr error
-		if app.CreatedAt != "" {
-			summaries[i].CreatedAt, parseErr = time.Parse(time.RFC3339, app.CreatedAt)
-			if parseErr != nil {
-				return nil, NewInternalError(fmt.Sprintf("corrupt created_at for application %s", app.ID), parseErr)
-			}
-		}
-		if app.UpdatedAt != "" {
-			summaries[i].UpdatedAt, parseErr = time.Parse(time.RFC3339, app.UpdatedAt)
-			if parseErr != nil {
-				return nil, NewInternalError(fmt.Sprintf("corrupt updated_at for application %s", app.ID), parseErr)
-			}
-		}
 	}
 
 	var nextCursor string
@@ -446,6 +1592,25 @@ func (s *Service) ListApplications(ctx context.Context, req *models.ListApplicat
 	}, nil
 }
 
+// ListApplicationGroups returns the named application groups with their member
+// counts, sorted by name, along with the number of ungrouped applications.
+func (s *Service) ListApplicationGroups(ctx context.Context) (*models.ListApplicationGroupsResponse, error) {
+	groups, err := s.storage.ListApplicationGroups(ctx)
+	if err != nil {
+		return nil, NewInternalError("failed to list application groups", err)
+	}
+
+	resp := &models.ListApplicationGroupsResponse{Groups: make([]models.ApplicationGroup, 0, len(groups))}
+	for _, g := range groups {
+		if g.Name == "" {
+			resp.Ungrouped = g.ApplicationCount
+			continue
+		}
+		resp.Groups = append(resp.Groups, g)
+	}
+	return resp, nil
+}
+
 // UpdateApplication applies partial updates to an existing application.
 func (s *Service) UpdateApplication(ctx context.Context, appID string, req *models.UpdateApplicationRequest) (*models.UpdateApplicationResponse, error) {
 	// Validate and normalize request
@@ -473,15 +1638,36 @@ func (s *Service) UpdateApplication(ctx context.Context, appID string, req *mode
 	if req.Config != nil {
 		app.Config = *req.Config
 	}
+	if req.Tags != nil {
+		app.Tags = req.Tags
+	}
+	if req.Group != nil {
+		app.Group = *req.Group
+	}
+	if req.ParentID != nil && *req.ParentID != app.ParentID {
+		if *req.ParentID != "" {
+			if err := s.validateParent(ctx, app.ID, *req.ParentID); err != nil {
+				return nil, err
+			}
+		}
+		app.ParentID = *req.ParentID
+	}
+	if req.Slug != nil && *req.Slug != app.Slug {
+		if err := s.checkSlugFree(ctx, *req.Slug, app.ID); err != nil {
+			return nil, err
+		}
+		app.Rename(*req.Slug)
+	}
 
 	// Update timestamp
-	now := time.Now()
-	app.UpdatedAt = now.Format(time.RFC3339)
+	now := s.now()
+	app.UpdatedAt = now
 
 	// Save updated application
 	if err := s.storage.SaveApplication(ctx, app); err != nil {
 		return nil, NewInternalError("failed to save application", err)
 	}
+	s.publishApplication(events.ApplicationUpdated, app.ID)
 
 	return &models.UpdateApplicationResponse{
 		ID:        app.ID,
@@ -490,13 +1676,106 @@ func (s *Service) UpdateApplication(ctx context.Context, appID string, req *mode
 	}, nil
 }
 
-// DeleteApplication removes an application that has no existing releases.
+// checkSlugFree returns a conflict error when slug already resolves to an
+// application other than appID, as its ID or as a current or former slug.
+func (s *Service) checkSlugFree(ctx context.Context, slug, appID string) error {
+	if app, err := s.storage.GetApplication(ctx, slug); err == nil && app.ID != appID {
+		return NewConflictError(fmt.Sprintf("'%s' is the ID of application '%s'", slug, app.ID))
+	}
+	if app, err := s.storage.GetApplicationBySlug(ctx, slug); err == nil && app.ID != appID {
+		return NewConflictError(fmt.Sprintf("slug '%s' is taken by application '%s'", slug, app.ID))
+	}
+	return nil
+}
+
+// ApplyApplicationDesiredState creates or replaces an application so that it
+// matches a desired state document. The application is only saved when it
+// differs from the document, so applying the same document again is a no-op
+// that leaves UpdatedAt alone.
+func (s *Service) ApplyApplicationDesiredState(ctx context.Context, appID string, state *models.ApplicationDesiredState) (*models.ApplyDesiredStateResponse, error) {
+	state.Normalize()
+	desired := state.Application(appID)
+	if err := desired.Validate(); err != nil {
+		return nil, NewValidationError("invalid desired state", err)
+	}
+
+	// As in CreateApplication, an application that cannot be read is created
+	existing, err := s.storage.GetApplication(ctx, appID)
+	if err != nil {
+		existing = nil
+	}
+
+	changes := []string{}
+	if existing != nil {
+		changes = append(changes, models.DesiredStateChanges(existing, desired)...)
+		if len(changes) == 0 {
+			return &models.ApplyDesiredStateResponse{
+				ID:      appID,
+				Changes: changes,
+				Message: fmt.Sprintf("Application '%s' already matches the desired state", appID),
+			}, nil
+		}
+	}
+
+	// Slugs are not part of the document and are kept as they are
+	if existing != nil {
+		desired.Slug, desired.FormerSlugs = existing.Slug, existing.FormerSlugs
+	} else {
+		if err := s.checkSlugFree(ctx, appID, appID); err != nil {
+			return nil, err
+		}
+		desired.Slug = appID
+	}
+
+	if desired.ParentID != "" && (existing == nil || desired.ParentID != existing.ParentID) {
+		if err := s.validateParent(ctx, appID, desired.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	desired.CreatedAt = now
+	if existing != nil {
+		desired.CreatedAt = existing.CreatedAt
+	}
+	desired.UpdatedAt = now
+	if err := s.storage.SaveApplication(ctx, desired); err != nil {
+		return nil, NewInternalError("failed to save application", err)
+	}
+
+	if existing == nil {
+		s.publishApplication(events.ApplicationCreated, appID)
+		return &models.ApplyDesiredStateResponse{
+			ID:      appID,
+			Created: true,
+			Changes: changes,
+			Message: fmt.Sprintf("Application '%s' created from the desired state", appID),
+		}, nil
+	}
+	s.publishApplication(events.ApplicationUpdated, appID)
+	return &models.ApplyDesiredStateResponse{
+		ID:      appID,
+		Changes: changes,
+		Message: fmt.Sprintf("Application '%s' updated to the desired state", appID),
+	}, nil
+}
+
+// DeleteApplication removes an application that has no existing releases or plugins.
 func (s *Service) DeleteApplication(ctx context.Context, appID string) error {
 	// Verify application exists
 	if _, err := s.storage.GetApplication(ctx, appID); err != nil {
 		return NewApplicationNotFoundError(appID)
 	}
 
+	// Plugins must be deleted or detached before their host
+	hosts, err := s.hasPlugins(ctx, appID)
+	if err != nil {
+		return NewInternalError("failed to list plugins", err)
+	}
+	if hosts {
+		return NewConflictError(fmt.Sprintf("cannot delete application '%s': has plugins", appID))
+	}
+
 	// Delete application
 	if err := s.storage.DeleteApplication(ctx, appID); err != nil {
 		if errors.Is(err, storage.ErrHasDependencies) {
@@ -504,10 +1783,23 @@ func (s *Service) DeleteApplication(ctx context.Context, appID string) error {
 		}
 		return NewInternalError("failed to delete application", err)
 	}
+	s.publishApplication(events.ApplicationDeleted, appID)
 
 	return nil
 }
 
+// GetReleaseSignature returns the detached OpenPGP signature of a release.
+func (s *Service) GetReleaseSignature(ctx context.Context, appID, version, platform, arch string) (string, error) {
+	release, err := s.storage.GetRelease(ctx, appID, version, platform, arch)
+	if err != nil {
+		return "", NewNotFoundError(fmt.Sprintf("release '%s-%s-%s-%s' not found", appID, version, platform, arch))
+	}
+	if release.PGPSignature == "" {
+		return "", NewNotFoundError(fmt.Sprintf("release '%s' has no signature", release.ID))
+	}
+	return release.PGPSignature, nil
+}
+
 // DeleteRelease removes a specific release.
 func (s *Service) DeleteRelease(ctx context.Context, appID, version, platform, arch string) (*models.DeleteReleaseResponse, error) {
 	// Verify release exists
@@ -520,6 +1812,8 @@ func (s *Service) DeleteRelease(ctx context.Context, appID, version, platform, a
 	if err := s.storage.DeleteRelease(ctx, appID, version, platform, arch); err != nil {
 		return nil, NewInternalError("failed to delete release", err)
 	}
+	s.publishRelease(events.ReleaseDeleted, release)
+	s.deleteHostedArtifact(ctx, release)
 
 	return &models.DeleteReleaseResponse{
 		ID:      release.ID,
@@ -2,10 +2,13 @@ package update
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 	"time"
+	"updater/internal/events"
 	"updater/internal/models"
 	"updater/internal/storage"
 
@@ -18,23 +21,40 @@ import (
 type MockStorage struct {
 	applications map[string]*models.Application
 	releases     map[string][]*models.Release
+	images       map[string][]*models.ContainerImage
+
+	getApplicationErr error
+	saveReleasesErr   error
 }
 
 func NewMockStorage() *MockStorage {
 	return &MockStorage{
 		applications: make(map[string]*models.Application),
 		releases:     make(map[string][]*models.Release),
+		images:       make(map[string][]*models.ContainerImage),
 	}
 }
 
 func (m *MockStorage) GetApplication(ctx context.Context, appID string) (*models.Application, error) {
+	if m.getApplicationErr != nil {
+		return nil, m.getApplicationErr
+	}
 	app, exists := m.applications[appID]
 	if !exists {
-		return nil, fmt.Errorf("application %s not found", appID)
+		return nil, fmt.Errorf("application %s %w", appID, storage.ErrNotFound)
 	}
 	return app, nil
 }
 
+func (m *MockStorage) GetApplicationBySlug(ctx context.Context, slug string) (*models.Application, error) {
+	for _, app := range m.applications {
+		if app.HasSlug(slug) {
+			return app, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
 func (m *MockStorage) SaveApplication(ctx context.Context, app *models.Application) error {
 	m.applications[app.ID] = app
 	return nil
@@ -65,6 +85,17 @@ func (m *MockStorage) GetRelease(ctx context.Context, appID, version, platform,
 	return nil, fmt.Errorf("release not found")
 }
 
+func (m *MockStorage) GetReleaseByID(ctx context.Context, id string) (*models.Release, error) {
+	for _, releases := range m.releases {
+		for _, release := range releases {
+			if release.ID == id {
+				return release, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("release not found")
+}
+
 func (m *MockStorage) SaveRelease(ctx context.Context, release *models.Release) error {
 	if m.releases[release.ApplicationID] == nil {
 		m.releases[release.ApplicationID] = make([]*models.Release, 0)
@@ -73,6 +104,18 @@ func (m *MockStorage) SaveRelease(ctx context.Context, release *models.Release)
 	return nil
 }
 
+func (m *MockStorage) SaveReleases(ctx context.Context, releases []*models.Release) error {
+	if m.saveReleasesErr != nil {
+		return m.saveReleasesErr
+	}
+	for _, release := range releases {
+		if err := m.SaveRelease(ctx, release); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MockStorage) DeleteRelease(ctx context.Context, appID, version, platform, arch string) error {
 	releases, exists := m.releases[appID]
 	if !exists {
@@ -161,9 +204,18 @@ func (m *MockStorage) DeleteAPIKey(_ context.Context, _ string) error {
 	return nil
 }
 
-func (m *MockStorage) ListApplicationsPaged(_ context.Context, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
+func (m *MockStorage) ListApplicationsPaged(_ context.Context, filters models.ApplicationFilters, limit int, cursor *models.ApplicationCursor) ([]*models.Application, int, error) {
 	apps := make([]*models.Application, 0, len(m.applications))
 	for _, app := range m.applications {
+		if !models.HasAllTags(app.Tags, filters.Tags) {
+			continue
+		}
+		if filters.Group != "" && app.Group != filters.Group {
+			continue
+		}
+		if filters.ParentID != "" && app.ParentID != filters.ParentID {
+			continue
+		}
 		copied := *app
 		apps = append(apps, &copied)
 	}
@@ -188,6 +240,19 @@ func (m *MockStorage) ListApplicationsPaged(_ context.Context, limit int, cursor
 	return apps[start:end], total, nil
 }
 
+func (m *MockStorage) ListApplicationGroups(_ context.Context) ([]models.ApplicationGroup, error) {
+	counts := make(map[string]int)
+	for _, app := range m.applications {
+		counts[app.Group]++
+	}
+	groups := make([]models.ApplicationGroup, 0, len(counts))
+	for name, n := range counts {
+		groups = append(groups, models.ApplicationGroup{Name: name, ApplicationCount: n})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
 func (m *MockStorage) ListReleasesPaged(_ context.Context, appID string, filters models.ReleaseFilters, sortBy, sortOrder string, limit int, cursor *models.ReleaseCursor) ([]*models.Release, int, error) {
 	var filtered []*models.Release
 	for _, r := range m.releases[appID] {
@@ -212,6 +277,9 @@ func (m *MockStorage) ListReleasesPaged(_ context.Context, appID string, filters
 				continue
 			}
 		}
+		if !models.HasAllTags(r.Tags, filters.Tags) {
+			continue
+		}
 		copied := *r
 		filtered = append(filtered, &copied)
 	}
@@ -262,6 +330,33 @@ func (m *MockStorage) GetLatestStableRelease(_ context.Context, appID, platform,
 	return latest, nil
 }
 
+func (m *MockStorage) SaveContainerImage(_ context.Context, image *models.ContainerImage) error {
+	images := m.images[image.ApplicationID]
+	for i, existing := range images {
+		if existing.Tag == image.Tag {
+			images[i] = image
+			return nil
+		}
+	}
+	m.images[image.ApplicationID] = append(images, image)
+	return nil
+}
+
+func (m *MockStorage) ListContainerImages(_ context.Context, appID string) ([]*models.ContainerImage, error) {
+	return append([]*models.ContainerImage(nil), m.images[appID]...), nil
+}
+
+func (m *MockStorage) DeleteContainerImage(_ context.Context, appID, tag string) error {
+	images := m.images[appID]
+	for i, image := range images {
+		if image.Tag == tag {
+			m.images[appID] = append(images[:i], images[i+1:]...)
+			return nil
+		}
+	}
+	return storage.ErrNotFound
+}
+
 func (m *MockStorage) GetApplicationStats(_ context.Context, appID string) (models.ApplicationStats, error) {
 	releases := m.releases[appID]
 	stats := models.ApplicationStats{TotalReleases: len(releases)}
@@ -404,6 +499,75 @@ func TestService_CheckForUpdate(t *testing.T) {
 	}
 }
 
+func TestService_CheckForUpdate_ReportsAnomalies(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+
+	var reported []string
+	ctx := WithAnomalyReporter(context.Background(), func(kind, appID string) {
+		reported = append(reported, kind+":"+appID)
+	})
+
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}})
+	mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("test-app", "1.1.0", "windows", "amd64"))
+
+	check := func(appID, version string) {
+		service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: appID, CurrentVersion: version, Platform: "windows", Architecture: "amd64",
+		})
+	}
+	check("test-app", "1.0.0")
+	check("test-app", "1.1.0")
+	assert.Empty(t, reported, "ordinary checks report nothing")
+
+	check("test-app", "9.0.0")
+	check("missing-app", "1.0.0")
+	assert.Equal(t, []string{
+		models.AnomalyFutureVersion + ":test-app",
+		models.AnomalyUnknownApplication + ":missing-app",
+	}, reported)
+}
+
+func TestService_CheckForUpdate_StorageErrorIsNotAnomaly(t *testing.T) {
+	mockStorage := NewMockStorage()
+	mockStorage.getApplicationErr = errors.New("connection refused")
+	service := NewService(mockStorage)
+
+	var reported []string
+	ctx := WithAnomalyReporter(context.Background(), func(kind, appID string) {
+		reported = append(reported, kind+":"+appID)
+	})
+
+	_, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "test-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+	})
+	assert.Error(t, err)
+	assert.Empty(t, reported, "a storage outage is not the client's doing")
+}
+
+func TestService_BatchCheckForUpdates_Validation(t *testing.T) {
+	service := NewService(NewMockStorage())
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		checks int
+	}{
+		{name: "empty batch", checks: 0},
+		{name: "too many checks", checks: models.MaxBatchChecks + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &models.BatchUpdateCheckRequest{Checks: make([]models.UpdateCheckRequest, tt.checks)}
+			_, err := service.BatchCheckForUpdates(ctx, req)
+			var serviceErr *ServiceError
+			require.ErrorAs(t, err, &serviceErr)
+			assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+		})
+	}
+}
+
 func TestService_CheckForUpdate_PreRelease(t *testing.T) {
 	mockStorage := NewMockStorage()
 	service := NewService(mockStorage)
@@ -491,6 +655,301 @@ func TestService_GetLatestVersion(t *testing.T) {
 	assert.NotEmpty(t, response.DownloadURL)
 }
 
+func TestService_GetLatestStableVersion(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows", "linux"}})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "empty-app", Name: "Empty App", Platforms: []string{"linux"}})
+	for _, r := range []*models.Release{
+		createTestReleaseForUpdate("test-app", "1.0.0", "windows", "amd64"),
+		createTestReleaseForUpdate("test-app", "1.3.0", "linux", "arm64"),
+		createTestReleaseForUpdate("test-app", "2.0.0-rc.1", "windows", "amd64"),
+	} {
+		mockStorage.SaveRelease(ctx, r)
+	}
+	paused := createTestReleaseForUpdate("test-app", "1.4.0", "linux", "arm64")
+	paused.Paused = true
+	mockStorage.SaveRelease(ctx, paused)
+
+	version, err := service.GetLatestStableVersion(ctx, "test-app")
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", version, "paused releases are not offered")
+
+	version, err = service.GetLatestStableVersion(ctx, "empty-app")
+	require.NoError(t, err)
+	assert.Empty(t, version)
+
+	_, err = service.GetLatestStableVersion(ctx, "missing-app")
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+}
+
+// setupPluginHost creates a "host" application with two plugins and
+// host-constrained releases for plugin-a on windows/amd64.
+func setupPluginHost(t *testing.T) (*Service, *MockStorage) {
+	t.Helper()
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "host", Name: "Host", Platforms: []string{"windows"}})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "plugin-a", Name: "Plugin A", Platforms: []string{"windows"}, ParentID: "host"})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "plugin-b", Name: "Plugin B", Platforms: []string{"linux"}, ParentID: "host"})
+
+	for _, r := range []struct{ version, constraint string }{
+		{"1.0.0", ">= 1.0.0, < 2.0.0"},
+		{"1.1.0", ">= 1.0.0, < 2.0.0"},
+		{"2.0.0", ">= 2.0.0"},
+		{"2.1.0-beta.1", ">= 2.0.0"},
+	} {
+		release := createTestReleaseForUpdate("plugin-a", r.version, "windows", "amd64")
+		release.HostVersionConstraint = r.constraint
+		mockStorage.SaveRelease(ctx, release)
+	}
+	return service, mockStorage
+}
+
+func TestService_CheckForUpdate_PluginHostCompatibility(t *testing.T) {
+	service, _ := setupPluginHost(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		hostVersion     string
+		allowPrerelease bool
+		wantAvailable   bool
+		wantVersion     string
+	}{
+		{name: "old host gets newest 1.x plugin", hostVersion: "1.5.0", wantAvailable: true, wantVersion: "1.1.0"},
+		{name: "new host gets newest stable plugin", hostVersion: "2.3.0", wantAvailable: true, wantVersion: "2.0.0"},
+		{name: "new host with prereleases", hostVersion: "2.3.0", allowPrerelease: true, wantAvailable: true, wantVersion: "2.1.0-beta.1"},
+		{name: "host too old for anything newer", hostVersion: "0.9.0", wantAvailable: false},
+		{name: "no host version ignores constraints", wantAvailable: true, wantVersion: "2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+				ApplicationID:   "plugin-a",
+				CurrentVersion:  "1.0.0",
+				Platform:        "windows",
+				Architecture:    "amd64",
+				HostVersion:     tt.hostVersion,
+				AllowPrerelease: tt.allowPrerelease,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAvailable, response.UpdateAvailable)
+			if tt.wantAvailable {
+				assert.Equal(t, tt.wantVersion, response.LatestVersion)
+			}
+		})
+	}
+}
+
+func TestService_DryRunCheckForUpdate(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}})
+	gated := createTestReleaseForUpdate("test-app", "1.1.0", "windows", "amd64")
+	gated.MinimumVersion = "1.0.5"
+	mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("test-app", "1.0.0", "windows", "amd64"))
+	mockStorage.SaveRelease(ctx, gated)
+	mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("test-app", "1.2.0-beta.1", "windows", "amd64"))
+
+	rules := func(trace []models.DecisionStep) []string {
+		var out []string
+		for _, step := range trace {
+			out = append(out, step.Rule+":"+step.Result)
+		}
+		return out
+	}
+
+	check := func(currentVersion, platform string) *models.UpdateCheckRequest {
+		return &models.UpdateCheckRequest{ApplicationID: "test-app", CurrentVersion: currentVersion, Platform: platform, Architecture: "amd64"}
+	}
+
+	t.Run("stable fallback offers update", func(t *testing.T) {
+		resp, err := service.DryRunCheckForUpdate(ctx, check("1.0.8", "windows"))
+		require.NoError(t, err)
+		assert.Equal(t, models.CheckDecisionUpdate, resp.Decision)
+		require.NotNil(t, resp.Result)
+		assert.Equal(t, "1.1.0", resp.Result.LatestVersion)
+		assert.Equal(t, []string{
+			"application:pass", "platform:pass", "latest_release:pass", "newer_version:pass",
+			"prerelease:fail", "stable_fallback:pass", "minimum_version:pass",
+		}, rules(resp.Trace))
+		assert.Equal(t, "1.2.0-beta.1", resp.Trace[3].Release)
+	})
+
+	t.Run("minimum version rejects", func(t *testing.T) {
+		resp, err := service.DryRunCheckForUpdate(ctx, check("1.0.0", "windows"))
+		require.NoError(t, err)
+		assert.Equal(t, models.CheckDecisionRejected, resp.Decision)
+		assert.Nil(t, resp.Result)
+		assert.Contains(t, resp.Error, "minimum required version 1.0.5")
+		assert.Equal(t, "minimum_version:fail", rules(resp.Trace)[len(resp.Trace)-1])
+	})
+
+	t.Run("no update", func(t *testing.T) {
+		req := check("1.2.0", "windows")
+		req.AllowPrerelease = true
+		resp, err := service.DryRunCheckForUpdate(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, models.CheckDecisionNoUpdate, resp.Decision)
+		assert.Equal(t, "newer_version:fail", rules(resp.Trace)[len(resp.Trace)-1])
+	})
+
+	t.Run("unsupported platform", func(t *testing.T) {
+		resp, err := service.DryRunCheckForUpdate(ctx, check("1.0.0", "linux"))
+		require.NoError(t, err)
+		assert.Equal(t, models.CheckDecisionRejected, resp.Decision)
+		assert.Equal(t, []string{"application:pass", "platform:fail"}, rules(resp.Trace))
+	})
+
+	t.Run("plugin host compatibility", func(t *testing.T) {
+		service, _ := setupPluginHost(t)
+		resp, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "plugin-a", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64", HostVersion: "1.5.0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.CheckDecisionUpdate, resp.Decision)
+		assert.Equal(t, "1.1.0", resp.Result.LatestVersion)
+		assert.Contains(t, rules(resp.Trace), "host_compatibility:fail")
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{ApplicationID: "test-app"})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+}
+
+func TestService_GetIntegrationSnippets(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"darwin", "windows"}})
+
+	resp, err := service.GetIntegrationSnippets(ctx, &models.IntegrationSnippetsRequest{ApplicationID: "test-app", BaseURL: "https://updates.example.com/"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://updates.example.com", resp.BaseURL)
+	assert.Equal(t, "darwin", resp.Platform)
+	assert.Equal(t, models.ArchAMD64, resp.Architecture)
+	assert.NotEmpty(t, resp.Snippets)
+
+	_, err = service.GetIntegrationSnippets(ctx, &models.IntegrationSnippetsRequest{ApplicationID: "test-app", BaseURL: "https://updates.example.com", Platform: "linux"})
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeInvalidRequest, serviceErr.Code)
+
+	_, err = service.GetIntegrationSnippets(ctx, &models.IntegrationSnippetsRequest{ApplicationID: "missing", BaseURL: "https://updates.example.com"})
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+}
+
+func TestService_ListPluginUpdates(t *testing.T) {
+	service, _ := setupPluginHost(t)
+	ctx := context.Background()
+
+	resp, err := service.ListPluginUpdates(ctx, &models.PluginUpdatesRequest{
+		HostApplicationID: "host",
+		HostVersion:       "1.2.0",
+		Platform:          "windows",
+		Architecture:      "amd64",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "host", resp.HostApplicationID)
+	assert.Equal(t, "1.2.0", resp.HostVersion)
+	require.Len(t, resp.Plugins, 2)
+	assert.Equal(t, "plugin-a", resp.Plugins[0].ApplicationID)
+	require.NotNil(t, resp.Plugins[0].Release)
+	assert.Equal(t, "1.1.0", resp.Plugins[0].Release.Version)
+	assert.Equal(t, "plugin-b", resp.Plugins[1].ApplicationID)
+	assert.Nil(t, resp.Plugins[1].Release, "plugin-b does not support windows")
+
+	_, err = service.ListPluginUpdates(ctx, &models.PluginUpdatesRequest{
+		HostApplicationID: "missing",
+		HostVersion:       "1.0.0",
+		Platform:          "windows",
+		Architecture:      "amd64",
+	})
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+
+	_, err = service.ListPluginUpdates(ctx, &models.PluginUpdatesRequest{
+		HostApplicationID: "host",
+		Platform:          "windows",
+		Architecture:      "amd64",
+	})
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+}
+
+func TestService_PluginParentValidation(t *testing.T) {
+	service, _ := setupPluginHost(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		parentID string
+		wantErr  bool
+	}{
+		{name: "valid host", parentID: "host"},
+		{name: "missing parent", parentID: "missing", wantErr: true},
+		{name: "parent is a plugin", parentID: "plugin-a", wantErr: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateApplication(ctx, &models.CreateApplicationRequest{
+				ID:        fmt.Sprintf("new-plugin-%d", i),
+				Name:      "New Plugin",
+				Platforms: []string{"windows"},
+				ParentID:  tt.parentID,
+			})
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var serviceErr *ServiceError
+			require.ErrorAs(t, err, &serviceErr)
+			assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+		})
+	}
+
+	t.Run("host cannot become a plugin", func(t *testing.T) {
+		require.NoError(t, service.storage.SaveApplication(ctx, &models.Application{ID: "other-host", Name: "Other", Platforms: []string{"windows"}}))
+		parent := "other-host"
+		_, err := service.UpdateApplication(ctx, "host", &models.UpdateApplicationRequest{ParentID: &parent})
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+
+	t.Run("host with plugins cannot be deleted", func(t *testing.T) {
+		err := service.DeleteApplication(ctx, "host")
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeConflict, serviceErr.Code)
+	})
+
+	t.Run("plugin can be detached", func(t *testing.T) {
+		detached := ""
+		_, err := service.UpdateApplication(ctx, "plugin-b", &models.UpdateApplicationRequest{ParentID: &detached})
+		require.NoError(t, err)
+		app, err := service.GetApplication(ctx, "plugin-b")
+		require.NoError(t, err)
+		assert.Empty(t, app.ParentID)
+	})
+}
+
 func TestService_ListReleases(t *testing.T) {
 	mockStorage := NewMockStorage()
 	service := NewService(mockStorage)
@@ -610,6 +1069,398 @@ func TestService_RegisterRelease(t *testing.T) {
 	assert.Equal(t, "1.0.0", releases[0].Version)
 }
 
+func TestService_RegisterRelease_DownloadURLPolicy(t *testing.T) {
+	mockStorage := NewMockStorage()
+	policy := models.DownloadURLPolicy{DenyPrivateNetworks: true, AllowedHosts: []string{"*.example.com"}}
+	service := NewService(mockStorage, WithDownloadURLPolicy(policy))
+	ctx := context.Background()
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}})
+
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://cdn.example.com/app.exe", false},
+		{"http://169.254.169.254/latest/meta-data/", true},
+		{"https://attacker.test/app.exe", true},
+	}
+	for _, tt := range tests {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "test-app",
+			Version:       "1.0.0",
+			Platform:      "windows",
+			Architecture:  "amd64",
+			DownloadURL:   tt.url,
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+		})
+		if !tt.wantErr {
+			assert.NoError(t, err, tt.url)
+			continue
+		}
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr, tt.url)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+		assert.Contains(t, serviceErr.Message, "download URL host")
+	}
+	assert.Len(t, mockStorage.releases["test-app"], 1)
+}
+
+func TestService_RegisterRelease_ApplicationDownloadHosts(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	app := models.NewApplication("test-app", "Test App", []string{"windows", "linux"})
+	app.Config.AllowedDownloadHosts = []string{"*.example.com"}
+	mockStorage.SaveApplication(ctx, app)
+
+	req := &models.RegisterReleaseRequest{
+		ApplicationID: "test-app",
+		Version:       "1.0.0",
+		Platform:      "windows",
+		Architecture:  "amd64",
+		DownloadURL:   "https://attacker.test/app.exe",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+	}
+	_, err := service.RegisterRelease(ctx, req)
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	assert.Contains(t, serviceErr.Message, "allowed download hosts")
+
+	req.DownloadURL = "https://cdn.example.com/app.exe"
+	_, err = service.RegisterRelease(ctx, req)
+	require.NoError(t, err)
+
+	manifest := &models.ReleaseManifest{
+		ApplicationID: "test-app",
+		Version:       "1.1.0",
+		Artifacts: []models.ManifestArtifact{
+			{Platform: "windows", Architecture: "amd64", DownloadURL: "https://cdn.example.com/app.exe", Checksum: "abc123", ChecksumType: "sha256"},
+			{Platform: "linux", Architecture: "amd64", DownloadURL: "https://attacker.test/app", Checksum: "abc123", ChecksumType: "sha256"},
+		},
+	}
+	_, err = service.IngestReleaseManifest(ctx, manifest)
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Contains(t, serviceErr.Message, "linux-amd64")
+	assert.Len(t, mockStorage.releases["test-app"], 1)
+}
+
+func TestService_RegisterRelease_RejectWeakChecksums(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage, WithRejectWeakChecksums(true))
+	ctx := context.Background()
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}})
+
+	req := &models.RegisterReleaseRequest{
+		ApplicationID: "test-app",
+		Version:       "1.0.0",
+		Platform:      "windows",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/app.exe",
+		Checksum:      "eb733a00c0c9d336e65691a37ab54293",
+		ChecksumType:  "MD5",
+	}
+	_, err := service.RegisterRelease(ctx, req)
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	assert.Contains(t, serviceErr.Message, "md5 is deprecated")
+
+	req.Checksum = strings.Repeat("a", 128)
+	req.ChecksumType = models.ChecksumTypeSHA512
+	req.Checksums = map[string]string{"sha1": "f48dd853820860816c75d54d0f584dc863327a7c"}
+	_, err = service.RegisterRelease(ctx, req)
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Contains(t, serviceErr.Message, "sha1 is deprecated")
+
+	req.Checksums = map[string]string{"BLAKE3": "ABC123"}
+	_, err = service.RegisterRelease(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"blake3": "abc123"}, mockStorage.releases["test-app"][0].Checksums)
+}
+
+// fakeArtifactFetcher serves a fixed artifact and counts the fetches.
+type fakeArtifactFetcher struct {
+	size     int64
+	checksum string
+	err      error
+	digests  int
+	sizes    int
+}
+
+func (f *fakeArtifactFetcher) Size(_ context.Context, _ string) (int64, error) {
+	f.sizes++
+	return f.size, f.err
+}
+
+func (f *fakeArtifactFetcher) Digest(_ context.Context, _, _ string) (int64, string, error) {
+	f.digests++
+	return f.size, f.checksum, f.err
+}
+
+func TestService_RegisterRelease_AutoFill(t *testing.T) {
+	ctx := context.Background()
+	newRequest := func(version string) *models.RegisterReleaseRequest {
+		return &models.RegisterReleaseRequest{
+			ApplicationID: "test-app",
+			Version:       version,
+			Platform:      "windows",
+			Architecture:  "amd64",
+			DownloadURL:   "https://example.com/app.exe",
+			AutoFill:      true,
+		}
+	}
+	setup := func(opts ...ServiceOption) (*Service, *MockStorage) {
+		mockStorage := NewMockStorage()
+		mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}})
+		return NewService(mockStorage, opts...), mockStorage
+	}
+
+	t.Run("fills checksum and size", func(t *testing.T) {
+		fetcher := &fakeArtifactFetcher{size: 2048, checksum: strings.Repeat("ab", 32)}
+		service, mockStorage := setup(WithArtifactFetcher(fetcher))
+
+		_, err := service.RegisterRelease(ctx, newRequest("1.0.0"))
+		require.NoError(t, err)
+		release := mockStorage.releases["test-app"][0]
+		assert.Equal(t, strings.Repeat("ab", 32), release.Checksum)
+		assert.Equal(t, models.ChecksumTypeSHA256, release.ChecksumType)
+		assert.Equal(t, int64(2048), release.FileSize)
+		assert.Equal(t, 1, fetcher.digests)
+	})
+
+	t.Run("fills only the size when the checksum is given", func(t *testing.T) {
+		fetcher := &fakeArtifactFetcher{size: 2048}
+		service, mockStorage := setup(WithArtifactFetcher(fetcher))
+
+		req := newRequest("1.0.0")
+		req.Checksum, req.ChecksumType = "abc123", "sha256"
+		_, err := service.RegisterRelease(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2048), mockStorage.releases["test-app"][0].FileSize)
+		assert.Equal(t, "abc123", mockStorage.releases["test-app"][0].Checksum)
+		assert.Equal(t, 0, fetcher.digests)
+		assert.Equal(t, 1, fetcher.sizes)
+	})
+
+	t.Run("rejects a mismatched file size", func(t *testing.T) {
+		service, mockStorage := setup(WithArtifactFetcher(&fakeArtifactFetcher{size: 2048, checksum: "abc123"}))
+
+		req := newRequest("1.0.0")
+		req.FileSize = 1000
+		_, err := service.RegisterRelease(ctx, req)
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+		assert.Contains(t, serviceErr.Message, "does not match")
+		assert.Empty(t, mockStorage.releases["test-app"])
+	})
+
+	t.Run("reports fetch failures", func(t *testing.T) {
+		service, _ := setup(WithArtifactFetcher(&fakeArtifactFetcher{err: fmt.Errorf("download returned HTTP 404")}))
+
+		_, err := service.RegisterRelease(ctx, newRequest("1.0.0"))
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Contains(t, serviceErr.Message, "HTTP 404")
+	})
+
+	t.Run("rejected without a fetcher", func(t *testing.T) {
+		service, _ := setup()
+
+		_, err := service.RegisterRelease(ctx, newRequest("1.0.0"))
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Contains(t, serviceErr.Message, "not enabled")
+	})
+
+	t.Run("not fetched when the URL policy refuses the URL", func(t *testing.T) {
+		fetcher := &fakeArtifactFetcher{size: 2048, checksum: "abc123"}
+		policy := models.DownloadURLPolicy{AllowedHosts: []string{"cdn.example.com"}}
+		service, _ := setup(WithArtifactFetcher(fetcher), WithDownloadURLPolicy(policy))
+
+		_, err := service.RegisterRelease(ctx, newRequest("1.0.0"))
+		require.Error(t, err)
+		assert.Equal(t, 0, fetcher.digests)
+	})
+
+	t.Run("rejected in desired state", func(t *testing.T) {
+		service, _ := setup(WithArtifactFetcher(&fakeArtifactFetcher{size: 2048, checksum: "abc123"}))
+
+		_, err := service.ApplyReleaseDesiredState(ctx, newRequest("1.0.0"))
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Contains(t, serviceErr.Message, "not supported in desired state")
+	})
+}
+
+func TestService_GetReleaseSignature(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"linux"}})
+
+	signature := "-----BEGIN PGP SIGNATURE-----\n\niHUEABYKAB0=\n-----END PGP SIGNATURE-----"
+	req := &models.RegisterReleaseRequest{
+		ApplicationID: "test-app",
+		Version:       "1.0.0",
+		Platform:      "linux",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/app.tar.gz",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+		PGPSignature:  "\n" + signature + "\n",
+	}
+	_, err := service.RegisterRelease(ctx, req)
+	require.NoError(t, err)
+
+	got, err := service.GetReleaseSignature(ctx, "test-app", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, signature, got)
+
+	req.Version = "1.1.0"
+	req.PGPSignature = ""
+	_, err = service.RegisterRelease(ctx, req)
+	require.NoError(t, err)
+
+	for _, version := range []string{"1.1.0", "9.9.9"} {
+		_, err = service.GetReleaseSignature(ctx, "test-app", version, "linux", "amd64")
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr, version)
+		assert.Equal(t, models.ErrorCodeNotFound, serviceErr.Code, version)
+	}
+
+	req.PGPSignature = "not a signature"
+	_, err = service.RegisterRelease(ctx, req)
+	assert.Error(t, err)
+}
+
+func TestService_ReleaseNotesTemplate(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	app := models.NewApplication("test-app", "Test App", []string{"windows", "darwin"})
+	app.Config.ReleaseNotesTemplate = "Version {{version}}, released {{date}}. macOS: {{download_url:darwin-arm64}}"
+	mockStorage.SaveApplication(ctx, app)
+
+	register := func(platform, arch, notes string) {
+		t.Helper()
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "test-app",
+			Version:       "1.2.0",
+			Platform:      platform,
+			Architecture:  arch,
+			DownloadURL:   "https://example.com/1.2.0/" + platform,
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+			ReleaseNotes:  notes,
+		})
+		require.NoError(t, err)
+	}
+	register("windows", "amd64", "")
+	register("darwin", "arm64", "Universal build")
+
+	// The template is stored unexpanded so it resolves against later releases.
+	releases := mockStorage.releases["test-app"]
+	require.Len(t, releases, 2)
+	assert.Equal(t, app.Config.ReleaseNotesTemplate, releases[0].ReleaseNotes)
+	assert.Equal(t, "Universal build", releases[1].ReleaseNotes)
+
+	date := releases[0].ReleaseDate.Format("2006-01-02")
+	want := "Version 1.2.0, released " + date + ". macOS: https://example.com/1.2.0/darwin"
+
+	check, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID:  "test-app",
+		CurrentVersion: "1.0.0",
+		Platform:       "windows",
+		Architecture:   "amd64",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, check.ReleaseNotes)
+
+	latest, err := service.GetLatestVersion(ctx, &models.LatestVersionRequest{
+		ApplicationID: "test-app",
+		Platform:      "windows",
+		Architecture:  "amd64",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, latest.ReleaseNotes)
+
+	list, err := service.ListReleases(ctx, &models.ListReleasesRequest{ApplicationID: "test-app"})
+	require.NoError(t, err)
+	assert.Equal(t, app.Config.ReleaseNotesTemplate, list.Releases[0].ReleaseNotes)
+}
+
+func TestService_CompareReleases(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	app := models.NewApplication("test-app", "Test App", []string{"windows", "linux", "darwin"})
+	mockStorage.SaveApplication(ctx, app)
+	add := func(version, platform string, size int64, required bool) {
+		release := models.NewRelease("test-app", version, platform, "amd64", "https://example.com/"+platform)
+		release.Checksum = "abc123"
+		release.FileSize = size
+		release.Required = required
+		release.ReleaseDate = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockStorage.releases["test-app"] = append(mockStorage.releases["test-app"], release)
+	}
+	add("1.0.0", "windows", 1000, false)
+	add("1.0.0", "linux", 2000, false)
+	add("1.1.0", "windows", 1200, true)
+	add("1.1.0", "linux", 2000, false)
+	add("1.1.0", "darwin", 3000, false)
+
+	t.Run("all targets", func(t *testing.T) {
+		resp, err := service.CompareReleases(ctx, &models.CompareReleasesRequest{ApplicationID: "test-app", From: "1.0.0", To: "1.1.0"})
+		require.NoError(t, err)
+		require.Len(t, resp.Targets, 3)
+
+		darwin, linux, windows := resp.Targets[0], resp.Targets[1], resp.Targets[2]
+		assert.Equal(t, "darwin", darwin.Platform)
+		assert.Equal(t, models.CompareStatusAdded, darwin.Status)
+		assert.Nil(t, darwin.From)
+		assert.NotNil(t, darwin.To)
+
+		assert.Equal(t, models.CompareStatusUnchanged, linux.Status)
+		assert.Empty(t, linux.Changes)
+
+		assert.Equal(t, models.CompareStatusChanged, windows.Status)
+		assert.Equal(t, int64(200), windows.FileSizeDelta)
+		assert.Equal(t, []models.FieldChange{
+			{Field: "file_size", From: int64(1000), To: int64(1200)},
+			{Field: "required", From: false, To: true},
+		}, windows.Changes)
+	})
+
+	t.Run("reversed comparison removes target", func(t *testing.T) {
+		resp, err := service.CompareReleases(ctx, &models.CompareReleasesRequest{ApplicationID: "test-app", From: "1.1.0", To: "1.0.0", Platform: "darwin"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "version 1.0.0")
+		assert.Nil(t, resp)
+
+		resp, err = service.CompareReleases(ctx, &models.CompareReleasesRequest{ApplicationID: "test-app", From: "1.1.0", To: "1.0.0"})
+		require.NoError(t, err)
+		assert.Equal(t, models.CompareStatusRemoved, resp.Targets[0].Status)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := service.CompareReleases(ctx, &models.CompareReleasesRequest{ApplicationID: "missing", From: "1.0.0", To: "1.1.0"})
+		assert.ErrorContains(t, err, "not found")
+
+		_, err = service.CompareReleases(ctx, &models.CompareReleasesRequest{ApplicationID: "test-app", From: "1.0.0", To: "2.0.0"})
+		assert.ErrorContains(t, err, "no releases found for test-app version 2.0.0")
+
+		_, err = service.CompareReleases(ctx, &models.CompareReleasesRequest{ApplicationID: "test-app", From: "1.0.0"})
+		assert.ErrorContains(t, err, "invalid request")
+	})
+}
+
 func TestService_RegisterRelease_Validation(t *testing.T) {
 	mockStorage := NewMockStorage()
 	service := NewService(mockStorage)
@@ -679,6 +1530,83 @@ func TestService_RegisterRelease_Validation(t *testing.T) {
 	}
 }
 
+func TestService_IngestReleaseManifest(t *testing.T) {
+	newManifest := func() *models.ReleaseManifest {
+		return &models.ReleaseManifest{
+			ApplicationID: "test-app",
+			Version:       "3.0.0",
+			ReleaseNotes:  "Big release",
+			Artifacts: []models.ManifestArtifact{
+				{Platform: "windows", Architecture: "amd64", DownloadURL: "https://example.com/w.exe", Checksum: "ABC123", ChecksumType: "SHA256"},
+				{Platform: "linux", Architecture: "amd64", DownloadURL: "https://example.com/l.tgz", Checksum: "def456", ChecksumType: "sha256"},
+			},
+		}
+	}
+
+	t.Run("registers every artifact", func(t *testing.T) {
+		mockStorage := NewMockStorage()
+		service := NewService(mockStorage)
+		ctx := context.Background()
+		mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows", "linux"}})
+
+		resp, err := service.IngestReleaseManifest(ctx, newManifest())
+		require.NoError(t, err)
+		assert.Equal(t, "3.0.0", resp.Version)
+		require.Len(t, resp.Releases, 2)
+		assert.Equal(t, "abc123", resp.Releases[0].Checksum, "checksums are normalized")
+		assert.Equal(t, "Big release", resp.Releases[1].ReleaseNotes)
+		assert.Len(t, mockStorage.releases["test-app"], 2)
+	})
+
+	t.Run("unsupported platform saves nothing", func(t *testing.T) {
+		mockStorage := NewMockStorage()
+		service := NewService(mockStorage)
+		ctx := context.Background()
+		mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows"}})
+
+		_, err := service.IngestReleaseManifest(ctx, newManifest())
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeInvalidRequest, serviceErr.Code)
+		assert.Empty(t, mockStorage.releases["test-app"])
+	})
+
+	t.Run("download URL policy saves nothing", func(t *testing.T) {
+		mockStorage := NewMockStorage()
+		service := NewService(mockStorage, WithDownloadURLPolicy(models.DownloadURLPolicy{AllowedHosts: []string{"downloads.example.com"}}))
+		ctx := context.Background()
+		mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows", "linux"}})
+
+		_, err := service.IngestReleaseManifest(ctx, newManifest())
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+		assert.Contains(t, serviceErr.Message, "windows-amd64")
+		assert.Empty(t, mockStorage.releases["test-app"])
+	})
+
+	t.Run("storage failure", func(t *testing.T) {
+		mockStorage := NewMockStorage()
+		mockStorage.saveReleasesErr = fmt.Errorf("disk full")
+		service := NewService(mockStorage)
+		ctx := context.Background()
+		mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test App", Platforms: []string{"windows", "linux"}})
+
+		_, err := service.IngestReleaseManifest(ctx, newManifest())
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeInternalError, serviceErr.Code)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		service := NewService(NewMockStorage())
+		_, err := service.IngestReleaseManifest(context.Background(), newManifest())
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+	})
+}
+
 func TestService_CreateApplication(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -775,6 +1703,157 @@ func TestService_CreateApplication(t *testing.T) {
 	}
 }
 
+func TestService_CreateApplication_Template(t *testing.T) {
+	templates := []models.ApplicationTemplate{{
+		Name:         "desktop-app",
+		Platforms:    []string{"windows", "darwin"},
+		CustomFields: map[string]string{"support_url": "https://support.example.com"},
+		Tags:         []string{"desktop"},
+		Group:        "Desktop",
+	}}
+
+	t.Run("applies template defaults", func(t *testing.T) {
+		m := NewMockStorage()
+		service := NewService(m, WithApplicationTemplates(templates))
+
+		_, err := service.CreateApplication(context.Background(), &models.CreateApplicationRequest{
+			ID:       "new-app",
+			Name:     "New App",
+			Tags:     []string{"beta"},
+			Template: "desktop-app",
+		})
+		require.NoError(t, err)
+
+		app := m.applications["new-app"]
+		require.NotNil(t, app)
+		assert.Equal(t, []string{"windows", "darwin"}, app.Platforms)
+		assert.Equal(t, []string{"beta", "desktop"}, app.Tags)
+		assert.Equal(t, "Desktop", app.Group)
+		assert.Equal(t, "https://support.example.com", app.Config.CustomFields["support_url"])
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		service := NewService(NewMockStorage(), WithApplicationTemplates(templates))
+
+		_, err := service.CreateApplication(context.Background(), &models.CreateApplicationRequest{
+			ID:       "new-app",
+			Name:     "New App",
+			Template: "mobile-app",
+		})
+		assert.ErrorContains(t, err, "unknown template: mobile-app")
+	})
+
+	t.Run("lists templates", func(t *testing.T) {
+		resp, err := NewService(NewMockStorage(), WithApplicationTemplates(templates)).ListApplicationTemplates(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, templates, resp.Templates)
+
+		resp, err = NewService(NewMockStorage()).ListApplicationTemplates(context.Background())
+		require.NoError(t, err)
+		assert.NotNil(t, resp.Templates)
+		assert.Empty(t, resp.Templates)
+	})
+}
+
+func TestService_CloneApplication(t *testing.T) {
+	newStorage := func() *MockStorage {
+		m := NewMockStorage()
+		app := models.NewApplication("source-app", "Source", []string{"windows", "linux"})
+		app.Description = "Desktop edition"
+		app.Config.CustomFields["tier"] = "desktop"
+		app.Tags = []string{"desktop"}
+		app.Group = "products"
+		m.applications[app.ID] = app
+
+		// Newest first, matching the release_date desc order the clone requests.
+		base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i, v := range []struct{ version, platform string }{
+			{"1.2.0", "windows"}, {"1.2.0", "linux"}, {"1.1.0", "windows"}, {"1.0.0", "windows"},
+		} {
+			release := models.NewRelease(app.ID, v.version, v.platform, "amd64", "https://example.com/"+v.version)
+			release.Checksum = "abc123"
+			release.ReleaseDate = base.AddDate(0, 0, -i)
+			release.Metadata["channel"] = "stable"
+			m.releases[app.ID] = append(m.releases[app.ID], release)
+		}
+		return m
+	}
+
+	t.Run("copies application and recent releases", func(t *testing.T) {
+		m := newStorage()
+		service := NewService(m)
+
+		resp, err := service.CloneApplication(context.Background(), "source-app", &models.CloneApplicationRequest{ID: " enterprise-app ", Releases: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "enterprise-app", resp.ID)
+		assert.Equal(t, "source-app", resp.SourceID)
+		assert.Equal(t, 3, resp.ReleasesCopied)
+
+		clone := m.applications["enterprise-app"]
+		require.NotNil(t, clone)
+		assert.Equal(t, "Source", clone.Name)
+		assert.Equal(t, "Desktop edition", clone.Description)
+		assert.Equal(t, []string{"windows", "linux"}, clone.Platforms)
+		assert.Equal(t, []string{"desktop"}, clone.Tags)
+		assert.Equal(t, "products", clone.Group)
+		assert.Equal(t, "desktop", clone.Config.CustomFields["tier"])
+
+		// The clone's config is independent of the source.
+		clone.Config.CustomFields["tier"] = "enterprise"
+		assert.Equal(t, "desktop", m.applications["source-app"].Config.CustomFields["tier"])
+
+		sourceIDs := map[string]bool{}
+		for _, release := range m.releases["source-app"] {
+			sourceIDs[release.ID] = true
+		}
+		copied := m.releases["enterprise-app"]
+		require.Len(t, copied, 3)
+		for _, release := range copied {
+			assert.Equal(t, "enterprise-app", release.ApplicationID)
+			assert.False(t, sourceIDs[release.ID], "a clone gets a new ID")
+			assert.NotEqual(t, "1.0.0", release.Version)
+			assert.Equal(t, "stable", release.Metadata["channel"])
+		}
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), copied[0].ReleaseDate)
+		assert.Len(t, m.releases["source-app"], 4)
+	})
+
+	t.Run("overrides name and skips releases", func(t *testing.T) {
+		m := newStorage()
+		service := NewService(m)
+
+		resp, err := service.CloneApplication(context.Background(), "source-app", &models.CloneApplicationRequest{ID: "copy", Name: "Copy"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, resp.ReleasesCopied)
+		assert.Equal(t, "Copy", m.applications["copy"].Name)
+		assert.Empty(t, m.releases["copy"])
+	})
+
+	t.Run("release save failure removes the clone", func(t *testing.T) {
+		m := newStorage()
+		m.saveReleasesErr = fmt.Errorf("disk full")
+		service := NewService(m)
+
+		_, err := service.CloneApplication(context.Background(), "source-app", &models.CloneApplicationRequest{ID: "copy", Releases: 1})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to save releases")
+		assert.NotContains(t, m.applications, "copy")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		service := NewService(newStorage())
+
+		_, err := service.CloneApplication(context.Background(), "missing", &models.CloneApplicationRequest{ID: "copy"})
+		assert.ErrorContains(t, err, "not found")
+
+		_, err = service.CloneApplication(context.Background(), "source-app", &models.CloneApplicationRequest{ID: "source-app"})
+		assert.ErrorContains(t, err, "already exists")
+
+		_, err = service.CloneApplication(context.Background(), "source-app", &models.CloneApplicationRequest{ID: "bad id"})
+		assert.ErrorContains(t, err, "invalid request")
+	})
+}
+
 func TestService_GetApplication(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -789,8 +1868,8 @@ func TestService_GetApplication(t *testing.T) {
 			setup: func(m *MockStorage) {
 				app := models.NewApplication("test-app", "Test App", []string{"windows", "linux"})
 				app.Description = "A test application"
-				app.CreatedAt = "2025-01-01T00:00:00Z"
-				app.UpdatedAt = "2025-01-02T00:00:00Z"
+				app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				app.UpdatedAt = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
 				m.applications[app.ID] = app
 
 				r1 := createTestReleaseForUpdate("test-app", "1.0.0", "windows", "amd64")
@@ -814,8 +1893,8 @@ func TestService_GetApplication(t *testing.T) {
 			name: "success with no releases",
 			setup: func(m *MockStorage) {
 				app := models.NewApplication("empty-app", "Empty App", []string{"windows"})
-				app.CreatedAt = "2025-01-01T00:00:00Z"
-				app.UpdatedAt = "2025-01-01T00:00:00Z"
+				app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 				m.applications[app.ID] = app
 			},
 			appID:       "empty-app",
@@ -881,8 +1960,8 @@ func TestService_ListApplications(t *testing.T) {
 				for i := 0; i < 3; i++ {
 					id := fmt.Sprintf("app-%d", i)
 					app := models.NewApplication(id, fmt.Sprintf("App %d", i), []string{"windows"})
-					app.CreatedAt = "2025-01-01T00:00:00Z"
-					app.UpdatedAt = "2025-01-01T00:00:00Z"
+					app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+					app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 					m.applications[id] = app
 				}
 			},
@@ -898,8 +1977,8 @@ func TestService_ListApplications(t *testing.T) {
 				for i := 0; i < 5; i++ {
 					id := fmt.Sprintf("app-%d", i)
 					app := models.NewApplication(id, fmt.Sprintf("App %d", i), []string{"windows"})
-					app.CreatedAt = "2025-01-01T00:00:00Z"
-					app.UpdatedAt = "2025-01-01T00:00:00Z"
+					app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+					app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 					m.applications[id] = app
 				}
 			},
@@ -916,8 +1995,8 @@ func TestService_ListApplications(t *testing.T) {
 				for i := 0; i < 3; i++ {
 					id := fmt.Sprintf("app-%d", i)
 					app := models.NewApplication(id, fmt.Sprintf("App %d", i), []string{"windows"})
-					app.CreatedAt = "2025-01-01T00:00:00Z"
-					app.UpdatedAt = "2025-01-01T00:00:00Z"
+					app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+					app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 					m.applications[id] = app
 				}
 			},
@@ -932,8 +2011,8 @@ func TestService_ListApplications(t *testing.T) {
 			name: "default limit when zero",
 			setup: func(m *MockStorage) {
 				app := models.NewApplication("app-1", "App 1", []string{"windows"})
-				app.CreatedAt = "2025-01-01T00:00:00Z"
-				app.UpdatedAt = "2025-01-01T00:00:00Z"
+				app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 				m.applications["app-1"] = app
 			},
 			req: &models.ListApplicationsRequest{Limit: 0},
@@ -945,8 +2024,8 @@ func TestService_ListApplications(t *testing.T) {
 			name: "invalid cursor returns validation error",
 			setup: func(m *MockStorage) {
 				app := models.NewApplication("app-1", "App 1", []string{"windows"})
-				app.CreatedAt = "2025-01-01T00:00:00Z"
-				app.UpdatedAt = "2025-01-01T00:00:00Z"
+				app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 				m.applications["app-1"] = app
 			},
 			req:         &models.ListApplicationsRequest{Limit: 10, After: "not-a-valid-cursor"},
@@ -990,8 +2069,8 @@ func TestService_UpdateApplication(t *testing.T) {
 			name: "success - full update",
 			setup: func(m *MockStorage) {
 				app := models.NewApplication("test-app", "Old Name", []string{"windows"})
-				app.CreatedAt = "2025-01-01T00:00:00Z"
-				app.UpdatedAt = "2025-01-01T00:00:00Z"
+				app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 				m.applications[app.ID] = app
 			},
 			appID: "test-app",
@@ -1020,8 +2099,8 @@ func TestService_UpdateApplication(t *testing.T) {
 			setup: func(m *MockStorage) {
 				app := models.NewApplication("test-app", "Old Name", []string{"windows"})
 				app.Description = "Existing Description"
-				app.CreatedAt = "2025-01-01T00:00:00Z"
-				app.UpdatedAt = "2025-01-01T00:00:00Z"
+				app.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				app.UpdatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 				m.applications[app.ID] = app
 			},
 			appID: "test-app",
@@ -1320,8 +2399,8 @@ func TestListApplications_CursorEmittedWhenPageFull(t *testing.T) {
 		app := &models.Application{
 			ID: fmt.Sprintf("app%d", i), Name: fmt.Sprintf("App%d", i),
 			Platforms: []string{"windows"}, Config: models.ApplicationConfig{},
-			CreatedAt: now.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
-			UpdatedAt: now.Format(time.RFC3339),
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+			UpdatedAt: now,
 		}
 		require.NoError(t, store.SaveApplication(ctx, app))
 	}
@@ -1381,88 +2460,276 @@ func TestService_CheckForUpdate_SemverOrdering(t *testing.T) {
 	assert.Equal(t, "1.10.0", response.LatestVersion)
 }
 
-func TestService_GetApplication_CorruptTimestamp(t *testing.T) {
-	tests := []struct {
-		name      string
-		createdAt string
-		updatedAt string
-	}{
-		{
-			name:      "corrupt created_at",
-			createdAt: "not-a-timestamp",
-			updatedAt: "2025-01-01T00:00:00Z",
-		},
-		{
-			name:      "corrupt updated_at",
-			createdAt: "2025-01-01T00:00:00Z",
-			updatedAt: "not-a-timestamp",
-		},
-	}
+// Helper functions
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockStorage := NewMockStorage()
-			app := models.NewApplication("test-app", "Test App", []string{"windows"})
-			app.CreatedAt = tt.createdAt
-			app.UpdatedAt = tt.updatedAt
-			mockStorage.applications["test-app"] = app
+func createTestReleaseForUpdate(appID, version, platform, arch string) *models.Release {
+	release := models.NewRelease(appID, version, platform, arch, "https://example.com/download")
+	release.Checksum = "abc123"
+	release.ChecksumType = "sha256"
+	release.FileSize = 1234567
+	release.ReleaseNotes = "Test release"
+	release.ReleaseDate = time.Now()
+	return release
+}
 
-			service := NewService(mockStorage)
-			_, err := service.GetApplication(context.Background(), "test-app")
+func TestService_WithClock(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockStorage := NewMockStorage()
+	ctx := context.Background()
+	service := NewService(mockStorage, WithClock(func() time.Time { return now }), WithDecisionLog(NewDecisionLog(10)))
 
-			require.Error(t, err)
-			var svcErr *ServiceError
-			require.ErrorAs(t, err, &svcErr)
-			assert.Equal(t, models.ErrorCodeInternalError, svcErr.Code)
-		})
-	}
+	resp, err := service.CreateApplication(ctx, &models.CreateApplicationRequest{ID: "clock-app", Name: "Clock App", Platforms: []string{"linux"}})
+	require.NoError(t, err)
+	assert.True(t, now.Equal(resp.CreatedAt))
+
+	_, err = service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "clock-app",
+		Version:       "1.0.0",
+		Platform:      "linux",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/app.tar.gz",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+	})
+	require.NoError(t, err)
+	release, err := mockStorage.GetRelease(ctx, "clock-app", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.True(t, now.Equal(release.ReleaseDate))
+	assert.Equal(t, models.NewULID(now)[:10], release.ID[:10], "the ID's timestamp comes from the clock")
+	assert.True(t, now.Equal(release.CreatedAt))
+
+	now = now.Add(time.Hour)
+	desc := "moved on"
+	_, err = service.UpdateApplication(ctx, "clock-app", &models.UpdateApplicationRequest{Description: &desc})
+	require.NoError(t, err)
+	app, err := mockStorage.GetApplication(ctx, "clock-app")
+	require.NoError(t, err)
+	assert.True(t, now.Add(-time.Hour).Equal(app.CreatedAt))
+	assert.True(t, now.Equal(app.UpdatedAt))
+
+	_, err = service.CheckForUpdate(WithRequestID(ctx, "req-1"), &models.UpdateCheckRequest{
+		ApplicationID: "clock-app", CurrentVersion: "0.9.0", Platform: "linux", Architecture: "amd64",
+	})
+	require.NoError(t, err)
+	log, err := service.GetDecisionLog(ctx, "req-1")
+	require.NoError(t, err)
+	require.Len(t, log.Records, 1)
+	assert.True(t, now.Equal(log.Records[0].RecordedAt))
 }
 
-func TestService_ListApplications_CorruptTimestamp(t *testing.T) {
-	tests := []struct {
-		name      string
-		createdAt string
-		updatedAt string
-	}{
-		{
-			name:      "corrupt created_at in summary",
-			createdAt: "not-a-timestamp",
-			updatedAt: "2025-01-01T00:00:00Z",
-		},
-		{
-			name:      "corrupt updated_at in summary",
-			createdAt: "2025-01-01T00:00:00Z",
-			updatedAt: "not-a-timestamp",
-		},
+func TestService_PublishesEvents(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	bus := events.NewBus()
+	var got []events.Event
+	bus.Subscribe(func(e events.Event) { got = append(got, e) })
+	service := NewService(store, WithEventBus(bus))
+	ctx := context.Background()
+
+	_, err = service.CreateApplication(ctx, &models.CreateApplicationRequest{ID: "evt-app", Name: "Events", Platforms: []string{"linux"}})
+	require.NoError(t, err)
+	registered, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "evt-app",
+		Version:       "1.0.0",
+		Platform:      "linux",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/evt-app.tar.gz",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+	})
+	require.NoError(t, err)
+	name := "Renamed"
+	_, err = service.UpdateApplication(ctx, "evt-app", &models.UpdateApplicationRequest{Name: &name})
+	require.NoError(t, err)
+	_, err = service.DeleteRelease(ctx, "evt-app", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteApplication(ctx, "evt-app"))
+
+	// Failed changes publish nothing
+	_, err = service.UpdateApplication(ctx, "evt-app", &models.UpdateApplicationRequest{Name: &name})
+	require.Error(t, err)
+
+	types := make([]events.Type, len(got))
+	for i, e := range got {
+		types[i] = e.Type
+		assert.Equal(t, "evt-app", e.ApplicationID)
+	}
+	assert.Equal(t, []events.Type{
+		events.ApplicationCreated,
+		events.ReleasePublished,
+		events.ApplicationUpdated,
+		events.ReleaseDeleted,
+		events.ApplicationDeleted,
+	}, types)
+	assert.Equal(t, registered.ID, got[1].ReleaseID)
+	assert.Equal(t, "1.0.0", got[1].Version)
+	assert.Equal(t, "linux", got[1].Platform)
+}
+
+func TestService_WithIDGenerator(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	ctx := context.Background()
+	next := 0
+	service := NewService(store, WithIDGenerator(func(time.Time) string {
+		next++
+		return fmt.Sprintf("id-%d", next)
+	}))
+	_, err = service.CreateApplication(ctx, &models.CreateApplicationRequest{ID: "id-app", Name: "ID App", Platforms: []string{"linux"}})
+	require.NoError(t, err)
+
+	req := &models.RegisterReleaseRequest{
+		ApplicationID: "id-app",
+		Version:       "1.0.0",
+		Platform:      "linux",
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/app.tar.gz",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
 	}
+	resp, err := service.RegisterRelease(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", resp.ID)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockStorage := NewMockStorage()
-			app := models.NewApplication("test-app", "Test App", []string{"windows"})
-			app.CreatedAt = tt.createdAt
-			app.UpdatedAt = tt.updatedAt
-			mockStorage.applications["test-app"] = app
+	// Registering the same release again replaces it under its existing ID
+	req.ReleaseNotes = "Updated notes"
+	resp, err = service.RegisterRelease(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", resp.ID)
+	release, err := store.GetRelease(ctx, "id-app", "1.0.0", "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", release.ID)
+	assert.Equal(t, "Updated notes", release.ReleaseNotes)
+}
 
-			service := NewService(mockStorage)
-			_, err := service.ListApplications(context.Background(), &models.ListApplicationsRequest{Limit: 10})
+func TestService_ApplicationSlugs(t *testing.T) {
+	mockStorage := NewMockStorage()
+	ctx := context.Background()
+	service := NewService(mockStorage)
+	create := func(req *models.CreateApplicationRequest) error {
+		req.Platforms = []string{"linux"}
+		_, err := service.CreateApplication(ctx, req)
+		return err
+	}
+	rename := func(appID, slug string) error {
+		_, err := service.UpdateApplication(ctx, appID, &models.UpdateApplicationRequest{Slug: &slug})
+		return err
+	}
+
+	require.NoError(t, create(&models.CreateApplicationRequest{ID: "acme-notes", Name: "Notes"}))
+	require.NoError(t, create(&models.CreateApplicationRequest{ID: "other", Name: "Other", Slug: "other-app"}))
+	assert.Equal(t, "acme-notes", mockStorage.applications["acme-notes"].Slug)
+	assert.Equal(t, "other-app", mockStorage.applications["other"].Slug)
+
+	require.NoError(t, rename("acme-notes", "notes"))
+	require.NoError(t, rename("acme-notes", "jotter"))
+	app := mockStorage.applications["acme-notes"]
+	assert.Equal(t, "jotter", app.Slug)
+	assert.Equal(t, []string{"notes"}, app.FormerSlugs)
+
+	// Taking back a former slug drops it from the former slugs
+	require.NoError(t, rename("acme-notes", "notes"))
+	assert.Equal(t, []string{"jotter"}, mockStorage.applications["acme-notes"].FormerSlugs)
+
+	// Nothing may take an ID or slug that already resolves elsewhere
+	var conflict *ServiceError
+	for name, err := range map[string]error{
+		"rename to another ID":            rename("acme-notes", "other"),
+		"rename to another slug":          rename("other", "notes"),
+		"rename to another former slug":   rename("other", "jotter"),
+		"create with a former slug as ID": create(&models.CreateApplicationRequest{ID: "jotter", Name: "Jotter"}),
+		"create with a taken slug":        create(&models.CreateApplicationRequest{ID: "new", Name: "New", Slug: "other-app"}),
+	} {
+		require.ErrorAs(t, err, &conflict, name)
+		assert.Equal(t, models.ErrorCodeConflict, conflict.Code, name)
+	}
+
+	// Desired state documents leave slugs alone
+	_, err := service.ApplyApplicationDesiredState(ctx, "acme-notes", &models.ApplicationDesiredState{
+		Name: "Notes 2", Platforms: []string{"linux"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "notes", mockStorage.applications["acme-notes"].Slug)
+}
 
-			require.Error(t, err)
-			var svcErr *ServiceError
-			require.ErrorAs(t, err, &svcErr)
-			assert.Equal(t, models.ErrorCodeInternalError, svcErr.Code)
+func TestService_CheckForUpdate_NextCheck(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	mockStorage := NewMockStorage()
+	app := &models.Application{ID: "fleet-app", Name: "Fleet App", Platforms: []string{"windows"}, Config: models.ApplicationConfig{UpdateInterval: 3600}}
+	require.NoError(t, mockStorage.SaveApplication(ctx, app))
+	require.NoError(t, mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("fleet-app", "1.0.0", "windows", "amd64")))
+	service := NewService(mockStorage, WithClock(func() time.Time { return now }))
+
+	check := func(clientID string) *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID:  "fleet-app",
+			CurrentVersion: "1.0.0",
+			Platform:       "windows",
+			Architecture:   "amd64",
+			ClientID:       clientID,
 		})
+		require.NoError(t, err)
+		return resp
 	}
+
+	resp := check("device-1")
+	assert.Equal(t, models.NextCheckSeconds("fleet-app", "device-1", time.Hour, now), resp.NextCheckSeconds)
+	assert.NotEqual(t, resp.NextCheckSeconds, check("device-2").NextCheckSeconds, "clients are spread over the interval")
+	assert.Zero(t, check("").NextCheckSeconds, "anonymous clients keep their own schedule")
 }
 
-// Helper functions
+func TestService_CheckForUpdate_VersionCode(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("droid-app", "Droid App", []string{"android"})))
+	service := NewService(store)
+
+	digest := strings.Repeat("ab", 32)
+	register := func(version string, versionCode int64) {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID:     "droid-app",
+			Version:           version,
+			Platform:          "android",
+			Architecture:      "arm64",
+			DownloadURL:       "https://example.com/app-" + version + ".apk",
+			Checksum:          "abc123",
+			ChecksumType:      "sha256",
+			Variant:           models.VariantAPK,
+			VersionCode:       versionCode,
+			SigningCertSHA256: strings.ToUpper(digest),
+		})
+		require.NoError(t, err)
+	}
+	check := func(current string, versionCode int64) *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "droid-app", CurrentVersion: current, Platform: "android", Architecture: "arm64", VersionCode: versionCode,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	register("1.0.0", 10)
+	register("1.1.0", 12)
 
-func createTestReleaseForUpdate(appID, version, platform, arch string) *models.Release {
-	release := models.NewRelease(appID, version, platform, arch, "https://example.com/download")
-	release.Checksum = "abc123"
-	release.ChecksumType = "sha256"
-	release.FileSize = 1234567
-	release.ReleaseNotes = "Test release"
-	release.ReleaseDate = time.Now()
-	return release
+	resp := check("1.0.0", 10)
+	assert.True(t, resp.UpdateAvailable)
+	assert.Equal(t, int64(12), resp.VersionCode)
+	assert.Equal(t, digest, resp.SigningCertSHA256)
+
+	// A hotfix build of 1.0.0 already has the offered version code
+	assert.False(t, check("1.0.0", 12).UpdateAvailable)
+	dryRun, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "droid-app", CurrentVersion: "1.0.0", Platform: "android", Architecture: "arm64", VersionCode: 12,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, dryRun.Trace, models.DecisionStep{
+		Rule: models.RuleVersionCode, Result: models.DecisionFail, Release: "1.1.0", Detail: "version code 12 is not above the client's 12",
+	})
+
+	// Android cannot install a downgrade off a yanked release
+	_, err = service.YankRelease(ctx, "droid-app", "1.1.0", "android", "arm64", &models.YankReleaseRequest{})
+	require.NoError(t, err)
+	assert.True(t, check("1.1.0", 0).Downgrade)
+	assert.False(t, check("1.1.0", 12).UpdateAvailable)
 }
@@ -0,0 +1,88 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"updater/internal/events"
+	"updater/internal/models"
+)
+
+// ReportStatusCheck records the result of a required status check for every
+// release of a version that requires it. The releases are offered once all of
+// their checks succeed; a later failure withdraws the offer again.
+func (s *Service) ReportStatusCheck(ctx context.Context, appID, version string, req *models.ReportStatusCheckRequest) (*models.ReleaseStatusResponse, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+	releases, err := s.releasesOfVersion(ctx, appID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	var updated []*models.Release
+	for _, release := range releases {
+		if _, ok := release.StatusChecks[req.Name]; !ok {
+			continue
+		}
+		// Stored releases may share the map with the storage's copy
+		release.StatusChecks = maps.Clone(release.StatusChecks)
+		release.StatusChecks[req.Name] = models.StatusCheck{
+			State:       req.State,
+			Description: req.Description,
+			TargetURL:   req.TargetURL,
+			UpdatedAt:   now,
+		}
+		release.UpdatedAt = now
+		updated = append(updated, release)
+	}
+	if len(updated) == 0 {
+		return nil, NewValidationError(fmt.Sprintf("no release of %s version %s requires the status check %q", appID, version, req.Name), nil)
+	}
+	if err := s.storage.SaveReleases(ctx, updated); err != nil {
+		return nil, NewInternalError("failed to save releases", err)
+	}
+	// A check that passes wakes long-poll checks waiting for a release to be offered
+	for _, release := range updated {
+		s.publishRelease(events.ReleaseUpdated, release)
+	}
+
+	resp := newReleaseStatusResponse(appID, version, releases)
+	resp.Message = fmt.Sprintf("Status check %q of %d release(s) is %s", req.Name, len(updated), req.State)
+	return resp, nil
+}
+
+// GetReleaseStatus combines the status checks of every release of a version.
+func (s *Service) GetReleaseStatus(ctx context.Context, appID, version string) (*models.ReleaseStatusResponse, error) {
+	releases, err := s.releasesOfVersion(ctx, appID, version)
+	if err != nil {
+		return nil, err
+	}
+	return newReleaseStatusResponse(appID, version, releases), nil
+}
+
+// releasesOfVersion returns every release of a version of an application.
+func (s *Service) releasesOfVersion(ctx context.Context, appID, version string) ([]*models.Release, error) {
+	if _, err := s.storage.GetApplication(ctx, appID); err != nil {
+		return nil, NewApplicationNotFoundError(appID)
+	}
+	releases, _, err := s.storage.ListReleasesPaged(ctx, appID, models.ReleaseFilters{Version: version}, "created_at", "asc", models.MaxPageSize, nil)
+	if err != nil {
+		return nil, NewInternalError("failed to list releases", err)
+	}
+	if len(releases) == 0 {
+		return nil, NewNotFoundError(fmt.Sprintf("no releases of %s version %s", appID, version))
+	}
+	return releases, nil
+}
+
+func newReleaseStatusResponse(appID, version string, releases []*models.Release) *models.ReleaseStatusResponse {
+	state, checks := models.CombineStatusChecks(releases)
+	resp := &models.ReleaseStatusResponse{ApplicationID: appID, Version: version, State: state, Checks: checks}
+	for _, release := range releases {
+		resp.ReleaseIDs = append(resp.ReleaseIDs, release.ID)
+	}
+	return resp
+}
@@ -0,0 +1,102 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ReportStatusCheck(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	app := models.NewApplication("gated-app", "Gated App", []string{"windows", "linux"})
+	require.NoError(t, store.SaveApplication(ctx, app))
+	service := NewService(store)
+
+	register := func(version, platform string) {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "gated-app",
+			Version:       version,
+			Platform:      platform,
+			Architecture:  "amd64",
+			DownloadURL:   "https://example.com/app-" + version,
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+		})
+		require.NoError(t, err)
+	}
+	check := func() *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "gated-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	report := func(name, state string) (*models.ReleaseStatusResponse, error) {
+		return service.ReportStatusCheck(ctx, "gated-app", "1.2.0", &models.ReportStatusCheckRequest{
+			Name: name, State: state, TargetURL: "https://ci.example.com/runs/7",
+		})
+	}
+
+	// Releases registered before checks were required are not gated
+	register("1.1.0", "windows")
+	app.Config.RequiredChecks = []string{"ci", "security-scan"}
+	require.NoError(t, store.SaveApplication(ctx, app))
+	register("1.2.0", "windows")
+	register("1.2.0", "linux")
+
+	status, err := service.GetReleaseStatus(ctx, "gated-app", "1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusCheckPending, status.State)
+	assert.Len(t, status.ReleaseIDs, 2)
+	assert.Equal(t, models.StatusCheckPending, status.Checks["ci"].State)
+	assert.Equal(t, "1.1.0", check().LatestVersion, "the release is held until its checks pass")
+
+	dryRun, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "gated-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, dryRun.Trace, models.DecisionStep{
+		Rule: models.RuleStatusChecks, Result: models.DecisionFail, Release: "1.2.0", Detail: "waiting for status checks: ci, security-scan",
+	})
+
+	status, err = report("ci", "success")
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusCheckPending, status.State)
+	assert.Equal(t, "https://ci.example.com/runs/7", status.Checks["ci"].TargetURL)
+
+	status, err = report("security-scan", "failure")
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusCheckFailure, status.State)
+	assert.Equal(t, "1.1.0", check().LatestVersion)
+
+	status, err = report("security-scan", "SUCCESS")
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusCheckSuccess, status.State)
+	assert.Equal(t, "1.2.0", check().LatestVersion)
+
+	// Registering the release again keeps its checks
+	register("1.2.0", "windows")
+	assert.Equal(t, "1.2.0", check().LatestVersion)
+
+	t.Run("check the release does not require", func(t *testing.T) {
+		_, err := report("lint", "success")
+		assertServiceError(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		_, err := report("ci", "green")
+		assertServiceError(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := service.GetReleaseStatus(ctx, "gated-app", "9.9.9")
+		assertServiceError(t, err, http.StatusNotFound)
+	})
+}
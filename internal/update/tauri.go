@@ -0,0 +1,94 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"updater/internal/models"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// tauriCandidate is the newest release of one Tauri target.
+type tauriCandidate struct {
+	platform, arch string
+	latest         *models.LatestVersionResponse
+	version        *semver.Version
+}
+
+// GetTauriManifest builds the Tauri updater manifest of an application's
+// newest release on channel. Every Tauri target the application supports is
+// looked up as a latest-version lookup, so pausing, yanking, targeting and
+// entitlements apply as usual. The manifest names the newest version found and
+// lists only targets whose newest release is that version and carries a Tauri
+// signature: Tauri would install an older download as the newer version, and
+// refuses downloads without a signature.
+func (s *Service) GetTauriManifest(ctx context.Context, appID, channel, licenseToken string) (*models.TauriUpdateManifest, error) {
+	app, err := s.storage.GetApplication(ctx, appID)
+	if err != nil {
+		return nil, NewApplicationNotFoundError(appID)
+	}
+	if channel == "" {
+		channel = models.ChannelStable
+	}
+
+	var candidates []tauriCandidate
+	var newest *semver.Version
+	for _, platform := range app.Platforms {
+		for _, arch := range models.TauriArchitectures {
+			if _, ok := models.TauriTarget(platform, arch); !ok {
+				continue
+			}
+			latest, err := s.GetLatestVersion(ctx, &models.LatestVersionRequest{
+				ApplicationID:   appID,
+				Platform:        platform,
+				Architecture:    arch,
+				IncludeMetadata: true,
+				LicenseToken:    licenseToken,
+				Channel:         channel,
+			})
+			if err != nil {
+				var serviceError *ServiceError
+				if errors.As(err, &serviceError) && serviceError.StatusCode == http.StatusNotFound {
+					continue
+				}
+				return nil, err
+			}
+			version, err := semver.NewVersion(latest.Version)
+			if err != nil {
+				return nil, NewInternalError("invalid release version", err)
+			}
+			candidates = append(candidates, tauriCandidate{platform: platform, arch: arch, latest: latest, version: version})
+			if newest == nil || version.GreaterThan(newest) {
+				newest = version
+			}
+		}
+	}
+	if newest == nil {
+		return nil, NewNotFoundError(fmt.Sprintf("no releases of %s for Tauri targets on channel %s", appID, channel))
+	}
+
+	manifest := &models.TauriUpdateManifest{Platforms: make(map[string]models.TauriPlatform)}
+	for _, c := range candidates {
+		signature := c.latest.Metadata[models.TauriSignatureMetadataKey]
+		if !c.version.Equal(newest) || signature == "" {
+			continue
+		}
+		if manifest.Version == "" {
+			manifest.Version = c.latest.Version
+			manifest.Notes = c.latest.ReleaseNotes
+			if !c.latest.ReleaseDate.IsZero() {
+				manifest.PubDate = c.latest.ReleaseDate.UTC().Format(time.RFC3339)
+			}
+		}
+		for _, target := range models.TauriTargets(c.platform, c.arch, c.latest.Variant) {
+			manifest.Platforms[target] = models.TauriPlatform{Signature: signature, URL: c.latest.DownloadURL}
+		}
+	}
+	if manifest.Version == "" {
+		return nil, NewNotFoundError(fmt.Sprintf("release %s of %s has no %s metadata for any Tauri target", newest.Original(), appID, models.TauriSignatureMetadataKey))
+	}
+	return manifest, nil
+}
@@ -0,0 +1,73 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_GetTauriManifest(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "tauri-app", Name: "Tauri App", Platforms: []string{"windows", "darwin", "linux"}})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "empty-app", Name: "Empty App", Platforms: []string{"windows", "android"}})
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "unsigned-app", Name: "Unsigned App", Platforms: []string{"linux"}})
+	releaseDate := time.Date(2026, 10, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	save := func(appID, version, platform, arch, variant, signature string) {
+		release := createTestReleaseForUpdate(appID, version, platform, arch)
+		release.DownloadURL = "https://cdn.example.com/" + platform + "-" + arch + "-" + version
+		release.ReleaseDate = releaseDate
+		release.Variant = variant
+		if signature != "" {
+			release.SetMetadata(models.TauriSignatureMetadataKey, signature)
+		}
+		mockStorage.SaveRelease(ctx, release)
+	}
+	save("tauri-app", "1.0.0", "windows", "amd64", models.VariantMSI, "sig-win-1.0.0")
+	save("tauri-app", "1.1.0", "windows", "amd64", models.VariantMSI, "sig-win")
+	save("tauri-app", "1.1.0", "darwin", "arm64", "", "sig-mac")
+	save("tauri-app", "1.1.0", "linux", "arm64", models.VariantAppImage, "")
+	save("tauri-app", "1.0.0", "linux", "amd64", models.VariantAppImage, "sig-linux")
+	save("unsigned-app", "1.0.0", "linux", "amd64", "", "")
+
+	t.Run("lists signed targets of the newest version", func(t *testing.T) {
+		manifest, err := service.GetTauriManifest(ctx, "tauri-app", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", manifest.Version)
+		assert.Equal(t, "Test release", manifest.Notes)
+		assert.Equal(t, "2026-10-01T10:00:00Z", manifest.PubDate)
+		assert.Equal(t, map[string]models.TauriPlatform{
+			"windows-x86_64":     {Signature: "sig-win", URL: "https://cdn.example.com/windows-amd64-1.1.0"},
+			"windows-x86_64-msi": {Signature: "sig-win", URL: "https://cdn.example.com/windows-amd64-1.1.0"},
+			"darwin-aarch64":     {Signature: "sig-mac", URL: "https://cdn.example.com/darwin-arm64-1.1.0"},
+		}, manifest.Platforms, "unsigned linux-aarch64 and older linux-x86_64 are omitted")
+	})
+
+	t.Run("no releases", func(t *testing.T) {
+		_, err := service.GetTauriManifest(ctx, "empty-app", "", "")
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeNotFound, serviceErr.Code)
+	})
+
+	t.Run("no signed target", func(t *testing.T) {
+		_, err := service.GetTauriManifest(ctx, "unsigned-app", "", "")
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeNotFound, serviceErr.Code)
+		assert.Contains(t, serviceErr.Message, models.TauriSignatureMetadataKey)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		_, err := service.GetTauriManifest(ctx, "missing", "", "")
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+	})
+}
@@ -0,0 +1,69 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"updater/internal/models"
+)
+
+// decisionTrace records the rules an update check evaluates. A nil trace
+// records nothing, so untraced checks pay only for the nil check.
+type decisionTrace struct {
+	steps []models.DecisionStep
+}
+
+func newDecisionTrace() *decisionTrace {
+	return &decisionTrace{steps: []models.DecisionStep{}}
+}
+
+func (t *decisionTrace) add(rule, result, release, format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.steps = append(t.steps, models.DecisionStep{
+		Rule:    rule,
+		Result:  result,
+		Release: release,
+		Detail:  fmt.Sprintf(format, args...),
+	})
+}
+
+// DryRunCheckForUpdate runs an update check for a hypothetical client and
+// reports the decision with the trace of rules that led to it. Failures the
+// client would see as error responses are reported in the response, so the
+// trace up to the failing rule is kept; only an invalid request is returned
+// as an error.
+func (s *Service) DryRunCheckForUpdate(ctx context.Context, req *models.UpdateCheckRequest) (*models.DryRunCheckResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+	req.Normalize()
+
+	trace := newDecisionTrace()
+	result, err := s.checkForUpdate(withDryRun(ctx), req, trace)
+	return decide(result, err, trace), nil
+}
+
+// decide summarizes the outcome of a traced update check.
+func decide(result *models.UpdateCheckResponse, err error, trace *decisionTrace) *models.DryRunCheckResponse {
+	resp := &models.DryRunCheckResponse{Trace: trace.steps}
+	if err != nil {
+		resp.Decision = models.CheckDecisionError
+		resp.Error = err.Error()
+		var svcErr *ServiceError
+		if errors.As(err, &svcErr) && svcErr.StatusCode < http.StatusInternalServerError {
+			resp.Decision = models.CheckDecisionRejected
+			resp.Error = svcErr.Message
+		}
+		return resp
+	}
+
+	resp.Result = result
+	resp.Decision = models.CheckDecisionNoUpdate
+	if result.UpdateAvailable {
+		resp.Decision = models.CheckDecisionUpdate
+	}
+	return resp
+}
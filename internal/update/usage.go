@@ -0,0 +1,47 @@
+package update
+
+import (
+	"context"
+	"updater/internal/models"
+)
+
+// GetApplicationUsage totals the releases, artifacts and container images an
+// application stores. Releases are read a page at a time, so totals taken
+// while releases are being registered may include some of them.
+func (s *Service) GetApplicationUsage(ctx context.Context, appID string) (*models.ApplicationUsageResponse, error) {
+	if _, err := s.storage.GetApplication(ctx, appID); err != nil {
+		return nil, NewApplicationNotFoundError(appID)
+	}
+
+	usage := &models.ApplicationUsageResponse{ApplicationID: appID, MeasuredAt: s.now().UTC()}
+	var cursor *models.ReleaseCursor
+	for {
+		releases, _, err := s.storage.ListReleasesPaged(ctx, appID, models.ReleaseFilters{}, "created_at", "asc", models.MaxPageSize, cursor)
+		if err != nil {
+			return nil, NewInternalError("failed to list releases", err)
+		}
+		for _, r := range releases {
+			usage.Releases++
+			usage.AddArtifact(r.FileSize)
+			for _, edition := range r.Editions {
+				usage.AddArtifact(edition.FileSize)
+			}
+			for _, variant := range r.Variants {
+				usage.AddArtifact(variant.FileSize)
+			}
+		}
+		if len(releases) < models.MaxPageSize {
+			break
+		}
+		last := releases[len(releases)-1]
+		cursor = &models.ReleaseCursor{SortBy: "created_at", SortOrder: "asc", ID: last.ID, CreatedAt: last.CreatedAt}
+	}
+
+	images, err := s.storage.ListContainerImages(ctx, appID)
+	if err != nil {
+		return nil, NewInternalError("failed to list container images", err)
+	}
+	usage.ContainerImages = len(images)
+
+	return usage, nil
+}
@@ -0,0 +1,56 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_GetApplicationUsage(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	service := NewService(store)
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("usage-app", "Usage App", []string{"windows"})))
+
+	// One more release than fits a page, so the totals span two pages
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range models.MaxPageSize + 1 {
+		release := models.NewRelease("usage-app", fmt.Sprintf("1.0.%d", i), "windows", "amd64", "https://example.com/app.exe")
+		release.FileSize = 100
+		release.CreatedAt = created.Add(time.Duration(i) * time.Second)
+		switch i {
+		case 0:
+			release.Editions = map[string]models.EditionArtifact{
+				"pro":  {DownloadURL: "https://example.com/pro.exe", FileSize: 50},
+				"lite": {DownloadURL: "https://example.com/lite.exe"},
+			}
+		case 1:
+			release.FileSize = 0
+		}
+		require.NoError(t, store.SaveRelease(ctx, release))
+	}
+	image := models.NewContainerImage("usage-app", "ghcr.io/example/app", "1.0.0", "sha256:"+fmt.Sprintf("%064d", 1), nil)
+	require.NoError(t, store.SaveContainerImage(ctx, image))
+
+	usage, err := service.GetApplicationUsage(ctx, "usage-app")
+	require.NoError(t, err)
+	assert.Equal(t, "usage-app", usage.ApplicationID)
+	assert.Equal(t, models.MaxPageSize+1, usage.Releases)
+	assert.Equal(t, models.MaxPageSize+3, usage.Artifacts)
+	assert.Equal(t, int64(100*models.MaxPageSize+50), usage.ArtifactBytes)
+	assert.Equal(t, 2, usage.UnsizedArtifacts)
+	assert.Equal(t, 1, usage.ContainerImages)
+	assert.False(t, usage.MeasuredAt.IsZero())
+
+	_, err = service.GetApplicationUsage(ctx, "missing-app")
+	var serviceErr *ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, models.ErrorCodeApplicationNotFound, serviceErr.Code)
+}
@@ -0,0 +1,53 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"updater/internal/models"
+)
+
+// VerifyChecksum compares the checksum a client computed for a downloaded
+// artifact with the registered release. A mismatch is not an error: it is
+// reported in the response, and the caller records it.
+func (s *Service) VerifyChecksum(ctx context.Context, req *models.VerifyChecksumRequest) (*models.VerifyChecksumResponse, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid request", err)
+	}
+
+	if _, err := s.storage.GetApplication(ctx, req.ApplicationID); err != nil {
+		reportUnknownApplication(ctx, req.ApplicationID, err)
+		return nil, NewApplicationNotFoundError(req.ApplicationID)
+	}
+	release, err := s.storage.GetRelease(ctx, req.ApplicationID, req.Version, req.Platform, req.Architecture)
+	if err != nil {
+		return nil, NewNotFoundError(fmt.Sprintf("release '%s-%s-%s-%s' not found", req.ApplicationID, req.Version, req.Platform, req.Architecture))
+	}
+
+	checksumType := req.ChecksumType
+	if checksumType == "" {
+		checksumType = strings.ToLower(release.ChecksumType)
+	}
+	var expected string
+	for t, checksum := range release.AllChecksums() {
+		if strings.EqualFold(t, checksumType) {
+			expected = checksum
+		}
+	}
+	if expected == "" {
+		return nil, NewInvalidRequestError(fmt.Sprintf("release '%s' has no %s checksum", release.ID, checksumType), nil)
+	}
+
+	response := &models.VerifyChecksumResponse{
+		ReleaseID:    release.ID,
+		ChecksumType: checksumType,
+	}
+	if strings.EqualFold(expected, req.Checksum) {
+		response.Verified = true
+		response.Message = "Checksum matches the registered release"
+	} else {
+		response.Message = "Checksum does not match the registered release; discard the download"
+	}
+	return response, nil
+}
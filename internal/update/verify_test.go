@@ -0,0 +1,76 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_VerifyChecksum(t *testing.T) {
+	mockStorage := NewMockStorage()
+	service := NewService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.SaveApplication(ctx, &models.Application{ID: "app", Name: "App", Platforms: []string{"linux"}})
+	release := createTestReleaseForUpdate("app", "1.2.0", "linux", "amd64")
+	release.Checksums = map[string]string{models.ChecksumTypeSHA512: "def456"}
+	mockStorage.SaveRelease(ctx, release)
+
+	request := func(checksum, checksumType string) *models.VerifyChecksumRequest {
+		return &models.VerifyChecksumRequest{
+			ApplicationID: "app",
+			Version:       "1.2.0",
+			Platform:      "linux",
+			Architecture:  "amd64",
+			Checksum:      checksum,
+			ChecksumType:  checksumType,
+		}
+	}
+
+	t.Run("match", func(t *testing.T) {
+		resp, err := service.VerifyChecksum(ctx, request("ABC123", ""))
+		require.NoError(t, err)
+		assert.True(t, resp.Verified)
+		assert.Equal(t, release.ID, resp.ReleaseID)
+		assert.Equal(t, models.ChecksumTypeSHA256, resp.ChecksumType, "the primary checksum is compared by default")
+	})
+
+	t.Run("additional checksum", func(t *testing.T) {
+		resp, err := service.VerifyChecksum(ctx, request("def456", models.ChecksumTypeSHA512))
+		require.NoError(t, err)
+		assert.True(t, resp.Verified)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		resp, err := service.VerifyChecksum(ctx, request("abc124", ""))
+		require.NoError(t, err)
+		assert.False(t, resp.Verified)
+	})
+
+	t.Run("checksum type the release lacks", func(t *testing.T) {
+		_, err := service.VerifyChecksum(ctx, request("abc123", models.ChecksumTypeSHA1))
+		var serviceErr *ServiceError
+		require.True(t, errors.As(err, &serviceErr))
+		assert.Equal(t, models.ErrorCodeInvalidRequest, serviceErr.Code)
+	})
+
+	t.Run("unknown release", func(t *testing.T) {
+		req := request("abc123", "")
+		req.Version = "9.9.9"
+		_, err := service.VerifyChecksum(ctx, req)
+		var serviceErr *ServiceError
+		require.True(t, errors.As(err, &serviceErr))
+		assert.Equal(t, models.ErrorCodeNotFound, serviceErr.Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := service.VerifyChecksum(ctx, request("not hex", ""))
+		var serviceErr *ServiceError
+		require.True(t, errors.As(err, &serviceErr))
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+}
@@ -0,0 +1,105 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"updater/internal/models"
+)
+
+// releaseNotifier wakes long-polling update checks when a release is
+// published. It is process-local: checks held by another instance are not
+// woken and return their no-update result when the wait elapses.
+type releaseNotifier struct {
+	mu      sync.Mutex
+	waiters map[string]map[chan struct{}]struct{}
+	stopped chan struct{}
+	stop    sync.Once
+}
+
+func newReleaseNotifier() *releaseNotifier {
+	return &releaseNotifier{
+		waiters: make(map[string]map[chan struct{}]struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// subscribe returns a channel that is closed on the next release published for
+// appID, and a function that drops the subscription if it did not fire.
+func (n *releaseNotifier) subscribe(appID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{})
+	n.mu.Lock()
+	if n.waiters[appID] == nil {
+		n.waiters[appID] = make(map[chan struct{}]struct{})
+	}
+	n.waiters[appID][ch] = struct{}{}
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.waiters[appID], ch)
+		if len(n.waiters[appID]) == 0 {
+			delete(n.waiters, appID)
+		}
+	}
+}
+
+// notify wakes every check waiting on appID.
+func (n *releaseNotifier) notify(appID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.waiters[appID] {
+		close(ch)
+	}
+	delete(n.waiters, appID)
+}
+
+// WaitForUpdate is a long-polling CheckForUpdate. When no update is available
+// it holds the check for up to wait, re-checking each time a release is
+// published for the application, and returns as soon as one matches. When
+// wait elapses, the client disconnects or CancelWaits is called, it returns
+// the no-update result. A zero wait is a plain CheckForUpdate.
+func (s *Service) WaitForUpdate(ctx context.Context, req *models.UpdateCheckRequest, wait time.Duration) (*models.UpdateCheckResponse, error) {
+	if wait < 0 || wait > models.MaxUpdateCheckWait {
+		return nil, NewValidationError("invalid request", fmt.Errorf("wait must be between 0 and %s", models.MaxUpdateCheckWait))
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	// Subscribe before each check so a release published between the check
+	// and the wait is not missed.
+	appID := strings.TrimSpace(req.ApplicationID)
+	for {
+		published, unsubscribe := s.notifier.subscribe(appID)
+		resp, err := s.CheckForUpdate(ctx, req)
+		if err != nil || resp.UpdateAvailable || wait == 0 {
+			unsubscribe()
+			return resp, err
+		}
+
+		select {
+		case <-published:
+			// A release for another platform also wakes the check; loop and re-check.
+		case <-timer.C:
+			unsubscribe()
+			return resp, nil
+		case <-ctx.Done():
+			unsubscribe()
+			return resp, nil
+		case <-s.notifier.stopped:
+			unsubscribe()
+			return resp, nil
+		}
+	}
+}
+
+// CancelWaits ends every held WaitForUpdate call with its current result and
+// makes later calls return immediately. It is registered as an HTTP server
+// shutdown hook so long-polling checks do not delay graceful shutdown.
+func (s *Service) CancelWaits() {
+	s.notifier.stop.Do(func() { close(s.notifier.stopped) })
+}
@@ -0,0 +1,127 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWaitTestService(t *testing.T) *Service {
+	t.Helper()
+	mockStorage := NewMockStorage()
+	ctx := context.Background()
+	require.NoError(t, mockStorage.SaveApplication(ctx, &models.Application{ID: "test-app", Name: "Test", Platforms: []string{"windows", "linux"}}))
+	require.NoError(t, mockStorage.SaveRelease(ctx, createTestReleaseForUpdate("test-app", "1.0.0", "windows", "amd64")))
+	return NewService(mockStorage)
+}
+
+func waitCheck() *models.UpdateCheckRequest {
+	return &models.UpdateCheckRequest{ApplicationID: "test-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64"}
+}
+
+func registerForWait(t *testing.T, service *Service, version, platform string) {
+	t.Helper()
+	_, err := service.RegisterRelease(context.Background(), &models.RegisterReleaseRequest{
+		ApplicationID: "test-app",
+		Version:       version,
+		Platform:      platform,
+		Architecture:  "amd64",
+		DownloadURL:   "https://example.com/download",
+		Checksum:      "abc123",
+		ChecksumType:  "sha256",
+	})
+	require.NoError(t, err)
+}
+
+// waitForWaiters blocks until n checks are held for test-app.
+func waitForWaiters(t *testing.T, service *Service, n int) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		service.notifier.mu.Lock()
+		defer service.notifier.mu.Unlock()
+		return len(service.notifier.waiters["test-app"]) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestService_WaitForUpdate(t *testing.T) {
+	t.Run("returns immediately when an update is available", func(t *testing.T) {
+		service := newWaitTestService(t)
+		registerForWait(t, service, "1.1.0", "windows")
+
+		start := time.Now()
+		resp, err := service.WaitForUpdate(context.Background(), waitCheck(), time.Minute)
+		require.NoError(t, err)
+		assert.True(t, resp.UpdateAvailable)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("wakes when a matching release is published", func(t *testing.T) {
+		service := newWaitTestService(t)
+		done := make(chan *models.UpdateCheckResponse)
+		go func() {
+			resp, err := service.WaitForUpdate(context.Background(), waitCheck(), time.Minute)
+			assert.NoError(t, err)
+			done <- resp
+		}()
+
+		waitForWaiters(t, service, 1)
+		registerForWait(t, service, "1.2.0", "linux")
+		// A release for another platform re-checks and keeps waiting
+		waitForWaiters(t, service, 1)
+		registerForWait(t, service, "1.2.0", "windows")
+
+		select {
+		case resp := <-done:
+			assert.True(t, resp.UpdateAvailable)
+			assert.Equal(t, "1.2.0", resp.LatestVersion)
+		case <-time.After(5 * time.Second):
+			t.Fatal("long-poll was not woken by the release")
+		}
+	})
+
+	t.Run("returns no update at timeout", func(t *testing.T) {
+		service := newWaitTestService(t)
+		resp, err := service.WaitForUpdate(context.Background(), waitCheck(), 20*time.Millisecond)
+		require.NoError(t, err)
+		assert.False(t, resp.UpdateAvailable)
+		waitForWaiters(t, service, 0)
+	})
+
+	t.Run("CancelWaits releases held checks", func(t *testing.T) {
+		service := newWaitTestService(t)
+		done := make(chan *models.UpdateCheckResponse)
+		go func() {
+			resp, _ := service.WaitForUpdate(context.Background(), waitCheck(), time.Minute)
+			done <- resp
+		}()
+
+		waitForWaiters(t, service, 1)
+		service.CancelWaits()
+		select {
+		case resp := <-done:
+			assert.False(t, resp.UpdateAvailable)
+		case <-time.After(5 * time.Second):
+			t.Fatal("long-poll was not released")
+		}
+	})
+
+	t.Run("rejects waits above the maximum", func(t *testing.T) {
+		service := newWaitTestService(t)
+		_, err := service.WaitForUpdate(context.Background(), waitCheck(), models.MaxUpdateCheckWait+time.Second)
+		var serviceErr *ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		assert.Equal(t, models.ErrorCodeValidation, serviceErr.Code)
+	})
+
+	t.Run("validation errors are returned without waiting", func(t *testing.T) {
+		service := newWaitTestService(t)
+		req := waitCheck()
+		req.ApplicationID = "missing"
+		_, err := service.WaitForUpdate(context.Background(), req, time.Minute)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"updater/internal/events"
+	"updater/internal/models"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// YankRelease withdraws a release that turned out to be broken. A yanked
+// release is not offered by update checks or latest-version lookups, and
+// clients that already installed it are offered the release before it as a
+// downgrade.
+func (s *Service) YankRelease(ctx context.Context, appID, version, platform, arch string, req *models.YankReleaseRequest) (*models.YankReleaseResponse, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError("invalid yank request", err)
+	}
+	return s.setReleaseYanked(ctx, appID, version, platform, arch, true, req.Reason)
+}
+
+// UnyankRelease withdraws the yank of a release, offering it again.
+func (s *Service) UnyankRelease(ctx context.Context, appID, version, platform, arch string) (*models.YankReleaseResponse, error) {
+	return s.setReleaseYanked(ctx, appID, version, platform, arch, false, "")
+}
+
+func (s *Service) setReleaseYanked(ctx context.Context, appID, version, platform, arch string, yanked bool, reason string) (*models.YankReleaseResponse, error) {
+	release, err := s.storage.GetRelease(ctx, appID, version, platform, arch)
+	if err != nil {
+		return nil, NewNotFoundError(fmt.Sprintf("release '%s-%s-%s-%s' not found", appID, version, platform, arch))
+	}
+
+	state := "restored"
+	if yanked {
+		state = "yanked"
+	}
+	if release.Yanked == yanked && release.YankReason == reason {
+		return &models.YankReleaseResponse{
+			ID:         release.ID,
+			Yanked:     yanked,
+			YankReason: release.YankReason,
+			UpdatedAt:  release.UpdatedAt,
+			Message:    fmt.Sprintf("Release '%s' is already %s", release.ID, state),
+		}, nil
+	}
+
+	release.Yanked = yanked
+	release.YankReason = reason
+	release.UpdatedAt = s.now().UTC()
+	if err := s.storage.SaveRelease(ctx, release); err != nil {
+		return nil, NewInternalError("failed to save release", err)
+	}
+	// Yanking wakes long-poll checks of clients on the release, which are now
+	// offered a downgrade
+	s.publishRelease(events.ReleaseUpdated, release)
+
+	return &models.YankReleaseResponse{
+		ID:         release.ID,
+		Yanked:     yanked,
+		YankReason: release.YankReason,
+		UpdatedAt:  release.UpdatedAt,
+		Message:    fmt.Sprintf("Release '%s' %s", release.ID, state),
+	}, nil
+}
+
+// offerYankDowngrade offers a client whose current version is yanked the
+// newest release before it that the client would otherwise be offered. The
+// result of a check that found no update is returned unchanged when the
+// current version is not yanked or nothing older qualifies.
+func (s *Service) offerYankDowngrade(ctx context.Context, app *models.Application, req *models.UpdateCheckRequest, result *models.UpdateCheckResponse, trace *decisionTrace) (*models.UpdateCheckResponse, error) {
+	// Clients on versions that were never registered are not on a yanked release
+	current, err := s.storage.GetRelease(ctx, req.ApplicationID, req.CurrentVersion, req.Platform, req.Architecture)
+	if err != nil || !current.Yanked {
+		return result, nil
+	}
+	currentVersion, err := semver.NewVersion(current.Version)
+	if err != nil {
+		return nil, NewInternalError("invalid current version format", err)
+	}
+
+	releases, err := s.storage.GetReleasesAfterVersion(ctx, req.ApplicationID, lowestVersion, req.Platform, req.Architecture)
+	if err != nil {
+		return nil, NewInternalError("failed to get releases", err)
+	}
+	var older []*models.Release
+	for _, release := range releases {
+		if v, err := semver.NewVersion(release.Version); err == nil && v.LessThan(currentVersion) {
+			older = append(older, release)
+		}
+	}
+
+	channel := clientChannel(req.Channel, req.AllowPrerelease)
+	older = channelReleases(older, channel, trace)
+	allowPrerelease := req.AllowPrerelease || req.Channel != ""
+	filter := s.newOfferFilter(req.ApplicationID, req.Edition, req.LicenseToken, channel, req.Attributes())
+	var release *models.Release
+	if app.ParentID != "" && req.HostVersion != "" {
+		release, err = newestCompatibleRelease(ctx, older, req.HostVersion, allowPrerelease, filter, trace)
+		if err != nil {
+			return nil, NewInternalError("failed to check host compatibility", err)
+		}
+	} else {
+		release = newestAllowedRelease(ctx, older, allowPrerelease, filter, trace)
+	}
+	if release == nil {
+		trace.add(models.RuleYanked, models.DecisionFail, "", "current version %s is yanked, but no older release can be offered", req.CurrentVersion)
+		return result, nil
+	}
+	trace.add(models.RuleYanked, models.DecisionPass, release.Version, "current version %s is yanked; offering %s as a downgrade", req.CurrentVersion, release.Version)
+
+	response := &models.UpdateCheckResponse{CurrentVersion: req.CurrentVersion}
+	response.SetUpdateAvailable(release)
+	response.Downgrade = true
+	response.ReleaseNotes = s.expandReleaseNotes(ctx, release)
+	if !req.IncludeMetadata {
+		response.Metadata = nil
+	}
+	return response, nil
+}
@@ -0,0 +1,122 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"updater/internal/models"
+	"updater/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_YankRelease(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("yank-app", "Yank App", []string{"windows"})))
+	service := NewService(store)
+
+	register := func(version string) {
+		_, err := service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+			ApplicationID: "yank-app",
+			Version:       version,
+			Platform:      "windows",
+			Architecture:  "amd64",
+			DownloadURL:   "https://example.com/app-" + version + ".exe",
+			Checksum:      "abc123",
+			ChecksumType:  "sha256",
+		})
+		require.NoError(t, err)
+	}
+	check := func(current string) *models.UpdateCheckResponse {
+		resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+			ApplicationID: "yank-app", CurrentVersion: current, Platform: "windows", Architecture: "amd64",
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	latest := func() string {
+		resp, err := service.GetLatestVersion(ctx, &models.LatestVersionRequest{ApplicationID: "yank-app", Platform: "windows", Architecture: "amd64"})
+		require.NoError(t, err)
+		return resp.Version
+	}
+	register("1.0.0")
+	register("1.1.0")
+	register("1.2.0")
+
+	yanked, err := service.YankRelease(ctx, "yank-app", "1.2.0", "windows", "amd64", &models.YankReleaseRequest{Reason: " crashes on start "})
+	require.NoError(t, err)
+	assert.True(t, yanked.Yanked)
+	assert.Equal(t, "crashes on start", yanked.YankReason)
+
+	resp := check("1.0.0")
+	assert.Equal(t, "1.1.0", resp.LatestVersion, "the yanked release is skipped")
+	assert.False(t, resp.Downgrade)
+	assert.Equal(t, "1.1.0", latest())
+	stable, err := service.GetLatestStableVersion(ctx, "yank-app")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", stable, "badges and the status page skip the yanked release")
+
+	// Clients on the yanked release are offered the release before it
+	resp = check("1.2.0")
+	assert.True(t, resp.UpdateAvailable)
+	assert.True(t, resp.Downgrade)
+	assert.True(t, resp.IsPriority())
+	assert.Equal(t, "1.1.0", resp.LatestVersion)
+	assert.Equal(t, "https://example.com/app-1.1.0.exe", resp.DownloadURL)
+
+	dryRun, err := service.DryRunCheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "yank-app", CurrentVersion: "1.2.0", Platform: "windows", Architecture: "amd64",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, dryRun.Trace, models.DecisionStep{
+		Rule: models.RuleYanked, Result: models.DecisionPass, Release: "1.1.0", Detail: "current version 1.2.0 is yanked; offering 1.1.0 as a downgrade",
+	})
+
+	// Registering the release again keeps the yank
+	register("1.2.0")
+	assert.True(t, check("1.2.0").Downgrade)
+
+	again, err := service.YankRelease(ctx, "yank-app", "1.2.0", "windows", "amd64", &models.YankReleaseRequest{Reason: "crashes on start"})
+	require.NoError(t, err)
+	assert.Contains(t, again.Message, "already yanked")
+
+	restored, err := service.UnyankRelease(ctx, "yank-app", "1.2.0", "windows", "amd64")
+	require.NoError(t, err)
+	assert.False(t, restored.Yanked)
+	assert.Empty(t, restored.YankReason)
+	assert.Equal(t, "1.2.0", check("1.0.0").LatestVersion)
+	assert.False(t, check("1.2.0").UpdateAvailable)
+	assert.Equal(t, "1.2.0", latest())
+
+	_, err = service.YankRelease(ctx, "yank-app", "9.9.9", "windows", "amd64", &models.YankReleaseRequest{})
+	assertServiceError(t, err, http.StatusNotFound)
+	_, err = service.YankRelease(ctx, "yank-app", "1.2.0", "windows", "amd64", &models.YankReleaseRequest{Reason: strings.Repeat("a", models.MaxYankReasonLength+1)})
+	assertServiceError(t, err, http.StatusUnprocessableEntity)
+}
+
+func TestService_YankRelease_NothingOlder(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveApplication(ctx, models.NewApplication("yank-app", "Yank App", []string{"windows"})))
+	service := NewService(store)
+
+	_, err = service.RegisterRelease(ctx, &models.RegisterReleaseRequest{
+		ApplicationID: "yank-app", Version: "1.0.0", Platform: "windows", Architecture: "amd64",
+		DownloadURL: "https://example.com/app-1.0.0.exe", Checksum: "abc123", ChecksumType: "sha256",
+	})
+	require.NoError(t, err)
+	_, err = service.YankRelease(ctx, "yank-app", "1.0.0", "windows", "amd64", &models.YankReleaseRequest{})
+	require.NoError(t, err)
+
+	resp, err := service.CheckForUpdate(ctx, &models.UpdateCheckRequest{
+		ApplicationID: "yank-app", CurrentVersion: "1.0.0", Platform: "windows", Architecture: "amd64",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.UpdateAvailable)
+	assert.False(t, resp.Downgrade)
+}
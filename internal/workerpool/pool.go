@@ -0,0 +1,183 @@
+// Package workerpool runs asynchronous tasks on a fixed number of goroutines
+// fed by a bounded queue. Work that would otherwise start a goroutine per
+// request or event submits to a pool instead, so a burst is rejected at the
+// queue rather than exhausting memory or file descriptors.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	// ErrQueueFull is returned by Submit when the queue has no room.
+	ErrQueueFull = errors.New("workerpool: queue full")
+	// ErrClosed is returned by Submit after Shutdown.
+	ErrClosed = errors.New("workerpool: closed")
+)
+
+// Task results, reported in updater_worker_tasks_total.
+const (
+	resultCompleted = "completed"
+	resultPanicked  = "panicked"
+	resultRejected  = "rejected"
+)
+
+// Task is a unit of work. Its context is canceled when Shutdown gives up
+// waiting for the queue to drain.
+type Task func(ctx context.Context)
+
+// Pool runs submitted tasks on size workers, holding up to queueDepth tasks
+// that are waiting for a worker. A panicking task is logged and counted; it
+// does not stop its worker.
+type Pool struct {
+	name  string
+	tasks chan Task
+	ctx   context.Context
+	stop  context.CancelFunc
+	wg    sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+
+	attrs    metric.MeasurementOption
+	results  metric.Int64Counter
+	duration metric.Float64Histogram
+	queued   metric.Int64ObservableGauge
+	reg      metric.Registration
+}
+
+// New starts a pool of size workers with a queue of queueDepth tasks. name
+// identifies the pool in logs and in the pool attribute of its metrics, which
+// are recorded through the global OpenTelemetry meter provider.
+func New(name string, size, queueDepth int) (*Pool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("workerpool %s: size must be at least 1", name)
+	}
+	if queueDepth < 0 {
+		return nil, fmt.Errorf("workerpool %s: queue depth cannot be negative", name)
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	p := &Pool{
+		name:  name,
+		tasks: make(chan Task, queueDepth),
+		ctx:   ctx,
+		stop:  stop,
+		attrs: metric.WithAttributes(attribute.String("pool", name)),
+	}
+	if err := p.initMetrics(); err != nil {
+		stop()
+		return nil, fmt.Errorf("workerpool %s: %w", name, err)
+	}
+
+	p.wg.Add(size)
+	for range size {
+		go p.work()
+	}
+	return p, nil
+}
+
+func (p *Pool) initMetrics() error {
+	meter := otel.Meter("updater/workerpool")
+	var err error
+	if p.results, err = meter.Int64Counter("updater_worker_tasks_total",
+		metric.WithDescription("Tasks submitted to a worker pool, by result"),
+		metric.WithUnit("{task}"),
+	); err != nil {
+		return err
+	}
+	if p.duration, err = meter.Float64Histogram("updater_worker_task_duration_seconds",
+		metric.WithDescription("Time a worker pool task took to run, excluding its wait in the queue"),
+		metric.WithUnit("s"),
+	); err != nil {
+		return err
+	}
+	if p.queued, err = meter.Int64ObservableGauge("updater_worker_queue_depth",
+		metric.WithDescription("Tasks waiting in a worker pool's queue"),
+		metric.WithUnit("{task}"),
+	); err != nil {
+		return err
+	}
+	p.reg, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(p.queued, int64(len(p.tasks)), p.attrs)
+		return nil
+	}, p.queued)
+	return err
+}
+
+// Submit queues task without blocking. It returns ErrQueueFull when the queue
+// is full and ErrClosed after Shutdown; the task is not run in either case.
+func (p *Pool) Submit(task Task) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		p.results.Add(context.Background(), 1, p.attrs, metric.WithAttributes(attribute.String("result", resultRejected)))
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting tasks and waits for the queued and running ones to
+// finish. When ctx expires first it cancels the context passed to tasks,
+// drops the tasks still queued and returns ctx.Err().
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	defer p.reg.Unregister()
+	select {
+	case <-done:
+		p.stop()
+		return nil
+	case <-ctx.Done():
+		p.stop()
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		if p.ctx.Err() != nil {
+			continue // shutting down without waiting; drop the rest
+		}
+		p.run(task)
+	}
+}
+
+func (p *Pool) run(task Task) {
+	start := time.Now()
+	result := resultCompleted
+	defer func() {
+		if r := recover(); r != nil {
+			result = resultPanicked
+			slog.Error("Worker pool task panicked", "pool", p.name, "panic", r)
+		}
+		p.duration.Record(context.Background(), time.Since(start).Seconds(), p.attrs)
+		p.results.Add(context.Background(), 1, p.attrs, metric.WithAttributes(attribute.String("result", result)))
+	}()
+	task(p.ctx)
+}
@@ -0,0 +1,78 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_RunsTasks(t *testing.T) {
+	p, err := New("test", 4, 16)
+	require.NoError(t, err)
+
+	var ran atomic.Int32
+	for range 10 {
+		require.NoError(t, p.Submit(func(context.Context) { ran.Add(1) }))
+	}
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, int32(10), ran.Load(), "shutdown drains the queue")
+	assert.ErrorIs(t, p.Submit(func(context.Context) {}), ErrClosed)
+}
+
+func TestPool_RejectsWhenQueueFull(t *testing.T) {
+	p, err := New("test", 1, 1)
+	require.NoError(t, err)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	require.NoError(t, p.Submit(func(context.Context) { close(started); <-release }))
+	<-started
+	require.NoError(t, p.Submit(func(context.Context) {}), "one task fits in the queue")
+	assert.ErrorIs(t, p.Submit(func(context.Context) {}), ErrQueueFull)
+
+	close(release)
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestPool_SurvivesPanics(t *testing.T) {
+	p, err := New("test", 1, 4)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	require.NoError(t, p.Submit(func(context.Context) { panic("boom") }))
+	require.NoError(t, p.Submit(func(context.Context) { wg.Done() }))
+	wg.Wait()
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestPool_ShutdownDeadline(t *testing.T) {
+	p, err := New("test", 1, 4)
+	require.NoError(t, err)
+
+	canceled := make(chan struct{})
+	require.NoError(t, p.Submit(func(ctx context.Context) {
+		<-ctx.Done()
+		close(canceled)
+	}))
+	var queuedRan atomic.Bool
+	require.NoError(t, p.Submit(func(context.Context) { queuedRan.Store(true) }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.Shutdown(ctx), context.DeadlineExceeded)
+	<-canceled
+	assert.Eventually(t, func() bool { return len(p.tasks) == 0 }, time.Second, time.Millisecond)
+	assert.False(t, queuedRan.Load(), "queued tasks are dropped once the deadline passes")
+}
+
+func TestNew_Validates(t *testing.T) {
+	_, err := New("test", 0, 1)
+	assert.Error(t, err)
+	_, err = New("test", 1, -1)
+	assert.Error(t, err)
+}
@@ -1,6 +1,6 @@
 ##@ Go Development
 
-.PHONY: build run test integration-test cover fmt fmt-check vet clean tidy check security secrets
+.PHONY: build run test integration-test cover fmt fmt-check vet clean tidy check security secrets clients-generate
 
 VERSION    := $(shell git describe --tags --always --dirty 2>/dev/null || echo "unknown")
 GIT_COMMIT := $(shell git rev-parse --short HEAD 2>/dev/null || echo "unknown")
@@ -9,9 +9,10 @@ LDFLAGS    := -X 'updater/internal/version.Version=$(VERSION)' \
               -X 'updater/internal/version.GitCommit=$(GIT_COMMIT)' \
               -X 'updater/internal/version.BuildDate=$(BUILD_DATE)'
 
-build: ## Build the application to bin/updater and bin/migrate
+build: ## Build the application to bin/updater, bin/migrate and bin/canary
 	$(GO_DOCKER) go build -ldflags "$(LDFLAGS)" -o $(BIN_DIR)/$(APP_NAME) ./cmd/$(APP_NAME)
 	$(GO_DOCKER) go build -ldflags "-w -s" -o $(BIN_DIR)/migrate ./cmd/migrate
+	$(GO_DOCKER) go build -ldflags "-w -s" -o $(BIN_DIR)/canary ./cmd/canary
 
 run: ## Run the application
 	$(GO_DOCKER) go run ./cmd/$(APP_NAME)
@@ -42,6 +43,9 @@ tidy: ## Tidy dependencies
 
 check: fmt-check vet test ## Run format check, vet, and test
 
+clients-generate: ## Generate TypeScript, Python and Rust client models under clients/
+	$(GO_DOCKER) go run ./cmd/clientgen
+
 security: ## Run gosec security scanner
 	docker run --rm \
 		-v "$(CURDIR):/app" \
@@ -0,0 +1,159 @@
+package selfupdate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Files kept next to the executable while an update is applied and
+// confirmed. The backup is the binary the update replaced, and the pending
+// marker counts the starts of the new binary that have not confirmed it.
+const (
+	newSuffix     = ".new"
+	backupSuffix  = ".old"
+	failedSuffix  = ".failed"
+	pendingSuffix = ".selfupdate-pending"
+)
+
+// Apply replaces the executable with data, which must be a verified binary,
+// and keeps the replaced binary as a backup for rollback. The new binary is
+// written next to the executable and renamed over it, so the executable is
+// never left half written. The running process keeps running the old binary
+// until it restarts.
+func (u *Updater) Apply(data []byte) error {
+	exe := u.executable
+	info, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("selfupdate: %w", err)
+	}
+
+	newPath := exe + newSuffix
+	if err := writeFileSync(newPath, data, info.Mode().Perm()); err != nil {
+		os.Remove(newPath)
+		return fmt.Errorf("selfupdate: writing the new binary: %w", err)
+	}
+
+	// Windows cannot replace a running executable, but can rename it
+	backup := exe + backupSuffix
+	if err := os.Remove(backup); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		os.Remove(newPath)
+		return fmt.Errorf("selfupdate: removing the previous backup: %w", err)
+	}
+	if err := os.Rename(exe, backup); err != nil {
+		os.Remove(newPath)
+		return fmt.Errorf("selfupdate: backing up the binary: %w", err)
+	}
+	if err := os.Rename(newPath, exe); err != nil {
+		// Put the old binary back so the application still starts
+		if restoreErr := os.Rename(backup, exe); restoreErr != nil {
+			return fmt.Errorf("selfupdate: installing the new binary: %w; restoring the backup: %v", err, restoreErr)
+		}
+		os.Remove(newPath)
+		return fmt.Errorf("selfupdate: installing the new binary: %w", err)
+	}
+
+	if err := writePending(exe, 0); err != nil {
+		return fmt.Errorf("selfupdate: marking the update pending: %w", err)
+	}
+	return nil
+}
+
+// Startup is called first thing when the application starts. After an
+// update it counts the start of the new binary, and when the binary has used
+// MaxStartAttempts starts without calling Confirm, it restores the backup
+// and returns ErrRolledBack; the process should then exit, or re-execute
+// itself, to run the restored binary. It does nothing when no update is
+// pending.
+func (u *Updater) Startup() error {
+	exe := u.executable
+	attempts, err := readPending(exe)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("selfupdate: reading the pending update: %w", err)
+	}
+	if attempts < u.cfg.MaxStartAttempts {
+		if err := writePending(exe, attempts+1); err != nil {
+			return fmt.Errorf("selfupdate: marking the update pending: %w", err)
+		}
+		return nil
+	}
+	if err := u.Rollback(); err != nil {
+		return err
+	}
+	return ErrRolledBack
+}
+
+// Confirm marks the running binary as good once the application is healthy,
+// removing the backup so it is no longer rolled back. It does nothing when
+// no update is pending.
+func (u *Updater) Confirm() error {
+	exe := u.executable
+	if err := os.Remove(exe + pendingSuffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("selfupdate: confirming the update: %w", err)
+	}
+	// Best effort: Windows keeps these locked while they may still run
+	os.Remove(exe + backupSuffix)
+	os.Remove(exe + failedSuffix)
+	return nil
+}
+
+// Rollback restores the binary the last update replaced. The replaced binary
+// is kept as a .failed file until the next Confirm, since Windows cannot
+// remove it while it runs.
+func (u *Updater) Rollback() error {
+	exe := u.executable
+	backup := exe + backupSuffix
+	if _, err := os.Stat(backup); err != nil {
+		return fmt.Errorf("selfupdate: no backup to roll back to: %w", err)
+	}
+	failed := exe + failedSuffix
+	if err := os.Remove(failed); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("selfupdate: removing the previous failed binary: %w", err)
+	}
+	if err := os.Rename(exe, failed); err != nil {
+		return fmt.Errorf("selfupdate: moving the failed binary aside: %w", err)
+	}
+	if err := os.Rename(backup, exe); err != nil {
+		return fmt.Errorf("selfupdate: restoring the backup: %w", err)
+	}
+	if err := os.Remove(exe + pendingSuffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("selfupdate: clearing the pending update: %w", err)
+	}
+	return nil
+}
+
+// writeFileSync writes data to path and flushes it to disk before returning,
+// so a crash cannot leave a renamed but empty binary behind.
+func writeFileSync(path string, data []byte, perm fs.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readPending(exe string) (int, error) {
+	data, err := os.ReadFile(exe + pendingSuffix)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func writePending(exe string, attempts int) error {
+	return writeFileSync(exe+pendingSuffix, []byte(strconv.Itoa(attempts)+"\n"), 0o600)
+}
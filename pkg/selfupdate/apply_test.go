@@ -0,0 +1,49 @@
+package selfupdate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdater_StartupConfirm(t *testing.T) {
+	u := newTestUpdater(t, "https://updates.example.com", "1.0.0")
+	require.NoError(t, u.Startup(), "nothing is pending before an update")
+
+	require.NoError(t, u.Apply(newBinary))
+	require.NoError(t, u.Startup(), "the new binary gets its start")
+	require.NoError(t, u.Confirm())
+
+	assert.NoFileExists(t, u.Executable()+pendingSuffix)
+	assert.NoFileExists(t, u.Executable()+backupSuffix)
+	require.NoError(t, u.Startup(), "a confirmed binary is not rolled back")
+	data, err := os.ReadFile(u.Executable())
+	require.NoError(t, err)
+	assert.Equal(t, newBinary, data)
+}
+
+func TestUpdater_StartupRollback(t *testing.T) {
+	u := newTestUpdater(t, "https://updates.example.com", "1.0.0")
+	require.NoError(t, u.Apply(newBinary))
+
+	require.NoError(t, u.Startup())
+	// The new binary failed before it confirmed, and is started again
+	assert.ErrorIs(t, u.Startup(), ErrRolledBack)
+
+	data, err := os.ReadFile(u.Executable())
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho 1.0.0\n", string(data), "the previous binary is restored")
+	assert.FileExists(t, u.Executable()+failedSuffix)
+	assert.NoFileExists(t, u.Executable()+pendingSuffix)
+	require.NoError(t, u.Startup(), "the restored binary starts normally")
+
+	require.NoError(t, u.Confirm())
+	assert.NoFileExists(t, u.Executable()+failedSuffix)
+}
+
+func TestUpdater_Rollback_NoBackup(t *testing.T) {
+	u := newTestUpdater(t, "https://updates.example.com", "1.0.0")
+	assert.ErrorContains(t, u.Rollback(), "no backup")
+}
@@ -0,0 +1,307 @@
+// Package selfupdate lets a Go application embed an update agent for its own
+// executable. An Updater checks the update service for a newer release,
+// downloads the artifact it is offered, verifies it with the release's
+// checksums and swaps it in place of the running binary, keeping the old
+// binary as a backup. The release's artifact must be the executable itself,
+// not an installer or archive.
+//
+// The new binary takes effect when the application restarts. It calls
+// Startup first thing on start and Confirm once it is healthy; a binary that
+// keeps failing before it confirms is rolled back to the backup:
+//
+//	u, err := selfupdate.New(selfupdate.Config{
+//		ServerURL:      "https://updates.example.com",
+//		ApplicationID:  "my-app",
+//		CurrentVersion: version,
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := u.Startup(); errors.Is(err, selfupdate.ErrRolledBack) {
+//		os.Exit(1) // Let the supervisor start the restored binary
+//	}
+//	// ... start serving, then:
+//	u.Confirm()
+//	go func() {
+//		if _, err := u.Run(ctx); err == nil {
+//			restart()
+//		}
+//	}()
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+	"updater/internal/models"
+)
+
+const (
+	// DefaultInterval is the time between checks made by Run when the server
+	// does not schedule them.
+	DefaultInterval = time.Hour
+	// DefaultMaxDownloadSize bounds artifacts whose check response gives no
+	// file size.
+	DefaultMaxDownloadSize = 512 << 20
+	// DefaultMaxStartAttempts is the number of starts a new binary gets to
+	// call Confirm before Startup rolls it back.
+	DefaultMaxStartAttempts = 1
+)
+
+// maxErrorBodySize bounds the error response that is read for its message.
+const maxErrorBodySize = 64 << 10
+
+var (
+	// ErrChecksumMismatch is returned when a downloaded artifact does not
+	// match the release's checksums.
+	ErrChecksumMismatch = errors.New("selfupdate: artifact does not match the release checksums")
+	// ErrRolledBack is returned by Startup when it restored the previous
+	// binary. The running process is the failed binary and should exit.
+	ErrRolledBack = errors.New("selfupdate: update failed to start and was rolled back")
+)
+
+// Config configures an Updater. ServerURL, ApplicationID and CurrentVersion
+// are required.
+type Config struct {
+	ServerURL        string        // Base URL of the update service, such as https://updates.example.com
+	ApplicationID    string        // Application ID or slug
+	CurrentVersion   string        // Version of the running binary
+	Platform         string        // Defaults to runtime.GOOS
+	Architecture     string        // Defaults to runtime.GOARCH
+	Channel          string        // Channel to follow; the server's default when empty
+	ClientID         string        // Stable client ID, so the server can schedule checks
+	LicenseToken     string        // Sent as X-License-Token for releases that require an entitlement
+	ClientToken      string        // Sent as X-Client-Token when the server requires client tokens
+	Executable       string        // Binary to replace; defaults to the running executable
+	HTTPClient       *http.Client  // Defaults to a client with a 10 minute timeout
+	Interval         time.Duration // Time between checks made by Run; defaults to DefaultInterval
+	MaxDownloadSize  int64         // Defaults to DefaultMaxDownloadSize
+	MaxStartAttempts int           // Defaults to DefaultMaxStartAttempts
+}
+
+// Updater checks for, downloads and applies updates to one executable.
+type Updater struct {
+	cfg        Config
+	serverURL  string
+	executable string
+}
+
+// New creates an Updater, filling in defaults for the optional settings.
+func New(cfg Config) (*Updater, error) {
+	if cfg.ServerURL == "" || cfg.ApplicationID == "" || cfg.CurrentVersion == "" {
+		return nil, errors.New("selfupdate: ServerURL, ApplicationID and CurrentVersion are required")
+	}
+	if u, err := url.Parse(cfg.ServerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("selfupdate: invalid ServerURL %q", cfg.ServerURL)
+	}
+	if cfg.Platform == "" {
+		cfg.Platform = runtime.GOOS
+	}
+	if cfg.Architecture == "" {
+		cfg.Architecture = runtime.GOARCH
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Minute}
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.MaxDownloadSize <= 0 {
+		cfg.MaxDownloadSize = DefaultMaxDownloadSize
+	}
+	if cfg.MaxStartAttempts <= 0 {
+		cfg.MaxStartAttempts = DefaultMaxStartAttempts
+	}
+
+	executable := cfg.Executable
+	if executable == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("selfupdate: locating the executable: %w", err)
+		}
+		executable = exe
+	}
+	// Replace the binary a symlink points at, not the link
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	return &Updater{
+		cfg:        cfg,
+		serverURL:  strings.TrimRight(cfg.ServerURL, "/"),
+		executable: executable,
+	}, nil
+}
+
+// Executable returns the path of the binary the Updater replaces.
+func (u *Updater) Executable() string {
+	return u.executable
+}
+
+// Check asks the server whether a newer release is available.
+func (u *Updater) Check(ctx context.Context) (*models.UpdateCheckResponse, error) {
+	query := url.Values{
+		"current_version": {u.cfg.CurrentVersion},
+		"platform":        {u.cfg.Platform},
+		"architecture":    {u.cfg.Architecture},
+	}
+	if u.cfg.Channel != "" {
+		query.Set("channel", u.cfg.Channel)
+	}
+	if u.cfg.ClientID != "" {
+		query.Set("client_id", u.cfg.ClientID)
+	}
+	checkURL := u.serverURL + "/api/v1/updates/" + url.PathEscape(u.cfg.ApplicationID) + "/check?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if u.cfg.LicenseToken != "" {
+		req.Header.Set("X-License-Token", u.cfg.LicenseToken)
+	}
+	if u.cfg.ClientToken != "" {
+		req.Header.Set("X-Client-Token", u.cfg.ClientToken)
+	}
+	body, err := u.get(req)
+	if err != nil {
+		return nil, fmt.Errorf("selfupdate: update check failed: %w", err)
+	}
+	defer body.Close()
+
+	var resp models.UpdateCheckResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("selfupdate: invalid check response: %w", err)
+	}
+	return &resp, nil
+}
+
+// Download fetches the artifact a check offered and verifies its size and
+// checksums with Release.VerifyChecksum.
+func (u *Updater) Download(ctx context.Context, update *models.UpdateCheckResponse) ([]byte, error) {
+	if !update.UpdateAvailable || update.DownloadURL == "" {
+		return nil, errors.New("selfupdate: the check offered no download")
+	}
+	downloadURL := update.DownloadURL
+	if strings.HasPrefix(downloadURL, "/") {
+		downloadURL = u.serverURL + downloadURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := u.get(req)
+	if err != nil {
+		return nil, fmt.Errorf("selfupdate: download failed: %w", err)
+	}
+	defer body.Close()
+
+	limit := u.cfg.MaxDownloadSize
+	if update.FileSize > 0 {
+		limit = update.FileSize
+	}
+	// Read one byte more than allowed to tell a full read from an oversized one
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("selfupdate: download failed: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("selfupdate: artifact exceeds %d bytes", limit)
+	}
+	if update.FileSize > 0 && int64(len(data)) != update.FileSize {
+		return nil, fmt.Errorf("selfupdate: downloaded %d bytes, check response says %d", len(data), update.FileSize)
+	}
+
+	release := &models.Release{
+		Checksum:     update.Checksum,
+		ChecksumType: update.ChecksumType,
+		Checksums:    update.Checksums,
+	}
+	if !release.VerifyChecksum(data) {
+		return nil, ErrChecksumMismatch
+	}
+	return data, nil
+}
+
+// Update checks for a newer release and, when one is offered, downloads,
+// verifies and applies it. It returns the check response, and applied
+// reports whether the binary was replaced; the new version runs once the
+// application restarts.
+func (u *Updater) Update(ctx context.Context) (resp *models.UpdateCheckResponse, applied bool, err error) {
+	resp, err = u.Check(ctx)
+	if err != nil || !resp.UpdateAvailable {
+		return resp, false, err
+	}
+	data, err := u.Download(ctx, resp)
+	if err != nil {
+		return resp, false, err
+	}
+	if err := u.Apply(data); err != nil {
+		return resp, false, err
+	}
+	return resp, true, nil
+}
+
+// Run checks for updates until one is applied, and returns its check
+// response so the caller can restart into it. Checks are made every
+// Interval, or when the server schedules them with next_check_seconds.
+// Failed checks and downloads are logged and retried at the next check. Run
+// returns the context's error when it is cancelled.
+func (u *Updater) Run(ctx context.Context) (*models.UpdateCheckResponse, error) {
+	for {
+		wait := u.cfg.Interval
+		resp, applied, err := u.Update(ctx)
+		switch {
+		case applied:
+			return resp, nil
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			slog.WarnContext(ctx, "Self-update failed; retrying at the next check",
+				"application_id", u.cfg.ApplicationID,
+				"error", err)
+		case resp.NextCheckSeconds > 0:
+			wait = time.Duration(resp.NextCheckSeconds) * time.Second
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// get sends req and returns the body of a 200 response, or an error with
+// the server's message.
+func (u *Updater) get(req *http.Request) (io.ReadCloser, error) {
+	req.Header.Set("User-Agent", "updater-selfupdate")
+	resp, err := u.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+
+	var errResp models.ErrorResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxErrorBodySize)).Decode(&errResp); err == nil && errResp.Message != "" {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, errResp.Message)
+	}
+	return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+}
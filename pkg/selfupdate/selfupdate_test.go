@@ -0,0 +1,150 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"updater/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var newBinary = []byte("#!/bin/sh\necho 1.1.0\n")
+
+// newTestServer serves an update check offering 1.1.0 to clients older than
+// it, with the artifact at /download.
+func newTestServer(t *testing.T, checksum string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/updates/my-app/check", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("platform") != "linux" || q.Get("architecture") != "amd64" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(models.NewErrorResponse("unsupported platform", models.ErrorCodeValidation))
+			return
+		}
+		resp := models.UpdateCheckResponse{CurrentVersion: q.Get("current_version")}
+		if q.Get("current_version") == "1.0.0" {
+			resp.UpdateAvailable = true
+			resp.LatestVersion = "1.1.0"
+			resp.DownloadURL = "/download"
+			resp.Checksum = checksum
+			resp.ChecksumType = models.ChecksumTypeSHA256
+			resp.FileSize = int64(len(newBinary))
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("GET /download", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(newBinary)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newTestUpdater returns an Updater for a fake executable in a temporary
+// directory.
+func newTestUpdater(t *testing.T, serverURL, version string) *Updater {
+	t.Helper()
+	exe := filepath.Join(t.TempDir(), "my-app")
+	require.NoError(t, os.WriteFile(exe, []byte("#!/bin/sh\necho 1.0.0\n"), 0o755))
+	u, err := New(Config{
+		ServerURL:      serverURL,
+		ApplicationID:  "my-app",
+		CurrentVersion: version,
+		Platform:       "linux",
+		Architecture:   "amd64",
+		Executable:     exe,
+		Interval:       10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	return u
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{ServerURL: "https://updates.example.com", ApplicationID: "my-app"})
+	assert.ErrorContains(t, err, "required")
+	_, err = New(Config{ServerURL: "updates.example.com", ApplicationID: "my-app", CurrentVersion: "1.0.0"})
+	assert.ErrorContains(t, err, "invalid ServerURL")
+
+	u, err := New(Config{ServerURL: "https://updates.example.com/", ApplicationID: "my-app", CurrentVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://updates.example.com", u.serverURL)
+	assert.NotEmpty(t, u.Executable(), "defaults to the running executable")
+	assert.Equal(t, DefaultMaxStartAttempts, u.cfg.MaxStartAttempts)
+}
+
+func TestUpdater_Update(t *testing.T) {
+	server := newTestServer(t, sha256Hex(newBinary))
+	u := newTestUpdater(t, server.URL, "1.0.0")
+
+	resp, applied, err := u.Update(context.Background())
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, "1.1.0", resp.LatestVersion)
+
+	data, err := os.ReadFile(u.Executable())
+	require.NoError(t, err)
+	assert.Equal(t, newBinary, data)
+	info, err := os.Stat(u.Executable())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm(), "the new binary keeps the old one's mode")
+	assert.FileExists(t, u.Executable()+backupSuffix)
+	assert.FileExists(t, u.Executable()+pendingSuffix)
+
+	current := newTestUpdater(t, server.URL, "1.1.0")
+	resp, applied, err = current.Update(context.Background())
+	require.NoError(t, err)
+	assert.False(t, applied)
+	assert.False(t, resp.UpdateAvailable)
+}
+
+func TestUpdater_Update_ChecksumMismatch(t *testing.T) {
+	server := newTestServer(t, sha256Hex([]byte("another build")))
+	u := newTestUpdater(t, server.URL, "1.0.0")
+
+	_, applied, err := u.Update(context.Background())
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.False(t, applied)
+	data, err := os.ReadFile(u.Executable())
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho 1.0.0\n", string(data), "the binary is untouched")
+	assert.NoFileExists(t, u.Executable()+backupSuffix)
+}
+
+func TestUpdater_Check_Error(t *testing.T) {
+	server := newTestServer(t, "")
+	u := newTestUpdater(t, server.URL, "1.0.0")
+	u.cfg.Platform = "plan9"
+
+	_, err := u.Check(context.Background())
+	assert.ErrorContains(t, err, "HTTP 422: unsupported platform")
+}
+
+func TestUpdater_Run(t *testing.T) {
+	server := newTestServer(t, sha256Hex(newBinary))
+
+	u := newTestUpdater(t, server.URL, "1.0.0")
+	resp, err := u.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", resp.LatestVersion)
+
+	current := newTestUpdater(t, server.URL, "1.1.0")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = current.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Run keeps checking until an update is applied")
+}